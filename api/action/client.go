@@ -5,9 +5,12 @@ package action
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
 )
 
 // Client provides access to the action facade.
@@ -90,6 +93,146 @@ func (c *Client) Cancel(arg params.Entities) (params.ActionResults, error) {
 	return results, err
 }
 
+// WatchActionProgress returns a watcher that reports the progress
+// messages logged by the action with the given id. Each change holds
+// JSON-encoded params.ActionMessages.
+func (c *Client) WatchActionProgress(actionId string) (watcher.StringsWatcher, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("watching action progress")
+	}
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: names.NewActionTag(actionId).String()},
+	}}
+	var results params.StringsWatchResults
+	if err := c.facade.FacadeCall("WatchActionsProgress", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// AddActionRollouts runs actions across the units of applications, a
+// batch at a time, and returns the rollouts that track them.
+func (c *Client) AddActionRollouts(arg params.ActionRolloutsArgs) (params.ActionRolloutResults, error) {
+	results := params.ActionRolloutResults{}
+	if c.facade.BestAPIVersion() < 4 {
+		return results, errors.NotSupportedf("action rollouts")
+	}
+	err := c.facade.FacadeCall("AddActionRollouts", arg, &results)
+	return results, err
+}
+
+// ActionRollouts returns the action rollouts with the given ids, or all
+// of the model's rollouts if no ids are given.
+func (c *Client) ActionRollouts(arg params.ActionRolloutIds) (params.ActionRolloutResults, error) {
+	results := params.ActionRolloutResults{}
+	if c.facade.BestAPIVersion() < 4 {
+		return results, errors.NotSupportedf("action rollouts")
+	}
+	err := c.facade.FacadeCall("ActionRollouts", arg, &results)
+	return results, err
+}
+
+// CancelActionRollouts stops the action rollouts with the given ids.
+func (c *Client) CancelActionRollouts(arg params.ActionRolloutIds) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if c.facade.BestAPIVersion() < 4 {
+		return results, errors.NotSupportedf("action rollouts")
+	}
+	err := c.facade.FacadeCall("CancelActionRollouts", arg, &results)
+	return results, err
+}
+
+// ListOperations returns the operations that match the query, newest
+// first.
+func (c *Client) ListOperations(arg params.OperationQueryArgs) (params.OperationResults, error) {
+	results := params.OperationResults{}
+	if c.facade.BestAPIVersion() < 5 {
+		return results, errors.NotSupportedf("operations")
+	}
+	err := c.facade.FacadeCall("ListOperations", arg, &results)
+	return results, err
+}
+
+// Operations returns the operations with the given ids.
+func (c *Client) Operations(arg params.OperationIds) (params.OperationResults, error) {
+	results := params.OperationResults{}
+	if c.facade.BestAPIVersion() < 5 {
+		return results, errors.NotSupportedf("operations")
+	}
+	err := c.facade.FacadeCall("Operations", arg, &results)
+	return results, err
+}
+
+// AddMachineActionScripts defines actions that can be run on any
+// machine in the model.
+func (c *Client) AddMachineActionScripts(arg params.MachineActionScripts) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if c.facade.BestAPIVersion() < 6 {
+		return results, errors.NotSupportedf("machine actions")
+	}
+	err := c.facade.FacadeCall("AddMachineActionScripts", arg, &results)
+	return results, err
+}
+
+// RemoveMachineActionScripts removes the named machine actions.
+func (c *Client) RemoveMachineActionScripts(arg params.MachineActionScriptNames) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if c.facade.BestAPIVersion() < 6 {
+		return results, errors.NotSupportedf("machine actions")
+	}
+	err := c.facade.FacadeCall("RemoveMachineActionScripts", arg, &results)
+	return results, err
+}
+
+// MachineActionScripts returns the model's machine actions.
+func (c *Client) MachineActionScripts() (params.MachineActionScripts, error) {
+	results := params.MachineActionScripts{}
+	if c.facade.BestAPIVersion() < 6 {
+		return results, errors.NotSupportedf("machine actions")
+	}
+	err := c.facade.FacadeCall("MachineActionScripts", nil, &results)
+	return results, err
+}
+
+// AddActionSchedules adds schedules that run actions on units at
+// recurring times.
+func (c *Client) AddActionSchedules(arg params.ActionSchedulesArgs) (params.ActionScheduleResults, error) {
+	results := params.ActionScheduleResults{}
+	if c.facade.BestAPIVersion() < 7 {
+		return results, errors.NotSupportedf("action schedules")
+	}
+	err := c.facade.FacadeCall("AddActionSchedules", arg, &results)
+	return results, err
+}
+
+// ActionSchedules returns all of the model's action schedules.
+func (c *Client) ActionSchedules() (params.ActionScheduleResults, error) {
+	results := params.ActionScheduleResults{}
+	if c.facade.BestAPIVersion() < 7 {
+		return results, errors.NotSupportedf("action schedules")
+	}
+	err := c.facade.FacadeCall("ActionSchedules", nil, &results)
+	return results, err
+}
+
+// RemoveActionSchedules removes the action schedules with the given ids.
+func (c *Client) RemoveActionSchedules(arg params.ActionScheduleIds) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if c.facade.BestAPIVersion() < 7 {
+		return results, errors.NotSupportedf("action schedules")
+	}
+	err := c.facade.FacadeCall("RemoveActionSchedules", arg, &results)
+	return results, err
+}
+
 // applicationsCharmActions is a batched query for the charm.Actions for a slice
 // of services by Entity.
 func (c *Client) applicationsCharmActions(arg params.Entities) (params.ApplicationsCharmActionsResults, error) {
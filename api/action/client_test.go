@@ -6,6 +6,7 @@ package action_test
 import (
 	"errors"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -109,6 +110,72 @@ func (s *actionSuite) TestApplicationCharmActions(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestWatchActionProgressNotSupported(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected facade call %q", req)
+			return nil
+		},
+	)
+	defer cleanup()
+	_, err := s.client.WatchActionProgress("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
+
+func (s *actionSuite) TestAddActionRolloutsNotSupported(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected facade call %q", req)
+			return nil
+		},
+	)
+	defer cleanup()
+	_, err := s.client.AddActionRollouts(params.ActionRolloutsArgs{})
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
+
+func (s *actionSuite) TestListOperationsNotSupported(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected facade call %q", req)
+			return nil
+		},
+	)
+	defer cleanup()
+	_, err := s.client.ListOperations(params.OperationQueryArgs{})
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
+
+func (s *actionSuite) TestMachineActionScriptsNotSupported(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected facade call %q", req)
+			return nil
+		},
+	)
+	defer cleanup()
+	_, err := s.client.AddMachineActionScripts(params.MachineActionScripts{})
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+	_, err = s.client.MachineActionScripts()
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
+
+func (s *actionSuite) TestActionSchedulesNotSupported(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected facade call %q", req)
+			return nil
+		},
+	)
+	defer cleanup()
+	_, err := s.client.AddActionSchedules(params.ActionSchedulesArgs{})
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+	_, err = s.client.ActionSchedules()
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+	_, err = s.client.RemoveActionSchedules(params.ActionScheduleIds{})
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
+
 // replace sCharmActions" facade call with required results and error
 // if desired
 func patchApplicationCharmActions(c *gc.C, apiCli *action.Client, patchResults []params.ApplicationCharmActionsResult, err string) func() {
@@ -0,0 +1,45 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionrollouts provides access to the ActionRollouts facade,
+// used by the action rollouts worker.
+package actionrollouts
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+const facadeName = "ActionRollouts"
+
+// API provides access to the ActionRollouts API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side ActionRollouts facade.
+func NewAPI(caller base.APICaller) *API {
+	return &API{facade: base.NewFacadeCaller(caller, facadeName)}
+}
+
+// AdvanceActionRollouts calls the server-side AdvanceActionRollouts
+// method.
+func (api *API) AdvanceActionRollouts() error {
+	return api.facade.FacadeCall("AdvanceActionRollouts", nil, nil)
+}
+
+// WatchActionRollouts calls the server-side WatchActionRollouts method.
+func (api *API) WatchActionRollouts() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := api.facade.FacadeCall("WatchActionRollouts", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(api.facade.RawAPICaller(), result), nil
+}
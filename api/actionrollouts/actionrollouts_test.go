@@ -0,0 +1,54 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollouts_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionrollouts"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionRolloutsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ActionRolloutsSuite{})
+
+func (s *ActionRolloutsSuite) TestAdvanceActionRollouts(c *gc.C) {
+	var called bool
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "ActionRollouts")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "AdvanceActionRollouts")
+		c.Check(arg, gc.IsNil)
+		return errors.New("boom")
+	})
+	api := actionrollouts.NewAPI(caller)
+	err := api.AdvanceActionRollouts()
+	c.Check(err, gc.ErrorMatches, "boom")
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *ActionRolloutsSuite) TestWatchActionRolloutsError(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionRollouts")
+		c.Check(request, gc.Equals, "WatchActionRollouts")
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResult{})
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	api := actionrollouts.NewAPI(caller)
+	w, err := api.WatchActionRollouts()
+	c.Check(err, gc.ErrorMatches, "boom")
+	c.Check(w, gc.IsNil)
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionschedules provides access to the ActionSchedules
+// facade, used by the action schedules worker.
+package actionschedules
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+const facadeName = "ActionSchedules"
+
+// API provides access to the ActionSchedules API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side ActionSchedules facade.
+func NewAPI(caller base.APICaller) *API {
+	return &API{facade: base.NewFacadeCaller(caller, facadeName)}
+}
+
+// RunActionSchedules calls the server-side RunActionSchedules method.
+// It returns the time at which the next action schedule falls due, or
+// the zero time if there are no schedules.
+func (api *API) RunActionSchedules() (time.Time, error) {
+	var result params.RunActionSchedulesResult
+	if err := api.facade.FacadeCall("RunActionSchedules", nil, &result); err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	if result.NextRun == nil {
+		return time.Time{}, nil
+	}
+	return *result.NextRun, nil
+}
+
+// WatchActionSchedules calls the server-side WatchActionSchedules
+// method.
+func (api *API) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := api.facade.FacadeCall("WatchActionSchedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(api.facade.RawAPICaller(), result), nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionschedules_test
+
+import (
+	"errors"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionschedules"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionSchedulesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ActionSchedulesSuite{})
+
+func (s *ActionSchedulesSuite) TestRunActionSchedules(c *gc.C) {
+	next := time.Date(2018, time.March, 2, 2, 0, 0, 0, time.UTC)
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionSchedules")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "RunActionSchedules")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.RunActionSchedulesResult{})
+		*(result.(*params.RunActionSchedulesResult)) = params.RunActionSchedulesResult{
+			NextRun: &next,
+		}
+		return nil
+	})
+	api := actionschedules.NewAPI(caller)
+	result, err := api.RunActionSchedules()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, next)
+}
+
+func (s *ActionSchedulesSuite) TestRunActionSchedulesError(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	api := actionschedules.NewAPI(caller)
+	_, err := api.RunActionSchedules()
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ActionSchedulesSuite) TestWatchActionSchedulesError(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionSchedules")
+		c.Check(request, gc.Equals, "WatchActionSchedules")
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResult{})
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	api := actionschedules.NewAPI(caller)
+	w, err := api.WatchActionSchedules()
+	c.Check(err, gc.ErrorMatches, "boom")
+	c.Check(w, gc.IsNil)
+}
@@ -932,6 +932,25 @@ func (s *state) APICall(facade string, version int, id, method string, args, res
 	panic("unreachable")
 }
 
+// APICallStream is part of the base.StreamingAPICaller interface.
+func (s *state) APICallStream(facade string, version int, id, method string, args interface{}, handler rpc.StreamHandler) error {
+	for a := retry.Start(apiCallRetryStrategy, s.clock); a.Next(); {
+		err := s.client.CallStream(rpc.Request{
+			Type:    facade,
+			Version: version,
+			Id:      id,
+			Action:  method,
+		}, args, handler)
+		if params.ErrCode(err) != params.CodeRetry {
+			return errors.Trace(err)
+		}
+		if !a.More() {
+			return errors.Annotatef(err, "too many retries")
+		}
+	}
+	panic("unreachable")
+}
+
 func (s *state) Close() error {
 	err := s.client.Close()
 	select {
@@ -0,0 +1,143 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apitokens
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+// Client allows access to the API tokens API end point.
+type Client struct {
+	base.ClientFacade
+	st     base.APICallCloser
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the API tokens api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "APITokens")
+	return &Client{ClientFacade: frontend, st: st, facade: backend}
+}
+
+// AddAPITokenArgs holds the arguments for AddAPIToken.
+type AddAPITokenArgs struct {
+	// Description is free-form text describing the token's purpose.
+	Description string
+
+	// Models holds the models the token may be used with.
+	Models []names.ModelTag
+
+	// Access is the maximum model access granted by the token.
+	Access permission.Access
+
+	// Facades optionally restricts the facades that may be called
+	// using the token.
+	Facades []string
+
+	// Expires is the time after which the token is no longer valid.
+	Expires time.Time
+}
+
+// AddAPIToken creates a new API token owned by the authenticated user,
+// returning the token's details and the encoded token to hand to the
+// client that will use it. The encoded token cannot be retrieved again.
+func (c *Client) AddAPIToken(args AddAPITokenArgs) (params.APIToken, string, error) {
+	modelTags := make([]string, len(args.Models))
+	for i, tag := range args.Models {
+		modelTags[i] = tag.String()
+	}
+	in := params.AddAPITokens{
+		Tokens: []params.AddAPIToken{{
+			Description: args.Description,
+			ModelTags:   modelTags,
+			Access:      string(args.Access),
+			Facades:     args.Facades,
+			Expires:     args.Expires,
+		}},
+	}
+	var results params.AddAPITokenResults
+	if err := c.facade.FacadeCall("AddAPITokens", in, &results); err != nil {
+		return params.APIToken{}, "", errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.APIToken{}, "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.APIToken{}, "", errors.Trace(result.Error)
+	}
+	if result.Token == nil {
+		return params.APIToken{}, "", errors.New("missing token in result")
+	}
+	encoded, err := EncodeToken(result.Macaroon)
+	if err != nil {
+		return params.APIToken{}, "", errors.Trace(err)
+	}
+	return *result.Token, encoded, nil
+}
+
+// ListAPITokens returns the API tokens owned by the specified user.
+func (c *Client) ListAPITokens(owner names.UserTag) ([]params.APIToken, error) {
+	in := params.Entities{Entities: []params.Entity{{Tag: owner.String()}}}
+	var results params.APITokensResults
+	if err := c.facade.FacadeCall("ListAPITokens", in, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Tokens, nil
+}
+
+// RevokeAPITokens revokes the API tokens with the given ids.
+func (c *Client) RevokeAPITokens(ids ...string) error {
+	in := params.RevokeAPITokens{Ids: ids}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RevokeAPITokens", in, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(ids) {
+		return errors.Errorf("expected %d results, got %d", len(ids), n)
+	}
+	return results.Combine()
+}
+
+// EncodeToken encodes the macaroons proving possession of an API token
+// into a string suitable for storing in client configuration.
+func EncodeToken(ms macaroon.Slice) (string, error) {
+	data, err := json.Marshal(ms)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeToken decodes a string produced by EncodeToken.
+func DecodeToken(token string) (macaroon.Slice, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decode API token")
+	}
+	var ms macaroon.Slice
+	if err := json.Unmarshal(data, &ms); err != nil {
+		return nil, errors.Annotate(err, "cannot decode API token")
+	}
+	if len(ms) == 0 {
+		return nil, errors.NotValidf("empty API token")
+	}
+	return ms, nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apitokens_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/api/apitokens"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/testing"
+)
+
+type APITokensSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&APITokensSuite{})
+
+func (s *APITokensSuite) TestAddAPIToken(c *gc.C) {
+	expires := testing.NonZeroTime().Add(time.Hour)
+	m, err := macaroon.New([]byte("root-key"), "token-0", "juju")
+	c.Assert(err, jc.ErrorIsNil)
+	token := params.APIToken{
+		Id:        "token-0",
+		Owner:     "user-bob",
+		ModelTags: []string{testing.ModelTag.String()},
+		Access:    "read",
+		Expires:   expires,
+	}
+
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "APITokens")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "AddAPITokens")
+			c.Check(a, jc.DeepEquals, params.AddAPITokens{
+				Tokens: []params.AddAPIToken{{
+					Description: "ci",
+					ModelTags:   []string{testing.ModelTag.String()},
+					Access:      "read",
+					Facades:     []string{"Client"},
+					Expires:     expires,
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.AddAPITokenResults{})
+			*(result.(*params.AddAPITokenResults)) = params.AddAPITokenResults{
+				Results: []params.AddAPITokenResult{{
+					Token:    &token,
+					Macaroon: macaroon.Slice{m},
+				}},
+			}
+			return nil
+		})
+
+	client := apitokens.NewClient(apiCaller)
+	result, encoded, err := client.AddAPIToken(apitokens.AddAPITokenArgs{
+		Description: "ci",
+		Models:      []names.ModelTag{testing.ModelTag},
+		Access:      permission.ReadAccess,
+		Facades:     []string{"Client"},
+		Expires:     expires,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, token)
+
+	ms, err := apitokens.DecodeToken(encoded)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ms, gc.HasLen, 1)
+	c.Assert(ms[0].Id(), gc.Equals, "token-0")
+	c.Assert(ms[0].Signature(), jc.DeepEquals, m.Signature())
+}
+
+func (s *APITokensSuite) TestAddAPITokenError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.AddAPITokenResults)) = params.AddAPITokenResults{
+				Results: []params.AddAPITokenResult{{
+					Error: common.ServerError(errors.New("fail")),
+				}},
+			}
+			return nil
+		})
+	client := apitokens.NewClient(apiCaller)
+	_, _, err := client.AddAPIToken(apitokens.AddAPITokenArgs{})
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func (s *APITokensSuite) TestListAPITokens(c *gc.C) {
+	tokens := []params.APIToken{{Id: "token-0", Owner: "user-bob"}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "APITokens")
+			c.Check(request, gc.Equals, "ListAPITokens")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "user-bob"}},
+			})
+			*(result.(*params.APITokensResults)) = params.APITokensResults{
+				Results: []params.APITokensResult{{Tokens: tokens}},
+			}
+			return nil
+		})
+	client := apitokens.NewClient(apiCaller)
+	result, err := client.ListAPITokens(names.NewUserTag("bob"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, tokens)
+}
+
+func (s *APITokensSuite) TestRevokeAPITokens(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "APITokens")
+			c.Check(request, gc.Equals, "RevokeAPITokens")
+			c.Check(a, jc.DeepEquals, params.RevokeAPITokens{
+				Ids: []string{"token-0", "token-1"},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{
+					{},
+					{Error: common.ServerError(errors.New("fail"))},
+				},
+			}
+			return nil
+		})
+	client := apitokens.NewClient(apiCaller)
+	err := client.RevokeAPITokens("token-0", "token-1")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func (s *APITokensSuite) TestDecodeTokenInvalid(c *gc.C) {
+	_, err := apitokens.DecodeToken("!!!")
+	c.Assert(err, gc.ErrorMatches, "cannot decode API token: .*")
+	encoded, err := apitokens.EncodeToken(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = apitokens.DecodeToken(encoded)
+	c.Assert(err, gc.ErrorMatches, "empty API token not valid")
+}
@@ -0,0 +1,41 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package autoscaler provides access to the Autoscaler facade, used by
+// the autoscaler worker.
+package autoscaler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const facadeName = "Autoscaler"
+
+// API provides access to the Autoscaler API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side Autoscaler facade.
+func NewAPI(caller base.APICaller) *API {
+	return &API{facade: base.NewFacadeCaller(caller, facadeName)}
+}
+
+// Autoscale calls the server-side Autoscale method.
+// It returns the time at which the next application waiting for its
+// policy's cooldown may be scaled, or the zero time if there is none.
+func (api *API) Autoscale() (time.Time, error) {
+	var result params.AutoscaleResult
+	if err := api.facade.FacadeCall("Autoscale", nil, &result); err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	if result.NextRun == nil {
+		return time.Time{}, nil
+	}
+	return *result.NextRun, nil
+}
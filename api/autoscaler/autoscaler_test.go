@@ -0,0 +1,61 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscaler_test
+
+import (
+	"errors"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/autoscaler"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AutoscalerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&AutoscalerSuite{})
+
+func (s *AutoscalerSuite) TestAutoscale(c *gc.C) {
+	next := time.Date(2018, time.March, 2, 2, 0, 0, 0, time.UTC)
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Autoscaler")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "Autoscale")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.AutoscaleResult{})
+		*(result.(*params.AutoscaleResult)) = params.AutoscaleResult{
+			NextRun: &next,
+		}
+		return nil
+	})
+	api := autoscaler.NewAPI(caller)
+	result, err := api.Autoscale()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, next)
+}
+
+func (s *AutoscalerSuite) TestAutoscaleNoneWaiting(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return nil
+	})
+	api := autoscaler.NewAPI(caller)
+	result, err := api.Autoscale()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(result.IsZero(), jc.IsTrue)
+}
+
+func (s *AutoscalerSuite) TestAutoscaleError(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	api := autoscaler.NewAPI(caller)
+	_, err := api.Autoscale()
+	c.Check(err, gc.ErrorMatches, "boom")
+}
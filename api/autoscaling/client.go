@@ -0,0 +1,104 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package autoscaling provides access to the Autoscaling facade, used
+// to manage the policies by which applications are scaled in response
+// to their units' metrics.
+package autoscaling
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the autoscaling API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the autoscaling API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Autoscaling")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// SetPolicy sets the policy by which the named application is scaled.
+func (c *Client) SetPolicy(application string, policy params.AutoscalingPolicy) error {
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.SetAutoscalingPolicies{
+		Policies: []params.SetAutoscalingPolicy{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Policy:         policy,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetAutoscalingPolicies", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Policy returns the policy by which the named application is scaled.
+func (c *Client) Policy(application string) (params.AutoscalingPolicy, error) {
+	if !names.IsValidApplication(application) {
+		return params.AutoscalingPolicy{}, errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.AutoscalingPolicyResults
+	if err := c.facade.FacadeCall("AutoscalingPolicies", args, &results); err != nil {
+		return params.AutoscalingPolicy{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.AutoscalingPolicy{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.AutoscalingPolicy{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// RemovePolicy removes the policy by which the named application is
+// scaled.
+func (c *Client) RemovePolicy(application string) error {
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveAutoscalingPolicies", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Decisions returns the decisions made by the named application's
+// autoscaling policy, most recent first.
+func (c *Client) Decisions(application string) ([]params.AutoscalingDecision, error) {
+	if !names.IsValidApplication(application) {
+		return nil, errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.AutoscalingDecisionsResults
+	if err := c.facade.FacadeCall("AutoscalingDecisions", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Decisions, nil
+}
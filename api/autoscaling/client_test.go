@@ -0,0 +1,143 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscaling_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/autoscaling"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type AutoscalingSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&AutoscalingSuite{})
+
+var policy = params.AutoscalingPolicy{
+	MetricKey: "load",
+	Target:    0.7,
+	MinUnits:  1,
+	MaxUnits:  5,
+	Cooldown:  5 * time.Minute,
+}
+
+func (s *AutoscalingSuite) TestSetPolicy(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "Autoscaling")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetAutoscalingPolicies")
+		c.Check(arg, jc.DeepEquals, params.SetAutoscalingPolicies{
+			Policies: []params.SetAutoscalingPolicy{{
+				ApplicationTag: "application-mysql",
+				Policy:         policy,
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	client := autoscaling.NewClient(apiCaller)
+	err := client.SetPolicy("mysql", policy)
+	c.Check(err, gc.ErrorMatches, "boom")
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *AutoscalingSuite) TestSetPolicyInvalidApplication(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	client := autoscaling.NewClient(apiCaller)
+	err := client.SetPolicy("mysql/0", policy)
+	c.Check(err, gc.ErrorMatches, `application name "mysql/0" not valid`)
+}
+
+func (s *AutoscalingSuite) TestPolicy(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Autoscaling")
+		c.Check(request, gc.Equals, "AutoscalingPolicies")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.AutoscalingPolicyResults{})
+		*(result.(*params.AutoscalingPolicyResults)) = params.AutoscalingPolicyResults{
+			Results: []params.AutoscalingPolicyResult{{Result: &policy}},
+		}
+		return nil
+	})
+	client := autoscaling.NewClient(apiCaller)
+	result, err := client.Policy("mysql")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, policy)
+}
+
+func (s *AutoscalingSuite) TestPolicyNotFound(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.AutoscalingPolicyResults)) = params.AutoscalingPolicyResults{
+			Results: []params.AutoscalingPolicyResult{{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `autoscaling policy for application "mysql" not found`,
+			}}},
+		}
+		return nil
+	})
+	client := autoscaling.NewClient(apiCaller)
+	_, err := client.Policy("mysql")
+	c.Check(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *AutoscalingSuite) TestRemovePolicy(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "Autoscaling")
+		c.Check(request, gc.Equals, "RemoveAutoscalingPolicies")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	client := autoscaling.NewClient(apiCaller)
+	err := client.RemovePolicy("mysql")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *AutoscalingSuite) TestDecisions(c *gc.C) {
+	decisions := []params.AutoscalingDecision{{
+		Time:      time.Date(2018, time.March, 1, 10, 0, 0, 0, time.UTC),
+		MetricKey: "load",
+		FromUnits: 2,
+		ToUnits:   4,
+	}}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Autoscaling")
+		c.Check(request, gc.Equals, "AutoscalingDecisions")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		*(result.(*params.AutoscalingDecisionsResults)) = params.AutoscalingDecisionsResults{
+			Results: []params.AutoscalingDecisionsResult{{Decisions: decisions}},
+		}
+		return nil
+	})
+	client := autoscaling.NewClient(apiCaller)
+	result, err := client.Decisions("mysql")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, decisions)
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/juju/httprequest"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
+
+	"github.com/juju/juju/rpc"
 )
 
 // APICaller is implemented by the client-facing State object.
@@ -47,6 +49,20 @@ type APICaller interface {
 	ControllerStreamConnector
 }
 
+// StreamingAPICaller is implemented by APICallers that can make
+// streaming requests, to which the server sends any number of partial
+// responses before completing. Callers must only make streaming
+// requests of facade versions known to support them.
+type StreamingAPICaller interface {
+	APICaller
+
+	// APICallStream makes a streaming call to the API server with
+	// the given object type, id, request and parameters. Each partial
+	// response is passed to handler; APICallStream returns when the
+	// request completes.
+	APICallStream(objType string, version int, id, request string, params interface{}, handler rpc.StreamHandler) error
+}
+
 // StreamConnector is implemented by the client-facing State object.
 type StreamConnector interface {
 	// ConnectStream connects to the given HTTP websocket
@@ -48,6 +48,40 @@ func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
 	return &result, nil
 }
 
+// FilteredStatus returns the status of the juju model, limited to the
+// entities matching the patterns and satisfying every filter
+// expression, and with only the given fields populated.
+func (c *Client) FilteredStatus(patterns, filters, fields []string) (*params.FullStatus, error) {
+	if (len(filters) > 0 || len(fields) > 0) && c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("status filters and fields on this version of Juju")
+	}
+	var result params.FullStatus
+	p := params.StatusParams{
+		Patterns: patterns,
+		Filters:  filters,
+		Fields:   fields,
+	}
+	if err := c.facade.FacadeCall("FullStatus", p, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// StatusHistoryTimeline returns the status history of the given units
+// and machines, or of every one in the model if none are given, merged
+// and ordered by time. If the result's Next time is set, the timeline
+// was truncated, and the rest may be fetched by passing it as From.
+func (c *Client) StatusHistoryTimeline(args params.StatusHistoryTimelineArgs) (params.StatusHistoryTimelineResult, error) {
+	var result params.StatusHistoryTimelineResult
+	if c.BestAPIVersion() < 3 {
+		return result, errors.NotSupportedf("status history timeline on this version of Juju")
+	}
+	if err := c.facade.FacadeCall("StatusHistoryTimeline", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
 // StatusHistory retrieves the last <size> results of
 // <kind:combined|agent|workload|machine|machineinstance|container|containerinstance> status
 // for <name> unit
@@ -58,6 +92,7 @@ func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter st
 		Filter: params.StatusHistoryFilter{
 			Size:    filter.Size,
 			Date:    filter.FromDate,
+			ToDate:  filter.ToDate,
 			Delta:   filter.Delta,
 			Exclude: filter.Exclude.Values(),
 		},
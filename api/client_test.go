@@ -325,6 +325,60 @@ func (s *clientSuite) TestClientModelUUID(c *gc.C) {
 	c.Assert(uuid, gc.Equals, model.Tag().Id())
 }
 
+func (s *clientSuite) TestFilteredStatus(c *gc.C) {
+	s.Factory.MakeApplication(c, nil)
+	client := s.APIState.Client()
+
+	status, err := client.FilteredStatus(nil, []string{"status==error"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Applications, gc.HasLen, 0)
+
+	status, err = client.FilteredStatus(nil, nil, []string{"applications.charm"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Applications, gc.HasLen, 1)
+	for _, app := range status.Applications {
+		c.Assert(app.Charm, gc.Not(gc.Equals), "")
+		c.Assert(app.Series, gc.Equals, "")
+	}
+	c.Assert(status.Model.Name, gc.Equals, "")
+
+	_, err = client.FilteredStatus(nil, []string{"colour==red"}, nil)
+	c.Assert(err, gc.ErrorMatches, `status filter field "colour" not valid`)
+}
+
+func (s *clientSuite) TestStatusHistoryTimeline(c *gc.C) {
+	client := s.APIState.Client()
+	from := time.Date(2018, 4, 1, 0, 0, 0, 0, time.UTC)
+	since := from.Add(time.Hour)
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, paramsIn interface{}, response interface{}) error {
+			c.Check(request, gc.Equals, "StatusHistoryTimeline")
+			c.Check(paramsIn, jc.DeepEquals, params.StatusHistoryTimelineArgs{
+				Entities: []string{"unit-mysql-0"},
+				From:     from,
+			})
+			result := response.(*params.StatusHistoryTimelineResult)
+			result.Entries = []params.StatusHistoryEntry{{
+				Entity: "unit-mysql-0",
+				Kind:   "workload",
+				Status: "active",
+				Since:  &since,
+			}}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := client.StatusHistoryTimeline(params.StatusHistoryTimelineArgs{
+		Entities: []string{"unit-mysql-0"},
+		From:     from,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, gc.HasLen, 1)
+	c.Check(result.Entries[0].Status, gc.Equals, "active")
+	c.Check(result.Next, gc.IsNil)
+}
+
 func (s *clientSuite) TestClientModelUsers(c *gc.C) {
 	client := s.APIState.Client()
 	cleanup := api.PatchClientFacadeCall(client,
@@ -11,16 +11,21 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       2,
+	"Action":                       7,
 	"ActionPruner":                 1,
+	"ActionRollouts":               1,
+	"ActionSchedules":              1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
+	"APITokens":                    1,
 	"Application":                  6,
 	"ApplicationOffers":            1,
 	"ApplicationScaler":            1,
+	"Autoscaler":                   1,
+	"Autoscaling":                  1,
 	"Backups":                      1,
 	"Block":                        2,
 	"Bundle":                       1,
@@ -31,7 +36,7 @@ var facadeVersions = map[string]int{
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       1,
+	"Client":                       3,
 	"Cloud":                        2,
 	"Controller":                   4,
 	"CrossController":              1,
@@ -56,8 +61,9 @@ var facadeVersions = map[string]int{
 	"LifeFlag":                     1,
 	"LogForwarding":                1,
 	"Logger":                       1,
-	"MachineActions":               1,
-	"MachineManager":               4,
+	"LostMachines":                 1,
+	"MachineActions":               2,
+	"MachineManager":               5,
 	"MachineUndertaker":            1,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
@@ -70,14 +76,15 @@ var facadeVersions = map[string]int{
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
 	"ModelConfig":                  1,
+	"ModelEvents":                  1,
 	"ModelManager":                 4,
 	"ModelUpgrader":                1,
-	"NotifyWatcher":                1,
+	"NotifyWatcher":                2,
 	"OfferStatusWatcher":           1,
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
 	"Pinger":                       1,
-	"Provisioner":                  5,
+	"Provisioner":                  6,
 	"ProxyUpdater":                 1,
 	"Reboot":                       2,
 	"RelationStatusWatcher":        1,
@@ -93,11 +100,11 @@ var facadeVersions = map[string]int{
 	"StatusHistory":                2,
 	"Storage":                      4,
 	"StorageProvisioner":           4,
-	"StringsWatcher":               1,
+	"StringsWatcher":               2,
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       7,
+	"Uniter":                       8,
 	"Upgrader":                     1,
 	"UserManager":                  2,
 	"VolumeAttachmentsWatcher":     2,
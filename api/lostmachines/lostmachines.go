@@ -0,0 +1,41 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package lostmachines provides access to the LostMachines facade,
+// used by the lost machines worker.
+package lostmachines
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const facadeName = "LostMachines"
+
+// API provides access to the LostMachines API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side LostMachines facade.
+func NewAPI(caller base.APICaller) *API {
+	return &API{facade: base.NewFacadeCaller(caller, facadeName)}
+}
+
+// ReplaceLostMachines calls the server-side ReplaceLostMachines method.
+// It returns the time at which the next lost machine is due to be
+// replaced, or the zero time if there is none.
+func (api *API) ReplaceLostMachines() (time.Time, error) {
+	var result params.ReplaceLostMachinesResult
+	if err := api.facade.FacadeCall("ReplaceLostMachines", nil, &result); err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	if result.NextRun == nil {
+		return time.Time{}, nil
+	}
+	return *result.NextRun, nil
+}
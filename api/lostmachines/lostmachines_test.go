@@ -0,0 +1,61 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lostmachines_test
+
+import (
+	"errors"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/lostmachines"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type LostMachinesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&LostMachinesSuite{})
+
+func (s *LostMachinesSuite) TestReplaceLostMachines(c *gc.C) {
+	next := time.Date(2018, time.March, 2, 2, 0, 0, 0, time.UTC)
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "LostMachines")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ReplaceLostMachines")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.ReplaceLostMachinesResult{})
+		*(result.(*params.ReplaceLostMachinesResult)) = params.ReplaceLostMachinesResult{
+			NextRun: &next,
+		}
+		return nil
+	})
+	api := lostmachines.NewAPI(caller)
+	result, err := api.ReplaceLostMachines()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, next)
+}
+
+func (s *LostMachinesSuite) TestReplaceLostMachinesNoneLost(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return nil
+	})
+	api := lostmachines.NewAPI(caller)
+	result, err := api.ReplaceLostMachines()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(result.IsZero(), jc.IsTrue)
+}
+
+func (s *LostMachinesSuite) TestReplaceLostMachinesError(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	api := lostmachines.NewAPI(caller)
+	_, err := api.ReplaceLostMachines()
+	c.Check(err, gc.ErrorMatches, "boom")
+}
@@ -148,3 +148,30 @@ func (c *Client) RunningActions(agent names.MachineTag) ([]params.ActionResult,
 
 	return result.Actions, nil
 }
+
+// ActionScript returns the script to run for the operator-defined
+// machine action with the given tag.
+func (c *Client) ActionScript(tag names.ActionTag) (string, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return "", errors.NotSupportedf("machine action scripts")
+	}
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+
+	err := c.facade.FacadeCall("ActionScripts", args, &results)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
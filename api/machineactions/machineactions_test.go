@@ -559,3 +559,46 @@ func (s *ClientSuite) TestRunningActionsTooManyResults(c *gc.C) {
 	c.Assert(actions, gc.IsNil)
 	stub.CheckCalls(c, expectedCalls)
 }
+
+func (s *ClientSuite) TestActionScriptSuccess(c *gc.C) {
+	tag := names.NewActionTag(utils.MustNewUUID().String())
+	expectedCalls := []jujutesting.StubCall{{
+		"MachineActions.ActionScripts",
+		[]interface{}{"", params.Entities{
+			Entities: []params.Entity{{Tag: tag.String()}},
+		}},
+	}}
+	var stub jujutesting.Stub
+
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			c.Check(result, gc.FitsTypeOf, &params.StringResults{})
+			*(result.(*params.StringResults)) = params.StringResults{
+				Results: []params.StringResult{{Result: "#!/bin/sh\nsosreport\n"}},
+			}
+			return nil
+		}),
+		BestVersion: 2,
+	}
+
+	client := machineactions.NewClient(apiCaller)
+	script, err := client.ActionScript(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(script, gc.Equals, "#!/bin/sh\nsosreport\n")
+	stub.CheckCalls(c, expectedCalls)
+}
+
+func (s *ClientSuite) TestActionScriptNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s.%s", objType, request)
+			return nil
+		}),
+		BestVersion: 1,
+	}
+
+	client := machineactions.NewClient(apiCaller)
+	_, err := client.ActionScript(names.NewActionTag(utils.MustNewUUID().String()))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
 )
 
 const machineManagerFacade = "MachineManager"
@@ -118,6 +119,28 @@ func (client *Client) destroyMachines(method string, machines []string) ([]param
 	return allResults, nil
 }
 
+// InstanceTypes returns, for each of the supplied constraints, the
+// instance types matching them in the model's cloud region. The
+// instance types are sorted by increasing cost, so that the first is
+// the one a machine with the constraints would be given.
+func (client *Client) InstanceTypes(cons []constraints.Value) ([]params.InstanceTypesResult, error) {
+	args := params.ModelInstanceTypesConstraints{
+		Constraints: make([]params.ModelInstanceTypesConstraint, len(cons)),
+	}
+	for i, value := range cons {
+		value := value
+		args.Constraints[i].Value = &value
+	}
+	var results params.InstanceTypesResults
+	if err := client.facade.FacadeCall("InstanceTypes", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(cons) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(cons), n)
+	}
+	return results.Results, nil
+}
+
 // UpdateMachineSeries updates the series of the machine in the db.
 func (client *Client) UpdateMachineSeries(machineName, series string, force bool) error {
 	args := params.UpdateSeriesArgs{
@@ -135,3 +158,39 @@ func (client *Client) UpdateMachineSeries(machineName, series string, force bool
 	}
 	return results.OneError()
 }
+
+// AddMachinePool adds a machine pool to the model.
+func (client *Client) AddMachinePool(pool params.MachinePool) error {
+	return client.machinePoolsCall("AddMachinePools", pool)
+}
+
+// SetMachinePool replaces the labels and applications of a machine pool.
+func (client *Client) SetMachinePool(pool params.MachinePool) error {
+	return client.machinePoolsCall("SetMachinePools", pool)
+}
+
+func (client *Client) machinePoolsCall(method string, pool params.MachinePool) error {
+	if client.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("machine pools")
+	}
+	args := params.MachinePools{
+		Pools: []params.MachinePool{pool},
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ListMachinePools returns the model's machine pools.
+func (client *Client) ListMachinePools() ([]params.MachinePool, error) {
+	if client.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("machine pools")
+	}
+	var result params.MachinePoolsResult
+	if err := client.facade.FacadeCall("ListMachinePools", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Pools, nil
+}
@@ -13,6 +13,7 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -210,3 +211,103 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParams(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *MachinemanagerSuite) TestAddMachinePool(c *gc.C) {
+	var called bool
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				called = true
+				c.Check(objType, gc.Equals, "MachineManager")
+				c.Check(request, gc.Equals, "AddMachinePools")
+				c.Check(arg, jc.DeepEquals, params.MachinePools{
+					Pools: []params.MachinePool{{
+						Name:         "gpu",
+						Applications: []string{"cuda"},
+					}},
+				})
+				*(result.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{
+						Error: &params.Error{Message: "boom"},
+					}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	})
+	err := client.AddMachinePool(params.MachinePool{
+		Name:         "gpu",
+		Applications: []string{"cuda"},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *MachinemanagerSuite) TestListMachinePools(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				c.Check(request, gc.Equals, "ListMachinePools")
+				*(result.(*params.MachinePoolsResult)) = params.MachinePoolsResult{
+					Pools: []params.MachinePool{{Name: "gpu", Machines: []string{"0"}}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	})
+	pools, err := client.ListMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pools, jc.DeepEquals, []params.MachinePool{{Name: "gpu", Machines: []string{"0"}}})
+}
+
+func (s *MachinemanagerSuite) TestMachinePoolsNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 4,
+	})
+	err := client.SetMachinePool(params.MachinePool{Name: "gpu"})
+	c.Assert(err, gc.ErrorMatches, "machine pools not supported")
+	_, err = client.ListMachinePools()
+	c.Assert(err, gc.ErrorMatches, "machine pools not supported")
+}
+
+func (s *MachinemanagerSuite) TestInstanceTypes(c *gc.C) {
+	cons := constraints.MustParse("mem=4G")
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "InstanceTypes")
+		c.Check(arg, jc.DeepEquals, params.ModelInstanceTypesConstraints{
+			Constraints: []params.ModelInstanceTypesConstraint{{Value: &cons}},
+		})
+		*(result.(*params.InstanceTypesResults)) = params.InstanceTypesResults{
+			Results: []params.InstanceTypesResult{{
+				InstanceTypes: []params.InstanceType{{Name: "m3.medium", Memory: 3840, Cost: 67}},
+				CostUnit:      "$USD/hour",
+				CostDivisor:   1000,
+			}},
+		}
+		return nil
+	})
+	results, err := client.InstanceTypes([]constraints.Value{cons})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.InstanceTypesResult{{
+		InstanceTypes: []params.InstanceType{{Name: "m3.medium", Memory: 3840, Cost: 67}},
+		CostUnit:      "$USD/hour",
+		CostDivisor:   1000,
+	}})
+}
+
+func (s *MachinemanagerSuite) TestInstanceTypesResultCountInvalid(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return nil
+	})
+	_, err := client.InstanceTypes([]constraints.Value{{}})
+	c.Assert(err, gc.ErrorMatches, `expected 1 result\(s\), got 0`)
+}
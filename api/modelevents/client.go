@@ -0,0 +1,36 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelevents provides access to the ModelEvents facade, which
+// reports the events that have happened in a model.
+package modelevents
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the model events API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the model events API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ModelEvents")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Events returns events that have happened in the model, ordered by
+// time, along with the cursor to pass in a later call to continue
+// from the last of them.
+func (c *Client) Events(args params.ModelEventsArgs) ([]params.ModelEvent, string, error) {
+	var result params.ModelEventsResult
+	if err := c.facade.FacadeCall("Events", args, &result); err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return result.Events, result.Cursor, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelevents_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelevents"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type ModelEventsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ModelEventsSuite{})
+
+func (s *ModelEventsSuite) TestEvents(c *gc.C) {
+	since := time.Date(2018, time.April, 1, 10, 0, 0, 0, time.UTC)
+	event := params.ModelEvent{
+		Source: "status",
+		Id:     "a",
+		Time:   since,
+		Entity: "unit-mysql-0",
+		Type:   "workload",
+		Status: "active",
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelEvents")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Events")
+			c.Check(a, jc.DeepEquals, params.ModelEventsArgs{
+				Since:   &since,
+				Sources: []string{"status"},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ModelEventsResult{})
+			*(result.(*params.ModelEventsResult)) = params.ModelEventsResult{
+				Events: []params.ModelEvent{event},
+				Cursor: "cursor",
+			}
+			return nil
+		},
+	)
+	client := modelevents.NewClient(apiCaller)
+	events, cursor, err := client.Events(params.ModelEventsArgs{
+		Since:   &since,
+		Sources: []string{"status"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(events, jc.DeepEquals, []params.ModelEvent{event})
+	c.Check(cursor, gc.Equals, "cursor")
+}
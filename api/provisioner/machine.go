@@ -440,6 +440,49 @@ func (m *Machine) SetSupportedContainers(containerTypes ...instance.ContainerTyp
 	return nil
 }
 
+// CharmProfileInfo returns the charm LXD profiles that the machine's
+// instance should have, along with the names of those that have been
+// applied to it.
+func (m *Machine) CharmProfileInfo() ([]params.CharmLXDProfile, []string, error) {
+	if m.st.facade.BestAPIVersion() < 6 {
+		return nil, nil, errors.NotImplementedf("CharmProfileInfo")
+	}
+	var results params.CharmProfileInfoResults
+	args := params.Entities{Entities: []params.Entity{{m.tag.String()}}}
+	err := m.st.facade.FacadeCall("CharmProfileInfo", args, &results)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+	return result.Profiles, result.Current, nil
+}
+
+// SetCharmProfiles records the names of the charm LXD profiles that
+// have been applied to the machine's instance.
+func (m *Machine) SetCharmProfiles(profiles []string) error {
+	if m.st.facade.BestAPIVersion() < 6 {
+		return errors.NotImplementedf("SetCharmProfiles")
+	}
+	var results params.ErrorResults
+	args := params.SetCharmProfilesArgs{
+		Args: []params.SetCharmProfiles{{
+			Entity:   params.Entity{Tag: m.tag.String()},
+			Profiles: profiles,
+		}},
+	}
+	err := m.st.facade.FacadeCall("SetCharmProfiles", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
+
 // SupportsNoContainers records the fact that this machine doesn't support any containers.
 func (m *Machine) SupportsNoContainers() error {
 	return m.SetSupportedContainers([]instance.ContainerType{}...)
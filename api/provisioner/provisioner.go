@@ -121,6 +121,25 @@ func (st *State) WatchMachineErrorRetry() (watcher.NotifyWatcher, error) {
 	return w, nil
 }
 
+// WatchApplicationCharms returns a StringsWatcher that notifies of
+// changes to the model's applications, such as charm upgrades, which
+// may require the charm LXD profiles of machines to be updated.
+func (st *State) WatchApplicationCharms() (watcher.StringsWatcher, error) {
+	if st.facade.BestAPIVersion() < 6 {
+		return nil, errors.NotImplementedf("WatchApplicationCharms")
+	}
+	var result params.StringsWatchResult
+	err := st.facade.FacadeCall("WatchApplicationCharms", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // StateAddresses returns the list of addresses used to connect to the state.
 func (st *State) StateAddresses() ([]string, error) {
 	var result params.StringsResult
@@ -264,6 +283,7 @@ func (st *State) prepareOrGetContainerInterfaceInfo(
 			ProviderId:          network.Id(cfg.ProviderId),
 			ProviderSubnetId:    network.Id(cfg.ProviderSubnetId),
 			ProviderSpaceId:     network.Id(cfg.ProviderSpaceId),
+			SpaceName:           cfg.SpaceName,
 			ProviderVLANId:      network.Id(cfg.ProviderVLANId),
 			ProviderAddressId:   network.Id(cfg.ProviderAddressId),
 			VLANTag:             cfg.VLANTag,
@@ -342,6 +362,28 @@ func (st *State) DistributionGroupByMachineId(tags ...names.MachineTag) ([]Distr
 	return results, nil
 }
 
+// CharmProfileInfo returns, for each of the given machines, the charm
+// LXD profiles that its instance should have, along with the names of
+// those that have been applied to it, in a single call.
+func (st *State) CharmProfileInfo(tags ...names.MachineTag) ([]params.CharmProfileInfoResult, error) {
+	if st.facade.BestAPIVersion() < 6 {
+		return nil, errors.NotImplementedf("CharmProfileInfo")
+	}
+	entities := make([]params.Entity, len(tags))
+	for i, t := range tags {
+		entities[i] = params.Entity{Tag: t.String()}
+	}
+	var results params.CharmProfileInfoResults
+	err := st.facade.FacadeCall("CharmProfileInfo", params.Entities{Entities: entities}, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d results, got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // CACert returns the certificate used to validate the API and state connections.
 func (a *State) CACert() (string, error) {
 	var result params.BytesResult
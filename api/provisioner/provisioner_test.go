@@ -637,6 +637,55 @@ func (s *provisionerSuite) TestWatchModelMachines(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *provisionerSuite) TestWatchApplicationCharms(c *gc.C) {
+	w, err := s.provisioner.WatchApplicationCharms()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewStringsWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertChange()
+
+	// Add an application and change it.
+	app := s.AddTestingApplication(c, "lxd-profile", s.AddTestingCharm(c, "lxd-profile"))
+	wc.AssertChange("lxd-profile")
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("lxd-profile")
+	wc.AssertNoChange()
+}
+
+func (s *provisionerSuite) TestCharmProfiles(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddTestingCharm(c, "lxd-profile")
+	app := s.AddTestingApplication(c, "lxd-profile", ch)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiMachine := s.assertGetOneMachine(c, machine.MachineTag())
+	err = apiMachine.SetCharmProfiles([]string{"juju-controller-lxd-profile-0"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	profiles, current, err := apiMachine.CharmProfileInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(current, jc.DeepEquals, []string{"juju-controller-lxd-profile-0"})
+	c.Assert(profiles, gc.HasLen, 1)
+	c.Assert(profiles[0].Name, gc.Equals, fmt.Sprintf("juju-controller-lxd-profile-%d", ch.Revision()))
+	c.Assert(profiles[0].Config, jc.DeepEquals, ch.LXDProfile().Config)
+	c.Assert(profiles[0].Devices, jc.DeepEquals, ch.LXDProfile().Devices)
+
+	results, err := s.provisioner.CharmProfileInfo(machine.MachineTag(), names.NewMachineTag("42"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Assert(results[0].Current, jc.DeepEquals, current)
+	c.Assert(results[0].Profiles, jc.DeepEquals, profiles)
+	c.Assert(results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
 func (s *provisionerSuite) TestStateAddresses(c *gc.C) {
 	err := s.machine.SetProviderAddresses(network.NewAddress("0.1.2.3"))
 	c.Assert(err, jc.ErrorIsNil)
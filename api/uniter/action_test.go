@@ -101,6 +101,24 @@ func (s *actionSuite) TestActionComplete(c *gc.C) {
 	c.Assert(completed[0].Name(), gc.Equals, "fakeaction")
 }
 
+func (s *actionSuite) TestLogActionMessage(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionBegin(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.LogActionMessage(action.ActionTag(), "halfway there")
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = model.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "halfway there")
+}
+
 func (s *actionSuite) TestActionFail(c *gc.C) {
 	completed, err := s.uniterSuite.wordpressUnit.CompletedActions()
 	c.Assert(err, jc.ErrorIsNil)
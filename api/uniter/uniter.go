@@ -260,6 +260,33 @@ func (st *State) ActionBegin(tag names.ActionTag) error {
 	return nil
 }
 
+// LogActionMessage adds a progress message to a running action.
+func (st *State) LogActionMessage(tag names.ActionTag, message string) error {
+	if st.BestAPIVersion() < 8 {
+		return errors.NotImplementedf("LogActionMessage")
+	}
+	var outcome params.ErrorResults
+
+	args := params.ActionMessageParams{
+		Messages: []params.EntityString{
+			{Tag: tag.String(), Value: message},
+		},
+	}
+
+	err := st.facade.FacadeCall("LogActionsMessages", args, &outcome)
+	if err != nil {
+		return err
+	}
+	if len(outcome.Results) != 1 {
+		return fmt.Errorf("expected 1 result, got %d", len(outcome.Results))
+	}
+	result := outcome.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
 // ActionFinish captures the structured output of an action.
 func (st *State) ActionFinish(tag names.ActionTag, status string, results map[string]interface{}, message string) error {
 	var outcome params.ErrorResults
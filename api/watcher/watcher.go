@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/set"
 	"gopkg.in/tomb.v1"
 
 	"github.com/juju/juju/api/base"
@@ -39,6 +40,18 @@ type commonWatcher struct {
 	// call should invoke the given API method, placing the call's
 	// returned value in result (if any).
 	call watcherAPICall
+
+	// These fields may be set by the embedding watcher, before
+	// calling init().
+
+	// stream, if set, is used to receive changes in place of
+	// repeated calls to Next.
+	stream watcherAPIStream
+
+	// merge must be set if stream is. It combines a change that has
+	// not yet been delivered with the one that follows it, as the
+	// server would have done had the client been slow to call Next.
+	merge func(pending, next interface{}) interface{}
 }
 
 // watcherAPICall wraps up the information about what facade and what watcher
@@ -56,6 +69,31 @@ func makeWatcherAPICaller(caller base.APICaller, facadeName, watcherId string) w
 	}
 }
 
+// watcherAPIStream makes a streaming call of a watcher's Stream
+// method, passing each change to the given handler. It returns when
+// the server stops streaming.
+type watcherAPIStream func(handler rpc.StreamHandler) error
+
+// makeWatcherAPIStreamer creates a watcherAPIStream function for a
+// given facade name and watcherId, if the API server supports
+// streaming from that facade. The Stream method was introduced in
+// the given version of the facade; if caller can't make streaming
+// calls, or the best version is earlier than that, it returns nil.
+func makeWatcherAPIStreamer(caller base.APICaller, facadeName, watcherId string, streamVersion int) watcherAPIStream {
+	streamer, ok := caller.(base.StreamingAPICaller)
+	if !ok {
+		return nil
+	}
+	bestVersion := caller.BestFacadeVersion(facadeName)
+	if bestVersion < streamVersion {
+		return nil
+	}
+	return func(handler rpc.StreamHandler) error {
+		return streamer.APICallStream(facadeName, bestVersion,
+			watcherId, "Stream", nil, handler)
+	}
+}
+
 // init must be called to initialize an embedded commonWatcher's
 // fields. Make sure newResult and call fields are set beforehand.
 func (w *commonWatcher) init() {
@@ -66,6 +104,9 @@ func (w *commonWatcher) init() {
 	if w.call == nil {
 		panic("call must be set")
 	}
+	if w.stream != nil && w.merge == nil {
+		panic("merge must be set if stream is")
+	}
 }
 
 // commonLoop implements the loop structure common to the client
@@ -95,39 +136,139 @@ func (w *commonWatcher) commonLoop() {
 	}()
 	wg.Add(1)
 	go func() {
-		// Because Next blocks until there are changes, we need to
-		// call it in a separate goroutine, so the watcher can be
-		// stopped normally.
+		// Because Next and Stream block until there are changes, we
+		// need to call them in a separate goroutine, so the watcher
+		// can be stopped normally.
 		defer wg.Done()
-		for {
-			result := w.newResult()
-			err := w.call("Next", &result)
-			if err != nil {
-				if params.IsCodeStopped(err) || params.IsCodeNotFound(err) {
-					if w.tomb.Err() != tomb.ErrStillAlive {
-						// The watcher has been stopped at the client end, so we're
-						// expecting one of the above two kinds of error.
-						// We might see the same errors if the server itself
-						// has been shut down, in which case we leave them
-						// untouched.
-						err = tomb.ErrDying
-					}
-				}
-				// Something went wrong, just report the error and bail out.
-				w.tomb.Kill(err)
-				return
-			}
-			select {
-			case <-w.tomb.Dying():
-				return
-			case w.in <- result:
-				// Report back the result we just got.
+		var err error
+		if w.stream != nil {
+			err = w.streamChanges()
+		} else {
+			err = w.nextChanges()
+		}
+		if err == nil {
+			return
+		}
+		if params.IsCodeStopped(err) || params.IsCodeNotFound(err) {
+			if w.tomb.Err() != tomb.ErrStillAlive {
+				// The watcher has been stopped at the client end, so we're
+				// expecting one of the above two kinds of error.
+				// We might see the same errors if the server itself
+				// has been shut down, in which case we leave them
+				// untouched.
+				err = tomb.ErrDying
 			}
 		}
+		// Something went wrong, just report the error and bail out.
+		w.tomb.Kill(err)
 	}()
 	wg.Wait()
 }
 
+// nextChanges calls Next repeatedly, sending each result to w.in,
+// until the watcher dies or a call fails. It returns the error from
+// the failed call, if any.
+func (w *commonWatcher) nextChanges() error {
+	for {
+		result := w.newResult()
+		if err := w.call("Next", &result); err != nil {
+			return err
+		}
+		select {
+		case <-w.tomb.Dying():
+			return nil
+		case w.in <- result:
+			// Report back the result we just got.
+		}
+	}
+}
+
+// streamChanges makes a streaming call for changes, sending each to
+// w.in, until the watcher dies or the call completes. It returns the
+// error that completed the call, if any.
+//
+// The connection delivers changes to the handler as they arrive, and
+// can't wait for the watcher's client to receive them, so changes
+// that arrive before the previous one is delivered are merged with
+// it.
+func (w *commonWatcher) streamChanges() error {
+	handler := &streamHandler{
+		newResult: w.newResult,
+		merge:     w.merge,
+		ready:     make(chan struct{}, 1),
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- w.stream(handler)
+	}()
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return nil
+		case err := <-done:
+			if err == nil {
+				err = errors.New("watcher stream ended unexpectedly")
+			}
+			return err
+		case <-handler.ready:
+		}
+		select {
+		case <-w.tomb.Dying():
+			return nil
+		case w.in <- handler.take():
+			// Report back the change we just got.
+		}
+	}
+}
+
+// streamHandler is the rpc.StreamHandler used by streamChanges. It
+// holds at most one pending change, into which later changes are
+// merged, and signals on ready when there is one.
+type streamHandler struct {
+	newResult func() interface{}
+	merge     func(pending, next interface{}) interface{}
+	ready     chan struct{}
+
+	mu         sync.Mutex
+	pending    interface{}
+	hasPending bool
+}
+
+// NewResponse is part of the rpc.StreamHandler interface. As with
+// calls to Next, the response is decoded through a pointer to the
+// result, so that watchers with no result can use nil.
+func (h *streamHandler) NewResponse() interface{} {
+	result := h.newResult()
+	return &result
+}
+
+// Handle is part of the rpc.StreamHandler interface.
+func (h *streamHandler) Handle(response interface{}) {
+	result := *response.(*interface{})
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.hasPending {
+		h.pending = h.merge(h.pending, result)
+		return
+	}
+	h.pending = result
+	h.hasPending = true
+	select {
+	case h.ready <- struct{}{}:
+	default:
+	}
+}
+
+// take returns the pending change, leaving none.
+func (h *streamHandler) take() interface{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	result := h.pending
+	h.pending = nil
+	h.hasPending = false
+	return result
+}
+
 // Kill is part of the worker.Worker interface.
 func (w *commonWatcher) Kill() {
 	w.tomb.Kill(nil)
@@ -166,6 +307,9 @@ func (w *notifyWatcher) loop() error {
 	// No results for this watcher type.
 	w.newResult = func() interface{} { return nil }
 	w.call = makeWatcherAPICaller(w.caller, "NotifyWatcher", w.notifyWatcherId)
+	w.stream = makeWatcherAPIStreamer(w.caller, "NotifyWatcher", w.notifyWatcherId, 2)
+	// Any number of notifications are as good as one.
+	w.merge = func(pending, _ interface{}) interface{} { return pending }
 	w.commonWatcher.init()
 	go w.commonLoop()
 
@@ -217,6 +361,8 @@ func (w *stringsWatcher) loop(initialChanges []string) error {
 	changes := initialChanges
 	w.newResult = func() interface{} { return new(params.StringsWatchResult) }
 	w.call = makeWatcherAPICaller(w.caller, "StringsWatcher", w.stringsWatcherId)
+	w.stream = makeWatcherAPIStreamer(w.caller, "StringsWatcher", w.stringsWatcherId, 2)
+	w.merge = mergeStringsWatchResults
 	w.commonWatcher.init()
 	go w.commonLoop()
 
@@ -244,6 +390,24 @@ func (w *stringsWatcher) Changes() watcher.StringsChannel {
 	return w.out
 }
 
+// mergeStringsWatchResults merges two *params.StringsWatchResults,
+// returning the first with any changes from the second that it does
+// not already contain appended.
+func mergeStringsWatchResults(pending, next interface{}) interface{} {
+	result := pending.(*params.StringsWatchResult)
+	seen := make(set.Strings)
+	for _, change := range result.Changes {
+		seen.Add(change)
+	}
+	for _, change := range next.(*params.StringsWatchResult).Changes {
+		if !seen.Contains(change) {
+			seen.Add(change)
+			result.Changes = append(result.Changes, change)
+		}
+	}
+	return result
+}
+
 // relationUnitsWatcher will sends notifications of units entering and
 // leaving the scope of a RelationUnit, and changes to the settings of
 // those units known to have entered.
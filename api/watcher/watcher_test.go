@@ -17,6 +17,7 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/crossmodelrelations"
 	"github.com/juju/juju/api/migrationminion"
 	"github.com/juju/juju/api/watcher"
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -93,6 +95,39 @@ func (s *watcherSuite) TestWatchMachine(c *gc.C) {
 	wc.AssertOneChange()
 }
 
+func (s *watcherSuite) TestWatchMachineChanges(c *gc.C) {
+	s.assertWatchMachineChanges(c, s.stateAPI)
+}
+
+func (s *watcherSuite) TestWatchMachineChangesWithoutStreaming(c *gc.C) {
+	// Wrapping the connection hides its APICallStream method, so the
+	// watcher must fall back to calling Next.
+	s.assertWatchMachineChanges(c, nonStreamingCaller{s.stateAPI})
+}
+
+func (s *watcherSuite) assertWatchMachineChanges(c *gc.C, caller base.APICaller) {
+	var results params.NotifyWatchResults
+	args := params.Entities{Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}}}
+	err := caller.APICall("Machiner", caller.BestFacadeVersion("Machiner"), "", "Watch", args, &results)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+
+	w := watcher.NewNotifyWatcher(caller, result)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+	wc.AssertOneChange()
+
+	err = s.rawMachine.SetMachineAddresses(network.NewAddress("10.0.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+type nonStreamingCaller struct {
+	base.APICaller
+}
+
 func (s *watcherSuite) TestNotifyWatcherStopsWithPendingSend(c *gc.C) {
 	var results params.NotifyWatchResults
 	args := params.Entities{Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}}}
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/presence"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/rpcreflect"
@@ -98,6 +99,11 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 	if err != nil {
 		return fail, errors.Trace(err)
 	}
+	if authResult.apiToken != nil {
+		if err := a.recordAPITokenLogin(req, authResult); err != nil {
+			return fail, errors.Trace(err)
+		}
+	}
 
 	// Fetch the API server addresses from state.
 	hostPorts, err := a.root.state.APIHostPorts()
@@ -154,6 +160,7 @@ type authResult struct {
 	controllerOnlyLogin    bool
 	controllerMachineLogin bool
 	userInfo               *params.AuthUserInfo
+	apiToken               *state.APIToken // nil unless logged in with an API token
 }
 
 func (a *admin) authenticate(req params.LoginRequest) (*authResult, error) {
@@ -200,15 +207,34 @@ func (a *admin) authenticate(req params.LoginRequest) (*authResult, error) {
 		result.userLogin = false
 	}
 
+	// A login presenting an API token is authenticated using only the
+	// token's macaroons, so that the token's restrictions can't be
+	// sidestepped by presenting other credentials alongside it.
+	tokenID, tokenMacaroons, err := authentication.APITokenMacaroons(req.Macaroons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if tokenID != "" {
+		if !result.userLogin || req.Credentials != "" {
+			return nil, errors.Trace(common.ErrBadCreds)
+		}
+		req.Macaroons = tokenMacaroons
+	}
+
 	// Only attempt to login with credentials if we are not doing an anonymous login.
 	var (
 		lastConnection *time.Time
 		entity         state.Entity
-		err            error
 		startPinger    = true
 	)
 	if !result.anonymousLogin {
 		entity, lastConnection, err = a.checkCreds(req, result.tag, result.userLogin)
+		if err != nil && tokenID != "" {
+			// There is nothing to discharge for an API token;
+			// an invalid or expired token is just bad credentials.
+			logger.Debugf("login with API token %q failed: %v", tokenID, err)
+			return nil, errors.Trace(common.ErrBadCreds)
+		}
 		if err != nil {
 			// If above login fails, we may still be a login to a controller
 			// machine in the controller model.
@@ -221,6 +247,12 @@ func (a *admin) authenticate(req params.LoginRequest) (*authResult, error) {
 			startPinger = false
 		}
 	}
+	if tokenID != "" {
+		result.apiToken, err = a.checkAPIToken(tokenID, result.tag, result.controllerOnlyLogin)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	a.loggedIn = true
 
 	// TODO(wallyworld) - we can't yet observe anonymous logins as entity must be non-nil
@@ -237,6 +269,7 @@ func (a *admin) authenticate(req params.LoginRequest) (*authResult, error) {
 			// presence pinger in the dependency engine also.
 		}
 		a.root.entity = entity
+		a.root.apiToken = result.apiToken
 		a.apiObserver.Login(entity.Tag(), a.root.model.ModelTag(), result.controllerMachineLogin, req.UserData)
 	}
 
@@ -293,7 +326,7 @@ func (a *admin) fillLoginDetails(result *authResult, lastConnection *time.Time)
 	if result.userLogin {
 		userTag := a.root.entity.Tag().(names.UserTag)
 		var err error
-		result.userInfo, err = a.checkUserPermissions(userTag, result.controllerOnlyLogin)
+		result.userInfo, err = a.checkUserPermissions(userTag, result.controllerOnlyLogin, result.apiToken)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -315,7 +348,11 @@ func (a *admin) fillLoginDetails(result *authResult, lastConnection *time.Time)
 	return nil
 }
 
-func (a *admin) checkUserPermissions(userTag names.UserTag, controllerOnlyLogin bool) (*params.AuthUserInfo, error) {
+func (a *admin) checkUserPermissions(
+	userTag names.UserTag,
+	controllerOnlyLogin bool,
+	apiToken *state.APIToken,
+) (*params.AuthUserInfo, error) {
 
 	modelAccess := permission.NoAccess
 
@@ -371,6 +408,15 @@ func (a *admin) checkUserPermissions(userTag names.UserTag, controllerOnlyLogin
 			return nil, errors.Trace(common.ErrPerm)
 		}
 	}
+	if apiToken != nil {
+		// The user's access is capped by the token they logged in with.
+		if modelAccess.GreaterModelAccessThan(apiToken.Access()) {
+			modelAccess = apiToken.Access()
+		}
+		if controllerAccess.GreaterControllerAccessThan(permission.LoginAccess) {
+			controllerAccess = permission.LoginAccess
+		}
+	}
 	if controllerOnlyLogin {
 		logger.Debugf("controller login: user %s has %q access", userTag.Id(), controllerAccess)
 	} else {
@@ -403,6 +449,55 @@ func filterFacades(registry *facade.Registry, allowFacadeAllMustMatch ...facadeF
 	return out
 }
 
+// checkAPIToken checks that the API token with the given id, whose
+// macaroon has already been verified, may be used by authTag to log
+// in to the connection's model or controller.
+func (a *admin) checkAPIToken(id string, authTag names.Tag, controllerOnlyLogin bool) (*state.APIToken, error) {
+	token, err := a.srv.statePool.SystemState().APIToken(id)
+	if errors.IsNotFound(err) {
+		logger.Debugf("API token %q not found", id)
+		return nil, errors.Trace(common.ErrBadCreds)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if token.Owner() != authTag {
+		logger.Debugf("API token %q not owned by %s", id, names.ReadableString(authTag))
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	if err := token.ValidAt(a.srv.clock.Now()); err != nil {
+		logger.Debugf("login failed: %v", err)
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	if !controllerOnlyLogin && !token.HasModel(a.root.model.UUID()) {
+		logger.Debugf("API token %q not valid for model %q", id, a.root.model.UUID())
+		return nil, errors.Trace(common.ErrPerm)
+	}
+	return token, nil
+}
+
+// recordAPITokenLogin records a login made with an API token in the
+// audit log, if there is one.
+func (a *admin) recordAPITokenLogin(req params.LoginRequest, result *authResult) error {
+	if a.srv.auditLog == nil {
+		return nil
+	}
+	token := result.apiToken
+	args := auditlog.ConversationArgs{
+		Who:          fmt.Sprintf("%s (API token %s)", token.Owner().Id(), token.Id()),
+		What:         req.CLIArgs,
+		When:         a.srv.clock.Now(),
+		ConnectionID: a.root.connectionID,
+	}
+	if !result.controllerOnlyLogin {
+		args.ModelName = fmt.Sprintf("%s/%s", a.root.model.Owner().Id(), a.root.model.Name())
+		args.ModelUUID = a.root.model.UUID()
+	}
+	if _, err := auditlog.NewRecorder(a.srv.auditLog, args); err != nil {
+		return errors.Annotate(err, "cannot record API token login")
+	}
+	return nil
+}
+
 func (a *admin) checkCreds(req params.LoginRequest, authTag names.Tag, userLogin bool) (state.Entity, *time.Time, error) {
 	return doCheckCreds(a.root.state, req, authTag, userLogin, a.authenticator())
 }
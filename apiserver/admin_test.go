@@ -19,15 +19,18 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
+	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api"
 	apimachiner "github.com/juju/juju/api/machiner"
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/apitokens"
 	"github.com/juju/juju/apiserver/facades/client/controller"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/auditlog"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
@@ -586,11 +589,11 @@ func (s *loginSuite) TestAnonymousModelLogin(c *gc.C) {
 	c.Assert(result.ModelTag, gc.Equals, s.IAASModel.ModelTag().String())
 	c.Assert(result.Facades, jc.DeepEquals, []params.FacadeVersions{
 		{Name: "CrossModelRelations", Versions: []int{1}},
-		{Name: "NotifyWatcher", Versions: []int{1}},
+		{Name: "NotifyWatcher", Versions: []int{1, 2}},
 		{Name: "OfferStatusWatcher", Versions: []int{1}},
 		{Name: "RelationStatusWatcher", Versions: []int{1}},
 		{Name: "RelationUnitsWatcher", Versions: []int{1}},
-		{Name: "StringsWatcher", Versions: []int{1}},
+		{Name: "StringsWatcher", Versions: []int{1, 2}},
 	})
 }
 
@@ -613,7 +616,7 @@ func (s *loginSuite) TestAnonymousControllerLogin(c *gc.C) {
 	c.Assert(result.ControllerTag, gc.Equals, s.State.ControllerTag().String())
 	c.Assert(result.Facades, jc.DeepEquals, []params.FacadeVersions{
 		{Name: "CrossController", Versions: []int{1}},
-		{Name: "NotifyWatcher", Versions: []int{1}},
+		{Name: "NotifyWatcher", Versions: []int{1, 2}},
 	})
 }
 
@@ -1345,3 +1348,186 @@ func (t errorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	}
 	return t.fallback.RoundTrip(req)
 }
+
+type apiTokenLoginSuite struct {
+	baseLoginSuite
+	auditLog *fakeAuditLog
+}
+
+var _ = gc.Suite(&apiTokenLoginSuite{})
+
+func (s *apiTokenLoginSuite) SetUpTest(c *gc.C) {
+	s.baseLoginSuite.SetUpTest(c)
+	s.auditLog = &fakeAuditLog{}
+}
+
+func (s *apiTokenLoginSuite) newServer(c *gc.C) (*api.Info, *apiserver.Server) {
+	cfg := defaultServerConfig(c)
+	cfg.AuditLog = s.auditLog
+	return newServerWithConfig(c, s.pool, cfg)
+}
+
+// addToken adds an API token for a new user with write access to the
+// model, returning the user's tag and the token's macaroon.
+func (s *apiTokenLoginSuite) addToken(c *gc.C, args state.AddAPITokenArgs) (*state.APIToken, macaroon.Slice) {
+	token, err := s.State.AddAPIToken(args)
+	c.Assert(err, jc.ErrorIsNil)
+	mint, err := apitokens.NewStateMacaroonMinter(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := mint(token.Id(), token.Owner(), token.Expires())
+	c.Assert(err, jc.ErrorIsNil)
+	return token, macaroon.Slice{m}
+}
+
+func (s *apiTokenLoginSuite) makeUser(c *gc.C) names.UserTag {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name:     "bob",
+		Password: "hunter2",
+		Access:   permission.WriteAccess,
+	})
+	return user.UserTag()
+}
+
+func (s *apiTokenLoginSuite) tokenArgs(user names.UserTag) state.AddAPITokenArgs {
+	return state.AddAPITokenArgs{
+		Owner:       user,
+		Description: "CI",
+		Models:      []string{s.State.ModelUUID()},
+		Access:      permission.ReadAccess,
+		Facades:     []string{"Client"},
+		Expires:     time.Now().Add(time.Hour),
+	}
+}
+
+func (s *apiTokenLoginSuite) login(c *gc.C, info *api.Info, user names.UserTag, ms macaroon.Slice) (api.Connection, error) {
+	info.ModelTag = s.IAASModel.ModelTag()
+	st := s.openAPIWithoutLogin(c, info)
+	return st, st.Login(user, "", "", []macaroon.Slice{ms})
+}
+
+func (s *apiTokenLoginSuite) TestLogin(c *gc.C) {
+	info, srv := s.newServer(c)
+	defer assertStop(c, srv)
+
+	user := s.makeUser(c)
+	token, ms := s.addToken(c, s.tokenArgs(user))
+	st, err := s.login(c, info, user, ms)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The user's write access is capped by the token.
+	c.Assert(st.ModelAccess(), gc.Equals, "read")
+	c.Assert(st.ControllerAccess(), gc.Equals, "login")
+
+	_, err = st.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.Client().GetModelConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.auditLog.conversations, gc.HasLen, 1)
+	conversation := s.auditLog.conversations[0]
+	c.Assert(conversation.Who, gc.Equals, fmt.Sprintf("bob (API token %s)", token.Id()))
+	c.Assert(conversation.ModelUUID, gc.Equals, s.State.ModelUUID())
+}
+
+func (s *apiTokenLoginSuite) TestLoginRestrictsFacades(c *gc.C) {
+	info, srv := s.newServer(c)
+	defer assertStop(c, srv)
+
+	user := s.makeUser(c)
+	_, ms := s.addToken(c, s.tokenArgs(user))
+	st, err := s.login(c, info, user, ms)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = st.APICall("Application", 5, "", "Get", params.ApplicationGet{ApplicationName: "foo"}, nil)
+	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
+		Message: `facade "Application" not supported for API token connection`,
+		Code:    "not supported",
+	})
+}
+
+func (s *apiTokenLoginSuite) TestLoginRestrictsPermissions(c *gc.C) {
+	info, srv := s.newServer(c)
+	defer assertStop(c, srv)
+
+	user := s.makeUser(c)
+	_, ms := s.addToken(c, s.tokenArgs(user))
+	st, err := s.login(c, info, user, ms)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Setting constraints requires write access, which the
+	// token doesn't grant even though the user has it.
+	err = st.Client().SetModelConstraints(constraints.MustParse("mem=4G"))
+	assertPermissionDenied(c, err)
+}
+
+func (s *apiTokenLoginSuite) TestLoginRevoked(c *gc.C) {
+	info, srv := s.newServer(c)
+	defer assertStop(c, srv)
+
+	user := s.makeUser(c)
+	token, ms := s.addToken(c, s.tokenArgs(user))
+	err := token.Revoke()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.login(c, info, user, ms)
+	assertInvalidEntityPassword(c, err)
+	c.Assert(s.auditLog.conversations, gc.HasLen, 0)
+}
+
+func (s *apiTokenLoginSuite) TestLoginWrongUser(c *gc.C) {
+	info, srv := s.newServer(c)
+	defer assertStop(c, srv)
+
+	user := s.makeUser(c)
+	_, ms := s.addToken(c, s.tokenArgs(user))
+	_, err := s.login(c, info, s.AdminUserTag(c), ms)
+	assertInvalidEntityPassword(c, err)
+}
+
+func (s *apiTokenLoginSuite) TestLoginOtherModel(c *gc.C) {
+	info, srv := s.newServer(c)
+	defer assertStop(c, srv)
+
+	user := s.makeUser(c)
+	args := s.tokenArgs(user)
+	args.Models = []string{utils.MustNewUUID().String()}
+	_, ms := s.addToken(c, args)
+	_, err := s.login(c, info, user, ms)
+	assertPermissionDenied(c, err)
+}
+
+func (s *apiTokenLoginSuite) TestLoginWithPassword(c *gc.C) {
+	info, srv := s.newServer(c)
+	defer assertStop(c, srv)
+
+	user := s.makeUser(c)
+	_, ms := s.addToken(c, s.tokenArgs(user))
+	info.ModelTag = s.IAASModel.ModelTag()
+	st := s.openAPIWithoutLogin(c, info)
+	err := st.Login(user, "hunter2", "", []macaroon.Slice{ms})
+	assertInvalidEntityPassword(c, err)
+}
+
+type fakeAuditLog struct {
+	mu            sync.Mutex
+	conversations []auditlog.Conversation
+}
+
+func (l *fakeAuditLog) AddConversation(m auditlog.Conversation) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.conversations = append(l.conversations, m)
+	return nil
+}
+
+func (l *fakeAuditLog) AddRequest(m auditlog.Request) error {
+	return nil
+}
+
+func (l *fakeAuditLog) AddResponse(m auditlog.ResponseErrors) error {
+	return nil
+}
+
+func (l *fakeAuditLog) Close() error {
+	return nil
+}
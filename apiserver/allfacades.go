@@ -39,8 +39,10 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/upgrader"
 	"github.com/juju/juju/apiserver/facades/client/action"
 	"github.com/juju/juju/apiserver/facades/client/annotations" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/apitokens"
 	"github.com/juju/juju/apiserver/facades/client/application" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
+	"github.com/juju/juju/apiserver/facades/client/autoscaling"
 	"github.com/juju/juju/apiserver/facades/client/backups" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/block"   // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/bundle"
@@ -56,7 +58,8 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/machinemanager" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/metricsdebug"   // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"    // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/modelmanager"   // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelevents"
+	"github.com/juju/juju/apiserver/facades/client/modelmanager" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/payloads"
 	"github.com/juju/juju/apiserver/facades/client/resources"
 	"github.com/juju/juju/apiserver/facades/client/spaces"    // ModelUser Write
@@ -65,8 +68,11 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/subnets"
 	"github.com/juju/juju/apiserver/facades/client/usermanager"
 	"github.com/juju/juju/apiserver/facades/controller/actionpruner"
+	"github.com/juju/juju/apiserver/facades/controller/actionrollouts"
+	"github.com/juju/juju/apiserver/facades/controller/actionschedules"
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
+	"github.com/juju/juju/apiserver/facades/controller/autoscaler"
 	"github.com/juju/juju/apiserver/facades/controller/caasfirewaller"
 	"github.com/juju/juju/apiserver/facades/controller/caasoperatorprovisioner"
 	"github.com/juju/juju/apiserver/facades/controller/caasunitprovisioner"
@@ -80,6 +86,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/instancepoller"
 	"github.com/juju/juju/apiserver/facades/controller/lifeflag"
 	"github.com/juju/juju/apiserver/facades/controller/logfwd"
+	"github.com/juju/juju/apiserver/facades/controller/lostmachines"
 	"github.com/juju/juju/apiserver/facades/controller/machineundertaker"
 	"github.com/juju/juju/apiserver/facades/controller/metricsmanager"
 	"github.com/juju/juju/apiserver/facades/controller/migrationmaster"
@@ -122,11 +129,19 @@ func AllFacades() *facade.Registry {
 		}
 	}
 
-	reg("Action", 2, action.NewActionAPI)
+	reg("Action", 2, action.NewActionAPIV2)
+	reg("Action", 3, action.NewActionAPIV3) // adds WatchActionsProgress
+	reg("Action", 4, action.NewActionAPIV4) // adds action rollouts
+	reg("Action", 5, action.NewActionAPIV5) // adds operation queries
+	reg("Action", 6, action.NewActionAPIV6) // adds machine action scripts
+	reg("Action", 7, action.NewActionAPI)   // adds action schedules
 	reg("ActionPruner", 1, actionpruner.NewAPI)
+	reg("ActionRollouts", 1, actionrollouts.NewFacade)
+	reg("ActionSchedules", 1, actionschedules.NewFacade)
 	reg("Agent", 2, agent.NewAgentAPIV2)
 	reg("AgentTools", 1, agenttools.NewFacade)
 	reg("Annotations", 2, annotations.NewAPI)
+	reg("APITokens", 1, apitokens.NewFacade)
 
 	// Application facade versions 1-4 share NewFacadeV4 as
 	// the newer methodology for versioning wasn't started with
@@ -139,6 +154,8 @@ func AllFacades() *facade.Registry {
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
+	reg("Autoscaler", 1, autoscaler.NewFacade)
+	reg("Autoscaling", 1, autoscaling.NewFacade)
 	reg("Backups", 1, backups.NewFacade)
 	reg("Block", 2, block.NewAPI)
 	reg("Bundle", 1, bundle.NewFacade)
@@ -146,6 +163,8 @@ func AllFacades() *facade.Registry {
 	reg("Charms", 2, charms.NewFacade)
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
 	reg("Client", 1, client.NewFacade)
+	reg("Client", 2, client.NewFacade) // adds filters and fields to FullStatus
+	reg("Client", 3, client.NewFacade) // adds StatusHistoryTimeline
 	reg("Cloud", 1, cloud.NewFacade)
 	if featureflag.Enabled(feature.CAAS) {
 		// CAAS related facades.
@@ -186,11 +205,14 @@ func AllFacades() *facade.Registry {
 	reg("LifeFlag", 1, lifeflag.NewExternalFacade)
 	reg("Logger", 1, loggerapi.NewLoggerAPI)
 	reg("LogForwarding", 1, logfwd.NewFacade)
-	reg("MachineActions", 1, machineactions.NewExternalFacade)
+	reg("LostMachines", 1, lostmachines.NewFacade)
+	reg("MachineActions", 1, machineactions.NewExternalFacadeV1)
+	reg("MachineActions", 2, machineactions.NewExternalFacade) // adds machine action scripts
 
 	reg("MachineManager", 2, machinemanager.NewFacade)
 	reg("MachineManager", 3, machinemanager.NewFacade)   // Version 3 adds DestroyMachine and ForceDestroyMachine.
 	reg("MachineManager", 4, machinemanager.NewFacadeV4) // Version 4 adds DestroyMachineWithParams.
+	reg("MachineManager", 5, machinemanager.NewFacadeV5) // Version 5 adds machine pools.
 
 	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
 	reg("Machiner", 1, machine.NewMachinerAPI)
@@ -206,6 +228,7 @@ func AllFacades() *facade.Registry {
 	reg("MigrationTarget", 1, migrationtarget.NewFacade)
 
 	reg("ModelConfig", 1, modelconfig.NewFacade)
+	reg("ModelEvents", 1, modelevents.NewFacade)
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
 	reg("ModelManager", 3, modelmanager.NewFacadeV3)
 	reg("ModelManager", 4, modelmanager.NewFacadeV4)
@@ -222,6 +245,7 @@ func AllFacades() *facade.Registry {
 	reg("Provisioner", 3, provisioner.NewProvisionerAPI)
 	reg("Provisioner", 4, provisioner.NewProvisionerAPI)
 	reg("Provisioner", 5, provisioner.NewProvisionerAPIV5) // v5 adds DistributionGroupByMachineId()
+	reg("Provisioner", 6, provisioner.NewProvisionerAPIV6) // v6 adds charm LXD profiles
 	reg("ProxyUpdater", 1, proxyupdater.NewAPI)
 	reg("Reboot", 2, reboot.NewRebootAPI)
 	reg("RemoteRelations", 1, remoterelations.NewStateRemoteRelationsAPI)
@@ -257,7 +281,8 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 4, uniter.NewUniterAPIV4)
 	reg("Uniter", 5, uniter.NewUniterAPIV5)
 	reg("Uniter", 6, uniter.NewUniterAPIV6)
-	reg("Uniter", 7, uniter.NewUniterAPI)
+	reg("Uniter", 7, uniter.NewUniterAPIV7)
+	reg("Uniter", 8, uniter.NewUniterAPI) // adds LogActionsMessages
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
@@ -270,7 +295,9 @@ func AllFacades() *facade.Registry {
 	// checks).
 	regRaw("AllModelWatcher", 2, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
 	regRaw("NotifyWatcher", 1, newNotifyWatcher, reflect.TypeOf((*srvNotifyWatcher)(nil)))
+	regRaw("NotifyWatcher", 2, newNotifyWatcherV2, reflect.TypeOf((*srvNotifyWatcherV2)(nil))) // adds Stream
 	regRaw("StringsWatcher", 1, newStringsWatcher, reflect.TypeOf((*srvStringsWatcher)(nil)))
+	regRaw("StringsWatcher", 2, newStringsWatcherV2, reflect.TypeOf((*srvStringsWatcherV2)(nil))) // adds Stream
 	regRaw("OfferStatusWatcher", 1, newOfferStatusWatcher, reflect.TypeOf((*srvOfferStatusWatcher)(nil)))
 	regRaw("RelationStatusWatcher", 1, newRelationStatusWatcher, reflect.TypeOf((*srvRelationStatusWatcher)(nil)))
 	regRaw("RelationUnitsWatcher", 1, newRelationUnitsWatcher, reflect.TypeOf((*srvRelationUnitsWatcher)(nil)))
@@ -4,14 +4,16 @@
 package apiserver_test
 
 import (
-	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/testing"
 )
 
 type AllFacadesSuite struct {
-	testing.IsolationSuite
+	testing.BaseSuite
 }
 
 var _ = gc.Suite(&AllFacadesSuite{})
@@ -21,3 +23,40 @@ func (s *AllFacadesSuite) TestNoPanic(c *gc.C) {
 	r := apiserver.AllFacades()
 	c.Assert(r, gc.NotNil)
 }
+
+// caasApplicationMethods are the Application facade methods that
+// are only available when the CAAS feature flag is enabled.
+var caasApplicationMethods = []string{
+	"CharmConfig",
+	"SetApplicationsConfig",
+	"UnsetApplicationsConfig",
+}
+
+// applicationMethods returns the names of the CAAS-only methods
+// exposed by any registered version of the Application facade.
+func (s *AllFacadesSuite) applicationMethods(c *gc.C) []string {
+	r := apiserver.AllFacades()
+	var found []string
+	for _, desc := range r.List() {
+		if desc.Name != "Application" {
+			continue
+		}
+		for _, version := range desc.Versions {
+			t, err := r.GetType(desc.Name, version)
+			c.Assert(err, jc.ErrorIsNil)
+			for _, name := range caasApplicationMethods {
+				if _, ok := t.MethodByName(name); ok {
+					found = append(found, name)
+				}
+			}
+		}
+	}
+	return found
+}
+
+func (s *AllFacadesSuite) TestApplicationCAASMethodsNeedFeatureFlag(c *gc.C) {
+	c.Assert(s.applicationMethods(c), gc.HasLen, 0)
+
+	s.SetFeatureFlags(feature.CAAS)
+	c.Assert(s.applicationMethods(c), jc.SameContents, caasApplicationMethods)
+}
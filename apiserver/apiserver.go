@@ -39,6 +39,8 @@ import (
 	"github.com/juju/juju/apiserver/logsink"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/rpc"
@@ -96,6 +98,8 @@ type Server struct {
 	dbloggers              dbloggers
 	upgradeComplete        func() bool
 	restoreStatus          func() state.RestoreStatus
+	auditLog               auditlog.AuditLog
+	tracer                 *tracing.Tracer
 
 	// mu guards the fields below it.
 	mu sync.Mutex
@@ -112,6 +116,11 @@ type Server struct {
 	// is to support registering the handlers underneath the
 	// "/introspection" prefix.
 	registerIntrospectionHandlers func(func(string, http.Handler))
+
+	// metricsPassword holds the password required to read the
+	// Prometheus metrics served at "/metrics". If it is empty,
+	// the metrics are only served under "/introspection".
+	metricsPassword string
 }
 
 // ServerConfig holds parameters required to set up an API server.
@@ -178,6 +187,25 @@ type ServerConfig struct {
 
 	// PrometheusRegisterer registers Prometheus collectors.
 	PrometheusRegisterer prometheus.Registerer
+
+	// AuditLog holds the audit log that API connections authenticated
+	// with API tokens are recorded in. If this is nil, no audit
+	// records are written. The server closes the audit log when it
+	// stops.
+	AuditLog auditlog.AuditLog
+
+	// MetricsPassword, if non-empty, causes the server to serve the
+	// Prometheus metrics registered as the "/metrics" introspection
+	// handler at "/metrics" too. Requests must authenticate using
+	// HTTP basic authentication, with the username "metrics" and
+	// this password, so that scrapers need no Juju user account.
+	MetricsPassword string
+
+	// Tracer, if non-nil, is used to record a trace of each API
+	// request served. Facades can add spans to the trace using the
+	// context passed to their methods. The server closes the tracer
+	// when it stops.
+	Tracer *tracing.Tracer
 }
 
 // Validate validates the API server configuration.
@@ -371,12 +399,15 @@ func newServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (_ *
 		loginRetryPause:               cfg.RateLimitConfig.LoginRetryPause,
 		upgradeComplete:               cfg.UpgradeComplete,
 		restoreStatus:                 cfg.RestoreStatus,
+		auditLog:                      cfg.AuditLog,
+		tracer:                        cfg.Tracer,
 		facades:                       AllFacades(),
 		centralHub:                    cfg.Hub,
 		getCertificate:                cfg.GetCertificate,
 		allowModelAccess:              cfg.AllowModelAccess,
 		publicDNSName_:                cfg.AutocertDNSName,
 		registerIntrospectionHandlers: cfg.RegisterIntrospectionHandlers,
+		metricsPassword:               cfg.MetricsPassword,
 		logsinkRateLimitConfig: logsink.RateLimitConfig{
 			Refill: cfg.LogSinkConfig.RateLimitRefill,
 			Burst:  cfg.LogSinkConfig.RateLimitBurst,
@@ -549,6 +580,12 @@ func (srv *Server) loop() error {
 		srv.wg.Wait() // wait for any outstanding requests to complete.
 		srv.dbloggers.dispose()
 		srv.logSinkWriter.Close()
+		if srv.auditLog != nil {
+			srv.auditLog.Close()
+		}
+		if srv.tracer != nil {
+			srv.tracer.Close()
+		}
 	}()
 
 	// for pat based handlers, they are matched in-order of being
@@ -781,6 +818,12 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 					handler,
 				},
 			)
+			if subpath == "/metrics" && srv.metricsPassword != "" {
+				add("/metrics", metricsHandler{
+					password: srv.metricsPassword,
+					handler:  handler,
+				})
+			}
 		}
 		srv.registerIntrospectionHandlers(handle)
 	}
@@ -889,6 +932,7 @@ func (srv *Server) apiHandler(w http.ResponseWriter, req *http.Request) {
 			modelUUID,
 			apiObserver,
 			req.Host,
+			connectionID,
 		); err != nil {
 			logger.Errorf("error serving RPCs: %v", err)
 		}
@@ -901,6 +945,7 @@ func (srv *Server) serveConn(
 	modelUUID string,
 	apiObserver observer.Observer,
 	host string,
+	connectionID uint64,
 ) error {
 	codec := jsoncodec.NewWebsocket(wsConn.Conn)
 	conn := rpc.NewConn(codec, apiObserver)
@@ -925,6 +970,9 @@ func (srv *Server) serveConn(
 		defer releaser()
 		h, err = newAPIHandler(srv, st, conn, modelUUID, host)
 	}
+	if err == nil {
+		h.connectionID = connectionID
+	}
 
 	if err != nil {
 		conn.ServeRoot(&errRoot{errors.Trace(err)}, serverError)
@@ -939,7 +987,7 @@ func (srv *Server) serveConn(
 		}
 		conn.ServeRoot(newAdminRoot(h, adminAPIs), serverError)
 	}
-	conn.Start(ctx)
+	conn.Start(tracing.WithTracer(ctx, srv.tracer))
 	select {
 	case <-conn.Dead():
 	case <-srv.tomb.Dying():
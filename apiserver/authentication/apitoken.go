@@ -0,0 +1,65 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon-bakery.v1/bakery/checkers"
+	"gopkg.in/macaroon.v1"
+)
+
+// apiTokenKey is the name of the declared caveat holding the
+// id of the API token a macaroon was minted for.
+const apiTokenKey = "api-token"
+
+// NewAPITokenMacaroon returns a macaroon that allows the owner of
+// the API token with the given id to log in without a password until
+// the specified expiry time. The restrictions recorded against the
+// token are enforced at login time, so that the token may be revoked
+// or inspected without needing to refer to the macaroon.
+func NewAPITokenMacaroon(
+	service BakeryService,
+	tokenID string,
+	owner names.UserTag,
+	expires time.Time,
+) (*macaroon.Macaroon, error) {
+	m, err := service.NewMacaroon("", nil, []checkers.Caveat{
+		checkers.DeclaredCaveat(usernameKey, owner.Id()),
+		checkers.DeclaredCaveat(apiTokenKey, tokenID),
+		checkers.TimeBeforeCaveat(expires),
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create API token macaroon")
+	}
+	return m, nil
+}
+
+// APITokenMacaroons returns the id of the API token declared by the
+// given macaroons, along with the macaroon slices that declare it.
+// If none of the macaroons declares a token, an empty id is returned.
+// It is an error for the macaroons to declare more than one token.
+//
+// The returned macaroons have not been verified; callers must still
+// authenticate them before trusting the token id.
+func APITokenMacaroons(ms []macaroon.Slice) (string, []macaroon.Slice, error) {
+	var (
+		tokenID string
+		tokenMs []macaroon.Slice
+	)
+	for _, m := range ms {
+		id := checkers.InferDeclared(m)[apiTokenKey]
+		if id == "" {
+			continue
+		}
+		if tokenID != "" && id != tokenID {
+			return "", nil, errors.New("more than one API token presented")
+		}
+		tokenID = id
+		tokenMs = append(tokenMs, m)
+	}
+	return tokenID, tokenMs, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon-bakery.v1/bakery"
+	"gopkg.in/macaroon-bakery.v1/bakery/checkers"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/apiserver/authentication"
+)
+
+type apiTokenSuite struct {
+	testing.IsolationSuite
+	service *bakery.Service
+}
+
+var _ = gc.Suite(&apiTokenSuite{})
+
+func (s *apiTokenSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	service, err := bakery.NewService(bakery.NewServiceParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.service = service
+}
+
+func (s *apiTokenSuite) TestNewAPITokenMacaroon(c *gc.C) {
+	expires := time.Now().Add(time.Hour)
+	m, err := authentication.NewAPITokenMacaroon(s.service, "token-id", names.NewUserTag("bob"), expires)
+	c.Assert(err, jc.ErrorIsNil)
+
+	declared, err := s.service.CheckAny(
+		[]macaroon.Slice{{m}},
+		map[string]string{"username": "bob"},
+		checkers.New(checkers.TimeBefore),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(declared["api-token"], gc.Equals, "token-id")
+}
+
+func (s *apiTokenSuite) TestNewAPITokenMacaroonExpired(c *gc.C) {
+	expires := time.Now().Add(-time.Hour)
+	m, err := authentication.NewAPITokenMacaroon(s.service, "token-id", names.NewUserTag("bob"), expires)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.service.CheckAny(
+		[]macaroon.Slice{{m}},
+		map[string]string{"username": "bob"},
+		checkers.New(checkers.TimeBefore),
+	)
+	c.Assert(err, gc.ErrorMatches, ".*macaroon has expired")
+}
+
+func (s *apiTokenSuite) TestAPITokenMacaroons(c *gc.C) {
+	expires := time.Now().Add(time.Hour)
+	token, err := authentication.NewAPITokenMacaroon(s.service, "token-id", names.NewUserTag("bob"), expires)
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.service.NewMacaroon("", nil, []checkers.Caveat{
+		checkers.DeclaredCaveat("username", "bob"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	id, ms, err := authentication.APITokenMacaroons([]macaroon.Slice{{other}, {token}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "token-id")
+	c.Assert(ms, jc.DeepEquals, []macaroon.Slice{{token}})
+}
+
+func (s *apiTokenSuite) TestAPITokenMacaroonsNoToken(c *gc.C) {
+	other, err := s.service.NewMacaroon("", nil, []checkers.Caveat{
+		checkers.DeclaredCaveat("username", "bob"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	id, ms, err := authentication.APITokenMacaroons([]macaroon.Slice{{other}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "")
+	c.Assert(ms, gc.HasLen, 0)
+}
+
+func (s *apiTokenSuite) TestAPITokenMacaroonsConflicting(c *gc.C) {
+	expires := time.Now().Add(time.Hour)
+	token1, err := authentication.NewAPITokenMacaroon(s.service, "token-1", names.NewUserTag("bob"), expires)
+	c.Assert(err, jc.ErrorIsNil)
+	token2, err := authentication.NewAPITokenMacaroon(s.service, "token-2", names.NewUserTag("bob"), expires)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = authentication.APITokenMacaroons([]macaroon.Slice{{token1}, {token2}})
+	c.Assert(err, gc.ErrorMatches, "more than one API token presented")
+}
@@ -239,12 +239,49 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.A
 			Tag:        action.ActionTag().String(),
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Operation:  action.Operation(),
 		},
 		Status:    string(action.Status()),
 		Message:   message,
 		Output:    output,
+		Log:       convertActionMessages(action.Messages()),
 		Enqueued:  action.Enqueued(),
 		Started:   action.Started(),
 		Completed: action.Completed(),
 	}
 }
+
+func convertActionMessages(messages []state.ActionMessage) []params.ActionMessage {
+	if len(messages) == 0 {
+		return nil
+	}
+	result := make([]params.ActionMessage, len(messages))
+	for i, message := range messages {
+		result[i] = params.ActionMessage{
+			Message:   message.Message,
+			Timestamp: message.Timestamp,
+		}
+	}
+	return result
+}
+
+// LogActionsMessages adds the given progress messages to the actions
+// they are tagged with.
+// It's a helper function currently used by the uniter.
+// It needs an actionFn that can fetch an action from state using it's id that's usually created by AuthAndActionFromTagFn
+func LogActionsMessages(args params.ActionMessageParams, actionFn func(string) (state.Action, error)) params.ErrorResults {
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Messages))}
+
+	for i, arg := range args.Messages {
+		action, err := actionFn(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+		if err := action.Log(arg.Value); err != nil {
+			results.Results[i].Error = ServerError(err)
+		}
+	}
+
+	return results
+}
@@ -159,6 +159,31 @@ func (s *actionsSuite) TestGetActions(c *gc.C) {
 	})
 }
 
+func (s *actionsSuite) TestLogActionsMessages(c *gc.C) {
+	args := params.ActionMessageParams{
+		Messages: []params.EntityString{
+			{Tag: "success", Value: "hello"},
+			{Tag: "notfound", Value: "hello"},
+			{Tag: "logFail", Value: "hello"},
+		},
+	}
+	expectErr := errors.New("explosivo")
+	logged := &[]string{}
+	actionFn := makeGetActionByTagString(map[string]state.Action{
+		"success": fakeAction{logged: logged},
+		"logFail": fakeAction{logErr: expectErr},
+	})
+	results := common.LogActionsMessages(args, actionFn)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		[]params.ErrorResult{
+			{},
+			{common.ServerError(actionNotFoundErr)},
+			{common.ServerError(expectErr)},
+		},
+	})
+	c.Assert(*logged, jc.DeepEquals, []string{"hello"})
+}
+
 func (s *actionsSuite) TestFinishActions(c *gc.C) {
 	args := params.ActionExecutionResults{
 		[]params.ActionExecutionResult{
@@ -334,6 +359,8 @@ type fakeAction struct {
 	name      string
 	beginErr  error
 	finishErr error
+	logErr    error
+	logged    *[]string
 	status    state.ActionStatus
 }
 
@@ -361,6 +388,14 @@ func (mock fakeAction) Finish(state.ActionResults) (state.Action, error) {
 	return nil, mock.finishErr
 }
 
+func (mock fakeAction) Log(message string) error {
+	if mock.logErr != nil {
+		return mock.logErr
+	}
+	*mock.logged = append(*mock.logged, message)
+	return nil
+}
+
 // entities is a convenience constructor for params.Entities.
 func entities(tags ...string) params.Entities {
 	entities := params.Entities{
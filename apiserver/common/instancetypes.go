@@ -4,7 +4,11 @@
 package common
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
@@ -12,6 +16,21 @@ import (
 	"github.com/juju/juju/environs/instances"
 )
 
+// instanceTypesLifetime is how long the instance types offered in a
+// cloud region are cached before the cloud is queried again.
+const instanceTypesLifetime = time.Hour
+
+// instanceTypesCatalogue caches the instance types reported by the
+// facades, shared by every model in the controller.
+var instanceTypesCatalogue = instances.NewCatalogue(clock.WallClock, instanceTypesLifetime)
+
+// InvalidateInstanceTypes discards the instance types cached for the
+// given credential, which must be called when the credential is
+// updated or removed, as the instance types offered may change.
+func InvalidateInstanceTypes(credential names.CloudCredentialTag) {
+	instanceTypesCatalogue.Invalidate(credential.Id())
+}
+
 func toParamsInstanceTypeResult(itypes []instances.InstanceType) []params.InstanceType {
 	result := make([]params.InstanceType, len(itypes))
 	for i, t := range itypes {
@@ -42,16 +61,44 @@ func NewInstanceTypeConstraints(env environs.Environ, constraints constraints.Va
 	}
 }
 
+// NewRegionInstanceTypeConstraints returns an instanceTypeConstraints
+// for the instance types offered to a credential in a cloud region.
+// The instance types are cached, so that the cloud is not queried
+// every time they are requested.
+func NewRegionInstanceTypeConstraints(
+	env environs.Environ,
+	key instances.CatalogueKey,
+	constraints constraints.Value,
+) instanceTypeConstraints {
+	return instanceTypeConstraints{
+		environ:     env,
+		constraints: constraints,
+		key:         &key,
+	}
+}
+
 // instanceTypeConstraints holds necesary params to filter instance types.
 type instanceTypeConstraints struct {
 	constraints constraints.Value
 	environ     environs.Environ
+
+	// key, if set, identifies the cloud region whose cached
+	// instance types are used.
+	key *instances.CatalogueKey
 }
 
 // InstanceTypes returns a list of the available instance types in the provider according
 // to the passed constraints.
 func InstanceTypes(cons instanceTypeConstraints) (params.InstanceTypesResult, error) {
-	instanceTypes, err := cons.environ.InstanceTypes(cons.constraints)
+	var instanceTypes instances.InstanceTypesWithCostMetadata
+	var err error
+	if cons.key != nil {
+		instanceTypes, err = instanceTypesCatalogue.InstanceTypes(
+			*cons.key, cons.constraints, cons.environ.InstanceTypes,
+		)
+	} else {
+		instanceTypes, err = cons.environ.InstanceTypes(cons.constraints)
+	}
 	if err != nil {
 		return params.InstanceTypesResult{}, errors.Trace(err)
 	}
@@ -173,6 +173,7 @@ func NetworkConfigFromInterfaceInfo(interfaceInfos []network.InterfaceInfo) []pa
 			ProviderId:          string(v.ProviderId),
 			ProviderSubnetId:    string(v.ProviderSubnetId),
 			ProviderSpaceId:     string(v.ProviderSpaceId),
+			SpaceName:           v.SpaceName,
 			ProviderVLANId:      string(v.ProviderVLANId),
 			ProviderAddressId:   string(v.ProviderAddressId),
 			VLANTag:             v.VLANTag,
@@ -5,9 +5,11 @@ package apiserver
 
 import (
 	"net"
+	"net/http"
 	"time"
 
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
@@ -97,6 +99,12 @@ func TestingAPIRoot(facades *facade.Registry) rpc.Root {
 	return newAPIRoot(nil, state.NewStatePool(nil), facades, common.NewResources(), nil)
 }
 
+// TestingAPIRootWithState is like TestingAPIRoot, but its facades
+// are given the supplied state.
+func TestingAPIRootWithState(st *state.State, facades *facade.Registry) rpc.Root {
+	return newAPIRoot(st, state.NewStatePool(st), facades, common.NewResources(), nil)
+}
+
 // TestingAPIHandler gives you an APIHandler that isn't connected to
 // anything real. It's enough to let test some basic functionality though.
 func TestingAPIHandler(c *gc.C, pool *state.StatePool, st *state.State) (*apiHandler, *common.Resources) {
@@ -166,6 +174,16 @@ func TestingCAASModelOnlyRoot() rpc.Root {
 	return restrictRoot(r, caasModelFacadesOnly)
 }
 
+// TestingAPITokenRoot returns a restricted srvRoot as if logged in
+// with an API token allowing the given facades.
+func TestingAPITokenRoot(facades ...string) rpc.Root {
+	r := TestingAPIRoot(AllFacades())
+	allowed := set.NewStrings(facades...)
+	return restrictRoot(r, apiTokenFacadesOnly(func(name string) bool {
+		return allowed.IsEmpty() || allowed.Contains(name)
+	}))
+}
+
 // TestingRestrictedRoot returns a restricted srvRoot.
 func TestingRestrictedRoot(check func(string, string) error) rpc.Root {
 	r := TestingAPIRoot(AllFacades())
@@ -179,6 +197,12 @@ func TestingAboutToRestoreRoot() rpc.Root {
 	return restrictRoot(r, aboutToRestoreMethodsOnly)
 }
 
+// NewMetricsHandler returns the handler used to serve "/metrics"
+// to clients presenting the given metrics password.
+func NewMetricsHandler(password string, handler http.Handler) http.Handler {
+	return metricsHandler{password: password, handler: handler}
+}
+
 // Addr returns the address that the server is listening on.
 func (srv *Server) Addr() *net.TCPAddr {
 	return srv.lis.Addr().(*net.TCPAddr) // cannot fail
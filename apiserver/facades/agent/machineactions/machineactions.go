@@ -20,6 +20,7 @@ type Backend interface {
 	FindEntity(tag names.Tag) (state.Entity, error)
 	TagToActionReceiverFn(findEntity func(names.Tag) (state.Entity, error)) func(string) (state.ActionReceiver, error)
 	ConvertActions(ar state.ActionReceiver, fn common.GetActionsFn) ([]params.ActionResult, error)
+	MachineActionScript(name string) (string, error)
 }
 
 // Facade implements the machineactions interface and is the concrete
@@ -30,6 +31,28 @@ type Facade struct {
 	accessMachine common.AuthFunc
 }
 
+// FacadeV1 is the V1 machineactions API end point, which has no
+// support for operator-defined machine actions.
+type FacadeV1 struct {
+	*Facade
+}
+
+// NewFacadeV1 creates a new server-side machineactions V1 API end point.
+func NewFacadeV1(
+	backend Backend,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*FacadeV1, error) {
+	f, err := NewFacade(backend, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &FacadeV1{f}, nil
+}
+
+// ActionScripts isn't on the V1 API.
+func (*FacadeV1) ActionScripts(_, _ struct{}) {}
+
 // NewFacade creates a new server-side machineactions API end point.
 func NewFacade(
 	backend Backend,
@@ -65,6 +88,27 @@ func (f *Facade) FinishActions(args params.ActionExecutionResults) params.ErrorR
 	return common.FinishActions(args, actionFn)
 }
 
+// ActionScripts returns the script to run for each of the passed in
+// action Tags, which must name operator-defined machine actions.
+func (f *Facade) ActionScripts(args params.Entities) params.StringResults {
+	actionFn := common.AuthAndActionFromTagFn(f.accessMachine, f.backend.ActionByTag)
+	results := params.StringResults{Results: make([]params.StringResult, len(args.Entities))}
+	for i, entity := range args.Entities {
+		action, err := actionFn(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		script, err := f.backend.MachineActionScript(action.Name())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = script
+	}
+	return results
+}
+
 // WatchActionNotifications returns a StringsWatcher for observing
 // incoming action calls to a machine.
 func (f *Facade) WatchActionNotifications(args params.Entities) params.StringsWatchResults {
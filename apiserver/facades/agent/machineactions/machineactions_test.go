@@ -74,6 +74,37 @@ func (*FacadeSuite) TestRunningActions(c *gc.C) {
 	stub.CheckCallNames(c, "TagToActionReceiverFn", "ConvertActions", "ConvertActions")
 }
 
+func (*FacadeSuite) TestActionScripts(c *gc.C) {
+	stub := &testing.Stub{}
+	backend := &mockBackend{
+		stub: stub,
+	}
+	facade, err := machineactions.NewFacade(backend, nil, agentAuth{machine: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.SetErrors(nil, nil, nil, nil, errors.New("machine action \"sosreport\" not found"))
+	results := facade.ActionScripts(entities(
+		"action-f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		"action-f47ac10b-58cc-4372-a567-0e02b2c3d480", // unauthorized
+		"action-f47ac10b-58cc-4372-a567-0e02b2c3d481", // script removed
+		"invalid",
+	))
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[:3], gc.DeepEquals, []params.StringResult{{
+		Result: "#!/bin/sh\nsosreport\n",
+	}, {
+		Error: common.ServerError(common.ErrPerm),
+	}, {
+		Error: common.ServerError(errors.New(`machine action "sosreport" not found`)),
+	}})
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `"invalid" is not a valid .*tag`)
+	stub.CheckCallNames(c,
+		"ActionByTag", "MachineActionScript",
+		"ActionByTag",
+		"ActionByTag", "MachineActionScript",
+	)
+}
+
 // entities is a convenience constructor for params.Entities.
 func entities(tags ...string) params.Entities {
 	entities := params.Entities{
@@ -97,7 +128,8 @@ func (auth agentAuth) AuthMachineAgent() bool {
 }
 
 func (auth agentAuth) AuthOwner(tag names.Tag) bool {
-	if tag.String() == "valid" {
+	switch tag.String() {
+	case "valid", "machine-0":
 		return true
 	}
 	return false
@@ -157,3 +189,37 @@ func (mock *mockBackend) ConvertActions(ar state.ActionReceiver, fn common.GetAc
 }
 
 var actions = []params.ActionResult{params.ActionResult{Action: &params.Action{Name: "foo"}}}
+
+func (mock *mockBackend) ActionByTag(tag names.ActionTag) (state.Action, error) {
+	mock.stub.AddCall("ActionByTag", tag)
+	if err := mock.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	receiver := "0"
+	if tag.Id() == "f47ac10b-58cc-4372-a567-0e02b2c3d480" {
+		receiver = "1"
+	}
+	return fakeAction{receiver: receiver, name: "sosreport"}, nil
+}
+
+func (mock *mockBackend) MachineActionScript(name string) (string, error) {
+	mock.stub.AddCall("MachineActionScript", name)
+	if err := mock.stub.NextErr(); err != nil {
+		return "", err
+	}
+	return "#!/bin/sh\nsosreport\n", nil
+}
+
+type fakeAction struct {
+	state.Action
+	receiver string
+	name     string
+}
+
+func (mock fakeAction) Receiver() string {
+	return mock.receiver
+}
+
+func (mock fakeAction) Name() string {
+	return mock.name
+}
@@ -18,6 +18,11 @@ func NewExternalFacade(st *state.State, res facade.Resources, auth facade.Author
 	return NewFacade(backendShim{st}, res, auth)
 }
 
+// NewExternalFacadeV1 is used for API registration.
+func NewExternalFacadeV1(st *state.State, res facade.Resources, auth facade.Authorizer) (*FacadeV1, error) {
+	return NewFacadeV1(backendShim{st}, res, auth)
+}
+
 type backendShim struct {
 	st *state.State
 }
@@ -42,3 +47,16 @@ func (shim backendShim) TagToActionReceiverFn(findEntity func(names.Tag) (state.
 func (shim backendShim) ConvertActions(ar state.ActionReceiver, fn common.GetActionsFn) ([]params.ActionResult, error) {
 	return common.ConvertActions(ar, fn)
 }
+
+func (shim backendShim) MachineActionScript(name string) (string, error) {
+	m, err := shim.st.Model()
+	if err != nil {
+		return "", err
+	}
+
+	script, err := m.MachineActionScript(name)
+	if err != nil {
+		return "", err
+	}
+	return script.Script(), nil
+}
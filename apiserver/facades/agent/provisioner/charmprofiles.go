@@ -0,0 +1,137 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// ProvisionerAPIV6 adds support for the LXD profiles contributed by
+// charms.
+type ProvisionerAPIV6 struct {
+	*ProvisionerAPIV5
+}
+
+// NewProvisionerAPIV6 creates a new server-side Provisioner API facade.
+func NewProvisionerAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ProvisionerAPIV6, error) {
+	provisionerAPI, err := NewProvisionerAPIV5(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ProvisionerAPIV6{provisionerAPI}, nil
+}
+
+// WatchApplicationCharms returns a StringsWatcher that notifies of
+// changes to the model's applications, such as charm upgrades, which
+// may require the charm LXD profiles of machines to be updated.
+func (p *ProvisionerAPIV6) WatchApplicationCharms() (params.StringsWatchResult, error) {
+	result := params.StringsWatchResult{}
+	watch := p.st.WatchApplicationCharms()
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		result.StringsWatcherId = p.resources.Register(watch)
+		result.Changes = changes
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
+
+// CharmProfileInfo returns, for each machine, the charm LXD profiles
+// that its instance should have, along with the names of the profiles
+// that have been applied to it.
+func (p *ProvisionerAPIV6) CharmProfileInfo(args params.Entities) (params.CharmProfileInfoResults, error) {
+	result := params.CharmProfileInfoResults{
+		Results: make([]params.CharmProfileInfoResult, len(args.Entities)),
+	}
+	canAccess, err := p.getAuthFunc()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := p.getMachine(canAccess, tag)
+		if err == nil {
+			result.Results[i].Profiles, err = p.machineCharmLXDProfiles(machine)
+			result.Results[i].Current = machine.CharmProfiles()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// SetCharmProfiles records the names of the charm LXD profiles that
+// have been applied to each machine's instance.
+func (p *ProvisionerAPIV6) SetCharmProfiles(args params.SetCharmProfilesArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := p.getAuthFunc()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseMachineTag(arg.Entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := p.getMachine(canAccess, tag)
+		if err == nil {
+			err = machine.SetCharmProfiles(arg.Profiles)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// machineCharmLXDProfiles returns the LXD profiles shipped with the
+// charms of the applications whose units are assigned to the machine.
+func (p *ProvisionerAPI) machineCharmLXDProfiles(m *state.Machine) ([]params.CharmLXDProfile, error) {
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var profiles []params.CharmLXDProfile
+	processedApplications := set.NewStrings()
+	for _, unit := range units {
+		appName := unit.ApplicationName()
+		if processedApplications.Contains(appName) {
+			continue
+		}
+		processedApplications.Add(appName)
+		app, err := unit.Application()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		profile := ch.LXDProfile()
+		if profile == nil || profile.Empty() {
+			continue
+		}
+		profiles = append(profiles, params.CharmLXDProfile{
+			Name:        lxdprofile.Name(p.m.Name(), appName, ch.Revision()),
+			Config:      profile.Config,
+			Description: profile.Description,
+			Devices:     profile.Devices,
+		})
+	}
+	return profiles, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/agent/provisioner"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+func (s *withoutControllerSuite) newProvisionerV6(c *gc.C) *provisioner.ProvisionerAPIV6 {
+	provisionerV6, err := provisioner.NewProvisionerAPIV6(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return provisionerV6
+}
+
+func (s *withoutControllerSuite) addLXDProfileUnit(c *gc.C) params.CharmLXDProfile {
+	ch := s.AddTestingCharm(c, "lxd-profile")
+	app := s.AddTestingApplication(c, "lxd-profile", ch)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machines[0])
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	profile := ch.LXDProfile()
+	return params.CharmLXDProfile{
+		Name:        lxdprofile.Name(model.Name(), "lxd-profile", ch.Revision()),
+		Config:      profile.Config,
+		Description: profile.Description,
+		Devices:     profile.Devices,
+	}
+}
+
+func (s *withoutControllerSuite) TestProvisioningInfoWithCharmLXDProfile(c *gc.C) {
+	expected := s.addLXDProfileUnit(c)
+
+	result, err := s.provisioner.ProvisioningInfo(params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.CharmLXDProfiles, jc.DeepEquals, []params.CharmLXDProfile{expected})
+}
+
+func (s *withoutControllerSuite) TestCharmProfileInfo(c *gc.C) {
+	expected := s.addLXDProfileUnit(c)
+	err := s.machines[0].SetCharmProfiles([]string{"juju-controller-lxd-profile-42"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.newProvisionerV6(c).CharmProfileInfo(params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: s.machines[1].Tag().String()},
+		{Tag: "machine-42"},
+		{Tag: "application-lxd-profile"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CharmProfileInfoResults{
+		Results: []params.CharmProfileInfoResult{
+			{
+				Profiles: []params.CharmLXDProfile{expected},
+				Current:  []string{"juju-controller-lxd-profile-42"},
+			},
+			{},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *withoutControllerSuite) TestSetCharmProfiles(c *gc.C) {
+	result, err := s.newProvisionerV6(c).SetCharmProfiles(params.SetCharmProfilesArgs{
+		Args: []params.SetCharmProfiles{{
+			Entity:   params.Entity{Tag: s.machines[0].Tag().String()},
+			Profiles: []string{"juju-controller-lxd-profile-0"},
+		}, {
+			Entity:   params.Entity{Tag: "machine-42"},
+			Profiles: []string{"juju-controller-lxd-profile-0"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+		},
+	})
+
+	err = s.machines[0].Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machines[0].CharmProfiles(), jc.DeepEquals, []string{"juju-controller-lxd-profile-0"})
+}
+
+func (s *withoutControllerSuite) TestWatchApplicationCharms(c *gc.C) {
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.newProvisionerV6(c).WatchApplicationCharms()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResult{
+		StringsWatcherId: "1",
+		Changes:          []string{"wordpress"},
+	})
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned"
+	// in the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
@@ -266,6 +266,20 @@ func (p *ProvisionerAPI) ContainerManagerConfig(args params.ContainerManagerConf
 		// TODO(jam): Do we want to handle ImageStream here, or do we
 		// hide it from them? (all cached images must come from the
 		// same image stream?)
+	case instance.KVM:
+		config, err := p.m.ModelConfig()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		for key, value := range map[string]string{
+			container.ConfigKVMStoragePool:    config.KVMStoragePool(),
+			container.ConfigKVMMacvtapDevices: config.KVMMacvtapDevices(),
+			container.ConfigKVMSRIOVDevices:   config.KVMSRIOVDevices(),
+		} {
+			if value != "" {
+				cfg[key] = value
+			}
+		}
 	}
 
 	result.ManagerConfig = cfg
@@ -872,11 +886,17 @@ func (ctx *prepareOrGetContext) ProcessOneContainer(env environs.Environ, idx in
 				info.ProviderSubnetId = parentDeviceSubnet.ProviderId()
 				info.VLANTag = parentDeviceSubnet.VLANTag()
 				info.IsDefaultGateway = firstAddress.IsDefaultGateway()
+				info.SpaceName = parentDeviceSubnet.SpaceName()
 			} else {
 				info.ConfigType = network.ConfigDHCP
 				info.CIDR = firstAddress.SubnetCIDR()
 				info.ProviderSubnetId = ""
 				info.VLANTag = 0
+				// The space lets the host pick how to attach the
+				// container's interface, eg using an SR-IOV device.
+				if parentDeviceSubnet, err := firstAddress.Subnet(); err == nil {
+					info.SpaceName = parentDeviceSubnet.SpaceName()
+				}
 			}
 		} else {
 			logger.Infof("host machine device %q has no addresses %v", parentDevice.Name(), parentAddrs)
@@ -905,6 +925,7 @@ func (ctx *prepareOrGetContext) ProcessOneContainer(env environs.Environ, idx in
 			return err
 		}
 		logger.Debugf("got allocated info from provider: %+v", allocatedInfo)
+		copySpaceNames(preparedInfo, allocatedInfo)
 	} else {
 		logger.Debugf("using dhcp allocated addresses")
 	}
@@ -915,6 +936,21 @@ func (ctx *prepareOrGetContext) ProcessOneContainer(env environs.Environ, idx in
 	return nil
 }
 
+// copySpaceNames sets the space name of each allocated interface that
+// the provider did not report one for, from the prepared interface with
+// the same name.
+func copySpaceNames(prepared, allocated []network.InterfaceInfo) {
+	spaces := make(map[string]string)
+	for _, info := range prepared {
+		spaces[info.InterfaceName] = info.SpaceName
+	}
+	for i, info := range allocated {
+		if info.SpaceName == "" {
+			allocated[i].SpaceName = spaces[info.InterfaceName]
+		}
+	}
+}
+
 func (p *ProvisionerAPI) prepareOrGetContainerInterfaceInfo(args params.Entities, maintain bool) (params.MachineNetworkConfigResults, error) {
 	ctx := &prepareOrGetContext{
 		result: params.MachineNetworkConfigResults{
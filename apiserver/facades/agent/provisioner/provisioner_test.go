@@ -1349,6 +1349,26 @@ func (s *withoutControllerSuite) TestContainerManagerConfig(c *gc.C) {
 	})
 }
 
+func (s *withoutControllerSuite) TestContainerManagerConfigKVM(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"kvm-storage-pool":  "nvme",
+		"kvm-sriov-devices": "dmz=enp3s0f0",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg := s.getManagerConfig(c, instance.KVM)
+	c.Assert(cfg, jc.DeepEquals, map[string]string{
+		container.ConfigModelUUID:       coretesting.ModelTag.Id(),
+		container.ConfigKVMStoragePool:  "nvme",
+		container.ConfigKVMSRIOVDevices: "dmz=enp3s0f0",
+	})
+
+	cfg = s.getManagerConfig(c, instance.LXD)
+	c.Assert(cfg, jc.DeepEquals, map[string]string{
+		container.ConfigModelUUID: coretesting.ModelTag.Id(),
+	})
+}
+
 func (s *withoutControllerSuite) TestContainerConfig(c *gc.C) {
 	attrs := map[string]interface{}{
 		"http-proxy":            "http://proxy.example.com:9000",
@@ -96,6 +96,11 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		return nil, errors.Annotate(err, "cannot get controller configuration")
 	}
 
+	charmLXDProfiles, err := p.machineCharmLXDProfiles(m)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get charm LXD profiles")
+	}
+
 	return &params.ProvisioningInfo{
 		Constraints:       cons,
 		Series:            m.Series(),
@@ -108,6 +113,7 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     imageMetadata,
 		ControllerConfig:  controllerCfg,
+		CharmLXDProfiles:  charmLXDProfiles,
 	}, nil
 }
 
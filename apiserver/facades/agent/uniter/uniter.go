@@ -31,7 +31,7 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v7) of the Uniter API.
+// UniterAPI implements the latest version (v8) of the Uniter API.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -53,9 +53,14 @@ type UniterAPI struct {
 	StorageAPI
 }
 
+// UniterAPIV7 doesn't have the LogActionsMessages method.
+type UniterAPIV7 struct {
+	UniterAPI
+}
+
 // UniterAPIV6 adds NetworkInfo as a preferred method to calling NetworkConfig.
 type UniterAPIV6 struct {
-	UniterAPI
+	UniterAPIV7
 }
 
 // UniterAPIV5 returns a RelationResultsV5 instead of RelationResults
@@ -170,14 +175,25 @@ func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade
 	}, nil
 }
 
+// NewUniterAPIV7 creates an instance of the V7 uniter API.
+func NewUniterAPIV7(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV7, error) {
+	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV7{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV6 creates an instance of the V6 uniter API.
 func NewUniterAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV6, error) {
-	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	uniterAPI, err := NewUniterAPIV7(st, resources, authorizer)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV6{
-		UniterAPI: *uniterAPI,
+		UniterAPIV7: *uniterAPI,
 	}, nil
 }
 
@@ -1004,6 +1020,23 @@ func (u *UniterAPI) FinishActions(args params.ActionExecutionResults) (params.Er
 	return common.FinishActions(args, actionFn), nil
 }
 
+// LogActionsMessages records the progress messages logged by running
+// actions, using the action-log hook tool.
+func (u *UniterAPI) LogActionsMessages(args params.ActionMessageParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	m, err := u.st.Model()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, m.ActionByTag)
+	return common.LogActionsMessages(args, actionFn), nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -2190,6 +2223,9 @@ func (u *UniterAPIV4) NetworkInfo(_, _ struct{}) {}
 // WatchUnitRelations isn't on the V4 API.
 func (u *UniterAPIV4) WatchUnitRelations(_, _ struct{}) {}
 
+// LogActionsMessages isn't on the V7 API.
+func (u *UniterAPIV7) LogActionsMessages(_, _ struct{}) {}
+
 func networkInfoResultsToV6(v7Results params.NetworkInfoResults) params.NetworkInfoResultsV6 {
 	results := make(map[string]params.NetworkInfoResultV6)
 	for k, v6Result := range v7Results.Results {
@@ -1665,6 +1665,32 @@ func (s *uniterSuite) TestBeginActions(c *gc.C) {
 	c.Assert(started.After(enqueued) || started.Equal(enqueued), jc.IsTrue, gc.Commentf("started should be after or equal to enqueued time"))
 }
 
+func (s *uniterSuite) TestLogActionsMessages(c *gc.C) {
+	good, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	good, err = good.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	bad, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionMessageParams{Messages: []params.EntityString{
+		{Tag: good.ActionTag().String(), Value: "working"},
+		{Tag: bad.ActionTag().String(), Value: "working"},
+	}}
+	res, err := s.uniter.LogActionsMessages(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{Error: nil},
+		{Error: apiservertesting.ErrUnauthorized},
+	}})
+
+	action, err := s.Model.Action(good.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "working")
+}
+
 func (s *uniterSuite) TestRelation(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	wpEp, err := rel.Endpoint("wordpress")
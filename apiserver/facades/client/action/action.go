@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 )
 
 // ActionAPI implements the client API for interacting with Actions
@@ -23,6 +24,81 @@ type ActionAPI struct {
 	check      *common.BlockChecker
 }
 
+// ActionAPIV6 implements version 6 of the Action API, which lacks
+// action schedules.
+type ActionAPIV6 struct {
+	*ActionAPI
+}
+
+// ActionAPIV5 implements version 5 of the Action API, which lacks
+// machine action scripts.
+type ActionAPIV5 struct {
+	*ActionAPIV6
+}
+
+// ActionAPIV4 implements version 4 of the Action API, which lacks
+// operation queries.
+type ActionAPIV4 struct {
+	*ActionAPIV5
+}
+
+// ActionAPIV3 implements version 3 of the Action API, which lacks
+// action rollouts.
+type ActionAPIV3 struct {
+	*ActionAPIV4
+}
+
+// ActionAPIV2 implements version 2 of the Action API, which lacks
+// WatchActionsProgress.
+type ActionAPIV2 struct {
+	*ActionAPIV3
+}
+
+// NewActionAPIV2 returns an initialized ActionAPIV2.
+func NewActionAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV2, error) {
+	api, err := NewActionAPIV3(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV2{api}, nil
+}
+
+// NewActionAPIV3 returns an initialized ActionAPIV3.
+func NewActionAPIV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV3, error) {
+	api, err := NewActionAPIV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV3{api}, nil
+}
+
+// NewActionAPIV4 returns an initialized ActionAPIV4.
+func NewActionAPIV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV4, error) {
+	api, err := NewActionAPIV5(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV4{api}, nil
+}
+
+// NewActionAPIV5 returns an initialized ActionAPIV5.
+func NewActionAPIV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV5, error) {
+	api, err := NewActionAPIV6(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV5{api}, nil
+}
+
+// NewActionAPIV6 returns an initialized ActionAPIV6.
+func NewActionAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV6, error) {
+	api, err := NewActionAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV6{api}, nil
+}
+
 // NewActionAPI returns an initialized ActionAPI
 func NewActionAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPI, error) {
 	if !authorizer.AuthClient() {
@@ -115,6 +191,36 @@ func (a *ActionAPI) Actions(arg params.Entities) (params.ActionResults, error) {
 	return response, nil
 }
 
+// WatchActionsProgress returns a StringsWatcher for the progress
+// messages logged by each of the given actions. Each change holds
+// JSON-encoded params.ActionMessages.
+func (a *ActionAPI) WatchActionsProgress(arg params.Entities) (params.StringsWatchResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StringsWatchResults{}, errors.Trace(err)
+	}
+
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(arg.Entities)),
+	}
+	for i, entity := range arg.Entities {
+		actionTag, err := names.ParseActionTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		w := a.state.WatchActionLogs(actionTag.Id())
+		// Consume the initial event and forward it to the result.
+		changes, ok := <-w.Changes()
+		if !ok {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
+			continue
+		}
+		results.Results[i].StringsWatcherId = a.resources.Register(w)
+		results.Results[i].Changes = changes
+	}
+	return results, nil
+}
+
 // FindActionTagsByPrefix takes a list of string prefixes and finds
 // corresponding ActionTags that match that prefix.
 func (a *ActionAPI) FindActionTagsByPrefix(arg params.FindTags) (params.FindTagsResults, error) {
@@ -174,7 +280,7 @@ func (a *ActionAPI) FindActionsByNames(arg params.FindActionsByNames) (params.Ac
 // Enqueue takes a list of Actions and queues them up to be executed by
 // the designated ActionReceiver, returning the params.Action for each
 // enqueued Action, or an error if there was a problem enqueueing the
-// Action.
+// Action. The enqueued Actions all belong to a single new operation.
 func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	if err := a.checkCanWrite(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
@@ -184,6 +290,11 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 		return params.ActionResults{}, errors.Trace(err)
 	}
 
+	operation, err := a.model.NewOperationId()
+	if err != nil {
+		return params.ActionResults{}, errors.Trace(err)
+	}
+
 	tagToActionReceiver := common.TagToActionReceiverFn(a.state.FindEntity)
 	response := params.ActionResults{Results: make([]params.ActionResult, len(arg.Actions))}
 	for i, action := range arg.Actions {
@@ -193,7 +304,7 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		enqueued, err := receiver.AddOperationAction(operation, action.Name, action.Parameters)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -404,3 +515,39 @@ func runningActions(ar state.ActionReceiver) ([]params.ActionResult, error) {
 func completedActions(ar state.ActionReceiver) ([]params.ActionResult, error) {
 	return common.ConvertActions(ar, ar.CompletedActions)
 }
+
+// WatchActionsProgress isn't on the V2 API.
+func (*ActionAPIV2) WatchActionsProgress(_, _ struct{}) {}
+
+// AddActionRollouts isn't on the V3 API.
+func (*ActionAPIV3) AddActionRollouts(_, _ struct{}) {}
+
+// ActionRollouts isn't on the V3 API.
+func (*ActionAPIV3) ActionRollouts(_, _ struct{}) {}
+
+// CancelActionRollouts isn't on the V3 API.
+func (*ActionAPIV3) CancelActionRollouts(_, _ struct{}) {}
+
+// ListOperations isn't on the V4 API.
+func (*ActionAPIV4) ListOperations(_, _ struct{}) {}
+
+// Operations isn't on the V4 API.
+func (*ActionAPIV4) Operations(_, _ struct{}) {}
+
+// AddMachineActionScripts isn't on the V5 API.
+func (*ActionAPIV5) AddMachineActionScripts(_, _ struct{}) {}
+
+// RemoveMachineActionScripts isn't on the V5 API.
+func (*ActionAPIV5) RemoveMachineActionScripts(_, _ struct{}) {}
+
+// MachineActionScripts isn't on the V5 API.
+func (*ActionAPIV5) MachineActionScripts(_, _ struct{}) {}
+
+// AddActionSchedules isn't on the V6 API.
+func (*ActionAPIV6) AddActionSchedules(_, _ struct{}) {}
+
+// ActionSchedules isn't on the V6 API.
+func (*ActionAPIV6) ActionSchedules(_, _ struct{}) {}
+
+// RemoveActionSchedules isn't on the V6 API.
+func (*ActionAPIV6) RemoveActionSchedules(_, _ struct{}) {}
@@ -4,6 +4,7 @@
 package action_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -161,6 +162,37 @@ func (s *actionSuite) TestActions(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestWatchActionsProgress(c *gc.C) {
+	api, err := action.NewActionAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("started")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.WatchActionsProgress(params.Entities{Entities: []params.Entity{
+		{Tag: a.ActionTag().String()},
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.StringsWatcherId, gc.Equals, "1")
+	c.Assert(result.Changes, gc.HasLen, 1)
+	var message params.ActionMessage
+	err = json.Unmarshal([]byte(result.Changes[0]), &message)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(message.Message, gc.Equals, "started")
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+
+	c.Assert(results.Results[1].Error, jc.DeepEquals, common.ServerError(common.ErrBadId))
+}
+
 func (s *actionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	// NOTE: full testing with multiple matches has been moved to state package.
 	arg := params.Actions{Actions: []params.Action{{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction", Parameters: map[string]interface{}{}}}}
@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddMachineActionScripts defines actions that can be run on any
+// machine in the model.
+func (a *ActionAPI) AddMachineActionScripts(args params.MachineActionScripts) (params.ErrorResults, error) {
+	if err := a.checkCanAdmin(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Scripts)),
+	}
+	for i, arg := range args.Scripts {
+		_, err := a.model.AddMachineActionScript(state.MachineActionScriptArgs{
+			Name:        arg.Name,
+			Description: arg.Description,
+			Script:      arg.Script,
+			Params:      arg.Params,
+			Required:    arg.Required,
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveMachineActionScripts removes the named machine actions.
+func (a *ActionAPI) RemoveMachineActionScripts(args params.MachineActionScriptNames) (params.ErrorResults, error) {
+	if err := a.checkCanAdmin(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		err := a.model.RemoveMachineActionScript(name)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// MachineActionScripts returns all of the model's machine actions.
+func (a *ActionAPI) MachineActionScripts() (params.MachineActionScripts, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.MachineActionScripts{}, errors.Trace(err)
+	}
+
+	scripts, err := a.model.MachineActionScripts()
+	if err != nil {
+		return params.MachineActionScripts{}, errors.Trace(err)
+	}
+	result := params.MachineActionScripts{
+		Scripts: make([]params.MachineActionScript, len(scripts)),
+	}
+	for i, script := range scripts {
+		result.Scripts[i] = makeMachineActionScript(script)
+	}
+	return result, nil
+}
+
+// makeMachineActionScript unpacks the parameter schema of a machine
+// action's spec into the form it was defined in.
+func makeMachineActionScript(script *state.MachineActionScript) params.MachineActionScript {
+	spec := script.Spec()
+	result := params.MachineActionScript{
+		Name:        script.Name(),
+		Description: spec.Description,
+		Script:      script.Script(),
+	}
+	if properties, ok := spec.Params["properties"].(map[string]interface{}); ok && len(properties) > 0 {
+		result.Params = properties
+	}
+	if required, ok := spec.Params["required"].([]interface{}); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				result.Required = append(result.Required, name)
+			}
+		}
+	}
+	return result
+}
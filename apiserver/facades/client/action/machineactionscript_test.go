@@ -0,0 +1,82 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/action"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+var sosreportScript = params.MachineActionScript{
+	Name:        "sosreport",
+	Description: "Collect a sosreport.",
+	Script:      "#!/bin/sh\nsosreport --batch --case-id \"$1\"\n",
+	Params: map[string]interface{}{
+		"case-id": map[string]interface{}{"type": "string"},
+	},
+	Required: []string{"case-id"},
+}
+
+func (s *actionSuite) TestMachineActionScripts(c *gc.C) {
+	results, err := s.action.AddMachineActionScripts(params.MachineActionScripts{
+		Scripts: []params.MachineActionScript{
+			sosreportScript,
+			{Name: "juju-upgrade", Script: "#!/bin/sh\napt-get -y upgrade\n"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `invalid machine action "juju-upgrade": .*`)
+
+	scripts, err := s.action.MachineActionScripts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scripts.Scripts, jc.DeepEquals, []params.MachineActionScript{sosreportScript})
+
+	enqueued, err := s.action.Enqueue(params.Actions{Actions: []params.Action{{
+		Receiver:   s.machine1.Tag().String(),
+		Name:       "sosreport",
+		Parameters: map[string]interface{}{"case-id": "1234"},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enqueued.Results[0].Error, gc.IsNil)
+	c.Assert(enqueued.Results[0].Action.Receiver, gc.Equals, s.machine1.Tag().String())
+
+	results, err = s.action.RemoveMachineActionScripts(params.MachineActionScriptNames{
+		Names: []string{"sosreport", "sosreport"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `machine action "sosreport" not found`)
+}
+
+func (s *actionSuite) TestAddMachineActionScriptsRequiresAdmin(c *gc.C) {
+	bob := names.NewUserTag("bob")
+	auth := apiservertesting.FakeAuthorizer{Tag: bob, HasWriteTag: bob}
+	api, err := action.NewActionAPI(s.State, nil, auth)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.AddMachineActionScripts(params.MachineActionScripts{
+		Scripts: []params.MachineActionScript{sosreportScript},
+	})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+	_, err = api.RemoveMachineActionScripts(params.MachineActionScriptNames{Names: []string{"sosreport"}})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+}
+
+func (s *actionSuite) TestBlockAddMachineActionScripts(c *gc.C) {
+	s.BlockAllChanges(c, "AddMachineActionScripts")
+	_, err := s.action.AddMachineActionScripts(params.MachineActionScripts{
+		Scripts: []params.MachineActionScript{sosreportScript},
+	})
+	s.AssertBlocked(c, err, "AddMachineActionScripts")
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ListOperations returns the operations that match the query, newest
+// first.
+func (a *ActionAPI) ListOperations(arg params.OperationQueryArgs) (params.OperationResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.OperationResults{}, errors.Trace(err)
+	}
+
+	query := state.OperationQuery{
+		Applications: arg.Applications,
+		ActionNames:  arg.ActionNames,
+		Offset:       arg.Offset,
+		Limit:        arg.Limit,
+	}
+	for _, status := range arg.Status {
+		query.Status = append(query.Status, state.ActionStatus(status))
+	}
+	if arg.From != nil {
+		query.From = *arg.From
+	}
+	if arg.To != nil {
+		query.To = *arg.To
+	}
+	operations, truncated, err := a.model.ListOperations(query)
+	if err != nil {
+		return params.OperationResults{}, errors.Trace(err)
+	}
+	results := params.OperationResults{
+		Results:   make([]params.OperationResult, len(operations)),
+		Truncated: truncated,
+	}
+	for i, op := range operations {
+		results.Results[i] = makeOperationResult(op)
+	}
+	return results, nil
+}
+
+// Operations returns the operations with the given ids.
+func (a *ActionAPI) Operations(arg params.OperationIds) (params.OperationResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.OperationResults{}, errors.Trace(err)
+	}
+
+	results := params.OperationResults{
+		Results: make([]params.OperationResult, len(arg.Ids)),
+	}
+	for i, id := range arg.Ids {
+		op, err := a.model.Operation(id)
+		if err != nil {
+			results.Results[i] = params.OperationResult{
+				Id:    id,
+				Error: common.ServerError(err),
+			}
+			continue
+		}
+		results.Results[i] = makeOperationResult(op)
+	}
+	return results, nil
+}
+
+func makeOperationResult(op *state.Operation) params.OperationResult {
+	result := params.OperationResult{
+		Id:        op.Id(),
+		Status:    string(op.Status()),
+		Enqueued:  op.Enqueued(),
+		Started:   op.Started(),
+		Completed: op.Completed(),
+		Actions:   make([]params.ActionResult, len(op.Actions())),
+	}
+	for i, action := range op.Actions() {
+		receiverTag, err := names.ActionReceiverTag(action.Receiver())
+		if err != nil {
+			result.Actions[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Actions[i] = common.MakeActionResult(receiverTag, action)
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *actionSuite) enqueueOperation(c *gc.C) string {
+	results, err := s.action.Enqueue(params.Actions{Actions: []params.Action{
+		{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+		{Receiver: s.mysqlUnit.Tag().String(), Name: "fakeaction"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	operation := results.Results[0].Action.Operation
+	c.Assert(operation, gc.Not(gc.Equals), "")
+	c.Assert(results.Results[1].Action.Operation, gc.Equals, operation)
+	return operation
+}
+
+func (s *actionSuite) TestEnqueueGroupsActions(c *gc.C) {
+	first := s.enqueueOperation(c)
+	second := s.enqueueOperation(c)
+	c.Assert(first, gc.Not(gc.Equals), second)
+}
+
+func (s *actionSuite) TestOperations(c *gc.C) {
+	operation := s.enqueueOperation(c)
+
+	results, err := s.action.Operations(params.OperationIds{Ids: []string{operation, "42"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Id, gc.Equals, operation)
+	c.Assert(result.Status, gc.Equals, params.ActionPending)
+	c.Assert(result.Actions, gc.HasLen, 2)
+	c.Assert(result.Actions[0].Action.Receiver, gc.Equals, s.mysqlUnit.Tag().String())
+	c.Assert(result.Actions[1].Action.Receiver, gc.Equals, s.wordpressUnit.Tag().String())
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `operation "42" not found`)
+}
+
+func (s *actionSuite) TestListOperations(c *gc.C) {
+	first := s.enqueueOperation(c)
+	second := s.enqueueOperation(c)
+
+	results, err := s.action.ListOperations(params.OperationQueryArgs{
+		Applications: []string{"wordpress"},
+		Limit:        1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Truncated, jc.IsTrue)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Id, gc.Equals, second)
+
+	results, err = s.action.ListOperations(params.OperationQueryArgs{
+		Status: []string{params.ActionPending},
+		Offset: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Truncated, jc.IsFalse)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Id, gc.Equals, first)
+
+	results, err = s.action.ListOperations(params.OperationQueryArgs{
+		Status: []string{params.ActionCompleted},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 0)
+}
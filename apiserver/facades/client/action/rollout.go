@@ -0,0 +1,127 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddActionRollouts runs actions across the units of applications, a
+// batch at a time, returning the rollout created for each.
+func (a *ActionAPI) AddActionRollouts(args params.ActionRolloutsArgs) (params.ActionRolloutResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ActionRolloutResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionRolloutResults{}, errors.Trace(err)
+	}
+
+	results := params.ActionRolloutResults{
+		Results: make([]params.ActionRolloutResult, len(args.Rollouts)),
+	}
+	for i, arg := range args.Rollouts {
+		rollout, err := a.model.AddActionRollout(state.ActionRolloutArgs{
+			Application: arg.Application,
+			Name:        arg.Name,
+			Parameters:  arg.Parameters,
+			MaxParallel: arg.MaxParallel,
+			Order:       state.ActionRolloutOrder(arg.Order),
+			Pause:       arg.Pause,
+			MaxFailures: arg.MaxFailures,
+		})
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result := makeActionRollout(rollout)
+		results.Results[i].Rollout = &result
+	}
+	return results, nil
+}
+
+// ActionRollouts returns the action rollouts with the given ids, or
+// all of the model's rollouts if no ids are given.
+func (a *ActionAPI) ActionRollouts(args params.ActionRolloutIds) (params.ActionRolloutResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionRolloutResults{}, errors.Trace(err)
+	}
+
+	if len(args.Ids) == 0 {
+		rollouts, err := a.model.ActionRollouts()
+		if err != nil {
+			return params.ActionRolloutResults{}, errors.Trace(err)
+		}
+		results := params.ActionRolloutResults{
+			Results: make([]params.ActionRolloutResult, len(rollouts)),
+		}
+		for i, rollout := range rollouts {
+			result := makeActionRollout(rollout)
+			results.Results[i].Rollout = &result
+		}
+		return results, nil
+	}
+
+	results := params.ActionRolloutResults{
+		Results: make([]params.ActionRolloutResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		rollout, err := a.model.ActionRollout(id)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result := makeActionRollout(rollout)
+		results.Results[i].Rollout = &result
+	}
+	return results, nil
+}
+
+// CancelActionRollouts stops the action rollouts with the given ids.
+// Actions that have already started are left to finish.
+func (a *ActionAPI) CancelActionRollouts(args params.ActionRolloutIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		rollout, err := a.model.ActionRollout(id)
+		if err == nil {
+			err = rollout.Cancel()
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func makeActionRollout(rollout *state.ActionRollout) params.ActionRollout {
+	status, message := rollout.Status()
+	return params.ActionRollout{
+		Id:          rollout.Id(),
+		Application: rollout.Application(),
+		Name:        rollout.Name(),
+		Parameters:  rollout.Parameters(),
+		MaxParallel: rollout.MaxParallel(),
+		Order:       string(rollout.Order()),
+		Pause:       rollout.Pause(),
+		MaxFailures: rollout.MaxFailures(),
+		Status:      string(status),
+		Message:     message,
+		Units:       rollout.Units(),
+		Actions:     rollout.Actions(),
+		Failures:    rollout.Failures(),
+		Operation:   rollout.Operation(),
+		Enqueued:    rollout.Enqueued(),
+		Completed:   rollout.Completed(),
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *actionSuite) addRollout(c *gc.C) params.ActionRollout {
+	results, err := s.action.AddActionRollouts(params.ActionRolloutsArgs{
+		Rollouts: []params.ActionRolloutArgs{{
+			Application: "wordpress",
+			Name:        "fakeaction",
+			MaxParallel: 2,
+			Pause:       time.Minute,
+			MaxFailures: 1,
+		}, {
+			Application: "wordpress",
+			Name:        "nonsense",
+			MaxParallel: 1,
+			MaxFailures: 1,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `action "nonsense" not defined for application "wordpress"`)
+	return *results.Results[0].Rollout
+}
+
+func (s *actionSuite) TestAddActionRollouts(c *gc.C) {
+	rollout := s.addRollout(c)
+	c.Assert(rollout.Id, gc.Not(gc.Equals), "")
+	c.Assert(rollout.Application, gc.Equals, "wordpress")
+	c.Assert(rollout.Name, gc.Equals, "fakeaction")
+	c.Assert(rollout.MaxParallel, gc.Equals, 2)
+	c.Assert(rollout.Pause, gc.Equals, time.Minute)
+	c.Assert(rollout.Status, gc.Equals, "running")
+	c.Assert(rollout.Units, jc.DeepEquals, []string{"wordpress/0"})
+}
+
+func (s *actionSuite) TestAddActionRolloutsBlocked(c *gc.C) {
+	s.BlockAllChanges(c, "AddActionRollouts")
+	_, err := s.action.AddActionRollouts(params.ActionRolloutsArgs{})
+	s.AssertBlocked(c, err, "AddActionRollouts")
+}
+
+func (s *actionSuite) TestActionRollouts(c *gc.C) {
+	rollout := s.addRollout(c)
+
+	results, err := s.action.ActionRollouts(params.ActionRolloutIds{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Rollout.Id, gc.Equals, rollout.Id)
+
+	results, err = s.action.ActionRollouts(params.ActionRolloutIds{
+		Ids: []string{rollout.Id, "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Rollout.Id, gc.Equals, rollout.Id)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `action rollout "missing" not found`)
+}
+
+func (s *actionSuite) TestCancelActionRollouts(c *gc.C) {
+	rollout := s.addRollout(c)
+
+	results, err := s.action.CancelActionRollouts(params.ActionRolloutIds{
+		Ids: []string{rollout.Id, "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `action rollout "missing" not found`)
+
+	rollouts, err := s.action.ActionRollouts(params.ActionRolloutIds{Ids: []string{rollout.Id}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollouts.Results[0].Rollout.Status, gc.Equals, "cancelled")
+}
@@ -0,0 +1,122 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddActionSchedules adds schedules that run actions on units at
+// recurring times, returning the schedule created for each.
+func (a *ActionAPI) AddActionSchedules(args params.ActionSchedulesArgs) (params.ActionScheduleResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(args.Schedules)),
+	}
+	for i, arg := range args.Schedules {
+		schedule, err := a.model.AddActionSchedule(state.ActionScheduleArgs{
+			Schedule:     arg.Schedule,
+			Applications: arg.Applications,
+			Units:        arg.Units,
+			Name:         arg.Name,
+			Parameters:   arg.Parameters,
+			Concurrency:  state.ActionScheduleConcurrency(arg.Concurrency),
+		})
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result, err := a.makeActionSchedule(schedule)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Schedule = &result
+	}
+	return results, nil
+}
+
+// ActionSchedules returns all of the model's action schedules, along
+// with the outcome of each one's most recent run.
+func (a *ActionAPI) ActionSchedules() (params.ActionScheduleResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	schedules, err := a.model.ActionSchedules()
+	if err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		result, err := a.makeActionSchedule(schedule)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Schedule = &result
+	}
+	return results, nil
+}
+
+// RemoveActionSchedules removes the action schedules with the given
+// ids. Actions that they have already enqueued are not affected.
+func (a *ActionAPI) RemoveActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		err := a.model.RemoveActionSchedule(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (a *ActionAPI) makeActionSchedule(schedule *state.ActionSchedule) (params.ActionSchedule, error) {
+	result := params.ActionSchedule{
+		Id:            schedule.Id(),
+		Schedule:      schedule.Schedule(),
+		Applications:  schedule.Applications(),
+		Units:         schedule.Units(),
+		Name:          schedule.Name(),
+		Parameters:    schedule.Parameters(),
+		Concurrency:   string(schedule.Concurrency()),
+		Created:       schedule.Created(),
+		NextRun:       schedule.NextRun(),
+		LastRun:       schedule.LastRun(),
+		LastOperation: schedule.LastOperation(),
+		LastMessage:   schedule.LastMessage(),
+	}
+	if result.LastOperation == "" {
+		return result, nil
+	}
+	operation, err := a.model.Operation(result.LastOperation)
+	if errors.IsNotFound(err) {
+		// The operation's actions have been pruned.
+		return result, nil
+	} else if err != nil {
+		return params.ActionSchedule{}, errors.Trace(err)
+	}
+	result.LastStatus = string(operation.Status())
+	return result, nil
+}
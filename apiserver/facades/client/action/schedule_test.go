@@ -0,0 +1,61 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *actionSuite) TestActionSchedules(c *gc.C) {
+	results, err := s.action.AddActionSchedules(params.ActionSchedulesArgs{
+		Schedules: []params.ActionScheduleArgs{{
+			Schedule:     "0 2 * * *",
+			Applications: []string{"wordpress"},
+			Units:        []string{"mysql/0"},
+			Name:         "fakeaction",
+		}, {
+			Schedule:     "0 2 * *",
+			Applications: []string{"wordpress"},
+			Name:         "fakeaction",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	schedule := results.Results[0].Schedule
+	c.Assert(schedule.Schedule, gc.Equals, "0 2 * * *")
+	c.Assert(schedule.Applications, jc.DeepEquals, []string{"wordpress"})
+	c.Assert(schedule.Units, jc.DeepEquals, []string{"mysql/0"})
+	c.Assert(schedule.Concurrency, gc.Equals, "forbid")
+	c.Assert(schedule.LastStatus, gc.Equals, "")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `invalid cron expression .*`)
+
+	listed, err := s.action.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(listed.Results, gc.HasLen, 1)
+	c.Assert(listed.Results[0].Schedule.Id, gc.Equals, schedule.Id)
+
+	removed, err := s.action.RemoveActionSchedules(params.ActionScheduleIds{
+		Ids: []string{schedule.Id, schedule.Id},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed.Results, gc.HasLen, 2)
+	c.Assert(removed.Results[0].Error, gc.IsNil)
+	c.Assert(removed.Results[1].Error, gc.ErrorMatches, `action schedule ".*" not found`)
+}
+
+func (s *actionSuite) TestAddActionSchedulesBlocked(c *gc.C) {
+	s.BlockAllChanges(c, "AddActionSchedules")
+	_, err := s.action.AddActionSchedules(params.ActionSchedulesArgs{})
+	s.AssertBlocked(c, err, "AddActionSchedules")
+}
+
+func (s *actionSuite) TestRemoveActionSchedulesBlocked(c *gc.C) {
+	s.BlockRemoveObject(c, "RemoveActionSchedules")
+	_, err := s.action.RemoveActionSchedules(params.ActionScheduleIds{})
+	s.AssertBlocked(c, err, "RemoveActionSchedules")
+}
@@ -0,0 +1,240 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package apitokens provides the facade used to manage the API tokens
+// that allow automated clients restricted, revocable access to models
+// without holding a user's password.
+package apitokens
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.apitokens")
+
+// API provides the APITokens facade.
+type API struct {
+	backend     Backend
+	authorizer  facade.Authorizer
+	mint        MacaroonMinter
+	clock       clock.Clock
+	apiUser     names.UserTag
+	isSuperuser bool
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	mint, err := NewStateMacaroonMinter(ctx.State())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(NewStateBackend(ctx.State()), ctx.Auth(), mint, clock.WallClock)
+}
+
+// NewAPI returns a new APITokens facade.
+func NewAPI(
+	backend Backend,
+	authorizer facade.Authorizer,
+	mint MacaroonMinter,
+	clock clock.Clock,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	apiUser, _ := authorizer.GetAuthTag().(names.UserTag)
+	isSuperuser, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{
+		backend:     backend,
+		authorizer:  authorizer,
+		mint:        mint,
+		clock:       clock,
+		apiUser:     apiUser,
+		isSuperuser: isSuperuser,
+	}, nil
+}
+
+// AddAPITokens creates API tokens owned by the authenticated user. A
+// user may only issue tokens for models they have at least the
+// requested access to.
+func (api *API) AddAPITokens(args params.AddAPITokens) (params.AddAPITokenResults, error) {
+	results := params.AddAPITokenResults{
+		Results: make([]params.AddAPITokenResult, len(args.Tokens)),
+	}
+	for i, arg := range args.Tokens {
+		token, m, err := api.addAPIToken(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		apiToken := apiTokenFromState(token)
+		results.Results[i].Token = &apiToken
+		results.Results[i].Macaroon = macaroon.Slice{m}
+	}
+	return results, nil
+}
+
+func (api *API) addAPIToken(arg params.AddAPIToken) (APIToken, *macaroon.Macaroon, error) {
+	access := permission.Access(arg.Access)
+	if err := permission.ValidateModelAccess(access); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if !arg.Expires.After(api.clock.Now()) {
+		return nil, nil, errors.NotValidf("expiry time %s in the past", arg.Expires.Format(time.RFC3339))
+	}
+	modelUUIDs := make([]string, len(arg.ModelTags))
+	for i, tagString := range arg.ModelTags {
+		modelTag, err := names.ParseModelTag(tagString)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if err := api.checkModelAccess(modelTag, access); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		modelUUIDs[i] = modelTag.Id()
+	}
+	token, err := api.backend.AddAPIToken(state.AddAPITokenArgs{
+		Owner:       api.apiUser,
+		Description: arg.Description,
+		Models:      modelUUIDs,
+		Access:      access,
+		Facades:     arg.Facades,
+		Expires:     arg.Expires,
+	})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	m, err := api.mint(token.Id(), token.Owner(), token.Expires())
+	if err != nil {
+		// The token is useless without its macaroon, so
+		// make sure it can never be used.
+		if err := token.Revoke(); err != nil {
+			logger.Errorf("cannot revoke unusable API token %q: %v", token.Id(), err)
+		}
+		return nil, nil, errors.Trace(err)
+	}
+	logger.Infof("user %q issued API token %q", api.apiUser.Id(), token.Id())
+	return token, m, nil
+}
+
+// checkModelAccess checks that the authenticated user may issue a token
+// granting the specified access to the model.
+func (api *API) checkModelAccess(modelTag names.ModelTag, access permission.Access) error {
+	if api.isSuperuser {
+		exists, err := api.backend.ModelExists(modelTag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !exists {
+			return errors.NotFoundf("model %q", modelTag.Id())
+		}
+		return nil
+	}
+	ok, err := api.authorizer.HasPermission(access, modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ListAPITokens returns the API tokens owned by each of the given
+// users. Only controller superusers may list tokens owned by other
+// users.
+func (api *API) ListAPITokens(args params.Entities) (params.APITokensResults, error) {
+	results := params.APITokensResults{
+		Results: make([]params.APITokensResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tokens, err := api.listAPITokens(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Tokens = tokens
+	}
+	return results, nil
+}
+
+func (api *API) listAPITokens(tagString string) ([]params.APIToken, error) {
+	owner, err := names.ParseUserTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if owner != api.apiUser && !api.isSuperuser {
+		return nil, common.ErrPerm
+	}
+	tokens, err := api.backend.APITokens(owner)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.APIToken, len(tokens))
+	for i, token := range tokens {
+		result[i] = apiTokenFromState(token)
+	}
+	return result, nil
+}
+
+// RevokeAPITokens revokes the API tokens with the given ids. Users may
+// revoke their own tokens; controller superusers may revoke any token.
+func (api *API) RevokeAPITokens(args params.RevokeAPITokens) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		results.Results[i].Error = common.ServerError(api.revokeAPIToken(id))
+	}
+	return results, nil
+}
+
+func (api *API) revokeAPIToken(id string) error {
+	token, err := api.backend.APIToken(id)
+	if errors.IsNotFound(err) && !api.isSuperuser {
+		// Don't reveal the existence of other users' tokens.
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if token.Owner() != api.apiUser && !api.isSuperuser {
+		return common.ErrPerm
+	}
+	if err := token.Revoke(); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("user %q revoked API token %q", api.apiUser.Id(), id)
+	return nil
+}
+
+func apiTokenFromState(token APIToken) params.APIToken {
+	modelTags := make([]string, len(token.Models()))
+	for i, uuid := range token.Models() {
+		modelTags[i] = names.NewModelTag(uuid).String()
+	}
+	return params.APIToken{
+		Id:          token.Id(),
+		Owner:       token.Owner().String(),
+		Description: token.Description(),
+		ModelTags:   modelTags,
+		Access:      string(token.Access()),
+		Facades:     token.Facades(),
+		Created:     token.Created(),
+		Expires:     token.Expires(),
+		Revoked:     token.Revoked(),
+	}
+}
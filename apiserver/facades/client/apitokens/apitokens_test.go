@@ -0,0 +1,238 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apitokens_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/apitokens"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type APITokensSuite struct {
+	testing.IsolationSuite
+
+	clock      *testing.Clock
+	backend    *mockBackend
+	minter     *mockMinter
+	authorizer apiservertesting.FakeAuthorizer
+	api        *apitokens.API
+}
+
+var _ = gc.Suite(&APITokensSuite{})
+
+func (s *APITokensSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(coretesting.NonZeroTime())
+	s.backend = &mockBackend{
+		models: map[string]bool{coretesting.ModelTag.Id(): true},
+		tokens: make(map[string]*mockAPIToken),
+	}
+	s.minter = &mockMinter{}
+	s.setAPIUser(c, names.NewUserTag("read-"+coretesting.ModelTag.String()))
+}
+
+func (s *APITokensSuite) setAPIUser(c *gc.C, user names.UserTag) {
+	s.authorizer = apiservertesting.FakeAuthorizer{Tag: user}
+	api, err := apitokens.NewAPI(s.backend, s.authorizer, s.minter.mint, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *APITokensSuite) TestNewAPIRequiresClient(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")}
+	_, err := apitokens.NewAPI(s.backend, authorizer, s.minter.mint, s.clock)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *APITokensSuite) TestAddAPITokens(c *gc.C) {
+	expires := s.clock.Now().Add(time.Hour)
+	results, err := s.api.AddAPITokens(params.AddAPITokens{
+		Tokens: []params.AddAPIToken{{
+			Description: "ci",
+			ModelTags:   []string{coretesting.ModelTag.String()},
+			Access:      "read",
+			Facades:     []string{"Client"},
+			Expires:     expires,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Macaroon, gc.HasLen, 1)
+	c.Assert(result.Macaroon[0].Id(), gc.Equals, "token-0")
+	c.Assert(result.Token, jc.DeepEquals, &params.APIToken{
+		Id:          "token-0",
+		Owner:       s.authorizer.Tag.String(),
+		Description: "ci",
+		ModelTags:   []string{coretesting.ModelTag.String()},
+		Access:      "read",
+		Facades:     []string{"Client"},
+		Created:     coretesting.NonZeroTime(),
+		Expires:     expires,
+	})
+
+	s.backend.CheckCallNames(c, "AddAPIToken")
+	s.backend.CheckCall(c, 0, "AddAPIToken", state.AddAPITokenArgs{
+		Owner:       s.authorizer.Tag.(names.UserTag),
+		Description: "ci",
+		Models:      []string{coretesting.ModelTag.Id()},
+		Access:      permission.ReadAccess,
+		Facades:     []string{"Client"},
+		Expires:     expires,
+	})
+	s.minter.CheckCall(c, 0, "Mint", "token-0", s.authorizer.Tag, expires)
+}
+
+func (s *APITokensSuite) TestAddAPITokensExceedsUserAccess(c *gc.C) {
+	results, err := s.api.AddAPITokens(params.AddAPITokens{
+		Tokens: []params.AddAPIToken{{
+			ModelTags: []string{coretesting.ModelTag.String()},
+			Access:    "write",
+			Expires:   s.clock.Now().Add(time.Hour),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *APITokensSuite) TestAddAPITokensInvalid(c *gc.C) {
+	results, err := s.api.AddAPITokens(params.AddAPITokens{
+		Tokens: []params.AddAPIToken{{
+			ModelTags: []string{coretesting.ModelTag.String()},
+			Access:    "superuser",
+			Expires:   s.clock.Now().Add(time.Hour),
+		}, {
+			ModelTags: []string{coretesting.ModelTag.String()},
+			Access:    "read",
+			Expires:   s.clock.Now().Add(-time.Hour),
+		}, {
+			ModelTags: []string{"machine-0"},
+			Access:    "read",
+			Expires:   s.clock.Now().Add(time.Hour),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"superuser" model access not valid`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `expiry time .* in the past not valid`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *APITokensSuite) TestAddAPITokensSuperuser(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("superuser-bob"))
+	results, err := s.api.AddAPITokens(params.AddAPITokens{
+		Tokens: []params.AddAPIToken{{
+			ModelTags: []string{coretesting.ModelTag.String()},
+			Access:    "admin",
+			Expires:   s.clock.Now().Add(time.Hour),
+		}, {
+			ModelTags: []string{names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f666").String()},
+			Access:    "admin",
+			Expires:   s.clock.Now().Add(time.Hour),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `model "deadbeef-0bad-400d-8000-4b1d0d06f666" not found`)
+}
+
+func (s *APITokensSuite) TestAddAPITokensMintFailureRevokes(c *gc.C) {
+	s.minter.SetErrors(errors.New("boom"))
+	results, err := s.api.AddAPITokens(params.AddAPITokens{
+		Tokens: []params.AddAPIToken{{
+			ModelTags: []string{coretesting.ModelTag.String()},
+			Access:    "read",
+			Expires:   s.clock.Now().Add(time.Hour),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "boom")
+	c.Assert(s.backend.tokens["token-0"].revoked, jc.IsTrue)
+}
+
+func (s *APITokensSuite) addToken(owner names.UserTag) *mockAPIToken {
+	token := &mockAPIToken{
+		id:      "token-" + owner.Id(),
+		owner:   owner,
+		models:  []string{coretesting.ModelTag.Id()},
+		access:  permission.ReadAccess,
+		created: coretesting.NonZeroTime(),
+		expires: coretesting.NonZeroTime().Add(time.Hour),
+	}
+	s.backend.tokens[token.id] = token
+	return token
+}
+
+func (s *APITokensSuite) TestListAPITokens(c *gc.C) {
+	s.addToken(s.authorizer.Tag.(names.UserTag))
+	s.addToken(names.NewUserTag("mary"))
+
+	results, err := s.api.ListAPITokens(params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.authorizer.Tag.String()},
+			{Tag: "user-mary"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Tokens, gc.HasLen, 1)
+	c.Assert(results.Results[0].Tokens[0].ModelTags, jc.DeepEquals, []string{coretesting.ModelTag.String()})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "permission denied")
+}
+
+func (s *APITokensSuite) TestListAPITokensSuperuser(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("superuser-bob"))
+	s.addToken(names.NewUserTag("mary"))
+
+	results, err := s.api.ListAPITokens(params.Entities{
+		Entities: []params.Entity{{Tag: "user-mary"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Tokens, gc.HasLen, 1)
+	c.Assert(results.Results[0].Tokens[0].Owner, gc.Equals, "user-mary")
+}
+
+func (s *APITokensSuite) TestRevokeAPITokens(c *gc.C) {
+	own := s.addToken(s.authorizer.Tag.(names.UserTag))
+	other := s.addToken(names.NewUserTag("mary"))
+
+	results, err := s.api.RevokeAPITokens(params.RevokeAPITokens{
+		Ids: []string{own.id, other.id, "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(own.revoked, jc.IsTrue)
+	c.Assert(other.revoked, jc.IsFalse)
+}
+
+func (s *APITokensSuite) TestRevokeAPITokensSuperuser(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("superuser-bob"))
+	other := s.addToken(names.NewUserTag("mary"))
+
+	results, err := s.api.RevokeAPITokens(params.RevokeAPITokens{
+		Ids: []string{other.id, "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `API token "missing" not found`)
+	c.Assert(other.revoked, jc.IsTrue)
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apitokens
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon-bakery.v1/bakery"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the apitokens
+// facade. For details on the methods, see the methods on state.State
+// with the same names.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	ModelExists(uuid string) (bool, error)
+	AddAPIToken(state.AddAPITokenArgs) (APIToken, error)
+	APIToken(id string) (APIToken, error)
+	APITokens(owner names.UserTag) ([]APIToken, error)
+}
+
+// APIToken defines the API token functionality required by the
+// apitokens facade. It is implemented by *state.APIToken.
+type APIToken interface {
+	Id() string
+	Owner() names.UserTag
+	Description() string
+	Models() []string
+	Access() permission.Access
+	Facades() []string
+	Created() time.Time
+	Expires() time.Time
+	Revoked() bool
+	Revoke() error
+}
+
+// MacaroonMinter mints the macaroon handed to the client as proof of
+// holding the API token with the given id.
+type MacaroonMinter func(tokenID string, owner names.UserTag, expires time.Time) (*macaroon.Macaroon, error)
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) AddAPIToken(args state.AddAPITokenArgs) (APIToken, error) {
+	token, err := s.State.AddAPIToken(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return token, nil
+}
+
+func (s stateShim) APIToken(id string) (APIToken, error) {
+	token, err := s.State.APIToken(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return token, nil
+}
+
+func (s stateShim) APITokens(owner names.UserTag) ([]APIToken, error) {
+	tokens, err := s.State.APITokens(owner)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]APIToken, len(tokens))
+	for i, t := range tokens {
+		result[i] = t
+	}
+	return result, nil
+}
+
+// NewStateMacaroonMinter returns a MacaroonMinter that stores the
+// macaroon root keys in the controller's bakery storage, so that the
+// API server's local user authenticator can verify them. The root keys
+// are removed from storage once the token expires.
+func NewStateMacaroonMinter(st *state.State) (MacaroonMinter, error) {
+	store, err := st.NewBakeryStorage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	location := "juju model " + st.ControllerModelUUID()
+	return func(tokenID string, owner names.UserTag, expires time.Time) (*macaroon.Macaroon, error) {
+		service, err := bakery.NewService(bakery.NewServiceParams{
+			Location: location,
+			Store:    store.ExpireAt(expires),
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return authentication.NewAPITokenMacaroon(service, tokenID, owner, expires)
+	}, nil
+}
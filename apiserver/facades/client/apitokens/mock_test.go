@@ -0,0 +1,119 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apitokens_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/apitokens"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	jtesting.Stub
+	models map[string]bool
+	tokens map[string]*mockAPIToken
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) ModelExists(uuid string) (bool, error) {
+	b.MethodCall(b, "ModelExists", uuid)
+	return b.models[uuid], b.NextErr()
+}
+
+func (b *mockBackend) AddAPIToken(args state.AddAPITokenArgs) (apitokens.APIToken, error) {
+	b.MethodCall(b, "AddAPIToken", args)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	token := &mockAPIToken{
+		id:          fmt.Sprintf("token-%d", len(b.tokens)),
+		owner:       args.Owner,
+		description: args.Description,
+		models:      args.Models,
+		access:      args.Access,
+		facades:     args.Facades,
+		created:     coretesting.NonZeroTime(),
+		expires:     args.Expires,
+	}
+	b.tokens[token.id] = token
+	return token, nil
+}
+
+func (b *mockBackend) APIToken(id string) (apitokens.APIToken, error) {
+	b.MethodCall(b, "APIToken", id)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	token, ok := b.tokens[id]
+	if !ok {
+		return nil, errors.NotFoundf("API token %q", id)
+	}
+	return token, nil
+}
+
+func (b *mockBackend) APITokens(owner names.UserTag) ([]apitokens.APIToken, error) {
+	b.MethodCall(b, "APITokens", owner)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	var result []apitokens.APIToken
+	for _, token := range b.tokens {
+		if token.owner == owner {
+			result = append(result, token)
+		}
+	}
+	return result, nil
+}
+
+type mockAPIToken struct {
+	apitokens.APIToken
+	id          string
+	owner       names.UserTag
+	description string
+	models      []string
+	access      permission.Access
+	facades     []string
+	created     time.Time
+	expires     time.Time
+	revoked     bool
+}
+
+func (t *mockAPIToken) Id() string                { return t.id }
+func (t *mockAPIToken) Owner() names.UserTag      { return t.owner }
+func (t *mockAPIToken) Description() string       { return t.description }
+func (t *mockAPIToken) Models() []string          { return t.models }
+func (t *mockAPIToken) Access() permission.Access { return t.access }
+func (t *mockAPIToken) Facades() []string         { return t.facades }
+func (t *mockAPIToken) Created() time.Time        { return t.created }
+func (t *mockAPIToken) Expires() time.Time        { return t.expires }
+func (t *mockAPIToken) Revoked() bool             { return t.revoked }
+
+func (t *mockAPIToken) Revoke() error {
+	t.revoked = true
+	return nil
+}
+
+type mockMinter struct {
+	jtesting.Stub
+}
+
+func (m *mockMinter) mint(tokenID string, owner names.UserTag, expires time.Time) (*macaroon.Macaroon, error) {
+	m.MethodCall(m, "Mint", tokenID, owner, expires)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return macaroon.New([]byte("root-key"), tokenID, "juju")
+}
@@ -211,7 +211,7 @@ func (api *APIv5) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, e
 
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return environschema.Fields{}, schema.Defaults{}, nil
+		return application.IAASConfigSchema, schema.Defaults{}, nil
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -954,60 +954,63 @@ func (api *APIv5) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyUni
 	if err := api.check.RemoveAllowed(); err != nil {
 		return params.DestroyUnitResults{}, errors.Trace(err)
 	}
-	destroyUnit := func(arg params.DestroyUnitParams) (*params.DestroyUnitInfo, error) {
-		unitTag, err := names.ParseUnitTag(arg.UnitTag)
+	results := make([]params.DestroyUnitResult, len(args.Units))
+	for i, entity := range args.Units {
+		info, err := destroyUnit(api.backend, entity)
 		if err != nil {
-			return nil, errors.Trace(err)
+			results[i].Error = common.ServerError(err)
+			continue
 		}
-		name := unitTag.Id()
-		unit, err := api.backend.Unit(name)
-		if errors.IsNotFound(err) {
-			return nil, errors.Errorf("unit %q does not exist", name)
-		} else if err != nil {
+		results[i].Info = info
+	}
+	return params.DestroyUnitResults{results}, nil
+}
+
+// destroyUnit destroys the unit described by arg, and returns the
+// storage that will be destroyed or detached along with it.
+func destroyUnit(backend Backend, arg params.DestroyUnitParams) (*params.DestroyUnitInfo, error) {
+	unitTag, err := names.ParseUnitTag(arg.UnitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	name := unitTag.Id()
+	unit, err := backend.Unit(name)
+	if errors.IsNotFound(err) {
+		return nil, errors.Errorf("unit %q does not exist", name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !unit.IsPrincipal() {
+		return nil, errors.Errorf("unit %q is a subordinate", name)
+	}
+	var info params.DestroyUnitInfo
+	if backend.ModelType() == state.ModelTypeIAAS {
+		storage, err := storagecommon.UnitStorage(backend, unit.UnitTag())
+		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		if !unit.IsPrincipal() {
-			return nil, errors.Errorf("unit %q is a subordinate", name)
-		}
-		var info params.DestroyUnitInfo
-		if api.backend.ModelType() == state.ModelTypeIAAS {
-			storage, err := storagecommon.UnitStorage(api.backend, unit.UnitTag())
+		if arg.DestroyStorage {
+			for _, s := range storage {
+				info.DestroyedStorage = append(
+					info.DestroyedStorage,
+					params.Entity{s.StorageTag().String()},
+				)
+			}
+		} else {
+			info.DestroyedStorage, info.DetachedStorage, err = storagecommon.ClassifyDetachedStorage(
+				backend, storage,
+			)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
-			if arg.DestroyStorage {
-				for _, s := range storage {
-					info.DestroyedStorage = append(
-						info.DestroyedStorage,
-						params.Entity{s.StorageTag().String()},
-					)
-				}
-			} else {
-				info.DestroyedStorage, info.DetachedStorage, err = storagecommon.ClassifyDetachedStorage(
-					api.backend, storage,
-				)
-				if err != nil {
-					return nil, errors.Trace(err)
-				}
-			}
 		}
-		op := unit.DestroyOperation()
-		op.DestroyStorage = arg.DestroyStorage
-		if err := api.backend.ApplyOperation(op); err != nil {
-			return nil, errors.Trace(err)
-		}
-		return &info, nil
 	}
-	results := make([]params.DestroyUnitResult, len(args.Units))
-	for i, entity := range args.Units {
-		info, err := destroyUnit(entity)
-		if err != nil {
-			results[i].Error = common.ServerError(err)
-			continue
-		}
-		results[i].Info = info
+	op := unit.DestroyOperation()
+	op.DestroyStorage = arg.DestroyStorage
+	if err := backend.ApplyOperation(op); err != nil {
+		return nil, errors.Trace(err)
 	}
-	return params.DestroyUnitResults{results}, nil
+	return &info, nil
 }
 
 // Destroy destroys a given application, local or remote.
@@ -1993,6 +1993,26 @@ func (s *applicationSuite) TestBlockChangeAddApplicationUnits(c *gc.C) {
 	s.assertAddApplicationUnitsBlocked(c, "TestBlockChangeAddApplicationUnits")
 }
 
+func (s *applicationSuite) TestAddUnitsFunc(c *gc.C) {
+	s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	unitNames, err := application.AddUnits(s.State, "dummy", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitNames, jc.DeepEquals, []string{"dummy/0", "dummy/1"})
+	for _, name := range unitNames {
+		unit, err := s.State.Unit(name)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = unit.AssignedMachineId()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *applicationSuite) TestAddUnitsFuncBlocked(c *gc.C) {
+	s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.BlockAllChanges(c, "TestAddUnitsFuncBlocked")
+	_, err := application.AddUnits(s.State, "dummy", 2)
+	s.AssertBlocked(c, err, "TestAddUnitsFuncBlocked")
+}
+
 func (s *applicationSuite) TestAddUnitToMachineNotFound(c *gc.C) {
 	s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
 	_, err := s.applicationAPI.AddUnits(params.AddApplicationUnits{
@@ -2422,6 +2442,22 @@ func (s *applicationSuite) TestBlockDestroyDestroyPrincipalUnits(c *gc.C) {
 	assertLife(c, units[1], state.Dying)
 }
 
+func (s *applicationSuite) TestDestroyUnitsFunc(c *gc.C) {
+	units := s.setupDestroyPrincipalUnits(c)
+	err := application.DestroyUnits(s.State, "wordpress/0", "wordpress/1")
+	c.Assert(err, jc.ErrorIsNil)
+	assertLife(c, units[0], state.Dying)
+	assertLife(c, units[1], state.Dying)
+	assertLife(c, units[2], state.Alive)
+}
+
+func (s *applicationSuite) TestDestroyUnitsFuncBlocked(c *gc.C) {
+	units := s.setupDestroyPrincipalUnits(c)
+	s.BlockRemoveObject(c, "TestDestroyUnitsFuncBlocked")
+	err := application.DestroyUnits(s.State, "wordpress/0", "wordpress/1")
+	s.assertBlockedErrorAndLiveliness(c, err, "TestDestroyUnitsFuncBlocked", units[0], units[1], units[2], units[3])
+}
+
 func (s *applicationSuite) assertDestroySubordinateUnits(c *gc.C, wordpress0, logging0 *state.Unit) {
 	// Try to destroy the principal and the subordinate together; check it warns
 	// about the subordinate, but destroys the one it can. (The principal unit
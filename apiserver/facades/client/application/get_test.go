@@ -9,6 +9,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/environschema.v1"
 
 	apiapplication "github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/common"
@@ -25,6 +26,8 @@ import (
 	"github.com/juju/juju/testing/factory"
 )
 
+var replaceLostMachinesDescription = coreapplication.IAASConfigSchema[coreapplication.ReplaceLostMachinesKey].Description
+
 type getSuite struct {
 	jujutesting.JujuConnSuite
 
@@ -112,8 +115,14 @@ func (s *getSuite) TestClientApplicationGetIAASModelSmoketest(c *gc.C) {
 				"value":       "My Title",
 			},
 		},
-		ApplicationConfig: map[string]interface{}{},
-		Series:            "quantal",
+		ApplicationConfig: map[string]interface{}{
+			"replace-lost-machines": map[string]interface{}{
+				"description": replaceLostMachinesDescription,
+				"source":      "unset",
+				"type":        environschema.Tbool,
+			},
+		},
+		Series: "quantal",
 	})
 }
 
@@ -330,6 +339,13 @@ func (s *getSuite) TestApplicationGet(c *gc.C) {
 		expect.Constraints = constraintsv
 		expect.Application = app.Name()
 		expect.Charm = ch.Meta().Name
+		expect.ApplicationConfig = map[string]interface{}{
+			"replace-lost-machines": map[string]interface{}{
+				"description": replaceLostMachinesDescription,
+				"source":      "unset",
+				"type":        "bool",
+			},
+		}
 		client := apiapplication.NewClient(s.APIState)
 		got, err := client.Get(app.Name())
 		c.Assert(err, jc.ErrorIsNil)
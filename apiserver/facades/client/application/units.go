@@ -0,0 +1,58 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddUnits adds n units to the named application, the same way as the
+// AddUnits API call, and returns the names of the units added. It fails
+// if changes to the model are blocked.
+func AddUnits(st *state.State, appName string, n int) ([]string, error) {
+	if err := common.NewBlockChecker(st).ChangeAllowed(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	backend, err := NewStateBackend(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := addApplicationUnits(backend, params.AddApplicationUnits{
+		ApplicationName: appName,
+		NumUnits:        n,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitNames := make([]string, len(units))
+	for i, unit := range units {
+		unitNames[i] = unit.UnitTag().Id()
+	}
+	return unitNames, nil
+}
+
+// DestroyUnits destroys the named units, the same way as the
+// DestroyUnit API call, leaving their storage. It fails if removals
+// from the model are blocked.
+func DestroyUnits(st *state.State, unitNames ...string) error {
+	if err := common.NewBlockChecker(st).RemoveAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	backend, err := NewStateBackend(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range unitNames {
+		arg := params.DestroyUnitParams{UnitTag: names.NewUnitTag(name).String()}
+		if _, err := destroyUnit(backend, arg); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
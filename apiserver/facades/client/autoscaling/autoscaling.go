@@ -0,0 +1,179 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package autoscaling provides the facade used to manage the policies
+// by which applications are scaled in response to their units' metrics.
+package autoscaling
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// API provides the Autoscaling facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	check      BlockChecker
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(
+		NewStateBackend(ctx.State()),
+		ctx.Auth(),
+		common.NewBlockChecker(ctx.State()),
+	)
+}
+
+// NewAPI returns a new Autoscaling facade.
+func NewAPI(
+	backend Backend,
+	authorizer facade.Authorizer,
+	blockChecker BlockChecker,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		check:      blockChecker,
+	}, nil
+}
+
+func (api *API) checkPermission(perm permission.Access) error {
+	allowed, err := api.authorizer.HasPermission(perm, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+func (api *API) application(tagString string) (Application, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return api.backend.Application(tag.Id())
+}
+
+// SetAutoscalingPolicies sets the policies by which the specified
+// applications are scaled.
+func (api *API) SetAutoscalingPolicies(args params.SetAutoscalingPolicies) (params.ErrorResults, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Policies))
+	for i, arg := range args.Policies {
+		app, err := api.application(arg.ApplicationTag)
+		if err == nil {
+			err = app.SetAutoscalingPolicy(state.AutoscalingPolicy{
+				MetricKey: arg.Policy.MetricKey,
+				Target:    arg.Policy.Target,
+				MinUnits:  arg.Policy.MinUnits,
+				MaxUnits:  arg.Policy.MaxUnits,
+				Cooldown:  arg.Policy.Cooldown,
+			})
+		}
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// AutoscalingPolicies returns the autoscaling policies of the specified
+// applications.
+func (api *API) AutoscalingPolicies(args params.Entities) (params.AutoscalingPolicyResults, error) {
+	if err := api.checkPermission(permission.ReadAccess); err != nil {
+		return params.AutoscalingPolicyResults{}, errors.Trace(err)
+	}
+	results := make([]params.AutoscalingPolicyResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		app, err := api.application(arg.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		policy, err := app.AutoscalingPolicy()
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = &params.AutoscalingPolicy{
+			MetricKey: policy.MetricKey,
+			Target:    policy.Target,
+			MinUnits:  policy.MinUnits,
+			MaxUnits:  policy.MaxUnits,
+			Cooldown:  policy.Cooldown,
+		}
+	}
+	return params.AutoscalingPolicyResults{Results: results}, nil
+}
+
+// RemoveAutoscalingPolicies removes the autoscaling policies of the
+// specified applications. The applications' units are left as they are.
+func (api *API) RemoveAutoscalingPolicies(args params.Entities) (params.ErrorResults, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		app, err := api.application(arg.Tag)
+		if err == nil {
+			err = app.RemoveAutoscalingPolicy()
+		}
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// AutoscalingDecisions returns the decisions made by the autoscaling
+// policies of the specified applications, most recent first.
+func (api *API) AutoscalingDecisions(args params.Entities) (params.AutoscalingDecisionsResults, error) {
+	if err := api.checkPermission(permission.ReadAccess); err != nil {
+		return params.AutoscalingDecisionsResults{}, errors.Trace(err)
+	}
+	results := make([]params.AutoscalingDecisionsResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		app, err := api.application(arg.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		decisions, err := app.AutoscalingDecisions()
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Decisions = make([]params.AutoscalingDecision, len(decisions))
+		for j, d := range decisions {
+			results[i].Decisions[j] = params.AutoscalingDecision{
+				Time:           d.Time,
+				MetricKey:      d.MetricKey,
+				Target:         d.Target,
+				MetricValue:    d.MetricValue,
+				ReportingUnits: d.ReportingUnits,
+				FromUnits:      d.FromUnits,
+				ToUnits:        d.ToUnits,
+				Units:          d.Units,
+				Error:          d.Error,
+			}
+		}
+	}
+	return params.AutoscalingDecisionsResults{Results: results}, nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscaling_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/autoscaling"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type AutoscalingSuite struct {
+	testing.IsolationSuite
+
+	backend      *mockBackend
+	app          *mockApplication
+	blockChecker mockBlockChecker
+	authorizer   apiservertesting.FakeAuthorizer
+	api          *autoscaling.API
+}
+
+var _ = gc.Suite(&AutoscalingSuite{})
+
+func (s *AutoscalingSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.app = &mockApplication{}
+	s.backend = &mockBackend{
+		applications: map[string]*mockApplication{"mysql": s.app},
+	}
+	s.blockChecker = mockBlockChecker{}
+	s.setAPIUser(c, names.NewUserTag("admin"))
+}
+
+func (s *AutoscalingSuite) setAPIUser(c *gc.C, user names.UserTag) {
+	s.authorizer = apiservertesting.FakeAuthorizer{Tag: user}
+	api, err := autoscaling.NewAPI(s.backend, s.authorizer, &s.blockChecker)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *AutoscalingSuite) TestNewAPIRequiresClient(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")}
+	_, err := autoscaling.NewAPI(s.backend, authorizer, &s.blockChecker)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *AutoscalingSuite) TestSetAutoscalingPolicies(c *gc.C) {
+	policy := params.AutoscalingPolicy{
+		MetricKey: "load",
+		Target:    0.7,
+		MinUnits:  1,
+		MaxUnits:  5,
+		Cooldown:  5 * time.Minute,
+	}
+	results, err := s.api.SetAutoscalingPolicies(params.SetAutoscalingPolicies{
+		Policies: []params.SetAutoscalingPolicy{
+			{ApplicationTag: "application-mysql", Policy: policy},
+			{ApplicationTag: "application-foo", Policy: policy},
+			{ApplicationTag: "machine-0", Policy: policy},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "foo" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid application tag`)
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.app.CheckCall(c, 0, "SetAutoscalingPolicy", state.AutoscalingPolicy{
+		MetricKey: "load",
+		Target:    0.7,
+		MinUnits:  1,
+		MaxUnits:  5,
+		Cooldown:  5 * time.Minute,
+	})
+}
+
+func (s *AutoscalingSuite) TestSetAutoscalingPoliciesBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetAutoscalingPolicies(params.SetAutoscalingPolicies{
+		Policies: []params.SetAutoscalingPolicy{{ApplicationTag: "application-mysql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.app.CheckNoCalls(c)
+}
+
+func (s *AutoscalingSuite) TestSetAutoscalingPoliciesPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("read"))
+	_, err := s.api.SetAutoscalingPolicies(params.SetAutoscalingPolicies{
+		Policies: []params.SetAutoscalingPolicy{{ApplicationTag: "application-mysql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.app.CheckNoCalls(c)
+}
+
+func (s *AutoscalingSuite) TestAutoscalingPolicies(c *gc.C) {
+	s.app.policy = &state.AutoscalingPolicy{
+		MetricKey: "load",
+		Target:    0.7,
+		MinUnits:  1,
+		MaxUnits:  5,
+		Cooldown:  time.Minute,
+	}
+	s.backend.applications["wordpress"] = &mockApplication{}
+	results, err := s.api.AutoscalingPolicies(params.Entities{
+		Entities: []params.Entity{{"application-mysql"}, {"application-wordpress"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.AutoscalingPolicyResults{
+		Results: []params.AutoscalingPolicyResult{{
+			Result: &params.AutoscalingPolicy{
+				MetricKey: "load",
+				Target:    0.7,
+				MinUnits:  1,
+				MaxUnits:  5,
+				Cooldown:  time.Minute,
+			},
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: "autoscaling policy not found",
+			},
+		}},
+	})
+}
+
+func (s *AutoscalingSuite) TestRemoveAutoscalingPolicies(c *gc.C) {
+	s.app.policy = &state.AutoscalingPolicy{MetricKey: "load"}
+	results, err := s.api.RemoveAutoscalingPolicies(params.Entities{
+		Entities: []params.Entity{{"application-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	c.Assert(s.app.policy, gc.IsNil)
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.app.CheckCallNames(c, "RemoveAutoscalingPolicy")
+}
+
+func (s *AutoscalingSuite) TestAutoscalingDecisions(c *gc.C) {
+	when := time.Date(2018, time.March, 1, 10, 0, 0, 0, time.UTC)
+	s.app.decisions = []state.AutoscalingDecision{{
+		Time:           when,
+		MetricKey:      "load",
+		Target:         0.7,
+		MetricValue:    1.4,
+		ReportingUnits: 2,
+		FromUnits:      2,
+		ToUnits:        4,
+		Units:          []string{"mysql/2", "mysql/3"},
+	}}
+	s.setAPIUser(c, names.NewUserTag("read"))
+	results, err := s.api.AutoscalingDecisions(params.Entities{
+		Entities: []params.Entity{{"application-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.AutoscalingDecisionsResults{
+		Results: []params.AutoscalingDecisionsResult{{
+			Decisions: []params.AutoscalingDecision{{
+				Time:           when,
+				MetricKey:      "load",
+				Target:         0.7,
+				MetricValue:    1.4,
+				ReportingUnits: 2,
+				FromUnits:      2,
+				ToUnits:        4,
+				Units:          []string{"mysql/2", "mysql/3"},
+			}},
+		}},
+	})
+}
@@ -0,0 +1,52 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscaling
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the autoscaling
+// facade. For details on the methods, see the methods on state.State
+// with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	Application(name string) (Application, error)
+}
+
+// Application defines the application functionality required by the
+// autoscaling facade. It is implemented by *state.Application.
+type Application interface {
+	SetAutoscalingPolicy(state.AutoscalingPolicy) error
+	AutoscalingPolicy() (state.AutoscalingPolicy, error)
+	RemoveAutoscalingPolicy() error
+	AutoscalingDecisions() ([]state.AutoscalingDecision, error)
+}
+
+// BlockChecker defines the block-checking functionality required by
+// the autoscaling facade. This is implemented by
+// apiserver/common.BlockChecker.
+type BlockChecker interface {
+	ChangeAllowed() error
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) Application(name string) (Application, error) {
+	app, err := s.State.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app, nil
+}
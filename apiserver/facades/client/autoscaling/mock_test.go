@@ -0,0 +1,78 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscaling_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/autoscaling"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	testing.Stub
+	applications map[string]*mockApplication
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) Application(name string) (autoscaling.Application, error) {
+	b.MethodCall(b, "Application", name)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	app, ok := b.applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
+type mockApplication struct {
+	testing.Stub
+	policy    *state.AutoscalingPolicy
+	decisions []state.AutoscalingDecision
+}
+
+func (a *mockApplication) SetAutoscalingPolicy(policy state.AutoscalingPolicy) error {
+	a.MethodCall(a, "SetAutoscalingPolicy", policy)
+	if err := a.NextErr(); err != nil {
+		return err
+	}
+	a.policy = &policy
+	return nil
+}
+
+func (a *mockApplication) AutoscalingPolicy() (state.AutoscalingPolicy, error) {
+	a.MethodCall(a, "AutoscalingPolicy")
+	if a.policy == nil {
+		return state.AutoscalingPolicy{}, errors.NotFoundf("autoscaling policy")
+	}
+	return *a.policy, a.NextErr()
+}
+
+func (a *mockApplication) RemoveAutoscalingPolicy() error {
+	a.MethodCall(a, "RemoveAutoscalingPolicy")
+	a.policy = nil
+	return a.NextErr()
+}
+
+func (a *mockApplication) AutoscalingDecisions() ([]state.AutoscalingDecision, error) {
+	a.MethodCall(a, "AutoscalingDecisions")
+	return a.decisions, a.NextErr()
+}
+
+type mockBlockChecker struct {
+	testing.Stub
+}
+
+func (c *mockBlockChecker) ChangeAllowed() error {
+	c.MethodCall(c, "ChangeAllowed")
+	return c.NextErr()
+}
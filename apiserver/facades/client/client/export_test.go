@@ -4,6 +4,7 @@
 package client
 
 import (
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs"
 )
 
@@ -16,3 +17,21 @@ var (
 func SetNewEnviron(c *Client, newEnviron func() (environs.Environ, error)) {
 	c.newEnviron = newEnviron
 }
+
+// ParseStatusFilters parses the given filter expressions as
+// FullStatus does.
+func ParseStatusFilters(filters []string) error {
+	_, err := parseStatusFilters(filters)
+	return err
+}
+
+// ProjectStatus clears the fields of the status not selected by the
+// given field paths, as FullStatus does.
+func ProjectStatus(fs *params.FullStatus, fields []string) error {
+	parsedFields, err := parseStatusFields(fields)
+	if err != nil {
+		return err
+	}
+	projectStatus(fs, parsedFields)
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/set"
@@ -46,7 +47,28 @@ func (s byTime) Swap(i, j int) {
 	s[i], s[j] = s[j], s[i]
 }
 func (s byTime) Less(i, j int) bool {
-	return s[i].Since.Before(*s[j].Since)
+	return timeBefore(s[i].Since, s[j].Since)
+}
+
+// timeBefore reports whether a is before b. A missing time is
+// before any other.
+func timeBefore(a, b *time.Time) bool {
+	if a == nil {
+		return b != nil
+	}
+	if b == nil {
+		return false
+	}
+	return a.Before(*b)
+}
+
+// sameTime reports whether a and b are the same time, or are both
+// missing.
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
 }
 
 // unitStatusHistory returns a list of status history entries for unit agents or workloads.
@@ -110,6 +132,7 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 		filter := status.StatusHistoryFilter{
 			Size:     request.Filter.Size,
 			FromDate: request.Filter.Date,
+			ToDate:   request.Filter.ToDate,
 			Delta:    request.Filter.Delta,
 			Exclude:  set.NewStrings(request.Filter.Exclude...),
 		}
@@ -162,6 +185,183 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 	return results
 }
 
+// timelineKinds holds the status kinds that may be requested from
+// StatusHistoryTimeline, and the kinds each expands to.
+var timelineKinds = map[string][]status.HistoryKind{
+	status.KindUnit.String():              {status.KindWorkload, status.KindUnitAgent},
+	status.KindWorkload.String():          {status.KindWorkload},
+	status.KindUnitAgent.String():         {status.KindUnitAgent},
+	status.KindMachine.String():           {status.KindMachine},
+	status.KindMachineInstance.String():   {status.KindMachineInstance},
+	status.KindContainer.String():         {status.KindContainer},
+	status.KindContainerInstance.String(): {status.KindContainerInstance},
+}
+
+const (
+	// defaultTimelineLimit is the number of entries returned by
+	// StatusHistoryTimeline when no limit is given.
+	defaultTimelineLimit = 1000
+
+	// maxTimelineLimit is the largest limit that may be given to
+	// StatusHistoryTimeline.
+	maxTimelineLimit = 10000
+)
+
+// StatusHistoryTimeline returns the status history of many units and
+// machines, or of every one in the model, within a period of time,
+// merged and ordered by time. At most the given limit of entries is
+// returned; if there are more, the result's Next time is set, from
+// which the rest may be fetched.
+func (c *Client) StatusHistoryTimeline(args params.StatusHistoryTimelineArgs) (params.StatusHistoryTimelineResult, error) {
+	var result params.StatusHistoryTimelineResult
+	if err := c.checkCanRead(); err != nil {
+		return result, err
+	}
+	if args.From.IsZero() {
+		return result, errors.NotValidf("missing start of period")
+	}
+	limit := args.Limit
+	if limit == 0 {
+		limit = defaultTimelineLimit
+	}
+	if limit < 0 || limit > maxTimelineLimit {
+		return result, errors.NotValidf("limit %d", args.Limit)
+	}
+	filter := status.StatusHistoryFilter{
+		FromDate: &args.From,
+		ToDate:   args.To,
+		Exclude:  set.NewStrings(args.Exclude...),
+		// Each query fetches one more entry than the limit, so
+		// that we know whether the timeline was truncated.
+		Limit: limit + 1,
+	}
+	if err := filter.Validate(); err != nil {
+		return result, errors.Annotate(err, "cannot validate status history filter")
+	}
+	kinds := make(map[status.HistoryKind]bool)
+	for _, k := range args.Kinds {
+		expanded, ok := timelineKinds[k]
+		if !ok {
+			return result, errors.NotValidf("status history kind %q", k)
+		}
+		for _, kind := range expanded {
+			kinds[kind] = true
+		}
+	}
+	wanted := func(kind status.HistoryKind) bool {
+		return len(kinds) == 0 || kinds[kind]
+	}
+
+	tags := args.Entities
+	if len(tags) == 0 {
+		var err error
+		if tags, err = c.modelHistoryEntities(); err != nil {
+			return result, errors.Trace(err)
+		}
+	}
+	for _, tag := range tags {
+		entityTag, err := names.ParseTag(tag)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		var hist []params.DetailedStatus
+		switch t := entityTag.(type) {
+		case names.UnitTag:
+			for _, kind := range []status.HistoryKind{status.KindWorkload, status.KindUnitAgent} {
+				if !wanted(kind) {
+					continue
+				}
+				statuses, err := c.unitStatusHistory(t, filter, kind)
+				if err != nil {
+					return result, errors.Annotatef(err, "fetching status history for %q", tag)
+				}
+				hist = append(hist, statuses...)
+			}
+		case names.MachineTag:
+			machineKinds := []status.HistoryKind{status.KindMachine, status.KindMachineInstance}
+			if names.IsContainerMachine(t.Id()) {
+				machineKinds = []status.HistoryKind{status.KindContainer, status.KindContainerInstance}
+			}
+			for _, kind := range machineKinds {
+				if !wanted(kind) {
+					continue
+				}
+				statuses, err := c.machineStatusHistory(t, filter, kind)
+				if err != nil {
+					return result, errors.Annotatef(err, "fetching status history for %q", tag)
+				}
+				hist = append(hist, statuses...)
+			}
+		default:
+			return result, errors.NotValidf("status history entity %q", tag)
+		}
+		for _, s := range hist {
+			result.Entries = append(result.Entries, params.StatusHistoryEntry{
+				Entity: tag,
+				Kind:   s.Kind,
+				Status: s.Status,
+				Info:   s.Info,
+				Data:   s.Data,
+				Since:  s.Since,
+			})
+		}
+	}
+	sort.SliceStable(result.Entries, func(i, j int) bool {
+		return timeBefore(result.Entries[i].Since, result.Entries[j].Since)
+	})
+	if len(result.Entries) > limit {
+		entries, err := truncateTimeline(result.Entries, limit)
+		if err != nil {
+			return params.StatusHistoryTimelineResult{}, errors.Trace(err)
+		}
+		result.Entries = entries
+		result.Next = entries[len(entries)-1].Since
+	}
+	return result, nil
+}
+
+// truncateTimeline returns at most the first limit entries. Entries
+// that share their time with the first entry beyond the limit are
+// dropped too, so that none are lost when the timeline is continued
+// from the time of the last entry returned.
+func truncateTimeline(entries []params.StatusHistoryEntry, limit int) ([]params.StatusHistoryEntry, error) {
+	n := limit
+	for n > 0 && sameTime(entries[n-1].Since, entries[limit].Since) {
+		n--
+	}
+	if n == 0 || entries[n-1].Since == nil {
+		return nil, errors.Errorf("more than %d status history entries at the same time", limit)
+	}
+	return entries[:n], nil
+}
+
+// modelHistoryEntities returns the tags of every unit and machine in
+// the model.
+func (c *Client) modelHistoryEntities() ([]string, error) {
+	var tags []string
+	applications, err := c.api.stateAccessor.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, app := range applications {
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			tags = append(tags, unit.Tag().String())
+		}
+	}
+	machines, err := c.api.stateAccessor.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, m := range machines {
+		tags = append(tags, m.Tag().String())
+	}
+	return tags, nil
+}
+
 // FullStatus gives the information needed for juju status over the api
 func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error) {
 	if err := c.checkCanRead(); err != nil {
@@ -169,8 +369,15 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	}
 
 	var noStatus params.FullStatus
+	filters, err := parseStatusFilters(args.Filters)
+	if err != nil {
+		return noStatus, errors.Trace(err)
+	}
+	fields, err := parseStatusFields(args.Fields)
+	if err != nil {
+		return noStatus, errors.Trace(err)
+	}
 	var context statusContext
-	var err error
 	if context.model, err = c.api.stateAccessor.Model(); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch model")
 	}
@@ -309,18 +516,47 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		}
 	}
 
+	var zones map[string]string
+	if needsZones(filters) {
+		if zones, err = fetchZones(context.machines); err != nil {
+			return noStatus, errors.Annotate(err, "could not fetch availability zones")
+		}
+	}
+
+	context.filter(filters, zones)
+
 	modelStatus, err := c.modelStatus()
 	if err != nil {
 		return noStatus, errors.Annotate(err, "cannot determine model status")
 	}
-	return params.FullStatus{
+	result := params.FullStatus{
 		Model:              modelStatus,
 		Machines:           context.processMachines(),
 		Applications:       context.processApplications(),
 		RemoteApplications: context.processRemoteApplications(),
 		Offers:             context.processOffers(),
 		Relations:          context.processRelations(),
-	}, nil
+	}
+	projectStatus(&result, fields)
+	return result, nil
+}
+
+// fetchZones returns the availability zone of each provisioned machine
+// in the given machine lists, keyed by machine id.
+func fetchZones(machines map[string][]*state.Machine) (map[string]string, error) {
+	zones := make(map[string]string)
+	for _, machineList := range machines {
+		for _, m := range machineList {
+			zone, err := m.AvailabilityZone()
+			if errors.IsNotProvisioned(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			zones[m.Id()] = zone
+		}
+	}
+	return zones, nil
 }
 
 // newToolsVersionAvailable will return a string representing a tools
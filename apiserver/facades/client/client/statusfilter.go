@@ -0,0 +1,530 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// statusFilter is a parsed status filter expression, of the form
+// <field><operator><value>.
+type statusFilter struct {
+	field string
+	op    string
+	value string
+	re    *regexp.Regexp
+	n     int
+}
+
+// statusFilterOps holds the operators a filter may use, longest first
+// so that "<=" is not taken for "<".
+var statusFilterOps = []string{"==", "!=", "~=", "<=", ">=", "<", ">", "="}
+
+// statusFilterFieldOps holds the operators valid for each field.
+var statusFilterFieldOps = map[string][]string{
+	"status":    {"==", "!="},
+	"message":   {"==", "!=", "~="},
+	"zone":      {"==", "!="},
+	"charm-rev": {"==", "!=", "<", "<=", ">", ">="},
+}
+
+// parseStatusFilters parses the given filter expressions.
+func parseStatusFilters(exprs []string) ([]statusFilter, error) {
+	var filters []statusFilter
+	for _, expr := range exprs {
+		f, err := parseStatusFilter(expr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+func parseStatusFilter(expr string) (statusFilter, error) {
+	var f statusFilter
+	at := -1
+	for _, op := range statusFilterOps {
+		if i := strings.Index(expr, op); i > 0 && (at == -1 || i < at) {
+			at, f.op = i, op
+		}
+	}
+	if at == -1 {
+		return f, errors.NotValidf("status filter %q", expr)
+	}
+	f.field = strings.TrimSpace(expr[:at])
+	f.value = strings.TrimSpace(expr[at+len(f.op):])
+	if f.op == "=" {
+		f.op = "=="
+	}
+	ops, ok := statusFilterFieldOps[f.field]
+	if !ok {
+		return f, errors.NotValidf("status filter field %q", f.field)
+	}
+	if !containsString(ops, f.op) {
+		return f, errors.NotValidf("operator %q for status filter field %q", f.op, f.field)
+	}
+	switch {
+	case f.op == "~=":
+		re, err := regexp.Compile(f.value)
+		if err != nil {
+			return f, errors.Annotatef(err, "status filter %q", expr)
+		}
+		f.re = re
+	case f.field == "charm-rev":
+		n, err := strconv.Atoi(f.value)
+		if err != nil {
+			return f, errors.NotValidf("charm revision %q", f.value)
+		}
+		f.n = n
+	}
+	return f, nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// filterSubject holds the attributes of an entity in the status that
+// filters are evaluated against. An entity's attributes may not all
+// apply to it, in which case it never matches filters on them.
+type filterSubject struct {
+	statuses []string
+	messages []string
+	zone     *string
+	charmRev *int
+}
+
+// match reports whether the subject satisfies the filter.
+func (f statusFilter) match(s filterSubject) bool {
+	switch f.field {
+	case "status":
+		return f.matchString(s.statuses)
+	case "message":
+		return f.matchString(s.messages)
+	case "zone":
+		if s.zone == nil {
+			return false
+		}
+		return f.matchString([]string{*s.zone})
+	case "charm-rev":
+		if s.charmRev == nil {
+			return false
+		}
+		rev := *s.charmRev
+		switch f.op {
+		case "==":
+			return rev == f.n
+		case "!=":
+			return rev != f.n
+		case "<":
+			return rev < f.n
+		case "<=":
+			return rev <= f.n
+		case ">":
+			return rev > f.n
+		case ">=":
+			return rev >= f.n
+		}
+	}
+	return false
+}
+
+// matchString reports whether any of the values satisfies an equality
+// or regular expression filter, or, for "!=", whether none of them
+// equals the filter's value.
+func (f statusFilter) matchString(values []string) bool {
+	if len(values) == 0 {
+		return false
+	}
+	for _, v := range values {
+		switch f.op {
+		case "==":
+			if v == f.value {
+				return true
+			}
+		case "!=":
+			if v == f.value {
+				return false
+			}
+		case "~=":
+			if f.re.MatchString(v) {
+				return true
+			}
+		}
+	}
+	return f.op == "!="
+}
+
+// matchAll reports whether the subject satisfies every filter.
+func matchAll(filters []statusFilter, s filterSubject) bool {
+	for _, f := range filters {
+		if !f.match(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// needsZones reports whether any of the filters refers to availability
+// zones, which are costly to fetch.
+func needsZones(filters []statusFilter) bool {
+	for _, f := range filters {
+		if f.field == "zone" {
+			return true
+		}
+	}
+	return false
+}
+
+// filter removes from the status context every entity that does not
+// satisfy all of the filters, and is not needed to show one that does,
+// so that the status of the others is never built. Units are matched
+// on their workload and agent status, workload message, the zone of
+// their machine and the revision of their charm. A principal unit is
+// kept with all of its subordinates if it matches, and with just the
+// matching ones otherwise. An application is kept if it or any of its
+// units match, and a machine if it matches or hosts a kept unit or
+// container. Relations, remote applications and offers are kept if
+// they involve a kept application. zones maps machine ids to
+// availability zones.
+func (context *statusContext) filter(filters []statusFilter, zones map[string]string) {
+	if len(filters) == 0 {
+		return
+	}
+	zone := func(machineId string) *string {
+		if z, ok := zones[machineId]; ok {
+			return &z
+		}
+		return nil
+	}
+	keptUnits := make(map[string]bool)
+	keptApps := make(map[string]bool)
+	keptMachines := make(map[string]bool)
+
+	for appName, units := range context.units {
+		app := context.applications[appName]
+		if app == nil || !app.IsPrincipal() {
+			// Subordinates are matched with their principals.
+			continue
+		}
+		for name, unit := range units {
+			machineId, err := unit.AssignedMachineId()
+			if err != nil {
+				machineId = ""
+			}
+			matches := matchAll(filters, context.unitSubject(unit, zone(machineId)))
+			var subs []string
+			for _, subName := range unit.SubordinateNames() {
+				sub := context.unitByName(subName)
+				if sub == nil {
+					continue
+				}
+				if matches || matchAll(filters, context.unitSubject(sub, zone(machineId))) {
+					subs = append(subs, subName)
+				}
+			}
+			if !matches && len(subs) == 0 {
+				continue
+			}
+			keptUnits[name] = true
+			keptApps[appName] = true
+			for _, subName := range subs {
+				keptUnits[subName] = true
+				keptApps[unitApplication(subName)] = true
+			}
+			if machineId != "" {
+				keptMachines[machineId] = true
+			}
+		}
+	}
+
+	for appName, app := range context.applications {
+		if !keptApps[appName] && matchAll(filters, context.applicationSubject(app)) {
+			keptApps[appName] = true
+		}
+	}
+	for appName := range context.applications {
+		if !keptApps[appName] {
+			delete(context.applications, appName)
+			delete(context.units, appName)
+			continue
+		}
+		for name := range context.units[appName] {
+			if !keptUnits[name] {
+				delete(context.units[appName], name)
+			}
+		}
+	}
+
+	for _, machineList := range context.machines {
+		for _, m := range machineList {
+			if keptMachines[m.Id()] || !matchAll(filters, context.machineSubject(m, zone(m.Id()))) {
+				continue
+			}
+			keptMachines[m.Id()] = true
+		}
+	}
+	// Keep the hosts of kept containers, so that they can be shown.
+	for id := range keptMachines {
+		for parent := state.ParentId(id); parent != ""; parent = state.ParentId(parent) {
+			keptMachines[parent] = true
+		}
+	}
+	for id, machineList := range context.machines {
+		var kept []*state.Machine
+		for _, m := range machineList {
+			if keptMachines[m.Id()] {
+				kept = append(kept, m)
+			}
+		}
+		if len(kept) == 0 {
+			delete(context.machines, id)
+			continue
+		}
+		context.machines[id] = kept
+	}
+
+	for name, relations := range context.relations {
+		var kept []*state.Relation
+		for _, rel := range relations {
+			if relationInvolves(rel, keptApps) {
+				kept = append(kept, rel)
+			}
+		}
+		if len(kept) == 0 {
+			delete(context.relations, name)
+			continue
+		}
+		context.relations[name] = kept
+	}
+	for id, rel := range context.relationsById {
+		if !relationInvolves(rel, keptApps) {
+			delete(context.relationsById, id)
+		}
+	}
+	for name := range context.consumerRemoteApplications {
+		if len(context.relations[name]) == 0 {
+			delete(context.consumerRemoteApplications, name)
+		}
+	}
+	for name, offer := range context.offers {
+		if !keptApps[offer.ApplicationName] {
+			delete(context.offers, name)
+		}
+	}
+}
+
+// unitSubject returns the attributes of the unit that filters are
+// evaluated against. zone is the availability zone of the unit's
+// machine, if known.
+func (context *statusContext) unitSubject(unit *state.Unit, zone *string) filterSubject {
+	var s filterSubject
+	agent, workload := common.UnitStatus(&contextUnit{unit, context})
+	if agent.Err == nil {
+		s.statuses = append(s.statuses, agent.Status.Status.String())
+	}
+	if workload.Err == nil {
+		s.statuses = append(s.statuses, workload.Status.Status.String())
+		s.messages = append(s.messages, workload.Status.Message)
+	}
+	s.zone = zone
+	curl, _ := unit.CharmURL()
+	if curl == nil {
+		if app := context.applications[unit.ApplicationName()]; app != nil {
+			curl, _ = app.CharmURL()
+		}
+	}
+	s.charmRev = charmRevision(curl)
+	return s
+}
+
+// applicationSubject returns the attributes of the application that
+// filters are evaluated against.
+func (context *statusContext) applicationSubject(app *state.Application) filterSubject {
+	var s filterSubject
+	var unitNames []string
+	for name := range context.units[app.Name()] {
+		unitNames = append(unitNames, name)
+	}
+	if info, err := context.status.Application(app.Name(), unitNames); err == nil {
+		s.statuses = []string{info.Status.String()}
+		s.messages = []string{info.Message}
+	}
+	curl, _ := app.CharmURL()
+	s.charmRev = charmRevision(curl)
+	return s
+}
+
+// machineSubject returns the attributes of the machine that filters
+// are evaluated against. zone is the machine's availability zone, if
+// known.
+func (context *statusContext) machineSubject(m *state.Machine, zone *string) filterSubject {
+	var s filterSubject
+	if info, err := common.MachineStatus(&contextMachine{m, context}); err == nil {
+		s.statuses = append(s.statuses, info.Status.String())
+		s.messages = append(s.messages, info.Message)
+	}
+	if info, err := context.status.MachineInstance(m.Id()); err == nil {
+		s.statuses = append(s.statuses, info.Status.String())
+		s.messages = append(s.messages, info.Message)
+	}
+	s.zone = zone
+	return s
+}
+
+// relationInvolves reports whether any of the relation's endpoints
+// belongs to one of the given applications.
+func relationInvolves(rel *state.Relation, apps map[string]bool) bool {
+	for _, ep := range rel.Endpoints() {
+		if apps[ep.ApplicationName] {
+			return true
+		}
+	}
+	return false
+}
+
+// charmRevision returns the revision in the given charm URL, or nil if
+// it has none.
+func charmRevision(curl *charm.URL) *int {
+	if curl == nil || curl.Revision < 0 {
+		return nil
+	}
+	rev := curl.Revision
+	return &rev
+}
+
+// unitApplication returns the application name part of a unit name.
+func unitApplication(unitName string) string {
+	return strings.SplitN(unitName, "/", 2)[0]
+}
+
+// statusFields is a tree of status field names, as given in their
+// JSON tags. A nil subtree selects the whole field.
+type statusFields map[string]statusFields
+
+// parseStatusFields parses dotted field paths, such as
+// "applications.units.workload-status", checking each against the
+// structure of params.FullStatus. Maps are traversed transparently, so
+// that "applications.status" selects the status of every application.
+func parseStatusFields(paths []string) (statusFields, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	fields := make(statusFields)
+	for _, path := range paths {
+		t := reflect.TypeOf(params.FullStatus{})
+		node := fields
+		parts := strings.Split(path, ".")
+		last := len(parts) - 1
+		// covered is set once an earlier path selects the whole of
+		// a field that this one refines; the rest of the path is
+		// then only checked.
+		covered := false
+		for i, part := range parts {
+			field, ok := jsonField(t, part)
+			if !ok {
+				return nil, errors.NotValidf("status field %q", path)
+			}
+			if !covered {
+				sub, seen := node[part]
+				switch {
+				case i == last:
+					node[part] = nil
+				case seen && sub == nil:
+					covered = true
+				case !seen:
+					sub = make(statusFields)
+					node[part] = sub
+					fallthrough
+				default:
+					node = sub
+				}
+			}
+			if i < last {
+				if t = elemStruct(field.Type); t == nil {
+					return nil, errors.NotValidf("status field %q", path)
+				}
+			}
+		}
+	}
+	return fields, nil
+}
+
+// jsonField returns the field of the struct type t whose JSON name is
+// name.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if strings.Split(f.Tag.Get("json"), ",")[0] == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// elemStruct returns the struct type held by t, directly or as the
+// elements of a map or slice, or nil if there is none.
+func elemStruct(t reflect.Type) reflect.Type {
+	switch t.Kind() {
+	case reflect.Map, reflect.Slice:
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+// projectStatus clears every field of the status not selected by
+// fields, so that it is not serialised. If fields is empty the status
+// is left untouched.
+func projectStatus(fs *params.FullStatus, fields statusFields) {
+	if len(fields) == 0 {
+		return
+	}
+	projectValue(reflect.ValueOf(fs).Elem(), fields)
+}
+
+func projectValue(v reflect.Value, fields statusFields) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			name := strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0]
+			sub, ok := fields[name]
+			switch {
+			case !ok:
+				v.Field(i).Set(reflect.Zero(v.Field(i).Type()))
+			case sub != nil:
+				projectValue(v.Field(i), sub)
+			}
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			projectValue(elem, fields)
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			projectValue(v.Index(i), fields)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"sort"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/client"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing/factory"
+)
+
+type statusFilterSuite struct{}
+
+var _ = gc.Suite(&statusFilterSuite{})
+
+func detailed(status, info string) params.DetailedStatus {
+	return params.DetailedStatus{Status: status, Info: info}
+}
+
+func sampleStatus() params.FullStatus {
+	return params.FullStatus{
+		Model: params.ModelStatusInfo{Name: "default"},
+		Machines: map[string]params.MachineStatus{
+			"0": {Id: "0", AgentStatus: detailed("started", "")},
+			"1": {
+				Id:          "1",
+				AgentStatus: detailed("started", ""),
+				Containers: map[string]params.MachineStatus{
+					"1/lxd/0": {Id: "1/lxd/0", AgentStatus: detailed("pending", "")},
+				},
+			},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm:  "cs:mysql-5",
+				Series: "xenial",
+				Status: detailed("error", "hook failed: install"),
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {
+						Machine:        "0",
+						WorkloadStatus: detailed("active", ""),
+						AgentStatus:    detailed("idle", ""),
+					},
+					"mysql/1": {
+						Machine:        "1",
+						WorkloadStatus: detailed("error", "hook failed: install"),
+						AgentStatus:    detailed("idle", ""),
+						Subordinates: map[string]params.UnitStatus{
+							"logging/0": {
+								WorkloadStatus: detailed("active", ""),
+								AgentStatus:    detailed("idle", ""),
+							},
+						},
+					},
+				},
+			},
+			"logging": {
+				Charm:         "cs:logging-12",
+				SubordinateTo: []string{"mysql"},
+				Status:        detailed("active", ""),
+			},
+			"wordpress": {
+				Charm:  "cs:wordpress-20",
+				Status: detailed("waiting", "waiting for db"),
+				Units: map[string]params.UnitStatus{
+					"wordpress/0": {
+						Machine:        "1/lxd/0",
+						WorkloadStatus: detailed("waiting", "waiting for db"),
+						AgentStatus:    detailed("idle", ""),
+					},
+				},
+			},
+		},
+		Relations: []params.RelationStatus{{
+			Id: 0,
+			Endpoints: []params.EndpointStatus{
+				{ApplicationName: "mysql"}, {ApplicationName: "wordpress"},
+			},
+		}, {
+			Id: 1,
+			Endpoints: []params.EndpointStatus{
+				{ApplicationName: "mysql"}, {ApplicationName: "logging"},
+			},
+		}},
+		Offers: map[string]params.ApplicationOfferStatus{
+			"hosted-mysql": {ApplicationName: "mysql"},
+		},
+	}
+}
+
+type statusFilterStateSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&statusFilterStateSuite{})
+
+type statusSummary struct {
+	machines     []string
+	applications []string
+	units        []string
+	relations    int
+}
+
+func summarise(fs *params.FullStatus) statusSummary {
+	var s statusSummary
+	var addMachines func(map[string]params.MachineStatus)
+	addMachines = func(machines map[string]params.MachineStatus) {
+		for id, m := range machines {
+			s.machines = append(s.machines, id)
+			addMachines(m.Containers)
+		}
+	}
+	addMachines(fs.Machines)
+	var addUnits func(map[string]params.UnitStatus)
+	addUnits = func(units map[string]params.UnitStatus) {
+		for name, u := range units {
+			s.units = append(s.units, name)
+			addUnits(u.Subordinates)
+		}
+	}
+	for name, app := range fs.Applications {
+		s.applications = append(s.applications, name)
+		addUnits(app.Units)
+	}
+	s.relations = len(fs.Relations)
+	sort.Strings(s.machines)
+	sort.Strings(s.applications)
+	sort.Strings(s.units)
+	return s
+}
+
+func (s *statusFilterStateSuite) TestFilters(c *gc.C) {
+	now := time.Now()
+	zoneA, zoneB := "zone-a", "zone-b"
+	m0 := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Characteristics: &instance.HardwareCharacteristics{AvailabilityZone: &zoneA},
+	})
+	m1 := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Characteristics: &instance.HardwareCharacteristics{AvailabilityZone: &zoneB},
+	})
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql, Machine: m0})
+	failed := s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql, Machine: m1})
+	err := failed.SetAgentStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "hook failed: install",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: wordpress,
+		Status: &status.StatusInfo{
+			Status:  status.Waiting,
+			Message: "waiting for db",
+			Since:   &now,
+		},
+	})
+	wordpressUnits, err := wordpress.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	wordpressMachine, err := wordpressUnits[0].AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	e1, err := wordpress.Endpoint("db")
+	c.Assert(err, jc.ErrorIsNil)
+	e2, err := mysql.Endpoint("server")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeRelation(c, &factory.RelationParams{Endpoints: []state.Endpoint{e1, e2}})
+
+	for i, test := range []struct {
+		filters []string
+		expect  statusSummary
+	}{{
+		filters: []string{"status==error"},
+		expect: statusSummary{
+			machines:     []string{m1.Id()},
+			applications: []string{"mysql"},
+			units:        []string{"mysql/1"},
+			relations:    1,
+		},
+	}, {
+		filters: []string{"zone=zone-a"},
+		expect: statusSummary{
+			machines:     []string{m0.Id()},
+			applications: []string{"mysql"},
+			units:        []string{"mysql/0"},
+			relations:    1,
+		},
+	}, {
+		filters: []string{"message~=for db$", "status!=error"},
+		expect: statusSummary{
+			machines:     []string{wordpressMachine},
+			applications: []string{"wordpress"},
+			units:        []string{"wordpress/0"},
+			relations:    1,
+		},
+	}, {
+		filters: []string{"status==active", "charm-rev>=100"},
+		expect:  statusSummary{},
+	}} {
+		c.Logf("test %d: %v", i, test.filters)
+		fs, err := s.APIState.Client().FilteredStatus(nil, test.filters, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(summarise(fs), jc.DeepEquals, test.expect)
+	}
+}
+
+func (*statusFilterSuite) TestFilterErrors(c *gc.C) {
+	for i, test := range []struct {
+		filter string
+		expect string
+	}{{
+		filter: "error",
+		expect: `status filter "error" not valid`,
+	}, {
+		filter: "colour==red",
+		expect: `status filter field "colour" not valid`,
+	}, {
+		filter: "status<error",
+		expect: `operator "<" for status filter field "status" not valid`,
+	}, {
+		filter: "charm-rev>=latest",
+		expect: `charm revision "latest" not valid`,
+	}, {
+		filter: "message~=(",
+		expect: `status filter "message~=\(": error parsing regexp: .*`,
+	}} {
+		c.Logf("test %d: %q", i, test.filter)
+		err := client.ParseStatusFilters([]string{test.filter})
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (*statusFilterSuite) TestFields(c *gc.C) {
+	fs := sampleStatus()
+	err := client.ProjectStatus(&fs, []string{
+		"machines",
+		"applications.units.workload-status",
+		"applications.units.subordinates",
+		"applications.charm",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fs.Model, jc.DeepEquals, params.ModelStatusInfo{})
+	c.Check(fs.Relations, gc.IsNil)
+	c.Check(fs.Offers, gc.IsNil)
+	c.Check(fs.Machines, jc.DeepEquals, sampleStatus().Machines)
+
+	mysql := fs.Applications["mysql"]
+	c.Check(mysql.Charm, gc.Equals, "cs:mysql-5")
+	c.Check(mysql.Series, gc.Equals, "")
+	c.Check(mysql.Status, jc.DeepEquals, params.DetailedStatus{})
+	unit := mysql.Units["mysql/1"]
+	c.Check(unit.WorkloadStatus, jc.DeepEquals, detailed("error", "hook failed: install"))
+	c.Check(unit.AgentStatus, jc.DeepEquals, params.DetailedStatus{})
+	c.Check(unit.Machine, gc.Equals, "")
+	c.Check(unit.Subordinates, jc.DeepEquals, sampleStatus().Applications["mysql"].Units["mysql/1"].Subordinates)
+}
+
+func (*statusFilterSuite) TestFieldsWholeSelectionWins(c *gc.C) {
+	fs := sampleStatus()
+	err := client.ProjectStatus(&fs, []string{"applications", "applications.charm"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fs.Applications, jc.DeepEquals, sampleStatus().Applications)
+}
+
+func (*statusFilterSuite) TestFieldErrors(c *gc.C) {
+	for i, field := range []string{
+		"colour",
+		"applications.colour",
+		"model.name.first",
+	} {
+		c.Logf("test %d: %q", i, field)
+		fs := sampleStatus()
+		err := client.ProjectStatus(&fs, []string{field})
+		c.Check(err, gc.ErrorMatches, `status field "`+field+`" not valid`)
+	}
+}
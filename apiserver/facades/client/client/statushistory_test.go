@@ -219,6 +219,199 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestStatusHistoryTimeline(c *gc.C) {
+	s.st.unitHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status:  status.Active,
+			Message: "running",
+		},
+		{
+			Status:  status.Maintenance,
+			Message: "working",
+		},
+		{
+			Status:  status.Waiting,
+			Message: "too old",
+		},
+	})
+	s.st.agentHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status: status.Idle,
+		},
+		{
+			Status: status.Executing,
+		},
+	})
+	from := time.Unix(998, 0)
+	to := time.Unix(1000, 0)
+	r, err := s.api.StatusHistoryTimeline(params.StatusHistoryTimelineArgs{
+		Entities: []string{"unit-unit-0"},
+		From:     from,
+		To:       &to,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := []params.StatusHistoryEntry{
+		{Entity: "unit-unit-0", Kind: "workload", Status: "maintenance", Info: "working"},
+		{Entity: "unit-unit-0", Kind: "juju-unit", Status: "executing"},
+		{Entity: "unit-unit-0", Kind: "workload", Status: "active", Info: "running"},
+		{Entity: "unit-unit-0", Kind: "juju-unit", Status: "idle"},
+	}
+	c.Assert(r.Entries, gc.HasLen, len(expected))
+	for i, entry := range r.Entries {
+		c.Check(entry.Since, gc.NotNil)
+		entry.Since = nil
+		c.Check(entry, jc.DeepEquals, expected[i])
+	}
+}
+
+func (s *statusHistoryTestSuite) TestStatusHistoryTimelineKinds(c *gc.C) {
+	s.st.unitHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status:  status.Active,
+			Message: "running",
+		},
+	})
+	s.st.agentHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status: status.Idle,
+		},
+	})
+	r, err := s.api.StatusHistoryTimeline(params.StatusHistoryTimelineArgs{
+		Entities: []string{"unit-unit-0"},
+		Kinds:    []string{"workload"},
+		From:     time.Unix(0, 0),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Entries, gc.HasLen, 1)
+	c.Check(r.Entries[0].Kind, gc.Equals, "workload")
+}
+
+func (s *statusHistoryTestSuite) TestStatusHistoryTimelineLimit(c *gc.C) {
+	s.st.unitHistory = statusInfoWithDates([]status.StatusInfo{
+		{Status: status.Active, Message: "3"},
+		{Status: status.Active, Message: "2"},
+		{Status: status.Active, Message: "1"},
+	})
+	s.st.agentHistory = statusInfoWithDates([]status.StatusInfo{
+		{Status: status.Idle},
+	})
+	r, err := s.api.StatusHistoryTimeline(params.StatusHistoryTimelineArgs{
+		Entities: []string{"unit-unit-0"},
+		From:     time.Unix(0, 0),
+		Limit:    2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Entries, gc.HasLen, 2)
+	c.Check(r.Entries[0].Info, gc.Equals, "1")
+	c.Check(r.Entries[1].Info, gc.Equals, "2")
+	c.Assert(r.Next, gc.NotNil)
+	c.Check(*r.Next, gc.Equals, *r.Entries[1].Since)
+
+	// Continuing from Next returns the rest.
+	r, err = s.api.StatusHistoryTimeline(params.StatusHistoryTimelineArgs{
+		Entities: []string{"unit-unit-0"},
+		From:     *r.Next,
+		Limit:    2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Entries, gc.HasLen, 2)
+	c.Check(r.Entries[0].Kind, gc.Equals, "workload")
+	c.Check(r.Entries[0].Info, gc.Equals, "3")
+	c.Check(r.Entries[1].Kind, gc.Equals, "juju-unit")
+	c.Check(r.Next, gc.IsNil)
+}
+
+func (s *statusHistoryTestSuite) TestStatusHistoryTimelineLimitSameTime(c *gc.C) {
+	s.st.unitHistory = statusInfoWithDates([]status.StatusInfo{
+		{Status: status.Active, Message: "later"},
+		{Status: status.Maintenance, Message: "earlier"},
+	})
+	s.st.agentHistory = statusInfoWithDates([]status.StatusInfo{
+		{Status: status.Idle},
+	})
+	// The workload's later entry and the agent's entry share a time,
+	// so the limit cannot fall between them; both are left for the
+	// next call.
+	r, err := s.api.StatusHistoryTimeline(params.StatusHistoryTimelineArgs{
+		Entities: []string{"unit-unit-0"},
+		From:     time.Unix(0, 0),
+		Limit:    2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Entries, gc.HasLen, 1)
+	c.Check(r.Entries[0].Info, gc.Equals, "earlier")
+	c.Assert(r.Next, gc.NotNil)
+
+	r, err = s.api.StatusHistoryTimeline(params.StatusHistoryTimelineArgs{
+		Entities: []string{"unit-unit-0"},
+		From:     *r.Next,
+		Limit:    2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Entries, gc.HasLen, 2)
+	c.Check(r.Entries[0].Info, gc.Equals, "later")
+	c.Check(r.Entries[1].Status, gc.Equals, "idle")
+	c.Check(r.Next, gc.IsNil)
+
+	// If more entries than the limit share a time, the timeline
+	// cannot be continued past them.
+	_, err = s.api.StatusHistoryTimeline(params.StatusHistoryTimelineArgs{
+		Entities: []string{"unit-unit-0"},
+		From:     time.Unix(999, 0),
+		Limit:    1,
+	})
+	c.Assert(err, gc.ErrorMatches, "more than 1 status history entries at the same time")
+}
+
+func (s *statusHistoryTestSuite) TestStatusHistoryTimelineMissingSince(c *gc.C) {
+	s.st.unitHistory = statusInfoWithDates([]status.StatusInfo{
+		{Status: status.Active, Message: "running"},
+	})
+	s.st.agentHistory = []status.StatusInfo{{Status: status.Idle}}
+	r, err := s.api.StatusHistoryTimeline(params.StatusHistoryTimelineArgs{
+		Entities: []string{"unit-unit-0"},
+		From:     time.Unix(0, 0),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Entries, gc.HasLen, 2)
+	c.Check(r.Entries[0].Since, gc.IsNil)
+	c.Check(r.Entries[1].Info, gc.Equals, "running")
+}
+
+func (s *statusHistoryTestSuite) TestStatusHistoryTimelineErrors(c *gc.C) {
+	from := time.Unix(1000, 0)
+	before := time.Unix(900, 0)
+	for i, test := range []struct {
+		args   params.StatusHistoryTimelineArgs
+		expect string
+	}{{
+		args:   params.StatusHistoryTimelineArgs{Entities: []string{"unit-unit-0"}},
+		expect: "missing start of period not valid",
+	}, {
+		args:   params.StatusHistoryTimelineArgs{From: from, To: &before},
+		expect: "cannot validate status history filter: ToDate before Date not valid",
+	}, {
+		args:   params.StatusHistoryTimelineArgs{From: from, Kinds: []string{"charm"}},
+		expect: `status history kind "charm" not valid`,
+	}, {
+		args:   params.StatusHistoryTimelineArgs{From: from, Entities: []string{"application-mysql"}},
+		expect: `status history entity "application-mysql" not valid`,
+	}, {
+		args:   params.StatusHistoryTimelineArgs{From: from, Limit: -1},
+		expect: "limit -1 not valid",
+	}, {
+		args:   params.StatusHistoryTimelineArgs{From: from, Limit: 10001},
+		expect: "limit 10001 not valid",
+	}, {
+		args:   params.StatusHistoryTimelineArgs{From: from, Entities: []string{"unit-mysql-1"}},
+		expect: `fetching status history for "unit-mysql-1": mysql/1 not found`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.api.StatusHistoryTimeline(test.args)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
 type mockState struct {
 	client.Backend
 	unitHistory  []status.StatusInfo
@@ -268,6 +461,24 @@ type mockUnitAgent struct {
 type statuses []status.StatusInfo
 
 func (s statuses) StatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	if filter.Size == 0 {
+		var result []status.StatusInfo
+		for _, info := range s {
+			if info.Since != nil && filter.FromDate != nil && !info.Since.After(*filter.FromDate) {
+				continue
+			}
+			if info.Since != nil && filter.ToDate != nil && info.Since.After(*filter.ToDate) {
+				continue
+			}
+			result = append(result, info)
+		}
+		// History is latest first, so the earliest entries are
+		// at the end.
+		if filter.Limit > 0 && len(result) > filter.Limit {
+			result = result[len(result)-filter.Limit:]
+		}
+		return result, nil
+	}
 	if filter.Size > len(s) {
 		filter.Size = len(s)
 	}
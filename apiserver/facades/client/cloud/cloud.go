@@ -229,6 +229,7 @@ func (api *CloudAPI) AddCredentials(args params.TaggedCredentials) (params.Error
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		common.InvalidateInstanceTypes(tag)
 	}
 	return results, nil
 }
@@ -267,6 +268,7 @@ func (api *CloudAPI) UpdateCredentials(args params.TaggedCredentials) (params.Er
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		common.InvalidateInstanceTypes(tag)
 	}
 	return results, nil
 }
@@ -294,7 +296,9 @@ func (api *CloudAPI) RevokeCredentials(args params.Entities) (params.ErrorResult
 		}
 		if err := api.backend.RemoveCloudCredential(tag); err != nil {
 			results.Results[i].Error = common.ServerError(err)
+			continue
 		}
+		common.InvalidateInstanceTypes(tag)
 	}
 	return results, nil
 }
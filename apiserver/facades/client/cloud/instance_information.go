@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/state/stateenvirons"
 )
 
@@ -50,8 +51,11 @@ func instanceTypes(api *CloudAPI,
 		return params.InstanceTypesResults{}, errors.Trace(err)
 	}
 
+	credential := ""
+	if credentialTag, ok := m.CloudCredential(); ok {
+		credential = credentialTag.Id()
+	}
 	result := make([]params.InstanceTypesResult, len(cons.Constraints))
-	// TODO(perrito666) Cache the results to avoid excessive querying of the cloud.
 	// TODO(perrito666) Add Region<>Cloud validation.
 	for i, cons := range cons.Constraints {
 		value := constraints.Value{}
@@ -77,7 +81,12 @@ func instanceTypes(api *CloudAPI,
 			return params.InstanceTypesResults{}, errors.Trace(err)
 		}
 
-		itCons := common.NewInstanceTypeConstraints(env, value)
+		key := instances.CatalogueKey{
+			Cloud:      cloudTag.Id(),
+			Region:     cons.CloudRegion,
+			Credential: credential,
+		}
+		itCons := common.NewRegionInstanceTypeConstraints(env, key, value)
 		it, err := common.InstanceTypes(itCons)
 		if err != nil {
 			result[i] = params.InstanceTypesResult{Error: common.ServerError(err)}
@@ -4,7 +4,6 @@
 package cloud_test
 
 import (
-	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -35,14 +34,12 @@ func (p *instanceTypesSuite) TestInstanceTypes(c *gc.C) {
 
 	itCons := constraints.Value{CpuCores: &over9kCPUCores}
 	env := &mockEnviron{
-		results: map[constraints.Value]instances.InstanceTypesWithCostMetadata{
-			itCons: instances.InstanceTypesWithCostMetadata{
-				CostUnit:     "USD/h",
-				CostCurrency: "USD",
-				InstanceTypes: []instances.InstanceType{
-					{Name: "instancetype-1"},
-					{Name: "instancetype-2"}},
-			},
+		instanceTypes: instances.InstanceTypesWithCostMetadata{
+			CostUnit:     "USD/h",
+			CostCurrency: "USD",
+			InstanceTypes: []instances.InstanceType{
+				{Name: "instancetype-1", Arches: []string{"amd64"}, CpuCores: 2, Mem: 2048},
+				{Name: "instancetype-2", Arches: []string{"amd64"}, CpuCores: over9kCPUCores, Mem: 4096}},
 		},
 	}
 	fakeEnvironGet := func(
@@ -54,7 +51,8 @@ func (p *instanceTypesSuite) TestInstanceTypes(c *gc.C) {
 
 	api := cloud.NewCloudTestingAPI(backend, ctlrBackend, authorizer)
 
-	failureCons := constraints.Value{}
+	tooManyCPUCores := over9kCPUCores + 1
+	failureCons := constraints.Value{CpuCores: &tooManyCPUCores}
 	cons := params.CloudInstanceTypesConstraints{
 		Constraints: []params.CloudInstanceTypesConstraint{
 			{CloudTag: "cloud-aws",
@@ -72,15 +70,14 @@ func (p *instanceTypesSuite) TestInstanceTypes(c *gc.C) {
 	c.Assert(r.Results, gc.HasLen, 3)
 	expected := []params.InstanceTypesResult{
 		params.InstanceTypesResult{
-			InstanceTypes: []params.InstanceType{
-				params.InstanceType{
-					Name: "instancetype-1"},
-				params.InstanceType{Name: "instancetype-2"}},
+			InstanceTypes: []params.InstanceType{{
+				Name: "instancetype-2", Arches: []string{"amd64"}, CPUCores: 9001, Memory: 4096,
+			}},
 			CostUnit:     "USD/h",
 			CostCurrency: "USD",
 		},
 		params.InstanceTypesResult{
-			Error: &params.Error{Message: "Instances matching constraint  not found", Code: "not found"}},
+			Error: &params.Error{Message: `no instance types in a-region matching constraints "cores=9002"`}},
 		params.InstanceTypesResult{
 			Error: &params.Error{Message: "asking gce cloud information to aws cloud not valid", Code: ""}}}
 	c.Assert(r.Results, gc.DeepEquals, expected)
@@ -98,13 +95,9 @@ type mockEnviron struct {
 	environs.Environ
 	cloud.Backend
 
-	results map[constraints.Value]instances.InstanceTypesWithCostMetadata
+	instanceTypes instances.InstanceTypesWithCostMetadata
 }
 
 func (m *mockEnviron) InstanceTypes(c constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
-	it, ok := m.results[c]
-	if !ok {
-		return instances.InstanceTypesWithCostMetadata{}, errors.NotFoundf("Instances matching constraint %v", c)
-	}
-	return it, nil
+	return m.instanceTypes, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/state/stateenvirons"
 )
 
@@ -42,14 +43,23 @@ func instanceTypes(mm *MachineManagerAPI,
 	}
 
 	env, err := getEnviron(backend, environs.New)
+	if err != nil {
+		return params.InstanceTypesResults{}, errors.Trace(err)
+	}
+	key := instances.CatalogueKey{
+		Cloud:  model.Cloud(),
+		Region: model.CloudRegion(),
+	}
+	if credentialTag, ok := model.CloudCredential(); ok {
+		key.Credential = credentialTag.Id()
+	}
 	result := make([]params.InstanceTypesResult, len(cons.Constraints))
-	// TODO(perrito666) Cache the results to avoid excessive querying of the cloud.
 	for i, c := range cons.Constraints {
 		value := constraints.Value{}
 		if c.Value != nil {
 			value = *c.Value
 		}
-		itCons := common.NewInstanceTypeConstraints(env, value)
+		itCons := common.NewRegionInstanceTypeConstraints(env, key, value)
 		it, err := common.InstanceTypes(itCons)
 		if err != nil {
 			it = params.InstanceTypesResult{Error: common.ServerError(err)}
@@ -4,7 +4,6 @@
 package machinemanager_test
 
 import (
-	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -35,16 +34,15 @@ func (p *instanceTypesSuite) TestInstanceTypes(c *gc.C) {
 	authorizer := testing.FakeAuthorizer{Tag: names.NewUserTag("admin"),
 		Controller: true}
 	itCons := constraints.Value{CpuCores: &over9kCPUCores}
-	failureCons := constraints.Value{}
+	tooManyCPUCores := over9kCPUCores + 1
+	failureCons := constraints.Value{CpuCores: &tooManyCPUCores}
 	env := mockEnviron{
-		results: map[constraints.Value]instances.InstanceTypesWithCostMetadata{
-			itCons: instances.InstanceTypesWithCostMetadata{
-				CostUnit:     "USD/h",
-				CostCurrency: "USD",
-				InstanceTypes: []instances.InstanceType{
-					{Name: "instancetype-1"},
-					{Name: "instancetype-2"}},
-			},
+		instanceTypes: instances.InstanceTypesWithCostMetadata{
+			CostUnit:     "USD/h",
+			CostCurrency: "USD",
+			InstanceTypes: []instances.InstanceType{
+				{Name: "instancetype-1", Arches: []string{"amd64"}, CpuCores: 2, Mem: 2048},
+				{Name: "instancetype-2", Arches: []string{"amd64"}, CpuCores: over9kCPUCores, Mem: 4096}},
 		},
 	}
 	api, err := machinemanager.NewMachineManagerAPI(backend, pool, authorizer)
@@ -61,23 +59,29 @@ func (p *instanceTypesSuite) TestInstanceTypes(c *gc.C) {
 	r, err := machinemanager.InstanceTypes(api, fakeEnvironGet, cons)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(r.Results, gc.HasLen, 3)
+	instanceType1 := params.InstanceType{
+		Name: "instancetype-1", Arches: []string{"amd64"}, CPUCores: 2, Memory: 2048,
+	}
+	instanceType2 := params.InstanceType{
+		Name: "instancetype-2", Arches: []string{"amd64"}, CPUCores: 9001, Memory: 4096,
+	}
 	expected := []params.InstanceTypesResult{
 		params.InstanceTypesResult{
-			InstanceTypes: []params.InstanceType{
-				params.InstanceType{
-					Name: "instancetype-1",
-				},
-				params.InstanceType{
-					Name: "instancetype-2",
-				}},
-			CostUnit:     "USD/h",
-			CostCurrency: "USD",
+			InstanceTypes: []params.InstanceType{instanceType2},
+			CostUnit:      "USD/h",
+			CostCurrency:  "USD",
 		},
 		params.InstanceTypesResult{
-			Error: &params.Error{Message: "Instances matching constraint  not found", Code: "not found"}},
+			Error: &params.Error{Message: `no instance types in a-region matching constraints "cores=9002"`}},
 		params.InstanceTypesResult{
-			Error: &params.Error{Message: "Instances matching constraint  not found", Code: "not found"}}}
+			InstanceTypes: []params.InstanceType{instanceType1, instanceType2},
+			CostUnit:      "USD/h",
+			CostCurrency:  "USD",
+		}}
 	c.Assert(r.Results, gc.DeepEquals, expected)
+
+	// The region's instance types are fetched once.
+	env.CheckCallNames(c, "InstanceTypes")
 }
 
 type mockBackend struct {
@@ -118,15 +122,12 @@ type mockEnviron struct {
 	machinemanager.Backend
 	jujutesting.Stub
 
-	results map[constraints.Value]instances.InstanceTypesWithCostMetadata
+	instanceTypes instances.InstanceTypesWithCostMetadata
 }
 
 func (m *mockEnviron) InstanceTypes(c constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
-	it, ok := m.results[c]
-	if !ok {
-		return instances.InstanceTypesWithCostMetadata{}, errors.NotFoundf("Instances matching constraint %v", c)
-	}
-	return it, nil
+	m.MethodCall(m, "InstanceTypes", c)
+	return m.instanceTypes, m.NextErr()
 }
 
 type mockModel struct {
@@ -57,6 +57,19 @@ func NewFacadeV4(ctx facade.Context) (*MachineManagerAPIV4, error) {
 	return &MachineManagerAPIV4{machineManagerAPI}, nil
 }
 
+type MachineManagerAPIV5 struct {
+	*MachineManagerAPIV4
+}
+
+// NewFacadeV5 creates a new server-side MachineManager API facade.
+func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
+	machineManagerAPIV4, err := NewFacadeV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV5{machineManagerAPIV4}, nil
+}
+
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
 func NewMachineManagerAPI(backend Backend, pool Pool, auth facade.Authorizer) (*MachineManagerAPI, error) {
 	if !auth.AuthClient() {
@@ -70,6 +83,17 @@ func NewMachineManagerAPI(backend Backend, pool Pool, auth facade.Authorizer) (*
 	}, nil
 }
 
+func (mm *MachineManagerAPI) checkCanRead() error {
+	canRead, err := mm.authorizer.HasPermission(permission.ReadAccess, mm.st.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 func (mm *MachineManagerAPI) checkCanWrite() error {
 	canWrite, err := mm.authorizer.HasPermission(permission.WriteAccess, mm.st.ModelTag())
 	if err != nil {
@@ -183,6 +207,7 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 		HardwareCharacteristics: p.HardwareCharacteristics,
 		Addresses:               params.NetworkAddresses(p.Addrs...),
 		Placement:               placementDirective,
+		Pool:                    p.Pool,
 	}
 	if p.ContainerType == "" {
 		return mm.st.AddOneMachine(template)
@@ -330,3 +355,74 @@ func (mm *MachineManagerAPIV4) updateOneMachineSeries(arg params.UpdateSeriesArg
 	}
 	return machine.UpdateMachineSeries(arg.Series, arg.Force)
 }
+
+// AddMachinePools adds the given machine pools to the model.
+func (mm *MachineManagerAPIV5) AddMachinePools(args params.MachinePools) (params.ErrorResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Pools)),
+	}
+	for i, pool := range args.Pools {
+		_, err := mm.st.AddMachinePool(pool.Name, machinePoolArgs(pool))
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetMachinePools replaces the labels and applications of the given
+// machine pools.
+func (mm *MachineManagerAPIV5) SetMachinePools(args params.MachinePools) (params.ErrorResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Pools)),
+	}
+	for i, pool := range args.Pools {
+		err := mm.st.SetMachinePool(pool.Name, machinePoolArgs(pool))
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// ListMachinePools returns the model's machine pools.
+func (mm *MachineManagerAPIV5) ListMachinePools() (params.MachinePoolsResult, error) {
+	if err := mm.checkCanRead(); err != nil {
+		return params.MachinePoolsResult{}, err
+	}
+	pools, err := mm.st.AllMachinePools()
+	if err != nil {
+		return params.MachinePoolsResult{}, errors.Trace(err)
+	}
+	result := params.MachinePoolsResult{
+		Pools: make([]params.MachinePool, len(pools)),
+	}
+	for i, pool := range pools {
+		machines, err := pool.MachineIds()
+		if err != nil {
+			return params.MachinePoolsResult{}, errors.Trace(err)
+		}
+		result.Pools[i] = params.MachinePool{
+			Name:         pool.Name(),
+			Labels:       pool.Labels(),
+			Applications: pool.Applications(),
+			Machines:     machines,
+		}
+	}
+	return result, nil
+}
+
+func machinePoolArgs(pool params.MachinePool) state.MachinePoolArgs {
+	return state.MachinePoolArgs{
+		Labels:       pool.Labels,
+		Applications: pool.Applications,
+	}
+}
@@ -313,16 +313,134 @@ func (s *MachineManagerSuite) TestUpdateMachineSeriesPermissionDenied(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *MachineManagerSuite) TestAddMachinesWithPool(c *gc.C) {
+	_, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series: "trusty",
+			Jobs:   []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+			Pool:   "gpu",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.st.machineTemplates, gc.HasLen, 1)
+	c.Assert(s.st.machineTemplates[0].Pool, gc.Equals, "gpu")
+}
+
+func (s *MachineManagerSuite) TestAddMachinePools(c *gc.C) {
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}}
+	s.st.pools = map[string]state.MachinePoolArgs{"fast": {}}
+	results, err := apiV5.AddMachinePools(params.MachinePools{
+		Pools: []params.MachinePool{{
+			Name:         "gpu",
+			Labels:       map[string]string{"gpu": "nvidia"},
+			Applications: []string{"cuda"},
+		}, {
+			Name: "fast",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `machine pool "fast" already exists`)
+	c.Assert(s.st.pools, jc.DeepEquals, map[string]state.MachinePoolArgs{
+		"fast": {},
+		"gpu": {
+			Labels:       map[string]string{"gpu": "nvidia"},
+			Applications: []string{"cuda"},
+		},
+	})
+}
+
+func (s *MachineManagerSuite) TestSetMachinePools(c *gc.C) {
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}}
+	results, err := apiV5.SetMachinePools(params.MachinePools{
+		Pools: []params.MachinePool{{
+			Name:         "gpu",
+			Applications: []string{"cuda", "trainer"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	c.Assert(s.st.pools, jc.DeepEquals, map[string]state.MachinePoolArgs{
+		"gpu": {Applications: []string{"cuda", "trainer"}},
+	})
+}
+
+func (s *MachineManagerSuite) TestSetMachinePoolsBlocked(c *gc.C) {
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}}
+	s.st.blockMsg = "TestSetMachinePoolsBlocked"
+	s.st.block = state.ChangeBlock
+	_, err := apiV5.SetMachinePools(params.MachinePools{
+		Pools: []params.MachinePool{{Name: "gpu"}},
+	})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+	c.Assert(s.st.pools, gc.HasLen, 0)
+}
+
+func (s *MachineManagerSuite) TestListMachinePools(c *gc.C) {
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}}
+	s.st.allPools = []machinemanager.MachinePool{&mockMachinePool{
+		name:         "gpu",
+		labels:       map[string]string{"gpu": "nvidia"},
+		applications: []string{"cuda"},
+		machines:     []string{"0", "0/lxd/0"},
+	}}
+	result, err := apiV5.ListMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MachinePoolsResult{
+		Pools: []params.MachinePool{{
+			Name:         "gpu",
+			Labels:       map[string]string{"gpu": "nvidia"},
+			Applications: []string{"cuda"},
+			Machines:     []string{"0", "0/lxd/0"},
+		}},
+	})
+}
+
+func (s *MachineManagerSuite) TestAddMachinePoolsPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}}
+	_, err := apiV5.AddMachinePools(params.MachinePools{
+		Pools: []params.MachinePool{{Name: "gpu"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 type mockState struct {
 	machinemanager.Backend
 	calls            int
 	machineTemplates []state.MachineTemplate
 	machines         map[string]*mockMachine
+	pools            map[string]state.MachinePoolArgs
+	allPools         []machinemanager.MachinePool
 	err              error
 	blockMsg         string
 	block            state.BlockType
 }
 
+func (st *mockState) AddMachinePool(name string, args state.MachinePoolArgs) (*state.MachinePool, error) {
+	if _, ok := st.pools[name]; ok {
+		return nil, errors.AlreadyExistsf("machine pool %q", name)
+	}
+	if st.pools == nil {
+		st.pools = make(map[string]state.MachinePoolArgs)
+	}
+	st.pools[name] = args
+	return &state.MachinePool{}, nil
+}
+
+func (st *mockState) SetMachinePool(name string, args state.MachinePoolArgs) error {
+	if st.pools == nil {
+		st.pools = make(map[string]state.MachinePoolArgs)
+	}
+	st.pools[name] = args
+	return nil
+}
+
+func (st *mockState) AllMachinePools() ([]machinemanager.MachinePool, error) {
+	return st.allPools, nil
+}
+
 func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machine, error) {
 	st.calls++
 	st.machineTemplates = append(st.machineTemplates, template)
@@ -494,3 +612,26 @@ type mockVolume struct {
 func (v *mockVolume) Detachable() bool {
 	return v.detachable
 }
+
+type mockMachinePool struct {
+	name         string
+	labels       map[string]string
+	applications []string
+	machines     []string
+}
+
+func (p *mockMachinePool) Name() string {
+	return p.name
+}
+
+func (p *mockMachinePool) Labels() map[string]string {
+	return p.labels
+}
+
+func (p *mockMachinePool) Applications() []string {
+	return p.applications
+}
+
+func (p *mockMachinePool) MachineIds() ([]string, error) {
+	return p.machines, nil
+}
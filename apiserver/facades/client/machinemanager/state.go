@@ -25,6 +25,9 @@ type Backend interface {
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
+	AddMachinePool(name string, args state.MachinePoolArgs) (*state.MachinePool, error)
+	SetMachinePool(name string, args state.MachinePoolArgs) error
+	AllMachinePools() ([]MachinePool, error)
 }
 
 type Pool interface {
@@ -40,6 +43,13 @@ type Model interface {
 	Config() (*config.Config, error)
 }
 
+type MachinePool interface {
+	Name() string
+	Labels() map[string]string
+	Applications() []string
+	MachineIds() ([]string, error)
+}
+
 type Machine interface {
 	Destroy() error
 	ForceDestroy() error
@@ -66,6 +76,18 @@ func (s stateShim) Model() (Model, error) {
 	return s.State.Model()
 }
 
+func (s stateShim) AllMachinePools() ([]MachinePool, error) {
+	pools, err := s.State.AllMachinePools()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]MachinePool, len(pools))
+	for i, pool := range pools {
+		out[i] = pool
+	}
+	return out, nil
+}
+
 type poolShim struct {
 	pool *state.StatePool
 }
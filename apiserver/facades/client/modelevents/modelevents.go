@@ -0,0 +1,331 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelevents provides the facade used to read a single,
+// time-ordered feed of the events that have happened in a model,
+// merged from its status history, actions, cleanups and the commands
+// recorded in the controller's audit log.
+package modelevents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+const (
+	// defaultLimit is the number of events returned when the caller
+	// does not ask for a particular number.
+	defaultLimit = 100
+
+	// maxLimit is the most events returned by a single call.
+	maxLimit = 1000
+
+	// auditSource identifies events read from the audit log.
+	auditSource = "audit"
+)
+
+// Backend defines the state functionality required by the model
+// events facade. It is implemented by *state.Model.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	ModelTag() names.ModelTag
+	Events(since time.Time, limit int) ([]state.ModelEvent, error)
+}
+
+// AuditReader returns up to limit conversations with the given model
+// recorded in the audit log that began at or after since.
+type AuditReader func(modelUUID string, since time.Time, limit int) ([]auditlog.Conversation, error)
+
+// API provides the ModelEvents facade.
+type API struct {
+	backend Backend
+	audit   AuditReader
+
+	// auditErr is returned when events from the audit log are asked
+	// for but cannot be read.
+	auditErr error
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	model, err := ctx.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := ctx.State().ControllerInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var audit AuditReader
+	// Each controller only records the commands it serves in its own
+	// audit log, so in a highly available controller the events read
+	// would depend on which controller answered.
+	if len(info.MachineIds) == 1 {
+		var logDir string
+		if res, ok := ctx.Resources().Get("logDir").(common.StringResource); ok {
+			logDir = res.String()
+		}
+		audit = NewAuditLogReader(logDir)
+	}
+	return NewAPI(model, ctx.Auth(), audit)
+}
+
+// NewAPI returns a new ModelEvents facade. Events from the audit log
+// are only returned to controller and model administrators, and none
+// are returned if audit is nil.
+func NewAPI(backend Backend, authorizer facade.Authorizer, audit AuditReader) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	canRead, err := authorizer.HasPermission(permission.ReadAccess, backend.ModelTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !canRead && !isAdmin {
+		return nil, common.ErrPerm
+	}
+	api := &API{backend: backend, audit: audit}
+	canAdmin, err := authorizer.HasPermission(permission.AdminAccess, backend.ModelTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch {
+	case !canAdmin && !isAdmin:
+		api.audit, api.auditErr = nil, common.ErrPerm
+	case audit == nil:
+		api.auditErr = errors.NotSupportedf("audit events from a highly available controller")
+	}
+	return api, nil
+}
+
+// NewAuditLogReader returns an AuditReader that reads the audit log
+// written to the given directory by this controller. Logs that have
+// been rotated are not read, and if auditing is not enabled no
+// conversations are returned.
+func NewAuditLogReader(logDir string) AuditReader {
+	return func(modelUUID string, since time.Time, limit int) ([]auditlog.Conversation, error) {
+		if logDir == "" {
+			return nil, nil
+		}
+		f, err := os.Open(filepath.Join(logDir, auditlog.LogFileName))
+		if os.IsNotExist(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return auditlog.ReadConversations(f, info.Size(), modelUUID, since, limit)
+	}
+}
+
+// Events returns the events that have happened in the model since the
+// given time or cursor, ordered by time, and the cursor from which to
+// continue reading.
+func (api *API) Events(args params.ModelEventsArgs) (params.ModelEventsResult, error) {
+	var result params.ModelEventsResult
+	limit := args.Limit
+	switch {
+	case limit < 0:
+		return result, errors.NotValidf("limit %d", limit)
+	case limit == 0:
+		limit = defaultLimit
+	case limit > maxLimit:
+		limit = maxLimit
+	}
+	sources := make(map[string]bool)
+	for _, source := range args.Sources {
+		switch state.ModelEventSource(source) {
+		case state.ModelEventStatus, state.ModelEventAction, state.ModelEventCleanup:
+		case auditSource:
+			if api.auditErr != nil {
+				return result, errors.Trace(api.auditErr)
+			}
+		default:
+			return result, errors.NotValidf("event source %q", source)
+		}
+		sources[source] = true
+	}
+	wanted := func(source string) bool {
+		if source == auditSource && api.audit == nil {
+			return false
+		}
+		return len(sources) == 0 || sources[source]
+	}
+
+	var after *cursor
+	var since time.Time
+	if args.Cursor != "" {
+		c, err := parseCursor(args.Cursor)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		after, since = &c, c.time
+	} else if args.Since != nil {
+		since = *args.Since
+	}
+
+	// Events at the cursor's time are fetched again, and those up to
+	// and including the cursor dropped, so more events may need to be
+	// fetched to fill the limit.
+	result.Cursor = args.Cursor
+	for n := limit; ; n *= 2 {
+		events, complete, err := api.events(since, n, wanted)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		result.Events = nil
+		for _, e := range events {
+			if after != nil && !after.before(e) {
+				continue
+			}
+			result.Events = append(result.Events, params.ModelEvent{
+				Source:  string(e.Source),
+				Id:      e.Id,
+				Time:    e.Time,
+				Entity:  e.Entity,
+				Type:    e.Type,
+				Status:  e.Status,
+				Message: e.Message,
+			})
+			result.Cursor = cursor{e.Time, string(e.Source), e.Id}.String()
+			if len(result.Events) == limit {
+				return result, nil
+			}
+		}
+		if complete {
+			return result, nil
+		}
+	}
+}
+
+// events returns the events from the wanted sources at or after since,
+// in order. No more than n events are read from state or the audit log;
+// if there may be more, complete is false and the events returned are
+// only those known to come before any not yet read.
+func (api *API) events(since time.Time, n int, wanted func(string) bool) (_ []state.ModelEvent, complete bool, _ error) {
+	var stateEvents []state.ModelEvent
+	if wanted(string(state.ModelEventStatus)) || wanted(string(state.ModelEventAction)) || wanted(string(state.ModelEventCleanup)) {
+		var err error
+		if stateEvents, err = api.backend.Events(since, n); err != nil {
+			return nil, false, errors.Trace(err)
+		}
+	}
+	// Events from a source at the time of the last one read from it
+	// may not all have been read.
+	complete = true
+	var horizon time.Time
+	truncated := func(last time.Time) {
+		if complete || last.Before(horizon) {
+			horizon = last
+		}
+		complete = false
+	}
+	if len(stateEvents) == n {
+		truncated(stateEvents[n-1].Time)
+	}
+	var events []state.ModelEvent
+	for _, e := range stateEvents {
+		if wanted(string(e.Source)) {
+			events = append(events, e)
+		}
+	}
+	if wanted(auditSource) {
+		conversations, err := api.audit(api.backend.ModelTag().Id(), since, n)
+		if err != nil {
+			return nil, false, errors.Annotate(err, "reading audit log")
+		}
+		for _, c := range conversations {
+			events = append(events, auditEvent(c))
+		}
+		if len(conversations) == n {
+			truncated(events[len(events)-1].Time)
+		}
+	}
+	state.SortModelEvents(events)
+	if !complete {
+		for i, e := range events {
+			if !e.Time.Before(horizon) {
+				events = events[:i]
+				break
+			}
+		}
+	}
+	return events, complete, nil
+}
+
+// auditEvent returns the model event for a conversation recorded in
+// the audit log.
+func auditEvent(c auditlog.Conversation) state.ModelEvent {
+	when, _ := time.Parse(time.RFC3339, c.When)
+	var entity string
+	if names.IsValidUser(c.Who) {
+		entity = names.NewUserTag(c.Who).String()
+	}
+	return state.ModelEvent{
+		Source:  auditSource,
+		Id:      c.ConversationID,
+		Time:    when.UTC(),
+		Entity:  entity,
+		Type:    "command",
+		Message: c.What,
+	}
+}
+
+// cursor identifies the last event returned to a client, so that it
+// can continue reading from the one following. Events are ordered by
+// time, source and id, so the cursor holds all three.
+type cursor struct {
+	time   time.Time
+	source string
+	id     string
+}
+
+// String returns the cursor in the form handed to clients.
+func (c cursor) String() string {
+	return fmt.Sprintf("%d:%s:%s", c.time.UnixNano(), c.source, c.id)
+}
+
+func parseCursor(s string) (cursor, error) {
+	parts := strings.SplitN(s, ":", 3)
+	if len(parts) != 3 {
+		return cursor{}, errors.NotValidf("cursor %q", s)
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return cursor{}, errors.NotValidf("cursor %q", s)
+	}
+	return cursor{time.Unix(0, nanos).UTC(), parts[1], parts[2]}, nil
+}
+
+// before reports whether the cursor comes before the event.
+func (c cursor) before(e state.ModelEvent) bool {
+	switch {
+	case !c.time.Equal(e.Time):
+		return c.time.Before(e.Time)
+	case c.source != string(e.Source):
+		return c.source < string(e.Source)
+	}
+	return c.id < e.Id
+}
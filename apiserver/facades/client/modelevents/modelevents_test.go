@@ -0,0 +1,250 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelevents_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/modelevents"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ModelEventsSuite struct {
+	testing.IsolationSuite
+
+	backend       *mockBackend
+	conversations []auditlog.Conversation
+	api           *modelevents.API
+}
+
+var _ = gc.Suite(&ModelEventsSuite{})
+
+var base = time.Date(2018, time.April, 1, 10, 0, 0, 0, time.UTC)
+
+func at(seconds int) time.Time {
+	return base.Add(time.Duration(seconds) * time.Second)
+}
+
+func (s *ModelEventsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{events: []state.ModelEvent{{
+		Source: state.ModelEventStatus, Id: "a", Time: at(1),
+		Entity: "unit-mysql-0", Type: "workload", Status: "active",
+	}, {
+		Source: state.ModelEventAction, Id: "1-enqueued", Time: at(2),
+		Entity: "unit-mysql-0", Type: "action-enqueued", Status: "pending", Message: "backup",
+	}, {
+		Source: state.ModelEventStatus, Id: "b", Time: at(2),
+		Entity: "machine-0", Type: "juju-machine", Status: "started",
+	}, {
+		Source: state.ModelEventCleanup, Id: "c", Time: at(3),
+		Entity: "unit-mysql-0", Type: "cleanup", Status: "dyingUnit", Message: "mysql/0",
+	}}}
+	s.conversations = []auditlog.Conversation{{
+		Who:            "bob",
+		What:           "juju remove-unit mysql/0",
+		When:           at(2).Format(time.RFC3339),
+		ModelUUID:      coretesting.ModelTag.Id(),
+		ConversationID: "0123456789abcdef",
+	}}
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	api, err := modelevents.NewAPI(s.backend, authorizer, s.readAudit)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *ModelEventsSuite) readAudit(modelUUID string, since time.Time, limit int) ([]auditlog.Conversation, error) {
+	var result []auditlog.Conversation
+	for _, c := range s.conversations {
+		when, _ := time.Parse(time.RFC3339, c.When)
+		if c.ModelUUID == modelUUID && !when.Before(since) {
+			result = append(result, c)
+		}
+	}
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// ids returns the source and id of each event.
+func ids(events []params.ModelEvent) []string {
+	var result []string
+	for _, e := range events {
+		result = append(result, e.Source+":"+e.Id)
+	}
+	return result
+}
+
+func (s *ModelEventsSuite) TestNewAPIRequiresReadAccess(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("nobody")}
+	_, err := modelevents.NewAPI(s.backend, authorizer, s.readAudit)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ModelEventsSuite) TestAuditEventsRequireAdminAccess(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("read")}
+	api, err := modelevents.NewAPI(s.backend, authorizer, s.readAudit)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.Events(params.ModelEventsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids(result.Events), jc.DeepEquals, []string{
+		"status:a", "action:1-enqueued", "status:b", "cleanup:c",
+	})
+	_, err = api.Events(params.ModelEventsArgs{Sources: []string{"audit"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ModelEventsSuite) TestAuditEventsNotAvailable(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	api, err := modelevents.NewAPI(s.backend, authorizer, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.Events(params.ModelEventsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids(result.Events), jc.DeepEquals, []string{
+		"status:a", "action:1-enqueued", "status:b", "cleanup:c",
+	})
+	_, err = api.Events(params.ModelEventsArgs{Sources: []string{"audit"}})
+	c.Assert(err, gc.ErrorMatches, "audit events from a highly available controller not supported")
+}
+
+func (s *ModelEventsSuite) TestEvents(c *gc.C) {
+	result, err := s.api.Events(params.ModelEventsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids(result.Events), jc.DeepEquals, []string{
+		"status:a", "action:1-enqueued", "audit:0123456789abcdef", "status:b", "cleanup:c",
+	})
+	c.Check(result.Events[2], jc.DeepEquals, params.ModelEvent{
+		Source:  "audit",
+		Id:      "0123456789abcdef",
+		Time:    at(2),
+		Entity:  "user-bob",
+		Type:    "command",
+		Message: "juju remove-unit mysql/0",
+	})
+	c.Check(result.Cursor, gc.Not(gc.Equals), "")
+}
+
+func (s *ModelEventsSuite) TestEventsSince(c *gc.C) {
+	since := at(3)
+	result, err := s.api.Events(params.ModelEventsArgs{Since: &since})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids(result.Events), jc.DeepEquals, []string{"cleanup:c"})
+}
+
+func (s *ModelEventsSuite) TestEventsCursor(c *gc.C) {
+	var pages [][]string
+	var cursor string
+	for i := 0; i < 4; i++ {
+		result, err := s.api.Events(params.ModelEventsArgs{Cursor: cursor, Limit: 2})
+		c.Assert(err, jc.ErrorIsNil)
+		pages = append(pages, ids(result.Events))
+		cursor = result.Cursor
+	}
+	c.Check(pages, jc.DeepEquals, [][]string{
+		{"status:a", "action:1-enqueued"},
+		{"audit:0123456789abcdef", "status:b"},
+		{"cleanup:c"},
+		nil,
+	})
+
+	// New events are returned after the cursor.
+	s.backend.events = append(s.backend.events, state.ModelEvent{
+		Source: state.ModelEventStatus, Id: "d", Time: at(3),
+	})
+	result, err := s.api.Events(params.ModelEventsArgs{Cursor: cursor})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids(result.Events), jc.DeepEquals, []string{"status:d"})
+}
+
+func (s *ModelEventsSuite) TestEventsSources(c *gc.C) {
+	result, err := s.api.Events(params.ModelEventsArgs{Sources: []string{"audit"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids(result.Events), jc.DeepEquals, []string{"audit:0123456789abcdef"})
+	c.Check(s.backend.calls, gc.Equals, 0)
+
+	// Audit events are read no more than a page at a time.
+	s.conversations = append(s.conversations, auditlog.Conversation{
+		Who:            "bob",
+		What:           "juju status",
+		When:           at(4).Format(time.RFC3339),
+		ModelUUID:      coretesting.ModelTag.Id(),
+		ConversationID: "fedcba9876543210",
+	})
+	result, err = s.api.Events(params.ModelEventsArgs{Sources: []string{"audit"}, Limit: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids(result.Events), jc.DeepEquals, []string{"audit:0123456789abcdef"})
+	result, err = s.api.Events(params.ModelEventsArgs{Sources: []string{"audit"}, Cursor: result.Cursor, Limit: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids(result.Events), jc.DeepEquals, []string{"audit:fedcba9876543210"})
+
+	result, err = s.api.Events(params.ModelEventsArgs{Sources: []string{"status", "cleanup"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids(result.Events), jc.DeepEquals, []string{"status:a", "status:b", "cleanup:c"})
+}
+
+func (s *ModelEventsSuite) TestEventsErrors(c *gc.C) {
+	for i, test := range []struct {
+		args   params.ModelEventsArgs
+		expect string
+	}{{
+		args:   params.ModelEventsArgs{Limit: -1},
+		expect: "limit -1 not valid",
+	}, {
+		args:   params.ModelEventsArgs{Sources: []string{"logs"}},
+		expect: `event source "logs" not valid`,
+	}, {
+		args:   params.ModelEventsArgs{Cursor: "yesterday"},
+		expect: `cursor "yesterday" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.api.Events(test.args)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+type mockBackend struct {
+	events []state.ModelEvent
+	calls  int
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) Events(since time.Time, limit int) ([]state.ModelEvent, error) {
+	b.calls++
+	var result []state.ModelEvent
+	for _, e := range b.events {
+		if !e.Time.Before(since) {
+			result = append(result, e)
+		}
+	}
+	state.SortModelEvents(result)
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionrollouts implements the API used by the action
+// rollouts worker.
+package actionrollouts
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend defines the state methods used by the API.
+type Backend interface {
+	AdvanceActionRollouts() error
+	WatchActionRollouts() state.NotifyWatcher
+}
+
+type backendShim struct {
+	*state.State
+	model *state.Model
+}
+
+// AdvanceActionRollouts is part of the Backend interface.
+func (b backendShim) AdvanceActionRollouts() error {
+	return b.model.AdvanceActionRollouts()
+}
+
+// API implements the API used by the action rollouts worker.
+type API struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewFacade creates a new API for the given model state.
+func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(backendShim{st, m}, resources, authorizer)
+}
+
+// NewAPI creates a new API using the given backend.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		resources: resources,
+	}, nil
+}
+
+// AdvanceActionRollouts starts the next batch of each running action
+// rollout whose current batch has finished.
+func (api *API) AdvanceActionRollouts() error {
+	return api.backend.AdvanceActionRollouts()
+}
+
+// WatchActionRollouts returns a NotifyWatcher that fires when an action
+// rollout changes, or one of its actions finishes.
+func (api *API) WatchActionRollouts() (params.NotifyWatchResult, error) {
+	w := api.backend.WatchActionRollouts()
+	if _, ok := <-w.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(w),
+		}, nil
+	}
+	return params.NotifyWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(w)),
+	}, nil
+}
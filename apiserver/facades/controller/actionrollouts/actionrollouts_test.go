@@ -0,0 +1,80 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollouts_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/actionrollouts"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionRolloutsSuite struct {
+	coretesting.BaseSuite
+
+	backend    *mockBackend
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	api        *actionrollouts.API
+}
+
+var _ = gc.Suite(&ActionRolloutsSuite{})
+
+func (s *ActionRolloutsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{Stub: &testing.Stub{}}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{Controller: true}
+
+	var err error
+	s.api, err = actionrollouts.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionRolloutsSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	api, err := actionrollouts.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *ActionRolloutsSuite) TestAdvanceActionRollouts(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	err := s.api.AdvanceActionRollouts()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.backend.CheckCallNames(c, "AdvanceActionRollouts")
+}
+
+func (s *ActionRolloutsSuite) TestWatchActionRollouts(c *gc.C) {
+	result, err := s.api.WatchActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	s.backend.CheckCallNames(c, "WatchActionRollouts")
+}
+
+type mockBackend struct {
+	*testing.Stub
+}
+
+func (b *mockBackend) AdvanceActionRollouts() error {
+	b.MethodCall(b, "AdvanceActionRollouts")
+	return b.NextErr()
+}
+
+func (b *mockBackend) WatchActionRollouts() state.NotifyWatcher {
+	b.MethodCall(b, "WatchActionRollouts")
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	return statetesting.NewMockNotifyWatcher(changes)
+}
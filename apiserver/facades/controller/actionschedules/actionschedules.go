@@ -0,0 +1,88 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionschedules implements the API used by the action
+// schedules worker.
+package actionschedules
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend defines the state methods used by the API.
+type Backend interface {
+	RunActionSchedules() (time.Time, error)
+	WatchActionSchedules() state.NotifyWatcher
+}
+
+type backendShim struct {
+	*state.State
+	model *state.Model
+}
+
+// RunActionSchedules is part of the Backend interface.
+func (b backendShim) RunActionSchedules() (time.Time, error) {
+	return b.model.RunActionSchedules()
+}
+
+// API implements the API used by the action schedules worker.
+type API struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewFacade creates a new API for the given model state.
+func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(backendShim{st, m}, resources, authorizer)
+}
+
+// NewAPI creates a new API using the given backend.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		resources: resources,
+	}, nil
+}
+
+// RunActionSchedules enqueues the actions of each action schedule that
+// has fallen due, and returns the time at which the next one does.
+func (api *API) RunActionSchedules() (params.RunActionSchedulesResult, error) {
+	next, err := api.backend.RunActionSchedules()
+	if err != nil {
+		return params.RunActionSchedulesResult{}, errors.Trace(err)
+	}
+	var result params.RunActionSchedulesResult
+	if !next.IsZero() {
+		result.NextRun = &next
+	}
+	return result, nil
+}
+
+// WatchActionSchedules returns a NotifyWatcher that fires when an
+// action schedule is added, changed or removed.
+func (api *API) WatchActionSchedules() (params.NotifyWatchResult, error) {
+	w := api.backend.WatchActionSchedules()
+	if _, ok := <-w.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(w),
+		}, nil
+	}
+	return params.NotifyWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(w)),
+	}, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionschedules_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/actionschedules"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionSchedulesSuite struct {
+	coretesting.BaseSuite
+
+	backend    *mockBackend
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	api        *actionschedules.API
+}
+
+var _ = gc.Suite(&ActionSchedulesSuite{})
+
+func (s *ActionSchedulesSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{Stub: &testing.Stub{}}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{Controller: true}
+
+	var err error
+	s.api, err = actionschedules.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionSchedulesSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	api, err := actionschedules.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *ActionSchedulesSuite) TestRunActionSchedules(c *gc.C) {
+	s.backend.next = time.Date(2018, time.March, 2, 2, 0, 0, 0, time.UTC)
+	result, err := s.api.RunActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RunActionSchedulesResult{NextRun: &s.backend.next})
+	s.backend.CheckCallNames(c, "RunActionSchedules")
+}
+
+func (s *ActionSchedulesSuite) TestRunActionSchedulesNoneDue(c *gc.C) {
+	result, err := s.api.RunActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.NextRun, gc.IsNil)
+}
+
+func (s *ActionSchedulesSuite) TestRunActionSchedulesError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.api.RunActionSchedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ActionSchedulesSuite) TestWatchActionSchedules(c *gc.C) {
+	result, err := s.api.WatchActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	s.backend.CheckCallNames(c, "WatchActionSchedules")
+}
+
+type mockBackend struct {
+	*testing.Stub
+	next time.Time
+}
+
+func (b *mockBackend) RunActionSchedules() (time.Time, error) {
+	b.MethodCall(b, "RunActionSchedules")
+	return b.next, b.NextErr()
+}
+
+func (b *mockBackend) WatchActionSchedules() state.NotifyWatcher {
+	b.MethodCall(b, "WatchActionSchedules")
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	return statetesting.NewMockNotifyWatcher(changes)
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package autoscaler implements the API used by the autoscaler worker.
+package autoscaler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state methods used by the API.
+type Backend interface {
+	Autoscale(state.UnitScaler) (time.Time, error)
+}
+
+// API implements the API used by the autoscaler worker.
+type API struct {
+	backend Backend
+	scaler  state.UnitScaler
+}
+
+// NewFacade creates a new API for the given model state. Units are
+// added and removed the same way as by the application facade.
+func NewFacade(st *state.State, _ facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(st, unitScaler{st}, authorizer)
+}
+
+// NewAPI creates a new API using the given backend, which adds and
+// removes units with the given scaler.
+func NewAPI(backend Backend, scaler state.UnitScaler, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend, scaler: scaler}, nil
+}
+
+// unitScaler implements state.UnitScaler using the application
+// facade's implementation of add-unit and remove-unit.
+type unitScaler struct {
+	st *state.State
+}
+
+// AddUnits is part of the state.UnitScaler interface.
+func (s unitScaler) AddUnits(appName string, n int) ([]string, error) {
+	return application.AddUnits(s.st, appName, n)
+}
+
+// DestroyUnits is part of the state.UnitScaler interface.
+func (s unitScaler) DestroyUnits(unitNames ...string) error {
+	return application.DestroyUnits(s.st, unitNames...)
+}
+
+// Autoscale scales each application according to its autoscaling
+// policy, and returns the time at which the next application waiting
+// for its policy's cooldown may be scaled.
+func (api *API) Autoscale() (params.AutoscaleResult, error) {
+	next, err := api.backend.Autoscale(api.scaler)
+	if err != nil {
+		return params.AutoscaleResult{}, errors.Trace(err)
+	}
+	var result params.AutoscaleResult
+	if !next.IsZero() {
+		result.NextRun = &next
+	}
+	return result, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscaler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/autoscaler"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AutoscalerSuite struct {
+	coretesting.BaseSuite
+
+	backend    *mockBackend
+	scaler     state.UnitScaler
+	authorizer apiservertesting.FakeAuthorizer
+	api        *autoscaler.API
+}
+
+var _ = gc.Suite(&AutoscalerSuite{})
+
+func (s *AutoscalerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{Stub: &testing.Stub{}}
+	s.scaler = &mockScaler{}
+	s.authorizer = apiservertesting.FakeAuthorizer{Controller: true}
+
+	var err error
+	s.api, err = autoscaler.NewAPI(s.backend, s.scaler, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AutoscalerSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	api, err := autoscaler.NewAPI(s.backend, s.scaler, s.authorizer)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *AutoscalerSuite) TestAutoscale(c *gc.C) {
+	s.backend.next = time.Date(2018, time.March, 2, 2, 0, 0, 0, time.UTC)
+	result, err := s.api.Autoscale()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AutoscaleResult{NextRun: &s.backend.next})
+	s.backend.CheckCalls(c, []testing.StubCall{{"Autoscale", []interface{}{s.scaler}}})
+}
+
+func (s *AutoscalerSuite) TestAutoscaleNoneWaiting(c *gc.C) {
+	result, err := s.api.Autoscale()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.NextRun, gc.IsNil)
+}
+
+func (s *AutoscalerSuite) TestAutoscaleError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.api.Autoscale()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	*testing.Stub
+	next time.Time
+}
+
+func (b *mockBackend) Autoscale(scaler state.UnitScaler) (time.Time, error) {
+	b.MethodCall(b, "Autoscale", scaler)
+	return b.next, b.NextErr()
+}
+
+type mockScaler struct {
+	state.UnitScaler
+}
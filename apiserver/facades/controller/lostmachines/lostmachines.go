@@ -0,0 +1,55 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package lostmachines implements the API used by the lost machines
+// worker.
+package lostmachines
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state methods used by the API.
+type Backend interface {
+	ReplaceLostMachines() (time.Time, error)
+}
+
+// API implements the API used by the lost machines worker.
+type API struct {
+	backend Backend
+}
+
+// NewFacade creates a new API for the given model state.
+func NewFacade(st *state.State, _ facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(st, authorizer)
+}
+
+// NewAPI creates a new API using the given backend.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// ReplaceLostMachines replaces each machine whose instance has been
+// missing from the cloud for longer than the model's grace period, and
+// returns the time at which the next one is due to be replaced.
+func (api *API) ReplaceLostMachines() (params.ReplaceLostMachinesResult, error) {
+	next, err := api.backend.ReplaceLostMachines()
+	if err != nil {
+		return params.ReplaceLostMachinesResult{}, errors.Trace(err)
+	}
+	var result params.ReplaceLostMachinesResult
+	if !next.IsZero() {
+		result.NextRun = &next
+	}
+	return result, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lostmachines_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/lostmachines"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type LostMachinesSuite struct {
+	coretesting.BaseSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *lostmachines.API
+}
+
+var _ = gc.Suite(&LostMachinesSuite{})
+
+func (s *LostMachinesSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{Stub: &testing.Stub{}}
+	s.authorizer = apiservertesting.FakeAuthorizer{Controller: true}
+
+	var err error
+	s.api, err = lostmachines.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LostMachinesSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	api, err := lostmachines.NewAPI(s.backend, s.authorizer)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *LostMachinesSuite) TestReplaceLostMachines(c *gc.C) {
+	s.backend.next = time.Date(2018, time.March, 2, 2, 0, 0, 0, time.UTC)
+	result, err := s.api.ReplaceLostMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ReplaceLostMachinesResult{NextRun: &s.backend.next})
+	s.backend.CheckCallNames(c, "ReplaceLostMachines")
+}
+
+func (s *LostMachinesSuite) TestReplaceLostMachinesNoneLost(c *gc.C) {
+	result, err := s.api.ReplaceLostMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.NextRun, gc.IsNil)
+}
+
+func (s *LostMachinesSuite) TestReplaceLostMachinesError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.api.ReplaceLostMachines()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	*testing.Stub
+	next time.Time
+}
+
+func (b *mockBackend) ReplaceLostMachines() (time.Time, error) {
+	b.MethodCall(b, "ReplaceLostMachines")
+	return b.next, b.NextErr()
+}
@@ -4,6 +4,7 @@
 package migrationtarget
 
 import (
+	"context"
 	"time"
 
 	"github.com/juju/errors"
@@ -223,7 +224,7 @@ func (api *API) AdoptResources(args params.AdoptResourcesArgs) error {
 
 // CheckMachines compares the machines in state with the ones reported
 // by the provider and reports any discrepancies.
-func (api *API) CheckMachines(args params.ModelArgs) (params.ErrorResults, error) {
+func (api *API) CheckMachines(ctx context.Context, args params.ModelArgs) (params.ErrorResults, error) {
 	var empty params.ErrorResults
 	tag, err := names.ParseModelTag(args.ModelTag)
 	if err != nil {
@@ -263,7 +264,7 @@ func (api *API) CheckMachines(args params.ModelArgs) (params.ErrorResults, error
 	if err != nil {
 		return empty, errors.Trace(err)
 	}
-	instances, err := env.AllInstances()
+	instances, err := environs.NewTracingEnviron(ctx, env).AllInstances()
 	if err != nil {
 		return empty, errors.Trace(err)
 	}
@@ -4,6 +4,7 @@
 package migrationtarget_test
 
 import (
+	"context"
 	"time"
 
 	"github.com/juju/description"
@@ -285,6 +286,7 @@ func (s *Suite) TestCheckMachinesInstancesMissing(c *gc.C) {
 	model, err := st.Model()
 	c.Assert(err, jc.ErrorIsNil)
 	results, err := api.CheckMachines(
+		context.Background(),
 		params.ModelArgs{ModelTag: model.ModelTag().String()})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -312,6 +314,7 @@ func (s *Suite) TestCheckMachinesExtraInstances(c *gc.C) {
 	model, err := st.Model()
 	c.Assert(err, jc.ErrorIsNil)
 	results, err := api.CheckMachines(
+		context.Background(),
 		params.ModelArgs{ModelTag: model.ModelTag().String()})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -329,6 +332,7 @@ func (s *Suite) TestCheckMachinesErrorGettingInstances(c *gc.C) {
 	model, err := st.Model()
 	c.Assert(err, jc.ErrorIsNil)
 	results, err := api.CheckMachines(
+		context.Background(),
 		params.ModelArgs{ModelTag: model.ModelTag().String()})
 	c.Assert(err, gc.ErrorMatches, "kablooie")
 	c.Assert(results, gc.DeepEquals, params.ErrorResults{})
@@ -358,6 +362,7 @@ func (s *Suite) TestCheckMachinesSuccess(c *gc.C) {
 	model, err := st.Model()
 	c.Assert(err, jc.ErrorIsNil)
 	results, err := api.CheckMachines(
+		context.Background(),
 		params.ModelArgs{ModelTag: model.ModelTag().String()})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ErrorResults{})
@@ -381,6 +386,7 @@ func (s *Suite) TestCheckMachinesHandlesContainers(c *gc.C) {
 	model, err := st.Model()
 	c.Assert(err, jc.ErrorIsNil)
 	results, err := api.CheckMachines(
+		context.Background(),
 		params.ModelArgs{ModelTag: model.ModelTag().String()})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ErrorResults{})
@@ -407,6 +413,7 @@ func (s *Suite) TestCheckMachinesHandlesManual(c *gc.C) {
 	model, err := st.Model()
 	c.Assert(err, jc.ErrorIsNil)
 	results, err := api.CheckMachines(
+		context.Background(),
 		params.ModelArgs{ModelTag: model.ModelTag().String()})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ErrorResults{})
@@ -0,0 +1,47 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// metricsUsername is the username that must be presented, along
+// with the configured metrics password, to read the "/metrics"
+// endpoint.
+const metricsUsername = "metrics"
+
+// metricsHandler is an http.Handler that serves Prometheus metrics to
+// clients that authenticate with the controller's metrics password.
+// Unlike the introspection endpoints, it does not require a Juju user,
+// as Prometheus scrapers can only be configured with static
+// credentials.
+type metricsHandler struct {
+	password string
+	handler  http.Handler
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h metricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authenticated(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="juju metrics"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	h.handler.ServeHTTP(w, r)
+}
+
+func (h metricsHandler) authenticated(r *http.Request) bool {
+	username, password, ok := r.BasicAuth()
+	if !ok || username != metricsUsername {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(password), []byte(h.password)) == 1
+}
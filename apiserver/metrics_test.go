@@ -0,0 +1,64 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+)
+
+type metricsHandlerSuite struct {
+	testing.IsolationSuite
+	handler http.Handler
+}
+
+var _ = gc.Suite(&metricsHandlerSuite{})
+
+func (s *metricsHandlerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.handler = apiserver.NewMetricsHandler("sekrit", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, "juju_state_txn_queue_depth 0\n")
+		},
+	))
+}
+
+func (s *metricsHandlerSuite) serve(method, username, password string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/metrics", nil)
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	w := httptest.NewRecorder()
+	s.handler.ServeHTTP(w, req)
+	return w
+}
+
+func (s *metricsHandlerSuite) TestAuthenticated(c *gc.C) {
+	w := s.serve("GET", "metrics", "sekrit")
+	c.Assert(w.Code, gc.Equals, http.StatusOK)
+	c.Assert(w.Body.String(), gc.Equals, "juju_state_txn_queue_depth 0\n")
+}
+
+func (s *metricsHandlerSuite) TestUnauthenticated(c *gc.C) {
+	for _, creds := range [][2]string{
+		{"", ""},
+		{"metrics", "wrong"},
+		{"user-admin", "sekrit"},
+	} {
+		w := s.serve("GET", creds[0], creds[1])
+		c.Check(w.Code, gc.Equals, http.StatusUnauthorized)
+		c.Check(w.Header().Get("WWW-Authenticate"), gc.Equals, `Basic realm="juju metrics"`)
+	}
+}
+
+func (s *metricsHandlerSuite) TestMethodNotAllowed(c *gc.C) {
+	w := s.serve("POST", "metrics", "sekrit")
+	c.Assert(w.Code, gc.Equals, http.StatusMethodNotAllowed)
+}
@@ -38,6 +38,10 @@ type Action struct {
 	Receiver   string                 `json:"receiver"`
 	Name       string                 `json:"name"`
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	// Operation is the id of the operation the action belongs to.
+	// It is ignored when enqueueing actions.
+	Operation string `json:"operation,omitempty"`
 }
 
 // ActionResults is a slice of ActionResult for bulk requests.
@@ -54,9 +58,23 @@ type ActionResult struct {
 	Status    string                 `json:"status,omitempty"`
 	Message   string                 `json:"message,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
+	Log       []ActionMessage        `json:"log,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
 }
 
+// ActionMessage is a timestamped progress message logged by a
+// running action.
+type ActionMessage struct {
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ActionMessageParams holds the progress messages to be logged to
+// running actions, each with the tag of its action.
+type ActionMessageParams struct {
+	Messages []EntityString `json:"messages"`
+}
+
 // ActionsByReceivers wrap a slice of Actions for API calls.
 type ActionsByReceivers struct {
 	Actions []ActionsByReceiver `json:"actions,omitempty"`
@@ -143,3 +161,172 @@ type ActionPruneArgs struct {
 	MaxHistoryTime time.Duration `json:"max-history-time"`
 	MaxHistoryMB   int           `json:"max-history-mb"`
 }
+
+// ActionRolloutArgs holds the parameters for running an action across
+// the units of an application, a batch at a time.
+type ActionRolloutArgs struct {
+	Application string                 `json:"application"`
+	Name        string                 `json:"name"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"`
+	MaxParallel int                    `json:"max-parallel"`
+	Order       string                 `json:"order,omitempty"`
+	Pause       time.Duration          `json:"pause,omitempty"`
+	MaxFailures int                    `json:"max-failures"`
+}
+
+// ActionRolloutsArgs holds the parameters for adding several action
+// rollouts.
+type ActionRolloutsArgs struct {
+	Rollouts []ActionRolloutArgs `json:"rollouts"`
+}
+
+// ActionRolloutIds holds the ids of action rollouts.
+type ActionRolloutIds struct {
+	Ids []string `json:"ids"`
+}
+
+// ActionRollout describes an action being run across the units of an
+// application.
+type ActionRollout struct {
+	Id          string                 `json:"id"`
+	Application string                 `json:"application"`
+	Name        string                 `json:"name"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"`
+	MaxParallel int                    `json:"max-parallel"`
+	Order       string                 `json:"order,omitempty"`
+	Pause       time.Duration          `json:"pause,omitempty"`
+	MaxFailures int                    `json:"max-failures"`
+	Status      string                 `json:"status"`
+	Message     string                 `json:"message,omitempty"`
+	Units       []string               `json:"units"`
+	Actions     []string               `json:"actions,omitempty"`
+	Failures    int                    `json:"failures"`
+	Operation   string                 `json:"operation,omitempty"`
+	Enqueued    time.Time              `json:"enqueued"`
+	Completed   time.Time              `json:"completed,omitempty"`
+}
+
+// ActionRolloutResult holds an action rollout or an error.
+type ActionRolloutResult struct {
+	Rollout *ActionRollout `json:"rollout,omitempty"`
+	Error   *Error         `json:"error,omitempty"`
+}
+
+// ActionRolloutResults holds the results of a bulk action rollout call.
+type ActionRolloutResults struct {
+	Results []ActionRolloutResult `json:"results"`
+}
+
+// OperationQueryArgs selects the operations to list. Empty fields
+// match every operation.
+type OperationQueryArgs struct {
+	Applications []string   `json:"applications,omitempty"`
+	ActionNames  []string   `json:"actions,omitempty"`
+	Status       []string   `json:"status,omitempty"`
+	From         *time.Time `json:"from,omitempty"`
+	To           *time.Time `json:"to,omitempty"`
+	Offset       int        `json:"offset,omitempty"`
+	Limit        int        `json:"limit,omitempty"`
+}
+
+// OperationIds holds the ids of operations.
+type OperationIds struct {
+	Ids []string `json:"ids"`
+}
+
+// OperationResult describes the actions enqueued by a single request.
+type OperationResult struct {
+	Id        string         `json:"id"`
+	Status    string         `json:"status,omitempty"`
+	Enqueued  time.Time      `json:"enqueued,omitempty"`
+	Started   time.Time      `json:"started,omitempty"`
+	Completed time.Time      `json:"completed,omitempty"`
+	Actions   []ActionResult `json:"actions,omitempty"`
+	Error     *Error         `json:"error,omitempty"`
+}
+
+// OperationResults holds a list of operations. Truncated is set when
+// more operations matched a query than were returned.
+type OperationResults struct {
+	Results   []OperationResult `json:"results"`
+	Truncated bool              `json:"truncated,omitempty"`
+}
+
+// MachineActionScript describes an operator-defined action that runs
+// a script on a machine. Params holds the schema of each parameter,
+// as in a charm's actions.yaml.
+type MachineActionScript struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Script      string                 `json:"script"`
+	Params      map[string]interface{} `json:"params,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+}
+
+// MachineActionScripts holds a list of machine actions.
+type MachineActionScripts struct {
+	Scripts []MachineActionScript `json:"scripts"`
+}
+
+// MachineActionScriptNames holds the names of machine actions.
+type MachineActionScriptNames struct {
+	Names []string `json:"names"`
+}
+
+// ActionScheduleArgs holds the parameters for running an action on a
+// recurring schedule.
+type ActionScheduleArgs struct {
+	Schedule     string                 `json:"schedule"`
+	Applications []string               `json:"applications,omitempty"`
+	Units        []string               `json:"units,omitempty"`
+	Name         string                 `json:"name"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+	Concurrency  string                 `json:"concurrency,omitempty"`
+}
+
+// ActionSchedulesArgs holds the parameters for adding several action
+// schedules.
+type ActionSchedulesArgs struct {
+	Schedules []ActionScheduleArgs `json:"schedules"`
+}
+
+// ActionScheduleIds holds the ids of action schedules.
+type ActionScheduleIds struct {
+	Ids []string `json:"ids"`
+}
+
+// ActionSchedule describes an action that is run on a schedule, and
+// the outcome of its most recent run.
+type ActionSchedule struct {
+	Id            string                 `json:"id"`
+	Schedule      string                 `json:"schedule"`
+	Applications  []string               `json:"applications,omitempty"`
+	Units         []string               `json:"units,omitempty"`
+	Name          string                 `json:"name"`
+	Parameters    map[string]interface{} `json:"parameters,omitempty"`
+	Concurrency   string                 `json:"concurrency"`
+	Created       time.Time              `json:"created"`
+	NextRun       time.Time              `json:"next-run,omitempty"`
+	LastRun       time.Time              `json:"last-run,omitempty"`
+	LastOperation string                 `json:"last-operation,omitempty"`
+	LastStatus    string                 `json:"last-status,omitempty"`
+	LastMessage   string                 `json:"last-message,omitempty"`
+}
+
+// ActionScheduleResult holds an action schedule or an error.
+type ActionScheduleResult struct {
+	Schedule *ActionSchedule `json:"schedule,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// ActionScheduleResults holds the results of a bulk action schedule
+// call.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results"`
+}
+
+// RunActionSchedulesResult holds the time at which the next action
+// schedule falls due, if there is one.
+type RunActionSchedulesResult struct {
+	NextRun *time.Time `json:"next-run,omitempty"`
+}
@@ -0,0 +1,79 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+
+	"gopkg.in/macaroon.v1"
+)
+
+// AddAPITokens holds the parameters for creating one or more
+// API tokens.
+type AddAPITokens struct {
+	Tokens []AddAPIToken `json:"tokens"`
+}
+
+// AddAPIToken holds the parameters for creating an API token
+// for the authenticated user.
+type AddAPIToken struct {
+	// Description is free-form text describing the token's purpose.
+	Description string `json:"description,omitempty"`
+
+	// ModelTags holds the tags of the models the token may be
+	// used with.
+	ModelTags []string `json:"model-tags"`
+
+	// Access is the maximum model access granted by the token.
+	Access string `json:"access"`
+
+	// Facades optionally restricts the facades that may be called
+	// using the token.
+	Facades []string `json:"facades,omitempty"`
+
+	// Expires is the time after which the token is no longer valid.
+	Expires time.Time `json:"expires"`
+}
+
+// AddAPITokenResults holds the results of an AddAPITokens call.
+type AddAPITokenResults struct {
+	Results []AddAPITokenResult `json:"results"`
+}
+
+// AddAPITokenResult holds the result of creating a single API token.
+// The macaroon is only ever returned at creation time.
+type AddAPITokenResult struct {
+	Token    *APIToken      `json:"token,omitempty"`
+	Macaroon macaroon.Slice `json:"macaroon,omitempty"`
+	Error    *Error         `json:"error,omitempty"`
+}
+
+// APIToken describes an API token issued by the controller.
+type APIToken struct {
+	Id          string    `json:"id"`
+	Owner       string    `json:"owner"`
+	Description string    `json:"description,omitempty"`
+	ModelTags   []string  `json:"model-tags"`
+	Access      string    `json:"access"`
+	Facades     []string  `json:"facades,omitempty"`
+	Created     time.Time `json:"created"`
+	Expires     time.Time `json:"expires"`
+	Revoked     bool      `json:"revoked"`
+}
+
+// APITokensResults holds the results of a ListAPITokens call.
+type APITokensResults struct {
+	Results []APITokensResult `json:"results"`
+}
+
+// APITokensResult holds the API tokens owned by a single user.
+type APITokensResult struct {
+	Tokens []APIToken `json:"tokens,omitempty"`
+	Error  *Error     `json:"error,omitempty"`
+}
+
+// RevokeAPITokens holds the ids of API tokens to revoke.
+type RevokeAPITokens struct {
+	Ids []string `json:"ids"`
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AutoscalingPolicy describes how an application is scaled in response
+// to a metric reported by its units.
+type AutoscalingPolicy struct {
+	MetricKey string        `json:"metric-key"`
+	Target    float64       `json:"target"`
+	MinUnits  int           `json:"min-units"`
+	MaxUnits  int           `json:"max-units"`
+	Cooldown  time.Duration `json:"cooldown"`
+}
+
+// SetAutoscalingPolicy holds the autoscaling policy to set for an
+// application.
+type SetAutoscalingPolicy struct {
+	ApplicationTag string            `json:"application-tag"`
+	Policy         AutoscalingPolicy `json:"policy"`
+}
+
+// SetAutoscalingPolicies holds the parameters for setting the
+// autoscaling policies of applications.
+type SetAutoscalingPolicies struct {
+	Policies []SetAutoscalingPolicy `json:"policies"`
+}
+
+// AutoscalingPolicyResult holds the autoscaling policy of an
+// application, or an error.
+type AutoscalingPolicyResult struct {
+	Result *AutoscalingPolicy `json:"result,omitempty"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// AutoscalingPolicyResults holds the results of a call to
+// AutoscalingPolicies.
+type AutoscalingPolicyResults struct {
+	Results []AutoscalingPolicyResult `json:"results"`
+}
+
+// AutoscalingDecision describes a change made to the number of an
+// application's units by its autoscaling policy.
+type AutoscalingDecision struct {
+	Time           time.Time `json:"time"`
+	MetricKey      string    `json:"metric-key"`
+	Target         float64   `json:"target"`
+	MetricValue    float64   `json:"metric-value"`
+	ReportingUnits int       `json:"reporting-units"`
+	FromUnits      int       `json:"from-units"`
+	ToUnits        int       `json:"to-units"`
+	Units          []string  `json:"units,omitempty"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// AutoscalingDecisionsResult holds the decisions made by an
+// application's autoscaling policy, most recent first, or an error.
+type AutoscalingDecisionsResult struct {
+	Decisions []AutoscalingDecision `json:"decisions,omitempty"`
+	Error     *Error                `json:"error,omitempty"`
+}
+
+// AutoscalingDecisionsResults holds the results of a call to
+// AutoscalingDecisions.
+type AutoscalingDecisionsResults struct {
+	Results []AutoscalingDecisionsResult `json:"results"`
+}
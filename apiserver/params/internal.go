@@ -685,6 +685,42 @@ type ProvisioningInfo struct {
 	ImageMetadata     []CloudImageMetadata      `json:"image-metadata,omitempty"`
 	EndpointBindings  map[string]string         `json:"endpoint-bindings,omitempty"`
 	ControllerConfig  map[string]interface{}    `json:"controller-config,omitempty"`
+	CharmLXDProfiles  []CharmLXDProfile         `json:"charm-lxd-profiles,omitempty"`
+}
+
+// CharmLXDProfile holds an LXD profile shipped with a charm, along with
+// the name of the LXD profile it is written to.
+type CharmLXDProfile struct {
+	Name        string                       `json:"name"`
+	Config      map[string]string            `json:"config,omitempty"`
+	Description string                       `json:"description,omitempty"`
+	Devices     map[string]map[string]string `json:"devices,omitempty"`
+}
+
+// CharmProfileInfoResult holds the charm LXD profiles that a machine's
+// instance should have, and the names of those that have been applied
+// to it, or an error.
+type CharmProfileInfoResult struct {
+	Profiles []CharmLXDProfile `json:"profiles,omitempty"`
+	Current  []string          `json:"current,omitempty"`
+	Error    *Error            `json:"error,omitempty"`
+}
+
+// CharmProfileInfoResults holds multiple CharmProfileInfoResults.
+type CharmProfileInfoResults struct {
+	Results []CharmProfileInfoResult `json:"results"`
+}
+
+// SetCharmProfiles holds the names of the charm LXD profiles applied
+// to a machine's instance.
+type SetCharmProfiles struct {
+	Entity   Entity   `json:"entity"`
+	Profiles []string `json:"profiles"`
+}
+
+// SetCharmProfilesArgs holds the arguments for a SetCharmProfiles call.
+type SetCharmProfilesArgs struct {
+	Args []SetCharmProfiles `json:"args"`
 }
 
 // ProvisioningInfoResult holds machine provisioning info or an error.
@@ -831,3 +867,15 @@ type EntityString struct {
 type SetContainerSpecParams struct {
 	Entities []EntityString `json:"entities"`
 }
+
+// ReplaceLostMachinesResult holds the time at which the next lost
+// machine is due to be replaced, if there is one.
+type ReplaceLostMachinesResult struct {
+	NextRun *time.Time `json:"next-run,omitempty"`
+}
+
+// AutoscaleResult holds the time at which the next application waiting
+// for its autoscaling policy's cooldown may be scaled, if there is one.
+type AutoscaleResult struct {
+	NextRun *time.Time `json:"next-run,omitempty"`
+}
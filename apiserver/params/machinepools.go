@@ -0,0 +1,27 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// MachinePool describes a named pool of machines, and the applications
+// whose units may be placed on them.
+type MachinePool struct {
+	Name         string            `json:"name"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Applications []string          `json:"applications,omitempty"`
+
+	// Machines holds the ids of the pool's machines. It is
+	// ignored when adding or updating a pool.
+	Machines []string `json:"machines,omitempty"`
+}
+
+// MachinePools holds the parameters for adding or updating machine
+// pools.
+type MachinePools struct {
+	Pools []MachinePool `json:"pools"`
+}
+
+// MachinePoolsResult holds the machine pools in a model.
+type MachinePoolsResult struct {
+	Pools []MachinePool `json:"pools"`
+}
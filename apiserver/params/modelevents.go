@@ -0,0 +1,45 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// ModelEventsArgs holds the parameters for fetching the events that
+// have happened in a model.
+type ModelEventsArgs struct {
+	// Cursor, if set, is the cursor returned by an earlier call; only
+	// events after those already returned are fetched.
+	Cursor string `json:"cursor,omitempty"`
+
+	// Since, if set and Cursor is not, is the time from which events
+	// are fetched.
+	Since *time.Time `json:"since,omitempty"`
+
+	// Sources restricts the events to those from the given sources:
+	// "status", "action", "cleanup" and "audit". If it is empty,
+	// events from every source are fetched.
+	Sources []string `json:"sources,omitempty"`
+
+	// Limit is the most events to return. If it is zero, a default
+	// limit is used.
+	Limit int `json:"limit,omitempty"`
+}
+
+// ModelEvent describes something that happened in a model.
+type ModelEvent struct {
+	Source  string    `json:"source"`
+	Id      string    `json:"id"`
+	Time    time.Time `json:"time"`
+	Entity  string    `json:"entity,omitempty"`
+	Type    string    `json:"type"`
+	Status  string    `json:"status,omitempty"`
+	Message string    `json:"message,omitempty"`
+}
+
+// ModelEventsResult holds events from a model, ordered by time, and
+// the cursor from which to fetch the events that follow them.
+type ModelEventsResult struct {
+	Events []ModelEvent `json:"events"`
+	Cursor string       `json:"cursor"`
+}
@@ -96,6 +96,10 @@ type NetworkConfig struct {
 	// is attached to, if known and supported.
 	ProviderSpaceId string `json:"provider-space-id"`
 
+	// SpaceName is the name of the Juju space the interface is in, if
+	// known.
+	SpaceName string `json:"space-name,omitempty"`
+
 	// ProviderAddressId is the provider-specific id of the assigned address, if
 	// supported and known.
 	ProviderAddressId string `json:"provider-address-id"`
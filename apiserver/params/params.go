@@ -201,6 +201,10 @@ type AddMachineParams struct {
 	// constraints and jobs.
 	ContainerType instance.ContainerType `json:"container-type"`
 
+	// Pool optionally names the machine pool the new machine
+	// joins. Containers always join the pool of their host.
+	Pool string `json:"pool,omitempty"`
+
 	// If InstanceId is non-empty, it will be associated with
 	// the new machine along with the given nonce,
 	// hardware characteristics and addresses.
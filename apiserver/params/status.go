@@ -16,6 +16,15 @@ import (
 // StatusParams holds parameters for the Status call.
 type StatusParams struct {
 	Patterns []string `json:"patterns"`
+
+	// Filters holds expressions of the form <field><op><value>, all of
+	// which an entity must satisfy to be included in the status.
+	Filters []string `json:"filters,omitempty"`
+
+	// Fields holds dotted paths of the fields to be included in the
+	// status, such as "applications.units.workload-status". All
+	// fields are included if it is empty.
+	Fields []string `json:"fields,omitempty"`
 }
 
 // TODO(ericsnow) Add FullStatusResult.
@@ -210,6 +219,7 @@ type History struct {
 type StatusHistoryFilter struct {
 	Size    int            `json:"size"`
 	Date    *time.Time     `json:"date"`
+	ToDate  *time.Time     `json:"to-date,omitempty"`
 	Delta   *time.Duration `json:"delta"`
 	Exclude []string       `json:"exclude"`
 }
@@ -238,6 +248,50 @@ type StatusHistoryResults struct {
 	Results []StatusHistoryResult `json:"results"`
 }
 
+// StatusHistoryTimelineArgs holds the parameters for fetching the
+// merged status history of many entities over a period of time.
+type StatusHistoryTimelineArgs struct {
+	// Entities holds the tags of the units and machines whose history
+	// is wanted. If it is empty, every unit and machine in the model
+	// is included.
+	Entities []string `json:"entities,omitempty"`
+
+	// Kinds restricts the history to the given status kinds. If it is
+	// empty, every kind is included.
+	Kinds []string `json:"kinds,omitempty"`
+
+	// From is the start of the period, exclusive. To continue a
+	// timeline that was truncated, pass the Next time of the previous
+	// result.
+	From    time.Time  `json:"from"`
+	To      *time.Time `json:"to,omitempty"`
+	Exclude []string   `json:"exclude,omitempty"`
+
+	// Limit is the maximum number of entries to return. If it is
+	// zero, a default limit is used.
+	Limit int `json:"limit,omitempty"`
+}
+
+// StatusHistoryEntry holds a single status history record of an entity.
+type StatusHistoryEntry struct {
+	Entity string                 `json:"entity"`
+	Kind   string                 `json:"kind"`
+	Status string                 `json:"status"`
+	Info   string                 `json:"info"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Since  *time.Time             `json:"since"`
+}
+
+// StatusHistoryTimelineResult holds the status history of many
+// entities, ordered by time.
+type StatusHistoryTimelineResult struct {
+	Entries []StatusHistoryEntry `json:"entries"`
+
+	// Next, if not nil, indicates that the timeline was truncated by
+	// the limit, and is the From time from which to fetch the rest.
+	Next *time.Time `json:"next,omitempty"`
+}
+
 // StatusHistoryPruneArgs holds arguments for status history
 // prunning process.
 type StatusHistoryPruneArgs struct {
@@ -0,0 +1,39 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// apiTokenFacadesOnly returns a restriction check for connections
+// authenticated with an API token, allowing only the facades that
+// allowsFacade reports the token permits.
+//
+// The Pinger facade and the watcher facades are always allowed, as
+// clients need them to keep the connection alive and watchers can only
+// be obtained through a call on an allowed facade. The APITokens facade
+// is never allowed, so that a token can't be used to issue or revoke
+// tokens.
+func apiTokenFacadesOnly(allowsFacade func(string) bool) func(string, string) error {
+	return func(facadeName, _ string) error {
+		if !isAPITokenFacade(facadeName, allowsFacade) {
+			return errors.NewNotSupported(nil, fmt.Sprintf("facade %q not supported for API token connection", facadeName))
+		}
+		return nil
+	}
+}
+
+func isAPITokenFacade(facadeName string, allowsFacade func(string) bool) bool {
+	switch {
+	case facadeName == "APITokens":
+		return false
+	case facadeName == "Pinger", strings.HasSuffix(facadeName, "Watcher"):
+		return true
+	}
+	return allowsFacade(facadeName)
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/testing"
+)
+
+type RestrictAPITokenSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&RestrictAPITokenSuite{})
+
+func (s *RestrictAPITokenSuite) TestAllowed(c *gc.C) {
+	root := apiserver.TestingAPITokenRoot("Client")
+	s.assertMethod(c, root, "Client", 1, "FullStatus")
+	s.assertMethod(c, root, "Pinger", 1, "Ping")
+	s.assertMethod(c, root, "AllWatcher", 1, "Next")
+}
+
+func (s *RestrictAPITokenSuite) TestAllFacadesAllowed(c *gc.C) {
+	root := apiserver.TestingAPITokenRoot()
+	s.assertMethod(c, root, "Client", 1, "FullStatus")
+	s.assertMethod(c, root, "Application", 5, "Deploy")
+}
+
+func (s *RestrictAPITokenSuite) TestNotAllowed(c *gc.C) {
+	root := apiserver.TestingAPITokenRoot("Client")
+	caller, err := root.FindMethod("Application", 5, "Deploy")
+	c.Assert(err, gc.ErrorMatches, `facade "Application" not supported for API token connection`)
+	c.Assert(errors.IsNotSupported(err), jc.IsTrue)
+	c.Assert(caller, gc.IsNil)
+}
+
+func (s *RestrictAPITokenSuite) TestAPITokensNeverAllowed(c *gc.C) {
+	for _, facades := range [][]string{nil, {"APITokens"}} {
+		root := apiserver.TestingAPITokenRoot(facades...)
+		caller, err := root.FindMethod("APITokens", 1, "AddAPITokens")
+		c.Check(err, gc.ErrorMatches, `facade "APITokens" not supported for API token connection`)
+		c.Check(caller, gc.IsNil)
+	}
+}
+
+func (s *RestrictAPITokenSuite) assertMethod(c *gc.C, root rpc.Root, facadeName string, version int, method string) {
+	caller, err := root.FindMethod(facadeName, version, method)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(caller, gc.NotNil)
+}
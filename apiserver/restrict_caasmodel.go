@@ -28,6 +28,7 @@ var commonModelFacadeNames = set.NewStrings(
 	"MigrationStatusWatcher",
 	"MigrationTarget",
 	"ModelConfig",
+	"ModelEvents",
 	"ModelUpgrader",
 	"NotifyWatcher",
 	"Pinger",
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/rpcreflect"
@@ -56,6 +57,13 @@ type apiHandler struct {
 	// serverHost is the host:port of the API server that the client
 	// connected to.
 	serverHost string
+
+	// connectionID is the server-unique id of the API connection.
+	connectionID uint64
+
+	// apiToken holds the API token used to log in, if any. It
+	// restricts the permissions of the logged in user.
+	apiToken *state.APIToken
 }
 
 var _ = (*apiHandler)(nil)
@@ -123,7 +131,7 @@ func (r *apiHandler) Kill() {
 type srvCaller struct {
 	objMethod rpcreflect.ObjMethod
 	goType    reflect.Type
-	creator   func(id string) (reflect.Value, error)
+	creator   func(id string) (facadeObject, error)
 }
 
 // ParamsType defines the parameters that should be supplied to this function.
@@ -141,11 +149,22 @@ func (s *srvCaller) ResultType() reflect.Type {
 // Call takes the object Id and an instance of ParamsType to create an object and place
 // a call on its method. It then returns an instance of ResultType.
 func (s *srvCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
-	objVal, err := s.creator(objId)
+	obj, err := s.creator(objId)
 	if err != nil {
 		return reflect.Value{}, err
 	}
-	return s.objMethod.Call(ctx, objVal, arg)
+	defer obj.calls.Enter(ctx)()
+	return s.objMethod.Call(ctx, obj.value, arg)
+}
+
+// facadeObject holds a facade cached by an apiRoot.
+type facadeObject struct {
+	value reflect.Value
+
+	// calls tracks the calls in flight on the facade, so that the
+	// transactions it runs are recorded as spans of the trace of
+	// the call that caused them.
+	calls *tracing.Calls
 }
 
 // apiRoot implements basic method dispatching to the facade registry.
@@ -156,7 +175,7 @@ type apiRoot struct {
 	resources   *common.Resources
 	authorizer  facade.Authorizer
 	objectMutex sync.RWMutex
-	objectCache map[objectKey]reflect.Value
+	objectCache map[objectKey]facadeObject
 }
 
 // newAPIRoot returns a new apiRoot.
@@ -167,7 +186,7 @@ func newAPIRoot(st *state.State, pool *state.StatePool, facades *facade.Registry
 		facades:     facades,
 		resources:   resources,
 		authorizer:  authorizer,
-		objectCache: make(map[objectKey]reflect.Value),
+		objectCache: make(map[objectKey]facadeObject),
 	}
 	return r
 }
@@ -200,6 +219,9 @@ func restrictAPIRoot(
 			apiRoot = restrictRoot(apiRoot, caasModelFacadesOnly)
 		}
 	}
+	if auth.apiToken != nil {
+		apiRoot = restrictRoot(apiRoot, apiTokenFacadesOnly(auth.apiToken.AllowsFacade))
+	}
 	return apiRoot, nil
 }
 
@@ -279,50 +301,33 @@ func (r *apiRoot) FindMethod(rootName string, version int, methodName string) (r
 		return nil, err
 	}
 
-	creator := func(id string) (reflect.Value, error) {
+	creator := func(id string) (facadeObject, error) {
 		objKey := objectKey{name: rootName, version: version, objId: id}
 		r.objectMutex.RLock()
-		objValue, ok := r.objectCache[objKey]
+		obj, ok := r.objectCache[objKey]
 		r.objectMutex.RUnlock()
 		if ok {
-			return objValue, nil
+			return obj, nil
 		}
 		r.objectMutex.Lock()
 		defer r.objectMutex.Unlock()
-		if objValue, ok := r.objectCache[objKey]; ok {
-			return objValue, nil
+		if obj, ok := r.objectCache[objKey]; ok {
+			return obj, nil
 		}
 		// Now that we have the write lock, check one more time in case
 		// someone got the write lock before us.
-		factory, err := r.facades.GetFactory(rootName, version)
-		if err != nil {
-			// We don't check for IsNotFound here, because it
-			// should have already been handled in the GetType
-			// check.
-			return reflect.Value{}, err
+		calls := new(tracing.Calls)
+		facadeCtx := r.facadeContext(objKey)
+		if r.state != nil {
+			facadeCtx.state = r.state.WithContextFunc(calls.Context)
 		}
-		obj, err := factory(r.facadeContext(objKey))
+		objValue, err := r.newFacade(rootName, version, goType, facadeCtx)
 		if err != nil {
-			return reflect.Value{}, err
+			return facadeObject{}, err
 		}
-		objValue = reflect.ValueOf(obj)
-		if !objValue.Type().AssignableTo(goType) {
-			return reflect.Value{}, errors.Errorf(
-				"internal error, %s(%d) claimed to return %s but returned %T",
-				rootName, version, goType, obj)
-		}
-		if goType.Kind() == reflect.Interface {
-			// If the original function wanted to return an
-			// interface type, the indirection in the factory via
-			// an interface{} strips the original interface
-			// information off. So here we have to create the
-			// interface again, and assign it.
-			asInterface := reflect.New(goType).Elem()
-			asInterface.Set(objValue)
-			objValue = asInterface
-		}
-		r.objectCache[objKey] = objValue
-		return objValue, nil
+		obj = facadeObject{value: objValue, calls: calls}
+		r.objectCache[objKey] = obj
+		return obj, nil
 	}
 	return &srvCaller{
 		creator:   creator,
@@ -330,6 +335,39 @@ func (r *apiRoot) FindMethod(rootName string, version int, methodName string) (r
 	}, nil
 }
 
+// newFacade creates the named facade from the registry, checking that
+// it has the type registered for it.
+func (r *apiRoot) newFacade(rootName string, version int, goType reflect.Type, ctx *facadeContext) (reflect.Value, error) {
+	factory, err := r.facades.GetFactory(rootName, version)
+	if err != nil {
+		// We don't check for IsNotFound here, because it
+		// should have already been handled in the GetType
+		// check.
+		return reflect.Value{}, err
+	}
+	obj, err := factory(ctx)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	objValue := reflect.ValueOf(obj)
+	if !objValue.Type().AssignableTo(goType) {
+		return reflect.Value{}, errors.Errorf(
+			"internal error, %s(%d) claimed to return %s but returned %T",
+			rootName, version, goType, obj)
+	}
+	if goType.Kind() == reflect.Interface {
+		// If the original function wanted to return an
+		// interface type, the indirection in the factory via
+		// an interface{} strips the original interface
+		// information off. So here we have to create the
+		// interface again, and assign it.
+		asInterface := reflect.New(goType).Elem()
+		asInterface.Set(objValue)
+		objValue = asInterface
+	}
+	return objValue, nil
+}
+
 func (r *apiRoot) lookupMethod(rootName string, version int, methodName string) (reflect.Type, rpcreflect.ObjMethod, error) {
 	noMethod := rpcreflect.ObjMethod{}
 	goType, err := r.facades.GetType(rootName, version)
@@ -374,6 +412,9 @@ func (r *apiRoot) facadeContext(key objectKey) *facadeContext {
 type facadeContext struct {
 	r   *apiRoot
 	key objectKey
+
+	// state, if set, is used in place of the root's state.
+	state *state.State
 }
 
 // Auth is part of of the facade.Context interface.
@@ -393,6 +434,9 @@ func (ctx *facadeContext) Resources() facade.Resources {
 
 // State is part of of the facade.Context interface.
 func (ctx *facadeContext) State() *state.State {
+	if ctx.state != nil {
+		return ctx.state
+	}
 	return ctx.r.state
 }
 
@@ -493,9 +537,25 @@ func (r *apiHandler) ConnectedModel() string {
 
 // HasPermission returns true if the logged in user can perform <operation> on <target>.
 func (r *apiHandler) HasPermission(operation permission.Access, target names.Tag) (bool, error) {
+	if r.apiToken != nil && !apiTokenPermits(r.apiToken, operation, target) {
+		return false, nil
+	}
 	return common.HasPermission(r.state.UserPermission, r.entity.Tag(), operation, target)
 }
 
+// apiTokenPermits reports whether the API token allows <operation> on
+// <target>. Tokens grant at most their recorded access to the models
+// they are scoped to, and never more than login access to the controller.
+func apiTokenPermits(token *state.APIToken, operation permission.Access, target names.Tag) bool {
+	switch target.Kind() {
+	case names.ModelTagKind:
+		return token.HasModel(target.Id()) && token.Access().EqualOrGreaterModelAccessThan(operation)
+	case names.ControllerTagKind:
+		return operation == permission.LoginAccess
+	}
+	return false
+}
+
 // UserHasPermission returns true if the passed in user can perform <operation> on <target>.
 func (r *apiHandler) UserHasPermission(user names.UserTag, operation permission.Access, target names.Tag) (bool, error) {
 	return common.HasPermission(r.state.UserPermission, user, operation, target)
@@ -12,13 +12,17 @@ import (
 
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/tracing"
+	"github.com/juju/juju/core/tracing/tracingtest"
 	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
 )
 
@@ -276,6 +280,34 @@ func (r *rootSuite) TestFindMethodCachesFacadesWithId(c *gc.C) {
 	assertCallResult(c, caller, "third-id", "ALT-third-id3")
 }
 
+func (r *rootSuite) TestFindMethodTracedCallsUseCachedFacade(c *gc.C) {
+	registry := new(facade.Registry)
+	var count int64
+	newCounter := func(
+		*state.State, facade.Resources, facade.Authorizer,
+	) (
+		*countingType, error,
+	) {
+		count += 1
+		return &countingType{count: count, id: ""}, nil
+	}
+	registry.RegisterStandard("my-counting-facade", 0, newCounter)
+	srvRoot := apiserver.TestingAPIRoot(registry)
+	caller, err := srvRoot.FindMethod("my-counting-facade", 0, "Count")
+	c.Assert(err, jc.ErrorIsNil)
+
+	exporter := &tracingtest.Exporter{}
+	ctx := tracing.WithTracer(context.Background(), tracing.NewTracer(exporter, clock.WallClock))
+	ctx, span := tracing.Start(ctx, "request")
+	defer span.End()
+	for i := 0; i < 2; i++ {
+		v, err := caller.Call(ctx, "", reflect.Value{})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(v.Interface(), gc.Equals, stringVar{"1"})
+	}
+	assertCallResult(c, caller, "", "1")
+}
+
 func (r *rootSuite) TestFindMethodCacheRaceSafe(c *gc.C) {
 	var count int64
 	newIdCounter := func(context facade.Context) (facade.Facade, error) {
@@ -405,3 +437,62 @@ func (r *rootSuite) TestAuthOwner(c *gc.C) {
 
 	c.Check(authorized, jc.IsFalse)
 }
+
+type rootStateSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&rootStateSuite{})
+
+type machineAdder struct {
+	st *state.State
+}
+
+func (m *machineAdder) AddMachine() (stringVar, error) {
+	machine, err := m.st.AddMachine("quantal", state.JobHostUnits)
+	if err != nil {
+		return stringVar{}, err
+	}
+	return stringVar{machine.Id()}, nil
+}
+
+func (s *rootStateSuite) TestTracedCallsRecordTransactions(c *gc.C) {
+	registry := new(facade.Registry)
+	var count int
+	newMachineAdder := func(
+		st *state.State, _ facade.Resources, _ facade.Authorizer,
+	) (
+		*machineAdder, error,
+	) {
+		count++
+		return &machineAdder{st}, nil
+	}
+	registry.RegisterStandard("machine-adder", 0, newMachineAdder)
+	srvRoot := apiserver.TestingAPIRootWithState(s.State, registry)
+	caller, err := srvRoot.FindMethod("machine-adder", 0, "AddMachine")
+	c.Assert(err, jc.ErrorIsNil)
+
+	exporter := &tracingtest.Exporter{}
+	tracerCtx := tracing.WithTracer(context.Background(), tracing.NewTracer(exporter, clock.WallClock))
+	for i := 0; i < 2; i++ {
+		ctx, span := tracing.Start(tracerCtx, "request")
+		_, err := caller.Call(ctx, "", reflect.Value{})
+		c.Assert(err, jc.ErrorIsNil)
+		span.End()
+	}
+	_, err = caller.Call(context.Background(), "", reflect.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// All the calls were served by the cached facade, but only the
+	// traced calls' transactions were recorded, as children of the
+	// calls' spans.
+	c.Assert(count, gc.Equals, 1)
+	spans := exporter.Spans()
+	c.Assert(spans, gc.HasLen, 4)
+	for i := 0; i < 4; i += 2 {
+		c.Check(spans[i].Name, gc.Equals, "state.RunTransaction")
+		c.Check(spans[i+1].Name, gc.Equals, "request")
+		c.Check(spans[i].ParentID, gc.Equals, spans[i+1].SpanID)
+	}
+	c.Check(spans[0].TraceID, gc.Not(gc.Equals), spans[2].TraceID)
+}
@@ -4,6 +4,8 @@
 package apiserver
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/apiserver/common"
@@ -15,6 +17,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/state"
 )
 
@@ -96,6 +99,10 @@ func isAgent(auth facade.Authorizer) bool {
 	return auth.AuthMachineAgent() || auth.AuthUnitAgent() || auth.AuthApplicationAgent()
 }
 
+func isAgentOrUser(auth facade.Authorizer) bool {
+	return isAgent(auth) || auth.AuthClient()
+}
+
 func newNotifyWatcher(context facade.Context) (facade.Facade, error) {
 	id := context.ID()
 	auth := context.Auth()
@@ -128,6 +135,44 @@ func (w *srvNotifyWatcher) Next() error {
 	return err
 }
 
+// srvNotifyWatcherV2 extends srvNotifyWatcher with a streaming
+// alternative to Next.
+type srvNotifyWatcherV2 struct {
+	*srvNotifyWatcher
+}
+
+func newNotifyWatcherV2(context facade.Context) (facade.Facade, error) {
+	w, err := newNotifyWatcher(context)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &srvNotifyWatcherV2{w.(*srvNotifyWatcher)}, nil
+}
+
+// Stream sends a partial response each time a change occurs to the
+// entity being watched, until the watcher is stopped. As with Next,
+// the change reported by the Watch call that created the watcher is
+// not repeated.
+func (w *srvNotifyWatcherV2) Stream(ctx context.Context) error {
+	sender, ok := rpc.SenderFromContext(ctx)
+	if !ok {
+		return errors.NotSupportedf("streaming")
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-w.watcher.Changes():
+			if !ok {
+				return stoppedWatcherError(w.watcher)
+			}
+			if err := sender.Send(struct{}{}); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
 // srvStringsWatcher defines the API for methods on a state.StringsWatcher.
 // Each client has its own current set of watchers, stored in resources.
 // srvStringsWatcher notifies about changes for all entities of a given kind,
@@ -144,7 +189,8 @@ func newStringsWatcher(context facade.Context) (facade.Facade, error) {
 
 	// TODO(wallyworld) - enhance this watcher to support
 	// anonymous api calls with macaroons.
-	if auth.GetAuthTag() != nil && !isAgent(auth) {
+	// Users need this to watch the progress of actions.
+	if auth.GetAuthTag() != nil && !isAgentOrUser(auth) {
 		return nil, common.ErrPerm
 	}
 	watcher, ok := resources.Get(id).(state.StringsWatcher)
@@ -173,6 +219,87 @@ func (w *srvStringsWatcher) Next() (params.StringsWatchResult, error) {
 	return params.StringsWatchResult{}, err
 }
 
+// srvStringsWatcherV2 extends srvStringsWatcher with a streaming
+// alternative to Next.
+type srvStringsWatcherV2 struct {
+	*srvStringsWatcher
+}
+
+func newStringsWatcherV2(context facade.Context) (facade.Facade, error) {
+	w, err := newStringsWatcher(context)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &srvStringsWatcherV2{w.(*srvStringsWatcher)}, nil
+}
+
+// Stream sends a partial params.StringsWatchResult each time a change
+// occurs to an entity of the collection being watched, until the
+// watcher is stopped. Changes that are already waiting when one is
+// sent are combined with it, so that a busy watcher sends fewer,
+// larger batches.
+func (w *srvStringsWatcherV2) Stream(ctx context.Context) error {
+	sender, ok := rpc.SenderFromContext(ctx)
+	if !ok {
+		return errors.NotSupportedf("streaming")
+	}
+	for {
+		var changes []string
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case more, ok := <-w.watcher.Changes():
+			if !ok {
+				return stoppedWatcherError(w.watcher)
+			}
+			changes = more
+		}
+	batch:
+		for {
+			select {
+			case more, ok := <-w.watcher.Changes():
+				if !ok {
+					// Send what we have; the next receive
+					// will report the error.
+					break batch
+				}
+				changes = mergeChanges(changes, more)
+			default:
+				break batch
+			}
+		}
+		if err := sender.Send(params.StringsWatchResult{Changes: changes}); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// mergeChanges returns the union of the given sets of changes,
+// preserving the order in which they were first seen.
+func mergeChanges(changes, more []string) []string {
+	seen := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		seen[change] = true
+	}
+	for _, change := range more {
+		if !seen[change] {
+			seen[change] = true
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// stoppedWatcherError returns the error that caused the given watcher
+// to stop, or common.ErrStoppedWatcher if it stopped cleanly.
+func stoppedWatcherError(w state.Watcher) error {
+	err := w.Err()
+	if err == nil {
+		err = common.ErrStoppedWatcher
+	}
+	return err
+}
+
 // srvRelationUnitsWatcher defines the API wrapping a state.RelationUnitsWatcher.
 // It notifies about units entering and leaving the scope of a RelationUnit,
 // and changes to the settings of those units known to have entered.
@@ -4,6 +4,8 @@
 package apiserver_test
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -18,6 +20,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
@@ -154,6 +157,66 @@ func (s *watcherSuite) TestMigrationStatusWatcherNotAgent(c *gc.C) {
 	c.Assert(err, gc.Equals, common.ErrPerm)
 }
 
+func (s *watcherSuite) TestNotifyWatcherStream(c *gc.C) {
+	w := apiservertesting.NewFakeNotifyWatcher()
+	close(w.C)
+	id := s.resources.Register(w)
+	s.authorizer.Tag = names.NewMachineTag("123")
+
+	var sender fakeSender
+	ctx := rpc.WithSender(context.Background(), &sender)
+	facade := s.getFacade(c, "NotifyWatcher", 2, id, nopDispose).(streamingWatcher)
+	err := facade.Stream(ctx)
+	c.Assert(err, gc.Equals, common.ErrStoppedWatcher)
+	c.Assert(sender.sent, jc.DeepEquals, []interface{}{struct{}{}})
+}
+
+func (s *watcherSuite) TestStringsWatcherStreamBatches(c *gc.C) {
+	ch := make(chan []string, 2)
+	id := s.resources.Register(&fakeStringsWatcher{ch: ch})
+	s.authorizer.Tag = names.NewMachineTag("123")
+
+	ch <- []string{"a", "b"}
+	ch <- []string{"b", "c"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sender := fakeSender{onSend: cancel}
+	ctx = rpc.WithSender(ctx, &sender)
+	facade := s.getFacade(c, "StringsWatcher", 2, id, nopDispose).(streamingWatcher)
+	err := facade.Stream(ctx)
+	c.Assert(err, gc.Equals, context.Canceled)
+	c.Assert(sender.sent, jc.DeepEquals, []interface{}{
+		params.StringsWatchResult{Changes: []string{"a", "b", "c"}},
+	})
+}
+
+func (s *watcherSuite) TestStreamWithoutSender(c *gc.C) {
+	id := s.resources.Register(apiservertesting.NewFakeNotifyWatcher())
+	s.authorizer.Tag = names.NewMachineTag("123")
+
+	facade := s.getFacade(c, "NotifyWatcher", 2, id, nopDispose).(streamingWatcher)
+	err := facade.Stream(context.Background())
+	c.Assert(err, gc.ErrorMatches, "streaming not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+type streamingWatcher interface {
+	Stream(context.Context) error
+}
+
+type fakeSender struct {
+	sent   []interface{}
+	onSend func()
+}
+
+func (s *fakeSender) Send(body interface{}) error {
+	s.sent = append(s.sent, body)
+	if s.onSend != nil {
+		s.onSend()
+	}
+	return nil
+}
+
 type machineStorageIdsWatcher interface {
 	Next() (params.MachineStorageIdsWatchResult, error)
 }
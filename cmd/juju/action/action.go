@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/watcher"
 )
 
 // type APIClient represents the action API functionality.
@@ -56,6 +57,49 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// WatchActionProgress returns a watcher that reports the progress
+	// messages logged by the action with the given id.
+	WatchActionProgress(actionId string) (watcher.StringsWatcher, error)
+
+	// AddActionRollouts runs actions across the units of applications,
+	// a batch at a time.
+	AddActionRollouts(params.ActionRolloutsArgs) (params.ActionRolloutResults, error)
+
+	// ActionRollouts returns the action rollouts with the given ids, or
+	// all rollouts if no ids are given.
+	ActionRollouts(params.ActionRolloutIds) (params.ActionRolloutResults, error)
+
+	// CancelActionRollouts stops the action rollouts with the given ids.
+	CancelActionRollouts(params.ActionRolloutIds) (params.ErrorResults, error)
+
+	// ListOperations returns the operations that match the query,
+	// newest first.
+	ListOperations(params.OperationQueryArgs) (params.OperationResults, error)
+
+	// Operations returns the operations with the given ids.
+	Operations(params.OperationIds) (params.OperationResults, error)
+
+	// AddMachineActionScripts defines actions that can be run on any
+	// machine in the model.
+	AddMachineActionScripts(params.MachineActionScripts) (params.ErrorResults, error)
+
+	// RemoveMachineActionScripts removes the named machine actions.
+	RemoveMachineActionScripts(params.MachineActionScriptNames) (params.ErrorResults, error)
+
+	// MachineActionScripts returns the model's machine actions.
+	MachineActionScripts() (params.MachineActionScripts, error)
+
+	// AddActionSchedules adds schedules that run actions on units at
+	// recurring times.
+	AddActionSchedules(params.ActionSchedulesArgs) (params.ActionScheduleResults, error)
+
+	// ActionSchedules returns the model's action schedules.
+	ActionSchedules() (params.ActionScheduleResults, error)
+
+	// RemoveActionSchedules removes the action schedules with the
+	// given ids.
+	RemoveActionSchedules(params.ActionScheduleIds) (params.ErrorResults, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
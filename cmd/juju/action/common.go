@@ -4,6 +4,11 @@
 package action
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -53,6 +58,74 @@ func getActionTagByPrefix(api APIClient, prefix string) (names.ActionTag, error)
 	return actiontags[0], nil
 }
 
+// watchProgress writes the progress messages logged by the action with
+// the given id to w as they arrive, until the returned function is
+// called. If the controller cannot report action progress, nothing is
+// written.
+func watchProgress(api APIClient, actionId string, w io.Writer) (func(), error) {
+	watcher, err := api.WatchActionProgress(actionId)
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not watching progress of action %s: %v", actionId, err)
+		return func() {}, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	write := func(changes []string) {
+		for _, change := range changes {
+			var message params.ActionMessage
+			if err := json.Unmarshal([]byte(change), &message); err != nil {
+				logger.Warningf("cannot decode progress of action %s: %v", actionId, err)
+				continue
+			}
+			fmt.Fprintln(w, formatActionMessage(message))
+		}
+	}
+
+	stopped := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stopped:
+				// Write out anything that arrived before we
+				// were stopped.
+				for {
+					select {
+					case changes, ok := <-watcher.Changes():
+						if !ok {
+							return
+						}
+						write(changes)
+					default:
+						return
+					}
+				}
+			case changes, ok := <-watcher.Changes():
+				if !ok {
+					return
+				}
+				write(changes)
+			}
+		}
+	}()
+	return func() {
+		close(stopped)
+		<-done
+		watcher.Kill()
+		if err := watcher.Wait(); err != nil {
+			logger.Debugf("watching progress of action %s: %v", actionId, err)
+		}
+	}, nil
+}
+
+// formatActionMessage renders a progress message logged by an action.
+func formatActionMessage(message params.ActionMessage) string {
+	return fmt.Sprintf("%s %s", message.Timestamp.Format(time.RFC3339), message.Message)
+}
+
 // getActionTags converts a slice of params.Entity to a slice of names.ActionTag, and
 // also populates a slice of strings for the params.Entity.Tag that are not a valid
 // names.ActionTag.
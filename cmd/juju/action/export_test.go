@@ -33,8 +33,8 @@ type RunCommand struct {
 	*runCommand
 }
 
-func (c *RunCommand) UnitTags() []names.UnitTag {
-	return c.unitTags
+func (c *RunCommand) Receivers() []names.Tag {
+	return c.receivers
 }
 
 func (c *RunCommand) ActionName() string {
@@ -95,6 +95,72 @@ func NewRunCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RunComman
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &RunCommand{c}
 }
 
+func NewRolloutCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &rolloutCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewShowRolloutCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &showRolloutCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewCancelRolloutCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &cancelRolloutCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewListOperationsCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listOperationsCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewShowOperationCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &showOperationCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewAddMachineActionCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &addMachineActionCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewListMachineActionsCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listMachineActionsCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveMachineActionCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &removeMachineActionCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewAddScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &addScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewListSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listSchedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &removeScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
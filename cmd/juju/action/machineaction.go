@@ -0,0 +1,312 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewAddMachineActionCommand returns a command that defines an action
+// that can be run on any machine in a model.
+func NewAddMachineActionCommand() cmd.Command {
+	return modelcmd.Wrap(&addMachineActionCommand{})
+}
+
+// addMachineActionCommand defines a machine action.
+type addMachineActionCommand struct {
+	ActionCommandBase
+	name        string
+	script      cmd.FileVar
+	schema      cmd.FileVar
+	description string
+}
+
+const addMachineActionDoc = `
+Define an action that runs the given script on a machine. Once defined,
+the action can be run on any machine in the model with run-action.
+
+The script must start with an interpreter line, such as "#!/bin/bash".
+It runs as the ubuntu user, with the action's name in $JUJU_ACTION_NAME
+and its parameters, encoded as JSON, in $JUJU_ACTION_PARAMS. The
+script's exit code and output are recorded as the action's results.
+
+The action's parameters are described by a YAML file passed with
+--schema, in the same form as an action in a charm's actions.yaml:
+
+    description: Collect a sosreport.
+    params:
+      case-id:
+        type: string
+        description: The support case to attach the report to.
+    required: [case-id]
+
+Parameters are validated against the schema when the action is queued.
+
+Examples:
+
+    juju add-machine-action rotate-logs rotate-logs.sh
+    juju add-machine-action sosreport sosreport.sh --schema sosreport.yaml
+    juju run-action 0 sosreport case-id=01234567
+
+See also:
+    machine-actions
+    remove-machine-action
+    run-action
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *addMachineActionCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	f.Var(&c.schema, "schema", "Path to a YAML file describing the action's parameters")
+	f.StringVar(&c.description, "description", "", "Description of the action, overriding any in the schema")
+}
+
+// Info is part of the cmd.Command interface.
+func (c *addMachineActionCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-machine-action",
+		Args:    "<action name> <script file>",
+		Purpose: "Define an action that can be run on machines.",
+		Doc:     addMachineActionDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *addMachineActionCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no action name specified")
+	case 1:
+		return errors.New("no script file specified")
+	}
+	c.name = args[0]
+	if !ActionNameRule.MatchString(c.name) {
+		return errors.NotValidf("action name %q", c.name)
+	}
+	c.script.Path = args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+// machineActionSchema is the form of the file passed with --schema.
+type machineActionSchema struct {
+	Description string                 `yaml:"description"`
+	Params      map[string]interface{} `yaml:"params"`
+	Required    []string               `yaml:"required"`
+}
+
+// Run is part of the cmd.Command interface.
+func (c *addMachineActionCommand) Run(ctx *cmd.Context) error {
+	script, err := c.script.Read(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	arg := params.MachineActionScript{
+		Name:   c.name,
+		Script: string(script),
+	}
+	if c.schema.Path != "" {
+		data, err := c.schema.Read(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var schema machineActionSchema
+		if err := yaml.Unmarshal(data, &schema); err != nil {
+			return errors.Annotate(err, "cannot parse schema")
+		}
+		if len(schema.Params) > 0 {
+			conformant, err := common.ConformYAML(schema.Params)
+			if err != nil {
+				return errors.Annotate(err, "cannot parse schema")
+			}
+			arg.Params = conformant.(map[string]interface{})
+		}
+		arg.Description = schema.Description
+		arg.Required = schema.Required
+	}
+	if c.description != "" {
+		arg.Description = c.description
+	}
+
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.AddMachineActionScripts(params.MachineActionScripts{
+		Scripts: []params.MachineActionScript{arg},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// NewListMachineActionsCommand returns a command that lists a model's
+// machine actions.
+func NewListMachineActionsCommand() cmd.Command {
+	return modelcmd.Wrap(&listMachineActionsCommand{})
+}
+
+// listMachineActionsCommand lists machine actions.
+type listMachineActionsCommand struct {
+	ActionCommandBase
+	out cmd.Output
+}
+
+const listMachineActionsDoc = `
+List the actions that can be run on machines in the model. The YAML and
+JSON formats include each action's parameter schema and script.
+
+See also:
+    add-machine-action
+    remove-machine-action
+    run-action
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *listMachineActionsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatMachineActionsTabular,
+	})
+}
+
+// Info is part of the cmd.Command interface.
+func (c *listMachineActionsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "machine-actions",
+		Purpose: "List the actions that can be run on machines.",
+		Doc:     listMachineActionsDoc,
+		Aliases: []string{"list-machine-actions"},
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *listMachineActionsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *listMachineActionsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	result, err := api.MachineActionScripts()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Scripts) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No machine actions defined.")
+		return nil
+	}
+	output := make(map[string]interface{}, len(result.Scripts))
+	for _, script := range result.Scripts {
+		action := map[string]interface{}{
+			"description": script.Description,
+			"script":      script.Script,
+		}
+		if len(script.Params) > 0 {
+			action["params"] = script.Params
+		}
+		if len(script.Required) > 0 {
+			action["required"] = script.Required
+		}
+		output[script.Name] = action
+	}
+	return c.out.Write(ctx, output)
+}
+
+// formatMachineActionsTabular writes the machine actions as a table
+// of names and descriptions.
+func formatMachineActionsTabular(writer io.Writer, value interface{}) error {
+	actions, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", actions, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Action\tDescription")
+	names := make([]string, 0, len(actions))
+	for name := range actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		action := actions[name].(map[string]interface{})
+		fmt.Fprintf(tw, "%s\t%s\n", name, action["description"])
+	}
+	return tw.Flush()
+}
+
+// NewRemoveMachineActionCommand returns a command that removes machine
+// actions.
+func NewRemoveMachineActionCommand() cmd.Command {
+	return modelcmd.Wrap(&removeMachineActionCommand{})
+}
+
+// removeMachineActionCommand removes machine actions.
+type removeMachineActionCommand struct {
+	ActionCommandBase
+	names []string
+}
+
+const removeMachineActionDoc = `
+Remove the named machine actions from the model. Queued actions that
+have not yet started will fail when they run.
+
+See also:
+    add-machine-action
+    machine-actions
+`
+
+// Info is part of the cmd.Command interface.
+func (c *removeMachineActionCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-machine-action",
+		Args:    "<action name> [<action name> ...]",
+		Purpose: "Remove actions that can be run on machines.",
+		Doc:     removeMachineActionDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *removeMachineActionCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no action name specified")
+	}
+	c.names = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *removeMachineActionCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.RemoveMachineActionScripts(params.MachineActionScriptNames{Names: c.names})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
@@ -0,0 +1,163 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type MachineActionSuite struct {
+	BaseActionSuite
+	client *fakeAPIClient
+	dir    string
+}
+
+var _ = gc.Suite(&MachineActionSuite{})
+
+const sosreportScript = "#!/bin/sh\nsosreport --batch\n"
+
+var testMachineAction = params.MachineActionScript{
+	Name:        "sosreport",
+	Description: "Collect a sosreport.",
+	Script:      sosreportScript,
+	Params: map[string]interface{}{
+		"case-id": map[string]interface{}{"type": "string"},
+	},
+	Required: []string{"case-id"},
+}
+
+func (s *MachineActionSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.client = &fakeAPIClient{}
+	restore := s.patchAPIClient(s.client)
+	s.AddCleanup(func(*gc.C) { restore() })
+}
+
+func (s *MachineActionSuite) TestAddInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		expectError string
+	}{{
+		expectError: "no action name specified",
+	}, {
+		args:        []string{"sosreport"},
+		expectError: "no script file specified",
+	}, {
+		args:        []string{"SOS", "sosreport.sh"},
+		expectError: `action name "SOS" not valid`,
+	}, {
+		args:        []string{"sosreport", "sosreport.sh", "foo"},
+		expectError: `unrecognized args: \["foo"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		cmd := action.NewAddMachineActionCommandForTest(s.store)
+		err := cmdtesting.InitCommand(cmd, append([]string{"-m", "admin"}, test.args...))
+		c.Check(err, gc.ErrorMatches, test.expectError)
+	}
+}
+
+func (s *MachineActionSuite) TestAdd(c *gc.C) {
+	script := setupValueFile(c, s.dir, "sosreport.sh", sosreportScript)
+	schema := setupValueFile(c, s.dir, "sosreport.yaml", `
+description: Collect a report.
+params:
+  case-id:
+    type: string
+required: [case-id]
+`[1:])
+	cmd := action.NewAddMachineActionCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, cmd, "-m", "admin",
+		"sosreport", script, "--schema", schema, "--description", "Collect a sosreport.",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.machineActionArgs, jc.DeepEquals, []interface{}{
+		params.MachineActionScripts{
+			Scripts: []params.MachineActionScript{testMachineAction},
+		},
+	})
+}
+
+func (s *MachineActionSuite) TestAddNoSchema(c *gc.C) {
+	script := setupValueFile(c, s.dir, "sosreport.sh", sosreportScript)
+	cmd := action.NewAddMachineActionCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", "sosreport", script)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.machineActionArgs, jc.DeepEquals, []interface{}{
+		params.MachineActionScripts{
+			Scripts: []params.MachineActionScript{{
+				Name:   "sosreport",
+				Script: sosreportScript,
+			}},
+		},
+	})
+}
+
+func (s *MachineActionSuite) TestList(c *gc.C) {
+	s.client.machineActions = params.MachineActionScripts{
+		Scripts: []params.MachineActionScript{
+			testMachineAction,
+			{Name: "apt-upgrade", Description: "Upgrade packages.", Script: "#!/bin/sh\n"},
+		},
+	}
+	cmd := action.NewListMachineActionsCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Action       Description
+apt-upgrade  Upgrade packages.
+sosreport    Collect a sosreport.
+`[1:])
+}
+
+func (s *MachineActionSuite) TestListYAML(c *gc.C) {
+	s.client.machineActions = params.MachineActionScripts{
+		Scripts: []params.MachineActionScript{testMachineAction},
+	}
+	cmd := action.NewListMachineActionsCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	var output map[string]interface{}
+	err = yaml.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &output)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, jc.DeepEquals, map[string]interface{}{
+		"sosreport": map[interface{}]interface{}{
+			"description": "Collect a sosreport.",
+			"params": map[interface{}]interface{}{
+				"case-id": map[interface{}]interface{}{"type": "string"},
+			},
+			"required": []interface{}{"case-id"},
+			"script":   sosreportScript,
+		},
+	})
+}
+
+func (s *MachineActionSuite) TestListNone(c *gc.C) {
+	cmd := action.NewListMachineActionsCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No machine actions defined.\n")
+}
+
+func (s *MachineActionSuite) TestRemove(c *gc.C) {
+	cmd := action.NewRemoveMachineActionCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", "sosreport", "apt-upgrade")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.machineActionArgs, jc.DeepEquals, []interface{}{
+		params.MachineActionScriptNames{Names: []string{"sosreport", "apt-upgrade"}},
+	})
+}
+
+func (s *MachineActionSuite) TestRemoveNoName(c *gc.C) {
+	cmd := action.NewRemoveMachineActionCommandForTest(s.store)
+	err := cmdtesting.InitCommand(cmd, []string{"-m", "admin"})
+	c.Assert(err, gc.ErrorMatches, "no action name specified")
+}
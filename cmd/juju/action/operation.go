@@ -0,0 +1,370 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewListOperationsCommand returns a command that lists the
+// operations run in a model.
+func NewListOperationsCommand() cmd.Command {
+	return modelcmd.Wrap(&listOperationsCommand{})
+}
+
+// listOperationsCommand lists operations.
+type listOperationsCommand struct {
+	ActionCommandBase
+	out          cmd.Output
+	applications string
+	actionNames  string
+	status       string
+	from         string
+	to           string
+	offset       int
+	limit        int
+
+	query params.OperationQueryArgs
+}
+
+const listOperationsDoc = `
+List the operations run in the model, newest first. An operation is
+the set of actions enqueued by a single request, such as a run-action
+or run command targeting several units.
+
+Operations can be filtered by the applications they ran on, the actions
+they ran, their status, and the time they were enqueued. Times are
+given in RFC3339 format, or as a duration before now.
+
+Examples:
+
+    juju operations
+    juju operations --applications mysql,wordpress --status failed
+    juju operations --actions backup --from 24h
+    juju operations --limit 10 --offset 10
+
+See also:
+    show-operation
+    run-action
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *listOperationsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatOperationsTabular,
+	})
+	f.StringVar(&c.applications, "applications", "", "Comma separated list of applications to filter on")
+	f.StringVar(&c.actionNames, "actions", "", "Comma separated list of actions to filter on")
+	f.StringVar(&c.status, "status", "", "Comma separated list of operation statuses to filter on")
+	f.StringVar(&c.from, "from", "", "Only show operations enqueued at or after this time")
+	f.StringVar(&c.to, "to", "", "Only show operations enqueued at or before this time")
+	f.IntVar(&c.offset, "offset", 0, "Number of matching operations to skip")
+	f.IntVar(&c.limit, "limit", 50, "Maximum number of operations to show")
+}
+
+// Info is part of the cmd.Command interface.
+func (c *listOperationsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "operations",
+		Purpose: "List the operations run in the model.",
+		Doc:     listOperationsDoc,
+		Aliases: []string{"list-operations"},
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *listOperationsCommand) Init(args []string) error {
+	c.query = params.OperationQueryArgs{
+		Applications: splitList(c.applications),
+		ActionNames:  splitList(c.actionNames),
+		Status:       splitList(c.status),
+		Offset:       c.offset,
+		Limit:        c.limit,
+	}
+	for _, app := range c.query.Applications {
+		if !names.IsValidApplication(app) {
+			return errors.NotValidf("application name %q", app)
+		}
+	}
+	for _, status := range c.query.Status {
+		switch status {
+		case params.ActionPending, params.ActionRunning, params.ActionCompleted,
+			params.ActionFailed, params.ActionCancelled:
+		default:
+			return errors.NotValidf("status %q", status)
+		}
+	}
+	if c.offset < 0 {
+		return errors.New("--offset must not be negative")
+	}
+	if c.limit < 0 {
+		return errors.New("--limit must not be negative")
+	}
+	var err error
+	now := time.Now()
+	if c.query.From, err = parseTimeFlag("from", c.from, now); err != nil {
+		return err
+	}
+	if c.query.To, err = parseTimeFlag("to", c.to, now); err != nil {
+		return err
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *listOperationsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ListOperations(c.query)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No operations to display.")
+		return nil
+	}
+	output := make([]map[string]interface{}, len(results.Results))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return result.Error
+		}
+		output[i] = FormatOperation(result, false)
+	}
+	if err := c.out.Write(ctx, output); err != nil {
+		return err
+	}
+	if results.Truncated {
+		ctx.Infof("More operations match; use --offset %d to see them.", c.offset+len(results.Results))
+	}
+	return nil
+}
+
+// NewShowOperationCommand returns a command that shows the actions of
+// an operation.
+func NewShowOperationCommand() cmd.Command {
+	return modelcmd.Wrap(&showOperationCommand{})
+}
+
+// showOperationCommand shows an operation.
+type showOperationCommand struct {
+	ActionCommandBase
+	out cmd.Output
+	id  string
+}
+
+const showOperationDoc = `
+Show the status and results of each of the actions in the operation
+with the given ID.
+
+See also:
+    operations
+    show-action-output
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *showOperationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatOperationTabular,
+	})
+}
+
+// Info is part of the cmd.Command interface.
+func (c *showOperationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-operation",
+		Args:    "<operation ID>",
+		Purpose: "Show the actions of an operation.",
+		Doc:     showOperationDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *showOperationCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no operation ID specified")
+	}
+	c.id = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *showOperationCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Operations(params.OperationIds{Ids: []string{c.id}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	return c.out.Write(ctx, FormatOperation(result, true))
+}
+
+// FormatOperation inserts the interesting parts of an operation into a
+// map[string]interface{} for cmd.Output to write in an easy-to-read
+// format. If full is true each action's results are included too.
+func FormatOperation(op params.OperationResult, full bool) map[string]interface{} {
+	actions := make([]map[string]interface{}, len(op.Actions))
+	for i, result := range op.Actions {
+		var action map[string]interface{}
+		if full {
+			action = FormatActionResult(result)
+		} else {
+			action = map[string]interface{}{"status": result.Status}
+		}
+		if result.Error != nil {
+			action["error"] = result.Error.Error()
+		}
+		if result.Action != nil {
+			if tag, err := names.ParseActionTag(result.Action.Tag); err == nil {
+				action["id"] = tag.Id()
+			}
+			action["action"] = result.Action.Name
+			action["receiver"] = receiverId(result.Action.Receiver)
+		}
+		actions[i] = action
+	}
+	response := map[string]interface{}{
+		"id":      op.Id,
+		"status":  op.Status,
+		"actions": actions,
+	}
+	timing := make(map[string]string)
+	for k, v := range map[string]time.Time{
+		"enqueued":  op.Enqueued,
+		"started":   op.Started,
+		"completed": op.Completed,
+	} {
+		if !v.IsZero() {
+			timing[k] = v.String()
+		}
+	}
+	response["timing"] = timing
+	return response
+}
+
+// formatOperationsTabular writes the operations formatted by
+// FormatOperation as a table with a row per operation.
+func formatOperationsTabular(writer io.Writer, value interface{}) error {
+	ops, ok := value.([]map[string]interface{})
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", ops, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "ID\tStatus\tEnqueued\tCompleted\tActions\tReceivers")
+	for _, op := range ops {
+		var actionNames, receivers []string
+		seen := make(map[string]bool)
+		for _, action := range op["actions"].([]map[string]interface{}) {
+			name, _ := action["action"].(string)
+			if !seen[name] {
+				seen[name] = true
+				actionNames = append(actionNames, name)
+			}
+			if receiver, ok := action["receiver"].(string); ok {
+				receivers = append(receivers, receiver)
+			}
+		}
+		sort.Strings(actionNames)
+		timing := op["timing"].(map[string]string)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			op["id"], op["status"], timing["enqueued"], timing["completed"],
+			strings.Join(actionNames, ","), strings.Join(receivers, ","),
+		)
+	}
+	return tw.Flush()
+}
+
+// formatOperationTabular writes an operation formatted by
+// FormatOperation as a table with a row per action.
+func formatOperationTabular(writer io.Writer, value interface{}) error {
+	op, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", op, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintf(tw, "Operation %s: %s\n\n", op["id"], op["status"])
+	fmt.Fprintln(tw, "Action ID\tReceiver\tAction\tStatus\tMessage")
+	for _, action := range op["actions"].([]map[string]interface{}) {
+		message, _ := action["message"].(string)
+		if err, ok := action["error"].(string); ok {
+			message = err
+		}
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%s\n",
+			action["id"], action["receiver"], action["action"], action["status"], message,
+		)
+	}
+	return tw.Flush()
+}
+
+// receiverId returns the id of the entity with the given tag, or the
+// tag itself if it cannot be parsed.
+func receiverId(receiver string) string {
+	tag, err := names.ParseTag(receiver)
+	if err != nil {
+		return receiver
+	}
+	return tag.Id()
+}
+
+// splitList splits a comma separated flag value, ignoring empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseTimeFlag parses the value of a time flag, which is either an
+// RFC3339 time or a duration before now.
+func parseTimeFlag(flag, value string, now time.Time) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		t := now.Add(-d).UTC()
+		return &t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.Errorf("--%s must be an RFC3339 time or a duration, not %q", flag, value)
+	}
+	t = t.UTC()
+	return &t, nil
+}
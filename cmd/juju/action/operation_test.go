@@ -0,0 +1,180 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type OperationSuite struct {
+	BaseActionSuite
+	client *fakeAPIClient
+}
+
+var _ = gc.Suite(&OperationSuite{})
+
+var testEnqueued = time.Date(2018, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+var testOperation = params.OperationResult{
+	Id:        "7",
+	Status:    "completed",
+	Enqueued:  testEnqueued,
+	Completed: testEnqueued.Add(time.Minute),
+	Actions: []params.ActionResult{{
+		Action: &params.Action{
+			Tag:      "action-f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			Receiver: "unit-mysql-0",
+			Name:     "backup",
+		},
+		Status: "completed",
+		Output: map[string]interface{}{"path": "/tmp/backup"},
+	}, {
+		Action: &params.Action{
+			Tag:      "action-f47ac10b-58cc-4372-a567-0e02b2c3d480",
+			Receiver: "unit-mysql-1",
+			Name:     "backup",
+		},
+		Status:  "completed",
+		Message: "nothing to do",
+	}},
+}
+
+func (s *OperationSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.client = &fakeAPIClient{
+		operations: params.OperationResults{
+			Results: []params.OperationResult{testOperation},
+		},
+	}
+	restore := s.patchAPIClient(s.client)
+	s.AddCleanup(func(*gc.C) { restore() })
+}
+
+func (s *OperationSuite) TestListInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		expectError string
+	}{{
+		args:        []string{"--applications", "mysql/0"},
+		expectError: `application name "mysql/0" not valid`,
+	}, {
+		args:        []string{"--status", "done"},
+		expectError: `status "done" not valid`,
+	}, {
+		args:        []string{"--limit", "-1"},
+		expectError: "--limit must not be negative",
+	}, {
+		args:        []string{"--from", "yesterday"},
+		expectError: `--from must be an RFC3339 time or a duration, not "yesterday"`,
+	}, {
+		args:        []string{"foo"},
+		expectError: `unrecognized args: \["foo"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		cmd := action.NewListOperationsCommandForTest(s.store)
+		err := cmdtesting.InitCommand(cmd, append([]string{"-m", "admin"}, test.args...))
+		c.Check(err, gc.ErrorMatches, test.expectError)
+	}
+}
+
+func (s *OperationSuite) TestList(c *gc.C) {
+	s.client.operations.Truncated = true
+	cmd := action.NewListOperationsCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin",
+		"--applications", "mysql", "--actions", "backup,restore", "--status", "completed",
+		"--from", "2018-03-01T09:00:00Z", "--limit", "1",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	from := time.Date(2018, time.March, 1, 9, 0, 0, 0, time.UTC)
+	c.Assert(s.client.operationArgs, jc.DeepEquals, []interface{}{
+		params.OperationQueryArgs{
+			Applications: []string{"mysql"},
+			ActionNames:  []string{"backup", "restore"},
+			Status:       []string{"completed"},
+			From:         &from,
+			Limit:        1,
+		},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+ID  Status     Enqueued                       Completed                      Actions  Receivers
+7   completed  2018-03-01 10:00:00 +0000 UTC  2018-03-01 10:01:00 +0000 UTC  backup   mysql/0,mysql/1
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "More operations match; use --offset 1 to see them.\n")
+}
+
+func (s *OperationSuite) TestListNone(c *gc.C) {
+	s.client.operations = params.OperationResults{}
+	cmd := action.NewListOperationsCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No operations to display.\n")
+}
+
+func (s *OperationSuite) TestListJSON(c *gc.C) {
+	cmd := action.NewListOperationsCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, `[{"actions":[{"action":"backup","id":"f47ac10b-58cc-4372-a567-0e02b2c3d479","receiver":"mysql/0","status":"completed"}`)
+}
+
+func (s *OperationSuite) TestShow(c *gc.C) {
+	cmd := action.NewShowOperationCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", "7")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.operationArgs, jc.DeepEquals, []interface{}{
+		params.OperationIds{Ids: []string{"7"}},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+actions:
+- action: backup
+  id: f47ac10b-58cc-4372-a567-0e02b2c3d479
+  receiver: mysql/0
+  results:
+    path: /tmp/backup
+  status: completed
+- action: backup
+  id: f47ac10b-58cc-4372-a567-0e02b2c3d480
+  message: nothing to do
+  receiver: mysql/1
+  status: completed
+id: "7"
+status: completed
+timing:
+  completed: 2018-03-01 10:01:00 +0000 UTC
+  enqueued: 2018-03-01 10:00:00 +0000 UTC
+`[1:])
+}
+
+func (s *OperationSuite) TestShowTabular(c *gc.C) {
+	cmd := action.NewShowOperationCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", "7", "--format", "tabular")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Operation 7: completed
+
+Action ID                             Receiver  Action  Status     Message
+f47ac10b-58cc-4372-a567-0e02b2c3d479  mysql/0   backup  completed  
+f47ac10b-58cc-4372-a567-0e02b2c3d480  mysql/1   backup  completed  nothing to do
+`[1:])
+}
+
+func (s *OperationSuite) TestShowError(c *gc.C) {
+	s.client.operations = params.OperationResults{
+		Results: []params.OperationResult{{
+			Id:    "42",
+			Error: &params.Error{Message: `operation "42" not found`},
+		}},
+	}
+	cmd := action.NewShowOperationCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", "42")
+	c.Assert(err, gc.ErrorMatches, `operation "42" not found`)
+}
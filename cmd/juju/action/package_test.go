@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	jujuerrors "github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -19,6 +20,8 @@ import (
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/jujuclient"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/watcher/watchertest"
 )
 
 const (
@@ -128,6 +131,15 @@ type fakeAPIClient struct {
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       map[string]params.ActionSpec
+	progress           []string
+	rollouts           []params.ActionRolloutResult
+	rolloutArgs        []interface{}
+	operations         params.OperationResults
+	operationArgs      []interface{}
+	machineActions     params.MachineActionScripts
+	machineActionArgs  []interface{}
+	schedules          params.ActionScheduleResults
+	scheduleArgs       []interface{}
 	apiErr             error
 }
 
@@ -210,3 +222,69 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) WatchActionProgress(actionId string) (watcher.StringsWatcher, error) {
+	if c.progress == nil {
+		return nil, jujuerrors.NotSupportedf("watching action progress")
+	}
+	changes := make(chan []string, 1)
+	changes <- c.progress
+	return watchertest.NewMockStringsWatcher(changes), nil
+}
+
+func (c *fakeAPIClient) AddActionRollouts(args params.ActionRolloutsArgs) (params.ActionRolloutResults, error) {
+	c.rolloutArgs = append(c.rolloutArgs, args)
+	return params.ActionRolloutResults{Results: c.rollouts}, c.apiErr
+}
+
+func (c *fakeAPIClient) ActionRollouts(args params.ActionRolloutIds) (params.ActionRolloutResults, error) {
+	c.rolloutArgs = append(c.rolloutArgs, args)
+	return params.ActionRolloutResults{Results: c.rollouts}, c.apiErr
+}
+
+func (c *fakeAPIClient) CancelActionRollouts(args params.ActionRolloutIds) (params.ErrorResults, error) {
+	c.rolloutArgs = append(c.rolloutArgs, args)
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(c.rollouts))}
+	for i, rollout := range c.rollouts {
+		results.Results[i].Error = rollout.Error
+	}
+	return results, c.apiErr
+}
+
+func (c *fakeAPIClient) ListOperations(args params.OperationQueryArgs) (params.OperationResults, error) {
+	c.operationArgs = append(c.operationArgs, args)
+	return c.operations, c.apiErr
+}
+
+func (c *fakeAPIClient) Operations(args params.OperationIds) (params.OperationResults, error) {
+	c.operationArgs = append(c.operationArgs, args)
+	return c.operations, c.apiErr
+}
+
+func (c *fakeAPIClient) AddMachineActionScripts(args params.MachineActionScripts) (params.ErrorResults, error) {
+	c.machineActionArgs = append(c.machineActionArgs, args)
+	return params.ErrorResults{Results: make([]params.ErrorResult, len(args.Scripts))}, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveMachineActionScripts(args params.MachineActionScriptNames) (params.ErrorResults, error) {
+	c.machineActionArgs = append(c.machineActionArgs, args)
+	return params.ErrorResults{Results: make([]params.ErrorResult, len(args.Names))}, c.apiErr
+}
+
+func (c *fakeAPIClient) MachineActionScripts() (params.MachineActionScripts, error) {
+	return c.machineActions, c.apiErr
+}
+
+func (c *fakeAPIClient) AddActionSchedules(args params.ActionSchedulesArgs) (params.ActionScheduleResults, error) {
+	c.scheduleArgs = append(c.scheduleArgs, args)
+	return c.schedules, c.apiErr
+}
+
+func (c *fakeAPIClient) ActionSchedules() (params.ActionScheduleResults, error) {
+	return c.schedules, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	c.scheduleArgs = append(c.scheduleArgs, args)
+	return params.ErrorResults{Results: make([]params.ErrorResult, len(args.Ids))}, c.apiErr
+}
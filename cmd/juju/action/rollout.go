@@ -0,0 +1,343 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewRolloutCommand returns a command that runs an action across the
+// units of an application, a batch at a time.
+func NewRolloutCommand() cmd.Command {
+	return modelcmd.Wrap(&rolloutCommand{})
+}
+
+// rolloutCommand starts an action rollout.
+type rolloutCommand struct {
+	ActionCommandBase
+	out          cmd.Output
+	application  string
+	actionName   string
+	paramsYAML   cmd.FileVar
+	parseStrings bool
+	args         [][]string
+	maxParallel  int
+	order        string
+	pause        time.Duration
+	maxFailures  int
+}
+
+const rolloutDoc = `
+Run an action on every unit of an application, a batch of units at a
+time, rather than on all of them at once.
+
+The controller starts the action on up to --max-parallel units, waits
+for them all to finish and for --pause to elapse, then moves on to the
+next batch. By default units are visited in unit number order; use
+--order leader-first or --order leader-last to visit the application
+leader before or after every other unit. Once --max-failures actions
+have failed, no further batches are started; by default, the rollout
+stops after the first failure.
+
+Params are given as for run-action. The rollout's progress can be seen
+with 'juju show-action-rollout <ID>', and it can be stopped with
+'juju cancel-action-rollout <ID>'.
+
+Examples:
+
+    juju rollout-action mysql restart --max-parallel 2 --order leader-last
+    juju rollout-action mysql backup --pause 5m --max-failures 2 compress=true
+
+See also:
+    run-action
+    show-action-rollout
+    cancel-action-rollout
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *rolloutCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
+	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+	f.IntVar(&c.maxParallel, "max-parallel", 1, "Maximum number of units running the action at once")
+	f.StringVar(&c.order, "order", "", "Where to place the leader: leader-first or leader-last")
+	f.DurationVar(&c.pause, "pause", 0, "Time to wait between batches")
+	f.IntVar(&c.maxFailures, "max-failures", 1, "Number of failed actions after which to stop")
+}
+
+// Info is part of the cmd.Command interface.
+func (c *rolloutCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "rollout-action",
+		Args:    "<application> <action name> [key.key.key...=value]",
+		Purpose: "Run an action across an application's units in batches.",
+		Doc:     rolloutDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *rolloutCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.application = args[0]
+	if len(args) == 1 {
+		return errors.New("no action specified")
+	}
+	if !ActionNameRule.MatchString(args[1]) {
+		return errors.NotValidf("action name %q", args[1])
+	}
+	c.actionName = args[1]
+	if c.maxParallel < 1 {
+		return errors.New("--max-parallel must be at least 1")
+	}
+	switch c.order {
+	case "", "leader-first", "leader-last":
+	default:
+		return errors.Errorf("--order must be leader-first or leader-last, not %q", c.order)
+	}
+	if c.pause < 0 {
+		return errors.New("--pause must not be negative")
+	}
+	if c.maxFailures < 1 {
+		return errors.New("--max-failures must be at least 1")
+	}
+	var err error
+	c.args, err = parseKeyValueArgs(args[2:])
+	return err
+}
+
+// Run is part of the cmd.Command interface.
+func (c *rolloutCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	actionParams, err := readActionParams(ctx, c.paramsYAML, c.parseStrings, c.args)
+	if err != nil {
+		return err
+	}
+	results, err := api.AddActionRollouts(params.ActionRolloutsArgs{
+		Rollouts: []params.ActionRolloutArgs{{
+			Application: c.application,
+			Name:        c.actionName,
+			Parameters:  actionParams,
+			MaxParallel: c.maxParallel,
+			Order:       c.order,
+			Pause:       c.pause,
+			MaxFailures: c.maxFailures,
+		}},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rollout, err := oneRollout(results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, FormatActionRollout(rollout))
+}
+
+// NewShowRolloutCommand returns a command that shows the progress of
+// action rollouts.
+func NewShowRolloutCommand() cmd.Command {
+	return modelcmd.Wrap(&showRolloutCommand{})
+}
+
+// showRolloutCommand shows action rollouts.
+type showRolloutCommand struct {
+	ActionCommandBase
+	out cmd.Output
+	id  string
+}
+
+const showRolloutDoc = `
+Show the progress of the action rollout with the given ID, or of every
+action rollout in the model if no ID is given.
+
+See also:
+    rollout-action
+    cancel-action-rollout
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *showRolloutCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Info is part of the cmd.Command interface.
+func (c *showRolloutCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-action-rollout",
+		Args:    "[<rollout ID>]",
+		Purpose: "Show the progress of action rollouts.",
+		Doc:     showRolloutDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *showRolloutCommand) Init(args []string) error {
+	if len(args) > 0 {
+		c.id = args[0]
+		args = args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *showRolloutCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	var ids params.ActionRolloutIds
+	if c.id != "" {
+		ids.Ids = []string{c.id}
+	}
+	results, err := api.ActionRollouts(ids)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.id != "" {
+		rollout, err := oneRollout(results)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return c.out.Write(ctx, FormatActionRollout(rollout))
+	}
+	output := make(map[string]interface{}, len(results.Results))
+	for _, result := range results.Results {
+		if result.Error != nil {
+			return result.Error
+		}
+		output[result.Rollout.Id] = FormatActionRollout(*result.Rollout)
+	}
+	return c.out.Write(ctx, output)
+}
+
+// NewCancelRolloutCommand returns a command that stops an action
+// rollout.
+func NewCancelRolloutCommand() cmd.Command {
+	return modelcmd.Wrap(&cancelRolloutCommand{})
+}
+
+// cancelRolloutCommand stops an action rollout.
+type cancelRolloutCommand struct {
+	ActionCommandBase
+	id string
+}
+
+const cancelRolloutDoc = `
+Stop the action rollout with the given ID. Actions that have not yet
+started are cancelled; actions that are already running are left to
+finish, and no further batches are started.
+
+See also:
+    rollout-action
+    show-action-rollout
+`
+
+// Info is part of the cmd.Command interface.
+func (c *cancelRolloutCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "cancel-action-rollout",
+		Args:    "<rollout ID>",
+		Purpose: "Stop an action rollout.",
+		Doc:     cancelRolloutDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *cancelRolloutCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no rollout ID specified")
+	}
+	c.id = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *cancelRolloutCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.CancelActionRollouts(params.ActionRolloutIds{Ids: []string{c.id}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// oneRollout returns the single rollout in results.
+func oneRollout(results params.ActionRolloutResults) (params.ActionRollout, error) {
+	if len(results.Results) != 1 {
+		return params.ActionRollout{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ActionRollout{}, result.Error
+	}
+	return *result.Rollout, nil
+}
+
+// FormatActionRollout removes empty values from the given rollout and
+// inserts the remaining ones in a map[string]interface{} for cmd.Output
+// to write in an easy-to-read format.
+func FormatActionRollout(rollout params.ActionRollout) map[string]interface{} {
+	response := map[string]interface{}{
+		"id":           rollout.Id,
+		"application":  rollout.Application,
+		"action":       rollout.Name,
+		"status":       rollout.Status,
+		"units":        rollout.Units,
+		"max-parallel": rollout.MaxParallel,
+		"max-failures": rollout.MaxFailures,
+		"failures":     rollout.Failures,
+	}
+	if len(rollout.Parameters) != 0 {
+		response["parameters"] = rollout.Parameters
+	}
+	if rollout.Order != "" {
+		response["order"] = rollout.Order
+	}
+	if rollout.Pause != 0 {
+		response["pause"] = rollout.Pause.String()
+	}
+	if rollout.Message != "" {
+		response["message"] = rollout.Message
+	}
+	if rollout.Operation != "" {
+		response["operation"] = rollout.Operation
+	}
+	if len(rollout.Actions) != 0 {
+		response["actions"] = rollout.Actions
+	}
+	timing := map[string]string{"enqueued": rollout.Enqueued.String()}
+	if !rollout.Completed.IsZero() {
+		timing["completed"] = rollout.Completed.String()
+	}
+	response["timing"] = timing
+	return response
+}
@@ -0,0 +1,146 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type RolloutSuite struct {
+	BaseActionSuite
+	client *fakeAPIClient
+}
+
+var _ = gc.Suite(&RolloutSuite{})
+
+var testRollout = params.ActionRollout{
+	Id:          "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+	Application: "mysql",
+	Name:        "restart",
+	MaxParallel: 2,
+	Order:       "leader-last",
+	Pause:       time.Minute,
+	MaxFailures: 1,
+	Status:      "running",
+	Units:       []string{"mysql/0", "mysql/2", "mysql/1"},
+	Actions:     []string{"a1", "a2"},
+	Enqueued:    time.Date(2018, time.March, 1, 10, 0, 0, 0, time.UTC),
+}
+
+func (s *RolloutSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.client = &fakeAPIClient{
+		rollouts: []params.ActionRolloutResult{{Rollout: &testRollout}},
+	}
+	restore := s.patchAPIClient(s.client)
+	s.AddCleanup(func(*gc.C) { restore() })
+}
+
+func (s *RolloutSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		expectError string
+	}{{
+		expectError: "no application specified",
+	}, {
+		args:        []string{"mysql/0", "restart"},
+		expectError: `application name "mysql/0" not valid`,
+	}, {
+		args:        []string{"mysql"},
+		expectError: "no action specified",
+	}, {
+		args:        []string{"mysql", "restart", "--max-parallel", "0"},
+		expectError: "--max-parallel must be at least 1",
+	}, {
+		args:        []string{"mysql", "restart", "--max-failures", "0"},
+		expectError: "--max-failures must be at least 1",
+	}, {
+		args:        []string{"mysql", "restart", "--order", "random"},
+		expectError: `--order must be leader-first or leader-last, not "random"`,
+	}, {
+		args:        []string{"mysql", "restart", "foo"},
+		expectError: `argument "foo" must be of the form key...=value`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		cmd := action.NewRolloutCommandForTest(s.store)
+		err := cmdtesting.InitCommand(cmd, append([]string{"-m", "admin"}, test.args...))
+		c.Check(err, gc.ErrorMatches, test.expectError)
+	}
+}
+
+func (s *RolloutSuite) TestRun(c *gc.C) {
+	cmd := action.NewRolloutCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin",
+		"mysql", "restart", "force=true",
+		"--max-parallel", "2", "--order", "leader-last", "--pause", "1m",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.rolloutArgs, jc.DeepEquals, []interface{}{
+		params.ActionRolloutsArgs{Rollouts: []params.ActionRolloutArgs{{
+			Application: "mysql",
+			Name:        "restart",
+			Parameters:  map[string]interface{}{"force": true},
+			MaxParallel: 2,
+			Order:       "leader-last",
+			Pause:       time.Minute,
+			MaxFailures: 1,
+		}}},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+action: restart
+actions:
+- a1
+- a2
+application: mysql
+failures: 0
+id: f47ac10b-58cc-4372-a567-0e02b2c3d479
+max-failures: 1
+max-parallel: 2
+order: leader-last
+pause: 1m0s
+status: running
+timing:
+  enqueued: 2018-03-01 10:00:00 +0000 UTC
+units:
+- mysql/0
+- mysql/2
+- mysql/1
+`[1:])
+}
+
+func (s *RolloutSuite) TestShowAll(c *gc.C) {
+	cmd := action.NewShowRolloutCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.rolloutArgs, jc.DeepEquals, []interface{}{params.ActionRolloutIds{}})
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, `{"f47ac10b-58cc-4372-a567-0e02b2c3d479":{"action":"restart"`)
+}
+
+func (s *RolloutSuite) TestShowOne(c *gc.C) {
+	cmd := action.NewShowRolloutCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", testRollout.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.rolloutArgs, jc.DeepEquals, []interface{}{
+		params.ActionRolloutIds{Ids: []string{testRollout.Id}},
+	})
+}
+
+func (s *RolloutSuite) TestCancel(c *gc.C) {
+	s.client.rollouts = []params.ActionRolloutResult{{
+		Error: &params.Error{Message: "action rollout is completed"},
+	}}
+	cmd := action.NewCancelRolloutCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", testRollout.Id)
+	c.Assert(err, gc.ErrorMatches, "action rollout is completed")
+	c.Assert(s.client.rolloutArgs, jc.DeepEquals, []interface{}{
+		params.ActionRolloutIds{Ids: []string{testRollout.Id}},
+	})
+}
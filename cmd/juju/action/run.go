@@ -26,11 +26,11 @@ func NewRunCommand() cmd.Command {
 	return modelcmd.Wrap(&runCommand{})
 }
 
-// runCommand enqueues an Action for running on the given unit with given
-// params
+// runCommand enqueues an Action for running on the given units or
+// machines with given params
 type runCommand struct {
 	ActionCommandBase
-	unitTags     []names.UnitTag
+	receivers    []names.Tag
 	actionName   string
 	paramsYAML   cmd.FileVar
 	parseStrings bool
@@ -46,6 +46,10 @@ The Action ID is returned for use with 'juju show-action-output <ID>' or
  
 Params are validated according to the charm for the unit's application.  The 
 valid params can be seen using "juju actions <application> --schema".
+
+Actions can also be run on machines. Machine actions are defined for the
+model with "juju add-machine-action", and are listed by
+"juju machine-actions".
 Params may be in a yaml file which is passed with the --params flag, or they
 may be specified by a key.key.key...=value format (see examples below.)
 
@@ -56,6 +60,9 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+While waiting with --wait, any progress messages logged by the action
+with action-log are printed as they arrive.
+
 Examples:
 
 $ juju run-action mysql/3 backup --wait
@@ -113,6 +120,9 @@ file:
 $ juju run-action sleeper/0 pause time=1000
 ...
 
+$ juju run-action 0 1 sosreport case-id=01234567
+...
+
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
@@ -133,84 +143,103 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 func (c *runCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "run-action",
-		Args:    "<unit> [<unit> ...] <action name> [key.key.key...=value]",
+		Args:    "<unit or machine> [<unit or machine> ...] <action name> [key.key.key...=value]",
 		Purpose: "Queue an action for execution.",
 		Doc:     runDoc,
 	}
 }
 
-// Init gets the unit tag(s), action name and action arguments.
+// Init gets the unit or machine tag(s), action name and action arguments.
 func (c *runCommand) Init(args []string) error {
-	var unitNames []string
-	for idx, arg := range args {
+	c.receivers = nil
+	for _, arg := range args {
 		if names.IsValidUnit(arg) {
-			unitNames = args[:idx+1]
+			c.receivers = append(c.receivers, names.NewUnitTag(arg))
+		} else if names.IsValidMachine(arg) {
+			c.receivers = append(c.receivers, names.NewMachineTag(arg))
 		} else if ActionNameRule.MatchString(arg) {
 			c.actionName = arg
 			break
 		} else {
-			return errors.Errorf("invalid unit or action name %q", arg)
+			return errors.Errorf("invalid unit, machine or action name %q", arg)
 		}
 	}
-	if len(unitNames) == 0 {
-		return errors.New("no unit specified")
+	if len(c.receivers) == 0 {
+		return errors.New("no unit or machine specified")
 	}
 	if c.actionName == "" {
 		return errors.New("no action specified")
 	}
-	c.unitTags = make([]names.UnitTag, len(unitNames))
-	for idx, unitName := range unitNames {
-		c.unitTags[idx] = names.NewUnitTag(unitName)
-	}
 
 	// Parse CLI key-value args if they exist.
-	c.args = make([][]string, 0)
-	for _, arg := range args[len(unitNames)+1:] {
+	var err error
+	c.args, err = parseKeyValueArgs(args[len(c.receivers)+1:])
+	return err
+}
+
+// receiverKey returns the output key and id describing the action
+// receiver with the given tag: "unit" for units, and "machine" for
+// machines.
+func receiverKey(receiver string) (string, string, error) {
+	tag, err := names.ParseTag(receiver)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	switch tag.Kind() {
+	case names.UnitTagKind:
+		return "unit", tag.Id(), nil
+	case names.MachineTagKind:
+		return "machine", tag.Id(), nil
+	}
+	return "", "", errors.NotValidf("action receiver %q", receiver)
+}
+
+// parseKeyValueArgs parses key.key.key...=value arguments, returning
+// each as a slice of keys followed by the value.
+func parseKeyValueArgs(args []string) ([][]string, error) {
+	result := make([][]string, 0)
+	for _, arg := range args {
 		thisArg := strings.SplitN(arg, "=", 2)
 		if len(thisArg) != 2 {
-			return errors.Errorf("argument %q must be of the form key...=value", arg)
+			return nil, errors.Errorf("argument %q must be of the form key...=value", arg)
 		}
 		keySlice := strings.Split(thisArg[0], ".")
 		// check each key for validity
 		for _, key := range keySlice {
 			if valid := keyRule.MatchString(key); !valid {
-				return errors.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
+				return nil, errors.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
 			}
 		}
-		// c.args={..., [key, key, key, key, value]}
-		c.args = append(c.args, append(keySlice, thisArg[1]))
+		// result={..., [key, key, key, key, value]}
+		result = append(result, append(keySlice, thisArg[1]))
 	}
-	return nil
+	return result, nil
 }
 
-func (c *runCommand) Run(ctx *cmd.Context) error {
-	api, err := c.NewActionAPIClient()
-	if err != nil {
-		return err
-	}
-	defer api.Close()
-
+// readActionParams builds action parameters from the YAML file named
+// by paramsYAML, if any, overridden by the parsed key-value args.
+func readActionParams(ctx *cmd.Context, paramsYAML cmd.FileVar, parseStrings bool, args [][]string) (map[string]interface{}, error) {
 	actionParams := map[string]interface{}{}
 
-	if c.paramsYAML.Path != "" {
-		b, err := c.paramsYAML.Read(ctx)
+	if paramsYAML.Path != "" {
+		b, err := paramsYAML.Read(ctx)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		err = yaml.Unmarshal(b, &actionParams)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		conformantParams, err := common.ConformYAML(actionParams)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		betterParams, ok := conformantParams.(map[string]interface{})
 		if !ok {
-			return errors.New("params must contain a YAML map with string keys")
+			return nil, errors.New("params must contain a YAML map with string keys")
 		}
 
 		actionParams = betterParams
@@ -218,15 +247,15 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 
 	// If we had explicit args {..., [key, key, key, key, value], ...}
 	// then iterate and set params ..., key.key.key.key=value, ...
-	for _, argSlice := range c.args {
+	for _, argSlice := range args {
 		valueIndex := len(argSlice) - 1
 		keys := argSlice[:valueIndex]
 		value := argSlice[valueIndex]
 		cleansedValue := interface{}(value)
-		if !c.parseStrings {
+		if !parseStrings {
 			err := yaml.Unmarshal([]byte(value), &cleansedValue)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		}
 		// Insert the value in the map.
@@ -235,17 +264,31 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 
 	conformantParams, err := common.ConformYAML(actionParams)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	typedConformantParams, ok := conformantParams.(map[string]interface{})
 	if !ok {
-		return errors.Errorf("params must be a map, got %T", typedConformantParams)
+		return nil, errors.Errorf("params must be a map, got %T", typedConformantParams)
+	}
+	return actionParams, nil
+}
+
+func (c *runCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	actionParams, err := readActionParams(ctx, c.paramsYAML, c.parseStrings, c.args)
+	if err != nil {
+		return err
 	}
 
-	actions := make([]params.Action, len(c.unitTags))
-	for i, unitTag := range c.unitTags {
-		actions[i].Receiver = unitTag.String()
+	actions := make([]params.Action, len(c.receivers))
+	for i, receiver := range c.receivers {
+		actions[i].Receiver = receiver.String()
 		actions[i].Name = c.actionName
 		actions[i].Parameters = actionParams
 	}
@@ -254,9 +297,12 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 
-	if len(results.Results) != len(c.unitTags) {
+	if len(results.Results) != len(c.receivers) {
 		return errors.New("illegal number of results returned")
 	}
+	if action := results.Results[0].Action; action != nil && action.Operation != "" {
+		ctx.Infof("Operation %s queued; see 'juju show-operation %s'.", action.Operation, action.Operation)
+	}
 
 	for _, result := range results.Results {
 		if result.Error != nil {
@@ -289,13 +335,13 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 			if err != nil {
 				return err
 			}
-			unitTag, err := names.ParseUnitTag(result.Action.Receiver)
+			key, id, err := receiverKey(result.Action.Receiver)
 			if err != nil {
 				return err
 			}
 			output[result.Action.Receiver] = map[string]string{
-				"id":   actionTag.Id(),
-				"unit": unitTag.Id(),
+				"id": actionTag.Id(),
+				key:  id,
 			}
 		}
 		return c.out.Write(ctx, output)
@@ -315,17 +361,22 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		if err != nil {
 			return err
 		}
+		stop, err := watchProgress(api, tag.Id(), ctx.Stderr)
+		if err != nil {
+			return errors.Trace(err)
+		}
 		result, err = GetActionResult(api, tag.Id(), wait)
+		stop()
 		if err != nil {
 			return errors.Trace(err)
 		}
-		unitTag, err := names.ParseUnitTag(result.Action.Receiver)
+		key, id, err := receiverKey(result.Action.Receiver)
 		if err != nil {
 			return err
 		}
 		d := FormatActionResult(result)
-		d["id"] = tag.Id()       // Action ID is required in case we timed out.
-		d["unit"] = unitTag.Id() // Formatted unit is nice to have.
+		d["id"] = tag.Id() // Action ID is required in case we timed out.
+		d[key] = id        // Formatted unit or machine is nice to have.
 		output[result.Action.Receiver] = d
 	}
 	return c.out.Write(ctx, output)
@@ -59,7 +59,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 	tests := []struct {
 		should               string
 		args                 []string
-		expectUnits          []names.UnitTag
+		expectReceivers      []names.Tag
 		expectAction         string
 		expectParamsYamlPath string
 		expectParseStrings   bool
@@ -69,7 +69,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 	}{{
 		should:      "fail with missing args",
 		args:        []string{},
-		expectError: "no unit specified",
+		expectError: "no unit or machine specified",
 	}, {
 		should:      "fail with no action specified",
 		args:        []string{validUnitId},
@@ -77,25 +77,31 @@ func (s *RunSuite) TestInit(c *gc.C) {
 	}, {
 		should:      "fail with invalid unit tag",
 		args:        []string{invalidUnitId, "valid-action-name"},
-		expectError: "invalid unit or action name \"something-strange-\"",
+		expectError: "invalid unit, machine or action name \"something-strange-\"",
 	}, {
 		should:      "fail with invalid unit tag first",
 		args:        []string{validUnitId, invalidUnitId, "valid-action-name"},
-		expectError: "invalid unit or action name \"something-strange-\"",
+		expectError: "invalid unit, machine or action name \"something-strange-\"",
 	}, {
 		should:      "fail with invalid unit tag second",
 		args:        []string{invalidUnitId, validUnitId, "valid-action-name"},
-		expectError: "invalid unit or action name \"something-strange-\"",
+		expectError: "invalid unit, machine or action name \"something-strange-\"",
 	}, {
-		should:       "work with multiple valid units",
-		args:         []string{validUnitId, validUnitId2, "valid-action-name"},
-		expectUnits:  []names.UnitTag{names.NewUnitTag(validUnitId), names.NewUnitTag(validUnitId2)},
-		expectAction: "valid-action-name",
-		expectKVArgs: [][]string{},
+		should:          "work with multiple valid units",
+		args:            []string{validUnitId, validUnitId2, "valid-action-name"},
+		expectReceivers: []names.Tag{names.NewUnitTag(validUnitId), names.NewUnitTag(validUnitId2)},
+		expectAction:    "valid-action-name",
+		expectKVArgs:    [][]string{},
+	}, {
+		should:          "work with units and machines",
+		args:            []string{validUnitId, "0", "0/lxd/1", "valid-action-name"},
+		expectReceivers: []names.Tag{names.NewUnitTag(validUnitId), names.NewMachineTag("0"), names.NewMachineTag("0/lxd/1")},
+		expectAction:    "valid-action-name",
+		expectKVArgs:    [][]string{},
 	}, {}, {
 		should:      "fail with invalid action name",
 		args:        []string{validUnitId, "BadName"},
-		expectError: "invalid unit or action name \"BadName\"",
+		expectError: "invalid unit, machine or action name \"BadName\"",
 	}, {
 		should:      "fail with wrong formatting of k-v args",
 		args:        []string{validUnitId, "valid-action-name", "uh"},
@@ -109,33 +115,33 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		args:        []string{validUnitId, "valid-action-name", "no-go?od=3"},
 		expectError: "key \"no-go\\?od\" must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens",
 	}, {
-		should:       "work with empty values",
-		args:         []string{validUnitId, "valid-action-name", "ok="},
-		expectUnits:  []names.UnitTag{names.NewUnitTag(validUnitId)},
-		expectAction: "valid-action-name",
-		expectKVArgs: [][]string{{"ok", ""}},
+		should:          "work with empty values",
+		args:            []string{validUnitId, "valid-action-name", "ok="},
+		expectReceivers: []names.Tag{names.NewUnitTag(validUnitId)},
+		expectAction:    "valid-action-name",
+		expectKVArgs:    [][]string{{"ok", ""}},
 	}, {
 		should:             "handle --parse-strings",
 		args:               []string{validUnitId, "valid-action-name", "--string-args"},
-		expectUnits:        []names.UnitTag{names.NewUnitTag(validUnitId)},
+		expectReceivers:    []names.Tag{names.NewUnitTag(validUnitId)},
 		expectAction:       "valid-action-name",
 		expectParseStrings: true,
 	}, {
 		// cf. worker/uniter/runner/jujuc/action-set_test.go per @fwereade
-		should:       "work with multiple '=' signs",
-		args:         []string{validUnitId, "valid-action-name", "ok=this=is=weird="},
-		expectUnits:  []names.UnitTag{names.NewUnitTag(validUnitId)},
-		expectAction: "valid-action-name",
-		expectKVArgs: [][]string{{"ok", "this=is=weird="}},
+		should:          "work with multiple '=' signs",
+		args:            []string{validUnitId, "valid-action-name", "ok=this=is=weird="},
+		expectReceivers: []names.Tag{names.NewUnitTag(validUnitId)},
+		expectAction:    "valid-action-name",
+		expectKVArgs:    [][]string{{"ok", "this=is=weird="}},
 	}, {
-		should:       "init properly with no params",
-		args:         []string{validUnitId, "valid-action-name"},
-		expectUnits:  []names.UnitTag{names.NewUnitTag(validUnitId)},
-		expectAction: "valid-action-name",
+		should:          "init properly with no params",
+		args:            []string{validUnitId, "valid-action-name"},
+		expectReceivers: []names.Tag{names.NewUnitTag(validUnitId)},
+		expectAction:    "valid-action-name",
 	}, {
 		should:               "handle --params properly",
 		args:                 []string{validUnitId, "valid-action-name", "--params=foo.yml"},
-		expectUnits:          []names.UnitTag{names.NewUnitTag(validUnitId)},
+		expectReceivers:      []names.Tag{names.NewUnitTag(validUnitId)},
 		expectAction:         "valid-action-name",
 		expectParamsYamlPath: "foo.yml",
 	}, {
@@ -148,7 +154,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 			"foo.baz.bo=3",
 			"bar.foo=hello",
 		},
-		expectUnits:          []names.UnitTag{names.NewUnitTag(validUnitId)},
+		expectReceivers:      []names.Tag{names.NewUnitTag(validUnitId)},
 		expectAction:         "valid-action-name",
 		expectParamsYamlPath: "foo.yml",
 		expectKVArgs: [][]string{
@@ -165,8 +171,8 @@ func (s *RunSuite) TestInit(c *gc.C) {
 			"foo.baz.bo=y",
 			"bar.foo=hello",
 		},
-		expectUnits:  []names.UnitTag{names.NewUnitTag(validUnitId)},
-		expectAction: "valid-action-name",
+		expectReceivers: []names.Tag{names.NewUnitTag(validUnitId)},
+		expectAction:    "valid-action-name",
 		expectKVArgs: [][]string{
 			{"foo", "bar", "2"},
 			{"foo", "baz", "bo", "y"},
@@ -182,7 +188,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 			args := append([]string{modelFlag, "admin"}, t.args...)
 			err := cmdtesting.InitCommand(wrappedCommand, args)
 			if t.expectError == "" {
-				c.Check(command.UnitTags(), gc.DeepEquals, t.expectUnits)
+				c.Check(command.Receivers(), gc.DeepEquals, t.expectReceivers)
 				c.Check(command.ActionName(), gc.Equals, t.expectAction)
 				c.Check(command.ParamsYAML().Path, gc.Equals, t.expectParamsYamlPath)
 				c.Check(command.Args(), jc.DeepEquals, t.expectKVArgs)
@@ -266,6 +272,17 @@ func (s *RunSuite) TestRun(c *gc.C) {
 			Parameters: map[string]interface{}{},
 			Receiver:   names.NewUnitTag(validUnitId).String(),
 		},
+	}, {
+		should:   "enqueue an action on a machine",
+		withArgs: []string{"0", "sosreport", "case-id=1234"},
+		withActionResults: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString},
+		}},
+		expectedActionEnqueued: params.Action{
+			Name:       "sosreport",
+			Parameters: map[string]interface{}{"case-id": 1234},
+			Receiver:   names.NewMachineTag("0").String(),
+		},
 	}, {
 		should: "enqueue an action with some explicit params",
 		withArgs: []string{validUnitId, "some-action",
@@ -413,3 +430,28 @@ func (s *RunSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+func (s *RunSuite) TestRunMachines(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString, Receiver: "machine-0"},
+		}, {
+			Action: &params.Action{Tag: validActionTagString, Receiver: "unit-mysql-0"},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin", "0", "mysql/0", "sosreport")
+	c.Assert(err, jc.ErrorIsNil)
+	tag, err := names.ParseActionTag(validActionTagString)
+	c.Assert(err, jc.ErrorIsNil)
+	var output map[string]map[string]string
+	err = yaml.Unmarshal(ctx.Stdout.(*bytes.Buffer).Bytes(), &output)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, jc.DeepEquals, map[string]map[string]string{
+		"machine-0":    {"id": tag.Id(), "machine": "0"},
+		"unit-mysql-0": {"id": tag.Id(), "unit": "mysql/0"},
+	})
+}
@@ -0,0 +1,367 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/cron"
+)
+
+// NewAddScheduleCommand returns a command that runs an action on a
+// recurring schedule.
+func NewAddScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&addScheduleCommand{})
+}
+
+// addScheduleCommand adds an action schedule.
+type addScheduleCommand struct {
+	ActionCommandBase
+	applications []string
+	units        []string
+	actionName   string
+	paramsYAML   cmd.FileVar
+	parseStrings bool
+	args         [][]string
+	schedule     string
+	concurrency  string
+}
+
+const addScheduleDoc = `
+Run an action on units at recurring times, given by a cron expression
+in UTC. The controller enqueues the action on each target unit when the
+schedule falls due; targets may be units, or applications, in which
+case every unit of the application at that time runs the action.
+
+The cron expression has five fields: minute, hour, day of month, month
+and day of week. Each field is "*", or a comma separated list of values
+and ranges, optionally with a "/step". The macros @hourly, @daily,
+@weekly, @monthly and @yearly may also be used.
+
+--concurrency controls what happens when the schedule falls due while
+actions from its previous run have yet to finish:
+
+    forbid:  skip this run (the default)
+    allow:   run the actions anyway
+    replace: cancel the previous run's actions that have not started
+
+Params are given as for run-action. The outcome of each schedule's last
+run is shown by 'juju action-schedules'.
+
+Examples:
+
+    juju add-action-schedule mysql backup --cron "0 2 * * *"
+    juju add-action-schedule mysql/0,postgresql compact --cron @weekly --concurrency replace
+
+See also:
+    action-schedules
+    remove-action-schedule
+    run-action
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *addScheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
+	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+	f.StringVar(&c.schedule, "cron", "", "Cron expression describing when to run the action, in UTC")
+	f.StringVar(&c.concurrency, "concurrency", "forbid", "What to do if the previous run is unfinished: forbid, allow or replace")
+}
+
+// Info is part of the cmd.Command interface.
+func (c *addScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-action-schedule",
+		Args:    "<unit or application>[,...] <action name> [key.key.key...=value]",
+		Purpose: "Run an action on units at recurring times.",
+		Doc:     addScheduleDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *addScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no unit or application specified")
+	}
+	for _, target := range strings.Split(args[0], ",") {
+		switch {
+		case names.IsValidUnit(target):
+			c.units = append(c.units, target)
+		case names.IsValidApplication(target):
+			c.applications = append(c.applications, target)
+		default:
+			return errors.NotValidf("unit or application name %q", target)
+		}
+	}
+	if len(args) == 1 {
+		return errors.New("no action specified")
+	}
+	if !ActionNameRule.MatchString(args[1]) {
+		return errors.NotValidf("action name %q", args[1])
+	}
+	c.actionName = args[1]
+	if c.schedule == "" {
+		return errors.New("no schedule specified; use --cron")
+	}
+	if _, err := cron.Parse(c.schedule); err != nil {
+		return errors.Trace(err)
+	}
+	switch c.concurrency {
+	case "forbid", "allow", "replace":
+	default:
+		return errors.Errorf("--concurrency must be forbid, allow or replace, not %q", c.concurrency)
+	}
+	var err error
+	c.args, err = parseKeyValueArgs(args[2:])
+	return err
+}
+
+// Run is part of the cmd.Command interface.
+func (c *addScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	actionParams, err := readActionParams(ctx, c.paramsYAML, c.parseStrings, c.args)
+	if err != nil {
+		return err
+	}
+	results, err := api.AddActionSchedules(params.ActionSchedulesArgs{
+		Schedules: []params.ActionScheduleArgs{{
+			Schedule:     c.schedule,
+			Applications: c.applications,
+			Units:        c.units,
+			Name:         c.actionName,
+			Parameters:   actionParams,
+			Concurrency:  c.concurrency,
+		}},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	ctx.Infof("Added action schedule %s; next run at %s.",
+		result.Schedule.Id, result.Schedule.NextRun.UTC().Format(time.RFC3339),
+	)
+	return nil
+}
+
+// NewListSchedulesCommand returns a command that lists a model's action
+// schedules.
+func NewListSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&listSchedulesCommand{})
+}
+
+// listSchedulesCommand lists action schedules.
+type listSchedulesCommand struct {
+	ActionCommandBase
+	out cmd.Output
+}
+
+const listSchedulesDoc = `
+List the model's action schedules, with the outcome of each one's most
+recent run: the operation holding the actions it enqueued, that
+operation's status, and a message if the run was skipped or some of
+its targets could not be given an action.
+
+The operation's actions can be seen with 'juju show-operation <ID>'.
+
+See also:
+    add-action-schedule
+    remove-action-schedule
+    show-operation
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *listSchedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSchedulesTabular,
+	})
+}
+
+// Info is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "action-schedules",
+		Purpose: "List the model's action schedules.",
+		Doc:     listSchedulesDoc,
+		Aliases: []string{"list-action-schedules"},
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No action schedules defined.")
+		return nil
+	}
+	schedules := make([]map[string]interface{}, 0, len(results.Results))
+	for _, result := range results.Results {
+		if result.Error != nil {
+			return result.Error
+		}
+		schedules = append(schedules, formatSchedule(*result.Schedule))
+	}
+	return c.out.Write(ctx, schedules)
+}
+
+// formatSchedule returns a map describing an action schedule, for
+// output.
+func formatSchedule(s params.ActionSchedule) map[string]interface{} {
+	out := map[string]interface{}{
+		"id":          s.Id,
+		"schedule":    s.Schedule,
+		"targets":     append(append([]string{}, s.Applications...), s.Units...),
+		"action":      s.Name,
+		"concurrency": s.Concurrency,
+		"created":     s.Created.UTC(),
+	}
+	if len(s.Parameters) > 0 {
+		out["parameters"] = s.Parameters
+	}
+	if !s.NextRun.IsZero() {
+		out["next-run"] = s.NextRun.UTC()
+	}
+	if !s.LastRun.IsZero() {
+		last := map[string]interface{}{
+			"time": s.LastRun.UTC(),
+		}
+		if s.LastOperation != "" {
+			last["operation"] = s.LastOperation
+		}
+		if s.LastStatus != "" {
+			last["status"] = s.LastStatus
+		}
+		if s.LastMessage != "" {
+			last["message"] = s.LastMessage
+		}
+		out["last-run"] = last
+	}
+	return out
+}
+
+// formatSchedulesTabular writes the action schedules as a table.
+func formatSchedulesTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.([]map[string]interface{})
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "ID\tSchedule\tTargets\tAction\tNext run\tLast run\tOperation\tStatus\tMessage")
+	for _, s := range schedules {
+		var next, lastRun, operation, status, message interface{} = "", "", "", "", ""
+		if t, ok := s["next-run"]; ok {
+			next = t
+		}
+		if last, ok := s["last-run"].(map[string]interface{}); ok {
+			lastRun = last["time"]
+			if v, ok := last["operation"]; ok {
+				operation = v
+			}
+			if v, ok := last["status"]; ok {
+				status = v
+			}
+			if v, ok := last["message"]; ok {
+				message = v
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			s["id"], s["schedule"], strings.Join(s["targets"].([]string), ","), s["action"],
+			next, lastRun, operation, status, message,
+		)
+	}
+	return tw.Flush()
+}
+
+// NewRemoveScheduleCommand returns a command that removes action
+// schedules.
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&removeScheduleCommand{})
+}
+
+// removeScheduleCommand removes action schedules.
+type removeScheduleCommand struct {
+	ActionCommandBase
+	ids []string
+}
+
+const removeScheduleDoc = `
+Remove the action schedules with the given IDs. Actions that they have
+already enqueued are not affected.
+
+See also:
+    add-action-schedule
+    action-schedules
+`
+
+// Info is part of the cmd.Command interface.
+func (c *removeScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-action-schedule",
+		Args:    "<schedule ID> [<schedule ID> ...]",
+		Purpose: "Remove action schedules.",
+		Doc:     removeScheduleDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *removeScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule ID specified")
+	}
+	c.ids = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *removeScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.RemoveActionSchedules(params.ActionScheduleIds{Ids: c.ids})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
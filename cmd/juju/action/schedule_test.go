@@ -0,0 +1,163 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ScheduleSuite struct {
+	BaseActionSuite
+	client *fakeAPIClient
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+var testSchedule = params.ActionSchedule{
+	Id:            "1",
+	Schedule:      "0 2 * * *",
+	Applications:  []string{"mysql"},
+	Name:          "backup",
+	Concurrency:   "forbid",
+	Created:       time.Date(2018, time.February, 1, 10, 0, 0, 0, time.UTC),
+	NextRun:       time.Date(2018, time.March, 2, 2, 0, 0, 0, time.UTC),
+	LastRun:       time.Date(2018, time.March, 1, 2, 0, 0, 0, time.UTC),
+	LastOperation: "7",
+	LastStatus:    "running",
+	LastMessage:   "skipped: operation 7 has not finished",
+}
+
+func (s *ScheduleSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.client = &fakeAPIClient{
+		schedules: params.ActionScheduleResults{
+			Results: []params.ActionScheduleResult{{Schedule: &testSchedule}},
+		},
+	}
+	restore := s.patchAPIClient(s.client)
+	s.AddCleanup(func(*gc.C) { restore() })
+}
+
+func (s *ScheduleSuite) TestAddInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		expectError string
+	}{{
+		expectError: "no unit or application specified",
+	}, {
+		args:        []string{"mysql,machine-0", "backup"},
+		expectError: `unit or application name "machine-0" not valid`,
+	}, {
+		args:        []string{"mysql"},
+		expectError: "no action specified",
+	}, {
+		args:        []string{"mysql", "backup"},
+		expectError: "no schedule specified; use --cron",
+	}, {
+		args:        []string{"mysql", "backup", "--cron", "0 2 * *"},
+		expectError: `invalid cron expression "0 2 \* \*": expected 5 fields, got 4`,
+	}, {
+		args:        []string{"mysql", "backup", "--cron", "@daily", "--concurrency", "queue"},
+		expectError: `--concurrency must be forbid, allow or replace, not "queue"`,
+	}, {
+		args:        []string{"mysql", "backup", "--cron", "@daily", "foo"},
+		expectError: `argument "foo" must be of the form key...=value`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		cmd := action.NewAddScheduleCommandForTest(s.store)
+		err := cmdtesting.InitCommand(cmd, append([]string{"-m", "admin"}, test.args...))
+		c.Check(err, gc.ErrorMatches, test.expectError)
+	}
+}
+
+func (s *ScheduleSuite) TestAdd(c *gc.C) {
+	cmd := action.NewAddScheduleCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin",
+		"mysql,postgresql/0", "backup", "compress=true",
+		"--cron", "0 2 * * *", "--concurrency", "replace",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.scheduleArgs, jc.DeepEquals, []interface{}{
+		params.ActionSchedulesArgs{
+			Schedules: []params.ActionScheduleArgs{{
+				Schedule:     "0 2 * * *",
+				Applications: []string{"mysql"},
+				Units:        []string{"postgresql/0"},
+				Name:         "backup",
+				Parameters:   map[string]interface{}{"compress": true},
+				Concurrency:  "replace",
+			}},
+		},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Added action schedule 1; next run at 2018-03-02T02:00:00Z.\n")
+}
+
+func (s *ScheduleSuite) TestAddError(c *gc.C) {
+	s.client.schedules = params.ActionScheduleResults{
+		Results: []params.ActionScheduleResult{{
+			Error: &params.Error{Message: `action "backup" not defined for application "mysql"`},
+		}},
+	}
+	cmd := action.NewAddScheduleCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", "mysql", "backup", "--cron", "@daily")
+	c.Assert(err, gc.ErrorMatches, `action "backup" not defined for application "mysql"`)
+}
+
+func (s *ScheduleSuite) TestList(c *gc.C) {
+	cmd := action.NewListSchedulesCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+ID  Schedule   Targets  Action  Next run                       Last run                       Operation  Status   Message
+1   0 2 * * *  mysql    backup  2018-03-02 02:00:00 +0000 UTC  2018-03-01 02:00:00 +0000 UTC  7          running  skipped: operation 7 has not finished
+`[1:])
+}
+
+func (s *ScheduleSuite) TestListYAML(c *gc.C) {
+	cmd := action.NewListSchedulesCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	var output []map[string]interface{}
+	err = yaml.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &output)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, gc.HasLen, 1)
+	c.Assert(output[0]["id"], gc.Equals, "1")
+	c.Assert(output[0]["targets"], jc.DeepEquals, []interface{}{"mysql"})
+	last := output[0]["last-run"].(map[interface{}]interface{})
+	c.Assert(last["operation"], gc.Equals, "7")
+	c.Assert(last["status"], gc.Equals, "running")
+	c.Assert(last["message"], gc.Equals, "skipped: operation 7 has not finished")
+}
+
+func (s *ScheduleSuite) TestListNone(c *gc.C) {
+	s.client.schedules = params.ActionScheduleResults{}
+	cmd := action.NewListSchedulesCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No action schedules defined.\n")
+}
+
+func (s *ScheduleSuite) TestRemove(c *gc.C) {
+	cmd := action.NewRemoveScheduleCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", "1", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.scheduleArgs, jc.DeepEquals, []interface{}{
+		params.ActionScheduleIds{Ids: []string{"1", "2"}},
+	})
+}
+
+func (s *ScheduleSuite) TestRemoveNoId(c *gc.C) {
+	cmd := action.NewRemoveScheduleCommandForTest(s.store)
+	err := cmdtesting.InitCommand(cmd, []string{"-m", "admin"})
+	c.Assert(err, gc.ErrorMatches, "no schedule ID specified")
+}
@@ -27,6 +27,7 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	watch       bool
 }
 
 const showOutputDoc = `
@@ -38,6 +39,10 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+The --watch flag prints the progress messages logged by the action while
+waiting for its results.  Unless --wait is also given, --watch waits
+indefinitely.
 `
 
 // Set up the output.
@@ -45,6 +50,7 @@ func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.BoolVar(&c.watch, "watch", false, "Print progress messages while waiting for results")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -63,6 +69,9 @@ func (c *showOutputCommand) Init(args []string) error {
 		return errors.New("no action ID specified")
 	case 1:
 		c.requestedId = args[0]
+		if c.watch && c.wait == "-1s" {
+			c.wait = "0"
+		}
 		return nil
 	default:
 		return cmd.CheckEmpty(args[1:])
@@ -104,6 +113,18 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 		wait = time.NewTimer(waitDur)
 	}
 
+	if c.watch {
+		actionTag, err := getActionTagByPrefix(api, c.requestedId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		stop, err := watchProgress(api, actionTag.Id(), ctx.Stderr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer stop()
+	}
+
 	result, err := GetActionResult(api, c.requestedId, wait)
 	if err != nil {
 		return errors.Trace(err)
@@ -204,6 +225,13 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
+	if len(result.Log) != 0 {
+		log := make([]string, len(result.Log))
+		for i, message := range result.Log {
+			log[i] = formatActionMessage(message)
+		}
+		response["log"] = log
+	}
 
 	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
 		return response
@@ -290,6 +290,38 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestRunWatch(c *gc.C) {
+	client := makeFakeClient(
+		0, 10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status: "completed",
+			Log: []params.ActionMessage{{
+				Message:   "starting backup",
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+			}},
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		params.ActionsByNames{},
+		"",
+	)
+	client.progress = []string{`{"message":"starting backup","timestamp":"2015-02-14T08:15:00Z"}`}
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, validActionId, "--watch")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "2015-02-14T08:15:00Z starting backup\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+log:
+- 2015-02-14T08:15:00Z starting backup
+status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+`[1:])
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
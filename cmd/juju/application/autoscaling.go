@@ -0,0 +1,353 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/autoscaling"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const defaultAutoscalingCooldown = 5 * time.Minute
+
+var usageSetAutoscalingPolicySummary = `
+Scales an application in response to a metric reported by its units.`[1:]
+
+var usageSetAutoscalingPolicyDetails = `
+The application's units are added and removed so that the named metric,
+averaged over the units that recently reported it with "add-metric",
+approaches the target value. The number of units is kept between the
+minimum and maximum, and at least the cooldown passes between changes.
+
+Units are added and removed as they are by "juju add-unit" and
+"juju remove-unit"; the most recently added units are removed first,
+and their storage is detached rather than destroyed. Each change is
+recorded, and may be seen with "juju show-autoscaling-policy".
+
+The metric must be defined in the charm's metrics.yaml. Setting a policy
+for an application that already has one replaces it.
+
+Examples:
+    juju set-autoscaling-policy web --metric requests-per-second --target 100 --min 2 --max 10
+    juju set-autoscaling-policy worker --metric queue-depth --target 50 --max 20 --cooldown 10m
+
+See also:
+    add-metric
+    remove-autoscaling-policy
+    show-autoscaling-policy`[1:]
+
+var usageShowAutoscalingPolicySummary = `
+Shows an application's autoscaling policy and the changes made by it.`[1:]
+
+var usageShowAutoscalingPolicyDetails = `
+The policy is shown along with the changes it has made to the number of
+the application's units, most recent first. Each change records the
+averaged metric value it responded to, the number of units before and
+after, and the units added or removed.
+
+Examples:
+    juju show-autoscaling-policy web
+    juju show-autoscaling-policy web --format json
+
+See also:
+    remove-autoscaling-policy
+    set-autoscaling-policy`[1:]
+
+var usageRemoveAutoscalingPolicySummary = `
+Stops scaling an application in response to its units' metrics.`[1:]
+
+var usageRemoveAutoscalingPolicyDetails = `
+The application's units are left as they are.
+
+Examples:
+    juju remove-autoscaling-policy web
+
+See also:
+    set-autoscaling-policy
+    show-autoscaling-policy`[1:]
+
+// AutoscalingAPI defines the API methods that the autoscaling policy
+// commands use.
+type AutoscalingAPI interface {
+	BestAPIVersion() int
+	SetPolicy(application string, policy params.AutoscalingPolicy) error
+	Policy(application string) (params.AutoscalingPolicy, error)
+	RemovePolicy(application string) error
+	Decisions(application string) ([]params.AutoscalingDecision, error)
+	Close() error
+}
+
+// NewSetAutoscalingPolicyCommand returns a command that sets the
+// autoscaling policy of an application.
+func NewSetAutoscalingPolicyCommand() cmd.Command {
+	cmd := &setAutoscalingPolicyCommand{}
+	cmd.newAPIFunc = cmd.newAPI
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowAutoscalingPolicyCommand returns a command that shows the
+// autoscaling policy of an application.
+func NewShowAutoscalingPolicyCommand() cmd.Command {
+	cmd := &showAutoscalingPolicyCommand{}
+	cmd.newAPIFunc = cmd.newAPI
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRemoveAutoscalingPolicyCommand returns a command that removes the
+// autoscaling policy of an application.
+func NewRemoveAutoscalingPolicyCommand() cmd.Command {
+	cmd := &removeAutoscalingPolicyCommand{}
+	cmd.newAPIFunc = cmd.newAPI
+	return modelcmd.Wrap(cmd)
+}
+
+// autoscalingCommandBase holds the state shared by the autoscaling
+// policy commands.
+type autoscalingCommandBase struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (AutoscalingAPI, error)
+
+	application string
+}
+
+func (c *autoscalingCommandBase) initApplication(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return nil, errors.NotValidf("application name %q", args[0])
+	}
+	c.application = args[0]
+	return args[1:], nil
+}
+
+func (c *autoscalingCommandBase) newAPI() (AutoscalingAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return autoscaling.NewClient(root), nil
+}
+
+func (c *autoscalingCommandBase) getAPI(command string) (AutoscalingAPI, error) {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if api.BestAPIVersion() < 1 {
+		api.Close()
+		return nil, errors.Errorf("%s is not supported by this version of Juju", command)
+	}
+	return api, nil
+}
+
+// setAutoscalingPolicyCommand sets the autoscaling policy of an
+// application.
+type setAutoscalingPolicyCommand struct {
+	autoscalingCommandBase
+
+	Policy params.AutoscalingPolicy
+}
+
+// Info implements Command.Info.
+func (c *setAutoscalingPolicyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-autoscaling-policy",
+		Args:    "<application>",
+		Purpose: usageSetAutoscalingPolicySummary,
+		Doc:     usageSetAutoscalingPolicyDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setAutoscalingPolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.Policy.MetricKey, "metric", "", "Name of the charm metric that drives scaling")
+	f.Float64Var(&c.Policy.Target, "target", 0, "Value of the metric, averaged over the units, to scale for")
+	f.IntVar(&c.Policy.MinUnits, "min", 1, "Minimum number of units")
+	f.IntVar(&c.Policy.MaxUnits, "max", 0, "Maximum number of units")
+	f.DurationVar(&c.Policy.Cooldown, "cooldown", defaultAutoscalingCooldown, "Minimum time between changes to the number of units")
+}
+
+// Init implements Command.Init.
+func (c *setAutoscalingPolicyCommand) Init(args []string) error {
+	args, err := c.initApplication(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	switch {
+	case c.Policy.MetricKey == "":
+		return errors.New("no metric specified")
+	case c.Policy.Target <= 0:
+		return errors.New("--target must be greater than 0")
+	case c.Policy.MinUnits < 0:
+		return errors.New("--min must not be negative")
+	case c.Policy.MaxUnits < 1:
+		return errors.New("--max must be at least 1")
+	case c.Policy.MinUnits > c.Policy.MaxUnits:
+		return errors.New("--min must not be greater than --max")
+	case c.Policy.Cooldown < 0:
+		return errors.New("--cooldown must not be negative")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setAutoscalingPolicyCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI("set-autoscaling-policy")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.SetPolicy(c.application, c.Policy); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
+
+// showAutoscalingPolicyCommand shows the autoscaling policy of an
+// application, and the decisions made by it.
+type showAutoscalingPolicyCommand struct {
+	autoscalingCommandBase
+	out cmd.Output
+}
+
+// AutoscalingPolicyInfo holds the details of an autoscaling policy for
+// output.
+type AutoscalingPolicyInfo struct {
+	Metric    string                    `yaml:"metric" json:"metric"`
+	Target    float64                   `yaml:"target" json:"target"`
+	MinUnits  int                       `yaml:"min-units" json:"min-units"`
+	MaxUnits  int                       `yaml:"max-units" json:"max-units"`
+	Cooldown  string                    `yaml:"cooldown" json:"cooldown"`
+	Decisions []AutoscalingDecisionInfo `yaml:"decisions,omitempty" json:"decisions,omitempty"`
+}
+
+// AutoscalingDecisionInfo holds the details of an autoscaling decision
+// for output.
+type AutoscalingDecisionInfo struct {
+	Time           string   `yaml:"time" json:"time"`
+	MetricValue    float64  `yaml:"metric-value" json:"metric-value"`
+	ReportingUnits int      `yaml:"reporting-units" json:"reporting-units"`
+	FromUnits      int      `yaml:"from-units" json:"from-units"`
+	ToUnits        int      `yaml:"to-units" json:"to-units"`
+	Units          []string `yaml:"units,omitempty" json:"units,omitempty"`
+	Error          string   `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// Info implements Command.Info.
+func (c *showAutoscalingPolicyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-autoscaling-policy",
+		Args:    "<application>",
+		Purpose: usageShowAutoscalingPolicySummary,
+		Doc:     usageShowAutoscalingPolicyDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showAutoscalingPolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+// Init implements Command.Init.
+func (c *showAutoscalingPolicyCommand) Init(args []string) error {
+	args, err := c.initApplication(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *showAutoscalingPolicyCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI("show-autoscaling-policy")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	policy, err := api.Policy(c.application)
+	if params.IsCodeNotFound(err) {
+		ctx.Infof("Application %q has no autoscaling policy.", c.application)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	decisions, err := api.Decisions(c.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info := AutoscalingPolicyInfo{
+		Metric:   policy.MetricKey,
+		Target:   policy.Target,
+		MinUnits: policy.MinUnits,
+		MaxUnits: policy.MaxUnits,
+		Cooldown: policy.Cooldown.String(),
+	}
+	for _, d := range decisions {
+		info.Decisions = append(info.Decisions, AutoscalingDecisionInfo{
+			Time:           d.Time.UTC().Format(time.RFC3339),
+			MetricValue:    d.MetricValue,
+			ReportingUnits: d.ReportingUnits,
+			FromUnits:      d.FromUnits,
+			ToUnits:        d.ToUnits,
+			Units:          d.Units,
+			Error:          d.Error,
+		})
+	}
+	return c.out.Write(ctx, map[string]AutoscalingPolicyInfo{c.application: info})
+}
+
+// removeAutoscalingPolicyCommand removes the autoscaling policy of an
+// application.
+type removeAutoscalingPolicyCommand struct {
+	autoscalingCommandBase
+}
+
+// Info implements Command.Info.
+func (c *removeAutoscalingPolicyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-autoscaling-policy",
+		Args:    "<application>",
+		Purpose: usageRemoveAutoscalingPolicySummary,
+		Doc:     usageRemoveAutoscalingPolicyDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *removeAutoscalingPolicyCommand) Init(args []string) error {
+	args, err := c.initApplication(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *removeAutoscalingPolicyCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI("remove-autoscaling-policy")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.RemovePolicy(c.application); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
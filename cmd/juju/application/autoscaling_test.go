@@ -0,0 +1,204 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AutoscalingPolicySuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockAutoscalingAPI
+}
+
+var _ = gc.Suite(&AutoscalingPolicySuite{})
+
+func (s *AutoscalingPolicySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockAutoscalingAPI{
+		Stub:    &testing.Stub{},
+		version: 1,
+		policy: params.AutoscalingPolicy{
+			MetricKey: "pings",
+			Target:    100,
+			MinUnits:  2,
+			MaxUnits:  10,
+			Cooldown:  5 * time.Minute,
+		},
+	}
+}
+
+func (s *AutoscalingPolicySuite) TestSetPolicy(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewSetAutoscalingPolicyCommandForTest(s.mockAPI),
+		"metered", "--metric", "pings", "--target", "100", "--min", "2", "--max", "10",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{FuncName: "SetPolicy", Args: []interface{}{"metered", params.AutoscalingPolicy{
+			MetricKey: "pings",
+			Target:    100,
+			MinUnits:  2,
+			MaxUnits:  10,
+			Cooldown:  5 * time.Minute,
+		}}},
+		{FuncName: "Close"},
+	})
+}
+
+func (s *AutoscalingPolicySuite) TestSetPolicyBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestSetPolicyBlocked"))
+	_, err := cmdtesting.RunCommand(c, NewSetAutoscalingPolicyCommandForTest(s.mockAPI),
+		"metered", "--metric", "pings", "--target", "100", "--max", "10",
+	)
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestSetPolicyBlocked.*")
+}
+
+func (s *AutoscalingPolicySuite) TestSetPolicyInvalidArgs(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"metered/0", "--metric", "pings", "--target", "1", "--max", "2"},
+		err:  `application name "metered/0" not valid`,
+	}, {
+		args: []string{"metered", "--target", "1", "--max", "2"},
+		err:  "no metric specified",
+	}, {
+		args: []string{"metered", "--metric", "pings", "--max", "2"},
+		err:  "--target must be greater than 0",
+	}, {
+		args: []string{"metered", "--metric", "pings", "--target", "1"},
+		err:  "--max must be at least 1",
+	}, {
+		args: []string{"metered", "--metric", "pings", "--target", "1", "--min", "3", "--max", "2"},
+		err:  "--min must not be greater than --max",
+	}, {
+		args: []string{"metered", "--metric", "pings", "--target", "1", "--max", "2", "--cooldown", "-1m"},
+		err:  "--cooldown must not be negative",
+	}, {
+		args: []string{"metered", "extra", "--metric", "pings", "--target", "1", "--max", "2"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		_, err := cmdtesting.RunCommand(c, NewSetAutoscalingPolicyCommandForTest(s.mockAPI), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *AutoscalingPolicySuite) TestSetPolicyOldServer(c *gc.C) {
+	s.mockAPI.version = 0
+	_, err := cmdtesting.RunCommand(c, NewSetAutoscalingPolicyCommandForTest(s.mockAPI),
+		"metered", "--metric", "pings", "--target", "100", "--max", "10",
+	)
+	c.Assert(err, gc.ErrorMatches, "set-autoscaling-policy is not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *AutoscalingPolicySuite) TestShowPolicy(c *gc.C) {
+	s.mockAPI.decisions = []params.AutoscalingDecision{{
+		Time:           time.Date(2018, time.March, 1, 10, 0, 0, 0, time.UTC),
+		MetricKey:      "pings",
+		Target:         100,
+		MetricValue:    210,
+		ReportingUnits: 2,
+		FromUnits:      2,
+		ToUnits:        5,
+		Units:          []string{"metered/2", "metered/3", "metered/4"},
+	}}
+	ctx, err := cmdtesting.RunCommand(c, NewShowAutoscalingPolicyCommandForTest(s.mockAPI), "metered")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+metered:
+  metric: pings
+  target: 100
+  min-units: 2
+  max-units: 10
+  cooldown: 5m0s
+  decisions:
+  - time: "2018-03-01T10:00:00Z"
+    metric-value: 210
+    reporting-units: 2
+    from-units: 2
+    to-units: 5
+    units:
+    - metered/2
+    - metered/3
+    - metered/4
+`[1:])
+	s.mockAPI.CheckCallNames(c, "Policy", "Decisions", "Close")
+}
+
+func (s *AutoscalingPolicySuite) TestShowPolicyNotFound(c *gc.C) {
+	s.mockAPI.SetErrors(&params.Error{Code: params.CodeNotFound, Message: "not found"})
+	ctx, err := cmdtesting.RunCommand(c, NewShowAutoscalingPolicyCommandForTest(s.mockAPI), "metered")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Application \"metered\" has no autoscaling policy.\n")
+	s.mockAPI.CheckCallNames(c, "Policy", "Close")
+}
+
+func (s *AutoscalingPolicySuite) TestRemovePolicy(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewRemoveAutoscalingPolicyCommandForTest(s.mockAPI), "metered")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{FuncName: "RemovePolicy", Args: []interface{}{"metered"}},
+		{FuncName: "Close"},
+	})
+}
+
+func (s *AutoscalingPolicySuite) TestRemovePolicyBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestRemovePolicyBlocked"))
+	_, err := cmdtesting.RunCommand(c, NewRemoveAutoscalingPolicyCommandForTest(s.mockAPI), "metered")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestRemovePolicyBlocked.*")
+}
+
+type mockAutoscalingAPI struct {
+	*testing.Stub
+	version   int
+	policy    params.AutoscalingPolicy
+	decisions []params.AutoscalingDecision
+}
+
+func (m *mockAutoscalingAPI) BestAPIVersion() int {
+	return m.version
+}
+
+func (m *mockAutoscalingAPI) SetPolicy(application string, policy params.AutoscalingPolicy) error {
+	m.MethodCall(m, "SetPolicy", application, policy)
+	return m.NextErr()
+}
+
+func (m *mockAutoscalingAPI) Policy(application string) (params.AutoscalingPolicy, error) {
+	m.MethodCall(m, "Policy", application)
+	return m.policy, m.NextErr()
+}
+
+func (m *mockAutoscalingAPI) RemovePolicy(application string) error {
+	m.MethodCall(m, "RemovePolicy", application)
+	return m.NextErr()
+}
+
+func (m *mockAutoscalingAPI) Decisions(application string) ([]params.AutoscalingDecision, error) {
+	m.MethodCall(m, "Decisions", application)
+	return m.decisions, m.NextErr()
+}
+
+func (m *mockAutoscalingAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
@@ -192,6 +193,15 @@ type bundleHandler struct {
 	// LXD.  This flag keeps us from writing the warning more than once per
 	// bundle.
 	warnedLXC bool
+
+	// modelConstraints holds the model's constraints, which are fetched
+	// the first time a dry run reports the instance type of a new machine.
+	modelConstraints *constraints.Value
+
+	// instanceTypes maps the effective constraints of new machines to
+	// the descriptions of the instance types they would be given, so
+	// that a dry run asks for each only once.
+	instanceTypes map[string]string
 }
 
 func makeBundleHandler(
@@ -220,6 +230,7 @@ func makeBundleHandler(
 		unitStatus:    make(map[string]string),
 		macaroons:     make(map[*charm.URL]*macaroon.Macaroon),
 		channels:      make(map[*charm.URL]csparams.Channel),
+		instanceTypes: make(map[string]string),
 	}
 }
 
@@ -463,6 +474,9 @@ func (h *bundleHandler) makeResourceMap(storeResources map[string]int, localReso
 func (h *bundleHandler) addApplication(change *bundlechanges.AddApplicationChange) error {
 	// TODO: add verbose output for details
 	if h.dryRun {
+		// Units added to the application in the dry run need its name
+		// to report the instance types of their new machines.
+		h.results[change.Id()] = change.Params.Application
 		return nil
 	}
 
@@ -590,7 +604,11 @@ func (h *bundleHandler) addMachine(change *bundlechanges.AddMachineChange) error
 		h.ctx.Verbosef("  %s", output)
 	}
 	if h.dryRun {
-		return nil
+		if p.ContainerType != "" && p.ParentId != "" {
+			// The container is placed on an existing machine.
+			return nil
+		}
+		return h.describeInstanceType(p.Constraints)
 	}
 
 	deployedApps := func() string {
@@ -661,6 +679,42 @@ func (h *bundleHandler) addMachine(change *bundlechanges.AddMachineChange) error
 	return nil
 }
 
+// describeInstanceType writes, in a dry run, the instance type, and its
+// estimated cost, that a new machine with the given constraints would
+// be given. The model's constraints are taken into account.
+func (h *bundleHandler) describeInstanceType(cons string) error {
+	if h.modelConstraints == nil {
+		modelCons, err := h.api.GetModelConstraints()
+		if err != nil {
+			return errors.Annotate(err, "cannot get model constraints")
+		}
+		h.modelConstraints = &modelCons
+	}
+	machineCons, err := constraints.Parse(cons)
+	if err != nil {
+		// This should never happen, as the bundle is already verified.
+		return errors.Annotate(err, "invalid constraints for machine")
+	}
+	// The machine's constraints take precedence over the model's.
+	effective, err := constraints.NewValidator().Merge(*h.modelConstraints, machineCons)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	description, ok := h.instanceTypes[effective.String()]
+	if !ok {
+		results, err := h.api.InstanceTypes([]constraints.Value{effective})
+		if err != nil {
+			return errors.Annotate(err, "cannot get instance types")
+		}
+		description = common.DescribeInstanceType(results[0])
+		h.instanceTypes[effective.String()] = description
+	}
+	if description != "" {
+		fmt.Fprintf(h.ctx.Stdout, "  %s\n", description)
+	}
+	return nil
+}
+
 // addRelation creates a relationship between two services.
 func (h *bundleHandler) addRelation(change *bundlechanges.AddRelationChange) error {
 	if h.dryRun {
@@ -685,11 +739,20 @@ func (h *bundleHandler) addRelation(change *bundlechanges.AddRelationChange) err
 
 // addUnit adds a single unit to an application already present in the environment.
 func (h *bundleHandler) addUnit(change *bundlechanges.AddUnitChange) error {
+	p := change.Params
 	if h.dryRun {
-		return nil
+		if p.To != "" {
+			return nil
+		}
+		// The unit is placed on a new machine, with the application's
+		// constraints.
+		var cons string
+		if app, ok := h.data.Applications[resolve(p.Application, h.results)]; ok {
+			cons = app.Constraints
+		}
+		return h.describeInstanceType(cons)
 	}
 
-	p := change.Params
 	applicationName := resolve(p.Application, h.results)
 	var err error
 	var placementArg []*instance.Placement
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/api/annotations"
 	"github.com/juju/juju/api/application"
 	apicharms "github.com/juju/juju/api/charms"
+	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/api/modelconfig"
 	apiparams "github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
@@ -96,6 +97,11 @@ type DeployAPI interface {
 	GetBundle(*charm.URL) (charm.Bundle, error)
 
 	WatchAll() (*api.AllWatcher, error)
+
+	// GetModelConstraints and InstanceTypes are used to report the
+	// instance types new machines would be given in a dry run.
+	GetModelConstraints() (constraints.Value, error)
+	InstanceTypes([]constraints.Value) ([]apiparams.InstanceTypesResult, error)
 }
 
 // The following structs exist purely because Go cannot create a
@@ -167,6 +173,10 @@ func (a *deployAPIAdapter) Deploy(args application.DeployArgs) error {
 	return errors.Trace(a.applicationClient.Deploy(args))
 }
 
+func (a *deployAPIAdapter) InstanceTypes(cons []constraints.Value) ([]apiparams.InstanceTypesResult, error) {
+	return machinemanager.NewClient(a.Connection).InstanceTypes(cons)
+}
+
 func (a *deployAPIAdapter) Resolve(cfg *config.Config, url *charm.URL) (
 	*charm.URL,
 	params.Channel,
@@ -454,6 +464,11 @@ for the bundle machine 3.
 Only top level machines can be mapped in this way, just as only top level
 machines can be defined in the machines section of the bundle.
 
+The option --dry-run shows the changes a bundle deployment would make
+without making them. For each new machine, it also shows the instance type
+the machine would be given, the cheapest matching the model's and the
+machine's constraints, and its estimated cost where the cloud reports one.
+
 
 Examples:
     juju deploy mysql               (deploy to a new machine)
@@ -106,6 +106,33 @@ func NewRemoveSaasCommandForTest(api RemoveSaasAPI) modelcmd.ModelCommand {
 	return modelcmd.Wrap(cmd)
 }
 
+// NewSetAutoscalingPolicyCommandForTest returns a SetAutoscalingPolicyCommand with the api provided as specified.
+func NewSetAutoscalingPolicyCommandForTest(api AutoscalingAPI) modelcmd.ModelCommand {
+	cmd := &setAutoscalingPolicyCommand{}
+	cmd.newAPIFunc = func() (AutoscalingAPI, error) {
+		return api, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowAutoscalingPolicyCommandForTest returns a ShowAutoscalingPolicyCommand with the api provided as specified.
+func NewShowAutoscalingPolicyCommandForTest(api AutoscalingAPI) modelcmd.ModelCommand {
+	cmd := &showAutoscalingPolicyCommand{}
+	cmd.newAPIFunc = func() (AutoscalingAPI, error) {
+		return api, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRemoveAutoscalingPolicyCommandForTest returns a RemoveAutoscalingPolicyCommand with the api provided as specified.
+func NewRemoveAutoscalingPolicyCommandForTest(api AutoscalingAPI) modelcmd.ModelCommand {
+	cmd := &removeAutoscalingPolicyCommand{}
+	cmd.newAPIFunc = func() (AutoscalingAPI, error) {
+		return api, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
@@ -298,6 +298,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(status.NewWaitForCommand())
+	r.Register(status.NewExportStatusHistoryCommand())
+	r.Register(status.NewEventsCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newDefaultRunCommand())
@@ -347,6 +350,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(user.NewLogoutCommand())
 	r.Register(user.NewRemoveCommand())
 	r.Register(user.NewWhoAmICommand())
+	r.Register(user.NewAddTokenCommand())
+	r.Register(user.NewListTokensCommand())
+	r.Register(user.NewRevokeTokenCommand())
 
 	// Manage cached images
 	r.Register(cachedimages.NewRemoveCommand())
@@ -357,6 +363,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(machine.NewRemoveCommand())
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
+	r.Register(machine.NewAddMachinePoolCommand())
+	r.Register(machine.NewSetMachinePoolCommand())
+	r.Register(machine.NewListMachinePoolsCommand())
 
 	// Manage model
 	r.Register(model.NewConfigCommand())
@@ -379,6 +388,17 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
 	r.Register(action.NewCancelCommand())
+	r.Register(action.NewRolloutCommand())
+	r.Register(action.NewShowRolloutCommand())
+	r.Register(action.NewCancelRolloutCommand())
+	r.Register(action.NewListOperationsCommand())
+	r.Register(action.NewShowOperationCommand())
+	r.Register(action.NewAddMachineActionCommand())
+	r.Register(action.NewListMachineActionsCommand())
+	r.Register(action.NewRemoveMachineActionCommand())
+	r.Register(action.NewAddScheduleCommand())
+	r.Register(action.NewListSchedulesCommand())
+	r.Register(action.NewRemoveScheduleCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
@@ -391,6 +411,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
+	r.Register(application.NewSetAutoscalingPolicyCommand())
+	r.Register(application.NewShowAutoscalingPolicyCommand())
+	r.Register(application.NewRemoveAutoscalingPolicyCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
@@ -386,16 +386,21 @@ func assertNoArgs(c *gc.C, argChan <-chan []string) {
 }
 
 var commandNames = []string{
+	"action-schedules",
 	"actions",
+	"add-action-schedule",
 	"add-cloud",
 	"add-credential",
 	"add-machine",
+	"add-machine-action",
+	"add-machine-pool",
 	"add-model",
 	"add-relation",
 	"add-space",
 	"add-ssh-key",
 	"add-storage",
 	"add-subnet",
+	"add-token",
 	"add-unit",
 	"add-user",
 	"agree",
@@ -409,6 +414,7 @@ var commandNames = []string{
 	"budget",
 	"cached-images",
 	"cancel-action",
+	"cancel-action-rollout",
 	"change-user-password",
 	"charm",
 	"charm-resources",
@@ -436,6 +442,8 @@ var commandNames = []string{
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
+	"events",
+	"export-status-history",
 	"expose",
 	"find-offers",
 	"firewall-rules",
@@ -450,6 +458,7 @@ var commandNames = []string{
 	"import-filesystem",
 	"import-ssh-key",
 	"kill-controller",
+	"list-action-schedules",
 	"list-actions",
 	"list-agreements",
 	"list-backups",
@@ -460,9 +469,12 @@ var commandNames = []string{
 	"list-credentials",
 	"list-disabled-commands",
 	"list-firewall-rules",
+	"list-machine-actions",
+	"list-machine-pools",
 	"list-machines",
 	"list-models",
 	"list-offers",
+	"list-operations",
 	"list-payloads",
 	"list-plans",
 	"list-regions",
@@ -472,10 +484,13 @@ var commandNames = []string{
 	"list-storage",
 	"list-storage-pools",
 	"list-subnets",
+	"list-tokens",
 	"list-users",
 	"list-wallets",
 	"login",
 	"logout",
+	"machine-actions",
+	"machine-pools",
 	"machines",
 	"metrics",
 	"migrate",
@@ -485,19 +500,23 @@ var commandNames = []string{
 	"models",
 	"offer",
 	"offers",
+	"operations",
 	"payloads",
 	"plans",
 	"regions",
 	"register",
 	"relate", //alias for add-relation
 	"reload-spaces",
+	"remove-action-schedule",
 	"remove-application",
+	"remove-autoscaling-policy",
 	"remove-backup",
 	"remove-cached-images",
 	"remove-cloud",
 	"remove-consumed-application",
 	"remove-credential",
 	"remove-machine",
+	"remove-machine-action",
 	"remove-offer",
 	"remove-relation",
 	"remove-saas",
@@ -512,25 +531,32 @@ var commandNames = []string{
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
+	"revoke-token",
+	"rollout-action",
 	"run",
 	"run-action",
 	"scp",
+	"set-autoscaling-policy",
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
 	"set-firewall-rule",
+	"set-machine-pool",
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
 	"set-wallet",
 	"show-action-output",
+	"show-action-rollout",
 	"show-action-status",
+	"show-autoscaling-policy",
 	"show-backup",
 	"show-cloud",
 	"show-controller",
 	"show-machine",
 	"show-model",
 	"show-offer",
+	"show-operation",
 	"show-status",
 	"show-status-log",
 	"show-storage",
@@ -548,6 +574,7 @@ var commandNames = []string{
 	"switch",
 	"sync-agent-binaries",
 	"sync-tools",
+	"tokens",
 	"unexpose",
 	"unregister",
 	"update-clouds",
@@ -559,6 +586,7 @@ var commandNames = []string{
 	"upload-backup",
 	"users",
 	"version",
+	"wait-for",
 	"wallets",
 	"whoami",
 }
@@ -0,0 +1,52 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// DescribeInstanceType returns a user facing description of the
+// instance type a new machine would be given, and its estimated cost,
+// from the instance types matching the machine's constraints. The
+// instance types are expected to be sorted by increasing cost, as the
+// MachineManager facade returns them. An empty string is returned if
+// the cloud does not report its instance types.
+func DescribeInstanceType(result params.InstanceTypesResult) string {
+	if result.Error != nil {
+		if params.IsCodeNotSupported(result.Error) {
+			return ""
+		}
+		return fmt.Sprintf("instance type unknown: %v", result.Error)
+	}
+	if len(result.InstanceTypes) == 0 {
+		return "no matching instance type"
+	}
+	itype := result.InstanceTypes[0]
+	if itype.Cost == 0 {
+		return fmt.Sprintf("instance type %s, cost unknown", itype.Name)
+	}
+	return fmt.Sprintf("instance type %s, estimated cost %s", itype.Name, FormatInstanceTypeCost(result, itype))
+}
+
+// FormatInstanceTypeCost returns the cost of an instance type in the
+// unit reported by the cloud, e.g. "0.1 $USD/hour".
+func FormatInstanceTypeCost(result params.InstanceTypesResult, itype params.InstanceType) string {
+	cost := float64(itype.Cost)
+	if result.CostDivisor > 0 {
+		cost /= float64(result.CostDivisor)
+	}
+	unit := result.CostUnit
+	if unit == "" {
+		unit = result.CostCurrency
+	}
+	formatted := strconv.FormatFloat(cost, 'f', -1, 64)
+	if unit != "" {
+		formatted += " " + unit
+	}
+	return formatted
+}
@@ -0,0 +1,67 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+)
+
+type InstanceTypesSuite struct{}
+
+var _ = gc.Suite(&InstanceTypesSuite{})
+
+func (s *InstanceTypesSuite) TestDescribeInstanceType(c *gc.C) {
+	for i, test := range []struct {
+		about    string
+		result   params.InstanceTypesResult
+		expected string
+	}{{
+		about: "cheapest instance type with cost",
+		result: params.InstanceTypesResult{
+			InstanceTypes: []params.InstanceType{
+				{Name: "m3.medium", Cost: 67},
+				{Name: "m4.large", Cost: 100},
+			},
+			CostUnit:     "$USD/hour",
+			CostCurrency: "USD",
+			CostDivisor:  1000,
+		},
+		expected: "instance type m3.medium, estimated cost 0.067 $USD/hour",
+	}, {
+		about: "currency used when there is no unit",
+		result: params.InstanceTypesResult{
+			InstanceTypes: []params.InstanceType{{Name: "Standard_D1", Cost: 2}},
+			CostCurrency:  "USD",
+		},
+		expected: "instance type Standard_D1, estimated cost 2 USD",
+	}, {
+		about: "unknown cost",
+		result: params.InstanceTypesResult{
+			InstanceTypes: []params.InstanceType{{Name: "m1.small"}},
+		},
+		expected: "instance type m1.small, cost unknown",
+	}, {
+		about:    "no matching instance types",
+		result:   params.InstanceTypesResult{},
+		expected: "no matching instance type",
+	}, {
+		about: "instance types not supported",
+		result: params.InstanceTypesResult{
+			Error: &params.Error{Code: params.CodeNotSupported, Message: "InstanceTypes not supported"},
+		},
+		expected: "",
+	}, {
+		about: "error",
+		result: params.InstanceTypesResult{
+			Error: &params.Error{Message: "boom"},
+		},
+		expected: "instance type unknown: boom",
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		c.Check(common.DescribeInstanceType(test.result), gc.Equals, test.expected)
+	}
+}
@@ -62,6 +62,15 @@ It is possible to override or augment constraints by passing provider-specific
 information about how to allocate the machine. For example, one can direct the
 MAAS provider to acquire a particular node by specifying its hostname.
 
+A machine added with "--pool" joins the named machine pool, and only units
+of the applications the pool allows will be placed on it. Containers join
+the pool of the machine that hosts them.
+
+With "--dry-run", no machines are added; instead the instance type each
+new machine would be given, the cheapest matching the model's and the
+machine's constraints, is shown with its estimated cost where the cloud
+reports one.
+
 Examples:
    juju add-machine                      (starts a new machine)
    juju add-machine -n 2                 (starts 2 new machines)
@@ -73,9 +82,12 @@ Examples:
    juju add-machine winrm:user@10.10.0.3 (manually provisions machine with winrm)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
    juju add-machine maas2.name           (acquire machine maas2.name on MAAS)
+   juju add-machine --pool gpu           (starts a new machine in the gpu pool)
+   juju add-machine --dry-run            (shows the instance type a new machine would get)
 
 See also:
     remove-machine
+    add-machine-pool
 `
 
 func init() {
@@ -114,6 +126,11 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// Pool is the name of the machine pool the new machines join.
+	Pool string
+	// DryRun, if true, reports the instance type each new machine
+	// would be given instead of adding the machines.
+	DryRun bool
 }
 
 func (c *addCommand) Info() *cmd.Info {
@@ -131,6 +148,8 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Additional machine constraints")
 	f.Var(disksFlag{&c.Disks}, "disks", "Constraints for disks to attach to the machine")
+	f.StringVar(&c.Pool, "pool", "", "The machine pool the new machine joins")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show the instance type and estimated cost of the new machines without adding them")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -152,6 +171,18 @@ func (c *addCommand) Init(args []string) error {
 	if c.NumMachines > 1 && c.Placement != nil && c.Placement.Directive != "" {
 		return errors.New("cannot use -n when specifying a placement directive")
 	}
+	if c.Pool != "" && c.Placement != nil {
+		switch c.Placement.Scope {
+		case sshScope, winrmScope:
+			return errors.New("cannot use --pool when manually provisioning a machine")
+		}
+	}
+	if c.DryRun && c.Placement != nil {
+		switch c.Placement.Scope {
+		case sshScope, winrmScope:
+			return errors.New("cannot use --dry-run when manually provisioning a machine")
+		}
+	}
 	return nil
 }
 
@@ -160,6 +191,7 @@ type AddMachineAPI interface {
 	Close() error
 	ForceDestroyMachines(machines ...string) error
 	DestroyMachinesWithParams(force, keep bool, machines ...string) error
+	GetModelConstraints() (constraints.Value, error)
 	ModelUUID() (string, bool)
 	ProvisioningScript(params.ProvisioningScriptParams) (script string, err error)
 }
@@ -173,6 +205,7 @@ type MachineManagerAPI interface {
 	AddMachines([]params.AddMachineParams) ([]params.AddMachinesResult, error)
 	BestAPIVersion() int
 	Close() error
+	InstanceTypes([]constraints.Value) ([]params.InstanceTypesResult, error)
 }
 
 // splitUserHost given a host string of example user@192.168.122.122
@@ -230,16 +263,23 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.DryRun {
+		return c.dryRun(ctx, client)
+	}
+
 	var machineManager MachineManagerAPI
-	if len(c.Disks) > 0 {
+	if len(c.Disks) > 0 || c.Pool != "" {
 		machineManager, err = c.getMachineManagerAPI()
 		if err != nil {
 			return errors.Trace(err)
 		}
 		defer machineManager.Close()
-		if machineManager.BestAPIVersion() < 1 {
+		if len(c.Disks) > 0 && machineManager.BestAPIVersion() < 1 {
 			return errors.New("cannot add machines with disks: not supported by the API server")
 		}
+		if c.Pool != "" && machineManager.BestAPIVersion() < 5 {
+			return errors.New("cannot add machines to a pool: not supported by the API server")
+		}
 	}
 
 	logger.Infof("load config")
@@ -289,6 +329,7 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		Constraints: c.Constraints,
 		Jobs:        jobs,
 		Disks:       c.Disks,
+		Pool:        c.Pool,
 	}
 	machines := make([]params.AddMachineParams, c.NumMachines)
 	for i := 0; i < c.NumMachines; i++ {
@@ -296,8 +337,9 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	}
 
 	var results []params.AddMachinesResult
-	// If storage is specified, we attempt to use a new API on the service facade.
-	if len(c.Disks) > 0 {
+	// If storage or a pool is specified, we attempt to use a new API on
+	// the machine manager facade.
+	if machineManager != nil {
 		results, err = machineManager.AddMachines(machines)
 	} else {
 		results, err = client.AddMachines(machines)
@@ -338,6 +380,52 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// dryRun reports the instance type, and its estimated cost, that the
+// new machines would be given, without adding them.
+func (c *addCommand) dryRun(ctx *cmd.Context, client AddMachineAPI) error {
+	if c.Placement != nil && c.Placement.Directive != "" {
+		if _, err := instance.ParseContainerType(c.Placement.Scope); err == nil {
+			fmt.Fprintf(ctx.Stdout, "Would add a %s container to machine %s.\n", c.Placement.Scope, c.Placement.Directive)
+			return nil
+		}
+	}
+	modelCons, err := client.GetModelConstraints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The machine's constraints take precedence over the model's.
+	cons, err := constraints.NewValidator().Merge(modelCons, c.Constraints)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machineManager, err := c.getMachineManagerAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer machineManager.Close()
+	results, err := machineManager.InstanceTypes([]constraints.Value{cons})
+	if err != nil {
+		return errors.Annotate(err, "cannot get instance types")
+	}
+
+	machines := "1 machine"
+	if c.NumMachines != 1 {
+		machines = fmt.Sprintf("%d machines", c.NumMachines)
+	}
+	fmt.Fprintf(ctx.Stdout, "Would add %s", machines)
+	if !constraints.IsEmpty(&cons) {
+		fmt.Fprintf(ctx.Stdout, " with constraints %q", cons.String())
+	}
+	if description := common.DescribeInstanceType(results[0]); description != "" {
+		fmt.Fprintf(ctx.Stdout, ": %s", description)
+		if c.NumMachines != 1 {
+			fmt.Fprint(ctx.Stdout, " each")
+		}
+	}
+	fmt.Fprintln(ctx.Stdout, ".")
+	return nil
+}
+
 var (
 	sshProvisioner    = sshprovisioner.ProvisionMachine
 	winrmProvisioner  = winrmprovisioner.ProvisionMachine
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/manual"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state/multiwatcher"
@@ -211,6 +212,91 @@ func (s *AddMachineSuite) TestAddMachineWithDisksUnsupported(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cannot add machines with disks: not supported by the API server")
 }
 
+func (s *AddMachineSuite) TestAddMachineWithPool(c *gc.C) {
+	s.fakeMachineManager.apiVersion = 5
+	_, err := s.run(c, "--pool", "gpu", "-n", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAddMachine.args, gc.HasLen, 0)
+	c.Assert(s.fakeMachineManager.args, gc.HasLen, 2)
+	c.Assert(s.fakeMachineManager.args[0].Pool, gc.Equals, "gpu")
+	c.Assert(s.fakeMachineManager.args[1].Pool, gc.Equals, "gpu")
+}
+
+func (s *AddMachineSuite) TestAddMachineWithPoolUnsupported(c *gc.C) {
+	s.fakeMachineManager.apiVersion = 4
+	_, err := s.run(c, "--pool", "gpu")
+	c.Assert(err, gc.ErrorMatches, "cannot add machines to a pool: not supported by the API server")
+}
+
+func (s *AddMachineSuite) TestAddMachineWithPoolManual(c *gc.C) {
+	_, err := s.run(c, "--pool", "gpu", "ssh:user@10.10.0.3")
+	c.Assert(err, gc.ErrorMatches, "cannot use --pool when manually provisioning a machine")
+}
+
+func (s *AddMachineSuite) TestDryRun(c *gc.C) {
+	s.fakeAddMachine.modelConstraints = constraints.MustParse("cores=2")
+	s.fakeMachineManager.instanceTypes = params.InstanceTypesResult{
+		InstanceTypes: []params.InstanceType{
+			{Name: "m4.large", CPUCores: 2, Memory: 8192, Cost: 100},
+			{Name: "m4.xlarge", CPUCores: 4, Memory: 16384, Cost: 200},
+		},
+		CostUnit:    "$USD/hour",
+		CostDivisor: 1000,
+	}
+	context, err := s.run(c, "--dry-run", "-n", "2", "--constraints", "mem=8G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals,
+		`Would add 2 machines with constraints "cores=2 mem=8192M": instance type m4.large, estimated cost 0.1 $USD/hour each.`+"\n",
+	)
+	c.Assert(s.fakeMachineManager.instanceTypesArgs, jc.DeepEquals, []constraints.Value{
+		constraints.MustParse("cores=2 mem=8G"),
+	})
+	c.Assert(s.fakeAddMachine.args, gc.HasLen, 0)
+	c.Assert(s.fakeMachineManager.args, gc.HasLen, 0)
+}
+
+func (s *AddMachineSuite) TestDryRunOverlappingConstraints(c *gc.C) {
+	s.fakeAddMachine.modelConstraints = constraints.MustParse("cores=2 mem=4G")
+	s.fakeMachineManager.instanceTypes = params.InstanceTypesResult{
+		InstanceTypes: []params.InstanceType{
+			{Name: "m4.large", CPUCores: 2, Memory: 8192, Cost: 100},
+		},
+		CostUnit:    "$USD/hour",
+		CostDivisor: 1000,
+	}
+	context, err := s.run(c, "--dry-run", "--constraints", "mem=8G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals,
+		`Would add 1 machine with constraints "cores=2 mem=8192M": instance type m4.large, estimated cost 0.1 $USD/hour.`+"\n",
+	)
+	c.Assert(s.fakeMachineManager.instanceTypesArgs, jc.DeepEquals, []constraints.Value{
+		constraints.MustParse("cores=2 mem=8G"),
+	})
+}
+
+func (s *AddMachineSuite) TestDryRunInstanceTypesNotSupported(c *gc.C) {
+	s.fakeMachineManager.instanceTypes = params.InstanceTypesResult{
+		Error: &params.Error{Code: params.CodeNotSupported, Message: "InstanceTypes not supported"},
+	}
+	context, err := s.run(c, "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "Would add 1 machine.\n")
+	c.Assert(s.fakeAddMachine.args, gc.HasLen, 0)
+}
+
+func (s *AddMachineSuite) TestDryRunContainerOnMachine(c *gc.C) {
+	context, err := s.run(c, "--dry-run", "lxd:4")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "Would add a lxd container to machine 4.\n")
+	c.Assert(s.fakeMachineManager.instanceTypesArgs, gc.HasLen, 0)
+	c.Assert(s.fakeAddMachine.args, gc.HasLen, 0)
+}
+
+func (s *AddMachineSuite) TestDryRunManual(c *gc.C) {
+	_, err := s.run(c, "--dry-run", "ssh:user@10.10.0.3")
+	c.Assert(err, gc.ErrorMatches, "cannot use --dry-run when manually provisioning a machine")
+}
+
 type fakeAddMachineAPI struct {
 	successOrder     []bool
 	currentOp        int
@@ -218,6 +304,7 @@ type fakeAddMachineAPI struct {
 	addError         error
 	addModelGetError error
 	providerType     string
+	modelConstraints constraints.Value
 }
 
 func (f *fakeAddMachineAPI) Close() error {
@@ -259,6 +346,10 @@ func (f *fakeAddMachineAPI) DestroyMachinesWithParams(force, keep bool, machines
 	return errors.NotImplementedf("ForceDestroyMachinesWithParams")
 }
 
+func (f *fakeAddMachineAPI) GetModelConstraints() (constraints.Value, error) {
+	return f.modelConstraints, nil
+}
+
 func (f *fakeAddMachineAPI) ProvisioningScript(params.ProvisioningScriptParams) (script string, err error) {
 	return "", errors.NotImplementedf("ProvisioningScript")
 }
@@ -279,8 +370,16 @@ func (f *fakeAddMachineAPI) ModelGet() (map[string]interface{}, error) {
 type fakeMachineManagerAPI struct {
 	apiVersion int
 	fakeAddMachineAPI
+
+	instanceTypes     params.InstanceTypesResult
+	instanceTypesArgs []constraints.Value
 }
 
 func (f *fakeMachineManagerAPI) BestAPIVersion() int {
 	return f.apiVersion
 }
+
+func (f *fakeMachineManagerAPI) InstanceTypes(cons []constraints.Value) ([]params.InstanceTypesResult, error) {
+	f.instanceTypesArgs = append(f.instanceTypesArgs, cons...)
+	return []params.InstanceTypesResult{f.instanceTypes}, nil
+}
@@ -57,3 +57,25 @@ func NewRemoveCommandForTest(apiRoot api.Connection, machineAPI RemoveMachineAPI
 func NewDisksFlag(disks *[]storage.Constraints) *disksFlag {
 	return &disksFlag{disks}
 }
+
+// NewAddMachinePoolCommandForTest returns an add-machine-pool command
+// with the api provided as specified.
+func NewAddMachinePoolCommandForTest(api MachinePoolAPI) cmd.Command {
+	c := &addMachinePoolCommand{}
+	c.api = api
+	return modelcmd.Wrap(c)
+}
+
+// NewSetMachinePoolCommandForTest returns a set-machine-pool command
+// with the api provided as specified.
+func NewSetMachinePoolCommandForTest(api MachinePoolAPI) cmd.Command {
+	c := &setMachinePoolCommand{}
+	c.api = api
+	return modelcmd.Wrap(c)
+}
+
+// NewListMachinePoolsCommandForTest returns a machine-pools command
+// with the api provided as specified.
+func NewListMachinePoolsCommandForTest(api ListMachinePoolsAPI) cmd.Command {
+	return modelcmd.Wrap(&listMachinePoolsCommand{api: api})
+}
@@ -0,0 +1,155 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var usageListMachinePoolsSummary = `
+Lists the machine pools in a model.`[1:]
+
+var usageListMachinePoolsDetails = `
+For each pool, the applications it allows, its labels and its machines
+are shown.
+
+Examples:
+    juju machine-pools
+    juju machine-pools --format yaml
+
+See also:
+    add-machine-pool
+    set-machine-pool`[1:]
+
+// ListMachinePoolsAPI defines the API methods that the machine-pools
+// command uses.
+type ListMachinePoolsAPI interface {
+	ListMachinePools() ([]params.MachinePool, error)
+	Close() error
+}
+
+// NewListMachinePoolsCommand returns a command that lists machine pools.
+func NewListMachinePoolsCommand() cmd.Command {
+	return modelcmd.Wrap(&listMachinePoolsCommand{})
+}
+
+// listMachinePoolsCommand lists the machine pools in a model.
+type listMachinePoolsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api ListMachinePoolsAPI
+}
+
+// MachinePoolInfo holds the details of a machine pool for output.
+type MachinePoolInfo struct {
+	Applications []string          `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Labels       map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Machines     []string          `yaml:"machines,omitempty" json:"machines,omitempty"`
+}
+
+// Info implements Command.Info.
+func (c *listMachinePoolsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "machine-pools",
+		Purpose: usageListMachinePoolsSummary,
+		Doc:     usageListMachinePoolsDetails,
+		Aliases: []string{"list-machine-pools"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listMachinePoolsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatMachinePoolsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listMachinePoolsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *listMachinePoolsCommand) getAPI() (ListMachinePoolsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *listMachinePoolsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	pools, err := api.ListMachinePools()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(pools) == 0 {
+		ctx.Infof("No machine pools to display.")
+		return nil
+	}
+	infos := make(map[string]MachinePoolInfo)
+	for _, pool := range pools {
+		infos[pool.Name] = MachinePoolInfo{
+			Applications: pool.Applications,
+			Labels:       pool.Labels,
+			Machines:     pool.Machines,
+		}
+	}
+	return c.out.Write(ctx, infos)
+}
+
+func formatMachinePoolsTabular(writer io.Writer, value interface{}) error {
+	pools, ok := value.(map[string]MachinePoolInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", pools, value)
+	}
+	names := make([]string, 0, len(pools))
+	for name := range pools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Pool", "Applications", "Labels", "Machines")
+	for _, name := range names {
+		pool := pools[name]
+		labels := make([]string, 0, len(pool.Labels))
+		for key, value := range pool.Labels {
+			labels = append(labels, key+"="+value)
+		}
+		sort.Strings(labels)
+		w.Println(
+			name,
+			strings.Join(pool.Applications, ","),
+			strings.Join(labels, ","),
+			strings.Join(pool.Machines, ","),
+		)
+	}
+	tw.Flush()
+	return nil
+}
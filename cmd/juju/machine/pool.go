@@ -0,0 +1,187 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageAddMachinePoolSummary = `
+Adds a machine pool to the model.`[1:]
+
+var usageAddMachinePoolDetails = `
+A machine pool is a named group of machines that only hosts units of the
+applications it allows. Units of any other application are never placed
+on the pool's machines, whether or not a placement directive is given.
+A pool that allows no applications hosts no units.
+
+Machines join a pool when they are added with "juju add-machine --pool";
+containers join the pool of the machine that hosts them. Labels are
+free-form key=value pairs describing the pool's machines.
+
+Examples:
+    juju add-machine-pool gpu --applications trainer,inference gpu=nvidia
+    juju add-machine --pool gpu -n 2
+
+See also:
+    add-machine
+    machine-pools
+    set-machine-pool`[1:]
+
+var usageSetMachinePoolSummary = `
+Sets the allowed applications and labels of a machine pool.`[1:]
+
+var usageSetMachinePoolDetails = `
+The pool's allowed applications and labels are replaced by those given.
+Units already placed on the pool's machines are not moved; the new rules
+apply to units placed from then on.
+
+Examples:
+    juju set-machine-pool gpu --applications trainer gpu=nvidia
+
+See also:
+    add-machine-pool
+    machine-pools`[1:]
+
+// MachinePoolAPI defines the API methods that the add-machine-pool and
+// set-machine-pool commands use.
+type MachinePoolAPI interface {
+	AddMachinePool(params.MachinePool) error
+	SetMachinePool(params.MachinePool) error
+	Close() error
+}
+
+// NewAddMachinePoolCommand returns a command that adds a machine pool.
+func NewAddMachinePoolCommand() cmd.Command {
+	return modelcmd.Wrap(&addMachinePoolCommand{})
+}
+
+// NewSetMachinePoolCommand returns a command that updates a machine pool.
+func NewSetMachinePoolCommand() cmd.Command {
+	return modelcmd.Wrap(&setMachinePoolCommand{})
+}
+
+// machinePoolCommandBase holds the arguments shared by the
+// add-machine-pool and set-machine-pool commands.
+type machinePoolCommandBase struct {
+	modelcmd.ModelCommandBase
+	api MachinePoolAPI
+
+	applications string
+	Pool         params.MachinePool
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *machinePoolCommandBase) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.applications, "applications", "", "Comma-separated names of the applications the pool allows")
+}
+
+// Init implements Command.Init.
+func (c *machinePoolCommandBase) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no machine pool name specified")
+	}
+	c.Pool.Name = args[0]
+	labels, err := keyvalues.Parse(args[1:], false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(labels) > 0 {
+		c.Pool.Labels = labels
+	}
+	for _, name := range strings.Split(c.applications, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !names.IsValidApplication(name) {
+			return errors.NotValidf("application name %q", name)
+		}
+		c.Pool.Applications = append(c.Pool.Applications, name)
+	}
+	return nil
+}
+
+func (c *machinePoolCommandBase) getAPI() (MachinePoolAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// addMachinePoolCommand adds a machine pool to the model.
+type addMachinePoolCommand struct {
+	machinePoolCommandBase
+}
+
+// Info implements Command.Info.
+func (c *addMachinePoolCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-machine-pool",
+		Args:    "<name> [<key>=<value> ...]",
+		Purpose: usageAddMachinePoolSummary,
+		Doc:     usageAddMachinePoolDetails,
+	}
+}
+
+// Run implements Command.Run.
+func (c *addMachinePoolCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.AddMachinePool(c.Pool); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("added machine pool %q", c.Pool.Name)
+	return nil
+}
+
+// setMachinePoolCommand replaces the allowed applications and labels of
+// a machine pool.
+type setMachinePoolCommand struct {
+	machinePoolCommandBase
+}
+
+// Info implements Command.Info.
+func (c *setMachinePoolCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-machine-pool",
+		Args:    "<name> [<key>=<value> ...]",
+		Purpose: usageSetMachinePoolSummary,
+		Doc:     usageSetMachinePoolDetails,
+	}
+}
+
+// Run implements Command.Run.
+func (c *setMachinePoolCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.SetMachinePool(c.Pool); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type MachinePoolCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *mockMachinePoolAPI
+}
+
+var _ = gc.Suite(&MachinePoolCommandSuite{})
+
+func (s *MachinePoolCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &mockMachinePoolAPI{}
+}
+
+func (s *MachinePoolCommandSuite) TestAddMachinePoolInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no machine pool name specified",
+	}, {
+		args: []string{"gpu", "--applications", "no_good"},
+		err:  `application name "no_good" not valid`,
+	}, {
+		args: []string{"gpu", "nvidia"},
+		err:  `expected "key=value", got "nvidia"`,
+	}} {
+		c.Logf("test %d", i)
+		err := cmdtesting.InitCommand(machine.NewAddMachinePoolCommandForTest(s.api), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *MachinePoolCommandSuite) TestAddMachinePool(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, machine.NewAddMachinePoolCommandForTest(s.api),
+		"gpu", "--applications", "trainer, inference", "gpu=nvidia",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "added machine pool \"gpu\"\n")
+	s.api.CheckCalls(c, []jtesting.StubCall{
+		{"AddMachinePool", []interface{}{params.MachinePool{
+			Name:         "gpu",
+			Labels:       map[string]string{"gpu": "nvidia"},
+			Applications: []string{"trainer", "inference"},
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *MachinePoolCommandSuite) TestAddMachinePoolBlocked(c *gc.C) {
+	s.api.SetErrors(common.OperationBlockedError("TestAddMachinePoolBlocked"))
+	_, err := cmdtesting.RunCommand(c, machine.NewAddMachinePoolCommandForTest(s.api), "gpu")
+	testing.AssertOperationWasBlocked(c, err, ".*TestAddMachinePoolBlocked.*")
+}
+
+func (s *MachinePoolCommandSuite) TestSetMachinePool(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, machine.NewSetMachinePoolCommandForTest(s.api),
+		"gpu", "--applications", "trainer",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jtesting.StubCall{
+		{"SetMachinePool", []interface{}{params.MachinePool{
+			Name:         "gpu",
+			Applications: []string{"trainer"},
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *MachinePoolCommandSuite) TestListMachinePools(c *gc.C) {
+	s.api.pools = []params.MachinePool{{
+		Name:         "storage",
+		Applications: []string{"ceph-osd"},
+		Machines:     []string{"3"},
+	}, {
+		Name:         "gpu",
+		Labels:       map[string]string{"gpu": "nvidia", "cuda": "10"},
+		Applications: []string{"inference", "trainer"},
+		Machines:     []string{"0", "0/lxd/0", "1"},
+	}}
+	ctx, err := cmdtesting.RunCommand(c, machine.NewListMachinePoolsCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Pool     Applications       Labels              Machines\n"+
+		"gpu      inference,trainer  cuda=10,gpu=nvidia  0,0/lxd/0,1\n"+
+		"storage  ceph-osd                               3\n",
+	)
+}
+
+func (s *MachinePoolCommandSuite) TestListMachinePoolsYAML(c *gc.C) {
+	s.api.pools = []params.MachinePool{{
+		Name:         "gpu",
+		Applications: []string{"trainer"},
+		Machines:     []string{"0"},
+	}}
+	ctx, err := cmdtesting.RunCommand(c, machine.NewListMachinePoolsCommandForTest(s.api), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"gpu:\n"+
+		"  applications:\n"+
+		"  - trainer\n"+
+		"  machines:\n"+
+		"  - \"0\"\n",
+	)
+}
+
+func (s *MachinePoolCommandSuite) TestListMachinePoolsNone(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, machine.NewListMachinePoolsCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No machine pools to display.\n")
+}
+
+type mockMachinePoolAPI struct {
+	jtesting.Stub
+	pools []params.MachinePool
+}
+
+func (m *mockMachinePoolAPI) AddMachinePool(pool params.MachinePool) error {
+	m.MethodCall(m, "AddMachinePool", pool)
+	return m.NextErr()
+}
+
+func (m *mockMachinePoolAPI) SetMachinePool(pool params.MachinePool) error {
+	m.MethodCall(m, "SetMachinePool", pool)
+	return m.NextErr()
+}
+
+func (m *mockMachinePoolAPI) ListMachinePools() ([]params.MachinePool, error) {
+	m.MethodCall(m, "ListMachinePools")
+	return m.pools, m.NextErr()
+}
+
+func (m *mockMachinePoolAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
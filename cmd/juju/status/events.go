@@ -0,0 +1,243 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/modelevents"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	// eventsPageSize is the number of events requested at a time.
+	eventsPageSize = 500
+
+	// eventsFollowInterval is how often new events are requested
+	// when following the timeline, after any have been shown.
+	eventsFollowInterval = 5 * time.Second
+
+	// eventsFollowMaxInterval is the longest time between requests
+	// for new events when none have been shown for a while.
+	eventsFollowMaxInterval = time.Minute
+)
+
+// NewEventsCommand returns a command that shows the model's event
+// timeline.
+func NewEventsCommand() cmd.Command {
+	return modelcmd.Wrap(&eventsCommand{clock: clock.WallClock})
+}
+
+// EventsAPI is the API surface for the events command.
+type EventsAPI interface {
+	Events(args params.ModelEventsArgs) ([]params.ModelEvent, string, error)
+	Close() error
+}
+
+type eventsCommand struct {
+	modelcmd.ModelCommandBase
+	out   cmd.Output
+	api   EventsAPI
+	clock clock.Clock
+
+	sinceArg  string
+	sourceArg string
+	follow    bool
+	utc       bool
+
+	since   time.Time
+	sources []string
+}
+
+var eventSources = []string{"action", "audit", "cleanup", "status"}
+
+const eventsDoc = `
+Show a single, time-ordered timeline of what has happened in the model.
+The timeline merges:
+
+    status   changes to the status of the model, applications,
+             units and machines
+    action   actions being queued, started and completed
+    cleanup  cleanups scheduled after entities are destroyed
+    audit    commands run against the model, if the controller
+             has auditing enabled and is not highly available;
+             these are shown to model administrators only
+
+By default the events of the last day are shown. Use --since to show
+events from a given time, which may be a date, an RFC3339 time or a
+duration before now. Use --source, once or with a comma-separated
+list, to show events from only some sources.
+
+With --follow, new events are shown as they happen until the command
+is interrupted. New events are requested every 5 seconds, and less
+often, down to once a minute, while nothing is happening.
+
+The json format writes one event per line.
+
+Examples:
+
+    juju events
+    juju events --since 2h --source status,action
+    juju events --since 2018-04-01 --utc
+    juju events --follow --format json
+
+See also:
+    show-status-log
+    export-status-history
+    status
+`
+
+// Info is part of the cmd.Command interface.
+func (c *eventsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "events",
+		Purpose: "Show the timeline of events in the model.",
+		Doc:     eventsDoc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *eventsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"tabular": c.formatTabular,
+		"json":    cmd.FormatJson,
+	})
+	f.StringVar(&c.sinceArg, "since", "", "Show events from this date, time or duration ago")
+	f.StringVar(&c.sourceArg, "source", "", "Show events from these comma-separated sources only")
+	f.BoolVar(&c.follow, "follow", false, "Keep showing new events as they happen")
+	f.BoolVar(&c.follow, "f", false, "")
+	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *eventsCommand) Init(args []string) error {
+	if c.sinceArg == "" {
+		c.since = c.clock.Now().Add(-24 * time.Hour)
+	} else if d, err := time.ParseDuration(c.sinceArg); err == nil {
+		if d < 0 {
+			return errors.Errorf("--since duration must not be negative")
+		}
+		c.since = c.clock.Now().Add(-d)
+	} else {
+		since, err := parseHistoryTime(c.sinceArg)
+		if err != nil {
+			return errors.Annotate(err, "parsing --since")
+		}
+		c.since = since
+	}
+	if c.sourceArg != "" {
+		for _, source := range strings.Split(c.sourceArg, ",") {
+			source = strings.TrimSpace(source)
+			if !containsString(eventSources, source) {
+				return errors.Errorf("unknown event source %q; expected one of %s", source, strings.Join(eventSources, ", "))
+			}
+			c.sources = append(c.sources, source)
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *eventsCommand) getAPI() (EventsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelevents.NewClient(root), nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *eventsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	since := c.since.UTC()
+	args := params.ModelEventsArgs{
+		Since:   &since,
+		Sources: c.sources,
+		Limit:   eventsPageSize,
+	}
+	interval := eventsFollowInterval
+	for {
+		events, cursor, err := client.Events(args)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, e := range events {
+			if err := c.out.Write(ctx, e); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		args.Cursor, args.Since = cursor, nil
+		if len(events) == eventsPageSize {
+			continue
+		}
+		if !c.follow {
+			return nil
+		}
+		if len(events) > 0 {
+			interval = eventsFollowInterval
+		}
+		select {
+		case <-c.clock.After(interval):
+		case <-interrupted:
+			return nil
+		}
+		if interval *= 2; interval > eventsFollowMaxInterval {
+			interval = eventsFollowMaxInterval
+		}
+	}
+}
+
+// formatTabular writes a single event as a line of text.
+func (c *eventsCommand) formatTabular(writer io.Writer, value interface{}) error {
+	e, ok := value.(params.ModelEvent)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", e, value)
+	}
+	t := e.Time.Local()
+	if c.utc {
+		t = e.Time.UTC()
+	}
+	_, err := fmt.Fprintf(writer, "%s  %-7s  %-16s  %-16s  %-10s  %s\n",
+		t.Format("2006-01-02 15:04:05Z07:00"),
+		e.Source, eventEntity(e.Entity), e.Type, e.Status, e.Message,
+	)
+	return errors.Trace(err)
+}
+
+// eventEntity returns the name shown for the entity with the given
+// tag: the id of a unit, application or machine, or else the tag.
+func eventEntity(tag string) string {
+	t, err := names.ParseTag(tag)
+	if err != nil {
+		return tag
+	}
+	switch t.Kind() {
+	case names.UnitTagKind, names.ApplicationTagKind, names.MachineTagKind:
+		return t.Id()
+	}
+	return tag
+}
@@ -0,0 +1,173 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	statuscmd "github.com/juju/juju/cmd/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type EventsSuite struct {
+	testing.IsolationSuite
+	clock *testing.Clock
+	api   *fakeEventsAPI
+}
+
+var _ = gc.Suite(&EventsSuite{})
+
+var eventsNow = time.Date(2018, time.April, 2, 10, 0, 0, 0, time.UTC)
+
+func (s *EventsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(eventsNow)
+	s.api = &fakeEventsAPI{called: make(chan struct{}, 10)}
+}
+
+func (s *EventsSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args   []string
+		expect string
+	}{{
+		args:   []string{"--since", "yesterday"},
+		expect: `parsing --since: "yesterday" is not a date \(YYYY-MM-DD\) or RFC3339 time`,
+	}, {
+		args:   []string{"--since", "-1h"},
+		expect: "--since duration must not be negative",
+	}, {
+		args:   []string{"--source", "status,logs"},
+		expect: `unknown event source "logs"; expected one of action, audit, cleanup, status`,
+	}, {
+		args:   []string{"extra"},
+		expect: `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		cmd := statuscmd.NewTestEventsCommand(nil, s.clock)
+		err := cmdtesting.InitCommand(cmd, test.args)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *EventsSuite) TestEvents(c *gc.C) {
+	s.api.pages = []eventsPage{{
+		events: []params.ModelEvent{{
+			Source: "status", Id: "a", Time: eventsNow.Add(-time.Hour),
+			Entity: "unit-mysql-0", Type: "workload", Status: "active", Message: "ready",
+		}, {
+			Source: "audit", Id: "b", Time: eventsNow.Add(-time.Minute),
+			Entity: "user-bob", Type: "command", Message: "juju remove-unit mysql/0",
+		}},
+		cursor: "2",
+	}}
+	ctx, err := cmdtesting.RunCommand(c, statuscmd.NewTestEventsCommand(s.api, s.clock),
+		"--since", "2h", "--source", "status,audit", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"2018-04-02 09:00:00Z  status   mysql/0           workload          active      ready\n"+
+		"2018-04-02 09:59:00Z  audit    user-bob          command                       juju remove-unit mysql/0\n",
+	)
+	since := eventsNow.Add(-2 * time.Hour)
+	c.Check(s.api.args, jc.DeepEquals, []params.ModelEventsArgs{{
+		Since:   &since,
+		Sources: []string{"status", "audit"},
+		Limit:   500,
+	}})
+}
+
+func (s *EventsSuite) TestEventsJSON(c *gc.C) {
+	s.api.pages = []eventsPage{{
+		events: []params.ModelEvent{{
+			Source: "cleanup", Id: "c", Time: eventsNow, Type: "cleanup", Status: "units", Message: "mysql",
+		}},
+	}}
+	ctx, err := cmdtesting.RunCommand(c, statuscmd.NewTestEventsCommand(s.api, s.clock), "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals,
+		`{"source":"cleanup","id":"c","time":"2018-04-02T10:00:00Z","type":"cleanup","status":"units","message":"mysql"}`+"\n",
+	)
+	since := eventsNow.Add(-24 * time.Hour)
+	c.Check(s.api.args[0].Since, jc.DeepEquals, &since)
+}
+
+func (s *EventsSuite) TestFollow(c *gc.C) {
+	s.api.pages = []eventsPage{{
+		cursor: "1",
+	}, {
+		cursor: "1",
+	}, {
+		events: []params.ModelEvent{{Source: "status", Id: "a", Time: eventsNow, Type: "workload", Status: "active"}},
+		cursor: "2",
+	}, {
+		err: errors.New("boom"),
+	}}
+	result := make(chan error, 1)
+	go func() {
+		_, err := cmdtesting.RunCommand(c, statuscmd.NewTestEventsCommand(s.api, s.clock), "--follow")
+		result <- err
+	}()
+	// Events are requested less often while there are none.
+	for _, wait := range []time.Duration{5 * time.Second, 10 * time.Second, 5 * time.Second} {
+		select {
+		case <-s.api.called:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for events to be requested")
+		}
+		err := s.clock.WaitAdvance(wait-time.Nanosecond, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+		select {
+		case <-s.api.called:
+			c.Fatalf("events requested after %v", wait-time.Nanosecond)
+		case <-time.After(coretesting.ShortWait):
+		}
+		s.clock.Advance(time.Nanosecond)
+	}
+	select {
+	case err := <-result:
+		c.Assert(err, gc.ErrorMatches, "boom")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for command to finish")
+	}
+	c.Assert(s.api.args, gc.HasLen, 4)
+	c.Check(s.api.args[1].Cursor, gc.Equals, "1")
+	c.Check(s.api.args[1].Since, gc.IsNil)
+	c.Check(s.api.args[3].Cursor, gc.Equals, "2")
+	c.Check(s.api.closed, jc.IsTrue)
+}
+
+type eventsPage struct {
+	events []params.ModelEvent
+	cursor string
+	err    error
+}
+
+type fakeEventsAPI struct {
+	pages  []eventsPage
+	args   []params.ModelEventsArgs
+	called chan struct{}
+	closed bool
+}
+
+func (f *fakeEventsAPI) Events(args params.ModelEventsArgs) ([]params.ModelEvent, string, error) {
+	f.args = append(f.args, args)
+	f.called <- struct{}{}
+	if len(f.pages) == 0 {
+		return nil, args.Cursor, nil
+	}
+	page := f.pages[0]
+	f.pages = f.pages[1:]
+	return page.events, page.cursor, page.err
+}
+
+func (f *fakeEventsAPI) Close() error {
+	f.closed = true
+	return nil
+}
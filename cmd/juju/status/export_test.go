@@ -3,8 +3,23 @@
 
 package status
 
-import "github.com/juju/cmd"
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
+)
 
 func NewTestStatusHistoryCommand(api HistoryAPI) cmd.Command {
 	return &statusHistoryCommand{api: api}
 }
+
+func NewTestWaitForCommand(api WaitForAPI, clock clock.Clock) cmd.Command {
+	return &waitForCommand{api: api, clock: clock}
+}
+
+func NewTestExportStatusHistoryCommand(api ExportHistoryAPI, clock clock.Clock) cmd.Command {
+	return &exportStatusHistoryCommand{api: api, clock: clock}
+}
+
+func NewTestEventsCommand(api EventsAPI, clock clock.Clock) cmd.Command {
+	return &eventsCommand{api: api, clock: clock}
+}
@@ -0,0 +1,256 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/status"
+)
+
+// NewExportStatusHistoryCommand returns a command that writes the
+// status history of many entities as a single timeline.
+func NewExportStatusHistoryCommand() cmd.Command {
+	return modelcmd.Wrap(&exportStatusHistoryCommand{clock: clock.WallClock})
+}
+
+// ExportHistoryAPI is the API surface for the export-status-history
+// command.
+type ExportHistoryAPI interface {
+	StatusHistoryTimeline(params.StatusHistoryTimelineArgs) (params.StatusHistoryTimelineResult, error)
+	Close() error
+}
+
+type exportStatusHistoryCommand struct {
+	modelcmd.ModelCommandBase
+	api   ExportHistoryAPI
+	clock clock.Clock
+	out   cmd.Output
+
+	fromArg              string
+	toArg                string
+	days                 int
+	kindsArg             string
+	includeStatusUpdates bool
+
+	entities []string
+	kinds    []string
+	from     time.Time
+	to       *time.Time
+}
+
+const exportStatusHistoryDoc = `
+Write the status history of units and machines in the model as a
+single timeline, ordered by time. If no units or machines are given,
+the history of every one in the model is written.
+
+The period covered starts at --from, or --days days ago, and ends at
+--to, or now. Dates may be given as YYYY-MM-DD or in RFC3339 format.
+If no start is given, the past day is covered.
+
+The history is written as CSV, with the columns time, entity, kind,
+status and message, or as JSON with --format json. Times are in UTC.
+
+Examples:
+
+    juju export-status-history --days 7 > history.csv
+    juju export-status-history mysql/0 mysql/1 0 --from 2018-04-01 --to 2018-04-02
+    juju export-status-history --kinds workload,juju-machine --format json
+
+See also:
+    show-status-log
+    status
+`
+
+// Info is part of the cmd.Command interface.
+func (c *exportStatusHistoryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-status-history",
+		Args:    "[<unit name>|<machine id> ...]",
+		Purpose: "Export the status history of the model as a timeline.",
+		Doc:     exportStatusHistoryDoc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *exportStatusHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.fromArg, "from", "", "Export history after this date (cannot be combined with --days)")
+	f.StringVar(&c.toArg, "to", "", "Export history up to this date")
+	f.IntVar(&c.days, "days", 0, "Export history for the past <days> days (cannot be combined with --from)")
+	f.StringVar(&c.kindsArg, "kinds", "", "Comma separated status kinds to export ["+supportedHistoryKindTypes()+"]")
+	f.BoolVar(&c.includeStatusUpdates, "include-status-updates", false, "Include update status hook messages in the exported history")
+	c.out.AddFlags(f, "csv", map[string]cmd.Formatter{
+		"csv":  FormatHistoryCSV,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *exportStatusHistoryCommand) Init(args []string) error {
+	for _, arg := range args {
+		switch {
+		case names.IsValidUnit(arg):
+			c.entities = append(c.entities, names.NewUnitTag(arg).String())
+		case names.IsValidMachine(arg):
+			c.entities = append(c.entities, names.NewMachineTag(arg).String())
+		default:
+			return errors.Errorf("%q is not a valid unit name or machine id", arg)
+		}
+	}
+	if c.fromArg != "" && c.days != 0 {
+		return errors.New("--from and --days cannot be specified together")
+	}
+	if c.days < 0 {
+		return errors.New("--days must be positive")
+	}
+	if c.fromArg != "" {
+		from, err := parseHistoryTime(c.fromArg)
+		if err != nil {
+			return errors.Annotate(err, "parsing --from")
+		}
+		c.from = from
+	}
+	if c.toArg != "" {
+		to, err := parseHistoryTime(c.toArg)
+		if err != nil {
+			return errors.Annotate(err, "parsing --to")
+		}
+		if !c.from.IsZero() && to.Before(c.from) {
+			return errors.New("--to must not be before --from")
+		}
+		c.to = &to
+	}
+	if c.kindsArg != "" {
+		for _, kind := range strings.Split(c.kindsArg, ",") {
+			kind = strings.TrimSpace(kind)
+			if !status.HistoryKind(kind).Valid() {
+				return errors.Errorf("unexpected status type %q", kind)
+			}
+			c.kinds = append(c.kinds, kind)
+		}
+	}
+	return nil
+}
+
+// parseHistoryTime parses a date, or a time in RFC3339 format.
+func parseHistoryTime(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("%q is not a date (YYYY-MM-DD) or RFC3339 time", value)
+	}
+	return t, nil
+}
+
+func (c *exportStatusHistoryCommand) getAPI() (ExportHistoryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// Run is part of the cmd.Command interface.
+func (c *exportStatusHistoryCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	from := c.from
+	if from.IsZero() {
+		days := c.days
+		if days == 0 {
+			days = 1
+		}
+		from = c.clock.Now().Add(-time.Duration(days*24) * time.Hour)
+	}
+	args := params.StatusHistoryTimelineArgs{
+		Entities: c.entities,
+		Kinds:    c.kinds,
+		From:     from,
+		To:       c.to,
+	}
+	if !c.includeStatusUpdates {
+		args.Exclude = []string{runningHookMSG}
+	}
+	// The controller returns the timeline in pages; fetch them
+	// all, continuing each from where the last one ended.
+	var entries []params.StatusHistoryEntry
+	for {
+		result, err := client.StatusHistoryTimeline(args)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		entries = append(entries, result.Entries...)
+		if result.Next == nil {
+			break
+		}
+		args.From = *result.Next
+	}
+
+	records := make([]historyRecord, len(entries))
+	for i, e := range entries {
+		entity := e.Entity
+		if tag, err := names.ParseTag(e.Entity); err == nil {
+			entity = tag.Id()
+		}
+		records[i] = historyRecord{
+			Entity:  entity,
+			Kind:    e.Kind,
+			Status:  e.Status,
+			Message: e.Info,
+			Data:    e.Data,
+		}
+		if e.Since != nil {
+			records[i].Time = e.Since.UTC()
+		}
+	}
+	return c.out.Write(ctx, records)
+}
+
+// historyRecord is a single entry of an exported status history.
+type historyRecord struct {
+	Time    time.Time              `json:"time"`
+	Entity  string                 `json:"entity"`
+	Kind    string                 `json:"kind"`
+	Status  string                 `json:"status"`
+	Message string                 `json:"message"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+// FormatHistoryCSV writes exported status history as CSV, with a header
+// row naming the columns.
+func FormatHistoryCSV(writer io.Writer, value interface{}) error {
+	records, ok := value.([]historyRecord)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", records, value)
+	}
+	w := csv.NewWriter(writer)
+	if err := w.Write([]string{"time", "entity", "kind", "status", "message"}); err != nil {
+		return errors.Trace(err)
+	}
+	for _, r := range records {
+		row := []string{r.Time.Format(time.RFC3339), r.Entity, r.Kind, r.Status, r.Message}
+		if err := w.Write(row); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	w.Flush()
+	return errors.Trace(w.Error())
+}
@@ -0,0 +1,173 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	statuscmd "github.com/juju/juju/cmd/juju/status"
+)
+
+type ExportStatusHistorySuite struct {
+	testing.IsolationSuite
+	clock *testing.Clock
+	api   *fakeExportHistoryAPI
+}
+
+var _ = gc.Suite(&ExportStatusHistorySuite{})
+
+func (s *ExportStatusHistorySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2018, time.April, 2, 12, 0, 0, 0, time.UTC))
+	first := time.Date(2018, time.April, 1, 10, 0, 0, 0, time.UTC)
+	second := first.Add(time.Minute)
+	s.api = &fakeExportHistoryAPI{
+		entries: []params.StatusHistoryEntry{{
+			Entity: "machine-0",
+			Kind:   "juju-machine",
+			Status: "started",
+			Since:  &first,
+		}, {
+			Entity: "unit-mysql-0",
+			Kind:   "workload",
+			Status: "active",
+			Info:   "ready, with \"quotes\"",
+			Since:  &second,
+		}},
+	}
+}
+
+func (s *ExportStatusHistorySuite) run(c *gc.C, args ...string) (string, error) {
+	cmd := statuscmd.NewTestExportStatusHistoryCommand(s.api, s.clock)
+	ctx, err := cmdtesting.RunCommand(c, cmd, args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx), nil
+}
+
+func (s *ExportStatusHistorySuite) TestCSV(c *gc.C) {
+	out, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, gc.Equals, ""+
+		"time,entity,kind,status,message\n"+
+		"2018-04-01T10:00:00Z,0,juju-machine,started,\n"+
+		"2018-04-01T10:01:00Z,mysql/0,workload,active,\"ready, with \"\"quotes\"\"\"\n",
+	)
+	c.Check(s.api.args, jc.DeepEquals, params.StatusHistoryTimelineArgs{
+		From:    time.Date(2018, time.April, 1, 12, 0, 0, 0, time.UTC),
+		Exclude: []string{"running update-status hook"},
+	})
+}
+
+func (s *ExportStatusHistorySuite) TestJSON(c *gc.C) {
+	out, err := s.run(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, gc.Equals, `[`+
+		`{"time":"2018-04-01T10:00:00Z","entity":"0","kind":"juju-machine","status":"started","message":""},`+
+		`{"time":"2018-04-01T10:01:00Z","entity":"mysql/0","kind":"workload","status":"active","message":"ready, with \"quotes\""}`+
+		"]\n")
+}
+
+func (s *ExportStatusHistorySuite) TestArgs(c *gc.C) {
+	_, err := s.run(c,
+		"mysql/0", "0/lxd/1",
+		"--from", "2018-03-01",
+		"--to", "2018-03-02T06:00:00Z",
+		"--kinds", "workload, juju-container",
+		"--include-status-updates",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	to := time.Date(2018, time.March, 2, 6, 0, 0, 0, time.UTC)
+	c.Check(s.api.args, jc.DeepEquals, params.StatusHistoryTimelineArgs{
+		Entities: []string{"unit-mysql-0", "machine-0-lxd-1"},
+		Kinds:    []string{"workload", "juju-container"},
+		From:     time.Date(2018, time.March, 1, 0, 0, 0, 0, time.UTC),
+		To:       &to,
+	})
+}
+
+func (s *ExportStatusHistorySuite) TestPages(c *gc.C) {
+	s.api.pageSize = 1
+	out, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, gc.Equals, ""+
+		"time,entity,kind,status,message\n"+
+		"2018-04-01T10:00:00Z,0,juju-machine,started,\n"+
+		"2018-04-01T10:01:00Z,mysql/0,workload,active,\"ready, with \"\"quotes\"\"\"\n",
+	)
+	c.Assert(s.api.calls, gc.HasLen, 2)
+	c.Check(s.api.calls[0].From, gc.Equals, time.Date(2018, time.April, 1, 12, 0, 0, 0, time.UTC))
+	c.Check(s.api.calls[1].From, gc.Equals, *s.api.entries[0].Since)
+}
+
+func (s *ExportStatusHistorySuite) TestDays(c *gc.C) {
+	_, err := s.run(c, "--days", "7")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.args.From, gc.Equals, time.Date(2018, time.March, 26, 12, 0, 0, 0, time.UTC))
+}
+
+func (s *ExportStatusHistorySuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args   []string
+		expect string
+	}{{
+		args:   []string{"mysql"},
+		expect: `"mysql" is not a valid unit name or machine id`,
+	}, {
+		args:   []string{"--from", "2018-03-01", "--days", "2"},
+		expect: "--from and --days cannot be specified together",
+	}, {
+		args:   []string{"--from", "yesterday"},
+		expect: `parsing --from: "yesterday" is not a date \(YYYY-MM-DD\) or RFC3339 time`,
+	}, {
+		args:   []string{"--from", "2018-03-02", "--to", "2018-03-01"},
+		expect: "--to must not be before --from",
+	}, {
+		args:   []string{"--kinds", "workload,charm"},
+		expect: `unexpected status type "charm"`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+type fakeExportHistoryAPI struct {
+	args    params.StatusHistoryTimelineArgs
+	calls   []params.StatusHistoryTimelineArgs
+	entries []params.StatusHistoryEntry
+	// pageSize, if positive, is the number of entries returned by
+	// each call.
+	pageSize int
+}
+
+func (*fakeExportHistoryAPI) Close() error {
+	return nil
+}
+
+func (f *fakeExportHistoryAPI) StatusHistoryTimeline(args params.StatusHistoryTimelineArgs) (params.StatusHistoryTimelineResult, error) {
+	if len(f.calls) == 0 {
+		f.args = args
+	}
+	f.calls = append(f.calls, args)
+	if f.pageSize == 0 {
+		return params.StatusHistoryTimelineResult{Entries: f.entries}, nil
+	}
+	start := (len(f.calls) - 1) * f.pageSize
+	end := start + f.pageSize
+	if end >= len(f.entries) {
+		return params.StatusHistoryTimelineResult{Entries: f.entries[start:]}, nil
+	}
+	return params.StatusHistoryTimelineResult{
+		Entries: f.entries[start:end],
+		Next:    f.entries[end-1].Since,
+	}, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -23,21 +24,26 @@ var logger = loggo.GetLogger("juju.cmd.juju.status")
 
 type statusAPI interface {
 	Status(patterns []string) (*params.FullStatus, error)
+	FilteredStatus(patterns, filters, fields []string) (*params.FullStatus, error)
 	Close() error
 }
 
 // NewStatusCommand returns a new command, which reports on the
 // runtime state of various system entities.
 func NewStatusCommand() cmd.Command {
-	return modelcmd.Wrap(&statusCommand{})
+	return modelcmd.Wrap(&statusCommand{clock: clock.WallClock})
 }
 
 type statusCommand struct {
 	modelcmd.ModelCommandBase
 	out      cmd.Output
 	patterns []string
+	filters  []string
+	fields   []string
 	isoTime  bool
+	watch    bool
 	api      statusAPI
+	clock    clock.Clock
 
 	color bool
 }
@@ -73,10 +79,39 @@ The available output formats are:
 - json: Displays information about the model, machines, applications, and units
       in structured JSON format.
 
+The --filter option selects entities by their attributes, and may be
+repeated; only entities satisfying every filter are shown, with the
+machines and applications they belong to. Each filter has the form
+<field><operator><value>, where the fields are:
+
+- status: a unit's workload or agent status, an application's status,
+      or a machine's agent or instance status (==, !=)
+- message: a unit's workload status message, or an application's or
+      machine's status message (==, !=, ~= for a regular expression)
+- zone: the availability zone of a machine, or of a unit's machine (==, !=)
+- charm-rev: the revision of an application's or unit's charm
+      (==, !=, <, <=, >, >=)
+
+The --field option limits the status to the named fields, given as
+dotted paths such as "applications.units.workload-status", and may be
+repeated. Fields that are not selected are left empty.
+
+Filters and fields are evaluated by the controller, so that large models
+need not send their full status.
+
+With --watch, the tabular status is redrawn as the model changes, over a
+single connection to the controller, and rows that changed since the
+last redraw are highlighted. Interrupt the command to stop watching.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
+    juju show-status --filter status==error
+    juju show-status --filter zone==us-east-1a --filter "message~=^hook failed"
+    juju show-status --filter "charm-rev<20" mysql
+    juju show-status --format yaml --field applications.units.workload-status
+    juju show-status --watch
 
 See also:
     machines
@@ -99,6 +134,9 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	f.Var(cmd.NewAppendStringsValue(&c.filters), "filter", "Only show entities satisfying this filter expression; may be repeated")
+	f.Var(cmd.NewAppendStringsValue(&c.fields), "field", "Only show this status field; may be repeated")
+	f.BoolVar(&c.watch, "watch", false, "Redraw the tabular status as the model changes")
 
 	defaultFormat := "tabular"
 
@@ -115,6 +153,9 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.watch && c.out.Name() != "tabular" {
+		return errors.Errorf("--watch is only supported with the tabular format")
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -134,13 +175,21 @@ var newAPIClientForStatus = func(c *statusCommand) (statusAPI, error) {
 }
 
 func (c *statusCommand) Run(ctx *cmd.Context) error {
+	if c.watch {
+		return c.runWatch(ctx)
+	}
 	apiclient, err := newAPIClientForStatus(c)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer apiclient.Close()
 
-	status, err := apiclient.Status(c.patterns)
+	var status *params.FullStatus
+	if len(c.filters) > 0 || len(c.fields) > 0 {
+		status, err = apiclient.FilteredStatus(c.patterns, c.filters, c.fields)
+	} else {
+		status, err = apiclient.Status(c.patterns)
+	}
 	if err != nil {
 		if status == nil {
 			// Status call completely failed, there is nothing to report
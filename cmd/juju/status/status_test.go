@@ -3850,14 +3850,35 @@ func (s *StatusSuite) setupMigrationTest(c *gc.C) *state.State {
 type fakeAPIClient struct {
 	statusReturn *params.FullStatus
 	patternsUsed []string
+	filtersUsed  []string
+	fieldsUsed   []string
 	closeCalled  bool
+	watcher      AllWatcher
+
+	// fetched, if set, is sent a value each time the status is
+	// fetched.
+	fetched chan struct{}
 }
 
 func (a *fakeAPIClient) Status(patterns []string) (*params.FullStatus, error) {
 	a.patternsUsed = patterns
+	if a.fetched != nil {
+		a.fetched <- struct{}{}
+	}
+	return a.statusReturn, nil
+}
+
+func (a *fakeAPIClient) FilteredStatus(patterns, filters, fields []string) (*params.FullStatus, error) {
+	a.patternsUsed = patterns
+	a.filtersUsed = filters
+	a.fieldsUsed = fields
 	return a.statusReturn, nil
 }
 
+func (a *fakeAPIClient) WatchAll() (AllWatcher, error) {
+	return a.watcher, nil
+}
+
 func (a *fakeAPIClient) Close() error {
 	a.closeCalled = true
 	return nil
@@ -4197,6 +4218,36 @@ func (s *StatusSuite) TestStatusWithNilStatusAPI(c *gc.C) {
 	c.Check(string(stderr), gc.Equals, "ERROR unable to obtain the current status\n")
 }
 
+func (s *StatusSuite) TestStatusWithFilters(c *gc.C) {
+	client := &fakeAPIClient{statusReturn: &params.FullStatus{}}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+
+	code, _, stderr := runStatus(c, "--format", "yaml",
+		"--filter", "status==error", "--filter", "message~=^hook failed", "mysql",
+	)
+	c.Check(code, gc.Equals, 0)
+	c.Check(string(stderr), gc.Equals, "")
+	c.Check(client.patternsUsed, jc.DeepEquals, []string{"mysql"})
+	c.Check(client.filtersUsed, jc.DeepEquals, []string{"status==error", "message~=^hook failed"})
+}
+
+func (s *StatusSuite) TestStatusWithFields(c *gc.C) {
+	client := &fakeAPIClient{statusReturn: &params.FullStatus{}}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+
+	code, _, stderr := runStatus(c, "--format", "yaml",
+		"--field", "machines", "--field", "applications.units.workload-status",
+	)
+	c.Check(code, gc.Equals, 0)
+	c.Check(string(stderr), gc.Equals, "")
+	c.Check(client.filtersUsed, gc.HasLen, 0)
+	c.Check(client.fieldsUsed, jc.DeepEquals, []string{"machines", "applications.units.workload-status"})
+}
+
 func (s *StatusSuite) TestFormatTabularMetering(c *gc.C) {
 	status := formattedStatus{
 		Applications: map[string]applicationStatus{
@@ -0,0 +1,407 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// NewWaitForCommand returns a command that blocks until the model
+// reaches a given state.
+func NewWaitForCommand() cmd.Command {
+	return modelcmd.Wrap(&waitForCommand{clock: clock.WallClock})
+}
+
+// WaitForAPI is the API surface for the wait-for command.
+type WaitForAPI interface {
+	WatchAll() (AllWatcher, error)
+	Close() error
+}
+
+// AllWatcher delivers changes to the entities in a model.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// allWatchClient adapts an *api.Client for the commands here that
+// use the all watcher.
+type allWatchClient struct {
+	*api.Client
+}
+
+// WatchAll is part of the WaitForAPI interface.
+func (a allWatchClient) WatchAll() (AllWatcher, error) {
+	return a.Client.WatchAll()
+}
+
+// watchDeltas calls Next on the watcher until it fails or done is
+// closed, delivering each batch of deltas on the first channel
+// returned and the error on the second.
+func watchDeltas(watcher AllWatcher, done <-chan struct{}) (<-chan []multiwatcher.Delta, <-chan error) {
+	deltas := make(chan []multiwatcher.Delta)
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			d, err := watcher.Next()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case deltas <- d:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return deltas, errs
+}
+
+type waitForCommand struct {
+	modelcmd.ModelCommandBase
+	api     WaitForAPI
+	clock   clock.Clock
+	timeout time.Duration
+
+	kind       string
+	name       string
+	conditions []waitCondition
+}
+
+// waitCondition is a single key=value term of a wait-for query.
+type waitCondition struct {
+	key   string
+	value string
+}
+
+// waitForKeys holds the condition keys understood for each kind of
+// entity.
+var waitForKeys = map[string][]string{
+	"model":       {"status"},
+	"application": {"agent", "status", "units", "workload"},
+	"unit":        {"agent", "workload"},
+	"machine":     {"instance-status", "status"},
+}
+
+const waitForDoc = `
+Wait until an entity in the model satisfies every one of the given
+conditions, then exit successfully. If the conditions are not all
+satisfied within --timeout, exit with an error listing those that
+were not.
+
+Changes are followed as they happen, rather than by polling status.
+
+The conditions that may be given for each kind of entity are:
+
+    model:
+        status=<status>          the model's status
+    application <name>:
+        status=<status>          the application's status
+        units=<count>            the number of units it has
+        workload=<status>        every unit's workload status
+        agent=<status>           every unit's agent status
+    unit <name>:
+        workload=<status>        the unit's workload status
+        agent=<status>           the unit's agent status
+    machine <id>:
+        status=<status>          the machine agent's status
+        instance-status=<status> the machine instance's status
+
+The workload and agent conditions on an application are only satisfied
+once it has at least one unit.
+
+Examples:
+
+    juju wait-for application mysql workload=active agent=idle
+    juju wait-for application mysql units=3
+    juju wait-for machine 3 status=started --timeout 5m
+    juju wait-for model status=available
+
+See also:
+    status
+    show-status-log
+`
+
+// Info is part of the cmd.Command interface.
+func (c *waitForCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "wait-for",
+		Args:    "<model|application|unit|machine> [<name>] <key>=<value> ...",
+		Purpose: "Wait for an entity in the model to reach a given state.",
+		Doc:     waitForDoc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *waitForCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.DurationVar(&c.timeout, "timeout", 10*time.Minute, "How long to wait before giving up")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *waitForCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no entity kind specified")
+	}
+	c.kind, args = args[0], args[1:]
+	keys, ok := waitForKeys[c.kind]
+	if !ok {
+		return errors.Errorf("entity kind %q not valid; expected model, application, unit or machine", c.kind)
+	}
+	if c.kind != "model" {
+		if len(args) == 0 {
+			return errors.Errorf("no %s name specified", c.kind)
+		}
+		c.name, args = args[0], args[1:]
+		var valid bool
+		switch c.kind {
+		case "application":
+			valid = names.IsValidApplication(c.name)
+		case "unit":
+			valid = names.IsValidUnit(c.name)
+		case "machine":
+			valid = names.IsValidMachine(c.name)
+		}
+		if !valid {
+			return errors.NotValidf("%s name %q", c.kind, c.name)
+		}
+	}
+	if len(args) == 0 {
+		return errors.New("no conditions specified")
+	}
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return errors.Errorf("condition %q must be of the form key=value", arg)
+		}
+		if !containsString(keys, parts[0]) {
+			return errors.Errorf("unknown %s condition %q; expected one of %s", c.kind, parts[0], strings.Join(keys, ", "))
+		}
+		if parts[0] == "units" {
+			if n, err := strconv.Atoi(parts[1]); err != nil || n < 0 {
+				return errors.Errorf("units must be a non-negative number, not %q", parts[1])
+			}
+		}
+		c.conditions = append(c.conditions, waitCondition{key: parts[0], value: parts[1]})
+	}
+	if c.timeout <= 0 {
+		return errors.New("--timeout must be positive")
+	}
+	return nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *waitForCommand) getAPI() (WaitForAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return allWatchClient{client}, nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *waitForCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	done := make(chan struct{})
+	defer watcher.Stop()
+	defer close(done)
+
+	deltas, watchErr := watchDeltas(watcher, done)
+
+	model := newWaitModel()
+	unsatisfied := c.unsatisfied(model)
+	timeout := c.clock.After(c.timeout)
+	for {
+		select {
+		case d := <-deltas:
+			model.apply(d)
+			unsatisfied = c.unsatisfied(model)
+			if len(unsatisfied) == 0 {
+				return nil
+			}
+		case err := <-watchErr:
+			return errors.Annotate(err, "watching model")
+		case <-timeout:
+			return errors.Errorf("timed out after %v waiting for %s; not satisfied:\n  %s",
+				c.timeout, c.target(), strings.Join(unsatisfied, "\n  "),
+			)
+		}
+	}
+}
+
+// target describes the entity being waited on.
+func (c *waitForCommand) target() string {
+	if c.kind == "model" {
+		return "model"
+	}
+	return c.kind + " " + c.name
+}
+
+// unsatisfied returns a description of each condition not yet
+// satisfied by the model.
+func (c *waitForCommand) unsatisfied(m *waitModel) []string {
+	var reasons []string
+	for _, cond := range c.conditions {
+		reasons = append(reasons, c.check(m, cond)...)
+	}
+	return reasons
+}
+
+func (c *waitForCommand) check(m *waitModel, cond waitCondition) []string {
+	mismatch := func(what string, got interface{}) []string {
+		if fmt.Sprint(got) == cond.value {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s is %q, not %q", what, got, cond.value)}
+	}
+	switch c.kind {
+	case "model":
+		if m.model == nil {
+			return []string{"model not yet seen"}
+		}
+		return mismatch("model status", m.model.Status.Current)
+	case "application":
+		app, ok := m.applications[c.name]
+		if !ok {
+			return []string{fmt.Sprintf("application %s not found", c.name)}
+		}
+		units := m.applicationUnits(c.name)
+		switch cond.key {
+		case "status":
+			return mismatch("application "+c.name+" status", app.Status.Current)
+		case "units":
+			return mismatch("application "+c.name+" unit count", strconv.Itoa(len(units)))
+		}
+		if len(units) == 0 {
+			return []string{fmt.Sprintf("application %s has no units", c.name)}
+		}
+		var reasons []string
+		for _, unit := range units {
+			reasons = append(reasons, checkUnit(unit, cond, mismatch)...)
+		}
+		return reasons
+	case "unit":
+		unit, ok := m.units[c.name]
+		if !ok {
+			return []string{fmt.Sprintf("unit %s not found", c.name)}
+		}
+		return checkUnit(unit, cond, mismatch)
+	case "machine":
+		machine, ok := m.machines[c.name]
+		if !ok {
+			return []string{fmt.Sprintf("machine %s not found", c.name)}
+		}
+		if cond.key == "instance-status" {
+			return mismatch("machine "+c.name+" instance status", machine.InstanceStatus.Current)
+		}
+		return mismatch("machine "+c.name+" status", machine.AgentStatus.Current)
+	}
+	return nil
+}
+
+func checkUnit(unit *multiwatcher.UnitInfo, cond waitCondition, mismatch func(string, interface{}) []string) []string {
+	if cond.key == "agent" {
+		return mismatch("unit "+unit.Name+" agent status", unit.AgentStatus.Current)
+	}
+	return mismatch("unit "+unit.Name+" workload status", unit.WorkloadStatus.Current)
+}
+
+// waitModel holds the entities seen by the all watcher that wait-for
+// conditions may refer to.
+type waitModel struct {
+	model        *multiwatcher.ModelInfo
+	applications map[string]*multiwatcher.ApplicationInfo
+	units        map[string]*multiwatcher.UnitInfo
+	machines     map[string]*multiwatcher.MachineInfo
+}
+
+func newWaitModel() *waitModel {
+	return &waitModel{
+		applications: make(map[string]*multiwatcher.ApplicationInfo),
+		units:        make(map[string]*multiwatcher.UnitInfo),
+		machines:     make(map[string]*multiwatcher.MachineInfo),
+	}
+}
+
+// apply updates the model with the given changes.
+func (m *waitModel) apply(deltas []multiwatcher.Delta) {
+	for _, d := range deltas {
+		switch entity := d.Entity.(type) {
+		case *multiwatcher.ModelInfo:
+			if d.Removed {
+				m.model = nil
+			} else {
+				m.model = entity
+			}
+		case *multiwatcher.ApplicationInfo:
+			if d.Removed {
+				delete(m.applications, entity.Name)
+			} else {
+				m.applications[entity.Name] = entity
+			}
+		case *multiwatcher.UnitInfo:
+			if d.Removed {
+				delete(m.units, entity.Name)
+			} else {
+				m.units[entity.Name] = entity
+			}
+		case *multiwatcher.MachineInfo:
+			if d.Removed {
+				delete(m.machines, entity.Id)
+			} else {
+				m.machines[entity.Id] = entity
+			}
+		}
+	}
+}
+
+// applicationUnits returns the units of the named application, sorted
+// by name.
+func (m *waitModel) applicationUnits(application string) []*multiwatcher.UnitInfo {
+	var units []*multiwatcher.UnitInfo
+	for _, unit := range m.units {
+		if unit.Application == application {
+			units = append(units, unit)
+		}
+	}
+	sort.Slice(units, func(i, j int) bool {
+		return units[i].Name < units[j].Name
+	})
+	return units
+}
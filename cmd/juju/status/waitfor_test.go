@@ -0,0 +1,221 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	statuscmd "github.com/juju/juju/cmd/juju/status"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WaitForSuite struct {
+	testing.IsolationSuite
+	clock   *testing.Clock
+	watcher *fakeAllWatcher
+}
+
+var _ = gc.Suite(&WaitForSuite{})
+
+func (s *WaitForSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2018, time.March, 1, 10, 0, 0, 0, time.UTC))
+	s.watcher = newFakeAllWatcher()
+}
+
+func (s *WaitForSuite) run(c *gc.C, args ...string) error {
+	cmd := statuscmd.NewTestWaitForCommand(&fakeWaitForAPI{watcher: s.watcher}, s.clock)
+	_, err := cmdtesting.RunCommand(c, cmd, args...)
+	return err
+}
+
+func unitInfo(name, workload, agent string) *multiwatcher.UnitInfo {
+	return &multiwatcher.UnitInfo{
+		Name:           name,
+		Application:    "mysql",
+		WorkloadStatus: multiwatcher.StatusInfo{Current: status.Status(workload)},
+		AgentStatus:    multiwatcher.StatusInfo{Current: status.Status(agent)},
+	}
+}
+
+func (s *WaitForSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args   []string
+		expect string
+	}{{
+		expect: "no entity kind specified",
+	}, {
+		args:   []string{"relation", "foo"},
+		expect: `entity kind "relation" not valid; expected model, application, unit or machine`,
+	}, {
+		args:   []string{"application"},
+		expect: "no application name specified",
+	}, {
+		args:   []string{"unit", "mysql"},
+		expect: `unit name "mysql" not valid`,
+	}, {
+		args:   []string{"machine", "3"},
+		expect: "no conditions specified",
+	}, {
+		args:   []string{"machine", "3", "status"},
+		expect: `condition "status" must be of the form key=value`,
+	}, {
+		args:   []string{"unit", "mysql/0", "units=3"},
+		expect: `unknown unit condition "units"; expected one of agent, workload`,
+	}, {
+		args:   []string{"application", "mysql", "units=many"},
+		expect: `units must be a non-negative number, not "many"`,
+	}, {
+		args:   []string{"model", "status=available", "--timeout", "0s"},
+		expect: "--timeout must be positive",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		cmd := statuscmd.NewTestWaitForCommand(nil, s.clock)
+		err := cmdtesting.InitCommand(cmd, test.args)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *WaitForSuite) TestApplicationSatisfied(c *gc.C) {
+	s.watcher.send(
+		&multiwatcher.ApplicationInfo{Name: "mysql"},
+		unitInfo("mysql/0", "active", "idle"),
+		unitInfo("mysql/1", "maintenance", "executing"),
+	)
+	s.watcher.send(unitInfo("mysql/1", "active", "idle"))
+	err := s.run(c, "application", "mysql", "workload=active", "agent=idle", "units=2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.watcher.stopped(), jc.IsTrue)
+}
+
+func (s *WaitForSuite) TestMachineSatisfied(c *gc.C) {
+	s.watcher.send(&multiwatcher.MachineInfo{
+		Id:             "3",
+		AgentStatus:    multiwatcher.StatusInfo{Current: status.Pending},
+		InstanceStatus: multiwatcher.StatusInfo{Current: status.Provisioning},
+	})
+	s.watcher.send(&multiwatcher.MachineInfo{
+		Id:             "3",
+		AgentStatus:    multiwatcher.StatusInfo{Current: status.Started},
+		InstanceStatus: multiwatcher.StatusInfo{Current: status.Running},
+	})
+	err := s.run(c, "machine", "3", "status=started", "instance-status=running")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WaitForSuite) TestTimeout(c *gc.C) {
+	s.watcher.send(
+		&multiwatcher.ApplicationInfo{Name: "mysql", Status: multiwatcher.StatusInfo{Current: status.Waiting}},
+		unitInfo("mysql/0", "active", "idle"),
+		unitInfo("mysql/1", "maintenance", "executing"),
+	)
+	s.watcher.send(multiwatcher.Delta{Removed: true, Entity: unitInfo("mysql/0", "active", "idle")})
+
+	result := make(chan error, 1)
+	go func() {
+		result <- s.run(c, "application", "mysql", "status=active", "workload=active", "--timeout", "5m")
+	}()
+	select {
+	case <-s.watcher.drained:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for deltas to be read")
+	}
+	err := s.clock.WaitAdvance(5*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case err := <-result:
+		c.Assert(err, gc.ErrorMatches, `timed out after 5m0s waiting for application mysql; not satisfied:
+  application mysql status is "waiting", not "active"
+  unit mysql/1 workload status is "maintenance", not "active"`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for command to finish")
+	}
+	c.Assert(s.watcher.stopped(), jc.IsTrue)
+}
+
+func (s *WaitForSuite) TestWatcherError(c *gc.C) {
+	s.watcher.err = errors.New("boom")
+	err := s.run(c, "unit", "mysql/0", "workload=active")
+	c.Assert(err, gc.ErrorMatches, "watching model: boom")
+}
+
+type fakeWaitForAPI struct {
+	watcher *fakeAllWatcher
+}
+
+func (f *fakeWaitForAPI) WatchAll() (statuscmd.AllWatcher, error) {
+	return f.watcher, nil
+}
+
+func (*fakeWaitForAPI) Close() error {
+	return nil
+}
+
+// fakeAllWatcher returns the batches of deltas given to send, then
+// blocks until it is stopped.
+type fakeAllWatcher struct {
+	batches [][]multiwatcher.Delta
+	err     error
+	drained chan struct{}
+	stop    chan struct{}
+}
+
+func newFakeAllWatcher() *fakeAllWatcher {
+	return &fakeAllWatcher{
+		drained: make(chan struct{}),
+		stop:    make(chan struct{}),
+	}
+}
+
+// send queues a batch of deltas. Each item is either a Delta or an
+// EntityInfo to be reported as changed.
+func (w *fakeAllWatcher) send(items ...interface{}) {
+	var batch []multiwatcher.Delta
+	for _, item := range items {
+		switch item := item.(type) {
+		case multiwatcher.Delta:
+			batch = append(batch, item)
+		case multiwatcher.EntityInfo:
+			batch = append(batch, multiwatcher.Delta{Entity: item})
+		}
+	}
+	w.batches = append(w.batches, batch)
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+	if len(w.batches) > 0 {
+		batch := w.batches[0]
+		w.batches = w.batches[1:]
+		return batch, nil
+	}
+	close(w.drained)
+	<-w.stop
+	return nil, errors.New("watcher stopped")
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	close(w.stop)
+	return nil
+}
+
+func (w *fakeAllWatcher) stopped() bool {
+	select {
+	case <-w.stop:
+		return true
+	default:
+		return false
+	}
+}
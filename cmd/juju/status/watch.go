@@ -0,0 +1,305 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/mattn/go-isatty"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+// statusWatchAPI is the API surface for status --watch.
+type statusWatchAPI interface {
+	statusAPI
+	WatchAll() (AllWatcher, error)
+}
+
+var newAPIClientForStatusWatch = func(c *statusCommand) (statusWatchAPI, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return allWatchClient{client}, nil
+}
+
+// watchRefetchDelay is how long status --watch waits after a change
+// that needs the status fetched again, so that a burst of such changes
+// is shown with a single fetch.
+const watchRefetchDelay = time.Second
+
+const (
+	// clearScreen moves the cursor to the top left of the terminal
+	// and clears it.
+	clearScreen = "\x1b[H\x1b[2J"
+
+	// highlightStart and highlightEnd surround a changed row, showing
+	// it in reverse video.
+	highlightStart = "\x1b[7m"
+	highlightEnd   = "\x1b[0m"
+)
+
+// runWatch shows the tabular status, redrawing it whenever the all
+// watcher reports a change. The status is fetched once, and kept up to
+// date by applying the watcher's deltas to it; it is only fetched again
+// when a delta cannot be applied locally, such as for a new unit or
+// relation, or when patterns, filters or fields are in use and so any
+// change may alter what is shown. Such changes are coalesced, so that
+// the status is fetched at most once every watchRefetchDelay.
+func (c *statusCommand) runWatch(ctx *cmd.Context) error {
+	client, err := newAPIClientForStatusWatch(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	done := make(chan struct{})
+	defer watcher.Stop()
+	defer close(done)
+	deltas, watchErr := watchDeltas(watcher, done)
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	fetch := func() (*params.FullStatus, error) {
+		if len(c.filters) > 0 || len(c.fields) > 0 {
+			return client.FilteredStatus(c.patterns, c.filters, c.fields)
+		}
+		return client.Status(c.patterns)
+	}
+	fullStatus, err := fetch()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	color := c.color || isTerminal(ctx.Stdout)
+	var previous []string
+	// refetch is set while waiting to fetch the status again; the
+	// changes that arrive meanwhile are picked up by that fetch.
+	var refetch <-chan time.Time
+	redraw := true
+	for {
+		if redraw {
+			formatted, err := newStatusFormatter(fullStatus, controllerName, c.isoTime).format()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if previous, err = redrawStatus(ctx.Stdout, formatted, color, previous); err != nil {
+				return errors.Trace(err)
+			}
+		}
+
+		select {
+		case d := <-deltas:
+			redraw = false
+			if refetch != nil {
+				continue
+			}
+			local := len(c.patterns) == 0 && len(c.filters) == 0 && len(c.fields) == 0
+			if local && applyStatusDeltas(fullStatus, d) {
+				redraw = true
+				continue
+			}
+			refetch = c.clock.After(watchRefetchDelay)
+		case <-refetch:
+			refetch = nil
+			if fullStatus, err = fetch(); err != nil {
+				return errors.Trace(err)
+			}
+			redraw = true
+		case err := <-watchErr:
+			return errors.Annotate(err, "watching model")
+		case <-interrupted:
+			return nil
+		}
+	}
+}
+
+func isTerminal(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd())
+}
+
+// redrawStatus clears the terminal and writes the tabular status,
+// highlighting rows that do not appear in previous, the rows last
+// written. It returns the rows written.
+func redrawStatus(w io.Writer, fs formattedStatus, color bool, previous []string) ([]string, error) {
+	var buf bytes.Buffer
+	if err := FormatTabular(&buf, color, fs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	rows := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	seen := make(map[string]int)
+	for _, row := range previous {
+		seen[row]++
+	}
+	var out bytes.Buffer
+	out.WriteString(clearScreen)
+	for _, row := range rows {
+		if previous == nil || seen[row] > 0 || strings.TrimSpace(row) == "" {
+			seen[row]--
+			fmt.Fprintln(&out, row)
+			continue
+		}
+		// Colored cells end with a reset, which would also end the
+		// highlight, so it is restored after each one.
+		row = strings.Replace(row, highlightEnd, highlightEnd+highlightStart, -1)
+		fmt.Fprintln(&out, highlightStart+row+highlightEnd)
+	}
+	_, err := w.Write(out.Bytes())
+	return rows, errors.Trace(err)
+}
+
+// applyStatusDeltas updates the status with the changes reported by
+// the all watcher. It reports whether every change could be applied;
+// if not, the status must be fetched again.
+func applyStatusDeltas(fs *params.FullStatus, deltas []multiwatcher.Delta) bool {
+	complete := true
+	for _, d := range deltas {
+		switch entity := d.Entity.(type) {
+		case *multiwatcher.ModelInfo:
+			if !d.Removed {
+				fs.Model.ModelStatus = applyStatusInfo(fs.Model.ModelStatus, entity.Status)
+			}
+		case *multiwatcher.ApplicationInfo:
+			complete = applyApplicationDelta(fs, d.Removed, entity) && complete
+		case *multiwatcher.UnitInfo:
+			complete = applyUnitDelta(fs, d.Removed, entity) && complete
+		case *multiwatcher.MachineInfo:
+			complete = applyMachineDelta(fs.Machines, d.Removed, entity) && complete
+		case *multiwatcher.RelationInfo,
+			*multiwatcher.RemoteApplicationInfo,
+			*multiwatcher.ApplicationOfferInfo:
+			complete = false
+		}
+	}
+	return complete
+}
+
+func applyApplicationDelta(fs *params.FullStatus, removed bool, info *multiwatcher.ApplicationInfo) bool {
+	app, ok := fs.Applications[info.Name]
+	if removed {
+		delete(fs.Applications, info.Name)
+		return true
+	}
+	if !ok {
+		return false
+	}
+	// An application without a status of its own has one derived
+	// from its units' by the controller, which the watcher does not
+	// report.
+	if info.Status.Current != status.Unset && info.Status.Current != "" {
+		app.Status = applyStatusInfo(app.Status, info.Status)
+	}
+	app.Charm = info.CharmURL
+	app.Exposed = info.Exposed
+	app.Life = string(info.Life)
+	app.WorkloadVersion = info.WorkloadVersion
+	fs.Applications[info.Name] = app
+	return true
+}
+
+func applyUnitDelta(fs *params.FullStatus, removed bool, info *multiwatcher.UnitInfo) bool {
+	units := findUnits(fs, info.Name)
+	if units == nil {
+		// A unit that is already gone needs nothing done; a new one
+		// needs the status to be fetched again.
+		return removed
+	}
+	if removed {
+		delete(units, info.Name)
+		return true
+	}
+	unit := units[info.Name]
+	unit.WorkloadStatus = applyStatusInfo(unit.WorkloadStatus, info.WorkloadStatus)
+	unit.AgentStatus = applyStatusInfo(unit.AgentStatus, info.AgentStatus)
+	if info.MachineId != "" {
+		unit.Machine = info.MachineId
+	}
+	unit.PublicAddress = info.PublicAddress
+	units[info.Name] = unit
+	return true
+}
+
+// findUnits returns the map holding the named unit, which is either
+// its application's units or its principal's subordinates, or nil if
+// the unit is not in the status.
+func findUnits(fs *params.FullStatus, name string) map[string]params.UnitStatus {
+	for _, app := range fs.Applications {
+		if _, ok := app.Units[name]; ok {
+			return app.Units
+		}
+		for _, unit := range app.Units {
+			if _, ok := unit.Subordinates[name]; ok {
+				return unit.Subordinates
+			}
+		}
+	}
+	return nil
+}
+
+func applyMachineDelta(machines map[string]params.MachineStatus, removed bool, info *multiwatcher.MachineInfo) bool {
+	machines = findMachines(machines, info.Id)
+	if machines == nil {
+		return removed
+	}
+	if removed {
+		delete(machines, info.Id)
+		return true
+	}
+	m := machines[info.Id]
+	m.AgentStatus = applyStatusInfo(m.AgentStatus, info.AgentStatus)
+	m.InstanceStatus = applyStatusInfo(m.InstanceStatus, info.InstanceStatus)
+	m.InstanceId = instance.Id(info.InstanceId)
+	m.Series = info.Series
+	machines[info.Id] = m
+	return true
+}
+
+// findMachines returns the map holding the machine or container with
+// the given id, or nil if it is not in the status.
+func findMachines(machines map[string]params.MachineStatus, id string) map[string]params.MachineStatus {
+	if _, ok := machines[id]; ok {
+		return machines
+	}
+	for _, m := range machines {
+		if found := findMachines(m.Containers, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func applyStatusInfo(s params.DetailedStatus, info multiwatcher.StatusInfo) params.DetailedStatus {
+	s.Status = string(info.Current)
+	s.Info = info.Message
+	s.Data = info.Data
+	s.Since = info.Since
+	if info.Version != "" {
+		s.Version = info.Version
+	}
+	return s
+}
@@ -0,0 +1,278 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WatchSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&WatchSuite{})
+
+func watchTestStatus() *params.FullStatus {
+	return &params.FullStatus{
+		Model: params.ModelStatusInfo{Name: "default"},
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id:          "0",
+				AgentStatus: params.DetailedStatus{Status: "started"},
+				Containers: map[string]params.MachineStatus{
+					"0/lxd/0": {Id: "0/lxd/0", AgentStatus: params.DetailedStatus{Status: "pending"}},
+				},
+			},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm:  "cs:xenial/mysql-5",
+				Series: "xenial",
+				Status: params.DetailedStatus{Status: "waiting", Info: "installing"},
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {
+						Machine:        "0",
+						WorkloadStatus: params.DetailedStatus{Status: "waiting", Info: "installing"},
+						AgentStatus:    params.DetailedStatus{Status: "idle"},
+						Subordinates: map[string]params.UnitStatus{
+							"logging/0": {WorkloadStatus: params.DetailedStatus{Status: "waiting"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *WatchSuite) TestApplyStatusDeltas(c *gc.C) {
+	fs := watchTestStatus()
+	complete := applyStatusDeltas(fs, []multiwatcher.Delta{{
+		Entity: &multiwatcher.UnitInfo{
+			Name:           "mysql/0",
+			Application:    "mysql",
+			MachineId:      "0",
+			WorkloadStatus: multiwatcher.StatusInfo{Current: status.Active, Message: "ready"},
+			AgentStatus:    multiwatcher.StatusInfo{Current: status.Idle},
+		},
+	}, {
+		Entity: &multiwatcher.UnitInfo{
+			Name:           "logging/0",
+			Application:    "logging",
+			Subordinate:    true,
+			WorkloadStatus: multiwatcher.StatusInfo{Current: status.Active},
+		},
+	}, {
+		Entity: &multiwatcher.ApplicationInfo{
+			Name:     "mysql",
+			CharmURL: "cs:xenial/mysql-6",
+			Status:   multiwatcher.StatusInfo{Current: status.Unset},
+		},
+	}, {
+		Entity: &multiwatcher.MachineInfo{
+			Id:          "0/lxd/0",
+			InstanceId:  "juju-0-lxd-0",
+			AgentStatus: multiwatcher.StatusInfo{Current: status.Started},
+		},
+	}, {
+		Removed: true,
+		Entity:  &multiwatcher.MachineInfo{Id: "7"},
+	}})
+	c.Assert(complete, jc.IsTrue)
+
+	mysql := fs.Applications["mysql"]
+	c.Check(mysql.Charm, gc.Equals, "cs:xenial/mysql-6")
+	c.Check(mysql.Status.Status, gc.Equals, "waiting")
+	unit := mysql.Units["mysql/0"]
+	c.Check(unit.WorkloadStatus.Status, gc.Equals, "active")
+	c.Check(unit.WorkloadStatus.Info, gc.Equals, "ready")
+	c.Check(unit.AgentStatus.Status, gc.Equals, "idle")
+	c.Check(unit.Subordinates["logging/0"].WorkloadStatus.Status, gc.Equals, "active")
+	container := fs.Machines["0"].Containers["0/lxd/0"]
+	c.Check(container.AgentStatus.Status, gc.Equals, "started")
+	c.Check(string(container.InstanceId), gc.Equals, "juju-0-lxd-0")
+}
+
+func (s *WatchSuite) TestApplyStatusDeltasIncomplete(c *gc.C) {
+	for i, entity := range []multiwatcher.EntityInfo{
+		&multiwatcher.UnitInfo{Name: "mysql/1", Application: "mysql"},
+		&multiwatcher.MachineInfo{Id: "1"},
+		&multiwatcher.ApplicationInfo{Name: "wordpress"},
+		&multiwatcher.RelationInfo{Key: "wordpress:db mysql:server"},
+	} {
+		c.Logf("test %d: %#v", i, entity)
+		complete := applyStatusDeltas(watchTestStatus(), []multiwatcher.Delta{{Entity: entity}})
+		c.Check(complete, jc.IsFalse)
+	}
+}
+
+func (s *WatchSuite) TestApplyStatusDeltasRemoved(c *gc.C) {
+	fs := watchTestStatus()
+	complete := applyStatusDeltas(fs, []multiwatcher.Delta{{
+		Removed: true,
+		Entity:  &multiwatcher.UnitInfo{Name: "logging/0"},
+	}, {
+		Removed: true,
+		Entity:  &multiwatcher.MachineInfo{Id: "0/lxd/0"},
+	}})
+	c.Assert(complete, jc.IsTrue)
+	c.Check(fs.Applications["mysql"].Units["mysql/0"].Subordinates, gc.HasLen, 0)
+	c.Check(fs.Machines["0"].Containers, gc.HasLen, 0)
+}
+
+func (s *WatchSuite) TestRedrawStatusHighlightsChanges(c *gc.C) {
+	fs := watchTestStatus()
+	var out bytes.Buffer
+	formatted, err := newStatusFormatter(fs, "ctrl", false).format()
+	c.Assert(err, jc.ErrorIsNil)
+	previous, err := redrawStatus(&out, formatted, false, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(strings.HasPrefix(out.String(), clearScreen), jc.IsTrue)
+	c.Check(out.String(), gc.Not(jc.Contains), highlightStart)
+
+	applyStatusDeltas(fs, []multiwatcher.Delta{{
+		Entity: &multiwatcher.UnitInfo{
+			Name:           "mysql/0",
+			Application:    "mysql",
+			MachineId:      "0",
+			WorkloadStatus: multiwatcher.StatusInfo{Current: status.Active, Message: "ready"},
+			AgentStatus:    multiwatcher.StatusInfo{Current: status.Idle},
+		},
+	}})
+	out.Reset()
+	formatted, err = newStatusFormatter(fs, "ctrl", false).format()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = redrawStatus(&out, formatted, false, previous)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var highlighted []string
+	for _, row := range strings.Split(out.String(), "\n") {
+		if strings.HasPrefix(row, highlightStart) {
+			highlighted = append(highlighted, row)
+		}
+	}
+	c.Assert(highlighted, gc.HasLen, 1)
+	c.Check(highlighted[0], gc.Matches, `\x1b\[7mmysql/0 +active +idle +0 .*ready\x1b\[0m`)
+}
+
+func (s *StatusSuite) TestStatusWatch(c *gc.C) {
+	watcher := &fakeStatusWatcher{
+		batches: [][]multiwatcher.Delta{{{
+			Entity: &multiwatcher.UnitInfo{
+				Name:           "mysql/0",
+				Application:    "mysql",
+				WorkloadStatus: multiwatcher.StatusInfo{Current: status.Active, Message: "ready"},
+				AgentStatus:    multiwatcher.StatusInfo{Current: status.Idle},
+			},
+		}}},
+		err: errors.New("boom"),
+	}
+	client := &fakeAPIClient{statusReturn: watchTestStatus(), watcher: watcher}
+	s.PatchValue(&newAPIClientForStatusWatch, func(_ *statusCommand) (statusWatchAPI, error) {
+		return client, nil
+	})
+
+	code, stdout, stderr := runStatus(c, "--watch")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Equals, "ERROR watching model: boom\n")
+	frames := strings.Split(string(stdout), clearScreen)
+	c.Assert(frames, gc.HasLen, 3)
+	c.Check(frames[1], gc.Not(jc.Contains), highlightStart)
+	c.Check(frames[2], jc.Contains, highlightStart+"mysql/0")
+	c.Check(watcher.stopped, jc.IsTrue)
+	c.Check(client.closeCalled, jc.IsTrue)
+}
+
+func (s *StatusSuite) TestStatusWatchCoalescesRefetches(c *gc.C) {
+	// With a pattern, every change needs the status fetched again.
+	delta := []multiwatcher.Delta{{
+		Entity: &multiwatcher.UnitInfo{Name: "mysql/0", Application: "mysql"},
+	}}
+	watcher := &fakeStatusWatcher{
+		batches: [][]multiwatcher.Delta{delta, delta, delta},
+		drained: make(chan struct{}),
+		release: make(chan struct{}),
+		err:     errors.New("boom"),
+	}
+	client := &fakeAPIClient{
+		statusReturn: watchTestStatus(),
+		watcher:      watcher,
+		fetched:      make(chan struct{}, 10),
+	}
+	s.PatchValue(&newAPIClientForStatusWatch, func(_ *statusCommand) (statusWatchAPI, error) {
+		return client, nil
+	})
+	clk := jujutesting.NewClock(time.Time{})
+	ctx := cmdtesting.Context(c)
+	done := make(chan int)
+	go func() {
+		done <- cmd.Main(modelcmd.Wrap(&statusCommand{clock: clk}), ctx, []string{"--watch", "mysql"})
+	}()
+
+	<-client.fetched
+	<-watcher.drained
+	err := clk.WaitAdvance(watchRefetchDelay, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-client.fetched:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("status not fetched again")
+	}
+	close(watcher.release)
+	c.Assert(<-done, gc.Equals, 1)
+
+	c.Check(client.fetched, gc.HasLen, 0)
+	frames := strings.Split(ctx.Stdout.(*bytes.Buffer).String(), clearScreen)
+	c.Check(frames, gc.HasLen, 3)
+}
+
+func (s *StatusSuite) TestStatusWatchRequiresTabular(c *gc.C) {
+	code, _, stderr := runStatus(c, "--watch", "--format", "yaml")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, "ERROR --watch is only supported with the tabular format\n")
+}
+
+// fakeStatusWatcher returns its batches of deltas, then its error.
+// If drained and release are set, it closes drained once its batches
+// have all been received, and waits for release to be closed before
+// returning its error.
+type fakeStatusWatcher struct {
+	batches [][]multiwatcher.Delta
+	err     error
+	stopped bool
+	drained chan struct{}
+	release chan struct{}
+}
+
+func (w *fakeStatusWatcher) Next() ([]multiwatcher.Delta, error) {
+	if len(w.batches) == 0 {
+		if w.drained != nil {
+			close(w.drained)
+			<-w.release
+		}
+		return nil, w.err
+	}
+	batch := w.batches[0]
+	w.batches = w.batches[1:]
+	return batch, nil
+}
+
+func (w *fakeStatusWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/apitokens"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/permission"
+)
+
+var usageAddTokenSummary = `
+Issues an API token for automated access to models.`[1:]
+
+var usageAddTokenDetails = `
+An API token allows a script or CI system to act as the current user
+without knowing their password. The token is limited to the specified
+models, to at most the specified access level, and optionally to a set
+of API facades. It stops working once it expires or is revoked.
+
+The encoded token is written to stdout and can not be retrieved again.
+To use it, record it as the "api-token" of the controller's account in
+accounts.yaml in place of a password.
+
+Examples:
+    juju add-token mymodel
+    juju add-token --access write --expires-in 168h mymodel othermodel
+    juju add-token --facades Client,Application --description "CI" mymodel
+
+See also:
+    tokens
+    revoke-token`[1:]
+
+// AddTokenAPI defines the API methods that the add-token command uses.
+type AddTokenAPI interface {
+	AddAPIToken(apitokens.AddAPITokenArgs) (params.APIToken, string, error)
+	Close() error
+}
+
+// NewAddTokenCommand returns a command that issues API tokens.
+func NewAddTokenCommand() cmd.Command {
+	return modelcmd.WrapController(&addTokenCommand{clock: clock.WallClock})
+}
+
+// addTokenCommand issues an API token for the current user.
+type addTokenCommand struct {
+	modelcmd.ControllerCommandBase
+	api   AddTokenAPI
+	clock clock.Clock
+
+	ModelNames  []string
+	Access      string
+	Facades     []string
+	ExpiresIn   time.Duration
+	Description string
+}
+
+// Info implements Command.Info.
+func (c *addTokenCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-token",
+		Args:    "<model name> ...",
+		Purpose: usageAddTokenSummary,
+		Doc:     usageAddTokenDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addTokenCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.Access, "access", string(permission.ReadAccess), "Maximum model access granted by the token (read, write or admin)")
+	f.Var(facadesValue{&c.Facades}, "facades", "Comma-separated list of API facades the token may be used with")
+	f.DurationVar(&c.ExpiresIn, "expires-in", 24*time.Hour, "How long the token remains valid")
+	f.StringVar(&c.Description, "description", "", "Description of the token's purpose")
+}
+
+// Init implements Command.Init.
+func (c *addTokenCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no model name specified")
+	}
+	for _, arg := range args {
+		modelName := arg
+		if jujuclient.IsQualifiedModelName(modelName) {
+			var err error
+			modelName, _, err = jujuclient.SplitModelName(modelName)
+			if err != nil {
+				return errors.Annotatef(err, "validating model name %q", arg)
+			}
+		}
+		if !names.IsValidModelName(modelName) {
+			return errors.NotValidf("model name %q", arg)
+		}
+	}
+	c.ModelNames = args
+	if err := permission.ValidateModelAccess(permission.Access(c.Access)); err != nil {
+		return errors.Trace(err)
+	}
+	if c.ExpiresIn <= 0 {
+		return errors.NotValidf("expiry duration %v", c.ExpiresIn)
+	}
+	return nil
+}
+
+func (c *addTokenCommand) getAPI() (AddTokenAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apitokens.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *addTokenCommand) Run(ctx *cmd.Context) error {
+	uuids, err := c.ModelUUIDs(c.ModelNames)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	models := make([]names.ModelTag, len(uuids))
+	for i, uuid := range uuids {
+		models[i] = names.NewModelTag(uuid)
+	}
+
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	token, encoded, err := api.AddAPIToken(apitokens.AddAPITokenArgs{
+		Description: c.Description,
+		Models:      models,
+		Access:      permission.Access(c.Access),
+		Facades:     c.Facades,
+		Expires:     c.clock.Now().Add(c.ExpiresIn),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("API token %q expires at %s", token.Id, token.Expires.Local().Format(time.RFC3339))
+	_, err = ctx.Stdout.Write([]byte(encoded + "\n"))
+	return errors.Trace(err)
+}
+
+// facadesValue implements gnuflag.Value for a comma-separated
+// list of facade names.
+type facadesValue struct {
+	facades *[]string
+}
+
+// Set implements gnuflag.Value.
+func (v facadesValue) Set(s string) error {
+	*v.facades = nil
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		*v.facades = append(*v.facades, name)
+	}
+	return nil
+}
+
+// String implements gnuflag.Value.
+func (v facadesValue) String() string {
+	if v.facades == nil {
+		return ""
+	}
+	return strings.Join(*v.facades, ",")
+}
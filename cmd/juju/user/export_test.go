@@ -124,3 +124,27 @@ func NewWhoAmICommandForTest(store jujuclient.ClientStore) cmd.Command {
 	c := &whoAmICommand{store: store}
 	return c
 }
+
+// NewAddTokenCommandForTest returns an add-token command with the api,
+// store and clock provided as specified.
+func NewAddTokenCommandForTest(api AddTokenAPI, store jujuclient.ClientStore, clock clock.Clock) cmd.Command {
+	c := &addTokenCommand{api: api, clock: clock}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewListTokensCommandForTest returns a tokens command with the api,
+// store and clock provided as specified.
+func NewListTokensCommandForTest(api ListTokensAPI, store jujuclient.ClientStore, clock clock.Clock) cmd.Command {
+	c := &listTokensCommand{api: api, clock: clock}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRevokeTokenCommandForTest returns a revoke-token command with the
+// api and store provided as specified.
+func NewRevokeTokenCommandForTest(api RevokeTokenAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &revokeTokenCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
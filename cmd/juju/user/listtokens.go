@@ -0,0 +1,202 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/apitokens"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var usageListTokensSummary = `
+Lists the API tokens issued to a user.`[1:]
+
+var usageListTokensDetails = `
+Without a user name, the tokens issued to the current user are listed.
+Only controller superusers may list the tokens of other users.
+
+Examples:
+    juju tokens
+    juju tokens bob --format yaml
+
+See also:
+    add-token
+    revoke-token`[1:]
+
+// ListTokensAPI defines the API methods that the tokens command uses.
+type ListTokensAPI interface {
+	ListAPITokens(owner names.UserTag) ([]params.APIToken, error)
+	Close() error
+}
+
+// NewListTokensCommand returns a command that lists API tokens.
+func NewListTokensCommand() cmd.Command {
+	return modelcmd.WrapController(&listTokensCommand{clock: clock.WallClock})
+}
+
+// listTokensCommand lists the API tokens issued to a user.
+type listTokensCommand struct {
+	modelcmd.ControllerCommandBase
+	out   cmd.Output
+	api   ListTokensAPI
+	clock clock.Clock
+
+	User string
+}
+
+// TokenInfo holds the details of an API token for output.
+type TokenInfo struct {
+	Id          string   `yaml:"id" json:"id"`
+	Owner       string   `yaml:"owner" json:"owner"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Models      []string `yaml:"models" json:"models"`
+	Access      string   `yaml:"access" json:"access"`
+	Facades     []string `yaml:"facades,omitempty" json:"facades,omitempty"`
+	Created     string   `yaml:"created" json:"created"`
+	Expires     string   `yaml:"expires" json:"expires"`
+	Status      string   `yaml:"status" json:"status"`
+}
+
+// Info implements Command.Info.
+func (c *listTokensCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "tokens",
+		Args:    "[<user name>]",
+		Purpose: usageListTokensSummary,
+		Doc:     usageListTokensDetails,
+		Aliases: []string{"list-tokens"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listTokensCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatTokensTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listTokensCommand) Init(args []string) (err error) {
+	c.User, err = cmd.ZeroOrOneArgs(args)
+	if err != nil {
+		return err
+	}
+	if c.User != "" && !names.IsValidUser(c.User) {
+		return errors.NotValidf("user name %q", c.User)
+	}
+	return nil
+}
+
+func (c *listTokensCommand) getAPI() (ListTokensAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apitokens.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *listTokensCommand) Run(ctx *cmd.Context) error {
+	user := c.User
+	if user == "" {
+		accountDetails, err := c.CurrentAccountDetails()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		user = accountDetails.User
+	}
+
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	tokens, err := api.ListAPITokens(names.NewUserTag(user))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(tokens) == 0 {
+		ctx.Infof("No API tokens to display.")
+		return nil
+	}
+	now := c.clock.Now()
+	infos := make([]TokenInfo, len(tokens))
+	for i, token := range tokens {
+		infos[i] = tokenInfoFromParams(token, now)
+	}
+	return c.out.Write(ctx, infos)
+}
+
+func tokenInfoFromParams(token params.APIToken, now time.Time) TokenInfo {
+	info := TokenInfo{
+		Id:          token.Id,
+		Owner:       token.Owner,
+		Description: token.Description,
+		Access:      token.Access,
+		Facades:     token.Facades,
+		Created:     token.Created.UTC().Format(time.RFC3339),
+		Expires:     token.Expires.UTC().Format(time.RFC3339),
+		Status:      "active",
+	}
+	if owner, err := names.ParseUserTag(token.Owner); err == nil {
+		info.Owner = owner.Id()
+	}
+	for _, tagString := range token.ModelTags {
+		if tag, err := names.ParseModelTag(tagString); err == nil {
+			info.Models = append(info.Models, tag.Id())
+		}
+	}
+	switch {
+	case token.Revoked:
+		info.Status = "revoked"
+	case !now.Before(token.Expires):
+		info.Status = "expired"
+	}
+	return info
+}
+
+func formatTokensTabular(writer io.Writer, value interface{}) error {
+	tokens, ok := value.([]TokenInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", tokens, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Id", "Description", "Access", "Models", "Facades", "Expires", "Status")
+	for _, token := range tokens {
+		facades := strings.Join(token.Facades, ",")
+		if facades == "" {
+			facades = "all"
+		}
+		w.Println(
+			token.Id,
+			token.Description,
+			token.Access,
+			strings.Join(token.Models, ","),
+			facades,
+			token.Expires,
+			token.Status,
+		)
+	}
+	tw.Flush()
+	return nil
+}
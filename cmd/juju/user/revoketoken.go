@@ -0,0 +1,87 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/apitokens"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageRevokeTokenSummary = `
+Revokes API tokens.`[1:]
+
+var usageRevokeTokenDetails = `
+Revoked tokens can no longer be used to log in. Connections already
+made with a token are not closed. Users may revoke their own tokens;
+controller superusers may revoke any token.
+
+Examples:
+    juju revoke-token 9b2a7e4c-0b5f-4ac3-8d30-d3f4ea8c2f65
+
+See also:
+    add-token
+    tokens`[1:]
+
+// RevokeTokenAPI defines the API methods that the revoke-token
+// command uses.
+type RevokeTokenAPI interface {
+	RevokeAPITokens(ids ...string) error
+	Close() error
+}
+
+// NewRevokeTokenCommand returns a command that revokes API tokens.
+func NewRevokeTokenCommand() cmd.Command {
+	return modelcmd.WrapController(&revokeTokenCommand{})
+}
+
+// revokeTokenCommand revokes API tokens.
+type revokeTokenCommand struct {
+	modelcmd.ControllerCommandBase
+	api RevokeTokenAPI
+
+	Ids []string
+}
+
+// Info implements Command.Info.
+func (c *revokeTokenCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "revoke-token",
+		Args:    "<token id> ...",
+		Purpose: usageRevokeTokenSummary,
+		Doc:     usageRevokeTokenDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *revokeTokenCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no token id specified")
+	}
+	c.Ids = args
+	return nil
+}
+
+func (c *revokeTokenCommand) getAPI() (RevokeTokenAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apitokens.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *revokeTokenCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+	return errors.Trace(api.RevokeAPITokens(c.Ids...))
+}
@@ -0,0 +1,203 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/apitokens"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/testing"
+)
+
+const tokenModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+type TokensCommandSuite struct {
+	BaseSuite
+	api   *mockTokensAPI
+	clock *jtesting.Clock
+}
+
+var _ = gc.Suite(&TokensCommandSuite{})
+
+func (s *TokensCommandSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"current-user/mymodel": {tokenModelUUID},
+		},
+	}
+	s.api = &mockTokensAPI{}
+	s.clock = jtesting.NewClock(testing.NonZeroTime())
+}
+
+func (s *TokensCommandSuite) TestAddTokenInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no model name specified",
+	}, {
+		args: []string{"bad/model/name"},
+		err:  `validating model name "bad/model/name": .*`,
+	}, {
+		args: []string{"--access", "superuser", "current-user/mymodel"},
+		err:  `"superuser" model access not valid`,
+	}, {
+		args: []string{"--expires-in", "0s", "current-user/mymodel"},
+		err:  "expiry duration 0s not valid",
+	}, {
+		args: []string{"current-user/mymodel"},
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		command := user.NewAddTokenCommandForTest(s.api, s.store, s.clock)
+		err := cmdtesting.InitCommand(command, test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *TokensCommandSuite) TestAddToken(c *gc.C) {
+	command := user.NewAddTokenCommandForTest(s.api, s.store, s.clock)
+	ctx, err := cmdtesting.RunCommand(c, command,
+		"--access", "write",
+		"--facades", "Client, Application",
+		"--expires-in", "2h",
+		"--description", "CI",
+		"current-user/mymodel",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "encoded-token\n")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Matches, `API token "token-0" expires at .*\n`)
+	s.api.CheckCalls(c, []jtesting.StubCall{{
+		"AddAPIToken", []interface{}{apitokens.AddAPITokenArgs{
+			Description: "CI",
+			Models:      []names.ModelTag{names.NewModelTag(tokenModelUUID)},
+			Access:      permission.WriteAccess,
+			Facades:     []string{"Client", "Application"},
+			Expires:     s.clock.Now().Add(2 * time.Hour),
+		}},
+	}, {
+		"Close", nil,
+	}})
+}
+
+func (s *TokensCommandSuite) TestAddTokenError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	command := user.NewAddTokenCommandForTest(s.api, s.store, s.clock)
+	_, err := cmdtesting.RunCommand(c, command, "current-user/mymodel")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *TokensCommandSuite) TestListTokensTabular(c *gc.C) {
+	command := user.NewListTokensCommandForTest(s.api, s.store, s.clock)
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "ListAPITokens", names.NewUserTag("current-user"))
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Id       Description  Access  Models                                Facades  Expires               Status
+token-0  CI           read    deadbeef-0bad-400d-8000-4b1d0d06f00d  Client   1970-01-01T02:00:00Z  active
+token-1               write   deadbeef-0bad-400d-8000-4b1d0d06f00d  all      1970-01-01T00:00:00Z  expired
+`[1:])
+}
+
+func (s *TokensCommandSuite) TestListTokensJSON(c *gc.C) {
+	command := user.NewListTokensCommandForTest(s.api, s.store, s.clock)
+	ctx, err := cmdtesting.RunCommand(c, command, "bob", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "ListAPITokens", names.NewUserTag("bob"))
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `[`+
+		`{"id":"token-0","owner":"current-user","description":"CI",`+
+		`"models":["deadbeef-0bad-400d-8000-4b1d0d06f00d"],"access":"read","facades":["Client"],`+
+		`"created":"1970-01-01T00:00:00Z","expires":"1970-01-01T02:00:00Z","status":"active"},`+
+		`{"id":"token-1","owner":"current-user",`+
+		`"models":["deadbeef-0bad-400d-8000-4b1d0d06f00d"],"access":"write",`+
+		`"created":"1970-01-01T00:00:00Z","expires":"1970-01-01T00:00:00Z","status":"expired"}`+
+		`]`+"\n")
+}
+
+func (s *TokensCommandSuite) TestListTokensNone(c *gc.C) {
+	s.api.tokens = []params.APIToken{}
+	command := user.NewListTokensCommandForTest(s.api, s.store, s.clock)
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No API tokens to display.\n")
+}
+
+func (s *TokensCommandSuite) TestRevokeToken(c *gc.C) {
+	command := user.NewRevokeTokenCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "token-0", "token-1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "RevokeAPITokens", []string{"token-0", "token-1"})
+}
+
+func (s *TokensCommandSuite) TestRevokeTokenNoArgs(c *gc.C) {
+	command := user.NewRevokeTokenCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, gc.ErrorMatches, "no token id specified")
+}
+
+type mockTokensAPI struct {
+	jtesting.Stub
+	tokens []params.APIToken
+}
+
+func (m *mockTokensAPI) AddAPIToken(args apitokens.AddAPITokenArgs) (params.APIToken, string, error) {
+	m.MethodCall(m, "AddAPIToken", args)
+	if err := m.NextErr(); err != nil {
+		return params.APIToken{}, "", err
+	}
+	return params.APIToken{Id: "token-0", Expires: args.Expires}, "encoded-token", nil
+}
+
+func (m *mockTokensAPI) ListAPITokens(owner names.UserTag) ([]params.APIToken, error) {
+	m.MethodCall(m, "ListAPITokens", owner)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	if m.tokens != nil {
+		return m.tokens, nil
+	}
+	now := testing.NonZeroTime()
+	return []params.APIToken{{
+		Id:          "token-0",
+		Owner:       "user-current-user",
+		Description: "CI",
+		ModelTags:   []string{names.NewModelTag(tokenModelUUID).String()},
+		Access:      "read",
+		Facades:     []string{"Client"},
+		Created:     now,
+		Expires:     now.Add(2 * time.Hour),
+	}, {
+		Id:        "token-1",
+		Owner:     "user-current-user",
+		ModelTags: []string{names.NewModelTag(tokenModelUUID).String()},
+		Access:    "write",
+		Created:   now,
+		Expires:   now,
+	}}, nil
+}
+
+func (m *mockTokensAPI) RevokeAPITokens(ids ...string) error {
+	m.MethodCall(m, "RevokeAPITokens", ids)
+	return m.NextErr()
+}
+
+func (m *mockTokensAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
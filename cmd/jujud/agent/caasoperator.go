@@ -51,6 +51,7 @@ type CaasOperatorAgent struct {
 	initialUpgradeCheckComplete chan struct{}
 
 	prometheusRegistry *prometheus.Registry
+	engineMetrics      *dependency.Metrics
 }
 
 // NewCaasOperatorAgent creates a new CAASOperatorAgent instance properly initialized.
@@ -59,12 +60,17 @@ func NewCaasOperatorAgent(ctx *cmd.Context, bufferedLogger *logsender.BufferedLo
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	engineMetrics := dependency.NewMetrics(clock.WallClock)
+	if err := prometheusRegistry.Register(engineMetrics); err != nil {
+		return nil, errors.Annotate(err, "registering dependency engine collector")
+	}
 	return &CaasOperatorAgent{
 		AgentConf: NewAgentConf(""),
 		ctx:       ctx,
 		initialUpgradeCheckComplete: make(chan struct{}),
 		bufferedLogger:              bufferedLogger,
 		prometheusRegistry:          prometheusRegistry,
+		engineMetrics:               engineMetrics,
 	}, nil
 }
 
@@ -138,6 +144,7 @@ func (op *CaasOperatorAgent) Workers() (worker.Worker, error) {
 		WorstError:  cmdutil.MoreImportantError,
 		ErrorDelay:  3 * time.Second,
 		BounceDelay: 10 * time.Millisecond,
+		Metrics:     op.engineMetrics,
 	}
 	engine, err := dependency.NewEngine(config)
 	if err != nil {
@@ -32,17 +32,21 @@ var (
 	}
 	aliveModelWorkers = []string{
 		"action-pruner",
+		"action-rollouts",
+		"action-schedules",
 		"charm-revision-updater",
 		"compute-provisioner",
 		"environ-tracker",
 		"firewaller",
 		"instance-poller",
+		"lost-machines",
 		"machine-undertaker",
 		"metric-worker",
 		"migration-fortress",
 		"migration-inactive-flag",
 		"migration-master",
 		"application-scaler",
+		"autoscaler",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
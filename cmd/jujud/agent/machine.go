@@ -292,6 +292,7 @@ func NewMachineAgent(
 		loopDeviceManager:           loopDeviceManager,
 		newIntrospectionSocketName:  newIntrospectionSocketName,
 		prometheusRegistry:          prometheusRegistry,
+		engineMetrics:               dependency.NewMetrics(clock.WallClock),
 		mongoTxnCollector:           mongometrics.NewTxnCollector(),
 		mongoDialCollector:          mongometrics.NewDialCollector(),
 		preUpgradeSteps:             preUpgradeSteps,
@@ -318,6 +319,9 @@ func (a *MachineAgent) registerPrometheusCollectors() error {
 	); err != nil {
 		return errors.Annotate(err, "registering logsender collector")
 	}
+	if err := a.prometheusRegistry.Register(a.engineMetrics); err != nil {
+		return errors.Annotate(err, "registering dependency engine collector")
+	}
 	if err := a.prometheusRegistry.Register(a.mongoTxnCollector); err != nil {
 		return errors.Annotate(err, "registering mgo/txn collector")
 	}
@@ -352,6 +356,7 @@ type MachineAgent struct {
 	loopDeviceManager          looputil.LoopDeviceManager
 	newIntrospectionSocketName func(names.Tag) string
 	prometheusRegistry         *prometheus.Registry
+	engineMetrics              *dependency.Metrics
 	mongoTxnCollector          *mongometrics.TxnCollector
 	mongoDialCollector         *mongometrics.DialCollector
 	preUpgradeSteps            upgrades.PreUpgradeStepsFunc
@@ -484,6 +489,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			WorstError:  cmdutil.MoreImportantError,
 			ErrorDelay:  3 * time.Second,
 			BounceDelay: 10 * time.Millisecond,
+			Metrics:     a.engineMetrics,
 		}
 		engine, err := dependency.NewEngine(config)
 		if err != nil {
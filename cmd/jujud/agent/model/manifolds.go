@@ -23,10 +23,13 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker/actionpruner"
+	"github.com/juju/juju/worker/actionrollouts"
+	"github.com/juju/juju/worker/actionschedules"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/applicationscaler"
+	"github.com/juju/juju/worker/autoscaler"
 	"github.com/juju/juju/worker/caasbroker"
 	"github.com/juju/juju/worker/caasmodelupgrader"
 	"github.com/juju/juju/worker/caasoperatorprovisioner"
@@ -43,6 +46,7 @@ import (
 	"github.com/juju/juju/worker/lifeflag"
 	"github.com/juju/juju/worker/logforwarder"
 	"github.com/juju/juju/worker/logforwarder/sinks"
+	"github.com/juju/juju/worker/lostmachines"
 	"github.com/juju/juju/worker/machineundertaker"
 	"github.com/juju/juju/worker/metricworker"
 	"github.com/juju/juju/worker/migrationflag"
@@ -301,6 +305,7 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 		// workers (firewaller, provisioners, address-cleaner?).
 		environTrackerName: ifResponsible(environ.Manifold(environ.ManifoldConfig{
 			APICallerName:  apiCallerName,
+			ClockName:      clockName,
 			NewEnvironFunc: config.NewEnvironFunc,
 		})),
 
@@ -358,6 +363,10 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     applicationscaler.NewFacade,
 			NewWorker:     applicationscaler.New,
 		})),
+		autoscalerName: ifNotMigrating(autoscaler.Manifold(autoscaler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+		})),
 		instancePollerName: ifNotMigrating(instancepoller.Manifold(instancepoller.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
@@ -367,6 +376,18 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 		metricWorkerName: ifNotMigrating(metricworker.Manifold(metricworker.ManifoldConfig{
 			APICallerName: apiCallerName,
 		})),
+		actionRolloutsName: ifNotMigrating(actionrollouts.Manifold(actionrollouts.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+		})),
+		actionSchedulesName: ifNotMigrating(actionschedules.Manifold(actionschedules.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+		})),
+		lostMachinesName: ifNotMigrating(lostmachines.Manifold(lostmachines.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+		})),
 		machineUndertakerName: ifNotMigrating(machineundertaker.Manifold(machineundertaker.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
@@ -536,12 +557,16 @@ const (
 	firewallerName           = "firewaller"
 	unitAssignerName         = "unit-assigner"
 	applicationScalerName    = "application-scaler"
+	autoscalerName           = "autoscaler"
 	instancePollerName       = "instance-poller"
 	charmRevisionUpdaterName = "charm-revision-updater"
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
+	actionRolloutsName       = "action-rollouts"
+	actionSchedulesName      = "action-schedules"
+	lostMachinesName         = "lost-machines"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
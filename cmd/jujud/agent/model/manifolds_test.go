@@ -32,10 +32,13 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
 		"action-pruner",
+		"action-rollouts",
+		"action-schedules",
 		"agent",
 		"api-caller",
 		"api-config-watcher",
 		"application-scaler",
+		"autoscaler",
 		"charm-revision-updater",
 		"clock",
 		"compute-provisioner",
@@ -44,6 +47,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"instance-poller",
 		"is-responsible-flag",
 		"log-forwarder",
+		"lost-machines",
 		"machine-undertaker",
 		"metric-worker",
 		"migration-fortress",
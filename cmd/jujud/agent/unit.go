@@ -9,6 +9,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/voyeur"
 	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/juju/names.v2"
@@ -56,6 +57,7 @@ type UnitAgent struct {
 	upgradeComplete             gate.Lock
 
 	prometheusRegistry *prometheus.Registry
+	engineMetrics      *dependency.Metrics
 }
 
 // NewUnitAgent creates a new UnitAgent value properly initialized.
@@ -64,6 +66,10 @@ func NewUnitAgent(ctx *cmd.Context, bufferedLogger *logsender.BufferedLogWriter)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	engineMetrics := dependency.NewMetrics(clock.WallClock)
+	if err := prometheusRegistry.Register(engineMetrics); err != nil {
+		return nil, errors.Annotate(err, "registering dependency engine collector")
+	}
 	return &UnitAgent{
 		AgentConf:        NewAgentConf(""),
 		configChangedVal: voyeur.NewValue(true),
@@ -71,6 +77,7 @@ func NewUnitAgent(ctx *cmd.Context, bufferedLogger *logsender.BufferedLogWriter)
 		initialUpgradeCheckComplete: gate.NewLock(),
 		bufferedLogger:              bufferedLogger,
 		prometheusRegistry:          prometheusRegistry,
+		engineMetrics:               engineMetrics,
 		preUpgradeSteps:             upgrades.PreUpgradeSteps,
 	}, nil
 }
@@ -185,6 +192,7 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 		WorstError:  cmdutil.MoreImportantError,
 		ErrorDelay:  3 * time.Second,
 		BounceDelay: 10 * time.Millisecond,
+		Metrics:     a.engineMetrics,
 	}
 	engine, err := dependency.NewEngine(config)
 	if err != nil {
@@ -284,6 +284,22 @@ var parseConstraintsTests = []struct {
 		args:    []string{"spaces="},
 	},
 
+	// zones
+	{
+		summary: "single zone",
+		args:    []string{"zones=az1"},
+	}, {
+		summary: "multiple zones",
+		args:    []string{"zones=az1,az2"},
+	}, {
+		summary: "no zones",
+		args:    []string{"zones="},
+	}, {
+		summary: "double set zones together",
+		args:    []string{"zones=az1 zones=az2"},
+		err:     `bad "zones" constraint: already set`,
+	},
+
 	// instance type
 	{
 		summary: "set instance type",
@@ -407,6 +423,19 @@ func (s *ConstraintsSuite) TestParseNoTagsNoSpaces(c *gc.C) {
 	c.Check(*con.Spaces, gc.HasLen, 0)
 }
 
+func (s *ConstraintsSuite) TestHasZones(c *gc.C) {
+	con := constraints.MustParse("zones=az1,az2")
+	c.Check(con.HasZones(), jc.IsTrue)
+	c.Check(*con.Zones, jc.DeepEquals, []string{"az1", "az2"})
+
+	con = constraints.MustParse("zones=")
+	c.Check(con.HasZones(), jc.IsFalse)
+	c.Check(con.Zones, gc.NotNil)
+
+	con = constraints.MustParse("mem=4G")
+	c.Check(con.HasZones(), jc.IsFalse)
+}
+
 func (s *ConstraintsSuite) TestIncludeExcludeAndHaveSpaces(c *gc.C) {
 	con := constraints.MustParse("spaces=space1,^space2,space3,^space4")
 	c.Assert(con.Spaces, gc.Not(gc.IsNil))
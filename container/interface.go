@@ -6,6 +6,7 @@ package container
 import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 )
@@ -14,6 +15,19 @@ const (
 	ConfigModelUUID        = "model-uuid"
 	ConfigLogDir           = "log-dir"
 	ConfigAvailabilityZone = "availability-zone"
+
+	// ConfigKVMStoragePool is the libvirt storage pool in which KVM
+	// containers' root disks are created.
+	ConfigKVMStoragePool = "kvm-storage-pool"
+
+	// ConfigKVMMacvtapDevices maps spaces to the host devices on which KVM
+	// container interfaces use macvtap devices, see network.ParseSpaceDevices.
+	ConfigKVMMacvtapDevices = "kvm-macvtap-devices"
+
+	// ConfigKVMSRIOVDevices maps spaces to the SR-IOV physical functions
+	// whose virtual functions KVM container interfaces are given, see
+	// network.ParseSpaceDevices.
+	ConfigKVMSRIOVDevices = "kvm-sriov-devices"
 )
 
 // ManagerConfig contains the initialization parameters for the ContainerManager.
@@ -50,6 +64,19 @@ type Manager interface {
 	Namespace() instance.Namespace
 }
 
+// LXDProfileManager is implemented by container managers that can
+// apply the LXD profiles contributed by charms to their containers.
+type LXDProfileManager interface {
+	// MaybeWriteLXDProfile writes the named profile, if it does not
+	// already exist.
+	MaybeWriteLXDProfile(name string, profile lxdprofile.Profile) error
+
+	// ReplaceLXDProfiles removes the named profiles from the container
+	// and applies the given profiles, writing any that do not yet
+	// exist.
+	ReplaceLXDProfiles(id instance.Id, remove []string, add []lxdprofile.NamedProfile) error
+}
+
 // Initialiser is responsible for performing the steps required to initialise
 // a host machine so it can run containers.
 type Initialiser interface {
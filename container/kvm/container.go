@@ -71,8 +71,13 @@ func (c *kvmContainer) Start(params StartParams) error {
 	if params.Network != nil {
 		if params.Network.NetworkType == container.BridgeNetwork {
 			bridge = params.Network.Device
-			for _, iface := range params.Network.Interfaces {
-				interfaces = append(interfaces, interfaceInfo{config: iface})
+			runCmd := c.runCmd
+			if runCmd == nil {
+				runCmd = run
+			}
+			var err error
+			if interfaces, err = networkInterfaces(runCmd, params); err != nil {
+				return errors.Trace(err)
 			}
 		} else {
 			err := errors.New("Non-bridge network devices not yet supported")
@@ -93,6 +98,7 @@ func (c *kvmContainer) Start(params StartParams) error {
 		Memory:            params.Memory,
 		CpuCores:          params.CpuCores,
 		RootDisk:          params.RootDisk,
+		StoragePool:       params.StoragePool,
 		Interfaces:        interfaces,
 	}); err != nil {
 		return err
@@ -133,8 +139,53 @@ func (c *kvmContainer) String() string {
 	return fmt.Sprintf("<KVM container %v>", *c)
 }
 
+// networkInterfaces returns the interfaces to create in the container. An
+// interface in a space with an SR-IOV device is given one of the device's
+// free virtual functions, and one in a space with a macvtap device uses a
+// macvtap device on it. All other interfaces are attached to their bridge.
+// Virtual functions passed through to any domain defined on the host, as
+// reported by runCmd, are not free.
+func networkInterfaces(runCmd runFunc, params StartParams) ([]libvirt.InterfaceInfo, error) {
+	var interfaces []libvirt.InterfaceInfo
+	var inUse map[string]bool
+	freeFunctions := make(map[string][]string)
+	for _, iface := range params.Network.Interfaces {
+		info := interfaceInfo{config: iface}
+		if pf, ok := params.SRIOVDevices[iface.SpaceName]; ok {
+			if inUse == nil {
+				var err error
+				if inUse, err = virtualFunctionsInUse(runCmd); err != nil {
+					return nil, errors.Annotate(err, "finding SR-IOV virtual functions in use")
+				}
+			}
+			free, ok := freeFunctions[pf]
+			if !ok {
+				var err error
+				if free, err = freeVirtualFunctions(pf, inUse); err != nil {
+					return nil, errors.Annotatef(err, "interface %q in space %q", iface.InterfaceName, iface.SpaceName)
+				}
+			}
+			if len(free) == 0 {
+				return nil, errors.Errorf(
+					"interface %q in space %q: no free SR-IOV virtual functions of %q",
+					iface.InterfaceName, iface.SpaceName, pf)
+			}
+			info.deviceType = libvirt.HostdevDevice
+			info.pciAddress = free[0]
+			freeFunctions[pf] = free[1:]
+		} else if dev, ok := params.MacvtapDevices[iface.SpaceName]; ok {
+			info.deviceType = libvirt.MacvtapDevice
+			info.config.ParentInterfaceName = dev
+		}
+		interfaces = append(interfaces, info)
+	}
+	return interfaces, nil
+}
+
 type interfaceInfo struct {
-	config network.InterfaceInfo
+	config     network.InterfaceInfo
+	deviceType string
+	pciAddress string
 }
 
 // MACAddress returns the embedded MacAddress value.
@@ -151,3 +202,14 @@ func (i interfaceInfo) InterfaceName() string {
 func (i interfaceInfo) ParentInterfaceName() string {
 	return i.config.ParentInterfaceName
 }
+
+// DeviceType returns the kind of libvirt device to create.
+func (i interfaceInfo) DeviceType() string {
+	return i.deviceType
+}
+
+// PCIAddress returns the PCI address of the virtual function to pass
+// through, if any.
+func (i interfaceInfo) PCIAddress() string {
+	return i.pciAddress
+}
@@ -5,8 +5,11 @@ package kvm
 
 import (
 	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/container"
+	"github.com/juju/juju/container/kvm/libvirt"
 	"github.com/juju/juju/network"
 )
 
@@ -19,8 +22,101 @@ var _ = gc.Suite(&containerInternalSuite{})
 
 func (containerInternalSuite) TestInterfaceInfo(c *gc.C) {
 	i := interfaceInfo{config: network.InterfaceInfo{
-		MACAddress: "mac", ParentInterfaceName: "piname", InterfaceName: "iname"}}
+		MACAddress: "mac", ParentInterfaceName: "piname", InterfaceName: "iname"},
+		deviceType: libvirt.HostdevDevice, pciAddress: "0000:03:10.1"}
 	c.Check(i.InterfaceName(), gc.Equals, "iname")
 	c.Check(i.ParentInterfaceName(), gc.Equals, "piname")
+	c.Check(i.DeviceType(), gc.Equals, libvirt.HostdevDevice)
+	c.Check(i.PCIAddress(), gc.Equals, "0000:03:10.1")
 	c.Assert(i.MACAddress(), gc.Equals, "mac")
 }
+
+func (s *containerInternalSuite) TestNetworkInterfaces(c *gc.C) {
+	s.PatchValue(&sysClassNet, fakeSysClassNet(c, "enp3s0f0", map[string]bool{
+		"0000:03:10.0": false,
+		"0000:03:10.2": true,
+		"0000:03:10.4": true,
+	}))
+	params := StartParams{
+		Network: container.BridgeNetworkConfig("virbr0", 0, []network.InterfaceInfo{
+			{InterfaceName: "eth0", ParentInterfaceName: "br-eth0", SpaceName: "default"},
+			{InterfaceName: "eth1", ParentInterfaceName: "br-eth1", SpaceName: "public"},
+			{InterfaceName: "eth2", ParentInterfaceName: "br-eth2", SpaceName: "dmz"},
+			{InterfaceName: "eth3", ParentInterfaceName: "br-eth2", SpaceName: "dmz"},
+		}),
+		MacvtapDevices: network.SpaceDevices{"public": "eno2"},
+		SRIOVDevices:   network.SpaceDevices{"dmz": "enp3s0f0"},
+	}
+
+	interfaces, err := networkInterfaces(fakeVirsh(nil), params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interfaces, gc.HasLen, 4)
+	for i, expected := range []struct {
+		deviceType, parent, pciAddress string
+	}{
+		{"", "br-eth0", ""},
+		{libvirt.MacvtapDevice, "eno2", ""},
+		{libvirt.HostdevDevice, "br-eth2", "0000:03:10.2"},
+		{libvirt.HostdevDevice, "br-eth2", "0000:03:10.4"},
+	} {
+		c.Check(interfaces[i].DeviceType(), gc.Equals, expected.deviceType)
+		c.Check(interfaces[i].ParentInterfaceName(), gc.Equals, expected.parent)
+		c.Check(interfaces[i].PCIAddress(), gc.Equals, expected.pciAddress)
+	}
+}
+
+func (s *containerInternalSuite) TestNetworkInterfacesSkipsFunctionsInUse(c *gc.C) {
+	s.PatchValue(&sysClassNet, fakeSysClassNet(c, "enp3s0f0", map[string]bool{
+		"0000:03:10.2": true,
+		"0000:03:10.4": true,
+	}))
+	params := StartParams{
+		Network: container.BridgeNetworkConfig("virbr0", 0, []network.InterfaceInfo{
+			{InterfaceName: "eth0", SpaceName: "dmz"},
+		}),
+		SRIOVDevices: network.SpaceDevices{"dmz": "enp3s0f0"},
+	}
+	// The first virtual function is passed through to a domain that is
+	// not running, so it still has a host network device.
+	runCmd := fakeVirsh(map[string]string{
+		"juju-06f00d-0": `<domain type="kvm"><devices>
+			<interface type="hostdev" managed="yes">
+				<source><address type="pci" domain="0x0000" bus="0x03" slot="0x10" function="0x2"/></source>
+			</interface>
+		</devices></domain>`,
+	})
+
+	interfaces, err := networkInterfaces(runCmd, params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interfaces, gc.HasLen, 1)
+	c.Check(interfaces[0].PCIAddress(), gc.Equals, "0000:03:10.4")
+}
+
+func (s *containerInternalSuite) TestNetworkInterfacesNoFreeVirtualFunctions(c *gc.C) {
+	s.PatchValue(&sysClassNet, fakeSysClassNet(c, "enp3s0f0", map[string]bool{
+		"0000:03:10.0": true,
+	}))
+	params := StartParams{
+		Network: container.BridgeNetworkConfig("virbr0", 0, []network.InterfaceInfo{
+			{InterfaceName: "eth0", SpaceName: "dmz"},
+			{InterfaceName: "eth1", SpaceName: "dmz"},
+		}),
+		SRIOVDevices: network.SpaceDevices{"dmz": "enp3s0f0"},
+	}
+
+	_, err := networkInterfaces(fakeVirsh(nil), params)
+	c.Assert(err, gc.ErrorMatches, `interface "eth1" in space "dmz": no free SR-IOV virtual functions of "enp3s0f0"`)
+}
+
+func (s *containerInternalSuite) TestNetworkInterfacesNotSRIOVDevice(c *gc.C) {
+	s.PatchValue(&sysClassNet, c.MkDir())
+	params := StartParams{
+		Network: container.BridgeNetworkConfig("virbr0", 0, []network.InterfaceInfo{
+			{InterfaceName: "eth0", SpaceName: "dmz"},
+		}),
+		SRIOVDevices: network.SpaceDevices{"dmz": "eno1"},
+	}
+
+	_, err := networkInterfaces(fakeVirsh(nil), params)
+	c.Assert(err, gc.ErrorMatches, `interface "eth0" in space "dmz": SR-IOV virtual functions of "eno1" not found`)
+}
@@ -5,6 +5,7 @@ package kvm
 
 import (
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 )
 
@@ -20,6 +21,18 @@ type StartParams struct {
 	RootDisk          uint64 // GB
 	ImageDownloadURL  string
 	StatusCallback    func(status status.Status, info string, data map[string]interface{}) error
+
+	// StoragePool is the libvirt storage pool to create the root disk in.
+	// The root disk is created in the guests directory if it is empty.
+	StoragePool string
+
+	// MacvtapDevices maps spaces to the host devices on which interfaces
+	// in those spaces use macvtap devices instead of the bridge.
+	MacvtapDevices network.SpaceDevices
+
+	// SRIOVDevices maps spaces to the SR-IOV devices whose virtual
+	// functions are passed through to interfaces in those spaces.
+	SRIOVDevices network.SpaceDevices
 }
 
 // Container represents a virtualized container instance and provides
@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -21,6 +22,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 )
 
@@ -103,8 +105,25 @@ func NewContainerManager(conf container.ManagerConfig) (container.Manager, error
 		logger.Infof("Availability zone will be empty for this container manager")
 	}
 
+	storagePool := conf.PopValue(container.ConfigKVMStoragePool)
+	macvtapDevices, err := network.ParseSpaceDevices(conf.PopValue(container.ConfigKVMMacvtapDevices))
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing macvtap devices")
+	}
+	sriovDevices, err := network.ParseSpaceDevices(conf.PopValue(container.ConfigKVMSRIOVDevices))
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing SR-IOV devices")
+	}
+
 	conf.WarnAboutUnused()
-	return &containerManager{namespace: namespace, logdir: logDir, availabilityZone: availabilityZone}, nil
+	return &containerManager{
+		namespace:        namespace,
+		logdir:           logDir,
+		availabilityZone: availabilityZone,
+		storagePool:      storagePool,
+		macvtapDevices:   macvtapDevices,
+		sriovDevices:     sriovDevices,
+	}, nil
 }
 
 // containerManager handles all of the business logic at the juju specific
@@ -114,6 +133,14 @@ type containerManager struct {
 	namespace        instance.Namespace
 	logdir           string
 	availabilityZone string
+	storagePool      string
+	macvtapDevices   network.SpaceDevices
+	sriovDevices     network.SpaceDevices
+
+	// sriovMutex is held while a container with SR-IOV devices is
+	// started, so that the virtual functions chosen for it are in use
+	// by its domain before they are chosen for another.
+	sriovMutex sync.Mutex
 }
 
 var _ container.Manager = (*containerManager)(nil)
@@ -177,6 +204,9 @@ func (manager *containerManager) CreateContainer(
 	startParams.UserDataFile = userDataFilename
 	startParams.NetworkConfigData = containerinit.CloudInitNetworkConfigDisabled
 	startParams.StatusCallback = callback
+	startParams.StoragePool = manager.storagePool
+	startParams.MacvtapDevices = manager.macvtapDevices
+	startParams.SRIOVDevices = manager.sriovDevices
 
 	// If the Simplestream requested is anything but released, update
 	// our StartParams to request it.
@@ -194,6 +224,10 @@ func (manager *containerManager) CreateContainer(
 
 	callback(status.Provisioning, "Creating container; it might take some time", nil)
 	logger.Tracef("create the container, constraints: %v", cons)
+	if len(manager.sriovDevices) > 0 {
+		manager.sriovMutex.Lock()
+		defer manager.sriovMutex.Unlock()
+	}
 	if err := kvmContainer.Start(startParams); err != nil {
 		err = errors.Annotate(err, "kvm container creation failed")
 		return nil, nil, err
@@ -218,11 +252,17 @@ func (manager *containerManager) IsInitialized() bool {
 
 func (manager *containerManager) DestroyContainer(id instance.Id) error {
 	name := string(id)
+	// The root disk volume is in the pool configured when the container
+	// was created, which may since have changed.
+	pool := rootVolumePool(run, name, manager.storagePool)
 	kvmContainer := KvmObjectFactory.New(name)
 	if err := kvmContainer.Stop(); err != nil {
 		logger.Errorf("failed to stop kvm container: %v", err)
 		return err
 	}
+	if pool != "" {
+		removeRootVolume(run, pool, name)
+	}
 	return container.RemoveDirectory(name)
 }
 
@@ -19,6 +19,7 @@ import (
 	kvmtesting "github.com/juju/juju/container/kvm/testing"
 	containertesting "github.com/juju/juju/container/testing"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -140,6 +141,33 @@ func (s *KVMSuite) TestCreateContainerUtilizesDailySimpleStream(c *gc.C) {
 	c.Assert(kvm.TestStartParams.ImageDownloadURL, gc.Equals, "http://cloud-images.ubuntu.com/daily")
 }
 
+// Test that CreateContainer passes the storage pool and space devices
+// from the manager config on in startParams.
+func (s *KVMSuite) TestCreateContainerUtilizesStoragePoolAndDevices(c *gc.C) {
+	manager, err := kvm.NewContainerManager(container.ManagerConfig{
+		container.ConfigModelUUID:         coretesting.ModelTag.Id(),
+		container.ConfigKVMStoragePool:    "nvme",
+		container.ConfigKVMMacvtapDevices: "public=eno1",
+		container.ConfigKVMSRIOVDevices:   "dmz=enp3s0f0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	containertesting.CreateContainer(c, manager, "1/kvm/0")
+
+	c.Assert(kvm.TestStartParams.StoragePool, gc.Equals, "nvme")
+	c.Assert(kvm.TestStartParams.MacvtapDevices, jc.DeepEquals, network.SpaceDevices{"public": "eno1"})
+	c.Assert(kvm.TestStartParams.SRIOVDevices, jc.DeepEquals, network.SpaceDevices{"dmz": "enp3s0f0"})
+}
+
+func (*KVMSuite) TestManagerInvalidSpaceDevices(c *gc.C) {
+	manager, err := kvm.NewContainerManager(container.ManagerConfig{
+		container.ConfigModelUUID:       coretesting.ModelTag.Id(),
+		container.ConfigKVMSRIOVDevices: "enp3s0f0",
+	})
+	c.Assert(err, gc.ErrorMatches, `parsing SR-IOV devices: space device entry "enp3s0f0" not valid`)
+	c.Assert(manager, gc.IsNil)
+}
+
 func (s *KVMSuite) TestStartContainerUtilizesSimpleStream(c *gc.C) {
 
 	startParams := kvm.StartParams{
@@ -6,6 +6,7 @@ package libvirt
 import (
 	"encoding/xml"
 	"fmt"
+	"regexp"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/arch"
@@ -16,12 +17,33 @@ import (
 // check any argument types here. We expect incoming params to be validate-able
 // by a function on the incoming domainParams.
 
+// The kinds of network interface we can create in a domain.
+const (
+	// BridgeDevice attaches the interface to the host bridge named by
+	// the interface's parent.
+	BridgeDevice = "bridge"
+	// MacvtapDevice attaches the interface to a macvtap device on the
+	// host device named by the interface's parent.
+	MacvtapDevice = "macvtap"
+	// HostdevDevice passes the SR-IOV virtual function at the
+	// interface's PCI address through to the guest.
+	HostdevDevice = "hostdev"
+)
+
+// pciAddressPattern matches PCI addresses in the
+// domain:bus:slot.function format used by sysfs, eg "0000:03:10.1".
+var pciAddressPattern = regexp.MustCompile(`^([[:xdigit:]]{4}):([[:xdigit:]]{2}):([[:xdigit:]]{2})\.([0-7])$`)
+
 // DiskInfo represents the type and location of a libvirt pool image.
 type DiskInfo interface {
-	// Source is the path to the disk image.
+	// Source is the path to the disk image, or the name of the volume if
+	// the disk is in a storage pool.
 	Source() string
 	// Driver is the type of disk, qcow, vkmd, raw, etc...
 	Driver() string
+	// Pool is the storage pool holding the disk volume. It is empty if
+	// the disk is a file.
+	Pool() string
 }
 
 // InterfaceInfo represents network interface parameters for a kvm domain.
@@ -32,6 +54,13 @@ type InterfaceInfo interface {
 	ParentInterfaceName() string
 	// InterfaceName returns the interface's device name.
 	InterfaceName() string
+	// DeviceType returns the kind of device to create for the interface,
+	// one of BridgeDevice, MacvtapDevice or HostdevDevice. An empty
+	// DeviceType is a BridgeDevice.
+	DeviceType() string
+	// PCIAddress returns the PCI address of the virtual function passed
+	// through for a HostdevDevice, eg "0000:03:10.1".
+	PCIAddress() string
 }
 
 type domainParams interface {
@@ -100,37 +129,87 @@ func NewDomain(p domainParams) (Domain, error) {
 			return Domain{}, errors.Trace(err)
 		}
 		switch diskInfo.Driver() {
-		case "raw":
-			d.Disk = append(d.Disk, Disk{
-				Device: "disk",
-				Type:   "file",
-				Driver: DiskDriver{Type: diskInfo.Driver(), Name: "qemu"},
-				Source: DiskSource{File: diskInfo.Source()},
-				Target: DiskTarget{Dev: devID},
-			})
-		case "qcow2":
-			d.Disk = append(d.Disk, Disk{
-				Device: "disk",
-				Type:   "file",
-				Driver: DiskDriver{Type: diskInfo.Driver(), Name: "qemu"},
-				Source: DiskSource{File: diskInfo.Source()},
-				Target: DiskTarget{Dev: devID},
-			})
+		case "raw", "qcow2":
 		default:
 			return Domain{}, errors.Errorf(
 				"unsupported disk type %q", diskInfo.Driver())
 		}
+		disk := Disk{
+			Device: "disk",
+			Type:   "file",
+			Driver: DiskDriver{Type: diskInfo.Driver(), Name: "qemu"},
+			Source: DiskSource{File: diskInfo.Source()},
+			Target: DiskTarget{Dev: devID},
+		}
+		if pool := diskInfo.Pool(); pool != "" {
+			disk.Type = "volume"
+			disk.Source = DiskSource{Pool: pool, Volume: diskInfo.Source()}
+		}
+		d.Disk = append(d.Disk, disk)
 	}
 	for _, iface := range p.NetworkInfo() {
-		d.Interface = append(d.Interface, Interface{
+		i, err := generateInterface(iface)
+		if err != nil {
+			return Domain{}, errors.Trace(err)
+		}
+		d.Interface = append(d.Interface, i)
+	}
+	return d, nil
+}
+
+// generateInterface creates the interface element for the kind of device
+// the interface is attached to.
+func generateInterface(iface InterfaceInfo) (Interface, error) {
+	mac := InterfaceMAC{Address: iface.MACAddress()}
+	switch iface.DeviceType() {
+	case "", BridgeDevice:
+		return Interface{
 			Type:   "bridge",
-			MAC:    InterfaceMAC{Address: iface.MACAddress()},
-			Model:  Model{Type: "virtio"},
+			MAC:    mac,
+			Model:  &Model{Type: "virtio"},
 			Source: InterfaceSource{Bridge: iface.ParentInterfaceName()},
-			Guest:  InterfaceGuest{Dev: iface.InterfaceName()},
-		})
+			Guest:  &InterfaceGuest{Dev: iface.InterfaceName()},
+		}, nil
+	case MacvtapDevice:
+		// Bridge mode lets the guest talk to other guests on the same
+		// host device as well as to the network.
+		return Interface{
+			Type:   "direct",
+			MAC:    mac,
+			Model:  &Model{Type: "virtio"},
+			Source: InterfaceSource{Dev: iface.ParentInterfaceName(), Mode: "bridge"},
+			Guest:  &InterfaceGuest{Dev: iface.InterfaceName()},
+		}, nil
+	case HostdevDevice:
+		address, err := pciAddress(iface.PCIAddress())
+		if err != nil {
+			return Interface{}, errors.Annotatef(err, "interface %q", iface.InterfaceName())
+		}
+		// Managed devices are detached from their host driver by libvirt
+		// when the domain starts, and reattached when it stops.
+		return Interface{
+			Type:    "hostdev",
+			Managed: "yes",
+			MAC:     mac,
+			Source:  InterfaceSource{Address: address},
+		}, nil
 	}
-	return d, nil
+	return Interface{}, errors.NotSupportedf("interface device type %q", iface.DeviceType())
+}
+
+// pciAddress converts a PCI address in sysfs format to an address element.
+func pciAddress(addr string) (*Address, error) {
+	parts := pciAddressPattern.FindStringSubmatch(addr)
+	if parts == nil {
+		return nil, errors.NotValidf("PCI address %q", addr)
+	}
+	return &Address{
+		Type:     "pci",
+		Domain:   "0x" + parts[1],
+		Bus:      "0x" + parts[2],
+		Slot:     "0x" + parts[3],
+		Function: "0x" + parts[4],
+	}, nil
 }
 
 // generateOSElement creates the architecture appropriate element details.
@@ -273,7 +352,7 @@ type CPU struct {
 }
 
 // Address is static. We generate a default value for it.
-// See: Controller, Video
+// See: Controller, Video, InterfaceSource
 type Address struct {
 	Type     string `xml:"type,attr,omitepmty"`
 	Domain   string `xml:"domain,attr,omitempty"`
@@ -330,12 +409,15 @@ type SerialTarget struct {
 // Interface is dynamic. It represents a network interface. We generate it from
 // an incoming argument.
 // See: https://libvirt.org/formatdomain.html#elementsNICSBridge
+// See also: https://libvirt.org/formatdomain.html#elementsNICSDirect
+// and https://libvirt.org/formatdomain.html#elementsNICSHostdev
 type Interface struct {
-	Type   string          `xml:"type,attr"`
-	MAC    InterfaceMAC    `xml:"mac"`
-	Model  Model           `xml:"model"`
-	Source InterfaceSource `xml:"source"`
-	Guest  InterfaceGuest  `xml:"guest"`
+	Type    string          `xml:"type,attr"`
+	Managed string          `xml:"managed,attr,omitempty"`
+	MAC     InterfaceMAC    `xml:"mac"`
+	Model   *Model          `xml:"model,omitempty"`
+	Source  InterfaceSource `xml:"source"`
+	Guest   *InterfaceGuest `xml:"guest,omitempty"`
 }
 
 // InterfaceMAC is the MAC address for an Interface.
@@ -344,10 +426,14 @@ type InterfaceMAC struct {
 	Address string `xml:"address,attr"`
 }
 
-// InterfaceSource it the host bridge to the network.
+// InterfaceSource it the host bridge to the network, the host device a
+// macvtap device is created on, or the PCI address of a virtual function.
 // See: Interface
 type InterfaceSource struct {
-	Bridge string `xml:"bridge,attr"`
+	Bridge  string   `xml:"bridge,attr,omitempty"`
+	Dev     string   `xml:"dev,attr,omitempty"`
+	Mode    string   `xml:"mode,attr,omitempty"`
+	Address *Address `xml:"address,omitempty"`
 }
 
 // InterfaceGuest is the guests network device.
@@ -374,10 +460,12 @@ type DiskDriver struct {
 }
 
 // DiskSource is the location of the disk image. In our case the path to the
-// necessary images.
+// necessary images, or the volume in a storage pool.
 // See: Disk
 type DiskSource struct {
-	File string `xml:"file,attr"`
+	File   string `xml:"file,attr,omitempty"`
+	Pool   string `xml:"pool,attr,omitempty"`
+	Volume string `xml:"volume,attr,omitempty"`
 }
 
 // DiskTarget is the target device on the guest. We generate these.
@@ -42,3 +42,20 @@ func (domainXMLInternalSuite) TestDeviceID(c *gc.C) {
 		c.Check(err, jc.ErrorIsNil)
 	}
 }
+
+func (domainXMLInternalSuite) TestPCIAddress(c *gc.C) {
+	got, err := pciAddress("0000:03:1f.7")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(got, jc.DeepEquals, &Address{
+		Type:     "pci",
+		Domain:   "0x0000",
+		Bus:      "0x03",
+		Slot:     "0x1f",
+		Function: "0x7",
+	})
+
+	for _, addr := range []string{"", "03:10.1", "0000:03:10.8", "0000:03:10"} {
+		_, err := pciAddress(addr)
+		c.Check(err, gc.ErrorMatches, `PCI address ".*" not valid`)
+	}
+}
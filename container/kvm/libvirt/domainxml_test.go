@@ -134,6 +134,102 @@ func (domainXMLSuite) TestNewDomain(c *gc.C) {
 	}
 }
 
+var nfvDomainStr = `
+<domain type="kvm">
+    <name>juju-someid</name>
+    <vcpu>2</vcpu>
+    <currentMemory unit="MiB">1024</currentMemory>
+    <memory unit="MiB">1024</memory>
+    <os>
+        <type>hvm</type>
+    </os>
+    <devices>
+        <disk device="disk" type="volume">
+            <driver type="qcow2" name="qemu"></driver>
+            <source pool="nvme" volume="juju-someid.qcow"></source>
+            <target dev="vda"></target>
+        </disk>
+        <disk device="disk" type="file">
+            <driver type="raw" name="qemu"></driver>
+            <source file="/another/path"></source>
+            <target dev="vdb"></target>
+        </disk>
+        <interface type="bridge">
+            <mac address="00:00:00:00:00:00"></mac>
+            <model type="virtio"></model>
+            <source bridge="br-eth0"></source>
+            <guest dev="eth0"></guest>
+        </interface>
+        <interface type="direct">
+            <mac address="00:00:00:00:00:01"></mac>
+            <model type="virtio"></model>
+            <source dev="eno2" mode="bridge"></source>
+            <guest dev="eth1"></guest>
+        </interface>
+        <interface type="hostdev" managed="yes">
+            <mac address="00:00:00:00:00:02"></mac>
+            <source>
+                <address type="pci" domain="0x0000" bus="0x03" slot="0x10" function="0x1"></address>
+            </source>
+        </interface>
+        <serial type="pty">
+            <source path="/dev/pts/2"></source>
+            <target port="0"></target>
+        </serial>
+        <console type="pty" tty="/dev/pts/2">
+            <source path="/dev/pts/2"></source>
+            <target port="0"></target>
+        </console>
+    </devices>
+</domain>`[1:]
+
+func (domainXMLSuite) TestNewDomainDevicesAndPool(c *gc.C) {
+	ifaces := []InterfaceInfo{
+		dummyInterface{
+			mac:    "00:00:00:00:00:00",
+			parent: "br-eth0",
+			name:   "eth0"},
+		dummyInterface{
+			mac:        "00:00:00:00:00:01",
+			parent:     "eno2",
+			name:       "eth1",
+			deviceType: MacvtapDevice},
+		dummyInterface{
+			mac:        "00:00:00:00:00:02",
+			name:       "eth2",
+			deviceType: HostdevDevice,
+			pciAddress: "0000:03:10.1"},
+	}
+	disks := []DiskInfo{
+		dummyDisk{driver: "qcow2", source: "juju-someid.qcow", pool: "nvme"},
+		dummyDisk{driver: "raw", source: "/another/path"},
+	}
+	params := dummyParams{ifaceInfo: ifaces, diskInfo: disks, memory: 1024, cpuCores: 2, hostname: "juju-someid", arch: "amd64"}
+
+	d, err := NewDomain(params)
+	c.Assert(err, jc.ErrorIsNil)
+	ml, err := xml.MarshalIndent(&d, "", "    ")
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(string(ml), jc.DeepEquals, nfvDomainStr)
+}
+
+func (domainXMLSuite) TestNewDomainInterfaceErrors(c *gc.C) {
+	for _, test := range []struct {
+		iface  dummyInterface
+		errMsg string
+	}{{
+		iface:  dummyInterface{name: "eth0", deviceType: HostdevDevice, pciAddress: "03:10.1"},
+		errMsg: `interface "eth0": PCI address "03:10.1" not valid`,
+	}, {
+		iface:  dummyInterface{name: "eth0", deviceType: "vhostuser"},
+		errMsg: `interface device type "vhostuser" not supported`,
+	}} {
+		params := dummyParams{ifaceInfo: []InterfaceInfo{test.iface}}
+		_, err := NewDomain(params)
+		c.Check(err, gc.ErrorMatches, test.errMsg)
+	}
+}
+
 func (domainXMLSuite) TestNewDomainError(c *gc.C) {
 	d, err := NewDomain(dummyParams{err: errors.Errorf("boom")})
 	c.Check(d, jc.DeepEquals, Domain{})
@@ -165,15 +261,20 @@ func (p dummyParams) ValidateDomainParams() error  { return p.err }
 type dummyDisk struct {
 	source string
 	driver string
+	pool   string
 }
 
 func (d dummyDisk) Driver() string { return d.driver }
 func (d dummyDisk) Source() string { return d.source }
+func (d dummyDisk) Pool() string   { return d.pool }
 
 type dummyInterface struct {
-	mac, parent, name string
+	mac, parent, name      string
+	deviceType, pciAddress string
 }
 
 func (i dummyInterface) InterfaceName() string       { return i.name }
 func (i dummyInterface) MACAddress() string          { return i.mac }
 func (i dummyInterface) ParentInterfaceName() string { return i.parent }
+func (i dummyInterface) DeviceType() string          { return i.deviceType }
+func (i dummyInterface) PCIAddress() string          { return i.pciAddress }
@@ -0,0 +1,89 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kvm
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// sysClassNet is the sysfs directory holding the host's network devices.
+// It is a variable so tests can override it.
+var sysClassNet = "/sys/class/net"
+
+// freeVirtualFunctions returns the PCI addresses of the SR-IOV virtual
+// functions of the host device pf that are not passed through to a guest,
+// ordered by virtual function number. A virtual function passed through to
+// a running guest is detached from its host network driver, so it no
+// longer has a network device of its own on the host; one passed through
+// to a guest that is not running is in inUse.
+func freeVirtualFunctions(pf string, inUse map[string]bool) ([]string, error) {
+	links, err := filepath.Glob(filepath.Join(sysClassNet, pf, "device", "virtfn*"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(links) == 0 {
+		return nil, errors.NotFoundf("SR-IOV virtual functions of %q", pf)
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return virtualFunctionIndex(links[i]) < virtualFunctionIndex(links[j])
+	})
+
+	var free []string
+	for _, link := range links {
+		target, err := os.Readlink(link)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := os.Stat(filepath.Join(link, "net")); os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if address := filepath.Base(target); !inUse[address] {
+			free = append(free, address)
+		}
+	}
+	return free, nil
+}
+
+// virtualFunctionsInUse returns the PCI addresses of the host devices
+// passed through to the domains defined on the host, whether or not
+// they are running.
+func virtualFunctionsInUse(runCmd runFunc) (map[string]bool, error) {
+	// Domains that are not running have no id, so are not matched by
+	// ListMachines.
+	output, err := runCmd("virsh", "-q", "list", "--all", "--name")
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to list domains")
+	}
+	inUse := make(map[string]bool)
+	for _, name := range strings.Fields(output) {
+		devices, err := domainDevices(runCmd, name)
+		if err != nil {
+			// The domain may have been undefined since it was listed.
+			logger.Debugf("cannot read devices of domain %q: %v", name, err)
+			continue
+		}
+		for _, address := range devices.HostdevAddresses() {
+			inUse[address] = true
+		}
+	}
+	return inUse, nil
+}
+
+// virtualFunctionIndex returns the number of the virtual function with the
+// given sysfs link, eg 12 for ".../virtfn12".
+func virtualFunctionIndex(link string) int {
+	index, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(link), "virtfn"))
+	if err != nil {
+		return -1
+	}
+	return index
+}
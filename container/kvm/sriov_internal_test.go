@@ -0,0 +1,126 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kvm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type sriovInternalSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&sriovInternalSuite{})
+
+// fakeSysClassNet creates a sysfs network device directory holding the
+// physical function pf, with a virtual function for each of the given
+// PCI addresses. Virtual functions with a true value have a host network
+// device, so they are free.
+func fakeSysClassNet(c *gc.C, pf string, functions map[string]bool) string {
+	root := c.MkDir()
+	devices := filepath.Join(root, "devices")
+	pfDevice := filepath.Join(root, pf, "device")
+	c.Assert(os.MkdirAll(pfDevice, 0755), jc.ErrorIsNil)
+
+	var addresses []string
+	for address := range functions {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	for i, address := range addresses {
+		vf := filepath.Join(devices, address)
+		c.Assert(os.MkdirAll(vf, 0755), jc.ErrorIsNil)
+		if functions[address] {
+			c.Assert(os.Mkdir(filepath.Join(vf, "net"), 0755), jc.ErrorIsNil)
+		}
+		link := filepath.Join(pfDevice, fmt.Sprintf("virtfn%d", i))
+		c.Assert(os.Symlink(vf, link), jc.ErrorIsNil)
+	}
+	return root
+}
+
+func (s *sriovInternalSuite) TestFreeVirtualFunctions(c *gc.C) {
+	functions := make(map[string]bool)
+	for i := 0; i < 12; i++ {
+		functions[fmt.Sprintf("0000:03:%02x.%d", 0x10+i/8, i%8)] = i != 1
+	}
+	s.PatchValue(&sysClassNet, fakeSysClassNet(c, "enp3s0f0", functions))
+
+	free, err := freeVirtualFunctions("enp3s0f0", map[string]bool{"0000:03:10.3": true})
+	c.Assert(err, jc.ErrorIsNil)
+	// Virtual functions are ordered by number, so virtfn10 comes after
+	// virtfn2. Those in use by a domain that is not running are not free.
+	c.Assert(free, jc.DeepEquals, []string{
+		"0000:03:10.0", "0000:03:10.2", "0000:03:10.4",
+		"0000:03:10.5", "0000:03:10.6", "0000:03:10.7", "0000:03:11.0",
+		"0000:03:11.1", "0000:03:11.2", "0000:03:11.3",
+	})
+}
+
+func (s *sriovInternalSuite) TestFreeVirtualFunctionsNotFound(c *gc.C) {
+	s.PatchValue(&sysClassNet, c.MkDir())
+
+	_, err := freeVirtualFunctions("eno1", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `SR-IOV virtual functions of "eno1" not found`)
+}
+
+// fakeVirsh returns a runFunc reporting the given domain definitions,
+// keyed by name.
+func fakeVirsh(domains map[string]string) runFunc {
+	return func(cmd string, args ...string) (string, error) {
+		switch strings.Join(append([]string{cmd}, args...), " ") {
+		case "virsh -q list --all --name":
+			var names []string
+			for name := range domains {
+				names = append(names, name)
+			}
+			return strings.Join(names, "\n"), nil
+		}
+		if len(args) == 2 && args[0] == "dumpxml" {
+			if domain, ok := domains[args[1]]; ok {
+				return domain, nil
+			}
+			return "", errors.Errorf("domain %q not found", args[1])
+		}
+		return "", errors.Errorf("unexpected command %q", cmd)
+	}
+}
+
+func (sriovInternalSuite) TestVirtualFunctionsInUse(c *gc.C) {
+	inUse, err := virtualFunctionsInUse(fakeVirsh(map[string]string{
+		"juju-06f00d-0": `<domain type="kvm"><devices>
+			<interface type="hostdev" managed="yes">
+				<mac address="52:54:00:00:00:01"/>
+				<source><address type="pci" domain="0x0000" bus="0x03" slot="0x10" function="0x1"/></source>
+			</interface>
+			<interface type="bridge"><source bridge="br-eth0"/></interface>
+			<hostdev mode="subsystem" type="pci" managed="yes">
+				<source><address domain="0x0000" bus="0x03" slot="0x11" function="0x2"/></source>
+			</hostdev>
+		</devices></domain>`,
+		"juju-06f00d-1": `<domain type="kvm"><devices>
+			<interface type="bridge"><source bridge="br-eth0"/></interface>
+		</devices></domain>`,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inUse, jc.DeepEquals, map[string]bool{
+		"0000:03:10.1": true,
+		"0000:03:11.2": true,
+	})
+}
+
+func (sriovInternalSuite) TestVirtualFunctionIndex(c *gc.C) {
+	c.Check(virtualFunctionIndex("/sys/class/net/eth0/device/virtfn12"), gc.Equals, 12)
+	c.Check(virtualFunctionIndex("/sys/class/net/eth0/device/virtfnx"), gc.Equals, -1)
+}
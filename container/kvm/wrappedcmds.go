@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/juju/errors"
@@ -67,6 +68,7 @@ type CreateMachineParams struct {
 	Memory            uint64
 	CpuCores          uint64
 	RootDisk          uint64
+	StoragePool       string
 	Interfaces        []libvirt.InterfaceInfo
 
 	disks    []libvirt.DiskInfo
@@ -151,7 +153,7 @@ func (p CreateMachineParams) ValidateDomainParams() error {
 
 // diskInfo is type for imlementing libvirt.DiskInfo.
 type diskInfo struct {
-	driver, source string
+	driver, source, pool string
 }
 
 // Driver implements libvirt.DiskInfo.
@@ -164,6 +166,11 @@ func (d diskInfo) Source() string {
 	return d.source
 }
 
+// Pool implements libvirt.DiskInfo.
+func (d diskInfo) Pool() string {
+	return d.pool
+}
+
 // CreateMachine creates a virtual machine and starts it.
 func CreateMachine(params CreateMachineParams) error {
 	if params.Hostname == "" {
@@ -184,12 +191,12 @@ func CreateMachine(params CreateMachineParams) error {
 		return errors.Annotatef(err, "failed to write data source volume for %q", params.Host())
 	}
 
-	imgPath, err := writeRootDisk(params)
+	rootDisk, err := writeRootDisk(params)
 	if err != nil {
 		return errors.Annotatef(err, "failed to write root volume for %q", params.Host())
 	}
 
-	params.disks = append(params.disks, diskInfo{source: imgPath, driver: "qcow2"})
+	params.disks = append(params.disks, rootDisk)
 	params.disks = append(params.disks, diskInfo{source: dsPath, driver: "raw"})
 
 	domainPath, err := writeDomainXML(templateDir, params)
@@ -258,8 +265,10 @@ func DestroyMachine(c *kvmContainer) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	err = os.Remove(filepath.Join(guestBase, fmt.Sprintf("%s.qcow", c.Name())))
-	if err != nil {
+	// The system disk is not in the guests directory if it was created in
+	// a storage pool, see removeRootVolume.
+	err = os.Remove(filepath.Join(guestBase, rootVolumeName(c.Name())))
+	if err != nil && !os.IsNotExist(err) {
 		logger.Errorf("failed to remove system disk for %q: %s", c.Name(), err)
 	}
 	err = os.Remove(filepath.Join(guestBase, fmt.Sprintf("%s-ds.iso", c.Name())))
@@ -460,17 +469,36 @@ func writeNetworkConfig(params CreateMachineParams, dir string) error {
 }
 
 // writeRootDisk writes out the root disk for the container.  This creates a
-// system disk backed by our shared series/arch backing store.
-func writeRootDisk(params CreateMachineParams) (string, error) {
+// system disk backed by our shared series/arch backing store. The disk is a
+// volume in the storage pool when one is given, otherwise it is a file in
+// the guests directory.
+func writeRootDisk(params CreateMachineParams) (diskInfo, error) {
 	guestBase, err := guestPath(params.findPath)
 	if err != nil {
-		return "", errors.Trace(err)
+		return diskInfo{}, errors.Trace(err)
 	}
-	imgPath := filepath.Join(guestBase, fmt.Sprintf("%s.qcow", params.Host()))
 	backingPath := filepath.Join(
 		guestBase,
 		backingFileName(params.Series, params.Arch()))
 
+	if params.StoragePool != "" {
+		volume := rootVolumeName(params.Host())
+		out, err := params.runCmdAsRoot(
+			"virsh",
+			"vol-create-as", params.StoragePool,
+			volume,
+			fmt.Sprintf("%dG", params.RootDisk),
+			"--format", "qcow2",
+			"--backing-vol", backingPath,
+			"--backing-vol-format", "qcow2")
+		logger.Debugf("create root volume: %s", out)
+		if err != nil {
+			return diskInfo{}, errors.Annotatef(err, "failed to create volume in storage pool %q", params.StoragePool)
+		}
+		return diskInfo{source: volume, driver: "qcow2", pool: params.StoragePool}, nil
+	}
+
+	imgPath := filepath.Join(guestBase, rootVolumeName(params.Host()))
 	out, err := params.runCmd(
 		"qemu-img",
 		"create",
@@ -480,10 +508,102 @@ func writeRootDisk(params CreateMachineParams) (string, error) {
 		fmt.Sprintf("%dG", params.RootDisk))
 	logger.Debugf("create root image: %s", out)
 	if err != nil {
-		return "", errors.Trace(err)
+		return diskInfo{}, errors.Trace(err)
 	}
 
-	return imgPath, nil
+	return diskInfo{source: imgPath, driver: "qcow2"}, nil
+}
+
+// rootVolumeName returns the name of the root disk image for a container.
+func rootVolumeName(hostname string) string {
+	return fmt.Sprintf("%s.qcow", hostname)
+}
+
+// rootVolumePool returns the storage pool holding the root disk volume of
+// the named domain, or "" if its root disk is not in a pool. If the domain
+// cannot be read, fallback is returned.
+func rootVolumePool(runCmd runFunc, name, fallback string) string {
+	devices, err := domainDevices(runCmd, name)
+	if err != nil {
+		logger.Infof("cannot read root disk of %q: %v", name, err)
+		return fallback
+	}
+	return devices.RootVolumePool(name)
+}
+
+// removeRootVolume removes the root disk volume of the named container from
+// the storage pool it was created in. Failures are logged rather than
+// returned, as the volume may never have been created.
+func removeRootVolume(runCmd runFunc, pool, name string) {
+	_, err := runCmd("virsh", "vol-delete", "--pool", pool, rootVolumeName(name))
+	if err != nil {
+		logger.Infof("`virsh vol-delete --pool %s %s` failed: %q", pool, rootVolumeName(name), err)
+	}
+}
+
+// domainDeviceInfo holds the devices of a domain definition that refer
+// to resources of the host.
+type domainDeviceInfo struct {
+	Disks       []libvirt.DiskSource `xml:"devices>disk>source"`
+	Interfaces  []libvirt.Interface  `xml:"devices>interface"`
+	HostdevPCIs []libvirt.Address    `xml:"devices>hostdev>source>address"`
+}
+
+// domainDevices parses and returns the devices in the output of
+// `virsh dumpxml <name>`.
+func domainDevices(runCmd runFunc, name string) (*domainDeviceInfo, error) {
+	output, err := runCmd("virsh", "dumpxml", name)
+	if err != nil {
+		return nil, errors.Annotatef(err, "failed to dump domain %q", name)
+	}
+	var devices domainDeviceInfo
+	if err := xml.Unmarshal([]byte(output), &devices); err != nil {
+		return nil, errors.Annotatef(err, "failed to parse domain %q", name)
+	}
+	return &devices, nil
+}
+
+// HostdevAddresses returns the PCI addresses, in sysfs format, of the host
+// devices passed through to the domain.
+func (d *domainDeviceInfo) HostdevAddresses() []string {
+	addresses := append([]libvirt.Address(nil), d.HostdevPCIs...)
+	for _, iface := range d.Interfaces {
+		if iface.Type == libvirt.HostdevDevice && iface.Source.Address != nil {
+			addresses = append(addresses, *iface.Source.Address)
+		}
+	}
+	var result []string
+	for _, address := range addresses {
+		if sysfs, ok := sysfsPCIAddress(address); ok {
+			result = append(result, sysfs)
+		}
+	}
+	return result
+}
+
+// RootVolumePool returns the storage pool holding the root disk volume of
+// the named domain, or "" if its root disk is not a volume in a pool.
+func (d *domainDeviceInfo) RootVolumePool(name string) string {
+	for _, source := range d.Disks {
+		if source.Volume == rootVolumeName(name) {
+			return source.Pool
+		}
+	}
+	return ""
+}
+
+// sysfsPCIAddress converts a PCI address element to the form used in
+// sysfs, eg "0000:03:10.1".
+func sysfsPCIAddress(address libvirt.Address) (string, bool) {
+	var parts [4]uint64
+	for i, field := range []string{address.Domain, address.Bus, address.Slot, address.Function} {
+		n, err := strconv.ParseUint(field, 0, 16)
+		if err != nil {
+			return "", false
+		}
+		parts[i] = n
+	}
+	return fmt.Sprintf("%04x:%02x:%02x.%x", parts[0], parts[1], parts[2], parts[3]), true
 }
 
 // pool info parses and returns the output of `virsh pool-info <poolname>`.
@@ -21,6 +21,22 @@ type libvirtInternalSuite struct {
 
 var _ = gc.Suite(&libvirtInternalSuite{})
 
+func (libvirtInternalSuite) TestRootVolumePool(c *gc.C) {
+	runCmd := fakeVirsh(map[string]string{
+		"host00": `<domain type="kvm"><devices>
+			<disk device="disk" type="file"><source file="/var/lib/juju/kvm/guests/host00-ds.iso"/></disk>
+			<disk device="disk" type="volume"><source pool="nvme" volume="host00.qcow"/></disk>
+		</devices></domain>`,
+		"host01": `<domain type="kvm"><devices>
+			<disk device="disk" type="file"><source file="/var/lib/juju/kvm/guests/host01.qcow"/></disk>
+		</devices></domain>`,
+	})
+	c.Check(rootVolumePool(runCmd, "host00", "ssd"), gc.Equals, "nvme")
+	c.Check(rootVolumePool(runCmd, "host01", "ssd"), gc.Equals, "")
+	// The configured pool is used when the domain cannot be read.
+	c.Check(rootVolumePool(runCmd, "host02", "ssd"), gc.Equals, "ssd")
+}
+
 func (libvirtInternalSuite) TestWriteMetadata(c *gc.C) {
 	d := c.MkDir()
 
@@ -159,3 +175,32 @@ func (libvirtInternalSuite) TestPoolInfoNoPool(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(got, gc.IsNil)
 }
+
+func (libvirtInternalSuite) TestWriteRootDiskInStoragePoolFails(c *gc.C) {
+	d := c.MkDir()
+	p := CreateMachineParams{
+		Hostname:     "host00",
+		Series:       "xenial",
+		RootDisk:     8,
+		StoragePool:  "nvme",
+		findPath:     func(string) (string, error) { return d, nil },
+		runCmdAsRoot: (&runStub{err: errors.New("pool not found")}).Run,
+		arch:         "amd64",
+	}
+
+	got, err := writeRootDisk(p)
+	c.Assert(err, gc.ErrorMatches, `failed to create volume in storage pool "nvme": pool not found`)
+	c.Assert(got, jc.DeepEquals, diskInfo{})
+}
+
+func (libvirtInternalSuite) TestRemoveRootVolume(c *gc.C) {
+	stub := &runStub{}
+	removeRootVolume(stub.Run, "nvme", "host00")
+	c.Assert(stub.Calls(), jc.DeepEquals, []string{"virsh vol-delete --pool nvme host00.qcow"})
+}
+
+func (libvirtInternalSuite) TestRemoveRootVolumeFails(c *gc.C) {
+	stub := &runStub{err: errors.New("boom")}
+	removeRootVolume(stub.Run, "nvme", "host00")
+	c.Assert(c.GetTestLog(), jc.Contains, "`virsh vol-delete --pool nvme host00.qcow` failed")
+}
@@ -156,6 +156,47 @@ func (commandWrapperSuite) TestCreateMachineSuccess(c *gc.C) {
 	}
 }
 
+func (commandWrapperSuite) TestCreateMachineInStoragePool(c *gc.C) {
+	stub := NewRunStub("success", nil)
+
+	tmpDir := c.MkDir()
+	err := os.MkdirAll(filepath.Join(tmpDir, "kvm", "guests"), 0755)
+	c.Check(err, jc.ErrorIsNil)
+	cloudInitPath := filepath.Join(tmpDir, "cloud-init")
+	err = ioutil.WriteFile(cloudInitPath, []byte("#cloud-init\nEOF\n"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	pathfinder := func(s string) (string, error) {
+		return tmpDir, nil
+	}
+
+	params := CreateMachineParams{
+		Hostname:     "host00",
+		Series:       "xenial",
+		UserDataFile: cloudInitPath,
+		RootDisk:     8,
+		StoragePool:  "nvme",
+	}
+
+	MakeCreateMachineParamsTestable(&params, pathfinder, stub.Run, "amd64")
+	err = CreateMachine(params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	want := []string{
+		`genisoimage -output .*\/kvm\/guests\/host00-ds\.iso -volid cidata -joliet -rock user-data meta-data network-config`,
+		`virsh vol-create-as nvme host00.qcow 8G --format qcow2 --backing-vol .*\/kvm\/guests\/xenial-amd64-backing-file.qcow --backing-vol-format qcow2`,
+		`virsh define .*\/host00.xml`,
+		"virsh start host00",
+	}
+	c.Assert(stub.Calls(), gc.HasLen, len(want))
+	for i, cmd := range stub.Calls() {
+		c.Check(cmd, gc.Matches, want[i])
+	}
+
+	domain, err := ioutil.ReadFile(filepath.Join(tmpDir, "host00.xml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(domain), jc.Contains, `<source pool="nvme" volume="host00.qcow"></source>`)
+}
+
 func (commandWrapperSuite) TestDestroyMachineSuccess(c *gc.C) {
 	tmpDir, err := ioutil.TempDir("", "juju-libvirtSuite-")
 	c.Check(err, jc.ErrorIsNil)
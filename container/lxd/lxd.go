@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -45,6 +46,9 @@ type containerManager struct {
 // containerManager implements container.Manager.
 var _ container.Manager = (*containerManager)(nil)
 
+// containerManager implements container.LXDProfileManager.
+var _ container.LXDProfileManager = (*containerManager)(nil)
+
 func ConnectLocal() (*lxdclient.Client, error) {
 	cfg := lxdclient.Config{
 		Remote: lxdclient.Local,
@@ -175,6 +179,10 @@ func (manager *containerManager) CreateContainer(
 	} else {
 		logger.Infof("instance %q configured with %v network devices", name, nics)
 	}
+	if len(instanceConfig.CharmLXDProfiles) > 0 {
+		logger.Infof("instance %q configured with charm profiles %v", name, instanceConfig.CharmLXDProfiles)
+		profiles = append(profiles, instanceConfig.CharmLXDProfiles...)
+	}
 
 	spec := lxdclient.InstanceSpec{
 		Name:     name,
@@ -228,6 +236,51 @@ func (manager *containerManager) ListContainers() (result []instance.Instance, e
 	return
 }
 
+// MaybeWriteLXDProfile implements container.LXDProfileManager.
+func (manager *containerManager) MaybeWriteLXDProfile(name string, profile lxdprofile.Profile) error {
+	if manager.client == nil {
+		var err error
+		manager.client, err = ConnectLocal()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := profile.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	err := manager.client.EnsureProfile(name, profile.Description, profile.Config, profile.Devices)
+	return errors.Annotatef(err, "writing LXD profile %q", name)
+}
+
+// ReplaceLXDProfiles implements container.LXDProfileManager.
+func (manager *containerManager) ReplaceLXDProfiles(id instance.Id, remove []string, add []lxdprofile.NamedProfile) error {
+	if manager.client == nil {
+		var err error
+		manager.client, err = ConnectLocal()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	names := make([]string, len(add))
+	for i, profile := range add {
+		if err := manager.MaybeWriteLXDProfile(profile.Name, profile.Profile); err != nil {
+			return errors.Trace(err)
+		}
+		names[i] = profile.Name
+	}
+	if _, err := manager.client.ReplaceProfiles(string(id), remove, names); err != nil {
+		return errors.Trace(err)
+	}
+	// Delete profiles no longer used by any container, such as those
+	// written for previous revisions of a charm.
+	for _, name := range remove {
+		if err := manager.client.DeleteProfileIfUnused(name); err != nil {
+			logger.Warningf("cannot delete LXD profile %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
 func (manager *containerManager) IsInitialized() bool {
 	if manager.client != nil {
 		return true
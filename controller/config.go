@@ -83,6 +83,19 @@ const (
 	// MaxTxnLogSize is the maximum size the of capped txn log collection, eg "10M"
 	MaxTxnLogSize = "max-txn-log-size"
 
+	// MetricsPasswordKey sets the password that Prometheus scrapers
+	// must present, using HTTP basic authentication with the username
+	// "metrics", to read the /metrics endpoint on the API server port.
+	// If it is not set, the endpoint is not served.
+	MetricsPasswordKey = "metrics-password"
+
+	// TracingEndpointKey sets the base URL of an OpenTelemetry
+	// collector, such as Jaeger, that accepts traces over HTTP, eg
+	// "http://jaeger.example.com:4318". If it is set, the controller
+	// records a trace of each API request it serves and sends them
+	// there. Tracing is disabled by default.
+	TracingEndpointKey = "tracing-endpoint"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -138,6 +151,8 @@ var ControllerOnlyConfigAttributes = []string{
 	MaxLogsSize,
 	MaxLogsAge,
 	MaxTxnLogSize,
+	MetricsPasswordKey,
+	TracingEndpointKey,
 	JujuHASpace,
 	JujuManagementSpace,
 }
@@ -282,6 +297,18 @@ func (c Config) IdentityPublicKey() *bakery.PublicKey {
 	return &pubKey
 }
 
+// MetricsPassword returns the password required to read the API
+// server's /metrics endpoint. See MetricsPasswordKey for more details.
+func (c Config) MetricsPassword() string {
+	return c.asString(MetricsPasswordKey)
+}
+
+// TracingEndpoint returns the URL of the collector to which the
+// controller sends traces, or "" if tracing is disabled.
+func (c Config) TracingEndpoint() string {
+	return c.asString(TracingEndpointKey)
+}
+
 // MongoMemoryProfile returns the selected profile or low.
 func (c Config) MongoMemoryProfile() string {
 	if profile, ok := c[MongoMemoryProfile]; ok {
@@ -363,6 +390,16 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[TracingEndpointKey].(string); ok {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid tracing endpoint")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("tracing endpoint %q: URL needs to be http or https", v)
+		}
+	}
+
 	caCert, caCertOK := c.CACert()
 	if !caCertOK {
 		return errors.Errorf("missing CA certificate")
@@ -446,6 +483,8 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxLogsAge:              schema.String(),
 	MaxLogsSize:             schema.String(),
 	MaxTxnLogSize:           schema.String(),
+	MetricsPasswordKey:      schema.String(),
+	TracingEndpointKey:      schema.String(),
 	JujuHASpace:             schema.String(),
 	JujuManagementSpace:     schema.String(),
 }, schema.Defaults{
@@ -462,6 +501,8 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxLogsAge:              fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
 	MaxLogsSize:             fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
 	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	MetricsPasswordKey:      schema.Omit,
+	TracingEndpointKey:      schema.Omit,
 	JujuHASpace:             schema.Omit,
 	JujuManagementSpace:     schema.Omit,
 })
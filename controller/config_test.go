@@ -121,6 +121,26 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "tracing endpoint OK",
+	config: controller.Config{
+		controller.TracingEndpointKey: "http://0.1.2.3:4318",
+		controller.CACertKey:          testing.CACert,
+	},
+}, {
+	about: "tracing endpoint without scheme",
+	config: controller.Config{
+		controller.TracingEndpointKey: "0.1.2.3:4318",
+		controller.CACertKey:          testing.CACert,
+	},
+	expectError: `invalid tracing endpoint: .*`,
+}, {
+	about: "tracing endpoint with bad scheme",
+	config: controller.Config{
+		controller.TracingEndpointKey: "udp://0.1.2.3:6831",
+		controller.CACertKey:          testing.CACert,
+	},
+	expectError: `tracing endpoint "udp://0.1.2.3:6831": URL needs to be http or https`,
 }, {
 	about: "invalid management space name - whitespace",
 	config: controller.Config{
@@ -209,6 +229,38 @@ func (s *ConfigSuite) TestTxnLogConfigValue(c *gc.C) {
 	c.Assert(cfg.MaxTxnLogSizeMB(), gc.Equals, 8192)
 }
 
+func (s *ConfigSuite) TestMetricsPassword(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MetricsPassword(), gc.Equals, "")
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.MetricsPasswordKey: "sekrit",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MetricsPassword(), gc.Equals, "sekrit")
+}
+
+func (s *ConfigSuite) TestTracingEndpoint(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.TracingEndpoint(), gc.Equals, "")
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.TracingEndpointKey: "http://jaeger.example.com:4318",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.TracingEndpoint(), gc.Equals, "http://jaeger.example.com:4318")
+}
+
 func (s *ConfigSuite) TestNetworkSpaceConfigValues(c *gc.C) {
 	haSpace := "space1"
 	managementSpace := "space2"
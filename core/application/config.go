@@ -10,6 +10,22 @@ import (
 	"gopkg.in/juju/environschema.v1"
 )
 
+// ReplaceLostMachinesKey is the application config key that opts the
+// application in or out of having the machines hosting its units
+// replaced when their instances disappear from the cloud. If it is not
+// set, the model config setting of the same name applies.
+const ReplaceLostMachinesKey = "replace-lost-machines"
+
+// IAASConfigSchema holds the config fields that apply to applications
+// in IAAS models.
+var IAASConfigSchema = environschema.Fields{
+	ReplaceLostMachinesKey: {
+		Description: "Determines whether machines hosting the application's units are replaced when their instance disappears from the cloud (defaults to the model setting)",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+}
+
 // ConfigAttributes is the config for an application.
 type ConfigAttributes map[string]interface{}
 
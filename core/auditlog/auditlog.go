@@ -3,6 +3,8 @@
 package auditlog
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -172,6 +174,108 @@ func newConversationID() string {
 	return hex.EncodeToString(buf)
 }
 
+// LogFileName is the name of the file, in the log directory, that the
+// audit log returned by NewLogFile writes to.
+const LogFileName = "audit.log"
+
+// conversationPrefix starts every line of an audit log that records a
+// conversation.
+var conversationPrefix = []byte(`{"conversation":`)
+
+// bisectThreshold is the size of the section of an audit log below
+// which ReadConversations stops bisecting the log and reads it.
+const bisectThreshold = 64 * 1024
+
+// ReadConversations reads the records of the first size bytes of an
+// audit log from r, and returns up to limit conversations with the
+// given model that began at or after since (or all of them if limit
+// is not positive). Records are appended to the log as conversations
+// begin, so the log is bisected to find the first of them rather than
+// read from the start. Lines that cannot be parsed, such as one still
+// being written, are skipped.
+func ReadConversations(r io.ReaderAt, size int64, modelUUID string, since time.Time, limit int) ([]Conversation, error) {
+	start, err := conversationsOffset(r, size, since)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var conversations []Conversation
+	reader := bufio.NewReader(io.NewSectionReader(r, start, size-start))
+	for {
+		line, err := reader.ReadBytes('\n')
+		if c, ok := parseConversation(line); ok && c.ModelUUID == modelUUID {
+			when, timeErr := time.Parse(time.RFC3339, c.When)
+			if timeErr == nil && !when.Before(since) {
+				conversations = append(conversations, c)
+				if limit > 0 && len(conversations) == limit {
+					return conversations, nil
+				}
+			}
+		}
+		if err == io.EOF {
+			return conversations, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+}
+
+// conversationsOffset returns the offset of a line in the first size
+// bytes of an audit log at or before the record of the first
+// conversation that began at or after since.
+func conversationsOffset(r io.ReaderAt, size int64, since time.Time) (int64, error) {
+	lo, hi := int64(0), size
+	for hi-lo > bisectThreshold {
+		mid := lo + (hi-lo)/2
+		offset, when, err := nextConversation(r, mid, hi)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		if offset < 0 || !when.Before(since) {
+			hi = mid
+		} else {
+			lo = offset
+		}
+	}
+	return lo, nil
+}
+
+// nextConversation returns the offset and time of the first record of
+// a conversation that starts on a line after offset from and before
+// offset to, or a negative offset if there is none.
+func nextConversation(r io.ReaderAt, from, to int64) (int64, time.Time, error) {
+	reader := bufio.NewReader(io.NewSectionReader(r, from, to-from))
+	// The first line read is most likely only part of one.
+	line, err := reader.ReadBytes('\n')
+	offset := from + int64(len(line))
+	for err == nil {
+		line, err = reader.ReadBytes('\n')
+		if c, ok := parseConversation(line); ok {
+			if when, timeErr := time.Parse(time.RFC3339, c.When); timeErr == nil {
+				return offset, when, nil
+			}
+		}
+		offset += int64(len(line))
+	}
+	if err != io.EOF {
+		return 0, time.Time{}, errors.Trace(err)
+	}
+	return -1, time.Time{}, nil
+}
+
+// parseConversation returns the conversation recorded on the given
+// line of an audit log, and false if it does not record one.
+func parseConversation(line []byte) (Conversation, bool) {
+	if !bytes.HasPrefix(line, conversationPrefix) {
+		return Conversation{}, false
+	}
+	var record Record
+	if err := json.Unmarshal(line, &record); err != nil || record.Conversation == nil {
+		logger.Debugf("skipping audit log line %q", line)
+		return Conversation{}, false
+	}
+	return *record.Conversation, true
+}
+
 type auditLogFile struct {
 	fileLogger io.WriteCloser
 }
@@ -182,7 +286,7 @@ type auditLogFile struct {
 // the maximum number of old compressed log files to keep (or 0 to
 // keep all of them).
 func NewLogFile(logDir string, maxSize, maxBackups int) AuditLog {
-	logPath := filepath.Join(logDir, "audit.log")
+	logPath := filepath.Join(logDir, LogFileName)
 	if err := primeLogFile(logPath); err != nil {
 		// This isn't a fatal error so log and continue if priming
 		// fails.
@@ -3,6 +3,8 @@
 package auditlog_test
 
 import (
+	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -131,6 +133,101 @@ func (s *AuditLogSuite) TestRecorder(c *gc.C) {
 	})
 }
 
+func (s *AuditLogSuite) TestReadConversations(c *gc.C) {
+	dir := c.MkDir()
+	logFile := auditlog.NewLogFile(dir, 300, 10)
+	for _, conversation := range []auditlog.Conversation{{
+		What:           "juju status",
+		When:           "2017-11-27T13:21:24Z",
+		ModelUUID:      "model-1",
+		ConversationID: "1",
+	}, {
+		What:           "juju deploy mysql",
+		When:           "2017-11-27T13:25:00Z",
+		ModelUUID:      "model-1",
+		ConversationID: "2",
+	}, {
+		What:           "juju deploy wordpress",
+		When:           "2017-11-27T13:26:00Z",
+		ModelUUID:      "model-2",
+		ConversationID: "3",
+	}} {
+		err := logFile.AddConversation(conversation)
+		c.Assert(err, jc.ErrorIsNil)
+		err = logFile.AddRequest(auditlog.Request{ConversationID: conversation.ConversationID})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := logFile.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	logPath := filepath.Join(dir, auditlog.LogFileName)
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = f.WriteString(`{"conversation":{"who":"partial`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+
+	f, err = os.Open(logPath)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	info, err := f.Stat()
+	c.Assert(err, jc.ErrorIsNil)
+	since, err := time.Parse(time.RFC3339, "2017-11-27T13:25:00Z")
+	c.Assert(err, jc.ErrorIsNil)
+	conversations, err := auditlog.ReadConversations(f, info.Size(), "model-1", since, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversations, gc.HasLen, 1)
+	c.Check(conversations[0].ConversationID, gc.Equals, "2")
+	c.Check(conversations[0].What, gc.Equals, "juju deploy mysql")
+}
+
+func (s *AuditLogSuite) TestReadConversationsBisects(c *gc.C) {
+	dir := c.MkDir()
+	logFile := auditlog.NewLogFile(dir, 300, 10)
+	start, err := time.Parse(time.RFC3339, "2017-11-27T13:00:00Z")
+	c.Assert(err, jc.ErrorIsNil)
+	for i := 0; i < 5000; i++ {
+		err := logFile.AddConversation(auditlog.Conversation{
+			What:           "juju status",
+			When:           start.Add(time.Duration(i) * time.Second).Format(time.RFC3339),
+			ModelUUID:      "model-1",
+			ConversationID: fmt.Sprint(i),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		err = logFile.AddRequest(auditlog.Request{ConversationID: fmt.Sprint(i)})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err = logFile.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	f, err := os.Open(filepath.Join(dir, auditlog.LogFileName))
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	info, err := f.Stat()
+	c.Assert(err, jc.ErrorIsNil)
+	reader := &countingReader{r: f}
+	conversations, err := auditlog.ReadConversations(reader, info.Size(), "model-1", start.Add(4000*time.Second), 3)
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []string
+	for _, conversation := range conversations {
+		ids = append(ids, conversation.ConversationID)
+	}
+	c.Check(ids, jc.DeepEquals, []string{"4000", "4001", "4002"})
+	c.Check(reader.read < info.Size()/2, jc.IsTrue)
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r    io.ReaderAt
+	read int64
+}
+
+func (r *countingReader) ReadAt(p []byte, off int64) (int, error) {
+	n, err := r.r.ReadAt(p, off)
+	r.read += int64(n)
+	return n, err
+}
+
 type fakeLog struct {
 	stub testing.Stub
 }
@@ -0,0 +1,200 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cron parses the standard five field cron expressions used to
+// schedule recurring tasks, and works out when they next fall due.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	expr   string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// domAny and dowAny record whether the day of month and day of
+	// week fields were "*". When both are restricted, a day matches
+	// if either field does.
+	domAny bool
+	dowAny bool
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Both 0 and 7 mean Sunday.
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a cron expression made up of minute, hour, day of
+// month, month and day of week fields. Each field is "*", or a comma
+// separated list of values and ranges, optionally with a "/step".
+// Months and days of the week may be given by their three letter
+// names. The macros @yearly, @monthly, @weekly, @daily and @hourly
+// are also accepted.
+func Parse(expr string) (*Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := macros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+	s := &Schedule{
+		expr:   expr,
+		domAny: strings.HasPrefix(fields[2], "*"),
+		dowAny: strings.HasPrefix(fields[4], "*"),
+	}
+	var err error
+	for i, f := range []struct {
+		field
+		bits *uint64
+	}{
+		{minuteField, &s.minute},
+		{hourField, &s.hour},
+		{domField, &s.dom},
+		{monthField, &s.month},
+		{dowField, &s.dow},
+	} {
+		if *f.bits, err = f.parse(fields[i]); err != nil {
+			return nil, errors.Annotatef(err, "invalid cron expression %q", expr)
+		}
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// parse returns the values matched by a field as a bit set.
+func (f field) parse(value string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		lo, hi, step := f.min, f.max, 1
+		rangePart := part
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return 0, errors.Errorf("invalid step in %s %q", f.name, part)
+			}
+			rangePart = part[:i]
+		}
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = f.value(bounds[0]); err != nil {
+				return 0, errors.Trace(err)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = f.value(bounds[1]); err != nil {
+					return 0, errors.Trace(err)
+				}
+			} else if step > 1 {
+				// "5/15" means every 15 from 5.
+				hi = f.max
+			}
+			if hi < lo {
+				return 0, errors.Errorf("invalid range in %s %q", f.name, part)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single value of a field.
+func (f field) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, errors.Errorf("%s %q not in range %d-%d", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// maxYears bounds the search for the next time a schedule falls due,
+// so that expressions that can never match, such as "0 0 30 2 *",
+// don't loop forever.
+const maxYears = 5
+
+// Next returns the first time after t at which the schedule falls due,
+// in t's location. It returns the zero time if the schedule never
+// falls due.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.Year() + maxYears
+	for t.Year() <= limit {
+		switch {
+		case !has(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !has(s.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !has(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := has(s.dom, t.Day())
+	dow := has(s.dow, int(t.Weekday()))
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
@@ -0,0 +1,108 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cron_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cron"
+)
+
+type CronSuite struct{}
+
+var _ = gc.Suite(&CronSuite{})
+
+func (*CronSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		expr   string
+		expect string
+	}{{
+		expr:   "",
+		expect: `invalid cron expression "": expected 5 fields, got 0`,
+	}, {
+		expr:   "* * * *",
+		expect: `invalid cron expression "\* \* \* \*": expected 5 fields, got 4`,
+	}, {
+		expr:   "60 * * * *",
+		expect: `invalid cron expression .*: minute "60" not in range 0-59`,
+	}, {
+		expr:   "* 24 * * *",
+		expect: `invalid cron expression .*: hour "24" not in range 0-23`,
+	}, {
+		expr:   "* * 0 * *",
+		expect: `invalid cron expression .*: day of month "0" not in range 1-31`,
+	}, {
+		expr:   "* * * foo *",
+		expect: `invalid cron expression .*: month "foo" not in range 1-12`,
+	}, {
+		expr:   "* * * * 8",
+		expect: `invalid cron expression .*: day of week "8" not in range 0-7`,
+	}, {
+		expr:   "*/0 * * * *",
+		expect: `invalid cron expression .*: invalid step in minute "\*/0"`,
+	}, {
+		expr:   "5-1 * * * *",
+		expect: `invalid cron expression .*: invalid range in minute "5-1"`,
+	}} {
+		c.Logf("test %d: %q", i, test.expr)
+		_, err := cron.Parse(test.expr)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (*CronSuite) TestString(c *gc.C) {
+	s, err := cron.Parse("@daily")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.String(), gc.Equals, "@daily")
+}
+
+func (*CronSuite) TestNext(c *gc.C) {
+	// A Thursday.
+	now := time.Date(2018, 3, 1, 10, 30, 15, 0, time.UTC)
+	for i, test := range []struct {
+		expr   string
+		expect time.Time
+	}{{
+		expr:   "0 2 * * *",
+		expect: time.Date(2018, 3, 2, 2, 0, 0, 0, time.UTC),
+	}, {
+		expr:   "*/15 * * * *",
+		expect: time.Date(2018, 3, 1, 10, 45, 0, 0, time.UTC),
+	}, {
+		expr:   "30 10 * * *",
+		expect: time.Date(2018, 3, 2, 10, 30, 0, 0, time.UTC),
+	}, {
+		expr:   "0 0 * * sun",
+		expect: time.Date(2018, 3, 4, 0, 0, 0, 0, time.UTC),
+	}, {
+		expr:   "0 0 * * 7",
+		expect: time.Date(2018, 3, 4, 0, 0, 0, 0, time.UTC),
+	}, {
+		expr:   "0 0 1 * *",
+		expect: time.Date(2018, 4, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		expr:   "@hourly",
+		expect: time.Date(2018, 3, 1, 11, 0, 0, 0, time.UTC),
+	}, {
+		// Day of month or day of week: the 13th, or any Friday.
+		expr:   "0 0 13 * fri",
+		expect: time.Date(2018, 3, 2, 0, 0, 0, 0, time.UTC),
+	}, {
+		expr:   "5/20 9-17 * jan-dec mon-fri",
+		expect: time.Date(2018, 3, 1, 10, 45, 0, 0, time.UTC),
+	}, {
+		expr:   "0 0 29 2 *",
+		expect: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+	}, {
+		expr: "0 0 30 2 *",
+	}} {
+		c.Logf("test %d: %q", i, test.expr)
+		s, err := cron.Parse(test.expr)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(s.Next(now), gc.Equals, test.expect)
+	}
+}
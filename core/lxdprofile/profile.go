@@ -0,0 +1,189 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package lxdprofile handles the LXD profiles that charms may ship in
+// order to adjust the configuration of the containers their units run
+// in, for example to load kernel modules or allow nesting.
+package lxdprofile
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/yaml.v2"
+)
+
+// Filename is the name of the file, at the root of a charm, holding
+// the charm's LXD profile.
+const Filename = "lxd-profile.yaml"
+
+// allowedConfigKeys holds the container config keys that a charm's
+// profile may set.
+var allowedConfigKeys = map[string]bool{
+	"linux.kernel_modules": true,
+	"security.nesting":     true,
+	"security.privileged":  true,
+}
+
+// allowedConfigPrefixes holds the prefixes of container config keys
+// that a charm's profile may set.
+var allowedConfigPrefixes = []string{
+	"environment.",
+	"security.syscalls.",
+}
+
+// allowedDeviceTypes holds the device types that a charm's profile
+// may add. Network interfaces and disks are managed by Juju, and may
+// not be added by charms.
+var allowedDeviceTypes = map[string]bool{
+	"gpu":        true,
+	"infiniband": true,
+	"unix-block": true,
+	"unix-char":  true,
+	"usb":        true,
+}
+
+// Profile is an LXD profile contributed by a charm.
+type Profile struct {
+	Config      map[string]string            `json:"config,omitempty" yaml:"config,omitempty"`
+	Description string                       `json:"description,omitempty" yaml:"description,omitempty"`
+	Devices     map[string]map[string]string `json:"devices,omitempty" yaml:"devices,omitempty"`
+}
+
+// NamedProfile is a charm's LXD profile, along with the name of the
+// LXD profile it is written to.
+type NamedProfile struct {
+	Name    string
+	Profile Profile
+}
+
+// Parse parses and validates the contents of a charm's
+// lxd-profile.yaml file.
+func Parse(data []byte) (*Profile, error) {
+	var profile Profile
+	if err := yaml.UnmarshalStrict(data, &profile); err != nil {
+		return nil, errors.Annotatef(err, "parsing %s", Filename)
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &profile, nil
+}
+
+// Empty reports whether or not the profile makes any changes to a
+// container's configuration.
+func (p Profile) Empty() bool {
+	return len(p.Config) == 0 && len(p.Devices) == 0
+}
+
+// Validate checks that the profile only sets allowed config keys and
+// adds allowed device types.
+func (p Profile) Validate() error {
+	for _, key := range sortedKeys(p.Config) {
+		if !configKeyAllowed(key) {
+			return errors.NotValidf("LXD profile config key %q", key)
+		}
+	}
+	for _, name := range sortedDeviceNames(p.Devices) {
+		device := p.Devices[name]
+		deviceType, ok := device["type"]
+		if !ok {
+			return errors.NotValidf("LXD profile device %q without type", name)
+		}
+		if !allowedDeviceTypes[deviceType] {
+			return errors.NotValidf("LXD profile device %q of type %q", name, deviceType)
+		}
+	}
+	return nil
+}
+
+func configKeyAllowed(key string) bool {
+	if allowedConfigKeys[key] {
+		return true
+	}
+	for _, prefix := range allowedConfigPrefixes {
+		if strings.HasPrefix(key, prefix) && len(key) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the name of the LXD profile for the given revision of
+// an application's charm. Each revision gets its own profile, so that
+// upgrading a charm does not alter the containers of units that have
+// not yet been upgraded.
+func Name(modelName, appName string, revision int) string {
+	return fmt.Sprintf("juju-%s-%s-%d", modelName, appName, revision)
+}
+
+// ReadCharm returns the LXD profile shipped with the given charm, or
+// nil if the charm does not have one. Only charm directories and
+// archives can ship a profile.
+func ReadCharm(ch charm.Charm) (*Profile, error) {
+	var data []byte
+	var err error
+	switch ch := ch.(type) {
+	case *charm.CharmDir:
+		data, err = ioutil.ReadFile(filepath.Join(ch.Path, Filename))
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+	case *charm.CharmArchive:
+		data, err = readArchiveFile(ch.Path, Filename)
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading %s", Filename)
+	}
+	return Parse(data)
+}
+
+func readArchiveFile(archivePath, name string) ([]byte, error) {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer reader.Close()
+	for _, file := range reader.File {
+		if file.Name != name {
+			continue
+		}
+		content, err := file.Open()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer content.Close()
+		return ioutil.ReadAll(content)
+	}
+	return nil, errors.NotFoundf("%s in charm archive", name)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedDeviceNames(m map[string]map[string]string) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,131 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxdprofile_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/testcharms"
+)
+
+type ProfileSuite struct{}
+
+var _ = gc.Suite(&ProfileSuite{})
+
+var expectedProfile = &lxdprofile.Profile{
+	Description: "lxd profile for testing",
+	Config: map[string]string{
+		"security.nesting":       "true",
+		"security.privileged":    "true",
+		"linux.kernel_modules":   "openvswitch,nbd,ip_tables,ip6_tables",
+		"environment.http_proxy": "",
+	},
+	Devices: map[string]map[string]string{
+		"tun": {
+			"path": "/dev/net/tun",
+			"type": "unix-char",
+		},
+	},
+}
+
+func (*ProfileSuite) TestParse(c *gc.C) {
+	profile, err := lxdprofile.Parse([]byte(`
+config:
+  security.nesting: "true"
+  security.syscalls.blacklist: "keyctl errno 38"
+devices:
+  gpu:
+    type: gpu
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, jc.DeepEquals, &lxdprofile.Profile{
+		Config: map[string]string{
+			"security.nesting":            "true",
+			"security.syscalls.blacklist": "keyctl errno 38",
+		},
+		Devices: map[string]map[string]string{
+			"gpu": {"type": "gpu"},
+		},
+	})
+	c.Assert(profile.Empty(), jc.IsFalse)
+}
+
+func (*ProfileSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		data   string
+		expect string
+	}{{
+		data:   "config: [",
+		expect: "parsing lxd-profile.yaml: .*",
+	}, {
+		data:   "name: foo",
+		expect: "parsing lxd-profile.yaml: .*field name not found.*",
+	}, {
+		data:   "config:\n  boot.autostart: \"false\"",
+		expect: `LXD profile config key "boot.autostart" not valid`,
+	}, {
+		data:   "config:\n  raw.lxc: lxc.aa_profile=unconfined",
+		expect: `LXD profile config key "raw.lxc" not valid`,
+	}, {
+		data:   "config:\n  environment.: x",
+		expect: `LXD profile config key "environment." not valid`,
+	}, {
+		data:   "devices:\n  eth1:\n    type: nic",
+		expect: `LXD profile device "eth1" of type "nic" not valid`,
+	}, {
+		data:   "devices:\n  root:\n    path: /",
+		expect: `LXD profile device "root" without type not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.data)
+		_, err := lxdprofile.Parse([]byte(test.data))
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (*ProfileSuite) TestEmpty(c *gc.C) {
+	c.Assert(lxdprofile.Profile{Description: "nothing"}.Empty(), jc.IsTrue)
+}
+
+func (*ProfileSuite) TestName(c *gc.C) {
+	c.Assert(lxdprofile.Name("default", "openvswitch", 3), gc.Equals, "juju-default-openvswitch-3")
+}
+
+func (*ProfileSuite) TestReadCharmDir(c *gc.C) {
+	profile, err := lxdprofile.ReadCharm(testcharms.Repo.CharmDir("lxd-profile"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, jc.DeepEquals, expectedProfile)
+}
+
+func (*ProfileSuite) TestReadCharmArchive(c *gc.C) {
+	profile, err := lxdprofile.ReadCharm(testcharms.Repo.CharmArchive(c.MkDir(), "lxd-profile"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, jc.DeepEquals, expectedProfile)
+}
+
+func (*ProfileSuite) TestReadCharmWithoutProfile(c *gc.C) {
+	profile, err := lxdprofile.ReadCharm(testcharms.Repo.CharmDir("dummy"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, gc.IsNil)
+
+	profile, err = lxdprofile.ReadCharm(testcharms.Repo.CharmArchive(c.MkDir(), "dummy"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, gc.IsNil)
+}
+
+func (*ProfileSuite) TestReadCharmInvalid(c *gc.C) {
+	_, err := lxdprofile.ReadCharm(testcharms.Repo.CharmDir("lxd-profile-invalid"))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (*ProfileSuite) TestReadCharmUnsupported(c *gc.C) {
+	var ch charm.Charm = &charm.CharmDir{}
+	ch = struct{ charm.Charm }{ch}
+	profile, err := lxdprofile.ReadCharm(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, gc.IsNil)
+}
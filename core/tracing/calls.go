@@ -0,0 +1,55 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing
+
+import (
+	"context"
+	"sync"
+)
+
+// Calls tracks the calls in flight through a long-lived object, such
+// as a cached API facade, so that work done by the object can be
+// attributed to the call that caused it without the call's context
+// being passed down explicitly.
+//
+// The zero value is ready to use.
+type Calls struct {
+	mu    sync.Mutex
+	next  int
+	calls map[int]context.Context
+}
+
+// Enter records that a call with the given context is in flight, and
+// returns a function that must be called when the call completes.
+// Untraced calls must be entered too, so that work done on their
+// behalf is not attributed to another call.
+func (c *Calls) Enter(ctx context.Context) func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.calls == nil {
+		c.calls = make(map[int]context.Context)
+	}
+	id := c.next
+	c.next++
+	c.calls[id] = ctx
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.calls, id)
+	}
+}
+
+// Context returns the context of the call in flight. If there is
+// not exactly one call in flight, the work cannot be attributed to
+// a call, and Context returns a context that carries no tracer.
+func (c *Calls) Context() context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.calls) == 1 {
+		for _, ctx := range c.calls {
+			return ctx
+		}
+	}
+	return context.Background()
+}
@@ -0,0 +1,319 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/tomb.v1"
+)
+
+var logger = loggo.GetLogger("juju.core.tracing")
+
+const (
+	// DefaultBatchSize is the maximum number of spans sent to an
+	// OTLP endpoint in a single request, if not otherwise specified.
+	DefaultBatchSize = 512
+
+	// DefaultFlushInterval is the longest time a completed span
+	// waits before being sent, if not otherwise specified.
+	DefaultFlushInterval = 5 * time.Second
+
+	// otlpTracesPath is the path, relative to the configured
+	// endpoint, to which OTLP/HTTP trace requests are sent.
+	otlpTracesPath = "/v1/traces"
+)
+
+// OTLPExporterConfig holds the configuration for an OTLPExporter.
+type OTLPExporterConfig struct {
+	// Endpoint is the base URL of the OTLP/HTTP collector, for
+	// example "http://jaeger.example.com:4318". Spans are sent
+	// to the "/v1/traces" path below it.
+	Endpoint string
+
+	// ServiceName is recorded as the "service.name" resource
+	// attribute of all exported spans.
+	ServiceName string
+
+	// Clock is used to time the flushing of batched spans.
+	Clock clock.Clock
+
+	// Client is used to send spans to the endpoint. If nil,
+	// http.DefaultClient is used.
+	Client *http.Client
+
+	// BatchSize is the maximum number of spans sent in each
+	// request. If zero, DefaultBatchSize is used.
+	BatchSize int
+
+	// FlushInterval is the longest time a completed span waits
+	// before being sent. If zero, DefaultFlushInterval is used.
+	FlushInterval time.Duration
+}
+
+// Validate checks that the config is valid.
+func (config OTLPExporterConfig) Validate() error {
+	if config.Endpoint == "" {
+		return errors.NotValidf("empty Endpoint")
+	}
+	if config.ServiceName == "" {
+		return errors.NotValidf("empty ServiceName")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.BatchSize < 0 {
+		return errors.NotValidf("negative BatchSize")
+	}
+	if config.FlushInterval < 0 {
+		return errors.NotValidf("negative FlushInterval")
+	}
+	return nil
+}
+
+// OTLPExporter is an Exporter that sends spans, in batches, to a
+// collector that accepts the OpenTelemetry protocol over HTTP with
+// JSON encoding; recent versions of Jaeger do so natively.
+//
+// Spans are queued, and dropped if the queue is full, so that a slow
+// or unavailable collector never holds up the work being traced.
+type OTLPExporter struct {
+	tomb   tomb.Tomb
+	config OTLPExporterConfig
+	url    string
+	spans  chan SpanData
+}
+
+// NewOTLPExporter returns a new OTLPExporter with the given config.
+// The exporter must be closed when it is no longer needed.
+func NewOTLPExporter(config OTLPExporterConfig) (*OTLPExporter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+	if config.FlushInterval == 0 {
+		config.FlushInterval = DefaultFlushInterval
+	}
+	e := &OTLPExporter{
+		config: config,
+		url:    strings.TrimSuffix(config.Endpoint, "/") + otlpTracesPath,
+		spans:  make(chan SpanData, 4*config.BatchSize),
+	}
+	go func() {
+		defer e.tomb.Done()
+		e.tomb.Kill(e.loop())
+	}()
+	return e, nil
+}
+
+// ExportSpan is part of the Exporter interface.
+func (e *OTLPExporter) ExportSpan(span SpanData) {
+	select {
+	case e.spans <- span:
+	default:
+		logger.Debugf("dropping span %q: queue full", span.Name)
+	}
+}
+
+// Close sends any queued spans, then stops the exporter.
+func (e *OTLPExporter) Close() error {
+	e.tomb.Kill(nil)
+	return e.tomb.Wait()
+}
+
+func (e *OTLPExporter) loop() error {
+	var batch []SpanData
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.send(batch); err != nil {
+			logger.Warningf("cannot send %d spans to %s: %v", len(batch), e.url, err)
+		}
+		batch = nil
+	}
+	add := func(span SpanData) {
+		batch = append(batch, span)
+		if len(batch) == e.config.BatchSize {
+			flush()
+		}
+	}
+	timer := e.config.Clock.NewTimer(e.config.FlushInterval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-e.tomb.Dying():
+			// Send whatever has been queued before stopping.
+			for {
+				select {
+				case span := <-e.spans:
+					add(span)
+				default:
+					flush()
+					return tomb.ErrDying
+				}
+			}
+		case span := <-e.spans:
+			add(span)
+		case <-timer.Chan():
+			flush()
+			timer.Reset(e.config.FlushInterval)
+		}
+	}
+}
+
+func (e *OTLPExporter) send(batch []SpanData) error {
+	body, err := json.Marshal(e.marshalBatch(batch))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := e.config.Client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("unexpected response status %q", resp.Status)
+	}
+	return nil
+}
+
+// The following types describe the subset of the OTLP JSON encoding
+// used by OTLPExporter. Identifiers are hex-encoded, and times are
+// nanoseconds since the epoch, encoded as strings.
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+const (
+	// otlpSpanKindServer is the OTLP span kind for spans that
+	// cover the handling of a remote request.
+	otlpSpanKindServer = 2
+
+	// otlpStatusError is the OTLP status code for failed spans.
+	otlpStatusError = 2
+)
+
+func (e *OTLPExporter) marshalBatch(batch []SpanData) otlpRequest {
+	spans := make([]otlpSpan, len(batch))
+	for i, data := range batch {
+		span := otlpSpan{
+			TraceID:           data.TraceID,
+			SpanID:            data.SpanID,
+			ParentSpanID:      data.ParentID,
+			Name:              data.Name,
+			Kind:              otlpSpanKindServer,
+			StartTimeUnixNano: fmt.Sprint(data.Start.UnixNano()),
+			EndTimeUnixNano:   fmt.Sprint(data.End.UnixNano()),
+		}
+		for key, value := range data.Attributes {
+			span.Attributes = append(span.Attributes, otlpKeyValue{
+				Key:   key,
+				Value: marshalValue(value),
+			})
+		}
+		if data.Error != "" {
+			span.Status = otlpStatus{
+				Code:    otlpStatusError,
+				Message: data.Error,
+			}
+		}
+		spans[i] = span
+	}
+	return otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: []otlpKeyValue{{
+					Key:   "service.name",
+					Value: marshalValue(e.config.ServiceName),
+				}},
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/juju/juju"},
+				Spans: spans,
+			}},
+		}},
+	}
+}
+
+func marshalValue(value interface{}) otlpValue {
+	switch value := value.(type) {
+	case bool:
+		return otlpValue{BoolValue: &value}
+	case int, int32, int64, uint, uint32, uint64:
+		s := fmt.Sprint(value)
+		return otlpValue{IntValue: &s}
+	case float32:
+		f := float64(value)
+		return otlpValue{DoubleValue: &f}
+	case float64:
+		return otlpValue{DoubleValue: &value}
+	case string:
+		return otlpValue{StringValue: &value}
+	default:
+		s := fmt.Sprint(value)
+		return otlpValue{StringValue: &s}
+	}
+}
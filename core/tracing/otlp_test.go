@@ -0,0 +1,192 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/tracing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type otlpSuite struct {
+	testing.IsolationSuite
+	clock    *testing.Clock
+	server   *httptest.Server
+	requests chan map[string]interface{}
+}
+
+var _ = gc.Suite(&otlpSuite{})
+
+func (s *otlpSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(coretesting.NonZeroTime())
+	s.requests = make(chan map[string]interface{}, 10)
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "POST")
+		c.Check(r.URL.Path, gc.Equals, "/v1/traces")
+		c.Check(r.Header.Get("Content-Type"), gc.Equals, "application/json")
+		var body map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&body)
+		c.Check(err, jc.ErrorIsNil)
+		s.requests <- body
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *otlpSuite) newExporter(c *gc.C, batchSize int) *tracing.OTLPExporter {
+	exporter, err := tracing.NewOTLPExporter(tracing.OTLPExporterConfig{
+		Endpoint:      s.server.URL + "/",
+		ServiceName:   "juju-controller",
+		Clock:         s.clock,
+		BatchSize:     batchSize,
+		FlushInterval: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return exporter
+}
+
+func (s *otlpSuite) nextRequest(c *gc.C) map[string]interface{} {
+	select {
+	case body := <-s.requests:
+		return body
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for request")
+	}
+	panic("unreachable")
+}
+
+func (s *otlpSuite) assertNoRequest(c *gc.C) {
+	select {
+	case body := <-s.requests:
+		c.Fatalf("unexpected request: %v", body)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func spanData(name string) tracing.SpanData {
+	start := coretesting.NonZeroTime()
+	return tracing.SpanData{
+		TraceID:  "0123456789abcdef0123456789abcdef",
+		SpanID:   "0123456789abcdef",
+		ParentID: "fedcba9876543210",
+		Name:     name,
+		Start:    start,
+		End:      start.Add(time.Second),
+	}
+}
+
+func requestSpans(c *gc.C, body map[string]interface{}) []interface{} {
+	resourceSpans := body["resourceSpans"].([]interface{})
+	c.Assert(resourceSpans, gc.HasLen, 1)
+	scopeSpans := resourceSpans[0].(map[string]interface{})["scopeSpans"].([]interface{})
+	c.Assert(scopeSpans, gc.HasLen, 1)
+	return scopeSpans[0].(map[string]interface{})["spans"].([]interface{})
+}
+
+func (s *otlpSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		config tracing.OTLPExporterConfig
+		err    string
+	}{{
+		config: tracing.OTLPExporterConfig{ServiceName: "x", Clock: s.clock},
+		err:    "empty Endpoint not valid",
+	}, {
+		config: tracing.OTLPExporterConfig{Endpoint: "x", Clock: s.clock},
+		err:    "empty ServiceName not valid",
+	}, {
+		config: tracing.OTLPExporterConfig{Endpoint: "x", ServiceName: "x"},
+		err:    "nil Clock not valid",
+	}, {
+		config: tracing.OTLPExporterConfig{Endpoint: "x", ServiceName: "x", Clock: s.clock, BatchSize: -1},
+		err:    "negative BatchSize not valid",
+	}} {
+		c.Logf("test %d", i)
+		_, err := tracing.NewOTLPExporter(test.config)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *otlpSuite) TestExportBatch(c *gc.C) {
+	exporter := s.newExporter(c, 2)
+	defer exporter.Close()
+
+	failed := spanData("failed")
+	failed.Attributes = map[string]interface{}{"facade": "Client", "version": 1}
+	failed.Error = "boom"
+	exporter.ExportSpan(spanData("ok"))
+	exporter.ExportSpan(failed)
+
+	body := s.nextRequest(c)
+	resource := body["resourceSpans"].([]interface{})[0].(map[string]interface{})["resource"]
+	c.Assert(resource, jc.DeepEquals, map[string]interface{}{
+		"attributes": []interface{}{
+			map[string]interface{}{
+				"key":   "service.name",
+				"value": map[string]interface{}{"stringValue": "juju-controller"},
+			},
+		},
+	})
+	spans := requestSpans(c, body)
+	c.Assert(spans, gc.HasLen, 2)
+
+	start := coretesting.NonZeroTime()
+	ok := spans[0].(map[string]interface{})
+	c.Assert(ok["name"], gc.Equals, "ok")
+	c.Assert(ok["traceId"], gc.Equals, "0123456789abcdef0123456789abcdef")
+	c.Assert(ok["spanId"], gc.Equals, "0123456789abcdef")
+	c.Assert(ok["parentSpanId"], gc.Equals, "fedcba9876543210")
+	c.Assert(ok["kind"], gc.Equals, float64(2))
+	c.Assert(ok["startTimeUnixNano"], gc.Equals, fmt.Sprint(start.UnixNano()))
+	c.Assert(ok["endTimeUnixNano"], gc.Equals, fmt.Sprint(start.Add(time.Second).UnixNano()))
+	c.Assert(ok["status"], jc.DeepEquals, map[string]interface{}{})
+
+	bad := spans[1].(map[string]interface{})
+	c.Assert(bad["name"], gc.Equals, "failed")
+	c.Assert(bad["status"], jc.DeepEquals, map[string]interface{}{
+		"code":    float64(2),
+		"message": "boom",
+	})
+	c.Assert(bad["attributes"], jc.SameContents, []interface{}{
+		map[string]interface{}{
+			"key":   "facade",
+			"value": map[string]interface{}{"stringValue": "Client"},
+		},
+		map[string]interface{}{
+			"key":   "version",
+			"value": map[string]interface{}{"intValue": "1"},
+		},
+	})
+}
+
+func (s *otlpSuite) TestExportFlushInterval(c *gc.C) {
+	exporter := s.newExporter(c, 10)
+	defer exporter.Close()
+
+	exporter.ExportSpan(spanData("foo"))
+	s.assertNoRequest(c)
+
+	s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	spans := requestSpans(c, s.nextRequest(c))
+	c.Assert(spans, gc.HasLen, 1)
+	c.Assert(spans[0].(map[string]interface{})["name"], gc.Equals, "foo")
+}
+
+func (s *otlpSuite) TestCloseFlushes(c *gc.C) {
+	exporter := s.newExporter(c, 10)
+	exporter.ExportSpan(spanData("foo"))
+	err := exporter.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	spans := requestSpans(c, s.nextRequest(c))
+	c.Assert(spans, gc.HasLen, 1)
+}
@@ -0,0 +1,202 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package tracing provides lightweight distributed tracing for Juju.
+//
+// A Tracer is attached to a context.Context with WithTracer; from then
+// on, code holding that context (or one derived from it) can call
+// Start to record a Span covering some unit of work. Spans started
+// from a context that already carries a span become its children, so
+// the time spent serving an API request can be broken down into the
+// facade, state and provider calls made on its behalf.
+//
+// Tracing is disabled by default: when no Tracer has been attached to
+// a context, Start returns a nil *Span, all of whose methods do
+// nothing.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/juju/utils/clock"
+)
+
+// SpanData holds the information recorded for a completed span.
+type SpanData struct {
+	// TraceID identifies the trace that the span is part of.
+	TraceID string
+
+	// SpanID identifies the span within its trace.
+	SpanID string
+
+	// ParentID identifies the span's parent, if it has one.
+	ParentID string
+
+	// Name describes the operation covered by the span.
+	Name string
+
+	// Start and End hold the times at which the span started
+	// and ended.
+	Start time.Time
+	End   time.Time
+
+	// Attributes holds additional information about the span.
+	Attributes map[string]interface{}
+
+	// Error holds the message of any error recorded for the span.
+	Error string
+}
+
+// Exporter is the interface that must be implemented to receive
+// completed spans from a Tracer. ExportSpan must not block.
+type Exporter interface {
+	ExportSpan(SpanData)
+}
+
+// Tracer creates spans, and passes them to an Exporter when they end.
+type Tracer struct {
+	exporter Exporter
+	clock    clock.Clock
+}
+
+// NewTracer returns a new Tracer that passes completed spans to the
+// given exporter.
+func NewTracer(exporter Exporter, clock clock.Clock) *Tracer {
+	return &Tracer{
+		exporter: exporter,
+		clock:    clock,
+	}
+}
+
+// Close closes the tracer's exporter, if it implements io.Closer.
+func (t *Tracer) Close() error {
+	if closer, ok := t.exporter.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Span records the timing of, and information about, a unit of work.
+// A nil *Span is valid, and does nothing.
+type Span struct {
+	tracer *Tracer
+
+	mu    sync.Mutex
+	data  SpanData
+	ended bool
+}
+
+// SetAttribute records a key/value pair describing the span.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data.Attributes == nil {
+		s.data.Attributes = make(map[string]interface{})
+	}
+	s.data.Attributes[key] = value
+}
+
+// RecordError records that the work covered by the span failed with
+// the given error. A nil error is ignored.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Error = err.Error()
+}
+
+// End marks the span as complete, and exports it. Calls to End after
+// the first have no effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = s.tracer.clock.Now()
+	data := s.data
+	s.mu.Unlock()
+	s.tracer.exporter.ExportSpan(data)
+}
+
+type tracerKey struct{}
+
+type spanKey struct{}
+
+// WithTracer returns a copy of ctx that carries the given tracer.
+// Spans started from the returned context, and those derived from it,
+// are created by the tracer. If tracer is nil, ctx is returned
+// unchanged.
+func WithTracer(ctx context.Context, tracer *Tracer) context.Context {
+	if tracer == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, tracerKey{}, tracer)
+}
+
+// NewContext returns a new context that carries the given tracer, for
+// use by workers that trace the work they do of their own accord,
+// rather than on behalf of a request. If tracer is nil, the context
+// carries no tracer.
+func NewContext(tracer *Tracer) context.Context {
+	return WithTracer(context.Background(), tracer)
+}
+
+// SpanFromContext returns the span carried by ctx, or nil if there
+// is none.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// Start starts a new span with the given name, using the tracer
+// carried by ctx. If ctx carries a span, the new span is its child;
+// otherwise the new span starts a new trace. Start returns a copy of
+// ctx that carries the new span, which the caller must End.
+//
+// If ctx carries no tracer, Start returns ctx and a nil *Span.
+func Start(ctx context.Context, name string) (context.Context, *Span) {
+	tracer, _ := ctx.Value(tracerKey{}).(*Tracer)
+	if tracer == nil {
+		return ctx, nil
+	}
+	span := &Span{
+		tracer: tracer,
+		data: SpanData{
+			SpanID: newID(8),
+			Name:   name,
+			Start:  tracer.clock.Now(),
+		},
+	}
+	if parent := SpanFromContext(ctx); parent != nil {
+		span.data.TraceID = parent.data.TraceID
+		span.data.ParentID = parent.data.SpanID
+	} else {
+		span.data.TraceID = newID(16)
+	}
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// newID returns a random, hex-encoded identifier of n bytes.
+func newID(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand does not fail on supported platforms.
+		panic(err)
+	}
+	return hex.EncodeToString(buf)
+}
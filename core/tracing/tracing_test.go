@@ -0,0 +1,133 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/tracing"
+	"github.com/juju/juju/core/tracing/tracingtest"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type tracingSuite struct {
+	testing.IsolationSuite
+	clock    *testing.Clock
+	exporter *tracingtest.Exporter
+	ctx      context.Context
+}
+
+var _ = gc.Suite(&tracingSuite{})
+
+func (s *tracingSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(coretesting.NonZeroTime())
+	s.exporter = &tracingtest.Exporter{}
+	tracer := tracing.NewTracer(s.exporter, s.clock)
+	s.ctx = tracing.WithTracer(context.Background(), tracer)
+}
+
+func (s *tracingSuite) TestNoTracer(c *gc.C) {
+	ctx := context.Background()
+	newCtx, span := tracing.Start(ctx, "foo")
+	c.Assert(newCtx, gc.Equals, ctx)
+	c.Assert(span, gc.IsNil)
+
+	// All methods on a nil span are no-ops.
+	span.SetAttribute("foo", "bar")
+	span.RecordError(errors.New("boom"))
+	span.End()
+}
+
+func (s *tracingSuite) TestWithNilTracer(c *gc.C) {
+	ctx := context.Background()
+	c.Assert(tracing.WithTracer(ctx, nil), gc.Equals, ctx)
+}
+
+func (s *tracingSuite) TestRootSpan(c *gc.C) {
+	start := s.clock.Now()
+	ctx, span := tracing.Start(s.ctx, "foo")
+	c.Assert(tracing.SpanFromContext(ctx), gc.Equals, span)
+	span.SetAttribute("answer", 42)
+	s.clock.Advance(time.Second)
+	span.End()
+
+	spans := s.exporter.Spans()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Assert(spans[0].TraceID, gc.HasLen, 32)
+	c.Assert(spans[0].SpanID, gc.HasLen, 16)
+	c.Assert(spans[0].ParentID, gc.Equals, "")
+	c.Assert(spans[0].Name, gc.Equals, "foo")
+	c.Assert(spans[0].Start, gc.Equals, start)
+	c.Assert(spans[0].End, gc.Equals, start.Add(time.Second))
+	c.Assert(spans[0].Attributes, jc.DeepEquals, map[string]interface{}{"answer": 42})
+	c.Assert(spans[0].Error, gc.Equals, "")
+}
+
+func (s *tracingSuite) TestChildSpan(c *gc.C) {
+	ctx, parent := tracing.Start(s.ctx, "parent")
+	_, child := tracing.Start(ctx, "child")
+	child.RecordError(errors.New("boom"))
+	child.End()
+	parent.End()
+
+	spans := s.exporter.Spans()
+	c.Assert(spans, gc.HasLen, 2)
+	c.Assert(spans[0].Name, gc.Equals, "child")
+	c.Assert(spans[0].Error, gc.Equals, "boom")
+	c.Assert(spans[1].Name, gc.Equals, "parent")
+	c.Assert(spans[0].TraceID, gc.Equals, spans[1].TraceID)
+	c.Assert(spans[0].ParentID, gc.Equals, spans[1].SpanID)
+	c.Assert(spans[0].SpanID, gc.Not(gc.Equals), spans[1].SpanID)
+}
+
+func (s *tracingSuite) TestEndTwice(c *gc.C) {
+	_, span := tracing.Start(s.ctx, "foo")
+	span.End()
+	span.End()
+	c.Assert(s.exporter.Spans(), gc.HasLen, 1)
+}
+
+func (s *tracingSuite) TestCloseClosesExporter(c *gc.C) {
+	exporter := &closingExporter{}
+	tracer := tracing.NewTracer(exporter, s.clock)
+	err := tracer.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exporter.closed, jc.IsTrue)
+}
+
+type closingExporter struct {
+	tracingtest.Exporter
+	closed bool
+}
+
+func (e *closingExporter) Close() error {
+	e.closed = true
+	return nil
+}
+
+func (s *tracingSuite) TestCalls(c *gc.C) {
+	var calls tracing.Calls
+	c.Assert(tracing.SpanFromContext(calls.Context()), gc.IsNil)
+
+	ctx1, span1 := tracing.Start(s.ctx, "one")
+	leave1 := calls.Enter(ctx1)
+	c.Assert(tracing.SpanFromContext(calls.Context()), gc.Equals, span1)
+
+	// Work done while two calls are in flight is not attributed
+	// to either of them.
+	leave2 := calls.Enter(context.Background())
+	c.Assert(tracing.SpanFromContext(calls.Context()), gc.IsNil)
+
+	leave2()
+	c.Assert(tracing.SpanFromContext(calls.Context()), gc.Equals, span1)
+	leave1()
+	c.Assert(tracing.SpanFromContext(calls.Context()), gc.IsNil)
+}
@@ -0,0 +1,46 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package tracingtest provides helpers for testing code that
+// records tracing spans.
+package tracingtest
+
+import (
+	"sync"
+
+	"github.com/juju/juju/core/tracing"
+)
+
+// Exporter is a tracing.Exporter that records spans in memory.
+type Exporter struct {
+	mu    sync.Mutex
+	spans []tracing.SpanData
+}
+
+// ExportSpan is part of the tracing.Exporter interface.
+func (e *Exporter) ExportSpan(span tracing.SpanData) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, span)
+}
+
+// Spans returns the spans exported so far, in the order in which
+// they ended.
+func (e *Exporter) Spans() []tracing.SpanData {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	spans := make([]tracing.SpanData, len(e.spans))
+	copy(spans, e.spans)
+	return spans
+}
+
+// SpanNames returns the names of the spans exported so far, in the
+// order in which they ended.
+func (e *Exporter) SpanNames() []string {
+	spans := e.Spans()
+	names := make([]string, len(spans))
+	for i, span := range spans {
+		names[i] = span.Name
+	}
+	return names
+}
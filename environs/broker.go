@@ -6,6 +6,7 @@ package environs
 import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/instance"
@@ -79,6 +80,11 @@ type StartInstanceParams struct {
 	// that may be used to start this instance.
 	ImageMetadata []*imagemetadata.ImageMetadata
 
+	// CharmLXDProfiles holds the LXD profiles shipped with the charms
+	// of the units to be deployed to the instance. Brokers that start
+	// LXD containers apply them; other brokers ignore them.
+	CharmLXDProfiles []lxdprofile.NamedProfile
+
 	// CleanupCallback is a callback to be used to clean up any residual
 	// status-reporting output from StatusCallback.
 	CleanupCallback func(info string) error
@@ -149,3 +155,12 @@ type InstanceBroker interface {
 	// correct network configuration.
 	MaintainInstance(args StartInstanceParams) error
 }
+
+// LXDProfiler is implemented by instance brokers that can apply the LXD
+// profiles contributed by charms to running instances.
+type LXDProfiler interface {
+	// ReplaceLXDProfiles removes the named profiles from the instance
+	// and applies the given profiles, writing any that do not yet
+	// exist.
+	ReplaceLXDProfiles(id instance.Id, remove []string, add []lxdprofile.NamedProfile) error
+}
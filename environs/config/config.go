@@ -169,6 +169,15 @@ const (
 	// UpdateStatusHookInterval is how often to run the update-status hook.
 	UpdateStatusHookInterval = "update-status-hook-interval"
 
+	// ReplaceLostMachinesKey determines whether machines whose instance
+	// has disappeared from the cloud are replaced automatically.
+	ReplaceLostMachinesKey = "replace-lost-machines"
+
+	// LostMachineGracePeriodKey is how long a machine's instance must
+	// have been missing from the cloud before the machine is replaced,
+	// eg "15m".
+	LostMachineGracePeriodKey = "lost-machine-grace-period"
+
 	// EgressSubnets are the source addresses from which traffic from this model
 	// originates if the model is deployed such that NAT or similar is in use.
 	EgressSubnets = "egress-subnets"
@@ -176,6 +185,20 @@ const (
 	// FanConfig defines the configuration for FAN network running in the model.
 	FanConfig = "fan-config"
 
+	// KVMStoragePoolKey is the libvirt storage pool in which the root
+	// disks of KVM containers are created.
+	KVMStoragePoolKey = "kvm-storage-pool"
+
+	// KVMMacvtapDevicesKey maps spaces to the host devices on which KVM
+	// container interfaces in those spaces use macvtap devices, eg
+	// "dmz=enp3s0f0".
+	KVMMacvtapDevicesKey = "kvm-macvtap-devices"
+
+	// KVMSRIOVDevicesKey maps spaces to the SR-IOV physical functions
+	// whose virtual functions are passed through to KVM container
+	// interfaces in those spaces, eg "dmz=enp3s0f0".
+	KVMSRIOVDevicesKey = "kvm-sriov-devices"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -339,6 +362,10 @@ const (
 	DefaultActionResultsAge = "336h" // 2 weeks
 
 	DefaultActionResultsSize = "5G"
+
+	// DefaultLostMachineGracePeriod is the default value for
+	// LostMachineGracePeriodKey.
+	DefaultLostMachineGracePeriod = "15m"
 )
 
 var defaultConfigValues = map[string]interface{}{
@@ -382,6 +409,9 @@ var defaultConfigValues = map[string]interface{}{
 	UpdateStatusHookInterval:   DefaultUpdateStatusHookInterval,
 	EgressSubnets:              "",
 	FanConfig:                  "",
+	KVMStoragePoolKey:          "",
+	KVMMacvtapDevicesKey:       "",
+	KVMSRIOVDevicesKey:         "",
 
 	// Image and agent streams and URLs.
 	"image-stream":       "released",
@@ -408,6 +438,10 @@ var defaultConfigValues = map[string]interface{}{
 	MaxStatusHistorySize: DefaultStatusHistorySize,
 	MaxActionResultsAge:  DefaultActionResultsAge,
 	MaxActionResultsSize: DefaultActionResultsSize,
+
+	// Lost machine replacement settings.
+	ReplaceLostMachinesKey:    false,
+	LostMachineGracePeriodKey: DefaultLostMachineGracePeriod,
 }
 
 // ConfigDefaults returns the config default values
@@ -550,6 +584,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[LostMachineGracePeriodKey].(string); ok {
+		if _, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid lost machine grace period in model configuration")
+		}
+	}
+
 	if v, ok := cfg.defined[UpdateStatusHookInterval].(string); ok {
 		if f, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid update status hook interval in model configuration")
@@ -582,6 +622,14 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	for _, key := range []string{KVMMacvtapDevicesKey, KVMSRIOVDevicesKey} {
+		if v, ok := cfg.defined[key].(string); ok && v != "" {
+			if _, err := network.ParseSpaceDevices(v); err != nil {
+				return errors.Annotatef(err, "invalid %s in model configuration", key)
+			}
+		}
+	}
+
 	if v, ok := cfg.defined[ContainerNetworkingMethod].(string); ok {
 		switch v {
 		case "fan":
@@ -1057,6 +1105,24 @@ func (c *Config) MaxActionResultsSizeMB() uint {
 	return uint(val)
 }
 
+// ReplaceLostMachines returns whether machines whose instance has
+// disappeared from the cloud should be replaced automatically.
+func (c *Config) ReplaceLostMachines() bool {
+	val, _ := c.defined[ReplaceLostMachinesKey].(bool)
+	return val
+}
+
+// LostMachineGracePeriod returns how long a machine's instance must have
+// been missing from the cloud before the machine is replaced.
+func (c *Config) LostMachineGracePeriod() time.Duration {
+	// Value has already been validated.
+	val, err := time.ParseDuration(c.asString(LostMachineGracePeriodKey))
+	if err != nil {
+		val, _ = time.ParseDuration(DefaultLostMachineGracePeriod)
+	}
+	return val
+}
+
 // UpdateStatusHookInterval is how often to run the charm
 // update-status hook.
 func (c *Config) UpdateStatusHookInterval() time.Duration {
@@ -1095,6 +1161,25 @@ func (c *Config) FanConfig() (network.FanConfig, error) {
 	return network.ParseFanConfig(c.asString(FanConfig))
 }
 
+// KVMStoragePool returns the libvirt storage pool in which the root disks
+// of KVM containers are created, if one is configured.
+func (c *Config) KVMStoragePool() string {
+	return c.asString(KVMStoragePoolKey)
+}
+
+// KVMMacvtapDevices returns the mapping of spaces to the host devices on
+// which KVM container interfaces in those spaces use macvtap devices.
+func (c *Config) KVMMacvtapDevices() string {
+	return c.asString(KVMMacvtapDevicesKey)
+}
+
+// KVMSRIOVDevices returns the mapping of spaces to the SR-IOV physical
+// functions whose virtual functions are passed through to KVM container
+// interfaces in those spaces.
+func (c *Config) KVMSRIOVDevices() string {
+	return c.asString(KVMSRIOVDevicesKey)
+}
+
 // UnknownAttrs returns a copy of the raw configuration attributes
 // that are supposedly specific to the environment type. They could
 // also be wrong attributes, though. Only the specific environment
@@ -1207,8 +1292,13 @@ var alwaysOptional = schema.Defaults{
 	MaxActionResultsAge:          schema.Omit,
 	MaxActionResultsSize:         schema.Omit,
 	UpdateStatusHookInterval:     schema.Omit,
+	ReplaceLostMachinesKey:       schema.Omit,
+	LostMachineGracePeriodKey:    schema.Omit,
 	EgressSubnets:                schema.Omit,
 	FanConfig:                    schema.Omit,
+	KVMStoragePoolKey:            schema.Omit,
+	KVMMacvtapDevicesKey:         schema.Omit,
+	KVMSRIOVDevicesKey:           schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -1634,6 +1724,16 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	ReplaceLostMachinesKey: {
+		Description: "Determines whether machines whose instance has disappeared from the cloud are replaced automatically",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	LostMachineGracePeriodKey: {
+		Description: "How long a machine's instance must be missing from the cloud before the machine is replaced, in human-readable time format",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	UpdateStatusHookInterval: {
 		Description: "How often to run the charm update-status hook, in human-readable time format (default 5m, range 1-60m)",
 		Type:        environschema.Tstring,
@@ -1649,4 +1749,19 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	KVMStoragePoolKey: {
+		Description: "The libvirt storage pool in which the root disks of KVM containers are created",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	KVMMacvtapDevicesKey: {
+		Description: `Space separated list of space=device pairs; KVM container interfaces in a space use macvtap devices on the host device, eg "dmz=enp3s0f0"`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	KVMSRIOVDevicesKey: {
+		Description: `Space separated list of space=device pairs; KVM container interfaces in a space are given a virtual function of the host's SR-IOV device, eg "dmz=enp3s0f0"`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
@@ -1138,6 +1138,28 @@ func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigValue(c *gc.C) {
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 30*time.Minute)
 }
 
+func (s *ConfigSuite) TestLostMachineConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ReplaceLostMachines(), jc.IsFalse)
+	c.Assert(cfg.LostMachineGracePeriod(), gc.Equals, 15*time.Minute)
+}
+
+func (s *ConfigSuite) TestLostMachineConfigValues(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"replace-lost-machines":     "true",
+		"lost-machine-grace-period": "1h",
+	})
+	c.Assert(cfg.ReplaceLostMachines(), jc.IsTrue)
+	c.Assert(cfg.LostMachineGracePeriod(), gc.Equals, time.Hour)
+}
+
+func (s *ConfigSuite) TestLostMachineGracePeriodInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"lost-machine-grace-period": "soon",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid lost machine grace period in model configuration: .*`)
+}
+
 func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		"egress-subnets": "10.0.0.1/32, 192.168.1.1/16",
@@ -1145,6 +1167,24 @@ func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	c.Assert(cfg.EgressSubnets(), gc.DeepEquals, []string{"10.0.0.1/32", "192.168.1.1/16"})
 }
 
+func (s *ConfigSuite) TestKVMContainerConfig(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"kvm-storage-pool":    "nvme",
+		"kvm-macvtap-devices": "public=eno1",
+		"kvm-sriov-devices":   "dmz=enp3s0f0 storage=enp3s0f1",
+	})
+	c.Assert(cfg.KVMStoragePool(), gc.Equals, "nvme")
+	c.Assert(cfg.KVMMacvtapDevices(), gc.Equals, "public=eno1")
+	c.Assert(cfg.KVMSRIOVDevices(), gc.Equals, "dmz=enp3s0f0 storage=enp3s0f1")
+}
+
+func (s *ConfigSuite) TestKVMSRIOVDevicesInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"kvm-sriov-devices": "dmz",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid kvm-sriov-devices in model configuration: space device entry "dmz" not valid`)
+}
+
 func (s *ConfigSuite) TestSchemaNoExtra(c *gc.C) {
 	schema, err := config.Schema(nil)
 	c.Assert(err, gc.IsNil)
@@ -0,0 +1,140 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instances
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/constraints"
+)
+
+// InstanceTypesFunc returns the instance types, and their costs, that
+// match the supplied constraints. It has the signature of the
+// InstanceTypes method of environs.InstanceTypesFetcher.
+type InstanceTypesFunc func(constraints.Value) (InstanceTypesWithCostMetadata, error)
+
+// CatalogueKey identifies the instance types offered to a credential in
+// a cloud region. Different credentials may be offered different
+// instance types, or charged differently for them.
+type CatalogueKey struct {
+	Cloud      string
+	Region     string
+	Credential string
+}
+
+// Catalogue caches the instance types, with their costs, offered in each
+// cloud region, so that providers need not be queried every time they
+// are reported. All the instance types in a region are fetched at once,
+// and held for the catalogue's lifetime, after which they are fetched
+// again; those matching particular constraints are selected from them.
+// Expired entries are evicted whenever the catalogue is used, and those
+// of a credential are invalidated when it changes. A Catalogue is safe
+// for concurrent use.
+type Catalogue struct {
+	clock    clock.Clock
+	lifetime time.Duration
+
+	mu      sync.Mutex
+	entries map[CatalogueKey]catalogueEntry
+
+	// generation is incremented whenever entries are invalidated,
+	// so that instance types fetched before then are not cached.
+	generation int
+}
+
+type catalogueEntry struct {
+	instanceTypes InstanceTypesWithCostMetadata
+	expires       time.Time
+}
+
+// NewCatalogue returns a new, empty Catalogue that holds instance types
+// for the given lifetime.
+func NewCatalogue(clock clock.Clock, lifetime time.Duration) *Catalogue {
+	return &Catalogue{
+		clock:    clock,
+		lifetime: lifetime,
+		entries:  make(map[CatalogueKey]catalogueEntry),
+	}
+}
+
+// allInstanceTypes are the constraints with which all of a region's
+// instance types are fetched. A memory constraint, even of zero,
+// stops providers preferring instance types with enough memory to run
+// a server.
+var allInstanceTypes = constraints.Value{Mem: new(uint64)}
+
+// InstanceTypes returns the instance types offered in the region
+// identified by key that match the supplied constraints, sorted by
+// increasing cost; the first is the one a machine with those
+// constraints would be given. If the catalogue holds no current
+// instance types for the region, they are obtained by calling fetch.
+// Errors from fetch are returned, and not cached.
+func (c *Catalogue) InstanceTypes(
+	key CatalogueKey,
+	cons constraints.Value,
+	fetch InstanceTypesFunc,
+) (InstanceTypesWithCostMetadata, error) {
+	now := c.clock.Now()
+
+	c.mu.Lock()
+	c.evictExpired(now)
+	entry, ok := c.entries[key]
+	generation := c.generation
+	c.mu.Unlock()
+	if !ok {
+		instanceTypes, err := fetch(allInstanceTypes)
+		if err != nil {
+			return InstanceTypesWithCostMetadata{}, errors.Trace(err)
+		}
+		itypes := make([]InstanceType, len(instanceTypes.InstanceTypes))
+		copy(itypes, instanceTypes.InstanceTypes)
+		instanceTypes.InstanceTypes = itypes
+		entry = catalogueEntry{
+			instanceTypes: instanceTypes,
+			expires:       now.Add(c.lifetime),
+		}
+
+		c.mu.Lock()
+		if c.generation == generation {
+			c.entries[key] = entry
+		}
+		c.mu.Unlock()
+	}
+
+	result := entry.instanceTypes
+	matching, err := MatchingInstanceTypes(result.InstanceTypes, key.Region, cons)
+	if err != nil {
+		return InstanceTypesWithCostMetadata{}, errors.Trace(err)
+	}
+	result.InstanceTypes = matching
+	return result, nil
+}
+
+// Invalidate removes the instance types cached for the given credential
+// in every cloud region, so that they are fetched again, using the
+// credential's new content, when they are next requested.
+func (c *Catalogue) Invalidate(credential string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for key := range c.entries {
+		if key.Credential == credential {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// evictExpired removes the entries that have expired by now. It must be
+// called with c.mu held.
+func (c *Catalogue) evictExpired(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instances
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/testing"
+)
+
+type catalogueSuite struct {
+	testing.BaseSuite
+
+	clock   *jujutesting.Clock
+	fetched []constraints.Value
+	err     error
+}
+
+var _ = gc.Suite(&catalogueSuite{})
+
+var catalogueKey = CatalogueKey{
+	Cloud:      "aws",
+	Region:     "us-east-1",
+	Credential: "aws/bob/default",
+}
+
+func (s *catalogueSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(time.Date(2018, time.March, 1, 10, 0, 0, 0, time.UTC))
+	s.fetched = nil
+	s.err = nil
+}
+
+func (s *catalogueSuite) fetch(cons constraints.Value) (InstanceTypesWithCostMetadata, error) {
+	s.fetched = append(s.fetched, cons)
+	if s.err != nil {
+		return InstanceTypesWithCostMetadata{}, s.err
+	}
+	return InstanceTypesWithCostMetadata{
+		InstanceTypes: []InstanceType{
+			{Name: "m1.large", Arches: []string{"amd64"}, Mem: 7680, Cost: 320},
+			{Name: "m1.unpriced", Arches: []string{"amd64"}, Mem: 1024},
+			{Name: "m1.small", Arches: []string{"amd64"}, Mem: 1740, Cost: 60},
+		},
+		CostUnit:     "$USD/hour",
+		CostCurrency: "USD",
+		CostDivisor:  1000,
+	}, nil
+}
+
+func instanceTypeNames(itypes []InstanceType) []string {
+	names := make([]string, len(itypes))
+	for i, itype := range itypes {
+		names[i] = itype.Name
+	}
+	return names
+}
+
+func (s *catalogueSuite) TestInstanceTypesSortedByCost(c *gc.C) {
+	catalogue := NewCatalogue(s.clock, time.Hour)
+	result, err := catalogue.InstanceTypes(catalogueKey, constraints.Value{}, s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instanceTypeNames(result.InstanceTypes), jc.DeepEquals, []string{
+		"m1.small", "m1.large", "m1.unpriced",
+	})
+	c.Assert(result.CostUnit, gc.Equals, "$USD/hour")
+	c.Assert(result.CostDivisor, gc.Equals, uint64(1000))
+}
+
+func (s *catalogueSuite) TestInstanceTypesCached(c *gc.C) {
+	catalogue := NewCatalogue(s.clock, time.Hour)
+	cons := constraints.MustParse("mem=2G")
+	for i := 0; i < 2; i++ {
+		result, err := catalogue.InstanceTypes(catalogueKey, cons, s.fetch)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(instanceTypeNames(result.InstanceTypes), jc.DeepEquals, []string{"m1.large"})
+	}
+	// All the region's instance types are fetched at once.
+	c.Assert(s.fetched, jc.DeepEquals, []constraints.Value{allInstanceTypes})
+
+	// Different constraints are selected from the same instance types.
+	_, err := catalogue.InstanceTypes(catalogueKey, constraints.Value{}, s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fetched, gc.HasLen, 1)
+
+	// Different regions are cached separately.
+	otherKey := catalogueKey
+	otherKey.Region = "eu-west-1"
+	_, err = catalogue.InstanceTypes(otherKey, cons, s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fetched, gc.HasLen, 2)
+}
+
+func (s *catalogueSuite) TestInstanceTypesNoneMatching(c *gc.C) {
+	catalogue := NewCatalogue(s.clock, time.Hour)
+	_, err := catalogue.InstanceTypes(catalogueKey, constraints.MustParse("mem=64G"), s.fetch)
+	c.Assert(err, gc.ErrorMatches, `no instance types in us-east-1 matching constraints "mem=65536M"`)
+}
+
+func (s *catalogueSuite) TestInstanceTypesExpire(c *gc.C) {
+	catalogue := NewCatalogue(s.clock, time.Hour)
+	_, err := catalogue.InstanceTypes(catalogueKey, constraints.Value{}, s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(time.Hour)
+	_, err = catalogue.InstanceTypes(catalogueKey, constraints.Value{}, s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fetched, gc.HasLen, 2)
+}
+
+func (s *catalogueSuite) TestExpiredInstanceTypesEvicted(c *gc.C) {
+	catalogue := NewCatalogue(s.clock, time.Hour)
+	_, err := catalogue.InstanceTypes(catalogueKey, constraints.Value{}, s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(time.Hour)
+
+	// Looking up another region evicts the expired one.
+	otherKey := catalogueKey
+	otherKey.Region = "eu-west-1"
+	_, err = catalogue.InstanceTypes(otherKey, constraints.Value{}, s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(catalogue.entries, gc.HasLen, 1)
+	_, ok := catalogue.entries[otherKey]
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *catalogueSuite) TestInstanceTypesErrorNotCached(c *gc.C) {
+	catalogue := NewCatalogue(s.clock, time.Hour)
+	s.err = errors.New("boom")
+	_, err := catalogue.InstanceTypes(catalogueKey, constraints.Value{}, s.fetch)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.err = nil
+	_, err = catalogue.InstanceTypes(catalogueKey, constraints.Value{}, s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fetched, gc.HasLen, 2)
+}
+
+func (s *catalogueSuite) TestInvalidate(c *gc.C) {
+	catalogue := NewCatalogue(s.clock, time.Hour)
+	otherKey := catalogueKey
+	otherKey.Credential = "aws/alice/default"
+	for _, key := range []CatalogueKey{catalogueKey, otherKey} {
+		_, err := catalogue.InstanceTypes(key, constraints.Value{}, s.fetch)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	catalogue.Invalidate(catalogueKey.Credential)
+	c.Assert(catalogue.entries, gc.HasLen, 1)
+	_, ok := catalogue.entries[otherKey]
+	c.Assert(ok, jc.IsTrue)
+
+	_, err := catalogue.InstanceTypes(catalogueKey, constraints.Value{}, s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fetched, gc.HasLen, 3)
+}
+
+func (s *catalogueSuite) TestInvalidateDuringFetch(c *gc.C) {
+	catalogue := NewCatalogue(s.clock, time.Hour)
+	fetch := func(cons constraints.Value) (InstanceTypesWithCostMetadata, error) {
+		// The credential changes while its instance types are
+		// being fetched, so they may be out of date.
+		catalogue.Invalidate(catalogueKey.Credential)
+		return s.fetch(cons)
+	}
+	_, err := catalogue.InstanceTypes(catalogueKey, constraints.Value{}, fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(catalogue.entries, gc.HasLen, 0)
+}
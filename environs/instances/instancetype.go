@@ -172,6 +172,11 @@ func (bc byCost) Len() int { return len(bc) }
 func (bc byCost) Less(i, j int) bool {
 	inst0, inst1 := &bc[i], &bc[j]
 	if inst0.Cost != inst1.Cost {
+		// An unknown (zero) cost is not cheaper than a known one; types
+		// we have prices for are preferred.
+		if inst0.Cost == 0 || inst1.Cost == 0 {
+			return inst1.Cost == 0
+		}
 		return inst0.Cost < inst1.Cost
 	}
 	if inst0.Mem != inst1.Mem {
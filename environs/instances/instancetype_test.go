@@ -332,6 +332,15 @@ var byCostTests = []struct {
 		expectedItypes: []string{
 			"it-2", "it-1",
 		},
+	}, {
+		about: "prefer known cost over unknown cost",
+		itypesToUse: []InstanceType{
+			{Id: "2", Name: "it-2", CpuCores: 1, Mem: 2048},
+			{Id: "1", Name: "it-1", CpuCores: 2, Mem: 4096, Cost: 241},
+		},
+		expectedItypes: []string{
+			"it-1", "it-2",
+		},
 	}, {
 		about: "when no cost associated, pick lowest ram",
 		itypesToUse: []InstanceType{
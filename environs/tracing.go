@@ -0,0 +1,96 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"context"
+
+	"github.com/juju/juju/core/tracing"
+	"github.com/juju/juju/instance"
+)
+
+// NewTracingEnviron returns an Environ that records calls to the
+// instance management methods of env as spans of the trace carried
+// by ctx, if any. All other methods are passed straight through.
+//
+// The returned Environ implements the Environ interface, and
+// LXDProfiler if env does, but no other optional interfaces; callers
+// must not use it to check for optional provider features, such as
+// Networking.
+func NewTracingEnviron(ctx context.Context, env Environ) Environ {
+	traced := &tracingEnviron{Environ: env, ctx: ctx}
+	if profiler, ok := env.(LXDProfiler); ok {
+		return &tracingLXDProfilerEnviron{
+			tracingEnviron: traced,
+			LXDProfiler:    profiler,
+		}
+	}
+	return traced
+}
+
+type tracingEnviron struct {
+	Environ
+	ctx context.Context
+}
+
+// tracingLXDProfilerEnviron is a tracingEnviron for an Environ that
+// implements LXDProfiler, which the provisioner relies on.
+type tracingLXDProfilerEnviron struct {
+	*tracingEnviron
+	LXDProfiler
+}
+
+// StartInstance is part of the InstanceBroker interface.
+func (e *tracingEnviron) StartInstance(args StartInstanceParams) (*StartInstanceResult, error) {
+	_, span := tracing.Start(e.ctx, "environs.StartInstance")
+	if args.InstanceConfig != nil {
+		span.SetAttribute("machine-id", args.InstanceConfig.MachineId)
+	}
+	result, err := e.Environ.StartInstance(args)
+	if err == nil {
+		span.SetAttribute("instance-id", string(result.Instance.Id()))
+	}
+	span.RecordError(err)
+	span.End()
+	return result, err
+}
+
+// StopInstances is part of the InstanceBroker interface.
+func (e *tracingEnviron) StopInstances(ids ...instance.Id) error {
+	_, span := tracing.Start(e.ctx, "environs.StopInstances")
+	span.SetAttribute("instances", len(ids))
+	err := e.Environ.StopInstances(ids...)
+	span.RecordError(err)
+	span.End()
+	return err
+}
+
+// Destroy is part of the Environ interface.
+func (e *tracingEnviron) Destroy() error {
+	_, span := tracing.Start(e.ctx, "environs.Destroy")
+	err := e.Environ.Destroy()
+	span.RecordError(err)
+	span.End()
+	return err
+}
+
+// AllInstances is part of the InstanceBroker interface.
+func (e *tracingEnviron) AllInstances() ([]instance.Instance, error) {
+	_, span := tracing.Start(e.ctx, "environs.AllInstances")
+	instances, err := e.Environ.AllInstances()
+	span.SetAttribute("instances", len(instances))
+	span.RecordError(err)
+	span.End()
+	return instances, err
+}
+
+// Instances is part of the Environ interface.
+func (e *tracingEnviron) Instances(ids []instance.Id) ([]instance.Instance, error) {
+	_, span := tracing.Start(e.ctx, "environs.Instances")
+	span.SetAttribute("instances", len(ids))
+	instances, err := e.Environ.Instances(ids)
+	span.RecordError(err)
+	span.End()
+	return instances, err
+}
@@ -0,0 +1,108 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/core/tracing"
+	"github.com/juju/juju/core/tracing/tracingtest"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+type tracingEnvironSuite struct {
+	testing.IsolationSuite
+	exporter *tracingtest.Exporter
+	env      environs.Environ
+	inner    *instancesEnviron
+}
+
+var _ = gc.Suite(&tracingEnvironSuite{})
+
+func (s *tracingEnvironSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.exporter = &tracingtest.Exporter{}
+	ctx := tracing.WithTracer(context.Background(), tracing.NewTracer(s.exporter, clock.WallClock))
+	s.inner = &instancesEnviron{}
+	s.env = environs.NewTracingEnviron(ctx, s.inner)
+}
+
+func (s *tracingEnvironSuite) TestAllInstances(c *gc.C) {
+	_, err := s.env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	s.inner.CheckCallNames(c, "AllInstances")
+
+	spans := s.exporter.Spans()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Assert(spans[0].Name, gc.Equals, "environs.AllInstances")
+	c.Assert(spans[0].Error, gc.Equals, "")
+}
+
+func (s *tracingEnvironSuite) TestStopInstancesError(c *gc.C) {
+	s.inner.SetErrors(errors.New("nope"))
+	err := s.env.StopInstances("i-0", "i-1")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	s.inner.CheckCall(c, 0, "StopInstances", []instance.Id{"i-0", "i-1"})
+
+	spans := s.exporter.Spans()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Assert(spans[0].Name, gc.Equals, "environs.StopInstances")
+	c.Assert(spans[0].Attributes["instances"], gc.Equals, 2)
+	c.Assert(spans[0].Error, gc.Equals, "nope")
+}
+
+func (s *tracingEnvironSuite) TestLXDProfiler(c *gc.C) {
+	_, ok := s.env.(environs.LXDProfiler)
+	c.Assert(ok, jc.IsFalse)
+
+	env := environs.NewTracingEnviron(context.Background(), &profilerEnviron{s.inner})
+	profiler, ok := env.(environs.LXDProfiler)
+	c.Assert(ok, jc.IsTrue)
+	err := profiler.ReplaceLXDProfiles("i-0", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.inner.CheckCallNames(c, "ReplaceLXDProfiles")
+}
+
+func (s *tracingEnvironSuite) TestOtherMethodsPassedThrough(c *gc.C) {
+	c.Assert(s.env.Provider(), gc.IsNil)
+	s.inner.CheckCallNames(c, "Provider")
+	c.Assert(s.exporter.Spans(), gc.HasLen, 0)
+}
+
+type instancesEnviron struct {
+	environs.Environ
+	testing.Stub
+}
+
+func (e *instancesEnviron) AllInstances() ([]instance.Instance, error) {
+	e.MethodCall(e, "AllInstances")
+	return nil, e.NextErr()
+}
+
+func (e *instancesEnviron) StopInstances(ids ...instance.Id) error {
+	e.MethodCall(e, "StopInstances", ids)
+	return e.NextErr()
+}
+
+func (e *instancesEnviron) Provider() environs.EnvironProvider {
+	e.MethodCall(e, "Provider")
+	return nil
+}
+
+type profilerEnviron struct {
+	*instancesEnviron
+}
+
+func (e *profilerEnviron) ReplaceLXDProfiles(id instance.Id, remove []string, add []lxdprofile.NamedProfile) error {
+	e.MethodCall(e, "ReplaceLXDProfiles", id, remove, add)
+	return e.NextErr()
+}
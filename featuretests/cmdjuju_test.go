@@ -111,6 +111,12 @@ func (s *cmdJujuSuite) TestApplicationUnset(c *gc.C) {
 
 func (s *cmdJujuSuite) TestApplicationGetIAASModel(c *gc.C) {
 	expected := `application: dummy-application
+application-config:
+  replace-lost-machines:
+    description: Determines whether machines hosting the application's units are replaced
+      when their instance disappears from the cloud (defaults to the model setting)
+    source: unset
+    type: bool
 charm: dummy
 settings:
   outlook:
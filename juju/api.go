@@ -10,8 +10,10 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/apitokens"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/network"
 )
@@ -185,6 +187,16 @@ func connectionInfo(args NewAPIConnectionParams) (*api.Info, *jujuclient.Control
 			apiInfo.Tag = userTag
 		}
 	}
+	if account.APIToken != "" {
+		// An API token takes the place of a password; it is
+		// presented as a macaroon at login.
+		ms, err := apitokens.DecodeToken(account.APIToken)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		apiInfo.Macaroons = []macaroon.Slice{ms}
+		return apiInfo, controller, nil
+	}
 	if args.AccountDetails.Password != "" {
 		// If a password is available, we always use that.
 		// If no password is recorded, we'll attempt to
@@ -14,8 +14,10 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/apitokens"
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/params"
 	sstesting "github.com/juju/juju/environs/simplestreams/testing"
@@ -136,6 +138,36 @@ func (s *NewAPIClientSuite) TestUpdatesLastKnownAccess(c *gc.C) {
 	)
 }
 
+func (s *NewAPIClientSuite) TestWithAPIToken(c *gc.C) {
+	m, err := macaroon.New([]byte("root-key"), "token-id", "juju")
+	c.Assert(err, jc.ErrorIsNil)
+	token, err := apitokens.EncodeToken(macaroon.Slice{m})
+	c.Assert(err, jc.ErrorIsNil)
+
+	store := newClientStore(c, "noconfig")
+	err = store.UpdateAccount("noconfig", jujuclient.AccountDetails{
+		User:     "admin",
+		APIToken: token,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	called := 0
+	expectState := mockedAPIState(mockedHostPort | mockedModelTag)
+	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
+		c.Check(apiInfo.Tag, gc.Equals, names.NewUserTag("admin"))
+		c.Check(apiInfo.Password, gc.Equals, "")
+		c.Assert(apiInfo.Macaroons, gc.HasLen, 1)
+		c.Assert(apiInfo.Macaroons[0], gc.HasLen, 1)
+		c.Check(apiInfo.Macaroons[0][0].Id(), gc.Equals, "token-id")
+		called++
+		return expectState, nil
+	}
+	st, err := newAPIConnectionFromNames(c, "noconfig", "admin/admin", store, apiOpen)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, gc.Equals, expectState)
+	c.Assert(called, gc.Equals, 1)
+}
+
 func (s *NewAPIClientSuite) TestUpdatesPublicDNSName(c *gc.C) {
 	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
 		conn := mockedAPIState(noFlags)
@@ -80,6 +80,10 @@ type AccountDetails struct {
 
 	// LastKnownAccess is the last known access level for the account.
 	LastKnownAccess string `yaml:"last-known-access,omitempty"`
+
+	// APIToken is an encoded API token, issued by the controller,
+	// to log in with instead of a password.
+	APIToken string `yaml:"api-token,omitempty"`
 }
 
 // BootstrapConfig holds the configuration used to bootstrap a controller.
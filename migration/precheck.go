@@ -5,6 +5,7 @@ package migration
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/version"
@@ -35,6 +36,7 @@ type PrecheckBackend interface {
 	ControllerBackend() (PrecheckBackend, error)
 	CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	UnmigratableFeatures() ([]string, error)
 }
 
 // Pool defines the interface to a StatePool used by the migration
@@ -162,6 +164,11 @@ func checkModel(backend PrecheckBackend) error {
 			return errors.New("model has revoked credentials")
 		}
 	}
+	if features, err := backend.UnmigratableFeatures(); err != nil {
+		return errors.Annotate(err, "checking for unmigratable features")
+	} else if len(features) > 0 {
+		return errors.Errorf("model uses features that cannot be migrated: %s", strings.Join(features, ", "))
+	}
 	return nil
 }
 
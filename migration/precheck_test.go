@@ -93,6 +93,20 @@ func (*SourcePrecheckSuite) TestImportingModel(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "model is being imported as part of another migration")
 }
 
+func (*SourcePrecheckSuite) TestUnmigratableFeaturesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.unmigratableFeaturesErr = errors.New("boom")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking for unmigratable features: boom")
+}
+
+func (*SourcePrecheckSuite) TestUnmigratableFeatures(c *gc.C) {
+	backend := newFakeBackend()
+	backend.unmigratableFeatures = []string{"foo", "bar"}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model uses features that cannot be migrated: foo, bar")
+}
+
 func (*SourcePrecheckSuite) TestCleanupsError(c *gc.C) {
 	backend := newFakeBackend()
 	backend.cleanupErr = errors.New("boom")
@@ -740,6 +754,9 @@ type fakeBackend struct {
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	unmigratableFeatures    []string
+	unmigratableFeaturesErr error
+
 	controllerBackend *fakeBackend
 }
 
@@ -787,6 +804,10 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) UnmigratableFeatures() ([]string, error) {
+	return b.unmigratableFeatures, b.unmigratableFeaturesErr
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackend, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -195,6 +195,10 @@ type InterfaceInfo struct {
 	// known and supported.
 	ProviderSpaceId Id
 
+	// SpaceName is the name of the Juju space the interface is in, if
+	// known.
+	SpaceName string
+
 	// ProviderVLANId is the provider-specific id of the VLAN for this
 	// interface.
 	ProviderVLANId Id
@@ -0,0 +1,34 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// SpaceDevices maps space names to the names of host network devices.
+type SpaceDevices map[string]string
+
+// ParseSpaceDevices parses a mapping of spaces to host devices from
+// model-config in the format: "space1=device1 space2=device2"
+// eg. "dmz=enp3s0f0 storage=enp4s0f1".
+func ParseSpaceDevices(line string) (SpaceDevices, error) {
+	if strings.TrimSpace(line) == "" {
+		return nil, nil
+	}
+	devices := make(SpaceDevices)
+	for _, entry := range strings.Fields(line) {
+		parts := strings.Split(entry, "=")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.NotValidf("space device entry %q", entry)
+		}
+		if _, ok := devices[parts[0]]; ok {
+			return nil, errors.NotValidf("duplicate space %q in space devices", parts[0])
+		}
+		devices[parts[0]] = parts[1]
+	}
+	return devices, nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type SpaceDevicesSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&SpaceDevicesSuite{})
+
+func (*SpaceDevicesSuite) TestParseSpaceDevicesEmpty(c *gc.C) {
+	devices, err := network.ParseSpaceDevices(" ")
+	c.Check(devices, gc.IsNil)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (*SpaceDevicesSuite) TestParseSpaceDevices(c *gc.C) {
+	devices, err := network.ParseSpaceDevices("dmz=enp3s0f0  storage=enp4s0f1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(devices, jc.DeepEquals, network.SpaceDevices{
+		"dmz":     "enp3s0f0",
+		"storage": "enp4s0f1",
+	})
+}
+
+func (*SpaceDevicesSuite) TestParseSpaceDevicesInvalid(c *gc.C) {
+	for _, line := range []string{"dmz", "dmz=", "=eth0", "dmz=eth0=eth1"} {
+		_, err := network.ParseSpaceDevices(line)
+		c.Check(err, gc.ErrorMatches, `space device entry ".*" not valid`)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (*SpaceDevicesSuite) TestParseSpaceDevicesDuplicate(c *gc.C) {
+	_, err := network.ParseSpaceDevices("dmz=eth0 dmz=eth1")
+	c.Check(err, gc.ErrorMatches, `duplicate space "dmz" in space devices not valid`)
+}
@@ -176,6 +176,17 @@ func (s *environPolSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	c.Check(unsupported, jc.SameContents, []string{"tags", "virt-type"})
 }
 
+func (s *environPolSuite) TestConstraintsValidatorZones(c *gc.C) {
+	validator, err := s.Env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("zones=home-zone")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(unsupported, jc.SameContents, []string{"zones"})
+}
+
 func (s *environPolSuite) TestConstraintsValidatorVocabInstType(c *gc.C) {
 	validator, err := s.Env.ConstraintsValidator()
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,189 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+// lxdAvailZone represents a member of an LXD cluster. Each cluster
+// member is exposed as an availability zone.
+type lxdAvailZone struct {
+	member lxdclient.ClusterMember
+}
+
+// Name implements common.AvailabilityZone.
+func (z *lxdAvailZone) Name() string {
+	return z.member.Name
+}
+
+// Available implements common.AvailabilityZone.
+func (z *lxdAvailZone) Available() bool {
+	return z.member.Online()
+}
+
+// AvailabilityZones is part of the common.ZonedEnviron interface. Each
+// member of an LXD cluster is an availability zone; a standalone LXD
+// host has no availability zones.
+func (env *environ) AvailabilityZones() ([]common.AvailabilityZone, error) {
+	if !env.raw.IsClustered() {
+		return nil, nil
+	}
+	members, err := env.raw.ClusterMembers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	zones := make([]common.AvailabilityZone, len(members))
+	for i, member := range members {
+		zones[i] = &lxdAvailZone{member}
+	}
+	return zones, nil
+}
+
+// InstanceAvailabilityZoneNames is part of the common.ZonedEnviron
+// interface. The error returned follows the same rules as
+// Environ.Instances.
+func (env *environ) InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, error) {
+	instances, err := env.Instances(ids)
+	if err != nil && err != environs.ErrPartialInstances {
+		// We let the two environs errors pass on through. However,
+		// we do not use errors.Trace in that case since callers
+		// may not call errors.Cause.
+		if err == environs.ErrNoInstances {
+			return nil, err
+		}
+		return nil, errors.Trace(err)
+	}
+
+	results := make([]string, len(ids))
+	if !env.raw.IsClustered() {
+		return results, err
+	}
+	locations, lerr := env.raw.InstanceLocations(env.namespace.Prefix())
+	if lerr != nil {
+		return nil, errors.Trace(lerr)
+	}
+	for i, inst := range instances {
+		if inst != nil {
+			results[i] = locations[string(inst.Id())]
+		}
+	}
+	return results, err
+}
+
+// DeriveAvailabilityZones is part of the common.ZonedEnviron interface.
+// A "zone=" placement directive takes precedence over any zones
+// constraint.
+func (env *environ) DeriveAvailabilityZones(args environs.StartInstanceParams) ([]string, error) {
+	placement, err := env.parsePlacement(args.Placement)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if placement.zone != "" {
+		return []string{placement.zone}, nil
+	}
+	if args.Constraints.HasZones() {
+		return *args.Constraints.Zones, nil
+	}
+	return nil, nil
+}
+
+// DistributeInstances implements the state.InstanceDistributor policy.
+func (env *environ) DistributeInstances(candidates, distributionGroup []instance.Id) ([]instance.Id, error) {
+	return common.DistributeInstances(env, candidates, distributionGroup)
+}
+
+// availZone returns the cluster member with the given name.
+func (env *environ) availZone(name string) (common.AvailabilityZone, error) {
+	zones, err := env.AvailabilityZones()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, z := range zones {
+		if z.Name() == name {
+			return z, nil
+		}
+	}
+	return nil, errors.NotFoundf("availability zone %q", name)
+}
+
+// availZoneUp returns the cluster member with the given name,
+// returning an error if the member is not online.
+func (env *environ) availZoneUp(name string) (common.AvailabilityZone, error) {
+	zone, err := env.availZone(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !zone.Available() {
+		return nil, errors.Errorf("availability zone %q is unavailable", name)
+	}
+	return zone, nil
+}
+
+// validateZonesConstraint checks that every zone named in the zones
+// constraint is a member of the cluster.
+func (env *environ) validateZonesConstraint(cons constraints.Value) error {
+	if !cons.HasZones() {
+		return nil
+	}
+	if !env.raw.IsClustered() {
+		return errors.NotSupportedf("zones constraint on a standalone LXD host")
+	}
+	for _, name := range *cons.Zones {
+		if _, err := env.availZone(name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// instanceZone returns the cluster member on which to start an
+// instance, taking into account the placement directive, the zones
+// constraint and the zone chosen by the provisioner.
+func (env *environ) instanceZone(args environs.StartInstanceParams) (string, error) {
+	placement, err := env.parsePlacement(args.Placement)
+	if err != nil {
+		return "", common.ZoneIndependentError(err)
+	}
+	if err := env.validateZonesConstraint(args.Constraints); err != nil {
+		return "", common.ZoneIndependentError(err)
+	}
+	zone := args.AvailabilityZone
+	if placement.zone != "" {
+		zone = placement.zone
+	}
+	if zone == "" {
+		if !args.Constraints.HasZones() {
+			return "", nil
+		}
+		zone = (*args.Constraints.Zones)[0]
+	}
+	if args.Constraints.HasZones() && !zoneIn(zone, *args.Constraints.Zones) {
+		return "", errors.Errorf(
+			"availability zone %q does not satisfy zones constraint %q",
+			zone, *args.Constraints.Zones,
+		)
+	}
+	if _, err := env.availZoneUp(zone); err != nil {
+		return "", errors.Trace(err)
+	}
+	return zone, nil
+}
+
+func zoneIn(zone string, zones []string) bool {
+	for _, z := range zones {
+		if z == zone {
+			return true
+		}
+	}
+	return false
+}
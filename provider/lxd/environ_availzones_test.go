@@ -0,0 +1,132 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/lxd"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+type environAvailzonesSuite struct {
+	lxd.BaseSuite
+}
+
+var _ = gc.Suite(&environAvailzonesSuite{})
+
+func (s *environAvailzonesSuite) zonedEnviron(c *gc.C) common.ZonedEnviron {
+	zonedEnv, ok := environs.Environ(s.Env).(common.ZonedEnviron)
+	c.Assert(ok, jc.IsTrue)
+	return zonedEnv
+}
+
+func (s *environAvailzonesSuite) TestAvailabilityZonesStandalone(c *gc.C) {
+	zones, err := s.zonedEnviron(c).AvailabilityZones()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.HasLen, 0)
+	s.CheckNoAPI(c)
+}
+
+func (s *environAvailzonesSuite) TestAvailabilityZones(c *gc.C) {
+	s.UseCluster()
+	zones, err := s.zonedEnviron(c).AvailabilityZones()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.HasLen, 3)
+	var names []string
+	var available []bool
+	for _, zone := range zones {
+		names = append(names, zone.Name())
+		available = append(available, zone.Available())
+	}
+	c.Assert(names, jc.DeepEquals, []string{"node1", "node2", "node3"})
+	c.Assert(available, jc.DeepEquals, []bool{true, false, true})
+}
+
+func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNames(c *gc.C) {
+	s.UseCluster()
+	s.Client.Insts = []lxdclient.Instance{
+		*s.NewRawInstance(c, "spam"),
+		*s.NewRawInstance(c, "eggs"),
+	}
+	s.Client.Locations = map[string]string{
+		"spam": "node1",
+		"eggs": "node3",
+	}
+	names, err := s.zonedEnviron(c).InstanceAvailabilityZoneNames([]instance.Id{"eggs", "ham", "spam"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(names, jc.DeepEquals, []string{"node3", "", "node1"})
+	s.Stub.CheckCallNames(c, "Instances", "InstanceLocations")
+	s.Stub.CheckCall(c, 1, "InstanceLocations", s.Prefix())
+}
+
+func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNamesStandalone(c *gc.C) {
+	s.Client.Insts = []lxdclient.Instance{*s.NewRawInstance(c, "spam")}
+	names, err := s.zonedEnviron(c).InstanceAvailabilityZoneNames([]instance.Id{"spam"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{""})
+	s.Stub.CheckCallNames(c, "Instances")
+}
+
+func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNamesNoInstances(c *gc.C) {
+	s.UseCluster()
+	_, err := s.zonedEnviron(c).InstanceAvailabilityZoneNames([]instance.Id{"spam"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZones(c *gc.C) {
+	s.UseCluster()
+	zones, err := s.zonedEnviron(c).DeriveAvailabilityZones(environs.StartInstanceParams{
+		Placement: "zone=node3",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, jc.DeepEquals, []string{"node3"})
+}
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZonesFromConstraints(c *gc.C) {
+	s.UseCluster()
+	zones, err := s.zonedEnviron(c).DeriveAvailabilityZones(environs.StartInstanceParams{
+		Constraints: constraints.MustParse("zones=node1,node3"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, jc.DeepEquals, []string{"node1", "node3"})
+}
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZonesNone(c *gc.C) {
+	zones, err := s.zonedEnviron(c).DeriveAvailabilityZones(environs.StartInstanceParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.HasLen, 0)
+}
+
+func (s *environAvailzonesSuite) TestAvailabilityZoneAllocations(c *gc.C) {
+	s.UseCluster()
+	s.Client.Insts = []lxdclient.Instance{
+		*s.NewRawInstance(c, "spam"),
+		*s.NewRawInstance(c, "eggs"),
+		*s.NewRawInstance(c, "ham"),
+	}
+	s.Client.Locations = map[string]string{
+		"spam": "node1",
+		"eggs": "node1",
+		"ham":  "node3",
+	}
+	allocations, err := common.AvailabilityZoneAllocations(s.zonedEnviron(c), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	// node2 is offline, so it is not considered; node3 is the least
+	// populated member, so new instances are spread there first.
+	c.Assert(allocations, jc.DeepEquals, []common.AvailabilityZoneInstances{{
+		ZoneName:  "node3",
+		Instances: []instance.Id{"ham"},
+	}, {
+		ZoneName:  "node1",
+		Instances: []instance.Id{"spam", "eggs"},
+	}})
+}
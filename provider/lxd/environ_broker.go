@@ -41,14 +41,23 @@ func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.
 
 	// TODO(ericsnow) Handle constraints?
 
-	raw, err := env.newRawInstance(args, arch)
+	zone, err := env.instanceZone(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	raw, err := env.newRawInstance(args, arch, zone)
 	if err != nil {
 		if args.StatusCallback != nil {
 			args.StatusCallback(status.ProvisioningError, err.Error(), nil)
 		}
 		return nil, errors.Trace(err)
 	}
-	logger.Infof("started instance %q", raw.Name)
+	if zone != "" {
+		logger.Infof("started instance %q on cluster member %q", raw.Name, zone)
+	} else {
+		logger.Infof("started instance %q", raw.Name)
+	}
 	inst := newInstance(raw, env)
 
 	// Build the result.
@@ -118,11 +127,13 @@ func (env *environ) getImageSources() ([]lxdclient.Remote, error) {
 }
 
 // newRawInstance is where the new physical instance is actually
-// provisioned, relative to the provided args and spec. Info for that
-// low-level instance is returned.
+// provisioned, relative to the provided args and spec. If zone is
+// not empty, the instance is created on the named cluster member.
+// Info for that low-level instance is returned.
 func (env *environ) newRawInstance(
 	args environs.StartInstanceParams,
 	arch string,
+	zone string,
 ) (*lxdclient.Instance, error) {
 	hostname, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
 	if err != nil {
@@ -181,6 +192,11 @@ func (env *environ) newRawInstance(
 		return nil, errors.Trace(err)
 	}
 
+	charmProfiles, err := env.writeCharmProfiles(args.CharmLXDProfiles)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	// TODO(ericsnow) Use the env ID for the network name (instead of default)?
 	// TODO(ericsnow) Make the network name configurable?
 	// TODO(ericsnow) Support multiple networks?
@@ -192,15 +208,16 @@ func (env *environ) newRawInstance(
 		//Disks:             getDisks(spec, args.Constraints),
 		//NetworkInterfaces: []string{"ExternalNAT"},
 		Metadata: metadata,
-		Profiles: []string{
+		Profiles: append([]string{
 			//TODO(wwitzel3) allow the user to specify lxc profiles to apply. This allows the
 			// user to setup any custom devices order config settings for their environment.
 			// Also we must ensure that a device with the parent: lxcbr0 exists in at least
 			// one of the profiles.
 			"default",
 			env.profileName(),
-		},
+		}, charmProfiles...),
 		// Network is omitted (left empty).
+		Target: zone,
 	}
 
 	logger.Infof("starting instance %q (image %q)...", instSpec.Name, instSpec.Image)
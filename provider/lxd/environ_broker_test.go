@@ -11,7 +11,11 @@ import (
 	"github.com/juju/utils/arch"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/provider/lxd"
+	"github.com/juju/juju/tools/lxdclient"
 )
 
 type environBrokerSuite struct {
@@ -40,6 +44,65 @@ func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
 	s.Stub.CheckCall(c, 0, "EnsureImageExists", "trusty", "arm64")
 }
 
+func (s *environBrokerSuite) TestStartInstanceAvailabilityZone(c *gc.C) {
+	s.UseCluster()
+	s.Client.Inst = s.RawInstance
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	s.StartInstArgs.AvailabilityZone = "node3"
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCallNames(c, "ClusterMembers", "EnsureImageExists", "AddInstance")
+	spec := s.Stub.Calls()[2].Args[0].(lxdclient.InstanceSpec)
+	c.Assert(spec.Target, gc.Equals, "node3")
+}
+
+func (s *environBrokerSuite) TestStartInstancePlacementZone(c *gc.C) {
+	s.UseCluster()
+	s.Client.Inst = s.RawInstance
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	s.StartInstArgs.AvailabilityZone = "node3"
+	s.StartInstArgs.Placement = "zone=node1"
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	spec := s.Stub.Calls()[len(s.Stub.Calls())-1].Args[0].(lxdclient.InstanceSpec)
+	c.Assert(spec.Target, gc.Equals, "node1")
+}
+
+func (s *environBrokerSuite) TestStartInstanceZoneNotInConstraints(c *gc.C) {
+	s.UseCluster()
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	s.StartInstArgs.AvailabilityZone = "node3"
+	s.StartInstArgs.Constraints = constraints.MustParse("zones=node1")
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, gc.ErrorMatches, `availability zone "node3" does not satisfy zones constraint \["node1"\]`)
+	// The provisioner should try another zone.
+	c.Assert(environs.IsAvailabilityZoneIndependent(err), jc.IsFalse)
+}
+
+func (s *environBrokerSuite) TestStartInstanceZoneOffline(c *gc.C) {
+	s.UseCluster()
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	s.StartInstArgs.AvailabilityZone = "node2"
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, gc.ErrorMatches, `availability zone "node2" is unavailable`)
+	c.Assert(environs.IsAvailabilityZoneIndependent(err), jc.IsFalse)
+}
+
+func (s *environBrokerSuite) TestStartInstanceZonesConstraintStandalone(c *gc.C) {
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	s.StartInstArgs.Constraints = constraints.MustParse("zones=node1")
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, gc.ErrorMatches, `zones constraint on a standalone LXD host not supported`)
+	c.Assert(environs.IsAvailabilityZoneIndependent(err), jc.IsTrue)
+}
+
 func (s *environBrokerSuite) TestStartInstanceNoTools(c *gc.C) {
 	s.Client.Inst = s.RawInstance
 
@@ -50,6 +113,60 @@ func (s *environBrokerSuite) TestStartInstanceNoTools(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "no matching agent binaries available")
 }
 
+func (s *environBrokerSuite) TestStartInstanceCharmLXDProfiles(c *gc.C) {
+	s.Client.Inst = s.RawInstance
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	profile := lxdprofile.Profile{
+		Config: map[string]string{"security.nesting": "true"},
+	}
+	s.StartInstArgs.CharmLXDProfiles = []lxdprofile.NamedProfile{{
+		Name:    "juju-model-app-1",
+		Profile: profile,
+	}}
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCallNames(c, "EnsureImageExists", "EnsureProfile", "AddInstance")
+	s.Stub.CheckCall(c, 1, "EnsureProfile", "juju-model-app-1", "", profile.Config, map[string]map[string]string(nil))
+	spec := s.Stub.Calls()[2].Args[0].(lxdclient.InstanceSpec)
+	c.Assert(spec.Profiles, jc.DeepEquals, []string{"default", "juju-" + s.Config.Name(), "juju-model-app-1"})
+}
+
+func (s *environBrokerSuite) TestStartInstanceInvalidCharmLXDProfile(c *gc.C) {
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	s.StartInstArgs.CharmLXDProfiles = []lxdprofile.NamedProfile{{
+		Name: "juju-model-app-1",
+		Profile: lxdprofile.Profile{
+			Config: map[string]string{"boot.autostart": "true"},
+		},
+	}}
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, gc.ErrorMatches, `LXD profile config key "boot.autostart" not valid`)
+	s.Stub.CheckCallNames(c, "EnsureImageExists")
+}
+
+func (s *environBrokerSuite) TestReplaceLXDProfiles(c *gc.C) {
+	profiler, ok := environs.Environ(s.Env).(environs.LXDProfiler)
+	c.Assert(ok, jc.IsTrue)
+
+	profile := lxdprofile.Profile{
+		Devices: map[string]map[string]string{
+			"tun": {"type": "unix-char", "path": "/dev/net/tun"},
+		},
+	}
+	err := profiler.ReplaceLXDProfiles("spam", []string{"juju-model-app-1"}, []lxdprofile.NamedProfile{{
+		Name:    "juju-model-app-2",
+		Profile: profile,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCallNames(c, "EnsureProfile", "ReplaceProfiles", "DeleteProfileIfUnused")
+	s.Stub.CheckCall(c, 1, "ReplaceProfiles", "spam", []string{"juju-model-app-1"}, []string{"juju-model-app-2"})
+	s.Stub.CheckCall(c, 2, "DeleteProfileIfUnused", "juju-model-app-1")
+}
+
 func (s *environBrokerSuite) TestStopInstances(c *gc.C) {
 	err := s.Env.StopInstances(s.Instance.Id())
 	c.Assert(err, jc.ErrorIsNil)
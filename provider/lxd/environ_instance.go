@@ -6,6 +6,8 @@
 package lxd
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/version"
 
@@ -115,13 +117,31 @@ func (env *environ) ControllerInstances(controllerUUID string) ([]instance.Id, e
 	return results, nil
 }
 
-type instPlacement struct{}
+type instPlacement struct {
+	// zone is the name of the cluster member on which
+	// the instance should be placed.
+	zone string
+}
 
 func (env *environ) parsePlacement(placement string) (*instPlacement, error) {
 	if placement == "" {
 		return &instPlacement{}, nil
 	}
 
+	pos := strings.IndexRune(placement, '=')
+	if pos == -1 {
+		return nil, errors.Errorf("unknown placement directive: %v", placement)
+	}
+	switch key, value := placement[:pos], placement[pos+1:]; key {
+	case "zone":
+		if !env.raw.IsClustered() {
+			return nil, errors.NotSupportedf("zone placement on a standalone LXD host")
+		}
+		if _, err := env.availZoneUp(value); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &instPlacement{zone: value}, nil
+	}
 	return nil, errors.Errorf("unknown placement directive: %v", placement)
 }
 
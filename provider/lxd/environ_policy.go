@@ -16,9 +16,19 @@ import (
 // PrecheckInstance verifies that the provided series and constraints
 // are valid for use in creating an instance in this environment.
 func (env *environ) PrecheckInstance(args environs.PrecheckInstanceParams) error {
-	if _, err := env.parsePlacement(args.Placement); err != nil {
+	placement, err := env.parsePlacement(args.Placement)
+	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := env.validateZonesConstraint(args.Constraints); err != nil {
+		return errors.Trace(err)
+	}
+	if placement.zone != "" && args.Constraints.HasZones() && !zoneIn(placement.zone, *args.Constraints.Zones) {
+		return errors.Errorf(
+			"placement zone %q does not satisfy zones constraint %q",
+			placement.zone, *args.Constraints.Zones,
+		)
+	}
 
 	if args.Constraints.HasInstanceType() {
 		return errors.Errorf("LXD does not support instance types (got %q)", *args.Constraints.InstanceType)
@@ -58,9 +58,66 @@ func (s *environPolSuite) TestPrecheckInstanceAvailZone(c *gc.C) {
 	placement := "zone=a-zone"
 	err := s.Env.PrecheckInstance(environs.PrecheckInstanceParams{Series: series.LatestLts(), Placement: placement})
 
+	c.Check(err, gc.ErrorMatches, `zone placement on a standalone LXD host not supported`)
+}
+
+func (s *environPolSuite) TestPrecheckInstanceUnknownPlacement(c *gc.C) {
+	placement := "a-zone"
+	err := s.Env.PrecheckInstance(environs.PrecheckInstanceParams{Series: series.LatestLts(), Placement: placement})
+
 	c.Check(err, gc.ErrorMatches, `unknown placement directive: .*`)
 }
 
+func (s *environPolSuite) TestPrecheckInstanceClusterAvailZone(c *gc.C) {
+	s.UseCluster()
+	placement := "zone=node1"
+	err := s.Env.PrecheckInstance(environs.PrecheckInstanceParams{Series: series.LatestLts(), Placement: placement})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.CheckCallNames(c, "ClusterMembers")
+}
+
+func (s *environPolSuite) TestPrecheckInstanceClusterAvailZoneOffline(c *gc.C) {
+	s.UseCluster()
+	placement := "zone=node2"
+	err := s.Env.PrecheckInstance(environs.PrecheckInstanceParams{Series: series.LatestLts(), Placement: placement})
+	c.Check(err, gc.ErrorMatches, `availability zone "node2" is unavailable`)
+}
+
+func (s *environPolSuite) TestPrecheckInstanceClusterUnknownZone(c *gc.C) {
+	s.UseCluster()
+	placement := "zone=node9"
+	err := s.Env.PrecheckInstance(environs.PrecheckInstanceParams{Series: series.LatestLts(), Placement: placement})
+	c.Check(err, gc.ErrorMatches, `availability zone "node9" not found`)
+}
+
+func (s *environPolSuite) TestPrecheckInstanceZonesConstraint(c *gc.C) {
+	s.UseCluster()
+	cons := constraints.MustParse("zones=node1,node3")
+	err := s.Env.PrecheckInstance(environs.PrecheckInstanceParams{Series: series.LatestLts(), Constraints: cons})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons = constraints.MustParse("zones=node1,node9")
+	err = s.Env.PrecheckInstance(environs.PrecheckInstanceParams{Series: series.LatestLts(), Constraints: cons})
+	c.Check(err, gc.ErrorMatches, `availability zone "node9" not found`)
+}
+
+func (s *environPolSuite) TestPrecheckInstanceZonesConstraintConflictsWithPlacement(c *gc.C) {
+	s.UseCluster()
+	cons := constraints.MustParse("zones=node3")
+	err := s.Env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:      series.LatestLts(),
+		Constraints: cons,
+		Placement:   "zone=node1",
+	})
+	c.Check(err, gc.ErrorMatches, `placement zone "node1" does not satisfy zones constraint \["node3"\]`)
+}
+
+func (s *environPolSuite) TestPrecheckInstanceZonesConstraintStandalone(c *gc.C) {
+	cons := constraints.MustParse("zones=node1")
+	err := s.Env.PrecheckInstance(environs.PrecheckInstanceParams{Series: series.LatestLts(), Constraints: cons})
+	c.Check(err, gc.ErrorMatches, `zones constraint on a standalone LXD host not supported`)
+}
+
 func (s *environPolSuite) TestConstraintsValidatorOkay(c *gc.C) {
 	s.PatchValue(&arch.HostArch, func() string { return arch.AMD64 })
 
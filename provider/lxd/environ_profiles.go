@@ -0,0 +1,54 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/instance"
+)
+
+// writeCharmProfiles writes the LXD profiles contributed by charms, if
+// they do not already exist, and returns their names.
+func (env *environ) writeCharmProfiles(profiles []lxdprofile.NamedProfile) ([]string, error) {
+	names := make([]string, len(profiles))
+	for i, profile := range profiles {
+		if err := profile.Profile.Validate(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		err := env.raw.EnsureProfile(
+			profile.Name,
+			profile.Profile.Description,
+			profile.Profile.Config,
+			profile.Profile.Devices,
+		)
+		if err != nil {
+			return nil, errors.Annotatef(err, "writing LXD profile %q", profile.Name)
+		}
+		names[i] = profile.Name
+	}
+	return names, nil
+}
+
+// ReplaceLXDProfiles implements environs.LXDProfiler. Removed profiles
+// that are no longer used by any instance, such as those written for
+// previous revisions of a charm, are deleted.
+func (env *environ) ReplaceLXDProfiles(id instance.Id, remove []string, add []lxdprofile.NamedProfile) error {
+	names, err := env.writeCharmProfiles(add)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := env.raw.ReplaceProfiles(string(id), remove, names); err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range remove {
+		if err := env.raw.DeleteProfileIfUnused(name); err != nil {
+			logger.Warningf("cannot delete LXD profile %q: %v", name, err)
+		}
+	}
+	return nil
+}
@@ -21,6 +21,7 @@ type rawProvider struct {
 	lxdProfiles
 	lxdImages
 	lxdStorage
+	lxdCluster
 
 	remote lxdclient.Remote
 }
@@ -45,12 +46,15 @@ type lxdInstances interface {
 	Addresses(string) ([]network.Address, error)
 	AttachDisk(string, string, lxdclient.DiskDevice) error
 	RemoveDevice(string, string) error
+	ReplaceProfiles(name string, remove, add []string) ([]string, error)
 }
 
 type lxdProfiles interface {
 	DefaultProfileBridgeName() string
 	CreateProfile(string, map[string]string) error
 	HasProfile(string) (bool, error)
+	EnsureProfile(name, description string, config map[string]string, devices map[string]map[string]string) error
+	DeleteProfileIfUnused(name string) error
 }
 
 type lxdImages interface {
@@ -71,6 +75,12 @@ type lxdStorage interface {
 	VolumeList(pool string) ([]lxdapi.StorageVolume, error)
 }
 
+type lxdCluster interface {
+	IsClustered() bool
+	ClusterMembers() ([]lxdclient.ClusterMember, error)
+	InstanceLocations(prefix string) (map[string]string, error)
+}
+
 func newRawProvider(spec environs.CloudSpec, local bool) (*rawProvider, error) {
 	if local {
 		return newLocalRawProvider()
@@ -103,6 +113,7 @@ func newRawProviderFromConfig(config lxdclient.Config) (*rawProvider, error) {
 		lxdProfiles:  client,
 		lxdImages:    client,
 		lxdStorage:   client,
+		lxdCluster:   client,
 		remote:       config.Remote,
 	}, nil
 }
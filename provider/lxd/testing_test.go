@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/testing"
 	coretools "github.com/juju/juju/tools"
 	"github.com/juju/juju/tools/lxdclient"
@@ -85,8 +86,10 @@ var (
 
 // We test these here since they are not exported.
 var (
-	_ environs.Environ  = (*environ)(nil)
-	_ instance.Instance = (*environInstance)(nil)
+	_ environs.Environ     = (*environ)(nil)
+	_ instance.Instance    = (*environInstance)(nil)
+	_ common.ZonedEnviron  = (*environ)(nil)
+	_ environs.LXDProfiler = (*environ)(nil)
 )
 
 type BaseSuiteUnpatched struct {
@@ -348,6 +351,7 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 		lxdProfiles:  s.Client,
 		lxdImages:    s.Client,
 		lxdStorage:   s.Client,
+		lxdCluster:   s.Client,
 		remote: lxdclient.Remote{
 			Cert: &lxdclient.Cert{
 				Name:    "juju",
@@ -392,6 +396,22 @@ func (s *BaseSuite) TestingCert(c *gc.C) (lxdclient.Cert, string) {
 	return cert, fingerprint
 }
 
+// UseCluster makes the LXD remote a cluster of three members, the
+// second of which is offline.
+func (s *BaseSuite) UseCluster() {
+	s.Client.Clustered = true
+	s.Client.Members = []lxdclient.ClusterMember{{
+		Name:   "node1",
+		Status: lxdclient.ClusterMemberOnline,
+	}, {
+		Name:   "node2",
+		Status: "Offline",
+	}, {
+		Name:   "node3",
+		Status: lxdclient.ClusterMemberOnline,
+	}}
+}
+
 func (s *BaseSuite) CheckNoAPI(c *gc.C) {
 	s.Stub.CheckCalls(c, nil)
 }
@@ -473,6 +493,9 @@ type StubClient struct {
 	Server             *api.Server
 	StorageIsSupported bool
 	Volumes            map[string][]api.StorageVolume
+	Clustered          bool
+	Members            []lxdclient.ClusterMember
+	Locations          map[string]string
 }
 
 func (conn *StubClient) Instances(prefix string, statuses ...string) ([]lxdclient.Instance, error) {
@@ -584,6 +607,24 @@ func (conn *StubClient) HasProfile(name string) (bool, error) {
 	return false, conn.NextErr()
 }
 
+func (conn *StubClient) EnsureProfile(name, description string, config map[string]string, devices map[string]map[string]string) error {
+	conn.AddCall("EnsureProfile", name, description, config, devices)
+	return conn.NextErr()
+}
+
+func (conn *StubClient) DeleteProfileIfUnused(name string) error {
+	conn.AddCall("DeleteProfileIfUnused", name)
+	return conn.NextErr()
+}
+
+func (conn *StubClient) ReplaceProfiles(name string, remove, add []string) ([]string, error) {
+	conn.AddCall("ReplaceProfiles", name, remove, add)
+	if err := conn.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return add, nil
+}
+
 func (conn *StubClient) AttachDisk(container, device string, disk lxdclient.DiskDevice) error {
 	conn.AddCall("AttachDisk", container, device, disk)
 	return conn.NextErr()
@@ -658,3 +699,23 @@ func (conn *StubClient) VolumeUpdate(pool, volume string, update api.StorageVolu
 	conn.AddCall("VolumeUpdate", pool, volume, update)
 	return conn.NextErr()
 }
+
+func (conn *StubClient) IsClustered() bool {
+	return conn.Clustered
+}
+
+func (conn *StubClient) ClusterMembers() ([]lxdclient.ClusterMember, error) {
+	conn.AddCall("ClusterMembers")
+	if err := conn.NextErr(); err != nil {
+		return nil, err
+	}
+	return conn.Members, nil
+}
+
+func (conn *StubClient) InstanceLocations(prefix string) (map[string]string, error) {
+	conn.AddCall("InstanceLocations", prefix)
+	if err := conn.NextErr(); err != nil {
+		return nil, err
+	}
+	return conn.Locations, nil
+}
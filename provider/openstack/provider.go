@@ -496,6 +496,7 @@ func (e *Environ) neutron() *neutron.Client {
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.CpuPower,
+	constraints.Zones,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
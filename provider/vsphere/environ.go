@@ -18,8 +18,6 @@ import (
 	"github.com/juju/juju/provider/common"
 )
 
-// Note: This provider/environment does *not* implement storage.
-
 type environ struct {
 	name     string
 	cloud    environs.CloudSpec
@@ -188,10 +186,31 @@ func (env *sessionEnviron) Destroy() error {
 	if err := DestroyEnv(env); err != nil {
 		return errors.Trace(err)
 	}
-	return env.client.DestroyVMFolder(env.ctx, path.Join(
+	if err := env.client.DestroyVMFolder(env.ctx, path.Join(
 		controllerFolderName("*"),
 		env.modelFolderName(),
-	))
+	)); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Remove the model's volume directory. Volumes may be created
+	// in any accessible datastore, so we must check them all.
+	datastores, err := env.client.Datastores(env.ctx)
+	if err != nil {
+		return errors.Annotate(err, "listing datastores")
+	}
+	modelUUID := env.Config().UUID()
+	for _, ds := range datastores {
+		if !ds.Summary.Accessible {
+			continue
+		}
+		datastorePath := fmt.Sprintf("[%s] %s", ds.Name, volumeDirectoryName(modelUUID))
+		logger.Debugf("deleting: %s", datastorePath)
+		if err := env.client.DeleteDatastoreFile(env.ctx, datastorePath); err != nil {
+			return errors.Annotatef(err, "deleting volumes from datastore %q", ds.Name)
+		}
+	}
+	return nil
 }
 
 // DestroyController implements the Environ interface.
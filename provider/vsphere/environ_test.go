@@ -51,6 +51,15 @@ func (s *environSuite) TestBootstrap(c *gc.C) {
 }
 
 func (s *environSuite) TestDestroy(c *gc.C) {
+	s.client.datastores = []*mo.Datastore{{
+		ManagedEntity: mo.ManagedEntity{Name: "foo"},
+	}, {
+		ManagedEntity: mo.ManagedEntity{Name: "bar"},
+		Summary: types.DatastoreSummary{
+			Accessible: true,
+		},
+	}}
+
 	var destroyCalled bool
 	s.PatchValue(&vsphere.DestroyEnv, func(env environs.Environ) error {
 		destroyCalled = true
@@ -60,13 +69,20 @@ func (s *environSuite) TestDestroy(c *gc.C) {
 	err := s.env.Destroy()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(destroyCalled, jc.IsTrue)
-	s.client.CheckCallNames(c, "DestroyVMFolder", "Close")
+	s.client.CheckCallNames(c, "DestroyVMFolder", "Datastores", "DeleteDatastoreFile", "Close")
 	destroyVMFolderCall := s.client.Calls()[0]
 	c.Assert(destroyVMFolderCall.Args, gc.HasLen, 2)
 	c.Assert(destroyVMFolderCall.Args[0], gc.Implements, new(context.Context))
 	c.Assert(destroyVMFolderCall.Args[1], gc.Equals,
 		`Juju Controller (*)/Model "testenv" (2d02eeac-9dbb-11e4-89d3-123b93f75cba)`,
 	)
+
+	deleteDatastoreFileCall := s.client.Calls()[2]
+	c.Assert(deleteDatastoreFileCall.Args, gc.HasLen, 2)
+	c.Assert(deleteDatastoreFileCall.Args[0], gc.Implements, new(context.Context))
+	c.Assert(deleteDatastoreFileCall.Args[1], gc.Equals,
+		"[bar] juju-volumes/2d02eeac-9dbb-11e4-89d3-123b93f75cba",
+	)
 }
 
 func (s *environSuite) TestDestroyController(c *gc.C) {
@@ -96,7 +112,8 @@ func (s *environSuite) TestDestroyController(c *gc.C) {
 
 	s.dialStub.CheckCallNames(c, "Dial")
 	s.client.CheckCallNames(c,
-		"DestroyVMFolder", "RemoveVirtualMachines", "DestroyVMFolder",
+		"DestroyVMFolder", "Datastores", "DeleteDatastoreFile", "DeleteDatastoreFile",
+		"RemoveVirtualMachines", "DestroyVMFolder",
 		"Datastores", "DeleteDatastoreFile", "DeleteDatastoreFile",
 		"Close",
 	)
@@ -108,24 +125,24 @@ func (s *environSuite) TestDestroyController(c *gc.C) {
 		`Juju Controller (*)/Model "testenv" (2d02eeac-9dbb-11e4-89d3-123b93f75cba)`,
 	)
 
-	removeVirtualMachinesCall := s.client.Calls()[1]
+	removeVirtualMachinesCall := s.client.Calls()[4]
 	c.Assert(removeVirtualMachinesCall.Args, gc.HasLen, 2)
 	c.Assert(removeVirtualMachinesCall.Args[0], gc.Implements, new(context.Context))
 	c.Assert(removeVirtualMachinesCall.Args[1], gc.Equals,
 		`Juju Controller (foo)/Model "*" (*)/*`,
 	)
 
-	destroyControllerVMFolderCall := s.client.Calls()[2]
+	destroyControllerVMFolderCall := s.client.Calls()[5]
 	c.Assert(destroyControllerVMFolderCall.Args, gc.HasLen, 2)
 	c.Assert(destroyControllerVMFolderCall.Args[0], gc.Implements, new(context.Context))
 	c.Assert(destroyControllerVMFolderCall.Args[1], gc.Equals, `Juju Controller (foo)`)
 
-	deleteDatastoreFileCall1 := s.client.Calls()[4]
+	deleteDatastoreFileCall1 := s.client.Calls()[7]
 	c.Assert(deleteDatastoreFileCall1.Args, gc.HasLen, 2)
 	c.Assert(deleteDatastoreFileCall1.Args[0], gc.Implements, new(context.Context))
 	c.Assert(deleteDatastoreFileCall1.Args[1], gc.Equals, "[bar] juju-vmdks/foo")
 
-	deleteDatastoreFileCall2 := s.client.Calls()[5]
+	deleteDatastoreFileCall2 := s.client.Calls()[8]
 	c.Assert(deleteDatastoreFileCall2.Args, gc.HasLen, 2)
 	c.Assert(deleteDatastoreFileCall2.Args[0], gc.Implements, new(context.Context))
 	c.Assert(deleteDatastoreFileCall2.Args[1], gc.Equals, "[baz] juju-vmdks/foo")
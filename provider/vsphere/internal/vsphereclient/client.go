@@ -101,6 +101,13 @@ func (c *Client) RemoveVirtualMachines(ctx context.Context, path string) error {
 	var lastError error
 	tasks := make([]*object.Task, 0, len(vms)*2)
 	for i, vm := range vms {
+		// Destroying a VM deletes all of its disks, so detach
+		// any volumes first to preserve them.
+		if err := c.detachVolumes(ctx, vm, mos[i].Config); err != nil {
+			lastError = errors.Annotatef(err, "detaching volumes from %q", vm.Name())
+			c.logger.Errorf(err.Error())
+			continue
+		}
 		if mos[i].Runtime.PowerState == types.VirtualMachinePowerStatePoweredOn {
 			c.logger.Debugf("powering off %q", vm.Name())
 			task, err := vm.PowerOff(ctx)
@@ -350,6 +350,10 @@ func (c *Client) createImportSpec(
 		s.ExtraConfig = append(s.ExtraConfig, &types.OptionValue{Key: k, Value: v})
 	}
 
+	// Have the guest see disk UUIDs, so that volumes attached later
+	// can be identified by their World Wide Name.
+	s.ExtraConfig = append(s.ExtraConfig, &types.OptionValue{Key: "disk.EnableUUID", Value: "TRUE"})
+
 	if args.ExternalNetwork != "" {
 		externalNetwork, err := findNetwork(networks, args.ExternalNetwork)
 		if err != nil {
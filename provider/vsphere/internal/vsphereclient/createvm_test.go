@@ -128,6 +128,7 @@ func (s *clientSuite) TestCreateVirtualMachine(c *gc.C) {
 				Name: "vm-name.tmp",
 				ExtraConfig: []types.BaseOptionValue{
 					&types.OptionValue{Key: "k", Value: "v"},
+					&types.OptionValue{Key: "disk.EnableUUID", Value: "TRUE"},
 				},
 			},
 		}}},
@@ -304,6 +305,7 @@ func (s *clientSuite) TestCreateVirtualMachineExternalNetworkSpecified(c *gc.C)
 			Name: "vm-name.tmp",
 			ExtraConfig: []types.BaseOptionValue{
 				&types.OptionValue{Key: "k", Value: "v"},
+				&types.OptionValue{Key: "disk.EnableUUID", Value: "TRUE"},
 			},
 			DeviceChange: []types.BaseVirtualDeviceConfigSpec{
 				&types.VirtualDeviceConfigSpec{
@@ -348,6 +350,7 @@ func (s *clientSuite) TestCreateVirtualMachineExternalNetworkSpecifiedDVPortgrou
 			Name: "vm-name.tmp",
 			ExtraConfig: []types.BaseOptionValue{
 				&types.OptionValue{Key: "k", Value: "v"},
+				&types.OptionValue{Key: "disk.EnableUUID", Value: "TRUE"},
 			},
 			DeviceChange: []types.BaseVirtualDeviceConfigSpec{
 				&types.VirtualDeviceConfigSpec{
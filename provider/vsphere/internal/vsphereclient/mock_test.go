@@ -62,6 +62,14 @@ var (
 		Type:  "Task",
 		Value: "ExtendVirtualDisk",
 	}
+	createVirtualDiskTask = types.ManagedObjectReference{
+		Type:  "Task",
+		Value: "CreateVirtualDisk",
+	}
+	deleteVirtualDiskTask = types.ManagedObjectReference{
+		Type:  "Task",
+		Value: "DeleteVirtualDisk",
+	}
 )
 
 type mockRoundTripper struct {
@@ -74,6 +82,7 @@ type mockRoundTripper struct {
 	importVAppResult types.ManagedObjectReference
 	taskError        map[types.ManagedObjectReference]*types.LocalizedMethodFault
 	taskResult       map[types.ManagedObjectReference]types.AnyType
+	reconfigSpecs    []types.VirtualMachineConfigSpec
 }
 
 func (r *mockRoundTripper) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
@@ -93,7 +102,9 @@ func (r *mockRoundTripper) RoundTrip(ctx context.Context, req, res soap.HasFault
 		r.MethodCall(r, "Logout")
 		res.Res = &types.LogoutResponse{}
 	case *methods.ReconfigVM_TaskBody:
+		req := req.(*methods.ReconfigVM_TaskBody).Req
 		r.MethodCall(r, "ReconfigVM_Task")
+		r.reconfigSpecs = append(r.reconfigSpecs, req.Spec)
 		res.Res = &types.ReconfigVM_TaskResponse{reconfigVMTask}
 	case *methods.Destroy_TaskBody:
 		r.MethodCall(r, "Destroy_Task")
@@ -156,6 +167,18 @@ func (r *mockRoundTripper) RoundTrip(ctx context.Context, req, res soap.HasFault
 		req := req.(*methods.ExtendVirtualDisk_TaskBody).Req
 		r.MethodCall(r, "ExtendVirtualDisk", req.Name, req.NewCapacityKb)
 		res.Res = &types.ExtendVirtualDisk_TaskResponse{extendVirtualDiskTask}
+	case *methods.CreateVirtualDisk_TaskBody:
+		req := req.(*methods.CreateVirtualDisk_TaskBody).Req
+		r.MethodCall(r, "CreateVirtualDisk", req.Name, req.Spec)
+		res.Res = &types.CreateVirtualDisk_TaskResponse{createVirtualDiskTask}
+	case *methods.DeleteVirtualDisk_TaskBody:
+		req := req.(*methods.DeleteVirtualDisk_TaskBody).Req
+		r.MethodCall(r, "DeleteVirtualDisk", req.Name)
+		res.Res = &types.DeleteVirtualDisk_TaskResponse{deleteVirtualDiskTask}
+	case *methods.QueryVirtualDiskUuidBody:
+		req := req.(*methods.QueryVirtualDiskUuidBody).Req
+		r.MethodCall(r, "QueryVirtualDiskUuid", req.Name)
+		res.Res = &types.QueryVirtualDiskUuidResponse{"60 00 C2 98 21 d6 7a 16-8c 42 bd e3 ad 6c 7b 15"}
 	case *methods.CreatePropertyCollectorBody:
 		r.MethodCall(r, "CreatePropertyCollector")
 		uuid := utils.MustNewUUID().String()
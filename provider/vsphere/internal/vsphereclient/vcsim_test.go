@@ -0,0 +1,143 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package vsphereclient
+
+import (
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25/mo"
+	"golang.org/x/net/context"
+	gc "gopkg.in/check.v1"
+)
+
+// vcsimSuite tests volume management against the govmomi vCenter
+// simulator, rather than against canned responses, so that the
+// sequence of requests is checked for its effect on a real inventory.
+type vcsimSuite struct {
+	testing.IsolationSuite
+
+	model     *simulator.Model
+	server    *simulator.Server
+	client    *Client
+	datastore *mo.Datastore
+}
+
+var _ = gc.Suite(&vcsimSuite{})
+
+const (
+	vcsimDatacenter = "DC0"
+	vcsimVM         = "DC0_H0_VM0"
+	vcsimDirectory  = "juju-volumes/model"
+)
+
+func (s *vcsimSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.model = simulator.VPX()
+	err := s.model.Create()
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { s.model.Remove() })
+
+	s.server = s.model.Service.NewServer()
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+
+	s.client, err = Dial(context.Background(), s.server.URL, vcsimDatacenter, loggo.GetLogger("vsphereclient"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	datastores, err := s.client.Datastores(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(datastores, gc.Not(gc.HasLen), 0)
+	s.datastore = datastores[0]
+}
+
+func (s *vcsimSuite) volumePath(name string) string {
+	return "[" + s.datastore.Name + "] " + vcsimDirectory + "/" + name + ".vmdk"
+}
+
+func (s *vcsimSuite) createVolume(c *gc.C, name string) string {
+	datastorePath := s.volumePath(name)
+	uuid, err := s.client.CreateVirtualDisk(context.Background(), datastorePath, 1024*1024)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uuid, gc.Not(gc.Equals), "")
+	return datastorePath
+}
+
+func (s *vcsimSuite) volumePaths(c *gc.C) []string {
+	disks, err := s.client.VirtualDisks(context.Background(), s.datastore, vcsimDirectory)
+	c.Assert(err, jc.ErrorIsNil)
+	paths := make([]string, len(disks))
+	for i, disk := range disks {
+		paths[i] = disk.DatastorePath
+	}
+	return paths
+}
+
+func (s *vcsimSuite) virtualMachine(c *gc.C) *mo.VirtualMachine {
+	vms, err := s.client.VirtualMachines(context.Background(), vcsimVM)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vms, gc.HasLen, 1)
+	return vms[0]
+}
+
+func (s *vcsimSuite) isAttached(c *gc.C, datastorePath string) bool {
+	vm := s.virtualMachine(c)
+	return findVirtualDisk(vm.Config.Hardware.Device, datastorePath) != nil
+}
+
+func (s *vcsimSuite) TestCreateVirtualDisk(c *gc.C) {
+	datastorePath := s.createVolume(c, "volume-0")
+	c.Assert(s.volumePaths(c), jc.DeepEquals, []string{datastorePath})
+}
+
+func (s *vcsimSuite) TestDeleteVirtualDisk(c *gc.C) {
+	datastorePath := s.createVolume(c, "volume-0")
+	err := s.client.DeleteVirtualDisk(context.Background(), datastorePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volumePaths(c), gc.HasLen, 0)
+
+	// Deleting a disk that no longer exists is not an error.
+	err = s.client.DeleteVirtualDisk(context.Background(), datastorePath)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *vcsimSuite) TestAttachDetachVirtualDisk(c *gc.C) {
+	datastorePath := s.createVolume(c, "volume-0")
+
+	err := s.client.AttachVirtualDisk(context.Background(), s.virtualMachine(c), datastorePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.isAttached(c, datastorePath), jc.IsTrue)
+
+	// Attaching an attached disk does nothing.
+	err = s.client.AttachVirtualDisk(context.Background(), s.virtualMachine(c), datastorePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.isAttached(c, datastorePath), jc.IsTrue)
+
+	err = s.client.DetachVirtualDisk(context.Background(), s.virtualMachine(c), datastorePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.isAttached(c, datastorePath), jc.IsFalse)
+	c.Assert(s.volumePaths(c), jc.DeepEquals, []string{datastorePath})
+
+	// Detaching a detached disk does nothing.
+	err = s.client.DetachVirtualDisk(context.Background(), s.virtualMachine(c), datastorePath)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *vcsimSuite) TestRemoveVirtualMachinesDetachesVolumes(c *gc.C) {
+	datastorePath := s.createVolume(c, "volume-0")
+	err := s.client.AttachVirtualDisk(context.Background(), s.virtualMachine(c), datastorePath)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.client.RemoveVirtualMachines(context.Background(), vcsimVM)
+	c.Assert(err, jc.ErrorIsNil)
+
+	vms, err := s.client.VirtualMachines(context.Background(), vcsimVM)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vms, gc.HasLen, 0)
+
+	// The volume was detached before the VM was destroyed, so it
+	// survives the VM and can be attached elsewhere.
+	c.Assert(s.volumePaths(c), jc.DeepEquals, []string{datastorePath})
+}
@@ -0,0 +1,375 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package vsphereclient
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// VolumesDirectory is the name of the datastore directory under
+// which volumes are stored. Disks stored under this directory are
+// detached from VMs before they are destroyed.
+const VolumesDirectory = "juju-volumes"
+
+// VirtualDisk describes a virtual disk (VMDK) stored in a datastore.
+type VirtualDisk struct {
+	// DatastorePath is the path of the disk's descriptor file, in
+	// the form "[datastore] path/to/disk.vmdk".
+	DatastorePath string
+
+	// CapacityKB is the capacity of the disk, in KiB.
+	CapacityKB int64
+
+	// UUID is the disk's UUID, as lower-case hex digits. When the
+	// VM's disk.EnableUUID setting is on, this is reported to the
+	// guest as the disk's World Wide Name.
+	UUID string
+}
+
+// CreateVirtualDisk creates a thin-provisioned virtual disk with the
+// given capacity at the given datastore path, creating the directory
+// that will hold it if necessary, and returns the disk's UUID.
+func (c *Client) CreateVirtualDisk(
+	ctx context.Context,
+	datastorePath string,
+	capacityKB int64,
+) (string, error) {
+	_, datacenter, err := c.finder(ctx)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	fileManager := object.NewFileManager(c.client.Client)
+	if err := fileManager.MakeDirectory(ctx, path.Dir(datastorePath), datacenter, true); err != nil {
+		if !isFileAlreadyExists(err) {
+			return "", errors.Annotate(err, "creating volume directory")
+		}
+	}
+
+	diskManager := object.NewVirtualDiskManager(c.client.Client)
+	task, err := diskManager.CreateVirtualDisk(ctx, datastorePath, datacenter, &types.FileBackedVirtualDiskSpec{
+		VirtualDiskSpec: types.VirtualDiskSpec{
+			DiskType:    string(types.VirtualDiskTypeThin),
+			AdapterType: string(types.VirtualDiskAdapterTypeLsiLogic),
+		},
+		CapacityKb: capacityKB,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if _, err := task.WaitForResult(ctx, nil); err != nil {
+		return "", errors.Annotate(err, "creating virtual disk")
+	}
+	return c.virtualDiskUUID(ctx, datacenter, datastorePath)
+}
+
+// DeleteVirtualDisk deletes the virtual disk at the given datastore
+// path. It is not an error for the disk not to exist.
+func (c *Client) DeleteVirtualDisk(ctx context.Context, datastorePath string) error {
+	_, datacenter, err := c.finder(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	diskManager := object.NewVirtualDiskManager(c.client.Client)
+	task, err := diskManager.DeleteVirtualDisk(ctx, datastorePath, datacenter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := task.WaitForResult(ctx, nil); err != nil {
+		if types.IsFileNotFound(err) {
+			return nil
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// VirtualDisks returns the virtual disks stored in the given directory
+// of the datastore. If the directory does not exist, no disks are
+// returned.
+func (c *Client) VirtualDisks(
+	ctx context.Context,
+	datastore *mo.Datastore,
+	directory string,
+) ([]VirtualDisk, error) {
+	_, datacenter, err := c.finder(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	browser := object.NewHostDatastoreBrowser(c.client.Client, datastore.Browser)
+	dirDatastorePath := "[" + datastore.Name + "] " + directory
+	task, err := browser.SearchDatastore(ctx, dirDatastorePath, &types.HostDatastoreBrowserSearchSpec{
+		MatchPattern: []string{"*.vmdk"},
+		Query:        []types.BaseFileQuery{&types.VmDiskFileQuery{}},
+		Details:      &types.FileQueryFlags{FileType: true},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := task.WaitForResult(ctx, nil)
+	if err != nil {
+		if types.IsFileNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Annotatef(err, "searching %q", dirDatastorePath)
+	}
+	results, ok := info.Result.(types.HostDatastoreBrowserSearchResults)
+	if !ok {
+		return nil, errors.Errorf("unexpected search result type %T", info.Result)
+	}
+
+	var disks []VirtualDisk
+	for _, file := range results.File {
+		diskInfo, ok := file.(*types.VmDiskFileInfo)
+		if !ok {
+			continue
+		}
+		datastorePath := path.Join(dirDatastorePath, diskInfo.Path)
+		uuid, err := c.virtualDiskUUID(ctx, datacenter, datastorePath)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		disks = append(disks, VirtualDisk{
+			DatastorePath: datastorePath,
+			CapacityKB:    diskInfo.CapacityKb,
+			UUID:          uuid,
+		})
+	}
+	return disks, nil
+}
+
+// AttachVirtualDisk attaches the virtual disk at the given datastore
+// path to the VM, on its first SCSI controller. If the disk is already
+// attached to the VM, AttachVirtualDisk does nothing.
+func (c *Client) AttachVirtualDisk(
+	ctx context.Context,
+	vmInfo *mo.VirtualMachine,
+	datastorePath string,
+) error {
+	var mo mo.VirtualMachine
+	if err := c.client.RetrieveOne(ctx, vmInfo.Reference(), []string{"config.hardware"}, &mo); err != nil {
+		return errors.Trace(err)
+	}
+	devices := mo.Config.Hardware.Device
+	if findVirtualDisk(devices, datastorePath) != nil {
+		return nil
+	}
+
+	var controller *types.VirtualController
+	for _, dev := range devices {
+		if scsi, ok := dev.(types.BaseVirtualSCSIController); ok {
+			controller = scsi.GetVirtualSCSIController().GetVirtualController()
+			break
+		}
+	}
+	if controller == nil {
+		return errors.New("SCSI controller not found")
+	}
+	unitNumber, err := freeUnitNumber(devices, controller)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	disk := &types.VirtualDisk{
+		VirtualDevice: types.VirtualDevice{
+			Key:           -1,
+			ControllerKey: controller.Key,
+			UnitNumber:    &unitNumber,
+			Backing: &types.VirtualDiskFlatVer2BackingInfo{
+				DiskMode: string(types.VirtualDiskModePersistent),
+				VirtualDeviceFileBackingInfo: types.VirtualDeviceFileBackingInfo{
+					FileName: datastorePath,
+				},
+			},
+		},
+	}
+	spec := types.VirtualMachineConfigSpec{
+		DeviceChange: []types.BaseVirtualDeviceConfigSpec{
+			&types.VirtualDeviceConfigSpec{
+				Operation: types.VirtualDeviceConfigSpecOperationAdd,
+				Device:    disk,
+				// No file operation: the disk already exists.
+			},
+		},
+	}
+	vm := object.NewVirtualMachine(c.client.Client, vmInfo.Reference())
+	task, err := vm.Reconfigure(ctx, spec)
+	if err != nil {
+		return errors.Annotate(err, "attaching disk")
+	}
+	if _, err := task.WaitForResult(ctx, nil); err != nil {
+		return errors.Annotate(err, "attaching disk")
+	}
+	return nil
+}
+
+// DetachVirtualDisk detaches the virtual disk at the given datastore
+// path from the VM, leaving the disk in place. If the disk is not
+// attached to the VM, DetachVirtualDisk does nothing.
+func (c *Client) DetachVirtualDisk(
+	ctx context.Context,
+	vmInfo *mo.VirtualMachine,
+	datastorePath string,
+) error {
+	var mo mo.VirtualMachine
+	if err := c.client.RetrieveOne(ctx, vmInfo.Reference(), []string{"config.hardware"}, &mo); err != nil {
+		return errors.Trace(err)
+	}
+	disk := findVirtualDisk(mo.Config.Hardware.Device, datastorePath)
+	if disk == nil {
+		return nil
+	}
+	spec := types.VirtualMachineConfigSpec{
+		DeviceChange: []types.BaseVirtualDeviceConfigSpec{
+			&types.VirtualDeviceConfigSpec{
+				Operation: types.VirtualDeviceConfigSpecOperationRemove,
+				Device:    disk,
+			},
+		},
+	}
+	vm := object.NewVirtualMachine(c.client.Client, vmInfo.Reference())
+	task, err := vm.Reconfigure(ctx, spec)
+	if err != nil {
+		return errors.Annotate(err, "detaching disk")
+	}
+	if _, err := task.WaitForResult(ctx, nil); err != nil {
+		return errors.Annotate(err, "detaching disk")
+	}
+	return nil
+}
+
+// detachVolumes detaches from the VM all disks stored under
+// VolumesDirectory, leaving the disks in place.
+func (c *Client) detachVolumes(
+	ctx context.Context,
+	vm *object.VirtualMachine,
+	config *types.VirtualMachineConfigInfo,
+) error {
+	if config == nil {
+		return nil
+	}
+	var spec types.VirtualMachineConfigSpec
+	for _, dev := range config.Hardware.Device {
+		disk, ok := dev.(*types.VirtualDisk)
+		if !ok {
+			continue
+		}
+		backing, ok := disk.Backing.(types.BaseVirtualDeviceFileBackingInfo)
+		if !ok || !isVolumePath(backing.GetVirtualDeviceFileBackingInfo().FileName) {
+			continue
+		}
+		spec.DeviceChange = append(spec.DeviceChange, &types.VirtualDeviceConfigSpec{
+			Operation: types.VirtualDeviceConfigSpecOperationRemove,
+			Device:    disk,
+		})
+	}
+	if len(spec.DeviceChange) == 0 {
+		return nil
+	}
+	c.logger.Debugf("detaching %d volume(s) from %q", len(spec.DeviceChange), vm.Name())
+	task, err := vm.Reconfigure(ctx, spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := task.WaitForResult(ctx, nil); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// isVolumePath reports whether the given datastore path, in the form
+// "[datastore] path/to/disk.vmdk", refers to a file stored under
+// VolumesDirectory.
+func isVolumePath(datastorePath string) bool {
+	if i := strings.Index(datastorePath, "] "); i >= 0 {
+		datastorePath = datastorePath[i+2:]
+	}
+	return strings.HasPrefix(datastorePath, VolumesDirectory+"/")
+}
+
+func (c *Client) virtualDiskUUID(
+	ctx context.Context,
+	datacenter *object.Datacenter,
+	datastorePath string,
+) (string, error) {
+	// NOTE(axw) as with extendDisk, there's no QueryVirtualDiskUuid
+	// on the disk manager type, hence why we're dealing with request
+	// types directly.
+	diskManager := object.NewVirtualDiskManager(c.client.Client)
+	dcref := datacenter.Reference()
+	req := types.QueryVirtualDiskUuid{
+		This:       diskManager.Reference(),
+		Name:       datastorePath,
+		Datacenter: &dcref,
+	}
+	res, err := methods.QueryVirtualDiskUuid(ctx, c.client.Client, &req)
+	if err != nil {
+		return "", errors.Annotate(err, "querying disk UUID")
+	}
+	return normaliseDiskUUID(res.Returnval), nil
+}
+
+// normaliseDiskUUID converts a disk UUID, which vSphere reports either
+// as "6000C298-21d6-..." or as space-separated bytes, to lower-case hex
+// digits.
+func normaliseDiskUUID(uuid string) string {
+	uuid = strings.Replace(uuid, " ", "", -1)
+	uuid = strings.Replace(uuid, "-", "", -1)
+	return strings.ToLower(uuid)
+}
+
+// findVirtualDisk returns the disk device backed by the file at the
+// given datastore path, or nil if there is none.
+func findVirtualDisk(devices []types.BaseVirtualDevice, datastorePath string) *types.VirtualDisk {
+	for _, dev := range devices {
+		disk, ok := dev.(*types.VirtualDisk)
+		if !ok {
+			continue
+		}
+		backing, ok := disk.Backing.(types.BaseVirtualDeviceFileBackingInfo)
+		if !ok {
+			continue
+		}
+		if backing.GetVirtualDeviceFileBackingInfo().FileName == datastorePath {
+			return disk
+		}
+	}
+	return nil
+}
+
+// freeUnitNumber returns the lowest unit number on the controller not
+// used by any device. Unit number 7 is reserved for the controller.
+func freeUnitNumber(devices []types.BaseVirtualDevice, controller *types.VirtualController) (int32, error) {
+	used := map[int32]bool{7: true}
+	for _, dev := range devices {
+		d := dev.GetVirtualDevice()
+		if d.ControllerKey == controller.Key && d.UnitNumber != nil {
+			used[*d.UnitNumber] = true
+		}
+	}
+	for unit := int32(0); unit < 16; unit++ {
+		if !used[unit] {
+			return unit, nil
+		}
+	}
+	return 0, errors.New("no free unit number on SCSI controller")
+}
+
+func isFileAlreadyExists(err error) bool {
+	if soap.IsSoapFault(err) {
+		switch soap.ToSoapFault(err).VimFault().(type) {
+		case types.FileAlreadyExists:
+			return true
+		}
+	}
+	return false
+}
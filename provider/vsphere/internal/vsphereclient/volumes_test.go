@@ -0,0 +1,252 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package vsphereclient
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	"golang.org/x/net/context"
+	gc "gopkg.in/check.v1"
+)
+
+const volumePath = "[datastore1] juju-volumes/model/volume-0.vmdk"
+
+func (s *clientSuite) TestCreateVirtualDisk(c *gc.C) {
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	uuid, err := client.CreateVirtualDisk(context.Background(), volumePath, 1024*1024)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uuid, gc.Equals, "6000c29821d67a168c42bde3ad6c7b15")
+
+	s.roundTripper.CheckCalls(c, []testing.StubCall{
+		retrievePropertiesStubCall("FakeRootFolder"),
+		retrievePropertiesStubCall("FakeRootFolder"),
+		{"MakeDirectory", []interface{}{"[datastore1] juju-volumes/model"}},
+		{"CreateVirtualDisk", []interface{}{volumePath, &types.FileBackedVirtualDiskSpec{
+			VirtualDiskSpec: types.VirtualDiskSpec{
+				DiskType:    "thin",
+				AdapterType: "lsiLogic",
+			},
+			CapacityKb: 1024 * 1024,
+		}}},
+		{"CreatePropertyCollector", nil},
+		{"CreateFilter", nil},
+		{"WaitForUpdatesEx", nil},
+		{"QueryVirtualDiskUuid", []interface{}{volumePath}},
+	})
+}
+
+func (s *clientSuite) TestCreateVirtualDiskError(c *gc.C) {
+	s.roundTripper.taskError[createVirtualDiskTask] = &types.LocalizedMethodFault{
+		Fault:            &types.NoDiskSpace{},
+		LocalizedMessage: "no space",
+	}
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	_, err := client.CreateVirtualDisk(context.Background(), volumePath, 1024*1024)
+	c.Assert(err, gc.ErrorMatches, "creating virtual disk: no space")
+}
+
+func (s *clientSuite) TestDeleteVirtualDisk(c *gc.C) {
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	err := client.DeleteVirtualDisk(context.Background(), volumePath)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.roundTripper.CheckCalls(c, []testing.StubCall{
+		retrievePropertiesStubCall("FakeRootFolder"),
+		retrievePropertiesStubCall("FakeRootFolder"),
+		{"DeleteVirtualDisk", []interface{}{volumePath}},
+		{"CreatePropertyCollector", nil},
+		{"CreateFilter", nil},
+		{"WaitForUpdatesEx", nil},
+	})
+}
+
+func (s *clientSuite) TestDeleteVirtualDiskNotFound(c *gc.C) {
+	s.roundTripper.taskError[deleteVirtualDiskTask] = &types.LocalizedMethodFault{
+		Fault: &types.FileNotFound{},
+	}
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	err := client.DeleteVirtualDisk(context.Background(), volumePath)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestVirtualDisks(c *gc.C) {
+	s.roundTripper.taskResult[searchDatastoreTask] = types.HostDatastoreBrowserSearchResults{
+		File: []types.BaseFileInfo{
+			&types.VmDiskFileInfo{FileInfo: types.FileInfo{Path: "volume-0.vmdk"}, CapacityKb: 1024},
+			&types.FileInfo{Path: "notes.txt"},
+		},
+	}
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	disks, err := client.VirtualDisks(context.Background(), &mo.Datastore{
+		ManagedEntity: mo.ManagedEntity{Name: "datastore1"},
+		Browser:       types.ManagedObjectReference{Type: "HostDatastoreBrowser", Value: "FakeBrowser"},
+	}, "juju-volumes/model")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(disks, jc.DeepEquals, []VirtualDisk{{
+		DatastorePath: volumePath,
+		CapacityKB:    1024,
+		UUID:          "6000c29821d67a168c42bde3ad6c7b15",
+	}})
+}
+
+func (s *clientSuite) TestVirtualDisksDirectoryNotFound(c *gc.C) {
+	s.roundTripper.taskError[searchDatastoreTask] = &types.LocalizedMethodFault{
+		Fault: &types.FileNotFound{},
+	}
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	disks, err := client.VirtualDisks(context.Background(), &mo.Datastore{
+		ManagedEntity: mo.ManagedEntity{Name: "datastore1"},
+	}, "juju-volumes/model")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(disks, gc.HasLen, 0)
+}
+
+func (s *clientSuite) setVM0Devices(devices ...types.BaseVirtualDevice) {
+	props := s.roundTripper.contents["FakeVm0"][0].PropSet
+	for i, prop := range props {
+		if prop.Name == "config.hardware.device" {
+			props[i].Val = devices
+		}
+	}
+}
+
+func (s *clientSuite) vm0() *mo.VirtualMachine {
+	var vm mo.VirtualMachine
+	vm.Self = types.ManagedObjectReference{
+		Type:  "VirtualMachine",
+		Value: "FakeVm0",
+	}
+	return &vm
+}
+
+func scsiDisk(unit int32, fileName string) *types.VirtualDisk {
+	return &types.VirtualDisk{
+		VirtualDevice: types.VirtualDevice{
+			ControllerKey: 1000,
+			UnitNumber:    &unit,
+			Backing: &types.VirtualDiskFlatVer2BackingInfo{
+				VirtualDeviceFileBackingInfo: types.VirtualDeviceFileBackingInfo{
+					FileName: fileName,
+				},
+			},
+		},
+	}
+}
+
+var scsiController = &types.VirtualLsiLogicController{
+	VirtualSCSIController: types.VirtualSCSIController{
+		VirtualController: types.VirtualController{
+			VirtualDevice: types.VirtualDevice{Key: 1000},
+		},
+	},
+}
+
+func (s *clientSuite) TestAttachVirtualDisk(c *gc.C) {
+	s.setVM0Devices(scsiController, scsiDisk(0, "[datastore1] disk.vmdk"))
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	err := client.AttachVirtualDisk(context.Background(), s.vm0(), volumePath)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.roundTripper.CheckCallNames(c,
+		"RetrieveProperties",
+		"ReconfigVM_Task",
+		"CreatePropertyCollector",
+		"CreateFilter",
+		"WaitForUpdatesEx",
+	)
+	c.Assert(s.roundTripper.reconfigSpecs, gc.HasLen, 1)
+	spec := s.roundTripper.reconfigSpecs[0]
+	c.Assert(spec.DeviceChange, gc.HasLen, 1)
+	change := spec.DeviceChange[0].GetVirtualDeviceConfigSpec()
+	c.Assert(change.Operation, gc.Equals, types.VirtualDeviceConfigSpecOperationAdd)
+	c.Assert(change.FileOperation, gc.Equals, types.VirtualDeviceConfigSpecFileOperation(""))
+	disk := change.Device.(*types.VirtualDisk)
+	c.Assert(disk.ControllerKey, gc.Equals, int32(1000))
+	c.Assert(*disk.UnitNumber, gc.Equals, int32(1))
+	backing := disk.Backing.(*types.VirtualDiskFlatVer2BackingInfo)
+	c.Assert(backing.FileName, gc.Equals, volumePath)
+}
+
+func (s *clientSuite) TestAttachVirtualDiskAlreadyAttached(c *gc.C) {
+	s.setVM0Devices(scsiController, scsiDisk(0, "[datastore1] disk.vmdk"), scsiDisk(1, volumePath))
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	err := client.AttachVirtualDisk(context.Background(), s.vm0(), volumePath)
+	c.Assert(err, jc.ErrorIsNil)
+	s.roundTripper.CheckCallNames(c, "RetrieveProperties")
+}
+
+func (s *clientSuite) TestAttachVirtualDiskNoController(c *gc.C) {
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	err := client.AttachVirtualDisk(context.Background(), s.vm0(), volumePath)
+	c.Assert(err, gc.ErrorMatches, "SCSI controller not found")
+}
+
+func (s *clientSuite) TestDetachVirtualDisk(c *gc.C) {
+	s.setVM0Devices(scsiController, scsiDisk(0, "[datastore1] disk.vmdk"), scsiDisk(1, volumePath))
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	err := client.DetachVirtualDisk(context.Background(), s.vm0(), volumePath)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.roundTripper.reconfigSpecs, gc.HasLen, 1)
+	spec := s.roundTripper.reconfigSpecs[0]
+	c.Assert(spec.DeviceChange, gc.HasLen, 1)
+	change := spec.DeviceChange[0].GetVirtualDeviceConfigSpec()
+	c.Assert(change.Operation, gc.Equals, types.VirtualDeviceConfigSpecOperationRemove)
+	c.Assert(change.FileOperation, gc.Equals, types.VirtualDeviceConfigSpecFileOperation(""))
+	c.Assert(*change.Device.(*types.VirtualDisk).UnitNumber, gc.Equals, int32(1))
+}
+
+func (s *clientSuite) TestDetachVirtualDiskNotAttached(c *gc.C) {
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	err := client.DetachVirtualDisk(context.Background(), s.vm0(), volumePath)
+	c.Assert(err, jc.ErrorIsNil)
+	s.roundTripper.CheckCallNames(c, "RetrieveProperties")
+}
+
+func (s *clientSuite) TestRemoveVirtualMachinesDetachesVolumes(c *gc.C) {
+	s.setVM0Devices(scsiController, scsiDisk(0, "[datastore1] disk.vmdk"), scsiDisk(1, volumePath))
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	err := client.RemoveVirtualMachines(context.Background(), "foo/bar/*")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The volume must be detached before the VM is destroyed.
+	calls := s.roundTripper.Calls()
+	var names []string
+	for _, call := range calls {
+		if call.FuncName == "ReconfigVM_Task" || call.FuncName == "Destroy_Task" {
+			names = append(names, call.FuncName)
+		}
+	}
+	c.Assert(names, jc.DeepEquals, []string{"ReconfigVM_Task", "Destroy_Task", "Destroy_Task"})
+
+	c.Assert(s.roundTripper.reconfigSpecs, gc.HasLen, 1)
+	spec := s.roundTripper.reconfigSpecs[0]
+	c.Assert(spec.DeviceChange, gc.HasLen, 1)
+	change := spec.DeviceChange[0].GetVirtualDeviceConfigSpec()
+	c.Assert(change.Operation, gc.Equals, types.VirtualDeviceConfigSpecOperationRemove)
+	c.Assert(change.FileOperation, gc.Equals, types.VirtualDeviceConfigSpecFileOperation(""))
+	c.Assert(*change.Device.(*types.VirtualDisk).UnitNumber, gc.Equals, int32(1))
+}
+
+func (s *clientSuite) TestRemoveVirtualMachinesDetachVolumesFails(c *gc.C) {
+	s.setVM0Devices(scsiController, scsiDisk(0, volumePath))
+	s.roundTripper.taskError[reconfigVMTask] = &types.LocalizedMethodFault{
+		Fault:            &types.InvalidDeviceSpec{},
+		LocalizedMessage: "nope",
+	}
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	err := client.RemoveVirtualMachines(context.Background(), "foo/bar/*")
+	c.Assert(err, gc.ErrorMatches, `failed to remove instances: detaching volumes from "vm-0": nope`)
+
+	// vm-0 must not be destroyed, or its volumes would go with it.
+	var destroyed int
+	for _, call := range s.roundTripper.Calls() {
+		if call.FuncName == "Destroy_Task" {
+			destroyed++
+		}
+	}
+	c.Assert(destroyed, gc.Equals, 1)
+}
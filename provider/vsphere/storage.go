@@ -4,17 +4,374 @@
 package vsphere
 
 import (
+	"fmt"
+	"path"
+
 	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"github.com/vmware/govmomi/vim25/mo"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/vsphere/internal/vsphereclient"
 	"github.com/juju/juju/storage"
 )
 
+const (
+	// vsphereStorageProviderType is the storage provider type for
+	// VMDK volumes.
+	vsphereStorageProviderType = storage.ProviderType("vsphere")
+
+	// vsphereDatastoreAttr is the storage pool attribute naming the
+	// datastore in which to create volumes. If it is not set, the
+	// model's datastore is used, or else any accessible datastore.
+	vsphereDatastoreAttr = "datastore"
+)
+
+var storageConfigFields = schema.Fields{
+	vsphereDatastoreAttr: schema.String(),
+}
+
+var storageConfigChecker = schema.FieldMap(
+	storageConfigFields,
+	schema.Defaults{
+		vsphereDatastoreAttr: schema.Omit,
+	},
+)
+
 // StorageProviderTypes implements storage.ProviderRegistry.
 func (*environ) StorageProviderTypes() ([]storage.ProviderType, error) {
-	return nil, nil
+	return []storage.ProviderType{vsphereStorageProviderType}, nil
 }
 
 // StorageProvider implements storage.ProviderRegistry.
-func (*environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+func (env *environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	if t == vsphereStorageProviderType {
+		return &storageProvider{env}, nil
+	}
 	return nil, errors.NotFoundf("storage provider %q", t)
 }
+
+// volumeDirectoryName returns the name of the datastore directory in
+// which the model's volumes are stored.
+func volumeDirectoryName(modelUUID string) string {
+	return path.Join(vsphereclient.VolumesDirectory, modelUUID)
+}
+
+type storageProvider struct {
+	env *environ
+}
+
+var _ storage.Provider = (*storageProvider)(nil)
+
+// ValidateConfig is part of the storage.Provider interface.
+func (*storageProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := storageConfigChecker.Coerce(cfg.Attrs(), nil)
+	return errors.Annotate(err, "validating vSphere storage config")
+}
+
+// Supports is part of the storage.Provider interface.
+func (*storageProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is part of the storage.Provider interface.
+func (*storageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is part of the storage.Provider interface.
+func (*storageProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is part of the storage.Provider interface.
+func (*storageProvider) Releasable() bool {
+	// VMDKs cannot be tagged, so a released volume could not be told
+	// apart from those still managed by the model.
+	return false
+}
+
+// DefaultPools is part of the storage.Provider interface.
+func (*storageProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// FilesystemSource is part of the storage.Provider interface.
+func (*storageProvider) FilesystemSource(*storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// VolumeSource is part of the storage.Provider interface.
+func (p *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
+	attrs, err := storageConfigChecker.Coerce(cfg.Attrs(), nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating vSphere storage config")
+	}
+	datastore, _ := attrs.(map[string]interface{})[vsphereDatastoreAttr].(string)
+	if datastore == "" {
+		p.env.lock.Lock()
+		datastore = p.env.ecfg.datastore()
+		p.env.lock.Unlock()
+	}
+	return &volumeSource{
+		env:       p.env,
+		datastore: datastore,
+		modelUUID: p.env.Config().UUID(),
+	}, nil
+}
+
+// volumeSource creates VMDK volumes in a datastore, and attaches them
+// to the model's VMs. Volume IDs are the datastore paths of the VMDKs.
+type volumeSource struct {
+	env       *environ
+	datastore string
+	modelUUID string
+}
+
+var _ storage.VolumeSource = (*volumeSource)(nil)
+
+// CreateVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) CreateVolumes(params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(params))
+	err := v.env.withSession(func(env *sessionEnviron) error {
+		datastore, err := v.selectDatastore(env)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for i, p := range params {
+			results[i].Volume, results[i].VolumeAttachment, results[i].Error = v.createVolume(env, datastore, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+func (v *volumeSource) createVolume(
+	env *sessionEnviron,
+	datastore *mo.Datastore,
+	p storage.VolumeParams,
+) (*storage.Volume, *storage.VolumeAttachment, error) {
+	datastorePath := fmt.Sprintf(
+		"[%s] %s/%s.vmdk", datastore.Name,
+		volumeDirectoryName(v.modelUUID), p.Tag.String(),
+	)
+	uuid, err := env.client.CreateVirtualDisk(env.ctx, datastorePath, int64(p.Size)*1024)
+	if err != nil {
+		return nil, nil, errors.Annotatef(err, "creating %s", names.ReadableString(p.Tag))
+	}
+	volume := &storage.Volume{
+		Tag: p.Tag,
+		VolumeInfo: storage.VolumeInfo{
+			VolumeId:   datastorePath,
+			WWN:        uuid,
+			Size:       p.Size,
+			Persistent: true,
+		},
+	}
+	if p.Attachment == nil {
+		return volume, nil, nil
+	}
+	if err := v.attachVolume(env, datastorePath, p.Attachment.InstanceId); err != nil {
+		return volume, nil, errors.Annotatef(err, "attaching %s to %s", names.ReadableString(p.Tag), names.ReadableString(p.Attachment.Machine))
+	}
+	return volume, &storage.VolumeAttachment{
+		Volume:  p.Tag,
+		Machine: p.Attachment.Machine,
+	}, nil
+}
+
+// selectDatastore returns the datastore in which to create volumes:
+// the one configured, or else the first accessible one.
+func (v *volumeSource) selectDatastore(env *sessionEnviron) (*mo.Datastore, error) {
+	datastores, err := env.client.Datastores(env.ctx)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing datastores")
+	}
+	for _, ds := range datastores {
+		if v.datastore != "" && ds.Name == v.datastore {
+			return ds, nil
+		}
+		if v.datastore == "" && ds.Summary.Accessible {
+			return ds, nil
+		}
+	}
+	if v.datastore != "" {
+		return nil, errors.NotFoundf("datastore %q", v.datastore)
+	}
+	return nil, errors.New("could not find an accessible datastore")
+}
+
+// ListVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ListVolumes() ([]string, error) {
+	var ids []string
+	err := v.env.withSession(func(env *sessionEnviron) error {
+		disks, err := v.virtualDisks(env)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, disk := range disks {
+			ids = append(ids, disk.DatastorePath)
+		}
+		return nil
+	})
+	return ids, errors.Trace(err)
+}
+
+// DescribeVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DescribeVolumes(volIds []string) ([]storage.DescribeVolumesResult, error) {
+	results := make([]storage.DescribeVolumesResult, len(volIds))
+	err := v.env.withSession(func(env *sessionEnviron) error {
+		disks, err := v.virtualDisks(env)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for i, id := range volIds {
+			results[i].Error = errors.NotFoundf("volume %q", id)
+			for _, disk := range disks {
+				if disk.DatastorePath != id {
+					continue
+				}
+				results[i].VolumeInfo = &storage.VolumeInfo{
+					VolumeId:   id,
+					WWN:        disk.UUID,
+					Size:       uint64(disk.CapacityKB / 1024),
+					Persistent: true,
+				}
+				results[i].Error = nil
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+// virtualDisks returns the model's volumes in every accessible
+// datastore. The datastore in which volumes are created may change
+// over time, so we must check them all.
+func (v *volumeSource) virtualDisks(env *sessionEnviron) ([]vsphereclient.VirtualDisk, error) {
+	datastores, err := env.client.Datastores(env.ctx)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing datastores")
+	}
+	var disks []vsphereclient.VirtualDisk
+	for _, ds := range datastores {
+		if !ds.Summary.Accessible {
+			continue
+		}
+		dsDisks, err := env.client.VirtualDisks(env.ctx, ds, volumeDirectoryName(v.modelUUID))
+		if err != nil {
+			return nil, errors.Annotatef(err, "listing volumes in datastore %q", ds.Name)
+		}
+		disks = append(disks, dsDisks...)
+	}
+	return disks, nil
+}
+
+// DestroyVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DestroyVolumes(volIds []string) ([]error, error) {
+	results := make([]error, len(volIds))
+	err := v.env.withSession(func(env *sessionEnviron) error {
+		for i, id := range volIds {
+			if err := env.client.DeleteVirtualDisk(env.ctx, id); err != nil {
+				results[i] = errors.Annotatef(err, "destroying volume %q", id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+// ReleaseVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ReleaseVolumes(volIds []string) ([]error, error) {
+	results := make([]error, len(volIds))
+	for i := range volIds {
+		results[i] = errors.NotSupportedf("releasing vSphere volumes")
+	}
+	return results, nil
+}
+
+// ValidateVolumeParams is part of the storage.VolumeSource interface.
+func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	return nil
+}
+
+// AttachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) AttachVolumes(params []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(params))
+	err := v.env.withSession(func(env *sessionEnviron) error {
+		for i, p := range params {
+			if err := v.attachVolume(env, p.VolumeId, p.InstanceId); err != nil {
+				results[i].Error = errors.Annotatef(err, "attaching %s to %s", names.ReadableString(p.Volume), names.ReadableString(p.Machine))
+				continue
+			}
+			results[i].VolumeAttachment = &storage.VolumeAttachment{
+				Volume:  p.Volume,
+				Machine: p.Machine,
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+func (v *volumeSource) attachVolume(env *sessionEnviron, volumeId string, instId instance.Id) error {
+	vm, err := v.virtualMachine(env, instId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return env.client.AttachVirtualDisk(env.ctx, vm, volumeId)
+}
+
+// DetachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(params))
+	err := v.env.withSession(func(env *sessionEnviron) error {
+		for i, p := range params {
+			vm, err := v.virtualMachine(env, p.InstanceId)
+			if errors.IsNotFound(err) {
+				// The VM has gone, so the volume is no longer attached.
+				continue
+			} else if err == nil {
+				err = env.client.DetachVirtualDisk(env.ctx, vm, p.VolumeId)
+			}
+			if err != nil {
+				results[i] = errors.Annotatef(err, "detaching %s from %s", names.ReadableString(p.Volume), names.ReadableString(p.Machine))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+// virtualMachine returns the model's VM with the given instance ID.
+func (v *volumeSource) virtualMachine(env *sessionEnviron, instId instance.Id) (*mo.VirtualMachine, error) {
+	vms, err := env.client.VirtualMachines(env.ctx, path.Join(
+		controllerFolderName("*"),
+		env.modelFolderName(),
+		string(instId),
+	))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(vms) == 0 {
+		return nil, errors.NotFoundf("instance %q", instId)
+	}
+	return vms[0], nil
+}
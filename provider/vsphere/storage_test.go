@@ -0,0 +1,261 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package vsphere_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/vsphere/internal/vsphereclient"
+	"github.com/juju/juju/storage"
+)
+
+type storageSuite struct {
+	EnvironFixture
+	provider storage.Provider
+	source   storage.VolumeSource
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+const volumeDir = "juju-volumes/2d02eeac-9dbb-11e4-89d3-123b93f75cba"
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	s.client.datastores = []*mo.Datastore{{
+		ManagedEntity: mo.ManagedEntity{Name: "foo"},
+	}, {
+		ManagedEntity: mo.ManagedEntity{Name: "bar"},
+		Summary:       types.DatastoreSummary{Accessible: true},
+	}, {
+		ManagedEntity: mo.ManagedEntity{Name: "baz"},
+		Summary:       types.DatastoreSummary{Accessible: true},
+	}}
+	s.client.virtualDiskUUID = "6000c29821d67a168c42bde3ad6c7b15"
+	s.client.virtualMachines = []*mo.VirtualMachine{buildVM("inst-0").vm()}
+
+	provider, err := s.env.StorageProvider("vsphere")
+	c.Assert(err, jc.ErrorIsNil)
+	s.provider = provider
+	s.source = s.volumeSource(c, nil)
+	s.client.ResetCalls()
+}
+
+func (s *storageSuite) volumeSource(c *gc.C, attrs map[string]interface{}) storage.VolumeSource {
+	cfg, err := storage.NewConfig("vsphere", "vsphere", attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	source, err := s.provider.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return source
+}
+
+func (s *storageSuite) TestStorageProviderTypes(c *gc.C) {
+	providerTypes, err := s.env.StorageProviderTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(providerTypes, jc.DeepEquals, []storage.ProviderType{"vsphere"})
+
+	_, err = s.env.StorageProvider("ebs")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageSuite) TestProvider(c *gc.C) {
+	c.Assert(s.provider.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(s.provider.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+	c.Assert(s.provider.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(s.provider.Dynamic(), jc.IsTrue)
+
+	cfg, err := storage.NewConfig("vsphere", "vsphere", map[string]interface{}{"datastore": 123})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.provider.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `validating vSphere storage config: datastore: expected string, got int\(123\)`)
+}
+
+func (s *storageSuite) TestCreateVolumes(c *gc.C) {
+	results, err := s.source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}, {
+		Tag:  names.NewVolumeTag("1"),
+		Size: 2048,
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				Machine:    names.NewMachineTag("0"),
+				InstanceId: "inst-0",
+			},
+			Volume: names.NewVolumeTag("1"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateVolumesResult{{
+		Volume: &storage.Volume{
+			Tag: names.NewVolumeTag("0"),
+			VolumeInfo: storage.VolumeInfo{
+				VolumeId:   "[bar] " + volumeDir + "/volume-0.vmdk",
+				WWN:        "6000c29821d67a168c42bde3ad6c7b15",
+				Size:       1024,
+				Persistent: true,
+			},
+		},
+	}, {
+		Volume: &storage.Volume{
+			Tag: names.NewVolumeTag("1"),
+			VolumeInfo: storage.VolumeInfo{
+				VolumeId:   "[bar] " + volumeDir + "/volume-1.vmdk",
+				WWN:        "6000c29821d67a168c42bde3ad6c7b15",
+				Size:       2048,
+				Persistent: true,
+			},
+		},
+		VolumeAttachment: &storage.VolumeAttachment{
+			Volume:  names.NewVolumeTag("1"),
+			Machine: names.NewMachineTag("0"),
+		},
+	}})
+
+	s.client.CheckCallNames(c,
+		"Datastores", "CreateVirtualDisk", "CreateVirtualDisk",
+		"VirtualMachines", "AttachVirtualDisk", "Close",
+	)
+	calls := s.client.Calls()
+	c.Assert(calls[1].Args[1:], jc.DeepEquals, []interface{}{"[bar] " + volumeDir + "/volume-0.vmdk", int64(1024 * 1024)})
+	c.Assert(calls[3].Args[1], gc.Equals,
+		`Juju Controller (*)/Model "testenv" (2d02eeac-9dbb-11e4-89d3-123b93f75cba)/inst-0`,
+	)
+	c.Assert(calls[4].Args[1:], jc.DeepEquals, []interface{}{
+		s.client.virtualMachines[0], "[bar] " + volumeDir + "/volume-1.vmdk",
+	})
+}
+
+func (s *storageSuite) TestCreateVolumesDatastore(c *gc.C) {
+	source := s.volumeSource(c, map[string]interface{}{"datastore": "baz"})
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.VolumeId, gc.Equals, "[baz] "+volumeDir+"/volume-0.vmdk")
+
+	source = s.volumeSource(c, map[string]interface{}{"datastore": "qux"})
+	_, err = source.CreateVolumes([]storage.VolumeParams{{Tag: names.NewVolumeTag("0"), Size: 1024}})
+	c.Assert(err, gc.ErrorMatches, `datastore "qux" not found`)
+}
+
+func (s *storageSuite) TestCreateVolumesError(c *gc.C) {
+	s.client.SetErrors(nil, errors.New("no space"))
+	results, err := s.source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "creating volume 0: no space")
+}
+
+func (s *storageSuite) TestListAndDescribeVolumes(c *gc.C) {
+	s.client.virtualDisks = map[string][]vsphereclient.VirtualDisk{
+		"bar": {{DatastorePath: "[bar] " + volumeDir + "/volume-0.vmdk", CapacityKB: 1024 * 1024, UUID: "abc"}},
+		"baz": {{DatastorePath: "[baz] " + volumeDir + "/volume-1.vmdk", CapacityKB: 2048 * 1024, UUID: "def"}},
+	}
+	ids, err := s.source.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []string{
+		"[bar] " + volumeDir + "/volume-0.vmdk",
+		"[baz] " + volumeDir + "/volume-1.vmdk",
+	})
+	// Inaccessible datastores are not searched.
+	s.client.CheckCallNames(c, "Datastores", "VirtualDisks", "VirtualDisks", "Close")
+	c.Assert(s.client.Calls()[1].Args[2], gc.Equals, volumeDir)
+
+	results, err := s.source.DescribeVolumes([]string{
+		"[baz] " + volumeDir + "/volume-1.vmdk",
+		"[bar] " + volumeDir + "/volume-2.vmdk",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId:   "[baz] " + volumeDir + "/volume-1.vmdk",
+		WWN:        "def",
+		Size:       2048,
+		Persistent: true,
+	})
+	c.Assert(results[1].Error, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
+	s.client.SetErrors(nil, errors.New("locked"))
+	results, err := s.source.DestroyVolumes([]string{"[bar] a.vmdk", "[bar] b.vmdk"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], gc.ErrorMatches, `destroying volume "\[bar\] b.vmdk": locked`)
+	s.client.CheckCall(c, 0, "DeleteVirtualDisk", s.client.Calls()[0].Args[0], "[bar] a.vmdk")
+}
+
+func (s *storageSuite) TestReleaseVolumes(c *gc.C) {
+	c.Assert(s.provider.Releasable(), jc.IsFalse)
+	results, err := s.source.ReleaseVolumes([]string{"[bar] a.vmdk"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0], jc.Satisfies, errors.IsNotSupported)
+}
+
+func attachmentParams(volumeId string, instId instance.Id) storage.VolumeAttachmentParams {
+	return storage.VolumeAttachmentParams{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: instId,
+		},
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: volumeId,
+	}
+}
+
+func (s *storageSuite) TestAttachVolumes(c *gc.C) {
+	results, err := s.source.AttachVolumes([]storage.VolumeAttachmentParams{
+		attachmentParams("[bar] a.vmdk", "inst-0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{
+			Volume:  names.NewVolumeTag("0"),
+			Machine: names.NewMachineTag("0"),
+		},
+	}})
+	s.client.CheckCallNames(c, "VirtualMachines", "AttachVirtualDisk", "Close")
+}
+
+func (s *storageSuite) TestAttachVolumesInstanceNotFound(c *gc.C) {
+	s.client.virtualMachines = nil
+	results, err := s.source.AttachVolumes([]storage.VolumeAttachmentParams{
+		attachmentParams("[bar] a.vmdk", "inst-1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, `attaching volume 0 to machine 0: instance "inst-1" not found`)
+}
+
+func (s *storageSuite) TestDetachVolumes(c *gc.C) {
+	results, err := s.source.DetachVolumes([]storage.VolumeAttachmentParams{
+		attachmentParams("[bar] a.vmdk", "inst-0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+	s.client.CheckCallNames(c, "VirtualMachines", "DetachVirtualDisk", "Close")
+	c.Assert(s.client.Calls()[1].Args[2], gc.Equals, "[bar] a.vmdk")
+}
+
+func (s *storageSuite) TestDetachVolumesInstanceGone(c *gc.C) {
+	s.client.virtualMachines = nil
+	results, err := s.source.DetachVolumes([]storage.VolumeAttachmentParams{
+		attachmentParams("[bar] a.vmdk", "inst-1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+	s.client.CheckCallNames(c, "VirtualMachines", "Close")
+}
@@ -22,6 +22,10 @@ type Call struct {
 	Response interface{}
 	Error    error
 	Done     chan *Call
+
+	// Stream, if non-nil, receives the partial responses to a
+	// streaming request.
+	Stream StreamHandler
 }
 
 // RequestError represents an error returned from an RPC request.
@@ -85,6 +89,9 @@ func (conn *Conn) send(call *Call) {
 }
 
 func (conn *Conn) handleResponse(hdr *Header) error {
+	if hdr.More {
+		return conn.handlePartialResponse(hdr)
+	}
 	reqId := hdr.RequestId
 	conn.mutex.Lock()
 	call := conn.clientPending[reqId]
@@ -121,6 +128,29 @@ func (conn *Conn) handleResponse(hdr *Header) error {
 	return errors.Annotate(err, "error handling response")
 }
 
+// handlePartialResponse passes a partial response to a streaming
+// request to the request's StreamHandler. Unlike a final response,
+// it leaves the request pending.
+func (conn *Conn) handlePartialResponse(hdr *Header) error {
+	conn.mutex.Lock()
+	call := conn.clientPending[hdr.RequestId]
+	conn.mutex.Unlock()
+
+	if call == nil || call.Stream == nil {
+		// Either the request has gone away, or it was not expecting
+		// to be streamed to; there's nothing sensible to do but
+		// drop the response.
+		logger.Debugf("discarding unexpected partial response to request %d", hdr.RequestId)
+		return errors.Annotate(conn.readBody(nil, false), "error handling partial response")
+	}
+	response := call.Stream.NewResponse()
+	if err := conn.readBody(response, false); err != nil {
+		return errors.Annotate(err, "error handling partial response")
+	}
+	call.Stream.Handle(response)
+	return nil
+}
+
 func (call *Call) done() {
 	select {
 	case call.Done <- call:
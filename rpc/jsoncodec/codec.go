@@ -72,6 +72,7 @@ type inMsgV1 struct {
 	Error     string          `json:"error"`
 	ErrorCode string          `json:"error-code"`
 	Response  json.RawMessage `json:"response"`
+	More      bool            `json:"more"`
 }
 
 // outMsg holds an outgoing message.
@@ -97,6 +98,7 @@ type outMsgV1 struct {
 	Error     string      `json:"error,omitempty"`
 	ErrorCode string      `json:"error-code,omitempty"`
 	Response  interface{} `json:"response,omitempty"`
+	More      bool        `json:"more,omitempty"`
 }
 
 func (c *Codec) Close() error {
@@ -139,6 +141,7 @@ func (c *Codec) ReadHeader(hdr *rpc.Header) error {
 	}
 	hdr.Error = c.msg.Error
 	hdr.ErrorCode = c.msg.ErrorCode
+	hdr.More = c.msg.More
 	hdr.Version = version
 	return nil
 }
@@ -230,6 +233,11 @@ func (c *Codec) WriteMessage(hdr *rpc.Header, body interface{}) error {
 func response(hdr *rpc.Header, body interface{}) (interface{}, error) {
 	switch hdr.Version {
 	case 0:
+		if hdr.More {
+			// Clients old enough to use the version 0 format
+			// cannot make streaming requests.
+			return nil, errors.Errorf("partial responses not supported by version 0")
+		}
 		return newOutMsgV0(hdr, body), nil
 	case 1:
 		return newOutMsgV1(hdr, body), nil
@@ -272,6 +280,7 @@ func newOutMsgV1(hdr *rpc.Header, body interface{}) outMsgV1 {
 		Request:   hdr.Request.Action,
 		Error:     hdr.Error,
 		ErrorCode: hdr.ErrorCode,
+		More:      hdr.More,
 	}
 	if hdr.IsRequest() {
 		result.Params = body
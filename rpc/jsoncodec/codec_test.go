@@ -115,6 +115,14 @@ func (*suite) TestRead(c *gc.C) {
 			Version: 1,
 		},
 		expectBody: &value{X: "param"},
+	}, {
+		msg: `{"request-id": 5, "response": {"X": "partial"}, "more": true}`,
+		expectHdr: rpc.Header{
+			RequestId: 5,
+			Version:   1,
+			More:      true,
+		},
+		expectBody: &value{X: "partial"},
 	}} {
 		c.Logf("test %d", i)
 		codec := jsoncodec.New(&testConn{
@@ -236,6 +244,14 @@ func (*suite) TestWrite(c *gc.C) {
 		},
 		body:   &value{X: "param"},
 		expect: `{"request-id": 4, "type": "foo", "version": 2, "request": "frob", "params": {"X": "param"}}`,
+	}, {
+		hdr: &rpc.Header{
+			RequestId: 5,
+			Version:   1,
+			More:      true,
+		},
+		body:   &value{X: "partial"},
+		expect: `{"request-id": 5, "response": {"X": "partial"}, "more": true}`,
 	}} {
 		c.Logf("test %d", i)
 		var conn testConn
@@ -248,6 +264,14 @@ func (*suite) TestWrite(c *gc.C) {
 	}
 }
 
+func (*suite) TestWritePartialResponseV0(c *gc.C) {
+	var conn testConn
+	codec := jsoncodec.New(&conn)
+	err := codec.WriteMessage(&rpc.Header{RequestId: 1, More: true}, &value{X: "partial"})
+	c.Assert(err, gc.ErrorMatches, "partial responses not supported by version 0")
+	c.Assert(conn.writeMsgs, gc.HasLen, 0)
+}
+
 func (*suite) TestDumpRequest(c *gc.C) {
 	for i, test := range []struct {
 		hdr    rpc.Header
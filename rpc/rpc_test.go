@@ -16,9 +16,12 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/tracing"
+	"github.com/juju/juju/core/tracing/tracingtest"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 	"github.com/juju/juju/rpc/rpcreflect"
@@ -228,6 +231,22 @@ func (c *ContextMethods) Wait(ctx context.Context) error {
 	}
 }
 
+// Stream sends each of the given strings as a partial response.
+func (c *ContextMethods) Stream(ctx context.Context, args stringsVal) error {
+	c.root.called(c, "Stream", args)
+	c.callContext = ctx
+	sender, ok := rpc.SenderFromContext(ctx)
+	if !ok {
+		return errors.New("no sender")
+	}
+	for _, s := range args.Vals {
+		if err := sender.Send(stringVal{s}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *ContextMethods) checkContext(ctx context.Context) error {
 	select {
 	case <-ctx.Done():
@@ -1116,6 +1135,109 @@ func (*rpcSuite) TestCodeNotImplementedMatchesAPIserverParams(c *gc.C) {
 	c.Assert(rpc.CodeNotImplemented, gc.Equals, params.CodeNotImplemented)
 }
 
+func (*rpcSuite) TestRequestSpans(c *gc.C) {
+	root := &Root{
+		errorInst: &ErrorMethods{errors.New("boom")},
+	}
+	root.contextInst = &ContextMethods{root: root}
+
+	exporter := &tracingtest.Exporter{}
+	ctx := tracing.WithTracer(context.Background(), tracing.NewTracer(exporter, clock.WallClock))
+	client, _, srvDone, _ := newRPCClientServerContext(c, ctx, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	err := client.Call(rpc.Request{"ContextMethods", 0, "", "Call0"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	// The method is passed a context carrying the request's span,
+	// so that it can record spans of its own.
+	c.Assert(tracing.SpanFromContext(root.contextInst.callContext), gc.NotNil)
+
+	err = client.Call(rpc.Request{"ErrorMethods", 0, "", "Call"}, nil, nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	spans := exporter.Spans()
+	c.Assert(spans, gc.HasLen, 2)
+	c.Assert(spans[0].Name, gc.Equals, "rpc ContextMethods.Call0")
+	c.Assert(spans[0].Error, gc.Equals, "")
+	c.Assert(spans[0].Attributes, jc.DeepEquals, map[string]interface{}{
+		"rpc.facade":     "ContextMethods",
+		"rpc.version":    0,
+		"rpc.method":     "Call0",
+		"rpc.request-id": uint64(1),
+	})
+	c.Assert(spans[1].Name, gc.Equals, "rpc ErrorMethods.Call")
+	c.Assert(spans[1].Error, gc.Equals, "boom")
+	c.Assert(spans[1].TraceID, gc.Not(gc.Equals), spans[0].TraceID)
+}
+
+type stringsVal struct {
+	Vals []string
+}
+
+// recordingHandler is an rpc.StreamHandler that records the partial
+// responses it is given.
+type recordingHandler struct {
+	mu        sync.Mutex
+	responses []string
+}
+
+func (h *recordingHandler) NewResponse() interface{} {
+	return new(stringVal)
+}
+
+func (h *recordingHandler) Handle(response interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.responses = append(h.responses, response.(*stringVal).Val)
+}
+
+func (*rpcSuite) TestCallStream(c *gc.C) {
+	root := &Root{}
+	root.contextInst = &ContextMethods{root: root}
+	client, _, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	var handler recordingHandler
+	args := stringsVal{Vals: []string{"one", "two", "three"}}
+	err := client.CallStream(rpc.Request{"ContextMethods", 0, "", "Stream"}, args, &handler)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(handler.responses, jc.DeepEquals, []string{"one", "two", "three"})
+
+	// Once the request has completed, nothing more may be sent.
+	sender, ok := rpc.SenderFromContext(root.contextInst.callContext)
+	c.Assert(ok, jc.IsTrue)
+	err = sender.Send(stringVal{"four"})
+	c.Assert(err, gc.ErrorMatches, "request has completed")
+}
+
+func (*rpcSuite) TestCallStreamError(c *gc.C) {
+	root := &Root{
+		errorInst: &ErrorMethods{errors.New("boom")},
+	}
+	client, _, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	var handler recordingHandler
+	err := client.CallStream(rpc.Request{"ErrorMethods", 0, "", "Call"}, nil, &handler)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(handler.responses, gc.HasLen, 0)
+}
+
+func (*rpcSuite) TestCallDiscardsPartialResponses(c *gc.C) {
+	root := &Root{}
+	root.contextInst = &ContextMethods{root: root}
+	client, _, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	// A client that doesn't expect a streamed response still sees
+	// the request complete, and the connection remains usable.
+	args := stringsVal{Vals: []string{"one", "two"}}
+	err := client.Call(rpc.Request{"ContextMethods", 0, "", "Stream"}, args, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.Call(rpc.Request{"ContextMethods", 0, "", "Call0"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (*rpcSuite) TestRequestContext(c *gc.C) {
 	root := &Root{}
 	root.contextInst = &ContextMethods{root: root}
@@ -1211,6 +1333,18 @@ func newRPCClientServer(
 	tfErr func(error) error,
 	bidir bool,
 ) (client *rpc.Conn, server *rpc.Conn, srvDone chan error, serverNotifier *notifier) {
+	return newRPCClientServerContext(c, context.Background(), root, tfErr, bidir)
+}
+
+// newRPCClientServerContext is like newRPCClientServer, but starts
+// the server connection with the given context.
+func newRPCClientServerContext(
+	c *gc.C,
+	ctx context.Context,
+	root interface{},
+	tfErr func(error) error,
+	bidir bool,
+) (client *rpc.Conn, server *rpc.Conn, srvDone chan error, serverNotifier *notifier) {
 
 	l, err := net.Listen("tcp", "127.0.0.1:0")
 	c.Assert(err, jc.ErrorIsNil)
@@ -1243,7 +1377,7 @@ func newRPCClientServer(
 		if root, ok := root.(*Root); ok {
 			root.conn = rpcConn
 		}
-		rpcConn.Start(context.Background())
+		rpcConn.Start(ctx)
 		srvStarted <- rpcConn
 		<-rpcConn.Dead()
 		srvDone <- rpcConn.Close()
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/rpc/rpcreflect"
 )
 
@@ -67,6 +68,11 @@ type Header struct {
 
 	// Version defines the wire format of the request and response structure.
 	Version int
+
+	// More is set on partial responses to streaming requests, to
+	// indicate that further responses to the same request will
+	// follow. See Sender for details.
+	More bool
 }
 
 // Request represents an RPC to be performed, absent its parameters.
@@ -219,6 +225,10 @@ func (conn *Conn) Start(ctx context.Context) {
 //	Method([context.Context,]T) (R, error)
 //	Method([context.Context,]T) error
 //
+// A method that takes a context may also use it to obtain a Sender,
+// with SenderFromContext, and stream partial responses to the client
+// before it returns.
+//
 // If transformErrors is non-nil, it will be called on all returned
 // non-nil errors, for example to transform the errors into ServerErrors
 // with specified codes.  There will be a panic if transformErrors
@@ -540,7 +550,25 @@ func (conn *Conn) runRequest(
 	ctx, cancel := context.WithCancel(conn.context)
 	defer cancel()
 
+	sender := &requestSender{
+		conn:      conn,
+		requestId: req.hdr.RequestId,
+		version:   version,
+	}
+	ctx = WithSender(ctx, sender)
+
+	// Record a span covering the call, if the connection's
+	// context carries a tracer.
+	ctx, span := tracing.Start(ctx, "rpc "+req.hdr.Request.Type+"."+req.hdr.Request.Action)
+	span.SetAttribute("rpc.facade", req.hdr.Request.Type)
+	span.SetAttribute("rpc.version", req.hdr.Request.Version)
+	span.SetAttribute("rpc.method", req.hdr.Request.Action)
+	span.SetAttribute("rpc.request-id", req.hdr.RequestId)
+
 	rv, err := req.Call(ctx, req.hdr.Request.Id, arg)
+	sender.close()
+	span.RecordError(err)
+	span.End()
 	if err != nil {
 		err = conn.writeErrorResponse(&req.hdr, req.transformErrors(err), observer)
 	} else {
@@ -0,0 +1,113 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rpc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+// A streaming request is one to which the server sends any number of
+// partial responses, each marked with Header.More, before the final
+// response that completes the request. This allows, for example, a
+// watcher to push each change to the client as it happens, rather
+// than waiting for the client to ask for it.
+//
+// Clients that do not know about partial responses would treat the
+// first as final, so servers must only send them in reply to requests
+// that the client knows to be streaming. In practice, that means the
+// streaming methods must be introduced in a new facade version.
+
+// Sender is used by a method serving a streaming request to send
+// partial responses to the client.
+type Sender interface {
+	// Send sends the given value to the client as a partial
+	// response. The value must be a struct, as for ordinary
+	// responses. Send returns an error if the request has
+	// already completed.
+	Send(body interface{}) error
+}
+
+type senderKey struct{}
+
+// WithSender returns a copy of ctx that carries the given Sender. A
+// Conn does this for every request it serves; it is exported so that
+// streaming methods can be tested without one.
+func WithSender(ctx context.Context, sender Sender) context.Context {
+	return context.WithValue(ctx, senderKey{}, sender)
+}
+
+// SenderFromContext returns the Sender for the request whose context
+// is given, and reports whether there is one. There is a Sender for
+// every request served by a Conn.
+func SenderFromContext(ctx context.Context) (Sender, bool) {
+	sender, ok := ctx.Value(senderKey{}).(Sender)
+	return sender, ok
+}
+
+// requestSender implements Sender for a single request.
+type requestSender struct {
+	conn      *Conn
+	requestId uint64
+	version   int
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Send is part of the Sender interface.
+func (s *requestSender) Send(body interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errors.New("request has completed")
+	}
+	hdr := &Header{
+		RequestId: s.requestId,
+		Version:   s.version,
+		More:      true,
+	}
+	s.conn.sending.Lock()
+	defer s.conn.sending.Unlock()
+	return errors.Trace(s.conn.codec.WriteMessage(hdr, body))
+}
+
+// close prevents any further partial responses from being sent, so
+// that none may follow the final response.
+func (s *requestSender) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+}
+
+// StreamHandler receives the partial responses to a streaming request
+// made with Conn.CallStream.
+type StreamHandler interface {
+	// NewResponse returns a pointer to a value into which the next
+	// partial response will be decoded.
+	NewResponse() interface{}
+
+	// Handle is called with each partial response, in the order in
+	// which they were sent. It is called by the connection's input
+	// loop, so it must not block.
+	Handle(response interface{})
+}
+
+// CallStream invokes a streaming method, as for Call, passing each
+// partial response sent by the server to handler. It returns when the
+// server sends the final response, whose body is discarded, or when
+// the connection is shut down.
+func (conn *Conn) CallStream(req Request, params interface{}, handler StreamHandler) error {
+	call := &Call{
+		Request: req,
+		Params:  params,
+		Stream:  handler,
+		Done:    make(chan *Call, 1),
+	}
+	conn.send(call)
+	result := <-call.Done
+	return errors.Trace(result.Error)
+}
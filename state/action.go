@@ -4,6 +4,7 @@
 package state
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
@@ -17,6 +18,14 @@ import (
 
 const (
 	actionMarker = "_a_"
+
+	// maxActionMessages is the number of progress messages kept for
+	// each action when actions are pruned.
+	maxActionMessages = 1000
+
+	// maxActionMessageLength is the maximum length, in bytes, of a
+	// progress message.
+	maxActionMessageLength = 1024
 )
 
 var (
@@ -104,6 +113,30 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Logs holds the progress messages logged by the action while
+	// it was running, oldest first.
+	Logs []ActionMessage `bson:"messages"`
+
+	// LogsCount is the number of messages logged by the action,
+	// including those since dropped from the front of Logs.
+	LogsCount int `bson:"messages-count"`
+
+	// Rollout is the id of the action rollout that enqueued this
+	// action, if any.
+	Rollout string `bson:"rollout,omitempty"`
+
+	// Operation is the id of the operation this action belongs to,
+	// if any. The actions enqueued by a single request share an
+	// operation.
+	Operation string `bson:"operation,omitempty"`
+}
+
+// ActionMessage is a timestamped progress message logged by a
+// running action.
+type ActionMessage struct {
+	Message   string    `bson:"message" json:"message"`
+	Timestamp time.Time `bson:"timestamp" json:"timestamp"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -136,6 +169,12 @@ func (a *action) Parameters() map[string]interface{} {
 	return a.doc.Parameters
 }
 
+// Operation returns the id of the operation the action belongs to, or
+// "" if it does not belong to one.
+func (a *action) Operation() string {
+	return a.doc.Operation
+}
+
 // Enqueued returns the time the action was added to state as a pending
 // Action.
 func (a *action) Enqueued() time.Time {
@@ -162,6 +201,36 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Messages returns the progress messages logged by the action, oldest
+// first. Old messages may have been dropped.
+func (a *action) Messages() []ActionMessage {
+	return a.doc.Logs
+}
+
+// Log adds a timestamped progress message to the action, which must
+// be running. Old messages are dropped when actions are pruned.
+func (a *action) Log(message string) error {
+	if len(message) > maxActionMessageLength {
+		return errors.NotValidf("message longer than %d bytes", maxActionMessageLength)
+	}
+	err := a.st.db().RunTransaction([]txn.Op{{
+		C:      actionsC,
+		Id:     a.doc.DocId,
+		Assert: bson.D{{"status", ActionRunning}},
+		Update: bson.D{
+			{"$push", bson.D{{"messages", ActionMessage{
+				Message:   message,
+				Timestamp: a.st.clock().Now().UTC(),
+			}}}},
+			{"$inc", bson.D{{"messages-count", 1}}},
+		},
+	}})
+	if err == txn.ErrAborted {
+		return errors.Errorf("cannot log message to action %q: action is not running", a.Id())
+	}
+	return errors.Annotatef(err, "cannot log message to action %q", a.Id())
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -225,7 +294,7 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 		return nil, errors.Trace(err)
 	}
 
-	err = m.st.db().RunTransaction([]txn.Op{
+	ops := []txn.Op{
 		{
 			C:  actionsC,
 			Id: a.doc.DocId,
@@ -245,7 +314,16 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 			C:      actionNotificationsC,
 			Id:     m.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
 			Remove: true,
-		}})
+		}}
+	if a.doc.Rollout != "" {
+		// Let the rollout know that one of its actions is done.
+		ops = append(ops, txn.Op{
+			C:      actionRolloutsC,
+			Id:     m.st.docID(a.doc.Rollout),
+			Update: bson.D{{"$inc", bson.D{{"finished", 1}}}},
+		})
+	}
+	err = m.st.db().RunTransaction(ops)
 	if err != nil {
 		return nil, err
 	}
@@ -369,8 +447,15 @@ func (m *Model) FindActionsByName(name string) ([]Action, error) {
 	return results, errors.Trace(iter.Close())
 }
 
-// EnqueueAction
+// EnqueueAction queues an action with the given name and payload for
+// the receiver, outside of any operation.
 func (m *Model) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (Action, error) {
+	return m.EnqueueOperationAction("", receiver, actionName, payload)
+}
+
+// EnqueueOperationAction queues an action with the given name and
+// payload for the receiver, as part of the given operation.
+func (m *Model) EnqueueOperationAction(operation string, receiver names.Tag, actionName string, payload map[string]interface{}) (Action, error) {
 	if len(actionName) == 0 {
 		return nil, errors.New("action name required")
 	}
@@ -380,11 +465,44 @@ func (m *Model) EnqueueAction(receiver names.Tag, actionName string, payload map
 		return nil, errors.Trace(err)
 	}
 
-	doc, ndoc, err := newActionDoc(m.st, receiver, actionName, payload)
+	doc, ops, err := m.enqueueActionOps(receiver, actionName, payload, operation, "")
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if notDead, err := isNotDead(m.st, receiverCollectionName, receiverId); err != nil {
+			return nil, err
+		} else if !notDead {
+			return nil, ErrDead
+		} else if attempt != 0 {
+			return nil, errors.Errorf("unexpected attempt number '%d'", attempt)
+		}
+		return ops, nil
+	}
+	if err = m.st.db().Run(buildTxn); err == nil {
+		return newAction(m.st, doc), nil
+	}
+	return nil, err
+}
+
+// enqueueActionOps returns the document for a new action, and the
+// operations needed to queue it for the receiver, as part of the given
+// operation. If the action is enqueued by an action rollout, rollout
+// holds the rollout's id.
+func (m *Model) enqueueActionOps(receiver names.Tag, actionName string, payload map[string]interface{}, operation, rollout string) (actionDoc, []txn.Op, error) {
+	receiverCollectionName, receiverId, err := m.st.tagToCollectionAndId(receiver)
+	if err != nil {
+		return actionDoc{}, nil, errors.Trace(err)
+	}
+
+	doc, ndoc, err := newActionDoc(m.st, receiver, actionName, payload)
+	if err != nil {
+		return actionDoc{}, nil, errors.Trace(err)
+	}
+	doc.Operation = operation
+	doc.Rollout = rollout
+
 	ops := []txn.Op{{
 		C:      receiverCollectionName,
 		Id:     receiverId,
@@ -400,21 +518,7 @@ func (m *Model) EnqueueAction(receiver names.Tag, actionName string, payload map
 		Assert: txn.DocMissing,
 		Insert: ndoc,
 	}}
-
-	buildTxn := func(attempt int) ([]txn.Op, error) {
-		if notDead, err := isNotDead(m.st, receiverCollectionName, receiverId); err != nil {
-			return nil, err
-		} else if !notDead {
-			return nil, ErrDead
-		} else if attempt != 0 {
-			return nil, errors.Errorf("unexpected attempt number '%d'", attempt)
-		}
-		return ops, nil
-	}
-	if err = m.st.db().Run(buildTxn); err == nil {
-		return newAction(m.st, doc), nil
-	}
-	return nil, err
+	return doc, ops, nil
 }
 
 // matchingActions finds actions that match ActionReceiver.
@@ -484,7 +588,46 @@ func (st *State) matchingActionsByReceiverAndStatus(tag names.Tag, statusConditi
 // only logs newer than <maxLogTime> remain and also ensures
 // that the collection is smaller than <maxLogsMB> after the
 // deletion.
+//
+// It also drops the oldest progress messages of any action with
+// more than maxActionMessages of them, so that a chatty action
+// cannot grow its document without bound.
 func PruneActions(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
 	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, actionsC, "completed", GoTime)
-	return errors.Trace(err)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(pruneActionMessages(st, maxActionMessages))
+}
+
+// pruneActionMessages drops the oldest progress messages of every
+// action that has more than max of them. The number of messages
+// logged is unchanged, so log watchers keep their place.
+func pruneActionMessages(st *State, max int) error {
+	actions, closer := st.db().GetCollection(actionsC)
+	defer closer()
+
+	var docs []actionDoc
+	sel := bson.D{{fmt.Sprintf("messages.%d", max), bson.D{{"$exists", true}}}}
+	fields := bson.D{{"messages", 1}}
+	if err := actions.Find(sel).Select(fields).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot find actions to prune messages from")
+	}
+	for _, doc := range docs {
+		err := st.db().RunTransaction([]txn.Op{{
+			C:  actionsC,
+			Id: doc.DocId,
+			// If more messages have been logged since we
+			// looked, leave the action for next time.
+			Assert: bson.D{{"messages", bson.D{{"$size", len(doc.Logs)}}}},
+			Update: bson.D{{"$push", bson.D{{"messages", bson.D{
+				{"$each", []ActionMessage{}},
+				{"$slice", -max},
+			}}}}},
+		}})
+		if err != nil && err != txn.ErrAborted {
+			return errors.Annotatef(err, "cannot prune messages of action %q", st.localID(doc.DocId))
+		}
+	}
+	return nil
 }
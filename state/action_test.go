@@ -5,6 +5,7 @@ package state_test
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -305,6 +306,23 @@ func makeUnits(c *gc.C, s *ActionSuite, units map[string]*state.Unit, schemas ma
 	}
 }
 
+func (s *ActionSuite) TestPendingActionCount(c *gc.C) {
+	a1, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err := s.State.PendingActionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 2)
+
+	_, err = a1.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	count, err = s.State.PendingActionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 1)
+}
+
 func (s *ActionSuite) TestEnqueueActionRequiresName(c *gc.C) {
 	name := ""
 
@@ -866,6 +884,132 @@ func (s *ActionSuite) TestActionStatusWatcher(c *gc.C) {
 	watchCancelledOrCompleted.AssertNoChange()
 }
 
+func (s *ActionSuite) runningAction(c *gc.C) state.Action {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	return a
+}
+
+func (s *ActionSuite) TestLog(c *gc.C) {
+	a := s.runningAction(c)
+	err := a.Log("first")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("second")
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err = s.model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message, gc.Equals, "first")
+	c.Assert(messages[1].Message, gc.Equals, "second")
+	c.Assert(messages[0].Timestamp.IsZero(), jc.IsFalse)
+}
+
+func (s *ActionSuite) TestLogNotRunning(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("too soon")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action is not running`)
+
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("too late")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action is not running`)
+}
+
+func (s *ActionSuite) TestWatchActionLogs(c *gc.C) {
+	a := s.runningAction(c)
+	err := a.Log("first")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchActionLogs(a.Id())
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	s.assertActionLogChange(c, wc, "first")
+	wc.AssertNoChange()
+
+	err = a.Log("second")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("third")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertActionLogChange(c, wc, "second", "third")
+	wc.AssertNoChange()
+}
+
+func (s *ActionSuite) TestPruneActionMessages(c *gc.C) {
+	a := s.runningAction(c)
+	for _, message := range []string{"one", "two", "three"} {
+		err := a.Log(message)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := state.PruneActionMessages(s.State, 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err = s.model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message, gc.Equals, "two")
+	c.Assert(messages[1].Message, gc.Equals, "three")
+}
+
+func (s *ActionSuite) TestLogMessageTooLong(c *gc.C) {
+	a := s.runningAction(c)
+	err := a.Log(strings.Repeat("x", 1025))
+	c.Assert(err, gc.ErrorMatches, "message longer than 1024 bytes not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	err = a.Log(strings.Repeat("x", 1024))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionSuite) TestWatchActionLogsAfterPruning(c *gc.C) {
+	a := s.runningAction(c)
+	for _, message := range []string{"one", "two", "three"} {
+		err := a.Log(message)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := state.PruneActionMessages(s.State, 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchActionLogs(a.Id())
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	s.assertActionLogChange(c, wc, "two", "three")
+
+	// Pruning messages doesn't cause old messages to be reported
+	// again, or new ones to be missed.
+	err = a.Log("four")
+	c.Assert(err, jc.ErrorIsNil)
+	err = state.PruneActionMessages(s.State, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertActionLogChange(c, wc, "four")
+	wc.AssertNoChange()
+}
+
+func (s *ActionSuite) assertActionLogChange(c *gc.C, wc statetesting.StringsWatcherC, expect ...string) {
+	s.State.StartSync()
+	select {
+	case changes, ok := <-wc.Watcher.Changes():
+		c.Assert(ok, jc.IsTrue)
+		var messages []string
+		for _, change := range changes {
+			var message state.ActionMessage
+			err := json.Unmarshal([]byte(change), &message)
+			c.Assert(err, jc.ErrorIsNil)
+			messages = append(messages, message.Message)
+		}
+		c.Assert(messages, jc.DeepEquals, expect)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("watcher did not send change")
+	}
+}
+
 func expectActionIds(actions ...state.Action) []string {
 	ids := make([]string, len(actions))
 	for i, action := range actions {
@@ -917,6 +1061,9 @@ var _ state.ActionReceiver = (*mockAR)(nil)
 func (r mockAR) AddAction(name string, payload map[string]interface{}) (state.Action, error) {
 	return nil, nil
 }
+func (r mockAR) AddOperationAction(operation, name string, payload map[string]interface{}) (state.Action, error) {
+	return nil, nil
+}
 func (r mockAR) CancelAction(state.Action) (state.Action, error) { return nil, nil }
 func (r mockAR) WatchActionNotifications() state.StringsWatcher  { return nil }
 func (r mockAR) Actions() ([]state.Action, error)                { return nil, nil }
@@ -0,0 +1,648 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// ActionRolloutStatus represents the state of an action rollout.
+type ActionRolloutStatus string
+
+const (
+	// ActionRolloutRunning indicates that the rollout is still
+	// enqueueing actions on, or waiting for, its units.
+	ActionRolloutRunning ActionRolloutStatus = "running"
+
+	// ActionRolloutCompleted indicates that the action has been run
+	// on every unit without exceeding the failure budget.
+	ActionRolloutCompleted ActionRolloutStatus = "completed"
+
+	// ActionRolloutFailed indicates that the rollout was stopped
+	// because too many of its actions failed.
+	ActionRolloutFailed ActionRolloutStatus = "failed"
+
+	// ActionRolloutCancelled indicates that the rollout was cancelled
+	// before it finished.
+	ActionRolloutCancelled ActionRolloutStatus = "cancelled"
+)
+
+// ActionRolloutOrder determines where the application leader is placed
+// in the order in which a rollout visits units.
+type ActionRolloutOrder string
+
+const (
+	// ActionRolloutUnitOrder visits units in unit number order,
+	// without regard to leadership.
+	ActionRolloutUnitOrder ActionRolloutOrder = ""
+
+	// ActionRolloutLeaderFirst visits the leader before any other
+	// unit.
+	ActionRolloutLeaderFirst ActionRolloutOrder = "leader-first"
+
+	// ActionRolloutLeaderLast visits the leader after every other
+	// unit.
+	ActionRolloutLeaderLast ActionRolloutOrder = "leader-last"
+)
+
+// ActionRolloutArgs holds the parameters for running an action across
+// the units of an application.
+type ActionRolloutArgs struct {
+	// Application is the name of the application whose units will
+	// run the action.
+	Application string
+
+	// Name is the name of the action to run.
+	Name string
+
+	// Parameters holds the action's parameters, if any.
+	Parameters map[string]interface{}
+
+	// MaxParallel is the maximum number of units that run the action
+	// at the same time. Units are visited in batches of this size.
+	MaxParallel int
+
+	// Order determines where the leader sits in the rollout.
+	Order ActionRolloutOrder
+
+	// Pause is the time to wait after one batch finishes before
+	// starting the next.
+	Pause time.Duration
+
+	// MaxFailures is the number of failed actions after which the
+	// rollout is stopped. It must be at least 1.
+	MaxFailures int
+}
+
+// Validate returns an error if the args are not valid.
+func (a ActionRolloutArgs) Validate() error {
+	if !names.IsValidApplication(a.Application) {
+		return errors.NotValidf("application name %q", a.Application)
+	}
+	if a.Name == "" {
+		return errors.NotValidf("empty action name")
+	}
+	if a.MaxParallel < 1 {
+		return errors.NotValidf("max parallel %d", a.MaxParallel)
+	}
+	switch a.Order {
+	case ActionRolloutUnitOrder, ActionRolloutLeaderFirst, ActionRolloutLeaderLast:
+	default:
+		return errors.NotValidf("order %q", a.Order)
+	}
+	if a.Pause < 0 {
+		return errors.NotValidf("negative pause %v", a.Pause)
+	}
+	if a.MaxFailures < 1 {
+		return errors.NotValidf("max failures %d", a.MaxFailures)
+	}
+	return nil
+}
+
+// actionRolloutDoc records the progress of an action being run across
+// the units of an application.
+type actionRolloutDoc struct {
+	DocId       string                 `bson:"_id"`
+	ModelUUID   string                 `bson:"model-uuid"`
+	Application string                 `bson:"application"`
+	Name        string                 `bson:"name"`
+	Parameters  map[string]interface{} `bson:"parameters"`
+	MaxParallel int                    `bson:"max-parallel"`
+	Order       ActionRolloutOrder     `bson:"order"`
+	Pause       time.Duration          `bson:"pause"`
+	MaxFailures int                    `bson:"max-failures"`
+
+	// Operation is the id of the operation that the rollout's
+	// actions belong to.
+	Operation string `bson:"operation"`
+
+	Status  ActionRolloutStatus `bson:"status"`
+	Message string              `bson:"message"`
+
+	// Units holds the names of the units to visit, in order, as
+	// they were when the rollout was created.
+	Units []string `bson:"units"`
+
+	// Next is the index in Units of the next unit to visit.
+	Next int `bson:"next"`
+
+	// Batch holds the ids of the actions in the current batch.
+	Batch []string `bson:"batch"`
+
+	// Actions holds the ids of every action enqueued by the rollout.
+	Actions []string `bson:"actions"`
+
+	// Failures is the number of actions in finished batches that
+	// did not complete.
+	Failures int `bson:"failures"`
+
+	// Finished is incremented whenever one of the rollout's actions
+	// finishes, so that watchers notice.
+	Finished int `bson:"finished"`
+
+	Enqueued       time.Time `bson:"enqueued"`
+	BatchCompleted time.Time `bson:"batch-completed"`
+	Completed      time.Time `bson:"completed"`
+}
+
+// ActionRollout represents an action being run across the units of an
+// application, a batch at a time.
+type ActionRollout struct {
+	st  *State
+	doc actionRolloutDoc
+}
+
+// Id returns the rollout's id.
+func (r *ActionRollout) Id() string {
+	return r.st.localID(r.doc.DocId)
+}
+
+// Application returns the name of the application the rollout runs on.
+func (r *ActionRollout) Application() string {
+	return r.doc.Application
+}
+
+// Name returns the name of the action being run.
+func (r *ActionRollout) Name() string {
+	return r.doc.Name
+}
+
+// Parameters returns the parameters passed to each action.
+func (r *ActionRollout) Parameters() map[string]interface{} {
+	return r.doc.Parameters
+}
+
+// MaxParallel returns the maximum number of actions run at once.
+func (r *ActionRollout) MaxParallel() int {
+	return r.doc.MaxParallel
+}
+
+// Order returns where the leader sits in the rollout.
+func (r *ActionRollout) Order() ActionRolloutOrder {
+	return r.doc.Order
+}
+
+// Pause returns the time waited between batches.
+func (r *ActionRollout) Pause() time.Duration {
+	return r.doc.Pause
+}
+
+// MaxFailures returns the number of failed actions after which the
+// rollout is stopped.
+func (r *ActionRollout) MaxFailures() int {
+	return r.doc.MaxFailures
+}
+
+// Status returns the status of the rollout, and a message explaining
+// why it stopped, if it did.
+func (r *ActionRollout) Status() (ActionRolloutStatus, string) {
+	return r.doc.Status, r.doc.Message
+}
+
+// Units returns the names of the units the rollout visits, in order.
+func (r *ActionRollout) Units() []string {
+	return r.doc.Units
+}
+
+// Actions returns the ids of the actions enqueued so far, in order.
+func (r *ActionRollout) Actions() []string {
+	return r.doc.Actions
+}
+
+// Failures returns the number of actions known to have failed.
+func (r *ActionRollout) Failures() int {
+	return r.doc.Failures
+}
+
+// Operation returns the id of the operation that the rollout's actions
+// belong to.
+func (r *ActionRollout) Operation() string {
+	return r.doc.Operation
+}
+
+// Enqueued returns the time the rollout was added.
+func (r *ActionRollout) Enqueued() time.Time {
+	return r.doc.Enqueued
+}
+
+// Completed returns the time the rollout stopped, or the zero time if
+// it is still running.
+func (r *ActionRollout) Completed() time.Time {
+	return r.doc.Completed
+}
+
+// Refresh reloads the rollout from state.
+func (r *ActionRollout) Refresh() error {
+	rollouts, closer := r.st.db().GetCollection(actionRolloutsC)
+	defer closer()
+
+	var doc actionRolloutDoc
+	err := rollouts.FindId(r.doc.DocId).One(&doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("action rollout %q", r.Id())
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot refresh action rollout %q", r.Id())
+	}
+	r.doc = doc
+	return nil
+}
+
+// Cancel stops the rollout. Actions in the current batch that have
+// not yet started are cancelled; those already running are left to
+// finish.
+func (r *ActionRollout) Cancel() error {
+	m, err := r.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := r.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if r.doc.Status != ActionRolloutRunning {
+			return nil, errors.Errorf("action rollout is %s", r.doc.Status)
+		}
+		ops := []txn.Op{{
+			C:      actionRolloutsC,
+			Id:     r.doc.DocId,
+			Assert: bson.D{{"status", ActionRolloutRunning}},
+			Update: bson.D{{"$set", bson.D{
+				{"status", ActionRolloutCancelled},
+				{"message", "cancelled"},
+				{"completed", r.st.nowToTheSecond()},
+			}}},
+		}}
+		for _, id := range r.doc.Batch {
+			a, err := m.Action(id)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if a.Status() != ActionPending {
+				continue
+			}
+			ops = append(ops, cancelPendingActionOps(m.st, a, "action rollout cancelled")...)
+		}
+		return ops, nil
+	}
+	err = r.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot cancel action rollout %q", r.Id())
+}
+
+// cancelPendingActionOps returns the operations needed to cancel an
+// action that has not yet started, recording message as the reason.
+func cancelPendingActionOps(st *State, a Action, message string) []txn.Op {
+	return []txn.Op{{
+		C:      actionsC,
+		Id:     st.docID(a.Id()),
+		Assert: bson.D{{"status", ActionPending}},
+		Update: bson.D{{"$set", bson.D{
+			{"status", ActionCancelled},
+			{"message", message},
+			{"completed", st.nowToTheSecond()},
+		}}},
+	}, {
+		C:      actionNotificationsC,
+		Id:     st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
+		Remove: true,
+	}}
+}
+
+// advance starts the rollout's next batch, or records its outcome,
+// if the current batch has finished.
+func (r *ActionRollout) advance(m *Model) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := r.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if r.doc.Status != ActionRolloutRunning {
+			return nil, jujutxn.ErrNoOperations
+		}
+
+		failures := r.doc.Failures
+		for _, id := range r.doc.Batch {
+			a, err := m.Action(id)
+			if errors.IsNotFound(err) {
+				// The action has been pruned, so it must
+				// have finished; we can't tell how.
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			switch a.Status() {
+			case ActionPending, ActionRunning:
+				return nil, jujutxn.ErrNoOperations
+			case ActionCompleted:
+			default:
+				failures++
+			}
+		}
+
+		now := r.st.nowToTheSecond()
+		assert := bson.D{
+			{"status", ActionRolloutRunning},
+			{"next", r.doc.Next},
+			{"batch", r.doc.Batch},
+		}
+		batchCompleted := r.doc.BatchCompleted
+		if len(r.doc.Batch) > 0 {
+			batchCompleted = now
+		}
+
+		var stopped ActionRolloutStatus
+		var message string
+		switch {
+		case failures >= r.doc.MaxFailures:
+			stopped = ActionRolloutFailed
+			message = "too many failed actions"
+		case r.doc.Next >= len(r.doc.Units):
+			stopped = ActionRolloutCompleted
+		}
+		if stopped != "" {
+			return []txn.Op{{
+				C:      actionRolloutsC,
+				Id:     r.doc.DocId,
+				Assert: assert,
+				Update: bson.D{{"$set", bson.D{
+					{"status", stopped},
+					{"message", message},
+					{"failures", failures},
+					{"batch", []string{}},
+					{"batch-completed", batchCompleted},
+					{"completed", now},
+				}}},
+			}}, nil
+		}
+
+		if now.Before(batchCompleted.Add(r.doc.Pause)) {
+			if len(r.doc.Batch) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			// Record the end of the batch, and wait out
+			// the pause before starting the next one.
+			return []txn.Op{{
+				C:      actionRolloutsC,
+				Id:     r.doc.DocId,
+				Assert: assert,
+				Update: bson.D{{"$set", bson.D{
+					{"failures", failures},
+					{"batch", []string{}},
+					{"batch-completed", batchCompleted},
+				}}},
+			}}, nil
+		}
+
+		var ops []txn.Op
+		var batch []string
+		next := r.doc.Next
+		for ; next < len(r.doc.Units) && len(batch) < r.doc.MaxParallel; next++ {
+			unitName := r.doc.Units[next]
+			if notDead, err := isNotDead(r.st, unitsC, r.st.docID(unitName)); err != nil {
+				return nil, errors.Trace(err)
+			} else if !notDead {
+				// Units that have gone away since the
+				// rollout began are skipped.
+				continue
+			}
+			doc, actionOps, err := m.enqueueActionOps(
+				names.NewUnitTag(unitName), r.doc.Name, r.doc.Parameters, r.doc.Operation, r.Id(),
+			)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			batch = append(batch, r.st.localID(doc.DocId))
+			ops = append(ops, actionOps...)
+		}
+		if batch == nil {
+			batch = []string{}
+		}
+		return append(ops, txn.Op{
+			C:      actionRolloutsC,
+			Id:     r.doc.DocId,
+			Assert: assert,
+			Update: bson.D{
+				{"$set", bson.D{
+					{"next", next},
+					{"failures", failures},
+					{"batch", batch},
+					{"batch-completed", batchCompleted},
+				}},
+				{"$push", bson.D{{"actions", bson.D{{"$each", batch}}}}},
+			},
+		}), nil
+	}
+	err := r.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot advance action rollout %q", r.Id())
+}
+
+// AddActionRollout adds a rollout that runs an action across the
+// current units of an application, as described by args. The actions
+// are enqueued by the action rollout worker.
+func (m *Model) AddActionRollout(args ActionRolloutArgs) (*ActionRollout, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := m.st.Application(args.Application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec, err := applicationActionSpec(app, args.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := spec.ValidateParams(args.Parameters); err != nil {
+		return nil, errors.Trace(err)
+	}
+	payload, err := spec.InsertDefaults(args.Parameters)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(units) == 0 {
+		return nil, errors.Errorf("application %q has no units", args.Application)
+	}
+	sort.Slice(units, func(i, j int) bool {
+		return units[i].UnitTag().Number() < units[j].UnitTag().Number()
+	})
+	unitNames := make([]string, 0, len(units))
+	for _, unit := range units {
+		unitNames = append(unitNames, unit.Name())
+	}
+	if args.Order != ActionRolloutUnitOrder {
+		leaders, err := m.st.ApplicationLeaders()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unitNames = orderLeader(unitNames, leaders[args.Application], args.Order)
+	}
+
+	id, err := NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	operation, err := m.NewOperationId()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := actionRolloutDoc{
+		DocId:       m.st.docID(id.String()),
+		ModelUUID:   m.UUID(),
+		Application: args.Application,
+		Name:        args.Name,
+		Parameters:  payload,
+		MaxParallel: args.MaxParallel,
+		Order:       args.Order,
+		Pause:       args.Pause,
+		MaxFailures: args.MaxFailures,
+		Operation:   operation,
+		Status:      ActionRolloutRunning,
+		Units:       unitNames,
+		Batch:       []string{},
+		Actions:     []string{},
+		Enqueued:    m.st.nowToTheSecond(),
+	}
+	err = m.st.db().RunTransaction([]txn.Op{{
+		C:      applicationsC,
+		Id:     app.doc.DocID,
+		Assert: isAliveDoc,
+	}, {
+		C:      actionRolloutsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}})
+	if err == txn.ErrAborted {
+		return nil, errors.Errorf("cannot add action rollout: application %q is not alive", args.Application)
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot add action rollout")
+	}
+	return &ActionRollout{st: m.st, doc: doc}, nil
+}
+
+// applicationActionSpec returns the spec of the named action, which
+// may be predefined or defined by the application's charm.
+func applicationActionSpec(app *Application, name string) (charm.ActionSpec, error) {
+	if spec, ok := actions.PredefinedActionsSpec[name]; ok {
+		return spec, nil
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return charm.ActionSpec{}, errors.Trace(err)
+	}
+	if ch.Actions() != nil {
+		if spec, ok := ch.Actions().ActionSpecs[name]; ok {
+			return spec, nil
+		}
+	}
+	return charm.ActionSpec{}, errors.Errorf("action %q not defined for application %q", name, app.Name())
+}
+
+// orderLeader moves the leader to the front or back of unitNames.
+func orderLeader(unitNames []string, leader string, order ActionRolloutOrder) []string {
+	result := make([]string, 0, len(unitNames))
+	found := false
+	for _, name := range unitNames {
+		if name == leader {
+			found = true
+			continue
+		}
+		result = append(result, name)
+	}
+	if !found {
+		return unitNames
+	}
+	if order == ActionRolloutLeaderFirst {
+		return append([]string{leader}, result...)
+	}
+	return append(result, leader)
+}
+
+// ActionRollout returns the action rollout with the given id.
+func (m *Model) ActionRollout(id string) (*ActionRollout, error) {
+	rollouts, closer := m.st.db().GetCollection(actionRolloutsC)
+	defer closer()
+
+	var doc actionRolloutDoc
+	err := rollouts.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action rollout %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action rollout %q", id)
+	}
+	return &ActionRollout{st: m.st, doc: doc}, nil
+}
+
+// ActionRollouts returns the model's action rollouts, oldest first.
+func (m *Model) ActionRollouts() ([]*ActionRollout, error) {
+	return m.actionRollouts(nil)
+}
+
+func (m *Model) actionRollouts(query bson.D) ([]*ActionRollout, error) {
+	rollouts, closer := m.st.db().GetCollection(actionRolloutsC)
+	defer closer()
+
+	var docs []actionRolloutDoc
+	if err := rollouts.Find(query).Sort("enqueued").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action rollouts")
+	}
+	results := make([]*ActionRollout, len(docs))
+	for i, doc := range docs {
+		results[i] = &ActionRollout{st: m.st, doc: doc}
+	}
+	return results, nil
+}
+
+// AdvanceActionRollouts moves each running action rollout on to its
+// next batch, or to its final status, once its current batch has
+// finished and any pause has elapsed. A rollout that cannot be
+// advanced does not stop the others; the errors are combined and
+// returned once they have all been tried.
+func (m *Model) AdvanceActionRollouts() error {
+	rollouts, err := m.actionRollouts(bson.D{{"status", ActionRolloutRunning}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var errs []string
+	for _, rollout := range rollouts {
+		if err := rollout.advance(m); err != nil {
+			errs = append(errs, fmt.Sprintf("cannot advance action rollout %q: %v", rollout.Id(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// WatchActionRollouts returns a NotifyWatcher that fires when an action
+// rollout is added or changed, including when one of its actions
+// finishes.
+func (st *State) WatchActionRollouts() NotifyWatcher {
+	return newNotifyCollWatcher(st, actionRolloutsC, nil)
+}
@@ -0,0 +1,282 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type ActionRolloutSuite struct {
+	ConnSuite
+	clock *jujutesting.Clock
+	app   *state.Application
+	model *state.Model
+}
+
+var _ = gc.Suite(&ActionRolloutSuite{})
+
+func (s *ActionRolloutSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(time.Date(2018, time.March, 1, 10, 0, 0, 0, time.UTC))
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.app = s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	for i := 0; i < 3; i++ {
+		_, err := s.app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionRolloutSuite) addRollout(c *gc.C, args state.ActionRolloutArgs) *state.ActionRollout {
+	args.Application = "dummy"
+	args.Name = "snapshot"
+	if args.MaxParallel == 0 {
+		args.MaxParallel = 1
+	}
+	if args.MaxFailures == 0 {
+		args.MaxFailures = 1
+	}
+	rollout, err := s.model.AddActionRollout(args)
+	c.Assert(err, jc.ErrorIsNil)
+	return rollout
+}
+
+// advance advances the model's rollouts, and returns the actions in
+// the given rollout's current batch.
+func (s *ActionRolloutSuite) advance(c *gc.C, rollout *state.ActionRollout) []state.Action {
+	before := len(rollout.Actions())
+	err := s.model.AdvanceActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	err = rollout.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	var actions []state.Action
+	for _, id := range rollout.Actions()[before:] {
+		a, err := s.model.Action(id)
+		c.Assert(err, jc.ErrorIsNil)
+		actions = append(actions, a)
+	}
+	return actions
+}
+
+func (s *ActionRolloutSuite) finish(c *gc.C, actions []state.Action, status state.ActionStatus) {
+	for _, a := range actions {
+		_, err := a.Finish(state.ActionResults{Status: status})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *ActionRolloutSuite) assertStatus(c *gc.C, rollout *state.ActionRollout, expect state.ActionRolloutStatus) {
+	err := rollout.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	status, _ := rollout.Status()
+	c.Assert(status, gc.Equals, expect)
+}
+
+func (s *ActionRolloutSuite) TestAddActionRolloutValidates(c *gc.C) {
+	_, err := s.model.AddActionRollout(state.ActionRolloutArgs{
+		Application: "dummy",
+		Name:        "snapshot",
+	})
+	c.Assert(err, gc.ErrorMatches, "max parallel 0 not valid")
+
+	_, err = s.model.AddActionRollout(state.ActionRolloutArgs{
+		Application: "dummy",
+		Name:        "snapshot",
+		MaxParallel: 1,
+		Order:       "random",
+	})
+	c.Assert(err, gc.ErrorMatches, `order "random" not valid`)
+
+	_, err = s.model.AddActionRollout(state.ActionRolloutArgs{
+		Application: "dummy",
+		Name:        "snapshot",
+		MaxParallel: 1,
+	})
+	c.Assert(err, gc.ErrorMatches, "max failures 0 not valid")
+
+	_, err = s.model.AddActionRollout(state.ActionRolloutArgs{
+		Application: "missing",
+		Name:        "snapshot",
+		MaxParallel: 1,
+		MaxFailures: 1,
+	})
+	c.Assert(err, gc.ErrorMatches, `application "missing" not found`)
+
+	_, err = s.model.AddActionRollout(state.ActionRolloutArgs{
+		Application: "dummy",
+		Name:        "missing",
+		MaxParallel: 1,
+		MaxFailures: 1,
+	})
+	c.Assert(err, gc.ErrorMatches, `action "missing" not defined for application "dummy"`)
+}
+
+func (s *ActionRolloutSuite) TestAddActionRollout(c *gc.C) {
+	rollout := s.addRollout(c, state.ActionRolloutArgs{
+		Parameters: map[string]interface{}{"outfile": "foo.txt"},
+		Pause:      time.Minute,
+	})
+	c.Assert(rollout.Application(), gc.Equals, "dummy")
+	c.Assert(rollout.Name(), gc.Equals, "snapshot")
+	c.Assert(rollout.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.txt"})
+	c.Assert(rollout.Pause(), gc.Equals, time.Minute)
+	c.Assert(rollout.Units(), jc.DeepEquals, []string{"dummy/0", "dummy/1", "dummy/2"})
+	c.Assert(rollout.Actions(), gc.HasLen, 0)
+
+	same, err := s.model.ActionRollout(rollout.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(same.Units(), jc.DeepEquals, rollout.Units())
+
+	all, err := s.model.ActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Assert(all[0].Id(), gc.Equals, rollout.Id())
+}
+
+func (s *ActionRolloutSuite) TestAddActionRolloutInsertsDefaults(c *gc.C) {
+	rollout := s.addRollout(c, state.ActionRolloutArgs{})
+	c.Assert(rollout.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.bz2"})
+}
+
+func (s *ActionRolloutSuite) TestLeaderOrder(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("dummy", "dummy/1", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rollout := s.addRollout(c, state.ActionRolloutArgs{Order: state.ActionRolloutLeaderFirst})
+	c.Assert(rollout.Units(), jc.DeepEquals, []string{"dummy/1", "dummy/0", "dummy/2"})
+
+	rollout = s.addRollout(c, state.ActionRolloutArgs{Order: state.ActionRolloutLeaderLast})
+	c.Assert(rollout.Units(), jc.DeepEquals, []string{"dummy/0", "dummy/2", "dummy/1"})
+}
+
+func (s *ActionRolloutSuite) TestAdvanceInBatches(c *gc.C) {
+	rollout := s.addRollout(c, state.ActionRolloutArgs{MaxParallel: 2})
+
+	batch := s.advance(c, rollout)
+	c.Assert(batch, gc.HasLen, 2)
+	c.Assert(batch[0].Receiver(), gc.Equals, "dummy/0")
+	c.Assert(batch[1].Receiver(), gc.Equals, "dummy/1")
+
+	// Nothing more happens until the batch has finished.
+	c.Assert(s.advance(c, rollout), gc.HasLen, 0)
+	s.finish(c, batch[:1], state.ActionCompleted)
+	c.Assert(s.advance(c, rollout), gc.HasLen, 0)
+	s.finish(c, batch[1:], state.ActionCompleted)
+
+	batch = s.advance(c, rollout)
+	c.Assert(batch, gc.HasLen, 1)
+	c.Assert(batch[0].Receiver(), gc.Equals, "dummy/2")
+	s.finish(c, batch, state.ActionCompleted)
+
+	c.Assert(s.advance(c, rollout), gc.HasLen, 0)
+	s.assertStatus(c, rollout, state.ActionRolloutCompleted)
+	c.Assert(rollout.Actions(), gc.HasLen, 3)
+	c.Assert(rollout.Completed().IsZero(), jc.IsFalse)
+}
+
+func (s *ActionRolloutSuite) TestAdvancePauses(c *gc.C) {
+	rollout := s.addRollout(c, state.ActionRolloutArgs{Pause: time.Minute})
+
+	batch := s.advance(c, rollout)
+	c.Assert(batch, gc.HasLen, 1)
+	s.finish(c, batch, state.ActionCompleted)
+
+	c.Assert(s.advance(c, rollout), gc.HasLen, 0)
+	s.clock.Advance(59 * time.Second)
+	c.Assert(s.advance(c, rollout), gc.HasLen, 0)
+	s.clock.Advance(time.Second)
+	c.Assert(s.advance(c, rollout), gc.HasLen, 1)
+}
+
+func (s *ActionRolloutSuite) TestAdvanceStopsAfterFailures(c *gc.C) {
+	rollout := s.addRollout(c, state.ActionRolloutArgs{MaxFailures: 2})
+
+	s.finish(c, s.advance(c, rollout), state.ActionFailed)
+	s.finish(c, s.advance(c, rollout), state.ActionFailed)
+	s.assertStatus(c, rollout, state.ActionRolloutRunning)
+
+	c.Assert(s.advance(c, rollout), gc.HasLen, 0)
+	s.assertStatus(c, rollout, state.ActionRolloutFailed)
+	c.Assert(rollout.Failures(), gc.Equals, 2)
+	c.Assert(rollout.Actions(), gc.HasLen, 2)
+	_, message := rollout.Status()
+	c.Assert(message, gc.Equals, "too many failed actions")
+}
+
+func (s *ActionRolloutSuite) TestAdvanceStopsAfterOneFailure(c *gc.C) {
+	rollout := s.addRollout(c, state.ActionRolloutArgs{MaxFailures: 1})
+
+	// A successful action does not count towards the limit.
+	s.finish(c, s.advance(c, rollout), state.ActionCompleted)
+	s.finish(c, s.advance(c, rollout), state.ActionFailed)
+	s.assertStatus(c, rollout, state.ActionRolloutRunning)
+
+	// The first failure stops the rollout before the last unit.
+	c.Assert(s.advance(c, rollout), gc.HasLen, 0)
+	s.assertStatus(c, rollout, state.ActionRolloutFailed)
+	c.Assert(rollout.Failures(), gc.Equals, 1)
+	c.Assert(rollout.Actions(), gc.HasLen, 2)
+}
+
+func (s *ActionRolloutSuite) TestAdvanceSkipsDeadUnits(c *gc.C) {
+	unit, err := s.State.Unit("dummy/0")
+	c.Assert(err, jc.ErrorIsNil)
+	rollout := s.addRollout(c, state.ActionRolloutArgs{})
+	err = unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	batch := s.advance(c, rollout)
+	c.Assert(batch, gc.HasLen, 1)
+	c.Assert(batch[0].Receiver(), gc.Equals, "dummy/1")
+}
+
+func (s *ActionRolloutSuite) TestCancel(c *gc.C) {
+	rollout := s.addRollout(c, state.ActionRolloutArgs{MaxParallel: 2})
+	batch := s.advance(c, rollout)
+	c.Assert(batch, gc.HasLen, 2)
+	_, err := batch[0].Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = rollout.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, rollout, state.ActionRolloutCancelled)
+
+	running, err := s.model.Action(batch[0].Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running.Status(), gc.Equals, state.ActionRunning)
+	cancelled, err := s.model.Action(batch[1].Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cancelled.Status(), gc.Equals, state.ActionCancelled)
+
+	c.Assert(s.advance(c, rollout), gc.HasLen, 0)
+	err = rollout.Cancel()
+	c.Assert(err, gc.ErrorMatches, `cannot cancel action rollout ".*": action rollout is cancelled`)
+}
+
+func (s *ActionRolloutSuite) TestWatchActionRollouts(c *gc.C) {
+	w := s.State.WatchActionRollouts()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	rollout := s.addRollout(c, state.ActionRolloutArgs{})
+	wc.AssertOneChange()
+
+	batch := s.advance(c, rollout)
+	wc.AssertOneChange()
+
+	// Finishing one of the rollout's actions is noticed.
+	s.finish(c, batch, state.ActionCompleted)
+	wc.AssertOneChange()
+}
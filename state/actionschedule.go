@@ -0,0 +1,531 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/cron"
+)
+
+// ActionScheduleConcurrency determines what happens when an action
+// schedule falls due while the actions from its previous run have yet
+// to finish.
+type ActionScheduleConcurrency string
+
+const (
+	// ActionScheduleForbid skips the run. This is the default.
+	ActionScheduleForbid ActionScheduleConcurrency = "forbid"
+
+	// ActionScheduleAllow runs the actions regardless.
+	ActionScheduleAllow ActionScheduleConcurrency = "allow"
+
+	// ActionScheduleReplace cancels the previous run's actions that
+	// have not yet started before running the actions again. Actions
+	// that are already running are left to finish.
+	ActionScheduleReplace ActionScheduleConcurrency = "replace"
+)
+
+// ActionScheduleArgs holds the parameters for running an action on a
+// recurring schedule.
+type ActionScheduleArgs struct {
+	// Schedule is a cron expression describing when the action is
+	// run, in UTC.
+	Schedule string
+
+	// Applications holds the names of applications whose units,
+	// as they are when the schedule falls due, run the action.
+	Applications []string
+
+	// Units holds the names of individual units that run the action.
+	Units []string
+
+	// Name is the name of the action to run.
+	Name string
+
+	// Parameters holds the action's parameters, if any.
+	Parameters map[string]interface{}
+
+	// Concurrency determines what happens when the previous run has
+	// not finished. If empty, ActionScheduleForbid is used.
+	Concurrency ActionScheduleConcurrency
+}
+
+// Validate returns an error if the args are not valid.
+func (a ActionScheduleArgs) Validate() error {
+	if _, err := cron.Parse(a.Schedule); err != nil {
+		return errors.Trace(err)
+	}
+	if len(a.Applications) == 0 && len(a.Units) == 0 {
+		return errors.NotValidf("action schedule without applications or units")
+	}
+	for _, name := range a.Applications {
+		if !names.IsValidApplication(name) {
+			return errors.NotValidf("application name %q", name)
+		}
+	}
+	for _, name := range a.Units {
+		if !names.IsValidUnit(name) {
+			return errors.NotValidf("unit name %q", name)
+		}
+	}
+	if a.Name == "" {
+		return errors.NotValidf("empty action name")
+	}
+	switch a.Concurrency {
+	case "", ActionScheduleForbid, ActionScheduleAllow, ActionScheduleReplace:
+	default:
+		return errors.NotValidf("concurrency policy %q", a.Concurrency)
+	}
+	return nil
+}
+
+// actionScheduleDoc records an action that is run on a schedule, and
+// the outcome of its most recent run.
+type actionScheduleDoc struct {
+	DocId        string                    `bson:"_id"`
+	ModelUUID    string                    `bson:"model-uuid"`
+	Schedule     string                    `bson:"schedule"`
+	Applications []string                  `bson:"applications"`
+	Units        []string                  `bson:"units"`
+	Name         string                    `bson:"name"`
+	Parameters   map[string]interface{}    `bson:"parameters"`
+	Concurrency  ActionScheduleConcurrency `bson:"concurrency"`
+	Created      time.Time                 `bson:"created"`
+
+	// NextRun is the time at which the schedule next falls due.
+	NextRun time.Time `bson:"next-run"`
+
+	// LastRun is the time the schedule last fell due, whether or
+	// not any actions were enqueued.
+	LastRun time.Time `bson:"last-run"`
+
+	// LastOperation is the id of the operation holding the actions
+	// enqueued by the most recent run that enqueued any.
+	LastOperation string `bson:"last-operation"`
+
+	// LastMessage explains the outcome of the last run, if it did
+	// not enqueue an action on every target unit.
+	LastMessage string `bson:"last-message"`
+}
+
+// ActionSchedule represents an action that is run on a schedule.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+// Id returns the schedule's id.
+func (s *ActionSchedule) Id() string {
+	return s.st.localID(s.doc.DocId)
+}
+
+// Schedule returns the schedule's cron expression.
+func (s *ActionSchedule) Schedule() string {
+	return s.doc.Schedule
+}
+
+// Applications returns the names of the applications whose units run
+// the action.
+func (s *ActionSchedule) Applications() []string {
+	return s.doc.Applications
+}
+
+// Units returns the names of the individual units that run the action.
+func (s *ActionSchedule) Units() []string {
+	return s.doc.Units
+}
+
+// Name returns the name of the action that is run.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Parameters returns the parameters passed to each action.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Concurrency returns the schedule's concurrency policy.
+func (s *ActionSchedule) Concurrency() ActionScheduleConcurrency {
+	return s.doc.Concurrency
+}
+
+// Created returns the time the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return s.doc.Created
+}
+
+// NextRun returns the time at which the schedule next falls due.
+func (s *ActionSchedule) NextRun() time.Time {
+	return s.doc.NextRun
+}
+
+// LastRun returns the time the schedule last fell due, or the zero time
+// if it has yet to.
+func (s *ActionSchedule) LastRun() time.Time {
+	return s.doc.LastRun
+}
+
+// LastOperation returns the id of the operation holding the actions
+// most recently enqueued by the schedule, if any.
+func (s *ActionSchedule) LastOperation() string {
+	return s.doc.LastOperation
+}
+
+// LastMessage returns a message explaining the outcome of the last run,
+// if it did not enqueue an action on every target unit.
+func (s *ActionSchedule) LastMessage() string {
+	return s.doc.LastMessage
+}
+
+// Refresh reloads the schedule from state.
+func (s *ActionSchedule) Refresh() error {
+	schedules, closer := s.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(s.doc.DocId).One(&doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("action schedule %q", s.Id())
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot refresh action schedule %q", s.Id())
+	}
+	s.doc = doc
+	return nil
+}
+
+// run enqueues the schedule's actions, subject to its concurrency
+// policy, and works out when the schedule next falls due. The actions
+// are enqueued in the same transaction that advances the schedule, so
+// that each run happens exactly once.
+func (s *ActionSchedule) run(m *Model, now time.Time) error {
+	schedule, err := cron.Parse(s.doc.Schedule)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); errors.IsNotFound(err) {
+				return nil, jujutxn.ErrNoOperations
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if s.doc.NextRun.After(now) {
+				// The schedule has run already.
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		ops, operation, message, err := s.enqueueOps(m)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      actionSchedulesC,
+			Id:     s.doc.DocId,
+			Assert: bson.D{{"next-run", s.doc.NextRun}},
+			Update: bson.D{{"$set", bson.D{
+				{"next-run", schedule.Next(now)},
+				{"last-run", now},
+				{"last-operation", operation},
+				{"last-message", message},
+			}}},
+		}), nil
+	}
+	return errors.Trace(s.st.db().Run(buildTxn))
+}
+
+// enqueueOps returns the operations needed to enqueue the schedule's
+// actions on each of its target units, along with the id of the
+// operation holding them and a message explaining why any target unit
+// was not given an action.
+func (s *ActionSchedule) enqueueOps(m *Model) ([]txn.Op, string, string, error) {
+	operation := s.doc.LastOperation
+	var ops []txn.Op
+	if operation != "" && s.doc.Concurrency != ActionScheduleAllow {
+		previous, err := m.Operation(operation)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, "", "", errors.Trace(err)
+		}
+		if err == nil && !actionFinished(previous.Status()) {
+			if s.doc.Concurrency != ActionScheduleReplace {
+				return nil, operation, "skipped: operation " + operation + " has not finished", nil
+			}
+			for _, a := range previous.Actions() {
+				if a.Status() == ActionPending {
+					ops = append(ops, cancelPendingActionOps(s.st, a, "replaced by scheduled run")...)
+				}
+			}
+		}
+	}
+
+	units, problems, err := s.targetUnits()
+	if err != nil {
+		return nil, "", "", errors.Trace(err)
+	}
+	if len(units) == 0 {
+		problems = append(problems, "no units to run on")
+		return ops, operation, strings.Join(problems, "; "), nil
+	}
+	id, err := m.NewOperationId()
+	if err != nil {
+		return nil, "", "", errors.Trace(err)
+	}
+	enqueued := 0
+	for _, unit := range units {
+		payload, err := unit.actionPayload(s.doc.Name, s.doc.Parameters)
+		if err != nil {
+			problems = append(problems, unit.Name()+": "+err.Error())
+			continue
+		}
+		_, actionOps, err := m.enqueueActionOps(unit.Tag(), s.doc.Name, payload, id, "")
+		if err != nil {
+			return nil, "", "", errors.Trace(err)
+		}
+		ops = append(ops, actionOps...)
+		enqueued++
+	}
+	if enqueued > 0 {
+		operation = id
+	}
+	return ops, operation, strings.Join(problems, "; "), nil
+}
+
+// targetUnits returns the schedule's target units in name order,
+// along with messages describing any targets that have gone away.
+func (s *ActionSchedule) targetUnits() ([]*Unit, []string, error) {
+	var problems []string
+	byName := make(map[string]*Unit)
+	for _, name := range s.doc.Applications {
+		app, err := s.st.Application(name)
+		if errors.IsNotFound(err) {
+			problems = append(problems, "application "+name+" not found")
+			continue
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			byName[unit.Name()] = unit
+		}
+	}
+	for _, name := range s.doc.Units {
+		if _, ok := byName[name]; ok {
+			continue
+		}
+		unit, err := s.st.Unit(name)
+		if errors.IsNotFound(err) {
+			problems = append(problems, "unit "+name+" not found")
+			continue
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		byName[name] = unit
+	}
+	units := make([]*Unit, 0, len(byName))
+	for _, unit := range byName {
+		if unit.Life() == Alive {
+			units = append(units, unit)
+		}
+	}
+	sort.Slice(units, func(i, j int) bool {
+		return units[i].Name() < units[j].Name()
+	})
+	return units, problems, nil
+}
+
+// AddActionSchedule adds a schedule that runs an action on a set of
+// units, as described by args. The actions are enqueued by the action
+// schedule worker when the schedule falls due.
+func (m *Model) AddActionSchedule(args ActionScheduleArgs) (*ActionSchedule, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	schedule, err := cron.Parse(args.Schedule)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	now := m.st.nowToTheSecond()
+	next := schedule.Next(now)
+	if next.IsZero() {
+		return nil, errors.Errorf("cron expression %q never falls due", args.Schedule)
+	}
+
+	// Check that the action is defined, and its parameters valid,
+	// for each application that will run it.
+	applications := make(map[string]*Application)
+	for _, name := range args.Applications {
+		app, err := m.st.Application(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		applications[name] = app
+	}
+	for _, name := range args.Units {
+		unit, err := m.st.Unit(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, ok := applications[unit.ApplicationName()]; ok {
+			continue
+		}
+		app, err := unit.Application()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		applications[app.Name()] = app
+	}
+	var ops []txn.Op
+	for _, app := range applications {
+		spec, err := applicationActionSpec(app, args.Name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := spec.ValidateParams(args.Parameters); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		})
+	}
+
+	seq, err := sequence(m.st, "actionschedule")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	concurrency := args.Concurrency
+	if concurrency == "" {
+		concurrency = ActionScheduleForbid
+	}
+	doc := actionScheduleDoc{
+		DocId:        m.st.docID(strconv.Itoa(seq)),
+		ModelUUID:    m.UUID(),
+		Schedule:     args.Schedule,
+		Applications: args.Applications,
+		Units:        args.Units,
+		Name:         args.Name,
+		Parameters:   args.Parameters,
+		Concurrency:  concurrency,
+		Created:      now,
+		NextRun:      next,
+	}
+	ops = append(ops, txn.Op{
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	})
+	err = m.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return nil, errors.New("cannot add action schedule: application is not alive")
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot add action schedule")
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// ActionSchedule returns the action schedule with the given id.
+func (m *Model) ActionSchedule(id string) (*ActionSchedule, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", id)
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// ActionSchedules returns the model's action schedules, oldest first.
+func (m *Model) ActionSchedules() ([]*ActionSchedule, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(nil).Sort("created", "_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	results := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		results[i] = &ActionSchedule{st: m.st, doc: doc}
+	}
+	return results, nil
+}
+
+// RemoveActionSchedule removes the action schedule with the given id.
+// Actions already enqueued by the schedule are not affected.
+func (m *Model) RemoveActionSchedule(id string) error {
+	err := m.st.db().RunTransaction([]txn.Op{{
+		C:      actionSchedulesC,
+		Id:     m.st.docID(id),
+		Assert: txn.DocExists,
+		Remove: true,
+	}})
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("action schedule %q", id)
+	}
+	return errors.Annotatef(err, "cannot remove action schedule %q", id)
+}
+
+// RunActionSchedules runs each action schedule that has fallen due. It
+// returns the time at which the next schedule falls due, or the zero
+// time if there are no schedules. A schedule that cannot be run is
+// logged and left to be retried, and does not stop the others.
+func (m *Model) RunActionSchedules() (time.Time, error) {
+	schedules, err := m.ActionSchedules()
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	now := m.st.nowToTheSecond()
+	var next time.Time
+	for _, schedule := range schedules {
+		if schedule.doc.NextRun.IsZero() {
+			// The schedule will never fall due again.
+			continue
+		}
+		if !schedule.doc.NextRun.After(now) {
+			if err := schedule.run(m, now); err != nil {
+				logger.Errorf("cannot run action schedule %q: %v", schedule.Id(), err)
+				continue
+			}
+			if err := schedule.Refresh(); errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return time.Time{}, errors.Trace(err)
+			}
+		}
+		if next.IsZero() || schedule.doc.NextRun.Before(next) {
+			next = schedule.doc.NextRun
+		}
+	}
+	return next, nil
+}
+
+// WatchActionSchedules returns a NotifyWatcher that fires when an action
+// schedule is added, changed or removed.
+func (st *State) WatchActionSchedules() NotifyWatcher {
+	return newNotifyCollWatcher(st, actionSchedulesC, nil)
+}
@@ -0,0 +1,282 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	clock *jujutesting.Clock
+	app   *state.Application
+	model *state.Model
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(time.Date(2018, time.March, 1, 10, 0, 0, 0, time.UTC))
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.app = s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	for i := 0; i < 2; i++ {
+		_, err := s.app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C, concurrency state.ActionScheduleConcurrency) *state.ActionSchedule {
+	schedule, err := s.model.AddActionSchedule(state.ActionScheduleArgs{
+		Schedule:     "0 2 * * *",
+		Applications: []string{"dummy"},
+		Name:         "snapshot",
+		Parameters:   map[string]interface{}{"outfile": "foo.txt"},
+		Concurrency:  concurrency,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return schedule
+}
+
+// run advances the clock to the given time and runs the model's action
+// schedules, returning the time the next one falls due.
+func (s *ActionScheduleSuite) run(c *gc.C, t time.Time) time.Time {
+	s.clock.Advance(t.Sub(s.clock.Now()))
+	next, err := s.model.RunActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	return next.UTC()
+}
+
+func (s *ActionScheduleSuite) lastActions(c *gc.C, schedule *state.ActionSchedule) []state.Action {
+	err := schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	op, err := s.model.Operation(schedule.LastOperation())
+	c.Assert(err, jc.ErrorIsNil)
+	return op.Actions()
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleValidates(c *gc.C) {
+	for i, test := range []struct {
+		args   state.ActionScheduleArgs
+		expect string
+	}{{
+		args: state.ActionScheduleArgs{
+			Schedule:     "0 2 * *",
+			Applications: []string{"dummy"},
+			Name:         "snapshot",
+		},
+		expect: `invalid cron expression "0 2 \* \*": expected 5 fields, got 4`,
+	}, {
+		args: state.ActionScheduleArgs{
+			Schedule: "0 2 * * *",
+			Name:     "snapshot",
+		},
+		expect: "action schedule without applications or units not valid",
+	}, {
+		args: state.ActionScheduleArgs{
+			Schedule:     "0 2 * * *",
+			Applications: []string{"dummy"},
+			Name:         "snapshot",
+			Concurrency:  "queue",
+		},
+		expect: `concurrency policy "queue" not valid`,
+	}, {
+		args: state.ActionScheduleArgs{
+			Schedule:     "0 0 30 2 *",
+			Applications: []string{"dummy"},
+			Name:         "snapshot",
+		},
+		expect: `cron expression "0 0 30 2 \*" never falls due`,
+	}, {
+		args: state.ActionScheduleArgs{
+			Schedule:     "0 2 * * *",
+			Applications: []string{"missing"},
+			Name:         "snapshot",
+		},
+		expect: `application "missing" not found`,
+	}, {
+		args: state.ActionScheduleArgs{
+			Schedule: "0 2 * * *",
+			Units:    []string{"dummy/5"},
+			Name:     "snapshot",
+		},
+		expect: `unit "dummy/5" not found`,
+	}, {
+		args: state.ActionScheduleArgs{
+			Schedule: "0 2 * * *",
+			Units:    []string{"dummy/0"},
+			Name:     "missing",
+		},
+		expect: `action "missing" not defined for application "dummy"`,
+	}, {
+		args: state.ActionScheduleArgs{
+			Schedule:     "0 2 * * *",
+			Applications: []string{"dummy"},
+			Name:         "snapshot",
+			Parameters:   map[string]interface{}{"outfile": 5},
+		},
+		expect: "validation failed: .*",
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.model.AddActionSchedule(test.args)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	schedule := s.addSchedule(c, "")
+	c.Assert(schedule.Id(), gc.Equals, "1")
+	c.Assert(schedule.Schedule(), gc.Equals, "0 2 * * *")
+	c.Assert(schedule.Applications(), jc.DeepEquals, []string{"dummy"})
+	c.Assert(schedule.Name(), gc.Equals, "snapshot")
+	c.Assert(schedule.Concurrency(), gc.Equals, state.ActionScheduleForbid)
+	c.Assert(schedule.NextRun(), gc.Equals, time.Date(2018, time.March, 2, 2, 0, 0, 0, time.UTC))
+	c.Assert(schedule.LastRun().IsZero(), jc.IsTrue)
+
+	schedules, err := s.model.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 1)
+	c.Assert(schedules[0].Id(), gc.Equals, "1")
+}
+
+func (s *ActionScheduleSuite) TestRemoveActionSchedule(c *gc.C) {
+	schedule := s.addSchedule(c, "")
+	err := s.model.RemoveActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.model.RemoveActionSchedule(schedule.Id())
+	c.Assert(err, gc.ErrorMatches, `action schedule "1" not found`)
+}
+
+func (s *ActionScheduleSuite) TestRunActionSchedules(c *gc.C) {
+	schedule := s.addSchedule(c, "")
+	due := schedule.NextRun()
+
+	next := s.run(c, due.Add(-time.Minute))
+	c.Assert(next, gc.Equals, due)
+	err := schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastOperation(), gc.Equals, "")
+
+	next = s.run(c, due)
+	c.Assert(next, gc.Equals, due.Add(24*time.Hour))
+	actions := s.lastActions(c, schedule)
+	c.Assert(actions, gc.HasLen, 2)
+	for i, a := range actions {
+		c.Check(a.Receiver(), gc.Equals, []string{"dummy/0", "dummy/1"}[i])
+		c.Check(a.Name(), gc.Equals, "snapshot")
+		c.Check(a.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.txt"})
+	}
+	c.Assert(schedule.LastRun().UTC(), gc.Equals, due)
+	c.Assert(schedule.LastMessage(), gc.Equals, "")
+
+	// Running again before the next time it falls due does nothing.
+	operation := schedule.LastOperation()
+	s.run(c, due.Add(time.Hour))
+	err = schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastOperation(), gc.Equals, operation)
+}
+
+func (s *ActionScheduleSuite) TestRunActionSchedulesForbid(c *gc.C) {
+	schedule := s.addSchedule(c, state.ActionScheduleForbid)
+	due := schedule.NextRun()
+	s.run(c, due)
+	previous := schedule.LastOperation()
+
+	s.run(c, due.Add(24*time.Hour))
+	err := schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastOperation(), gc.Equals, previous)
+	c.Assert(schedule.LastMessage(), gc.Equals, "skipped: operation "+previous+" has not finished")
+	c.Assert(schedule.LastRun().UTC(), gc.Equals, due.Add(24*time.Hour))
+}
+
+func (s *ActionScheduleSuite) TestRunActionSchedulesReplace(c *gc.C) {
+	schedule := s.addSchedule(c, state.ActionScheduleReplace)
+	due := schedule.NextRun()
+	s.run(c, due)
+	previous := s.lastActions(c, schedule)
+	_, err := previous[0].Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.run(c, due.Add(24*time.Hour))
+	c.Assert(s.lastActions(c, schedule), gc.HasLen, 2)
+	c.Assert(schedule.LastOperation(), gc.Not(gc.Equals), previous[0].Operation())
+
+	running, err := s.model.Action(previous[0].Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running.Status(), gc.Equals, state.ActionRunning)
+	cancelled, err := s.model.Action(previous[1].Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cancelled.Status(), gc.Equals, state.ActionCancelled)
+	_, message := cancelled.Results()
+	c.Assert(message, gc.Equals, "replaced by scheduled run")
+}
+
+func (s *ActionScheduleSuite) TestRunActionSchedulesAllow(c *gc.C) {
+	schedule := s.addSchedule(c, state.ActionScheduleAllow)
+	due := schedule.NextRun()
+	s.run(c, due)
+	previous := schedule.LastOperation()
+
+	s.run(c, due.Add(24*time.Hour))
+	err := schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastOperation(), gc.Not(gc.Equals), previous)
+	c.Assert(schedule.LastMessage(), gc.Equals, "")
+}
+
+func (s *ActionScheduleSuite) TestRunActionSchedulesMissingTargets(c *gc.C) {
+	schedule, err := s.model.AddActionSchedule(state.ActionScheduleArgs{
+		Schedule: "@hourly",
+		Units:    []string{"dummy/0"},
+		Name:     "snapshot",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.State.Unit("dummy/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.run(c, schedule.NextRun())
+	err = schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastOperation(), gc.Equals, "")
+	c.Assert(schedule.LastMessage(), gc.Equals, "unit dummy/0 not found; no units to run on")
+}
+
+func (s *ActionScheduleSuite) TestRunActionSchedulesContinuesPastErrors(c *gc.C) {
+	broken := s.addSchedule(c, "")
+	schedule := s.addSchedule(c, "")
+	coll, closer := state.GetRawCollection(s.State, "actionschedules")
+	defer closer()
+	err := coll.UpdateId(state.DocID(s.State, broken.Id()), bson.D{{"$set", bson.D{{"schedule", "bad"}}}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	due := schedule.NextRun()
+	next := s.run(c, due)
+	c.Assert(next, gc.Equals, due.Add(24*time.Hour))
+	c.Assert(s.lastActions(c, schedule), gc.HasLen, 2)
+
+	err = broken.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(broken.NextRun().UTC(), gc.Equals, due)
+	c.Assert(broken.LastOperation(), gc.Equals, "")
+}
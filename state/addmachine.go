@@ -92,6 +92,11 @@ type MachineTemplate struct {
 	// with the machine.
 	Placement string
 
+	// Pool holds the name of the machine pool the new machine
+	// joins, if any. Containers always join the pool of their
+	// host machine.
+	Pool string
+
 	// principals holds the principal units that will
 	// associated with the machine.
 	principals []string
@@ -255,6 +260,11 @@ func (st *State) effectiveMachineTemplate(p MachineTemplate, allowController boo
 			return tmpl, errControllerNotAllowed
 		}
 	}
+	if p.Pool != "" {
+		if _, err := st.MachinePool(p.Pool); err != nil {
+			return tmpl, errors.Trace(err)
+		}
+	}
 	return p, nil
 }
 
@@ -357,6 +367,10 @@ func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId s
 	if !parent.supportsContainerType(containerType) {
 		return nil, nil, errors.Errorf("machine %s cannot host %s containers", parentId, containerType)
 	}
+	if template.Pool != "" && template.Pool != parent.doc.Pool {
+		return nil, nil, errors.Errorf("machine %s is not in machine pool %q", parentId, template.Pool)
+	}
+	template.Pool = parent.doc.Pool
 
 	newId, err := st.newContainerId(parentId, containerType)
 	if err != nil {
@@ -430,6 +444,10 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	if err != nil {
 		return nil, nil, err
 	}
+	if template.Pool != "" && template.Pool != parentTemplate.Pool {
+		return nil, nil, errors.Errorf("new machine is not in machine pool %q", template.Pool)
+	}
+	template.Pool = parentTemplate.Pool
 	mdoc := st.machineDocForTemplate(template, newId)
 	mdoc.ContainerType = string(containerType)
 	parentPrereqOps, parentOp, err := st.insertNewMachineOps(parentDoc, parentTemplate)
@@ -507,6 +525,7 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		PreferredPublicAddress:  fromNetworkAddress(publicAddr, OriginMachine),
 		NoVote:                  template.NoVote,
 		Placement:               template.Placement,
+		Pool:                    template.Pool,
 	}
 }
 
@@ -198,6 +198,15 @@ func allCollections() collectionSchema {
 			global: true,
 		},
 
+		// This collection holds the API tokens issued by the controller
+		// to allow automated clients restricted access to models.
+		apiTokensC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"owner"},
+			}},
+		},
+
 		// This collection holds information cached by autocert certificate
 		// acquisition.
 		autocertCacheC: {
@@ -302,6 +311,23 @@ func allCollections() collectionSchema {
 		rebootC:      {},
 		sshHostKeysC: {},
 
+		// This collection holds the named pools that machines can
+		// be added to, restricting the units placed on them.
+		machinePoolsC: {},
+
+		// This collection holds the policies by which applications
+		// are scaled in response to their units' metrics.
+		autoscalingPoliciesC: {},
+
+		// This collection holds the scaling decisions made by
+		// autoscaling policies.
+		autoscalingDecisionsC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application", "-time"},
+			}},
+		},
+
 		// This collection contains information from removed machines
 		// that needs to be cleaned up in the provider.
 		machineRemovalsC: {},
@@ -364,9 +390,28 @@ func allCollections() collectionSchema {
 		actionsC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "name"},
+			}, {
+				Key: []string{"model-uuid", "operation"},
+			}, {
+				// used for the model event timeline
+				Key: []string{"model-uuid", "enqueued"},
+			}, {
+				Key: []string{"model-uuid", "started"},
+			}, {
+				Key: []string{"model-uuid", "completed"},
 			}},
 		},
 		actionNotificationsC: {},
+		actionRolloutsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "status"},
+			}},
+		},
+		actionSchedulesC: {},
+
+		// This collection holds the operator-defined actions that can
+		// be run on any machine in a model.
+		machineActionScriptsC: {},
 
 		// -----
 
@@ -413,7 +458,8 @@ func allCollections() collectionSchema {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "globalkey", "updated"},
 			}, {
-				// used for migration and model-specific pruning
+				// used for migration, model-specific pruning and
+				// the model event timeline
 				Key: []string{"model-uuid", "-updated", "-_id"},
 			}, {
 				// used for global pruning (after size check)
@@ -479,8 +525,13 @@ func allCollections() collectionSchema {
 const (
 	actionNotificationsC     = "actionnotifications"
 	actionresultsC           = "actionresults"
+	actionRolloutsC          = "actionrollouts"
+	actionSchedulesC         = "actionschedules"
+	autoscalingDecisionsC    = "autoscalingdecisions"
+	autoscalingPoliciesC     = "autoscalingpolicies"
 	actionsC                 = "actions"
 	annotationsC             = "annotations"
+	apiTokensC               = "apiTokens"
 	autocertCacheC           = "autocertCache"
 	assignUnitC              = "assignUnits"
 	bakeryStorageItemsC      = "bakeryStorageItems"
@@ -504,6 +555,8 @@ const (
 	guisettingsC             = "guisettings"
 	instanceDataC            = "instanceData"
 	leasesC                  = "leases"
+	machineActionScriptsC    = "machineactionscripts"
+	machinePoolsC            = "machinepools"
 	machinesC                = "machines"
 	machineRemovalsC         = "machineremovals"
 	meterStatusC             = "meterStatus"
@@ -0,0 +1,265 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+// APIToken represents a controller-issued, revocable credential that
+// allows a user to log in to a restricted set of models with at most
+// the specified access, without presenting a password.
+type APIToken struct {
+	st  *State
+	doc apiTokenDoc
+}
+
+type apiTokenDoc struct {
+	DocID       string    `bson:"_id"`
+	Owner       string    `bson:"owner"`
+	Description string    `bson:"description,omitempty"`
+	Models      []string  `bson:"models"`
+	Access      string    `bson:"access"`
+	Facades     []string  `bson:"facades,omitempty"`
+	Created     time.Time `bson:"created"`
+	Expires     time.Time `bson:"expires"`
+	Revoked     bool      `bson:"revoked"`
+}
+
+// AddAPITokenArgs holds the parameters for creating an API token.
+type AddAPITokenArgs struct {
+	// Owner is the user on whose behalf the token will act.
+	Owner names.UserTag
+
+	// Description is free-form text describing what the token is for.
+	Description string
+
+	// Models holds the UUIDs of the models the token may be used with.
+	Models []string
+
+	// Access is the maximum model access the token grants. It may not
+	// exceed the owner's own access to any of the models.
+	Access permission.Access
+
+	// Facades optionally restricts the facades that may be called
+	// using the token. An empty list allows all facades.
+	Facades []string
+
+	// Expires is the time after which the token is no longer valid.
+	Expires time.Time
+}
+
+// Validate checks that the arguments are sensible.
+func (args AddAPITokenArgs) Validate() error {
+	if args.Owner.Id() == "" {
+		return errors.NotValidf("empty owner")
+	}
+	if len(args.Models) == 0 {
+		return errors.NotValidf("token without models")
+	}
+	for _, uuid := range args.Models {
+		if !names.IsValidModel(uuid) {
+			return errors.NotValidf("model UUID %q", uuid)
+		}
+	}
+	if err := permission.ValidateModelAccess(args.Access); err != nil {
+		return errors.Trace(err)
+	}
+	if args.Expires.IsZero() {
+		return errors.NotValidf("token without expiry time")
+	}
+	return nil
+}
+
+// Id returns the unique identifier of the token.
+func (t *APIToken) Id() string {
+	return t.doc.DocID
+}
+
+// Owner returns the tag of the user on whose behalf the token acts.
+func (t *APIToken) Owner() names.UserTag {
+	return names.NewUserTag(t.doc.Owner)
+}
+
+// Description returns the free-form description of the token.
+func (t *APIToken) Description() string {
+	return t.doc.Description
+}
+
+// Models returns the UUIDs of the models the token may be used with.
+func (t *APIToken) Models() []string {
+	return t.doc.Models
+}
+
+// Access returns the maximum model access granted by the token.
+func (t *APIToken) Access() permission.Access {
+	return permission.Access(t.doc.Access)
+}
+
+// Facades returns the facades the token may be used to call. An empty
+// result means that all facades are permitted.
+func (t *APIToken) Facades() []string {
+	return t.doc.Facades
+}
+
+// Created returns the time the token was issued.
+func (t *APIToken) Created() time.Time {
+	return t.doc.Created.UTC()
+}
+
+// Expires returns the time after which the token is no longer valid.
+func (t *APIToken) Expires() time.Time {
+	return t.doc.Expires.UTC()
+}
+
+// Revoked reports whether the token has been revoked.
+func (t *APIToken) Revoked() bool {
+	return t.doc.Revoked
+}
+
+// ValidAt returns an error satisfying errors.IsNotValid if the token
+// has been revoked or has expired at the given time.
+func (t *APIToken) ValidAt(now time.Time) error {
+	if t.doc.Revoked {
+		return errors.NotValidf("revoked API token %q", t.doc.DocID)
+	}
+	if !now.Before(t.doc.Expires) {
+		return errors.NotValidf("expired API token %q", t.doc.DocID)
+	}
+	return nil
+}
+
+// HasModel reports whether the token may be used with the model
+// with the given UUID.
+func (t *APIToken) HasModel(modelUUID string) bool {
+	return set.NewStrings(t.doc.Models...).Contains(modelUUID)
+}
+
+// AllowsFacade reports whether the token may be used to call the named
+// facade.
+func (t *APIToken) AllowsFacade(name string) bool {
+	if len(t.doc.Facades) == 0 {
+		return true
+	}
+	return set.NewStrings(t.doc.Facades...).Contains(name)
+}
+
+// Refresh reloads the token's state from the database.
+func (t *APIToken) Refresh() error {
+	doc, err := t.st.apiTokenDoc(t.doc.DocID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	t.doc = *doc
+	return nil
+}
+
+// Revoke marks the token as revoked, so that it may no longer be
+// used to log in. Revoking an already revoked token is not an error.
+func (t *APIToken) Revoke() error {
+	ops := []txn.Op{{
+		C:      apiTokensC,
+		Id:     t.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"revoked", true}}}},
+	}}
+	if err := t.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("API token %q", t.doc.DocID)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot revoke API token %q", t.doc.DocID)
+	}
+	t.doc.Revoked = true
+	return nil
+}
+
+// AddAPIToken records a new API token with the given parameters.
+func (st *State) AddAPIToken(args AddAPITokenArgs) (*APIToken, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Annotate(err, "cannot add API token")
+	}
+	id, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	models := set.NewStrings(args.Models...).SortedValues()
+	facades := set.NewStrings(args.Facades...).SortedValues()
+	doc := apiTokenDoc{
+		DocID:       id.String(),
+		Owner:       args.Owner.Id(),
+		Description: args.Description,
+		Models:      models,
+		Access:      string(args.Access),
+		Facades:     facades,
+		Created:     st.nowToTheSecond(),
+		Expires:     args.Expires.UTC(),
+	}
+	ops := []txn.Op{{
+		C:      apiTokensC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return nil, errors.Annotate(err, "cannot add API token")
+	}
+	return &APIToken{st: st, doc: doc}, nil
+}
+
+// APIToken returns the API token with the given id.
+func (st *State) APIToken(id string) (*APIToken, error) {
+	doc, err := st.apiTokenDoc(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIToken{st: st, doc: *doc}, nil
+}
+
+// APITokens returns all the API tokens owned by the given user,
+// ordered by creation time.
+func (st *State) APITokens(owner names.UserTag) ([]*APIToken, error) {
+	coll, closer := st.db().GetCollection(apiTokensC)
+	defer closer()
+
+	var docs []apiTokenDoc
+	if err := coll.Find(bson.D{{"owner", owner.Id()}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get API tokens for %q", owner.Id())
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		if docs[i].Created.Equal(docs[j].Created) {
+			return docs[i].DocID < docs[j].DocID
+		}
+		return docs[i].Created.Before(docs[j].Created)
+	})
+	tokens := make([]*APIToken, len(docs))
+	for i, doc := range docs {
+		tokens[i] = &APIToken{st: st, doc: doc}
+	}
+	return tokens, nil
+}
+
+func (st *State) apiTokenDoc(id string) (*apiTokenDoc, error) {
+	coll, closer := st.db().GetCollection(apiTokensC)
+	defer closer()
+
+	var doc apiTokenDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("API token %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get API token %q", id)
+	}
+	return &doc, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type APITokenSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&APITokenSuite{})
+
+func (s *APITokenSuite) addToken(c *gc.C, expires time.Time) *state.APIToken {
+	token, err := s.State.AddAPIToken(state.AddAPITokenArgs{
+		Owner:       names.NewUserTag("bob"),
+		Description: "ci",
+		Models:      []string{s.State.ModelUUID()},
+		Access:      permission.ReadAccess,
+		Facades:     []string{"Client", "Action"},
+		Expires:     expires,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return token
+}
+
+func (s *APITokenSuite) TestAddAPIToken(c *gc.C) {
+	expires := testing.NonZeroTime().Add(time.Hour).Round(time.Second).UTC()
+	token := s.addToken(c, expires)
+	c.Assert(token.Id(), gc.Not(gc.Equals), "")
+	c.Assert(token.Owner(), gc.Equals, names.NewUserTag("bob"))
+	c.Assert(token.Description(), gc.Equals, "ci")
+	c.Assert(token.Models(), jc.DeepEquals, []string{s.State.ModelUUID()})
+	c.Assert(token.Access(), gc.Equals, permission.ReadAccess)
+	c.Assert(token.Facades(), jc.DeepEquals, []string{"Action", "Client"})
+	c.Assert(token.Expires(), gc.Equals, expires)
+	c.Assert(token.Revoked(), jc.IsFalse)
+
+	fetched, err := s.State.APIToken(token.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fetched.Owner(), gc.Equals, token.Owner())
+	c.Assert(fetched.Expires(), gc.Equals, expires)
+}
+
+func (s *APITokenSuite) TestAddAPITokenInvalid(c *gc.C) {
+	for i, test := range []struct {
+		args   state.AddAPITokenArgs
+		expect string
+	}{{
+		args:   state.AddAPITokenArgs{},
+		expect: "cannot add API token: empty owner not valid",
+	}, {
+		args: state.AddAPITokenArgs{
+			Owner: names.NewUserTag("bob"),
+		},
+		expect: "cannot add API token: token without models not valid",
+	}, {
+		args: state.AddAPITokenArgs{
+			Owner:  names.NewUserTag("bob"),
+			Models: []string{"foo"},
+		},
+		expect: `cannot add API token: model UUID "foo" not valid`,
+	}, {
+		args: state.AddAPITokenArgs{
+			Owner:  names.NewUserTag("bob"),
+			Models: []string{s.State.ModelUUID()},
+			Access: permission.SuperuserAccess,
+		},
+		expect: `cannot add API token: "superuser" model access not valid`,
+	}, {
+		args: state.AddAPITokenArgs{
+			Owner:  names.NewUserTag("bob"),
+			Models: []string{s.State.ModelUUID()},
+			Access: permission.ReadAccess,
+		},
+		expect: "cannot add API token: token without expiry time not valid",
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.State.AddAPIToken(test.args)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *APITokenSuite) TestAPITokenNotFound(c *gc.C) {
+	_, err := s.State.APIToken("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *APITokenSuite) TestAPITokens(c *gc.C) {
+	expires := testing.NonZeroTime().Add(time.Hour)
+	token1 := s.addToken(c, expires)
+	token2 := s.addToken(c, expires)
+
+	tokens, err := s.State.APITokens(names.NewUserTag("bob"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens, gc.HasLen, 2)
+	ids := []string{tokens[0].Id(), tokens[1].Id()}
+	c.Assert(ids, jc.SameContents, []string{token1.Id(), token2.Id()})
+
+	tokens, err = s.State.APITokens(names.NewUserTag("mary"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens, gc.HasLen, 0)
+}
+
+func (s *APITokenSuite) TestRevoke(c *gc.C) {
+	token := s.addToken(c, testing.NonZeroTime().Add(time.Hour))
+	err := token.Revoke()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token.Revoked(), jc.IsTrue)
+
+	fetched, err := s.State.APIToken(token.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fetched.Revoked(), jc.IsTrue)
+
+	// Revoking twice is fine.
+	err = fetched.Revoke()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *APITokenSuite) TestValidAt(c *gc.C) {
+	now := testing.NonZeroTime()
+	token := s.addToken(c, now.Add(time.Hour))
+
+	c.Assert(token.ValidAt(now), jc.ErrorIsNil)
+	err := token.ValidAt(now.Add(2 * time.Hour))
+	c.Assert(err, gc.ErrorMatches, `expired API token ".*" not valid`)
+
+	err = token.Revoke()
+	c.Assert(err, jc.ErrorIsNil)
+	err = token.ValidAt(now)
+	c.Assert(err, gc.ErrorMatches, `revoked API token ".*" not valid`)
+}
+
+func (s *APITokenSuite) TestHasModel(c *gc.C) {
+	token := s.addToken(c, testing.NonZeroTime().Add(time.Hour))
+	c.Assert(token.HasModel(s.State.ModelUUID()), jc.IsTrue)
+	c.Assert(token.HasModel(utils.MustNewUUID().String()), jc.IsFalse)
+}
+
+func (s *APITokenSuite) TestAllowsFacade(c *gc.C) {
+	token := s.addToken(c, testing.NonZeroTime().Add(time.Hour))
+	c.Assert(token.AllowsFacade("Client"), jc.IsTrue)
+	c.Assert(token.AllowsFacade("Application"), jc.IsFalse)
+
+	unrestricted, err := s.State.AddAPIToken(state.AddAPITokenArgs{
+		Owner:   names.NewUserTag("bob"),
+		Models:  []string{s.State.ModelUUID()},
+		Access:  permission.WriteAccess,
+		Expires: testing.NonZeroTime().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unrestricted.AllowsFacade("Application"), jc.IsTrue)
+}
@@ -202,6 +202,11 @@ func (op *DestroyApplicationOperation) Build(attempt int) ([]txn.Op, error) {
 
 // Done is part of the ModelOperation interface.
 func (op *DestroyApplicationOperation) Done(err error) error {
+	if err == nil {
+		// A destroyed application is no longer scaled, so its
+		// scaling decisions are of no further interest.
+		err = eraseAutoscalingDecisions(op.app.st, op.app.doc.Name)
+	}
 	return errors.Annotatef(err, "cannot destroy application %q", op.app)
 }
 
@@ -365,6 +370,7 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 		removeSettingsOp(settingsC, a.applicationConfigKey()),
 		removeModelApplicationRefOp(a.st, name),
 		removeContainerSpecOp(a.Tag()),
+		removeAutoscalingPolicyOp(a.st, name),
 	)
 	return ops, nil
 }
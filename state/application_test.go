@@ -2571,6 +2571,28 @@ func (s *ApplicationSuite) TestWatchApplication(c *gc.C) {
 	testing.NewNotifyWatcherC(c, s.State, w).AssertOneChange()
 }
 
+func (s *ApplicationSuite) TestWatchApplicationCharms(c *gc.C) {
+	w := s.State.WatchApplicationCharms()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange("mysql")
+	wc.AssertNoChange()
+
+	// Upgrading the charm is reported.
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 6)
+	err := s.mysql.SetCharm(state.SetCharmConfig{Charm: sch})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("mysql")
+	wc.AssertNoChange()
+
+	// So is a new application.
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	wc.AssertChange("wordpress")
+	wc.AssertNoChange()
+}
+
 func (s *ApplicationSuite) TestMetricCredentials(c *gc.C) {
 	err := s.mysql.SetMetricCredentials([]byte("hello there"))
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,564 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const (
+	// autoscalingMetricWindow is how far back metrics are considered
+	// when deciding how to scale an application.
+	autoscalingMetricWindow = 15 * time.Minute
+
+	// autoscalingTolerance is the relative distance from the target
+	// within which the metric value is considered on target, so that
+	// the application is not scaled in response to small changes.
+	autoscalingTolerance = 0.1
+
+	// maxAutoscalingDecisions is the number of scaling decisions kept
+	// for each application.
+	maxAutoscalingDecisions = 100
+)
+
+// AutoscalingPolicy describes how an application is scaled in response
+// to a metric reported by its units.
+type AutoscalingPolicy struct {
+	// MetricKey is the name of the charm metric that drives scaling.
+	MetricKey string
+
+	// Target is the value of the metric, averaged over the units, that
+	// the application is scaled to achieve.
+	Target float64
+
+	// MinUnits and MaxUnits bound the number of units the application
+	// is scaled to.
+	MinUnits int
+	MaxUnits int
+
+	// Cooldown is the minimum time between scaling decisions.
+	Cooldown time.Duration
+}
+
+// Validate returns an error if the policy is not valid.
+func (p AutoscalingPolicy) Validate() error {
+	if p.MetricKey == "" {
+		return errors.NotValidf("empty metric key")
+	}
+	if p.Target <= 0 || math.IsInf(p.Target, 0) || math.IsNaN(p.Target) {
+		return errors.NotValidf("target %v", p.Target)
+	}
+	if p.MinUnits < 0 {
+		return errors.NotValidf("negative minimum units")
+	}
+	if p.MaxUnits < 1 {
+		return errors.NotValidf("maximum units less than 1")
+	}
+	if p.MinUnits > p.MaxUnits {
+		return errors.NotValidf("minimum units %d greater than maximum units %d", p.MinUnits, p.MaxUnits)
+	}
+	if p.Cooldown < 0 {
+		return errors.NotValidf("negative cooldown")
+	}
+	return nil
+}
+
+// autoscalingPolicyDoc records the autoscaling policy of an application,
+// and when the application was last scaled by it.
+type autoscalingPolicyDoc struct {
+	DocId       string  `bson:"_id"`
+	ModelUUID   string  `bson:"model-uuid"`
+	Application string  `bson:"application"`
+	MetricKey   string  `bson:"metric-key"`
+	Target      float64 `bson:"target"`
+	MinUnits    int     `bson:"min-units"`
+	MaxUnits    int     `bson:"max-units"`
+	Cooldown    int64   `bson:"cooldown"`
+	LastScaled  int64   `bson:"last-scaled,omitempty"`
+}
+
+func (doc *autoscalingPolicyDoc) policy() AutoscalingPolicy {
+	return AutoscalingPolicy{
+		MetricKey: doc.MetricKey,
+		Target:    doc.Target,
+		MinUnits:  doc.MinUnits,
+		MaxUnits:  doc.MaxUnits,
+		Cooldown:  time.Duration(doc.Cooldown),
+	}
+}
+
+// AutoscalingDecision records a change made to the number of an
+// application's units by its autoscaling policy.
+type AutoscalingDecision struct {
+	// Time is when the decision was made.
+	Time time.Time
+
+	// MetricKey and Target are those of the policy that made the
+	// decision.
+	MetricKey string
+	Target    float64
+
+	// MetricValue is the value of the metric, averaged over the
+	// units that reported it. It is zero if no units reported it,
+	// in which case the decision only brought the number of units
+	// within the policy's bounds.
+	MetricValue float64
+
+	// ReportingUnits is the number of units that reported the metric.
+	ReportingUnits int
+
+	// FromUnits and ToUnits are the number of units before and after
+	// the decision.
+	FromUnits int
+	ToUnits   int
+
+	// Units holds the names of the units that were added or removed.
+	Units []string
+
+	// Error describes why the decision could not be carried out in
+	// full, if it could not.
+	Error string
+}
+
+// autoscalingDecisionDoc is the persistent form of an
+// AutoscalingDecision.
+type autoscalingDecisionDoc struct {
+	Id             bson.ObjectId `bson:"_id"`
+	ModelUUID      string        `bson:"model-uuid"`
+	Application    string        `bson:"application"`
+	Time           int64         `bson:"time"`
+	MetricKey      string        `bson:"metric-key"`
+	Target         float64       `bson:"target"`
+	MetricValue    float64       `bson:"metric-value"`
+	ReportingUnits int           `bson:"reporting-units"`
+	FromUnits      int           `bson:"from-units"`
+	ToUnits        int           `bson:"to-units"`
+	Units          []string      `bson:"units,omitempty"`
+	Error          string        `bson:"error,omitempty"`
+}
+
+// SetAutoscalingPolicy sets the policy by which the application is
+// scaled in response to the metrics reported by its units. The metric
+// must be defined by the application's charm.
+func (a *Application) SetAutoscalingPolicy(policy AutoscalingPolicy) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set autoscaling policy for application %q", a)
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if !a.IsPrincipal() {
+		return errors.NotSupportedf("autoscaling a subordinate application")
+	}
+	ch, _, err := a.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var defined bool
+	if metrics := ch.Metrics(); metrics != nil {
+		_, defined = metrics.Metrics[policy.MetricKey]
+	}
+	if !defined {
+		return errors.Errorf("metric %q not defined by charm %q", policy.MetricKey, ch.URL())
+	}
+
+	app := &Application{st: a.st, doc: a.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := app.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if app.doc.Life != Alive {
+			return nil, errors.New("application is no longer alive")
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		_, err := app.autoscalingPolicyDoc()
+		if errors.IsNotFound(err) {
+			return append(ops, txn.Op{
+				C:      autoscalingPoliciesC,
+				Id:     app.st.docID(app.doc.Name),
+				Assert: txn.DocMissing,
+				Insert: &autoscalingPolicyDoc{
+					Application: app.doc.Name,
+					MetricKey:   policy.MetricKey,
+					Target:      policy.Target,
+					MinUnits:    policy.MinUnits,
+					MaxUnits:    policy.MaxUnits,
+					Cooldown:    int64(policy.Cooldown),
+				},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      autoscalingPoliciesC,
+			Id:     app.st.docID(app.doc.Name),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"metric-key", policy.MetricKey},
+				{"target", policy.Target},
+				{"min-units", policy.MinUnits},
+				{"max-units", policy.MaxUnits},
+				{"cooldown", int64(policy.Cooldown)},
+			}}},
+		}), nil
+	}
+	return a.st.db().Run(buildTxn)
+}
+
+// AutoscalingPolicy returns the application's autoscaling policy, or an
+// error satisfying errors.IsNotFound if it has none.
+func (a *Application) AutoscalingPolicy() (AutoscalingPolicy, error) {
+	doc, err := a.autoscalingPolicyDoc()
+	if err != nil {
+		return AutoscalingPolicy{}, errors.Trace(err)
+	}
+	return doc.policy(), nil
+}
+
+func (a *Application) autoscalingPolicyDoc() (*autoscalingPolicyDoc, error) {
+	policies, closer := a.st.db().GetCollection(autoscalingPoliciesC)
+	defer closer()
+
+	var doc autoscalingPolicyDoc
+	err := policies.FindId(a.doc.Name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("autoscaling policy for application %q", a.doc.Name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get autoscaling policy for application %q", a.doc.Name)
+	}
+	return &doc, nil
+}
+
+// RemoveAutoscalingPolicy removes the application's autoscaling policy,
+// if it has one. The application's units are left as they are.
+func (a *Application) RemoveAutoscalingPolicy() error {
+	err := a.st.db().RunTransaction([]txn.Op{
+		removeAutoscalingPolicyOp(a.st, a.doc.Name),
+	})
+	return errors.Annotatef(err, "cannot remove autoscaling policy for application %q", a)
+}
+
+// removeAutoscalingPolicyOp returns the operation required to remove the
+// named application's autoscaling policy, whether or not it has one.
+func removeAutoscalingPolicyOp(st *State, applicationname string) txn.Op {
+	return txn.Op{
+		C:      autoscalingPoliciesC,
+		Id:     st.docID(applicationname),
+		Remove: true,
+	}
+}
+
+// AutoscalingDecisions returns the decisions made by the application's
+// autoscaling policy, most recent first.
+func (a *Application) AutoscalingDecisions() ([]AutoscalingDecision, error) {
+	decisions, closer := a.st.db().GetCollection(autoscalingDecisionsC)
+	defer closer()
+
+	var docs []autoscalingDecisionDoc
+	err := decisions.Find(bson.D{{"application", a.doc.Name}}).Sort("-time", "-_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get autoscaling decisions for application %q", a.doc.Name)
+	}
+	result := make([]AutoscalingDecision, len(docs))
+	for i, doc := range docs {
+		result[i] = AutoscalingDecision{
+			Time:           time.Unix(0, doc.Time).UTC(),
+			MetricKey:      doc.MetricKey,
+			Target:         doc.Target,
+			MetricValue:    doc.MetricValue,
+			ReportingUnits: doc.ReportingUnits,
+			FromUnits:      doc.FromUnits,
+			ToUnits:        doc.ToUnits,
+			Units:          doc.Units,
+			Error:          doc.Error,
+		}
+	}
+	return result, nil
+}
+
+// recordAutoscalingDecision records a scaling decision for the named
+// application, discarding the application's oldest decisions beyond
+// maxAutoscalingDecisions.
+func (st *State) recordAutoscalingDecision(applicationname string, decision AutoscalingDecision) error {
+	decisions, closer := st.db().GetCollection(autoscalingDecisionsC)
+	defer closer()
+	decisionsW := decisions.Writeable()
+
+	err := decisionsW.Insert(&autoscalingDecisionDoc{
+		Id:             bson.NewObjectId(),
+		Application:    applicationname,
+		Time:           decision.Time.UnixNano(),
+		MetricKey:      decision.MetricKey,
+		Target:         decision.Target,
+		MetricValue:    decision.MetricValue,
+		ReportingUnits: decision.ReportingUnits,
+		FromUnits:      decision.FromUnits,
+		ToUnits:        decision.ToUnits,
+		Units:          decision.Units,
+		Error:          decision.Error,
+	})
+	if err != nil {
+		return errors.Annotatef(err, "cannot record autoscaling decision for application %q", applicationname)
+	}
+
+	var stale []struct {
+		Id bson.ObjectId `bson:"_id"`
+	}
+	err = decisions.Find(bson.D{{"application", applicationname}}).
+		Sort("-time", "-_id").Skip(maxAutoscalingDecisions).Select(bson.D{{"_id", 1}}).All(&stale)
+	if err != nil {
+		return errors.Annotatef(err, "cannot prune autoscaling decisions for application %q", applicationname)
+	}
+	for _, doc := range stale {
+		if err := decisionsW.RemoveId(doc.Id); err != nil && err != mgo.ErrNotFound {
+			return errors.Annotatef(err, "cannot prune autoscaling decisions for application %q", applicationname)
+		}
+	}
+	return nil
+}
+
+// eraseAutoscalingDecisions removes the recorded scaling decisions of
+// the named application.
+func eraseAutoscalingDecisions(st *State, applicationname string) error {
+	decisions, closer := st.db().GetCollection(autoscalingDecisionsC)
+	defer closer()
+
+	_, err := decisions.Writeable().RemoveAll(bson.D{{"application", applicationname}})
+	return errors.Annotatef(err, "cannot remove autoscaling decisions for application %q", applicationname)
+}
+
+// UnitScaler adds and removes the units of applications on behalf of
+// Autoscale, so that they are added and removed the same way as they
+// are by "juju add-unit" and "juju remove-unit".
+type UnitScaler interface {
+	// AddUnits adds n units to the named application, and returns
+	// the names of the units added.
+	AddUnits(application string, n int) ([]string, error)
+
+	// DestroyUnits destroys the named units, leaving their storage.
+	DestroyUnits(units ...string) error
+}
+
+// Autoscale scales each application that has an autoscaling policy, and
+// whose policy's cooldown has passed since it was last scaled, so that
+// the policy's metric, averaged over the units that recently reported
+// it, approaches the policy's target; the number of units is kept
+// within the policy's bounds. Units are added and removed using the
+// given scaler. Each decision is recorded; see
+// Application.AutoscalingDecisions. Failing to scale one application
+// is logged, and does not prevent the others from being scaled.
+//
+// It returns the time at which the cooldown of the next application
+// waiting to be scaled passes, or the zero time if there is none.
+func (st *State) Autoscale(scaler UnitScaler) (time.Time, error) {
+	policies, closer := st.db().GetCollection(autoscalingPoliciesC)
+	defer closer()
+
+	var docs []autoscalingPolicyDoc
+	if err := policies.Find(nil).All(&docs); err != nil {
+		return time.Time{}, errors.Annotate(err, "cannot get autoscaling policies")
+	}
+
+	var next time.Time
+	for _, doc := range docs {
+		due, err := st.autoscaleApplication(doc, scaler)
+		if err != nil {
+			logger.Errorf("cannot autoscale application %q: %v", doc.Application, err)
+			continue
+		}
+		if !due.IsZero() && (next.IsZero() || due.Before(next)) {
+			next = due
+		}
+	}
+	return next, nil
+}
+
+// autoscaleApplication scales the application with the given policy, if
+// it needs scaling. If its policy's cooldown prevents it from being
+// scaled, the time at which the cooldown passes is returned.
+func (st *State) autoscaleApplication(doc autoscalingPolicyDoc, scaler UnitScaler) (time.Time, error) {
+	app, err := st.Application(doc.Application)
+	if errors.IsNotFound(err) {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	if app.Life() != Alive {
+		return time.Time{}, nil
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	var alive []*Unit
+	for _, unit := range units {
+		if unit.Life() == Alive {
+			alive = append(alive, unit)
+		}
+	}
+
+	now := st.clock().Now()
+	since := now.Add(-autoscalingMetricWindow)
+	var lastScaled time.Time
+	if doc.LastScaled != 0 {
+		lastScaled = time.Unix(0, doc.LastScaled)
+	}
+	if lastScaled.After(since) {
+		// Metrics reported by the time of the last decision
+		// describe the load on a different number of units.
+		since = lastScaled
+	}
+	value, reporting, err := st.autoscalingMetric(alive, doc.MetricKey, since)
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+
+	policy := doc.policy()
+	current := len(alive)
+	desired := desiredUnits(policy, current, value, reporting)
+	if desired == current {
+		return time.Time{}, nil
+	}
+	if due := lastScaled.Add(policy.Cooldown); !lastScaled.IsZero() && due.After(now) {
+		return due, nil
+	}
+
+	decision := AutoscalingDecision{
+		Time:           now,
+		MetricKey:      doc.MetricKey,
+		Target:         doc.Target,
+		MetricValue:    value,
+		ReportingUnits: reporting,
+		FromUnits:      current,
+		ToUnits:        desired,
+	}
+	var scaleErr error
+	if desired > current {
+		decision.Units, scaleErr = scaler.AddUnits(app.Name(), desired-current)
+	} else {
+		decision.Units = newestUnits(alive, current-desired)
+		scaleErr = scaler.DestroyUnits(decision.Units...)
+	}
+	if scaleErr != nil {
+		logger.Warningf("cannot autoscale application %q: %v", app.Name(), scaleErr)
+		decision.Error = scaleErr.Error()
+	} else {
+		logger.Infof(
+			"autoscaled application %q from %d to %d units (%s %v, target %v)",
+			app.Name(), current, desired, doc.MetricKey, value, doc.Target,
+		)
+	}
+
+	err = st.db().RunTransaction([]txn.Op{{
+		C:      autoscalingPoliciesC,
+		Id:     doc.DocId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"last-scaled", now.UnixNano()}}}},
+	}})
+	if err != nil && err != txn.ErrAborted {
+		return time.Time{}, errors.Annotatef(err, "cannot update autoscaling policy for application %q", app.Name())
+	}
+	if err := st.recordAutoscalingDecision(app.Name(), decision); err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	return time.Time{}, nil
+}
+
+// autoscalingMetric returns the value of the named metric averaged over
+// the given units, taking the latest value reported by each unit after
+// the given time, along with the number of units that reported it.
+func (st *State) autoscalingMetric(units []*Unit, key string, since time.Time) (float64, int, error) {
+	if len(units) == 0 {
+		return 0, 0, nil
+	}
+	unitNames := make([]string, len(units))
+	for i, unit := range units {
+		unitNames[i] = unit.Name()
+	}
+	batches, err := st.queryMetricBatches(bson.M{
+		"unit":    bson.M{"$in": unitNames},
+		"created": bson.M{"$gt": since},
+	})
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+
+	type reading struct {
+		value float64
+		time  time.Time
+	}
+	latest := make(map[string]reading)
+	for _, batch := range batches {
+		for _, metric := range batch.Metrics() {
+			if metric.Key != key {
+				continue
+			}
+			value, err := strconv.ParseFloat(metric.Value, 64)
+			if err != nil {
+				logger.Debugf("ignoring metric %q from unit %q: %v", key, batch.Unit(), err)
+				continue
+			}
+			if r, ok := latest[batch.Unit()]; ok && r.time.After(metric.Time) {
+				continue
+			}
+			latest[batch.Unit()] = reading{value, metric.Time}
+		}
+	}
+	if len(latest) == 0 {
+		return 0, 0, nil
+	}
+	var sum float64
+	for _, r := range latest {
+		sum += r.value
+	}
+	return sum / float64(len(latest)), len(latest), nil
+}
+
+// desiredUnits returns the number of units the policy requires, given
+// the current number of units and the average value of the policy's
+// metric over the number of units that reported it. The number of units
+// is scaled in proportion to the ratio of the value to the target.
+func desiredUnits(policy AutoscalingPolicy, current int, value float64, reporting int) int {
+	desired := current
+	if reporting > 0 {
+		ratio := value / policy.Target
+		if math.Abs(ratio-1) > autoscalingTolerance {
+			desired = int(math.Ceil(float64(current) * ratio))
+		}
+	}
+	if desired < policy.MinUnits {
+		desired = policy.MinUnits
+	}
+	if desired > policy.MaxUnits {
+		desired = policy.MaxUnits
+	}
+	return desired
+}
+
+// newestUnits returns the names of the n most recently added of the
+// given units, newest first.
+func newestUnits(units []*Unit, n int) []string {
+	units = append([]*Unit(nil), units...)
+	sort.Slice(units, func(i, j int) bool {
+		return units[i].UnitTag().Number() > units[j].UnitTag().Number()
+	})
+	names := make([]string, n)
+	for i, unit := range units[:n] {
+		names[i] = unit.Name()
+	}
+	return names
+}
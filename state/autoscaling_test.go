@@ -0,0 +1,343 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type AutoscalingSuite struct {
+	ConnSuite
+	application *state.Application
+	policy      state.AutoscalingPolicy
+	scaler      *unitScaler
+}
+
+var _ = gc.Suite(&AutoscalingSuite{})
+
+func (s *AutoscalingSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "metered", URL: "cs:quantal/metered-1"})
+	s.application = s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: ch})
+	s.policy = state.AutoscalingPolicy{
+		MetricKey: "pings",
+		Target:    80,
+		MinUnits:  2,
+		MaxUnits:  5,
+		Cooldown:  5 * time.Minute,
+	}
+	s.scaler = &unitScaler{st: s.State}
+}
+
+// unitScaler adds and removes units the way the application facade
+// does, failing with err if it is set.
+type unitScaler struct {
+	st  *state.State
+	err error
+}
+
+func (s *unitScaler) AddUnits(appName string, n int) ([]string, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	app, err := s.st.Application(appName)
+	if err != nil {
+		return nil, err
+	}
+	var added []string
+	for i := 0; i < n; i++ {
+		unit, err := app.AddUnit(state.AddUnitParams{})
+		if err != nil {
+			return added, err
+		}
+		if err := s.st.AssignUnit(unit, state.AssignCleanEmpty); err != nil {
+			return added, err
+		}
+		added = append(added, unit.Name())
+	}
+	return added, nil
+}
+
+func (s *unitScaler) DestroyUnits(unitNames ...string) error {
+	if s.err != nil {
+		return s.err
+	}
+	for _, name := range unitNames {
+		unit, err := s.st.Unit(name)
+		if err != nil {
+			return err
+		}
+		if err := s.st.ApplyOperation(unit.DestroyOperation()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addUnits adds n units of the application, each reporting the given
+// value of the "pings" metric.
+func (s *AutoscalingSuite) addUnits(c *gc.C, n int, pings string) []*state.Unit {
+	units := make([]*state.Unit, n)
+	for i := range units {
+		units[i] = s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.application, SetCharmURL: true})
+		s.reportPings(c, units[i], pings)
+	}
+	return units
+}
+
+func (s *AutoscalingSuite) reportPings(c *gc.C, unit *state.Unit, pings string) {
+	now := s.Clock.Now()
+	s.Factory.MakeMetric(c, &factory.MetricParams{
+		Unit:    unit,
+		Time:    &now,
+		Metrics: []state.Metric{{"pings", pings, now}},
+	})
+}
+
+func (s *AutoscalingSuite) aliveUnitNames(c *gc.C) []string {
+	units, err := s.application.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	var names []string
+	for _, unit := range units {
+		if unit.Life() == state.Alive {
+			names = append(names, unit.Name())
+		}
+	}
+	return names
+}
+
+func (s *AutoscalingSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		modify func(*state.AutoscalingPolicy)
+		err    string
+	}{{
+		modify: func(p *state.AutoscalingPolicy) { p.MetricKey = "" },
+		err:    "empty metric key not valid",
+	}, {
+		modify: func(p *state.AutoscalingPolicy) { p.Target = 0 },
+		err:    "target 0 not valid",
+	}, {
+		modify: func(p *state.AutoscalingPolicy) { p.MinUnits = -1 },
+		err:    "negative minimum units not valid",
+	}, {
+		modify: func(p *state.AutoscalingPolicy) { p.MaxUnits = 0; p.MinUnits = 0 },
+		err:    "maximum units less than 1 not valid",
+	}, {
+		modify: func(p *state.AutoscalingPolicy) { p.MinUnits = 6 },
+		err:    "minimum units 6 greater than maximum units 5 not valid",
+	}, {
+		modify: func(p *state.AutoscalingPolicy) { p.Cooldown = -time.Second },
+		err:    "negative cooldown not valid",
+	}} {
+		c.Logf("test %d", i)
+		policy := s.policy
+		test.modify(&policy)
+		c.Check(policy.Validate(), gc.ErrorMatches, test.err)
+	}
+	c.Assert(s.policy.Validate(), jc.ErrorIsNil)
+}
+
+func (s *AutoscalingSuite) TestSetAutoscalingPolicy(c *gc.C) {
+	_, err := s.application.AutoscalingPolicy()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.application.SetAutoscalingPolicy(s.policy)
+	c.Assert(err, jc.ErrorIsNil)
+	policy, err := s.application.AutoscalingPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, s.policy)
+
+	s.policy.MaxUnits = 10
+	err = s.application.SetAutoscalingPolicy(s.policy)
+	c.Assert(err, jc.ErrorIsNil)
+	policy, err = s.application.AutoscalingPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, s.policy)
+
+	err = s.application.RemoveAutoscalingPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.application.AutoscalingPolicy()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a policy that does not exist is not an error.
+	err = s.application.RemoveAutoscalingPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AutoscalingSuite) TestSetAutoscalingPolicyUnknownMetric(c *gc.C) {
+	s.policy.MetricKey = "load"
+	err := s.application.SetAutoscalingPolicy(s.policy)
+	c.Assert(err, gc.ErrorMatches, `cannot set autoscaling policy for application "metered": metric "load" not defined by charm "cs:quantal/metered-1"`)
+
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.policy.MetricKey = "pings"
+	err = wordpress.SetAutoscalingPolicy(s.policy)
+	c.Assert(err, gc.ErrorMatches, `cannot set autoscaling policy for application "wordpress": metric "pings" not defined by charm .*`)
+}
+
+func (s *AutoscalingSuite) TestAutoscaleUp(c *gc.C) {
+	s.addUnits(c, 2, "160")
+	err := s.application.SetAutoscalingPolicy(s.policy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	next, err := s.State.Autoscale(s.scaler)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.IsZero(), jc.IsTrue)
+	c.Assert(s.aliveUnitNames(c), jc.SameContents, []string{"metered/0", "metered/1", "metered/2", "metered/3"})
+	for _, name := range []string{"metered/2", "metered/3"} {
+		unit, err := s.State.Unit(name)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = unit.AssignedMachineId()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	decisions, err := s.application.AutoscalingDecisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decisions, gc.HasLen, 1)
+	c.Assert(decisions[0].Time.Equal(s.Clock.Now()), jc.IsTrue)
+	decisions[0].Time = time.Time{}
+	c.Assert(decisions[0], jc.DeepEquals, state.AutoscalingDecision{
+		MetricKey:      "pings",
+		Target:         80,
+		MetricValue:    160,
+		ReportingUnits: 2,
+		FromUnits:      2,
+		ToUnits:        4,
+		Units:          []string{"metered/2", "metered/3"},
+	})
+}
+
+func (s *AutoscalingSuite) TestAutoscaleDown(c *gc.C) {
+	s.addUnits(c, 4, "10")
+	err := s.application.SetAutoscalingPolicy(s.policy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Autoscale(s.scaler)
+	c.Assert(err, jc.ErrorIsNil)
+	// The most recently added units are removed, down to the
+	// policy's minimum.
+	c.Assert(s.aliveUnitNames(c), jc.SameContents, []string{"metered/0", "metered/1"})
+
+	decisions, err := s.application.AutoscalingDecisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decisions, gc.HasLen, 1)
+	c.Assert(decisions[0].FromUnits, gc.Equals, 4)
+	c.Assert(decisions[0].ToUnits, gc.Equals, 2)
+	c.Assert(decisions[0].Units, jc.DeepEquals, []string{"metered/3", "metered/2"})
+}
+
+func (s *AutoscalingSuite) TestAutoscaleScalerError(c *gc.C) {
+	s.addUnits(c, 2, "160")
+	err := s.application.SetAutoscalingPolicy(s.policy)
+	c.Assert(err, jc.ErrorIsNil)
+	s.scaler.err = errors.New("changes are blocked")
+
+	_, err = s.State.Autoscale(s.scaler)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.aliveUnitNames(c), gc.HasLen, 2)
+
+	decisions, err := s.application.AutoscalingDecisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decisions, gc.HasLen, 1)
+	c.Assert(decisions[0].ToUnits, gc.Equals, 4)
+	c.Assert(decisions[0].Units, gc.HasLen, 0)
+	c.Assert(decisions[0].Error, gc.Equals, "changes are blocked")
+}
+
+func (s *AutoscalingSuite) TestAutoscaleWithinTolerance(c *gc.C) {
+	s.addUnits(c, 2, "85")
+	err := s.application.SetAutoscalingPolicy(s.policy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Autoscale(s.scaler)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.aliveUnitNames(c), gc.HasLen, 2)
+	decisions, err := s.application.AutoscalingDecisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decisions, gc.HasLen, 0)
+}
+
+func (s *AutoscalingSuite) TestAutoscaleBoundsWithoutMetrics(c *gc.C) {
+	s.policy.MinUnits = 1
+	err := s.application.SetAutoscalingPolicy(s.policy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Autoscale(s.scaler)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.aliveUnitNames(c), jc.DeepEquals, []string{"metered/0"})
+	decisions, err := s.application.AutoscalingDecisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decisions, gc.HasLen, 1)
+	c.Assert(decisions[0].ReportingUnits, gc.Equals, 0)
+	c.Assert(decisions[0].FromUnits, gc.Equals, 0)
+	c.Assert(decisions[0].ToUnits, gc.Equals, 1)
+}
+
+func (s *AutoscalingSuite) TestAutoscaleCooldown(c *gc.C) {
+	units := s.addUnits(c, 2, "160")
+	err := s.application.SetAutoscalingPolicy(s.policy)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Autoscale(s.scaler)
+	c.Assert(err, jc.ErrorIsNil)
+	scaled := s.Clock.Now()
+
+	// The load stays high, but metrics reported before the last
+	// decision are not considered.
+	s.Clock.Advance(time.Minute)
+	next, err := s.State.Autoscale(s.scaler)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.IsZero(), jc.IsTrue)
+
+	for _, unit := range units {
+		s.reportPings(c, unit, "160")
+	}
+	next, err = s.State.Autoscale(s.scaler)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.Equal(scaled.Add(5*time.Minute)), jc.IsTrue)
+	c.Assert(s.aliveUnitNames(c), gc.HasLen, 4)
+
+	s.Clock.Advance(4 * time.Minute)
+	_, err = s.State.Autoscale(s.scaler)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.aliveUnitNames(c), gc.HasLen, 5)
+	decisions, err := s.application.AutoscalingDecisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decisions, gc.HasLen, 2)
+	c.Assert(decisions[0].FromUnits, gc.Equals, 4)
+	c.Assert(decisions[0].ToUnits, gc.Equals, 5)
+}
+
+func (s *AutoscalingSuite) TestDestroyApplication(c *gc.C) {
+	s.policy.MinUnits = 1
+	err := s.application.SetAutoscalingPolicy(s.policy)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Autoscale(s.scaler)
+	c.Assert(err, jc.ErrorIsNil)
+	decisions, err := s.application.AutoscalingDecisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decisions, gc.HasLen, 1)
+
+	// The decisions are erased when the application is destroyed...
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	decisions, err = s.application.AutoscalingDecisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decisions, gc.HasLen, 0)
+
+	// ...and the policy when it is removed.
+	unit, err := s.State.Unit("metered/0")
+	c.Assert(err, jc.ErrorIsNil)
+	removeUnit(c, unit)
+	err = s.application.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.application.AutoscalingPolicy()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
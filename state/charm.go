@@ -17,6 +17,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/mongo"
 	mongoutils "github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/state/storage"
@@ -81,6 +82,18 @@ type charmDoc struct {
 	Config  *charm.Config  `bson:"config"`
 	Actions *charm.Actions `bson:"actions"`
 	Metrics *charm.Metrics `bson:"metrics"`
+
+	// LXDProfile holds the LXD profile shipped with the charm, if
+	// any, with mongo-significant characters in its keys escaped.
+	LXDProfile *lxdProfileDoc `bson:"lxd-profile,omitempty"`
+}
+
+// lxdProfileDoc is the persistent representation of a charm's LXD
+// profile.
+type lxdProfileDoc struct {
+	Config      map[string]string            `bson:"config,omitempty"`
+	Description string                       `bson:"description,omitempty"`
+	Devices     map[string]map[string]string `bson:"devices,omitempty"`
 }
 
 // CharmInfo contains all the data necessary to store a charm's metadata.
@@ -98,6 +111,10 @@ func insertCharmOps(mb modelBackend, info CharmInfo) ([]txn.Op, error) {
 	if info.ID == nil {
 		return nil, errors.New("*charm.URL was nil")
 	}
+	profile, err := safeLXDProfile(info.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	doc := charmDoc{
 		DocID:        info.ID.String(),
@@ -106,6 +123,7 @@ func insertCharmOps(mb modelBackend, info CharmInfo) ([]txn.Op, error) {
 		Config:       safeConfig(info.Charm),
 		Metrics:      info.Charm.Metrics(),
 		Actions:      info.Charm.Actions(),
+		LXDProfile:   profile,
 		BundleSha256: info.SHA256,
 		StoragePath:  info.StoragePath,
 	}
@@ -205,11 +223,17 @@ func updateCharmOps(mb modelBackend, info CharmInfo, assert bson.D) ([]txn.Op, e
 	}
 	op.Assert = append(lifeAssert, assert...)
 
+	profile, err := safeLXDProfile(info.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	data := bson.D{
 		{"meta", info.Charm.Meta()},
 		{"config", safeConfig(info.Charm)},
 		{"actions", info.Charm.Actions()},
 		{"metrics", info.Charm.Metrics()},
+		{"lxd-profile", profile},
 		{"storagepath", info.StoragePath},
 		{"bundlesha256", info.SHA256},
 		{"pendingupload", false},
@@ -308,6 +332,42 @@ func safeConfig(ch charm.Charm) *charm.Config {
 	return escapedConfig
 }
 
+// safeLXDProfile reads and validates the LXD profile shipped with the
+// charm, if any, and escapes mongo-significant characters in its keys.
+func safeLXDProfile(ch charm.Charm) (*lxdProfileDoc, error) {
+	profile, err := lxdprofile.ReadCharm(ch)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid charm LXD profile")
+	}
+	if profile == nil {
+		return nil, nil
+	}
+	doc := &lxdProfileDoc{
+		Config:      copyStringMap(profile.Config, escapeReplacer.Replace),
+		Description: profile.Description,
+	}
+	if len(profile.Devices) > 0 {
+		doc.Devices = make(map[string]map[string]string)
+		for name, device := range profile.Devices {
+			doc.Devices[escapeReplacer.Replace(name)] = copyStringMap(device, escapeReplacer.Replace)
+		}
+	}
+	return doc, nil
+}
+
+// copyStringMap returns a copy of the map, with each key replaced
+// using the supplied function.
+func copyStringMap(in map[string]string, replace func(string) string) map[string]string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for key, value := range in {
+		out[replace(key)] = value
+	}
+	return out
+}
+
 // Charm represents the state of a charm in the model.
 type Charm struct {
 	st  *State
@@ -326,6 +386,19 @@ func newCharm(st *State, cdoc *charmDoc) *Charm {
 		}
 		cdoc.Config = unescapedConfig
 	}
+	if cdoc != nil && cdoc.LXDProfile != nil {
+		profile := &lxdProfileDoc{
+			Config:      copyStringMap(cdoc.LXDProfile.Config, unescapeReplacer.Replace),
+			Description: cdoc.LXDProfile.Description,
+		}
+		if len(cdoc.LXDProfile.Devices) > 0 {
+			profile.Devices = make(map[string]map[string]string)
+			for name, device := range cdoc.LXDProfile.Devices {
+				profile.Devices[unescapeReplacer.Replace(name)] = copyStringMap(device, unescapeReplacer.Replace)
+			}
+		}
+		cdoc.LXDProfile = profile
+	}
 	ch := Charm{st: st, doc: *cdoc}
 	return &ch
 }
@@ -454,6 +527,19 @@ func (c *Charm) Actions() *charm.Actions {
 	return c.doc.Actions
 }
 
+// LXDProfile returns the LXD profile shipped with the charm, or nil if
+// the charm does not have one.
+func (c *Charm) LXDProfile() *lxdprofile.Profile {
+	if c.doc.LXDProfile == nil {
+		return nil
+	}
+	return &lxdprofile.Profile{
+		Config:      c.doc.LXDProfile.Config,
+		Description: c.doc.LXDProfile.Description,
+		Devices:     c.doc.LXDProfile.Devices,
+	}
+}
+
 // StoragePath returns the storage path of the charm bundle.
 func (c *Charm) StoragePath() string {
 	return c.doc.StoragePath
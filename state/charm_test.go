@@ -17,6 +17,7 @@ import (
 	"gopkg.in/macaroon.v1"
 	"gopkg.in/mgo.v2"
 
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/testcharms"
@@ -368,6 +369,51 @@ func (s *CharmSuite) TestAddCharmWithInvalidMetaData(c *gc.C) {
 	})
 }
 
+func (s *CharmSuite) TestAddCharmWithLXDProfile(c *gc.C) {
+	ch := testcharms.Repo.CharmDir("lxd-profile")
+	curl := charm.MustParseURL("local:quantal/lxd-profile-0")
+	added, err := s.State.AddCharm(state.CharmInfo{
+		Charm:       ch,
+		ID:          curl,
+		StoragePath: "dummy-path",
+		SHA256:      "dummy-1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added.LXDProfile(), gc.NotNil)
+
+	sch, err := s.State.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.LXDProfile(), jc.DeepEquals, &lxdprofile.Profile{
+		Description: "lxd profile for testing",
+		Config: map[string]string{
+			"security.nesting":       "true",
+			"security.privileged":    "true",
+			"linux.kernel_modules":   "openvswitch,nbd,ip_tables,ip6_tables",
+			"environment.http_proxy": "",
+		},
+		Devices: map[string]map[string]string{
+			"tun": {
+				"path": "/dev/net/tun",
+				"type": "unix-char",
+			},
+		},
+	})
+
+	dummy, err := s.State.Charm(s.curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dummy.LXDProfile(), gc.IsNil)
+}
+
+func (s *CharmSuite) TestAddCharmWithInvalidLXDProfile(c *gc.C) {
+	_, err := s.State.AddCharm(state.CharmInfo{
+		Charm:       testcharms.Repo.CharmDir("lxd-profile-invalid"),
+		ID:          charm.MustParseURL("local:quantal/lxd-profile-invalid-0"),
+		StoragePath: "dummy-path",
+		SHA256:      "dummy-1",
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid charm LXD profile: LXD profile config key "boot.autostart" not valid`)
+}
+
 func (s *CharmSuite) TestPrepareLocalCharmUpload(c *gc.C) {
 	// First test the sanity checks.
 	curl, err := s.State.PrepareLocalCharmUpload(charm.MustParseURL("local:quantal/dummy"))
@@ -30,6 +30,8 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.AutocertURLKey:      true,
 		controller.AutocertDNSNameKey:  true,
 		controller.AllowModelAccessKey: true,
+		controller.MetricsPasswordKey:  true,
+		controller.TracingEndpointKey:  true,
 		controller.MongoMemoryProfile:  true,
 		controller.JujuHASpace:         true,
 		controller.JujuManagementSpace: true,
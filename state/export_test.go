@@ -297,6 +297,10 @@ func NewActionStatusWatcher(st *State, receivers []ActionReceiver, statuses ...A
 	return newActionStatusWatcher(st, receivers, statuses...)
 }
 
+func PruneActionMessages(st *State, max int) error {
+	return pruneActionMessages(st, max)
+}
+
 func GetAllUpgradeInfos(st *State) ([]*UpgradeInfo, error) {
 	upgradeInfos, closer := st.db().GetCollection(upgradeInfoC)
 	defer closer()
@@ -477,6 +481,12 @@ func ForceDestroyMachineOps(m *Machine) ([]txn.Op, error) {
 	return m.forceDestroyOps()
 }
 
+// AddMachineReplacement adds the machine to replace m, as Machine.Replace
+// does before moving any units.
+func AddMachineReplacement(m *Machine) (*Machine, error) {
+	return m.addReplacement()
+}
+
 func IsManagerMachineError(err error) bool {
 	return errors.Cause(err) == managerMachineError
 }
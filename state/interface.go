@@ -143,6 +143,10 @@ type ActionReceiver interface {
 	// ActionReceiver.
 	AddAction(name string, payload map[string]interface{}) (Action, error)
 
+	// AddOperationAction queues an action with the given name and
+	// payload for this ActionReceiver, as part of the given operation.
+	AddOperationAction(operation, name string, payload map[string]interface{}) (Action, error)
+
 	// CancelAction removes a pending Action from the queue for this
 	// ActionReceiver and marks it as cancelled.
 	CancelAction(action Action) (Action, error)
@@ -189,6 +193,10 @@ type Action interface {
 	// Name returns the name of the action, as defined in the charm.
 	Name() string
 
+	// Operation returns the id of the operation the action belongs
+	// to, or "" if it does not belong to one.
+	Operation() string
+
 	// Parameters will contain a structure representing arguments or parameters to
 	// an action, and is expected to be validated by the Unit using the Charm
 	// definition of the Action.
@@ -210,6 +218,14 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// Messages returns the progress messages logged by the action,
+	// oldest first.
+	Messages() []ActionMessage
+
+	// Log adds a timestamped progress message to the action, which
+	// must be running.
+	Log(message string) error
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
@@ -0,0 +1,382 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/status"
+)
+
+// lostUnitDoc records a principal unit of a lost machine that is to be
+// replaced by a unit of the same application on the replacement machine.
+type lostUnitDoc struct {
+	Unit string `bson:"unit"`
+
+	// Storage holds the ids of the unit's detachable storage
+	// instances, which are attached to the replacement unit.
+	Storage []string `bson:"storage,omitempty"`
+}
+
+// ReplaceLostMachines replaces each machine whose instance has been
+// missing from the cloud for longer than the model's lost machine grace
+// period, if the applications of all the units on the machine have
+// opted in to replacement; see Machine.Replace. An application opts in
+// or out with its "replace-lost-machines" config setting; if that is
+// not set, the model's setting of the same name applies. Machines that
+// host containers are not replaced. Replacements that were interrupted
+// are resumed.
+//
+// It returns the time at which the next lost machine would be replaced,
+// or the zero time if there is none. A machine that cannot be replaced
+// does not prevent the others from being replaced; the errors for all
+// of them are returned together.
+func (st *State) ReplaceLostMachines() (time.Time, error) {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	machines, err := st.AllMachines()
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	now := st.clock().Now()
+	var next time.Time
+	var errs []string
+	for _, m := range machines {
+		due, err := st.lostMachineDue(m, cfg.ReplaceLostMachines(), cfg.LostMachineGracePeriod())
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("checking machine %s: %v", m.Id(), err))
+			continue
+		}
+		if due.IsZero() {
+			continue
+		}
+		if due.After(now) {
+			if next.IsZero() || due.Before(next) {
+				next = due
+			}
+			continue
+		}
+		replacement, err := m.Replace()
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		logger.Infof("replaced lost machine %s with machine %s", m.Id(), replacement.Id())
+	}
+	if len(errs) > 0 {
+		return next, errors.New(strings.Join(errs, "; "))
+	}
+	return next, nil
+}
+
+// lostMachineDue returns the time at which the machine is due to be
+// replaced, or the zero time if it is not lost or may not be replaced.
+func (st *State) lostMachineDue(m *Machine, modelPolicy bool, gracePeriod time.Duration) (time.Time, error) {
+	if m.Life() != Alive || m.IsContainer() || m.IsManager() {
+		return time.Time{}, nil
+	}
+	if m.doc.ReplacedBy != "" {
+		// The machine's replacement was interrupted.
+		return st.clock().Now(), nil
+	}
+	if len(m.Principals()) == 0 {
+		return time.Time{}, nil
+	}
+	if containers, err := m.Containers(); err != nil {
+		return time.Time{}, errors.Trace(err)
+	} else if len(containers) > 0 {
+		return time.Time{}, nil
+	}
+	instStatus, err := m.InstanceStatus()
+	if errors.IsNotFound(err) {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	if instStatus.Status != status.Missing || instStatus.Since == nil {
+		return time.Time{}, nil
+	}
+	for _, unitName := range m.Principals() {
+		appName, err := names.UnitApplication(unitName)
+		if err != nil {
+			return time.Time{}, errors.Trace(err)
+		}
+		app, err := st.Application(appName)
+		if err != nil {
+			return time.Time{}, errors.Trace(err)
+		}
+		appConfig, err := app.ApplicationConfig()
+		if err != nil {
+			return time.Time{}, errors.Trace(err)
+		}
+		if !appConfig.GetBool(application.ReplaceLostMachinesKey, modelPolicy) {
+			return time.Time{}, nil
+		}
+	}
+	return instStatus.Since.Add(gracePeriod), nil
+}
+
+// Replace replaces a machine whose instance has been lost. A new
+// machine is added with the same series, jobs, constraints, availability
+// zone and machine pool; each principal unit on the lost machine is
+// removed, and a unit of the same application is added to the new
+// machine, taking over the lost unit's detachable storage. The lost
+// machine is then destroyed. Machines that host containers cannot be
+// replaced, as the containers' units would be lost with them.
+//
+// The replacement machine, and the units to move to it, are recorded on
+// the lost machine when the replacement is added, so calling Replace
+// again after an interruption resumes the replacement rather than adding
+// another machine.
+//
+// The replacement is recorded in the instance status history of both
+// machines.
+func (m *Machine) Replace() (_ *Machine, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot replace machine %s", m.Id())
+	if m.IsManager() {
+		return nil, errors.Trace(managerMachineError)
+	}
+	if m.IsContainer() {
+		return nil, errors.NotSupportedf("replacing a container")
+	}
+	if manual, err := m.IsManual(); err != nil {
+		return nil, errors.Trace(err)
+	} else if manual {
+		return nil, errors.NotSupportedf("replacing a manually provisioned machine")
+	}
+	if containers, err := m.Containers(); err != nil {
+		return nil, errors.Trace(err)
+	} else if len(containers) > 0 {
+		return nil, errors.NotSupportedf("replacing a machine that hosts containers")
+	}
+	replacement, err := m.addReplacement()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := m.redeployLostUnits(replacement); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := m.ForceDestroy(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err := replacement.SetInstanceStatus(status.StatusInfo{
+		Status:  status.Pending,
+		Message: fmt.Sprintf("replacing lost machine %s", m.Id()),
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := m.SetInstanceStatus(status.StatusInfo{
+		Status:  status.Missing,
+		Message: fmt.Sprintf("replaced by machine %s", replacement.Id()),
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return replacement, nil
+}
+
+// addReplacement adds the machine that replaces m, recording it and the
+// principal units to move to it on m in the same transaction. If m has
+// already been replaced, the existing replacement is returned.
+func (m *Machine) addReplacement() (*Machine, error) {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.ReplacedBy != "" {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if m.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		cons, err := m.Constraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		placement := m.Placement()
+		if zone, err := m.AvailabilityZone(); err == nil && zone != "" {
+			placement = "zone=" + zone
+		} else if err != nil && !errors.IsNotProvisioned(err) {
+			return nil, errors.Trace(err)
+		}
+		lostUnits, err := m.st.lostUnits(m.doc.Principals)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		mdoc, ops, err := m.st.addMachineOps(MachineTemplate{
+			Series:      m.Series(),
+			Jobs:        m.Jobs(),
+			Constraints: cons,
+			Placement:   placement,
+			Pool:        m.Pool(),
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: append(isAliveDoc, bson.DocElem{"replaced-by", bson.D{{"$exists", false}}}),
+			Update: bson.D{{"$set", bson.D{
+				{"replaced-by", mdoc.Id},
+				{"lost-units", lostUnits},
+			}}},
+		}), nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := m.Refresh(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m.st.Machine(m.doc.ReplacedBy)
+}
+
+// lostUnits returns the records of the named units, and their detachable
+// storage, for moving them to a replacement machine.
+func (st *State) lostUnits(unitNames []string) ([]lostUnitDoc, error) {
+	im, err := st.IAASModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var docs []lostUnitDoc
+	for _, unitName := range unitNames {
+		storageTags, err := im.detachableUnitStorage(names.NewUnitTag(unitName))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc := lostUnitDoc{Unit: unitName}
+		for _, tag := range storageTags {
+			doc.Storage = append(doc.Storage, tag.Id())
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// redeployLostUnits removes the units recorded as lost on the machine,
+// and adds a unit of the same application to the replacement for each.
+// Units that an interrupted replacement already added are not added
+// again.
+func (m *Machine) redeployLostUnits(replacement *Machine) error {
+	for _, lost := range m.doc.LostUnits {
+		if err := m.st.obliterateUnit(lost.Unit); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	redeployed := make(map[string]int)
+	for _, unitName := range replacement.Principals() {
+		appName, err := names.UnitApplication(unitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		redeployed[appName]++
+	}
+	for _, lost := range m.doc.LostUnits {
+		appName, err := names.UnitApplication(lost.Unit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if redeployed[appName] > 0 {
+			redeployed[appName]--
+			continue
+		}
+		if err := m.st.redeployLostUnit(appName, lost.Storage, replacement); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// redeployLostUnit adds a unit of the named application to the given
+// machine, attaching the given storage to it where the lost unit that
+// owned it has released it.
+func (st *State) redeployLostUnit(appName string, storage []string, m *Machine) error {
+	app, err := st.Application(appName)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	im, err := st.IAASModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Only storage that survived the removal of the lost unit, and
+	// has been released by it, can be attached to the new one.
+	var attachStorage []names.StorageTag
+	for _, id := range storage {
+		tag := names.NewStorageTag(id)
+		si, err := im.storageInstance(tag)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if _, owned := si.Owner(); owned || si.Life() != Alive {
+			continue
+		}
+		attachStorage = append(attachStorage, tag)
+	}
+	newUnit, err := app.AddUnit(AddUnitParams{AttachStorage: attachStorage})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(newUnit.AssignToMachine(m))
+}
+
+// detachableUnitStorage returns the tags of the storage instances
+// attached to the unit that may be detached from its machine and
+// attached to another.
+func (im *IAASModel) detachableUnitStorage(unit names.UnitTag) ([]names.StorageTag, error) {
+	attachments, err := im.UnitStorageAttachments(unit)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var tags []names.StorageTag
+	for _, attachment := range attachments {
+		tag := attachment.StorageInstance()
+		si, err := im.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var detachable bool
+		switch si.Kind() {
+		case StorageKindBlock:
+			volume, err := im.storageInstanceVolume(tag)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			detachable = volume.Detachable()
+		case StorageKindFilesystem:
+			filesystem, err := im.storageInstanceFilesystem(tag)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			detachable = filesystem.Detachable()
+		}
+		if detachable {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
@@ -0,0 +1,352 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/constraints"
+	coreapplication "github.com/juju/juju/core/application"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type LostMachineSuite struct {
+	StorageStateSuiteBase
+	wordpress *state.Application
+}
+
+var _ = gc.Suite(&LostMachineSuite{})
+
+func (s *LostMachineSuite) SetUpTest(c *gc.C) {
+	s.StorageStateSuiteBase.SetUpTest(c)
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+// addLostMachine adds a provisioned machine in zone "az1" hosting a unit
+// of the given application, and marks its instance missing since the
+// given time.
+func (s *LostMachineSuite) addLostMachine(c *gc.C, app *state.Application, since time.Time) (*state.Machine, *state.Unit) {
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	zone := "az1"
+	err = m.SetProvisioned("inst-id", "fake_nonce", &instance.HardwareCharacteristics{
+		AvailabilityZone: &zone,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetInstanceStatus(status.StatusInfo{
+		Status:  status.Missing,
+		Message: "instance not found",
+		Since:   &since,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	return m, unit
+}
+
+func (s *LostMachineSuite) setModelPolicy(c *gc.C, replace bool) {
+	err := s.IAASModel.UpdateModelConfig(map[string]interface{}{
+		"replace-lost-machines":     replace,
+		"lost-machine-grace-period": "10m",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LostMachineSuite) TestReplace(c *gc.C) {
+	m, unit := s.addLostMachine(c, s.wordpress, s.Clock.Now())
+
+	replacement, err := m.Replace()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replacement.Id(), gc.Not(gc.Equals), m.Id())
+	c.Assert(replacement.Series(), gc.Equals, "quantal")
+	c.Assert(replacement.Jobs(), jc.DeepEquals, []state.MachineJob{state.JobHostUnits})
+	c.Assert(replacement.Placement(), gc.Equals, "zone=az1")
+	cons, err := replacement.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem=4G"))
+
+	err = unit.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	units, err := replacement.Units()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].Name(), gc.Equals, "wordpress/1")
+
+	instStatus, err := replacement.InstanceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instStatus.Message, gc.Equals, "replacing lost machine 0")
+	instStatus, err = m.InstanceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instStatus.Status, gc.Equals, status.Missing)
+	c.Assert(instStatus.Message, gc.Equals, "replaced by machine 1")
+
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Life(), gc.Equals, state.Dead)
+}
+
+func (s *LostMachineSuite) TestReplaceMovesDetachableStorage(c *gc.C) {
+	app, unit, storageTag := s.setupSingleStorageDetachable(c, "block", "persistent-block")
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Name(), gc.Equals, "storage-block")
+
+	replacement, err := m.Replace()
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := replacement.Units()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+
+	attachments, err := s.IAASModel.UnitStorageAttachments(units[0].UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Assert(attachments[0].StorageInstance(), gc.Equals, storageTag)
+	si, err := s.IAASModel.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := si.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, names.NewUnitTag(units[0].Name()))
+}
+
+func (s *LostMachineSuite) TestReplaceKeepsPool(c *gc.C) {
+	_, err := s.State.AddMachinePool("web", state.MachinePoolArgs{Applications: []string{"wordpress"}})
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Pool:   "web",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	replacement, err := m.Replace()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replacement.Pool(), gc.Equals, "web")
+}
+
+func (s *LostMachineSuite) TestReplaceNotSupported(c *gc.C) {
+	host, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = container.Replace()
+	c.Assert(err, gc.ErrorMatches, `cannot replace machine 0/lxd/0: replacing a container not supported`)
+
+	manual, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:     "quantal",
+		Jobs:       []state.MachineJob{state.JobHostUnits},
+		InstanceId: "manual:10.0.0.1",
+		Nonce:      "manual:fake_nonce",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = manual.Replace()
+	c.Assert(err, gc.ErrorMatches, `cannot replace machine 1: replacing a manually provisioned machine not supported`)
+
+	_, err = host.Replace()
+	c.Assert(err, gc.ErrorMatches, `cannot replace machine 0: replacing a machine that hosts containers not supported`)
+}
+
+func (s *LostMachineSuite) TestReplaceResumes(c *gc.C) {
+	m, unit := s.addLostMachine(c, s.wordpress, s.Clock.Now())
+
+	// Simulate a replacement interrupted after the new machine
+	// was added.
+	added, err := state.AddMachineReplacement(m)
+	c.Assert(err, jc.ErrorIsNil)
+	again, err := state.AddMachineReplacement(m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again.Id(), gc.Equals, added.Id())
+
+	replacement, err := m.Replace()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replacement.Id(), gc.Equals, added.Id())
+	err = unit.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	units, err := replacement.Units()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	all, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+
+	// Replacing the machine again does not add more units.
+	replacement, err = m.Replace()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replacement.Id(), gc.Equals, added.Id())
+	units, err = replacement.Units()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+}
+
+func (s *LostMachineSuite) TestReplaceLostMachinesModelPolicy(c *gc.C) {
+	m, _ := s.addLostMachine(c, s.wordpress, s.Clock.Now().Add(-time.Hour))
+
+	next, err := s.State.ReplaceLostMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.IsZero(), jc.IsTrue)
+	all, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+
+	s.setModelPolicy(c, true)
+	next, err = s.State.ReplaceLostMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.IsZero(), jc.IsTrue)
+	err = m.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Principals(), gc.HasLen, 0)
+	all, err = s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+	c.Assert(all[1].Principals(), jc.DeepEquals, []string{"wordpress/1"})
+
+	// The lost machine no longer hosts units, so it is not replaced
+	// again.
+	_, err = s.State.ReplaceLostMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	all, err = s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+}
+
+func (s *LostMachineSuite) TestReplaceLostMachinesGracePeriod(c *gc.C) {
+	s.setModelPolicy(c, true)
+	since := s.Clock.Now()
+	s.addLostMachine(c, s.wordpress, since)
+
+	next, err := s.State.ReplaceLostMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.Equal(since.Add(10*time.Minute)), jc.IsTrue)
+	all, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+
+	s.Clock.Advance(10 * time.Minute)
+	next, err = s.State.ReplaceLostMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.IsZero(), jc.IsTrue)
+	all, err = s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+}
+
+func (s *LostMachineSuite) TestReplaceLostMachinesResumes(c *gc.C) {
+	s.setModelPolicy(c, true)
+	m, _ := s.addLostMachine(c, s.wordpress, s.Clock.Now())
+	added, err := state.AddMachineReplacement(m)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The interrupted replacement is resumed without waiting for
+	// the grace period.
+	_, err = s.State.ReplaceLostMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added.Refresh(), jc.ErrorIsNil)
+	c.Assert(added.Principals(), jc.DeepEquals, []string{"wordpress/1"})
+	all, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+}
+
+func (s *LostMachineSuite) TestReplaceLostMachinesSkipsContainerHosts(c *gc.C) {
+	s.setModelPolicy(c, true)
+	m, unit := s.addLostMachine(c, s.wordpress, s.Clock.Now().Add(-time.Hour))
+	_, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, m.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	next, err := s.State.ReplaceLostMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.IsZero(), jc.IsTrue)
+	c.Assert(unit.Refresh(), jc.ErrorIsNil)
+	all, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+}
+
+type failOncePrechecker struct {
+	failed bool
+}
+
+func (p *failOncePrechecker) PrecheckInstance(environs.PrecheckInstanceParams) error {
+	if p.failed {
+		return nil
+	}
+	p.failed = true
+	return errors.New("no instance for you")
+}
+
+func (s *LostMachineSuite) TestReplaceLostMachinesContinuesPastErrors(c *gc.C) {
+	s.setModelPolicy(c, true)
+	s.addLostMachine(c, s.wordpress, s.Clock.Now().Add(-time.Hour))
+	s.addLostMachine(c, s.wordpress, s.Clock.Now().Add(-time.Hour))
+	var prechecker failOncePrechecker
+	s.policy.GetPrechecker = func() (environs.InstancePrechecker, error) {
+		return &prechecker, nil
+	}
+
+	_, err := s.State.ReplaceLostMachines()
+	c.Assert(err, gc.ErrorMatches, `cannot replace machine 0: no instance for you`)
+	all, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 3)
+	c.Assert(all[2].Principals(), jc.DeepEquals, []string{"wordpress/2"})
+}
+
+func (s *LostMachineSuite) TestReplaceLostMachinesApplicationPolicy(c *gc.C) {
+	s.setModelPolicy(c, true)
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err := mysql.UpdateApplicationConfig(map[string]interface{}{
+		"replace-lost-machines": false,
+	}, nil, coreapplication.IAASConfigSchema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addLostMachine(c, mysql, s.Clock.Now().Add(-time.Hour))
+
+	_, err = s.State.ReplaceLostMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	all, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+
+	// An application may opt in when the model does not.
+	s.setModelPolicy(c, false)
+	err = mysql.UpdateApplicationConfig(map[string]interface{}{
+		"replace-lost-machines": true,
+	}, nil, coreapplication.IAASConfigSchema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ReplaceLostMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	all, err = s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+}
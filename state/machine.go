@@ -140,6 +140,22 @@ type machineDoc struct {
 	// StopMongoUntilVersion holds the version that must be checked to
 	// know if mongo must be stopped.
 	StopMongoUntilVersion string `bson:",omitempty"`
+
+	// CharmProfiles holds the names of the charm LXD profiles that
+	// have been applied to the machine's instance.
+	CharmProfiles []string `bson:"charm-profiles,omitempty"`
+
+	// Pool holds the name of the machine pool the machine belongs
+	// to, if any.
+	Pool string `bson:"pool,omitempty"`
+
+	// ReplacedBy holds the id of the machine added to replace this
+	// one after its instance was lost, if any.
+	ReplacedBy string `bson:"replaced-by,omitempty"`
+
+	// LostUnits records the principal units to move to the machine
+	// that replaces this one.
+	LostUnits []lostUnitDoc `bson:"lost-units,omitempty"`
 }
 
 func newMachine(st *State, doc *machineDoc) *Machine {
@@ -1615,6 +1631,12 @@ func (m *Machine) Placement() string {
 	return m.doc.Placement
 }
 
+// Pool returns the name of the machine pool the machine belongs to, or
+// the empty string if it belongs to none.
+func (m *Machine) Pool() string {
+	return m.doc.Pool
+}
+
 // Constraints returns the exact constraints that should apply when provisioning
 // an instance for the machine.
 func (m *Machine) Constraints() (constraints.Value, error) {
@@ -1728,6 +1750,28 @@ func (m *Machine) SupportedContainers() ([]instance.ContainerType, bool) {
 	return m.doc.SupportedContainers, m.doc.SupportedContainersKnown
 }
 
+// CharmProfiles returns the names of the charm LXD profiles that have
+// been applied to the machine's instance.
+func (m *Machine) CharmProfiles() []string {
+	return m.doc.CharmProfiles
+}
+
+// SetCharmProfiles records the names of the charm LXD profiles that
+// have been applied to the machine's instance.
+func (m *Machine) SetCharmProfiles(profiles []string) error {
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"charm-profiles", profiles}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, ErrDead), "cannot set charm profiles of machine %v", m)
+	}
+	m.doc.CharmProfiles = profiles
+	return nil
+}
+
 // SupportsNoContainers records the fact that this machine doesn't support any containers.
 func (m *Machine) SupportsNoContainers() (err error) {
 	if err = m.updateSupportedContainers([]instance.ContainerType{}); err != nil {
@@ -1840,13 +1884,31 @@ func (m *Machine) VolumeAttachments() ([]VolumeAttachment, error) {
 
 // AddAction is part of the ActionReceiver interface.
 func (m *Machine) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return m.AddOperationAction("", name, payload)
+}
+
+// AddOperationAction is part of the ActionReceiver interface.
+func (m *Machine) AddOperationAction(operation, name string, payload map[string]interface{}) (Action, error) {
+	model, err := m.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Machines run the predefined actions, and any actions that
+	// operators have defined for the model's machines.
 	spec, ok := actions.PredefinedActionsSpec[name]
 	if !ok {
-		return nil, errors.Errorf("cannot add action %q to a machine; only predefined actions allowed", name)
+		script, err := model.MachineActionScript(name)
+		if errors.IsNotFound(err) {
+			return nil, errors.Errorf("cannot add action %q to a machine; only predefined and machine actions allowed", name)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		spec = script.Spec()
 	}
 
 	// Reject bad payloads before attempting to insert defaults.
-	err := spec.ValidateParams(payload)
+	err = spec.ValidateParams(payload)
 	if err != nil {
 		return nil, err
 	}
@@ -1855,12 +1917,7 @@ func (m *Machine) AddAction(name string, payload map[string]interface{}) (Action
 		return nil, err
 	}
 
-	model, err := m.st.Model()
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	return model.EnqueueAction(m.Tag(), name, payloadWithDefaults)
+	return model.EnqueueOperationAction(operation, m.Tag(), name, payloadWithDefaults)
 }
 
 // CancelAction is part of the ActionReceiver interface.
@@ -2239,6 +2239,31 @@ func (s *MachineSuite) TestSupportedContainersInitiallyUnknown(c *gc.C) {
 	assertSupportedContainersUnknown(c, machine)
 }
 
+func (s *MachineSuite) TestSetCharmProfiles(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.CharmProfiles(), gc.HasLen, 0)
+
+	profiles := []string{"juju-testenv-lxd-profile-0"}
+	err = machine.SetCharmProfiles(profiles)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.CharmProfiles(), jc.DeepEquals, profiles)
+
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.CharmProfiles(), jc.DeepEquals, profiles)
+}
+
+func (s *MachineSuite) TestSetCharmProfilesDeadMachine(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetCharmProfiles([]string{"juju-testenv-lxd-profile-0"})
+	c.Assert(err, gc.ErrorMatches, `cannot set charm profiles of machine 0: not found or dead`)
+}
+
 func (s *MachineSuite) TestSupportsNoContainers(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -2398,7 +2423,7 @@ func (s *MachineSuite) TestMachineValidActions(c *gc.C) {
 		},
 		{
 			actionName: "baiku",
-			errString:  `cannot add action "baiku" to a machine; only predefined actions allowed`,
+			errString:  `cannot add action "baiku" to a machine; only predefined and machine actions allowed`,
 		},
 	}
 
@@ -2420,7 +2445,7 @@ func (s *MachineSuite) TestMachineAddDifferentAction(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	_, err = m.AddAction("benchmark", nil)
-	c.Assert(err, gc.ErrorMatches, `cannot add action "benchmark" to a machine; only predefined actions allowed`)
+	c.Assert(err, gc.ErrorMatches, `cannot add action "benchmark" to a machine; only predefined and machine actions allowed`)
 }
 
 func (s *MachineSuite) setupTestUpdateMachineSeries(c *gc.C) *state.Machine {
@@ -0,0 +1,180 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+	"gopkg.in/yaml.v2"
+)
+
+// MachineActionScriptArgs holds the parameters for defining an action
+// that can be run on any machine in a model.
+type MachineActionScriptArgs struct {
+	// Name is the name of the action.
+	Name string
+
+	// Description describes what the action does.
+	Description string
+
+	// Script is the script run on the machine. It must start with
+	// an interpreter line, such as "#!/bin/bash".
+	Script string
+
+	// Params holds the schema for each of the action's parameters,
+	// as found under "params" in a charm's actions.yaml.
+	Params map[string]interface{}
+
+	// Required holds the names of the parameters that must be
+	// given.
+	Required []string
+}
+
+// spec validates the args in the same way as a charm's actions.yaml,
+// returning the resulting action spec. Like charm actions, machine
+// actions may not use the "juju-" prefix reserved for predefined
+// actions.
+func (a MachineActionScriptArgs) spec() (charm.ActionSpec, error) {
+	if !strings.HasPrefix(a.Script, "#!") {
+		return charm.ActionSpec{}, errors.NotValidf("script without interpreter line")
+	}
+	action := map[string]interface{}{}
+	if a.Description != "" {
+		action["description"] = a.Description
+	}
+	if len(a.Params) > 0 {
+		action["params"] = a.Params
+	}
+	if len(a.Required) > 0 {
+		action["required"] = a.Required
+	}
+	data, err := yaml.Marshal(map[string]interface{}{a.Name: action})
+	if err != nil {
+		return charm.ActionSpec{}, errors.Trace(err)
+	}
+	parsed, err := charm.ReadActionsYaml(bytes.NewReader(data))
+	if err != nil {
+		return charm.ActionSpec{}, errors.Annotatef(err, "invalid machine action %q", a.Name)
+	}
+	return parsed.ActionSpecs[a.Name], nil
+}
+
+// machineActionScriptDoc records an operator-defined machine action.
+type machineActionScriptDoc struct {
+	DocId       string                 `bson:"_id"`
+	ModelUUID   string                 `bson:"model-uuid"`
+	Name        string                 `bson:"name"`
+	Description string                 `bson:"description"`
+	Script      string                 `bson:"script"`
+	Params      map[string]interface{} `bson:"params"`
+}
+
+// MachineActionScript is an operator-defined action that runs a script
+// on any machine in a model.
+type MachineActionScript struct {
+	doc machineActionScriptDoc
+}
+
+// Name returns the name of the action.
+func (s *MachineActionScript) Name() string {
+	return s.doc.Name
+}
+
+// Script returns the script run by the action.
+func (s *MachineActionScript) Script() string {
+	return s.doc.Script
+}
+
+// Spec returns the action's spec, against which the parameters of
+// enqueued actions are validated.
+func (s *MachineActionScript) Spec() charm.ActionSpec {
+	return charm.ActionSpec{
+		Description: s.doc.Description,
+		Params:      s.doc.Params,
+	}
+}
+
+// AddMachineActionScript defines an action that can be run on any
+// machine in the model.
+func (m *Model) AddMachineActionScript(args MachineActionScriptArgs) (*MachineActionScript, error) {
+	spec, err := args.spec()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := machineActionScriptDoc{
+		DocId:       m.st.docID(args.Name),
+		ModelUUID:   m.UUID(),
+		Name:        args.Name,
+		Description: spec.Description,
+		Script:      args.Script,
+		Params:      spec.Params,
+	}
+	err = m.st.db().RunTransaction([]txn.Op{{
+		C:      machineActionScriptsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}})
+	if err == txn.ErrAborted {
+		return nil, errors.AlreadyExistsf("machine action %q", args.Name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot add machine action %q", args.Name)
+	}
+	return &MachineActionScript{doc: doc}, nil
+}
+
+// RemoveMachineActionScript removes the named machine action. Actions
+// that have already been enqueued are not affected until they run,
+// when they will fail.
+func (m *Model) RemoveMachineActionScript(name string) error {
+	err := m.st.db().RunTransaction([]txn.Op{{
+		C:      machineActionScriptsC,
+		Id:     m.st.docID(name),
+		Assert: txn.DocExists,
+		Remove: true,
+	}})
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("machine action %q", name)
+	}
+	return errors.Annotatef(err, "cannot remove machine action %q", name)
+}
+
+// MachineActionScript returns the named machine action.
+func (m *Model) MachineActionScript(name string) (*MachineActionScript, error) {
+	scripts, closer := m.st.db().GetCollection(machineActionScriptsC)
+	defer closer()
+
+	var doc machineActionScriptDoc
+	err := scripts.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("machine action %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get machine action %q", name)
+	}
+	return &MachineActionScript{doc: doc}, nil
+}
+
+// MachineActionScripts returns all of the model's machine actions,
+// sorted by name.
+func (m *Model) MachineActionScripts() ([]*MachineActionScript, error) {
+	scripts, closer := m.st.db().GetCollection(machineActionScriptsC)
+	defer closer()
+
+	var docs []machineActionScriptDoc
+	if err := scripts.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get machine actions")
+	}
+	result := make([]*MachineActionScript, len(docs))
+	for i, doc := range docs {
+		result[i] = &MachineActionScript{doc: doc}
+	}
+	return result, nil
+}
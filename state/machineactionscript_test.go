@@ -0,0 +1,126 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type MachineActionScriptSuite struct {
+	ConnSuite
+	model *state.Model
+}
+
+var _ = gc.Suite(&MachineActionScriptSuite{})
+
+func (s *MachineActionScriptSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+var sosreportArgs = state.MachineActionScriptArgs{
+	Name:        "sosreport",
+	Description: "Collect a sosreport.",
+	Script:      "#!/bin/bash\nsosreport --batch --tmp-dir \"$1\"\n",
+	Params: map[string]interface{}{
+		"tmp-dir": map[string]interface{}{
+			"type":    "string",
+			"default": "/tmp",
+		},
+		"case-id": map[string]interface{}{
+			"type": "string",
+		},
+	},
+	Required: []string{"case-id"},
+}
+
+func (s *MachineActionScriptSuite) TestAddMachineActionScript(c *gc.C) {
+	script, err := s.model.AddMachineActionScript(sosreportArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(script.Name(), gc.Equals, "sosreport")
+	c.Assert(script.Script(), gc.Equals, sosreportArgs.Script)
+	c.Assert(script.Spec().Description, gc.Equals, "Collect a sosreport.")
+	c.Assert(script.Spec().Params["required"], jc.DeepEquals, []interface{}{"case-id"})
+
+	same, err := s.model.MachineActionScript("sosreport")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(same.Spec(), jc.DeepEquals, script.Spec())
+
+	_, err = s.model.AddMachineActionScript(sosreportArgs)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *MachineActionScriptSuite) TestAddMachineActionScriptValidates(c *gc.C) {
+	for i, test := range []struct {
+		args   state.MachineActionScriptArgs
+		expect string
+	}{{
+		args:   state.MachineActionScriptArgs{Name: "rotate-logs", Script: "logrotate -f"},
+		expect: "script without interpreter line not valid",
+	}, {
+		args:   state.MachineActionScriptArgs{Name: "Rotate", Script: "#!/bin/sh"},
+		expect: `invalid machine action "Rotate": .*`,
+	}, {
+		args:   state.MachineActionScriptArgs{Name: "juju-rotate", Script: "#!/bin/sh"},
+		expect: `invalid machine action "juju-rotate": .*`,
+	}, {
+		args: state.MachineActionScriptArgs{
+			Name:   "rotate-logs",
+			Script: "#!/bin/sh",
+			Params: map[string]interface{}{"force": "yes"},
+		},
+		expect: `invalid machine action "rotate-logs": .*`,
+	}} {
+		c.Logf("test %d: %s", i, test.args.Name)
+		_, err := s.model.AddMachineActionScript(test.args)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *MachineActionScriptSuite) TestMachineActionScripts(c *gc.C) {
+	_, err := s.model.AddMachineActionScript(sosreportArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.model.AddMachineActionScript(state.MachineActionScriptArgs{
+		Name:   "apt-upgrade",
+		Script: "#!/bin/sh\napt-get -y upgrade\n",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	scripts, err := s.model.MachineActionScripts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scripts, gc.HasLen, 2)
+	c.Assert(scripts[0].Name(), gc.Equals, "apt-upgrade")
+	c.Assert(scripts[1].Name(), gc.Equals, "sosreport")
+
+	err = s.model.RemoveMachineActionScript("apt-upgrade")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.model.MachineActionScript("apt-upgrade")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.model.RemoveMachineActionScript("apt-upgrade")
+	c.Assert(err, gc.ErrorMatches, `machine action "apt-upgrade" not found`)
+}
+
+func (s *MachineActionScriptSuite) TestMachineAddAction(c *gc.C) {
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.model.AddMachineActionScript(sosreportArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = m.AddAction("sosreport", nil)
+	c.Assert(err, gc.ErrorMatches, `validation failed: .*case-id.*`)
+
+	action, err := m.AddAction("sosreport", map[string]interface{}{"case-id": "1234"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Receiver(), gc.Equals, m.Id())
+	c.Assert(action.Parameters(), jc.DeepEquals, map[string]interface{}{
+		"case-id": "1234",
+		"tmp-dir": "/tmp",
+	})
+}
@@ -0,0 +1,275 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+var validMachinePoolName = regexp.MustCompile("^[a-z][a-z0-9-]*$")
+
+// IsValidMachinePoolName reports whether name is a valid machine pool
+// name.
+func IsValidMachinePoolName(name string) bool {
+	return validMachinePoolName.MatchString(name)
+}
+
+// MachinePoolArgs holds the parameters for a machine pool.
+type MachinePoolArgs struct {
+	// Labels holds arbitrary key/value pairs describing the pool's
+	// machines, such as "gpu=nvidia".
+	Labels map[string]string
+
+	// Applications holds the names of the applications whose units
+	// may be placed on the pool's machines. Units of any other
+	// application are kept off them, whether they are placed
+	// explicitly or not. If empty, no units may be placed on the
+	// pool's machines.
+	Applications []string
+}
+
+// Validate returns an error if the args are not valid.
+func (a MachinePoolArgs) Validate() error {
+	for key := range a.Labels {
+		if key == "" {
+			return errors.NotValidf("empty label key")
+		}
+	}
+	for _, name := range a.Applications {
+		if !names.IsValidApplication(name) {
+			return errors.NotValidf("application name %q", name)
+		}
+	}
+	return nil
+}
+
+// machinePoolDoc records a named group of machines reserved for the
+// units of particular applications.
+type machinePoolDoc struct {
+	DocId        string            `bson:"_id"`
+	ModelUUID    string            `bson:"model-uuid"`
+	Name         string            `bson:"name"`
+	Labels       map[string]string `bson:"labels"`
+	Applications []string          `bson:"applications"`
+	TxnRevno     int64             `bson:"txn-revno"`
+}
+
+// MachinePool is a named group of machines whose units are restricted
+// to those of particular applications. Machines join a pool when they
+// are added; containers belong to the pool of their host.
+type MachinePool struct {
+	st  *State
+	doc machinePoolDoc
+}
+
+// Name returns the name of the pool.
+func (p *MachinePool) Name() string {
+	return p.doc.Name
+}
+
+// Labels returns the labels describing the pool's machines.
+func (p *MachinePool) Labels() map[string]string {
+	return p.doc.Labels
+}
+
+// Applications returns the names of the applications whose units may be
+// placed on the pool's machines, sorted by name.
+func (p *MachinePool) Applications() []string {
+	return p.doc.Applications
+}
+
+// Admits reports whether units of the named application may be placed
+// on the pool's machines.
+func (p *MachinePool) Admits(application string) bool {
+	for _, name := range p.doc.Applications {
+		if name == application {
+			return true
+		}
+	}
+	return false
+}
+
+// MachineIds returns the ids of the pool's machines, including
+// containers.
+func (p *MachinePool) MachineIds() ([]string, error) {
+	machines, closer := p.st.db().GetCollection(machinesC)
+	defer closer()
+
+	var docs []struct {
+		Id string `bson:"machineid"`
+	}
+	err := machines.Find(bson.D{{"pool", p.doc.Name}}).Select(bson.D{{"machineid", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get machines in pool %q", p.doc.Name)
+	}
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.Id
+	}
+	return utils.SortStringsNaturally(ids), nil
+}
+
+// Refresh reloads the pool from state.
+func (p *MachinePool) Refresh() error {
+	pool, err := p.st.MachinePool(p.doc.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	p.doc = pool.doc
+	return nil
+}
+
+// assertUnchangedOp returns a txn.Op that asserts that the pool has not
+// changed since it was read.
+func (p *MachinePool) assertUnchangedOp() txn.Op {
+	return txn.Op{
+		C:      machinePoolsC,
+		Id:     p.doc.DocId,
+		Assert: bson.D{{"txn-revno", p.doc.TxnRevno}},
+	}
+}
+
+func sortedStrings(in []string) []string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := append([]string(nil), in...)
+	sort.Strings(out)
+	return out
+}
+
+// AddMachinePool adds a machine pool to the model.
+func (st *State) AddMachinePool(name string, args MachinePoolArgs) (*MachinePool, error) {
+	if !IsValidMachinePoolName(name) {
+		return nil, errors.NotValidf("machine pool name %q", name)
+	}
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := machinePoolDoc{
+		DocId:        st.docID(name),
+		ModelUUID:    st.ModelUUID(),
+		Name:         name,
+		Labels:       args.Labels,
+		Applications: sortedStrings(args.Applications),
+	}
+	err := st.db().RunTransaction([]txn.Op{
+		assertModelActiveOp(st.ModelUUID()),
+		{
+			C:      machinePoolsC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		},
+	})
+	if err == txn.ErrAborted {
+		if err := checkModelActive(st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, errors.AlreadyExistsf("machine pool %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot add machine pool %q", name)
+	}
+	return st.MachinePool(name)
+}
+
+// SetMachinePool replaces the labels and applications of the named
+// machine pool. Units already placed on the pool's machines are not
+// moved.
+func (st *State) SetMachinePool(name string, args MachinePoolArgs) error {
+	if err := args.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	err := st.db().RunTransaction([]txn.Op{{
+		C:      machinePoolsC,
+		Id:     st.docID(name),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{
+			{"labels", args.Labels},
+			{"applications", sortedStrings(args.Applications)},
+		}}},
+	}})
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("machine pool %q", name)
+	}
+	return errors.Annotatef(err, "cannot set machine pool %q", name)
+}
+
+// MachinePool returns the named machine pool.
+func (st *State) MachinePool(name string) (*MachinePool, error) {
+	pools, closer := st.db().GetCollection(machinePoolsC)
+	defer closer()
+
+	var doc machinePoolDoc
+	err := pools.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("machine pool %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get machine pool %q", name)
+	}
+	return &MachinePool{st: st, doc: doc}, nil
+}
+
+// AllMachinePools returns all of the model's machine pools, sorted by
+// name.
+func (st *State) AllMachinePools() ([]*MachinePool, error) {
+	pools, closer := st.db().GetCollection(machinePoolsC)
+	defer closer()
+
+	var docs []machinePoolDoc
+	if err := pools.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get machine pools")
+	}
+	result := make([]*MachinePool, len(docs))
+	for i, doc := range docs {
+		result[i] = &MachinePool{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// machinePoolsExcluding returns the names of the machine pools that do
+// not admit units of the named application.
+func (st *State) machinePoolsExcluding(application string) ([]string, error) {
+	pools, err := st.AllMachinePools()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var excluded []string
+	for _, pool := range pools {
+		if !pool.Admits(application) {
+			excluded = append(excluded, pool.Name())
+		}
+	}
+	return excluded, nil
+}
+
+// machinePoolAdmissionOps returns txn.Ops that assert that the machine's
+// pool, if any, still admits units of the named application. An error
+// is returned if it does not.
+func machinePoolAdmissionOps(m *Machine, application string) ([]txn.Op, error) {
+	if m.doc.Pool == "" {
+		return nil, nil
+	}
+	pool, err := m.st.MachinePool(m.doc.Pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !pool.Admits(application) {
+		return nil, errors.Errorf(
+			"machine pool %q does not admit application %q",
+			pool.Name(), application,
+		)
+	}
+	return []txn.Op{pool.assertUnchangedOp()}, nil
+}
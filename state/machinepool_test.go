@@ -0,0 +1,178 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+type MachinePoolSuite struct {
+	ConnSuite
+	wordpress *state.Application
+}
+
+var _ = gc.Suite(&MachinePoolSuite{})
+
+func (s *MachinePoolSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *MachinePoolSuite) addPool(c *gc.C, name string, applications ...string) *state.MachinePool {
+	pool, err := s.State.AddMachinePool(name, state.MachinePoolArgs{
+		Labels:       map[string]string{"gpu": "nvidia"},
+		Applications: applications,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return pool
+}
+
+func (s *MachinePoolSuite) addMachine(c *gc.C, pool string) *state.Machine {
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Pool:   pool,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return m
+}
+
+func (s *MachinePoolSuite) TestAddMachinePool(c *gc.C) {
+	pool := s.addPool(c, "gpu", "trainer", "cuda")
+	c.Assert(pool.Name(), gc.Equals, "gpu")
+	c.Assert(pool.Labels(), jc.DeepEquals, map[string]string{"gpu": "nvidia"})
+	c.Assert(pool.Applications(), jc.DeepEquals, []string{"cuda", "trainer"})
+	c.Assert(pool.Admits("cuda"), jc.IsTrue)
+	c.Assert(pool.Admits("wordpress"), jc.IsFalse)
+
+	same, err := s.State.MachinePool("gpu")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(same.Applications(), jc.DeepEquals, pool.Applications())
+
+	_, err = s.State.AddMachinePool("gpu", state.MachinePoolArgs{})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *MachinePoolSuite) TestAddMachinePoolValidates(c *gc.C) {
+	_, err := s.State.AddMachinePool("GPU", state.MachinePoolArgs{})
+	c.Assert(err, gc.ErrorMatches, `machine pool name "GPU" not valid`)
+	_, err = s.State.AddMachinePool("gpu", state.MachinePoolArgs{Applications: []string{"no_good"}})
+	c.Assert(err, gc.ErrorMatches, `application name "no_good" not valid`)
+	_, err = s.State.AddMachinePool("gpu", state.MachinePoolArgs{Labels: map[string]string{"": "x"}})
+	c.Assert(err, gc.ErrorMatches, `empty label key not valid`)
+}
+
+func (s *MachinePoolSuite) TestSetMachinePool(c *gc.C) {
+	pool := s.addPool(c, "gpu", "cuda")
+	err := s.State.SetMachinePool("gpu", state.MachinePoolArgs{Applications: []string{"wordpress"}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = pool.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool.Labels(), gc.HasLen, 0)
+	c.Assert(pool.Applications(), jc.DeepEquals, []string{"wordpress"})
+
+	err = s.State.SetMachinePool("fast", state.MachinePoolArgs{})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MachinePoolSuite) TestAllMachinePools(c *gc.C) {
+	s.addPool(c, "storage")
+	s.addPool(c, "gpu")
+	pools, err := s.State.AllMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pools, gc.HasLen, 2)
+	c.Assert(pools[0].Name(), gc.Equals, "gpu")
+	c.Assert(pools[1].Name(), gc.Equals, "storage")
+}
+
+func (s *MachinePoolSuite) TestAddMachineToPool(c *gc.C) {
+	s.addPool(c, "gpu")
+	m := s.addMachine(c, "gpu")
+	c.Assert(m.Pool(), gc.Equals, "gpu")
+
+	_, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Pool:   "fast",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MachinePoolSuite) TestContainerJoinsHostPool(c *gc.C) {
+	s.addPool(c, "gpu")
+	s.addPool(c, "storage")
+	host := s.addMachine(c, "gpu")
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	container, err := s.State.AddMachineInsideMachine(template, host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(container.Pool(), gc.Equals, "gpu")
+
+	template.Pool = "storage"
+	_, err = s.State.AddMachineInsideMachine(template, host.Id(), instance.LXD)
+	c.Assert(err, gc.ErrorMatches, `machine 0 is not in machine pool "storage"`)
+
+	container, err = s.State.AddMachineInsideNewMachine(template, template, instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(container.Pool(), gc.Equals, "storage")
+
+	pool, err := s.State.MachinePool("gpu")
+	c.Assert(err, jc.ErrorIsNil)
+	ids, err := pool.MachineIds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []string{"0", "0/lxd/0"})
+}
+
+func (s *MachinePoolSuite) TestAssignToCleanMachineSkipsPool(c *gc.C) {
+	s.addPool(c, "gpu", "cuda")
+	m := s.addMachine(c, "gpu")
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = unit.AssignToCleanMachine()
+	c.Assert(err, gc.ErrorMatches, `all eligible machines in use`)
+
+	err = s.State.SetMachinePool("gpu", state.MachinePoolArgs{Applications: []string{"cuda", "wordpress"}})
+	c.Assert(err, jc.ErrorIsNil)
+	assigned, err := unit.AssignToCleanMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(assigned.Id(), gc.Equals, m.Id())
+}
+
+func (s *MachinePoolSuite) TestAssignToCleanMachineOutsidePool(c *gc.C) {
+	s.addPool(c, "gpu", "cuda")
+	s.addMachine(c, "gpu")
+	m := s.addMachine(c, "")
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	assigned, err := unit.AssignToCleanMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(assigned.Id(), gc.Equals, m.Id())
+}
+
+func (s *MachinePoolSuite) TestAssignToMachineInPool(c *gc.C) {
+	s.addPool(c, "gpu", "cuda")
+	m := s.addMachine(c, "gpu")
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit.AssignToMachine(m)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "wordpress/0" to machine 0: machine pool "gpu" does not admit application "wordpress"`)
+
+	err = s.State.AssignUnitWithPlacement(unit, &instance.Placement{
+		Scope: string(instance.LXD), Directive: m.Id(),
+	})
+	c.Assert(err, gc.ErrorMatches, `machine pool "gpu" does not admit application "wordpress"`)
+	containers, err := m.Containers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(containers, gc.HasLen, 0)
+}
@@ -1552,3 +1552,61 @@ func (s *MigrationExportSuite) TestRelationWithNoStatus(c *gc.C) {
 	c.Assert(rels, gc.HasLen, 1)
 	c.Assert(rels[0].Status(), gc.IsNil)
 }
+
+func (s *MigrationExportSuite) checkUnmigratableFeatures(c *gc.C, expected ...string) {
+	features, err := s.State.UnmigratableFeatures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, jc.SameContents, expected)
+}
+
+func (s *MigrationExportSuite) TestUnmigratableZonesConstraints(c *gc.C) {
+	s.checkUnmigratableFeatures(c)
+
+	err := s.State.SetModelConstraints(constraints.MustParse("zones=az1,az2"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkUnmigratableFeatures(c, "zones constraints")
+}
+
+func (s *MigrationExportSuite) TestUnmigratableMachinePools(c *gc.C) {
+	s.checkUnmigratableFeatures(c)
+
+	_, err := s.State.AddMachinePool("gpu", state.MachinePoolArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkUnmigratableFeatures(c, "machine pools")
+}
+
+func (s *MigrationExportSuite) TestUnmigratableAutoscalingPolicies(c *gc.C) {
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "metered", URL: "cs:quantal/metered-1"})
+	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: ch})
+	s.checkUnmigratableFeatures(c)
+
+	err := application.SetAutoscalingPolicy(state.AutoscalingPolicy{
+		MetricKey: "pings",
+		Target:    80,
+		MinUnits:  1,
+		MaxUnits:  3,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkUnmigratableFeatures(c, "autoscaling policies")
+}
+
+func (s *MigrationExportSuite) TestUnmigratableMachineActionScripts(c *gc.C) {
+	s.checkUnmigratableFeatures(c)
+
+	_, err := s.Model.AddMachineActionScript(sosreportArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkUnmigratableFeatures(c, "machine action scripts")
+}
+
+func (s *MigrationExportSuite) TestUnmigratableActionSchedules(c *gc.C) {
+	s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.checkUnmigratableFeatures(c)
+
+	_, err := s.Model.AddActionSchedule(state.ActionScheduleArgs{
+		Schedule:     "0 2 * * *",
+		Applications: []string{"dummy"},
+		Name:         "snapshot",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkUnmigratableFeatures(c, "action schedules")
+}
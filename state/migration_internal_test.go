@@ -119,6 +119,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// temporary credentials in there; after migration you'll just have
 		// to log back in.
 		bakeryStorageItemsC,
+		// API tokens are issued by, and only valid for, the
+		// controller that created them.
+		apiTokensC,
 		// Transaction stuff.
 		"txns",
 		"txns.log",
@@ -157,6 +160,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Recreated whilst migrating actions.
 		actionNotificationsC,
 
+		// Action rollouts are driven by a worker on the source
+		// controller, and are not carried across a migration.
+		actionRolloutsC,
+
 		// Global settings store controller specific configuration settings
 		// and are not to be migrated.
 		globalSettingsC,
@@ -198,6 +205,22 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// TODO(caas)
 		containerSpecsC,
+
+		// Machine action scripts need support in the description
+		// package before they can be migrated. Until then, models
+		// using them or any of the features below fail the migration
+		// prechecks; see UnmigratableFeatures.
+		machineActionScriptsC,
+
+		// As do action schedules.
+		actionSchedulesC,
+
+		// And machine pools.
+		machinePoolsC,
+
+		// And autoscaling policies, and the decisions made by them.
+		autoscalingPoliciesC,
+		autoscalingDecisionsC,
 	)
 
 	envCollections := set.NewStrings()
@@ -301,6 +324,17 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
+		// Charm profiles are reapplied by the provisioner in the
+		// target controller, as required.
+		"CharmProfiles",
+		// Machine pools need support in the description package
+		// before they can be migrated, so models using them fail
+		// the migration prechecks.
+		"Pool",
+		// A machine is only replaced once its instance is lost, and
+		// models with lost machines fail the migration prechecks.
+		"ReplacedBy",
+		"LostUnits",
 	)
 	migrated := set.NewStrings(
 		"Addresses",
@@ -545,6 +579,9 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Tags",
 		"Spaces",
 		"VirtType",
+		// Zones is not yet supported by the description package,
+		// so models using it fail the migration prechecks.
+		"Zones",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
@@ -680,6 +717,16 @@ func (s *MigrationSuite) TestSSHHostKeyDocFields(c *gc.C) {
 func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
+		// Progress messages are not supported by the description
+		// package; the final message and results are migrated.
+		"Logs",
+		"LogsCount",
+		// Rollouts are not supported by the description package, so
+		// the link from an action to its rollout is not migrated.
+		"Rollout",
+		// Operations are not supported by the description package,
+		// so actions are migrated without their grouping.
+		"Operation",
 	)
 	migrated := set.NewStrings(
 		"DocId",
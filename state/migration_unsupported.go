@@ -0,0 +1,61 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// unmigratableFeature describes a model feature whose data the model
+// description cannot yet carry. The feature is in use if any document
+// in the collection matches the query.
+type unmigratableFeature struct {
+	name       string
+	collection string
+	query      bson.D
+}
+
+var unmigratableFeatures = []unmigratableFeature{{
+	name:       "zones constraints",
+	collection: constraintsC,
+	query:      bson.D{{"zones", bson.D{{"$ne", nil}}}},
+}, {
+	// A machine can only be in a pool that exists, so this also
+	// covers the machines' pool memberships.
+	name:       "machine pools",
+	collection: machinePoolsC,
+}, {
+	name:       "autoscaling policies",
+	collection: autoscalingPoliciesC,
+}, {
+	// Decisions outlive the policy that made them.
+	name:       "autoscaling decisions",
+	collection: autoscalingDecisionsC,
+}, {
+	name:       "machine action scripts",
+	collection: machineActionScriptsC,
+}, {
+	name:       "action schedules",
+	collection: actionSchedulesC,
+}}
+
+// UnmigratableFeatures returns the names of the features in use in the
+// model that cannot be migrated, because exporting the model would
+// drop their data.
+func (st *State) UnmigratableFeatures() ([]string, error) {
+	var names []string
+	for _, feature := range unmigratableFeatures {
+		coll, closer := st.db().GetCollection(feature.collection)
+		n, err := coll.Find(feature.query).Count()
+		closer()
+		if err != nil {
+			return nil, errors.Annotatef(err, "checking for %s", feature.name)
+		}
+		if n > 0 {
+			names = append(names, feature.name)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// The mgo/txn states of a transaction that has not yet been applied
+// or aborted. See the tstate constants in gopkg.in/mgo.v2/txn.
+const (
+	txnStatePreparing = 1
+	txnStatePrepared  = 2
+	txnStateAborting  = 3
+	txnStateApplying  = 5
+)
+
+// UnitCount returns the number of units in the model.
+func (st *State) UnitCount() (int, error) {
+	units, closer := st.db().GetCollection(unitsC)
+	defer closer()
+	count, err := units.Count()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot count units")
+	}
+	return count, nil
+}
+
+// PendingActionCount returns the number of actions in the model that
+// have been enqueued but not yet started.
+func (st *State) PendingActionCount() (int, error) {
+	actions, closer := st.db().GetCollection(actionsC)
+	defer closer()
+	count, err := actions.Find(bson.D{{"status", ActionPending}}).Count()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot count pending actions")
+	}
+	return count, nil
+}
+
+// PendingCleanupCount returns the number of cleanups scheduled in the
+// model that have not yet been run.
+func (st *State) PendingCleanupCount() (int, error) {
+	cleanups, closer := st.db().GetCollection(cleanupsC)
+	defer closer()
+	count, err := cleanups.Count()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot count cleanups")
+	}
+	return count, nil
+}
+
+// LogCountSince returns the number of log records written to the
+// model's log collection at or after the given time.
+func (st *State) LogCountSince(t time.Time) (int, error) {
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+	count, err := logsColl.Find(bson.M{
+		"t": bson.M{"$gte": t.UnixNano()},
+	}).Count()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot count log records")
+	}
+	return count, nil
+}
+
+// PendingTransactionCount returns the number of transactions, across
+// the whole controller, that have not yet been applied or aborted.
+// A growing count indicates that the transaction queue is backing up.
+func (st *State) PendingTransactionCount() (int, error) {
+	txns, closer := st.db().GetRawCollection(txnsC)
+	defer closer()
+	count, err := txns.Find(bson.M{
+		"s": bson.M{"$in": []int{
+			txnStatePreparing,
+			txnStatePrepared,
+			txnStateAborting,
+			txnStateApplying,
+		}},
+	}).Count()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot count pending transactions")
+	}
+	return count, nil
+}
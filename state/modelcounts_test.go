@@ -0,0 +1,103 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ModelCountsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelCountsSuite{})
+
+func (s *ModelCountsSuite) TestUnitCount(c *gc.C) {
+	count, err := s.State.UnitCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+
+	s.Factory.MakeUnit(c, nil)
+	s.Factory.MakeUnit(c, nil)
+
+	count, err = s.State.UnitCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 2)
+
+	// Units in other models are not counted.
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	count, err = otherSt.UnitCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
+
+func (s *ModelCountsSuite) TestPendingCleanupCount(c *gc.C) {
+	count, err := s.State.PendingCleanupCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+
+	app := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err = s.State.PendingCleanupCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Not(gc.Equals), 0)
+
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	count, err = s.State.PendingCleanupCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
+
+func (s *ModelCountsSuite) TestLogCountSince(c *gc.C) {
+	logger := state.NewDbLogger(s.State)
+	defer logger.Close()
+
+	t0 := coretesting.ZeroTime().Truncate(time.Millisecond)
+	var records []state.LogRecord
+	for i := 0; i < 3; i++ {
+		records = append(records, state.LogRecord{
+			Time:     t0.Add(time.Duration(i) * time.Minute),
+			Entity:   names.NewMachineTag("0"),
+			Module:   "some.where",
+			Location: "foo.go:99",
+			Level:    loggo.INFO,
+			Message:  "all is well",
+		})
+	}
+	err := logger.Log(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err := s.State.LogCountSince(t0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 3)
+
+	count, err = s.State.LogCountSince(t0.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 2)
+
+	count, err = s.State.LogCountSince(t0.Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
+
+func (s *ModelCountsSuite) TestPendingTransactionCount(c *gc.C) {
+	// All transactions run by the test setup have completed.
+	count, err := s.State.PendingTransactionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
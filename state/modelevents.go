@@ -0,0 +1,304 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/status"
+)
+
+// ModelEventSource identifies the record from which a model event was
+// derived.
+type ModelEventSource string
+
+const (
+	// ModelEventStatus events are changes of an entity's status.
+	ModelEventStatus ModelEventSource = "status"
+
+	// ModelEventAction events are actions being enqueued, started and
+	// completed.
+	ModelEventAction ModelEventSource = "action"
+
+	// ModelEventCleanup events are cleanups scheduled after an entity
+	// has been destroyed.
+	ModelEventCleanup ModelEventSource = "cleanup"
+)
+
+// ModelEvent describes something that happened in a model.
+type ModelEvent struct {
+	// Source identifies the record the event was derived from.
+	Source ModelEventSource
+
+	// Id identifies the event among those from the same source.
+	Id string
+
+	// Time is when the event happened.
+	Time time.Time
+
+	// Entity holds the tag of the entity the event concerns, if any.
+	Entity string
+
+	// Type describes what happened. For status events this is the
+	// kind of status that changed; for actions it is one of
+	// "action-enqueued", "action-started" and "action-completed";
+	// and for cleanups it is "cleanup".
+	Type string
+
+	// Status holds the new status of the entity or action, or the
+	// kind of cleanup.
+	Status string
+
+	// Message holds any further detail.
+	Message string
+}
+
+// Events returns the first limit events in the model that happened at
+// or after since, ordered by time.
+func (m *Model) Events(since time.Time, limit int) ([]ModelEvent, error) {
+	if limit <= 0 {
+		return nil, errors.NotValidf("limit %d", limit)
+	}
+	statusEvents, err := m.statusEvents(since, limit)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading status history")
+	}
+	actionEvents, err := m.actionEvents(since, limit)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading actions")
+	}
+	cleanupEvents, err := m.cleanupEvents(since)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading cleanups")
+	}
+	events := append(statusEvents, actionEvents...)
+	events = append(events, cleanupEvents...)
+	SortModelEvents(events)
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+// SortModelEvents sorts events by time, breaking ties by source and
+// then id so that the order is stable across calls.
+func SortModelEvents(events []ModelEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		switch {
+		case !a.Time.Equal(b.Time):
+			return a.Time.Before(b.Time)
+		case a.Source != b.Source:
+			return a.Source < b.Source
+		}
+		return a.Id < b.Id
+	})
+}
+
+func (m *Model) statusEvents(since time.Time, limit int) ([]ModelEvent, error) {
+	history, closer := m.st.db().GetCollection(statusesHistoryC)
+	defer closer()
+
+	// The query is served by the index on model-uuid and updated.
+	query := history.Find(bson.D{
+		{"updated", bson.D{{"$gte", since.UnixNano()}}},
+		{"globalkey", bson.D{{"$in", statusEventKeys}}},
+	}).Sort("updated", "_id").Limit(limit)
+	var docs []struct {
+		Id         bson.ObjectId `bson:"_id"`
+		GlobalKey  string        `bson:"globalkey"`
+		Status     status.Status `bson:"status"`
+		StatusInfo string        `bson:"statusinfo"`
+		Updated    int64         `bson:"updated"`
+	}
+	if err := query.All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var events []ModelEvent
+	for _, doc := range docs {
+		entity, kind, ok := m.statusEntityForGlobalKey(doc.GlobalKey)
+		if !ok {
+			continue
+		}
+		events = append(events, ModelEvent{
+			Source:  ModelEventStatus,
+			Id:      doc.Id.Hex(),
+			Time:    time.Unix(0, doc.Updated).UTC(),
+			Entity:  entity,
+			Type:    string(kind),
+			Status:  string(doc.Status),
+			Message: doc.StatusInfo,
+		})
+	}
+	return events, nil
+}
+
+// statusEventKeys match the global keys of the statuses reported as
+// events: those of the model, applications, unit agents and workloads,
+// and machine agents and instances.
+var statusEventKeys = []bson.RegEx{
+	{Pattern: `^e$`},
+	{Pattern: `^a#[^#]+$`},
+	{Pattern: `^u#[^#]+(#charm)?$`},
+	{Pattern: `^m#[^#]+(#instance)?$`},
+}
+
+// statusEntityForGlobalKey returns the tag of the entity whose status
+// is recorded under the given global key, and the kind of that status.
+// It returns false for keys that are not of interest.
+func (m *Model) statusEntityForGlobalKey(key string) (string, status.HistoryKind, bool) {
+	if key == modelGlobalKey {
+		return m.ModelTag().String(), "model", true
+	}
+	if len(key) < 3 || key[1] != '#' {
+		return "", "", false
+	}
+	parts := strings.Split(key[2:], "#")
+	id := parts[0]
+	switch key[0] {
+	case 'u':
+		switch {
+		case len(parts) == 1:
+			return names.NewUnitTag(id).String(), status.KindUnitAgent, true
+		case len(parts) == 2 && parts[1] == "charm":
+			return names.NewUnitTag(id).String(), status.KindWorkload, true
+		}
+	case 'm':
+		container := names.IsContainerMachine(id)
+		switch {
+		case len(parts) == 1 && container:
+			return names.NewMachineTag(id).String(), status.KindContainer, true
+		case len(parts) == 1:
+			return names.NewMachineTag(id).String(), status.KindMachine, true
+		case len(parts) == 2 && parts[1] == "instance" && container:
+			return names.NewMachineTag(id).String(), status.KindContainerInstance, true
+		case len(parts) == 2 && parts[1] == "instance":
+			return names.NewMachineTag(id).String(), status.KindMachineInstance, true
+		}
+	case 'a':
+		return names.NewApplicationTag(id).String(), "application", true
+	}
+	return "", "", false
+}
+
+// actionEvents returns the first limit events of each kind for actions
+// enqueued, started or completed at or after since. Each kind is read
+// with its own query, served by the index on its time field.
+func (m *Model) actionEvents(since time.Time, limit int) ([]ModelEvent, error) {
+	actions, closer := m.st.db().GetCollection(actionsC)
+	defer closer()
+
+	var events []ModelEvent
+	for _, what := range []string{"enqueued", "started", "completed"} {
+		var docs []actionDoc
+		err := actions.Find(bson.D{
+			{what, bson.D{{"$gte", since}}},
+		}).Sort(what).Limit(limit).All(&docs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, doc := range docs {
+			if event := m.actionEvent(doc, what); !event.Time.IsZero() {
+				events = append(events, event)
+			}
+		}
+	}
+	return events, nil
+}
+
+// actionEvent returns the event for the action being enqueued, started
+// or completed, according to what.
+func (m *Model) actionEvent(doc actionDoc, what string) ModelEvent {
+	receiver := names.NewMachineTag(doc.Receiver).String()
+	if names.IsValidUnit(doc.Receiver) {
+		receiver = names.NewUnitTag(doc.Receiver).String()
+	}
+	event := ModelEvent{
+		Source:  ModelEventAction,
+		Id:      m.st.localID(doc.DocId) + "-" + what,
+		Entity:  receiver,
+		Type:    "action-" + what,
+		Message: doc.Name,
+	}
+	switch what {
+	case "enqueued":
+		event.Time, event.Status = doc.Enqueued, string(ActionPending)
+	case "started":
+		event.Time, event.Status = doc.Started, string(ActionRunning)
+	case "completed":
+		event.Time, event.Status = doc.Completed, string(doc.Status)
+		if doc.Message != "" {
+			event.Message += ": " + doc.Message
+		}
+	}
+	event.Time = event.Time.UTC()
+	return event
+}
+
+func (m *Model) cleanupEvents(since time.Time) ([]ModelEvent, error) {
+	cleanups, closer := m.st.db().GetCollection(cleanupsC)
+	defer closer()
+
+	var docs []cleanupDoc
+	if err := cleanups.Find(nil).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var events []ModelEvent
+	for _, doc := range docs {
+		// Cleanups do not record when they were scheduled, but their
+		// ids are derived from object ids, which do.
+		hex := strings.TrimSuffix(strings.TrimPrefix(m.st.localID(doc.DocID), `ObjectIdHex("`), `")`)
+		if !bson.IsObjectIdHex(hex) {
+			continue
+		}
+		t := bson.ObjectIdHex(hex).Time().UTC()
+		if t.Before(since) {
+			continue
+		}
+		events = append(events, ModelEvent{
+			Source:  ModelEventCleanup,
+			Id:      hex,
+			Time:    t,
+			Entity:  cleanupEntity(doc.Kind, doc.Prefix),
+			Type:    "cleanup",
+			Status:  string(doc.Kind),
+			Message: doc.Prefix,
+		})
+	}
+	return events, nil
+}
+
+// cleanupEntity returns the tag of the entity a cleanup of the given
+// kind and prefix concerns, or "" if it does not concern one.
+func cleanupEntity(kind cleanupKind, prefix string) string {
+	switch kind {
+	case cleanupDyingUnit, cleanupRemovedUnit:
+		if names.IsValidUnit(prefix) {
+			return names.NewUnitTag(prefix).String()
+		}
+	case cleanupDyingMachine, cleanupForceDestroyedMachine:
+		if names.IsValidMachine(prefix) {
+			return names.NewMachineTag(prefix).String()
+		}
+	case cleanupUnitsForDyingApplication:
+		if names.IsValidApplication(prefix) {
+			return names.NewApplicationTag(prefix).String()
+		}
+	case cleanupApplicationsForDyingModel, cleanupMachinesForDyingModel, cleanupStorageForDyingModel:
+		if names.IsValidModel(prefix) {
+			return names.NewModelTag(prefix).String()
+		}
+	case cleanupAttachmentsForDyingStorage:
+		if names.IsValidStorage(prefix) {
+			return names.NewStorageTag(prefix).String()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type ModelEventsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelEventsSuite{})
+
+func (s *ModelEventsSuite) TestEvents(c *gc.C) {
+	ch := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingApplication(c, "dummy", ch)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	err = unit.SetCharmURL(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Action times are stored to the millisecond.
+	s.Clock.Advance(time.Hour)
+	since := s.Clock.Now().Truncate(time.Millisecond)
+
+	action, err := model.EnqueueAction(unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Second)
+	now := s.Clock.Now()
+	err = unit.SetStatus(status.StatusInfo{Status: status.Active, Message: "ready", Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Second)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Second)
+	_, err = action.Finish(state.ActionResults{Status: state.ActionFailed, Message: "oops"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Cleanups are timed by the wall clock.
+	before := time.Now().Truncate(time.Second)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	events, err := model.Events(since, 100)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 5)
+	cleanup := events[4]
+	c.Check(cleanup.Time.Before(before), jc.IsFalse)
+	cleanup.Id, cleanup.Time = "", time.Time{}
+	c.Check(cleanup, jc.DeepEquals, state.ModelEvent{
+		Source:  state.ModelEventCleanup,
+		Entity:  "application-dummy",
+		Type:    "cleanup",
+		Status:  "units",
+		Message: "dummy",
+	})
+
+	type event struct {
+		source  state.ModelEventSource
+		time    time.Time
+		typ     string
+		status  string
+		message string
+	}
+	var got []event
+	for _, e := range events[:4] {
+		c.Check(e.Entity, gc.Equals, "unit-dummy-0")
+		got = append(got, event{e.Source, e.Time, e.Type, e.Status, e.Message})
+	}
+	c.Check(got, jc.DeepEquals, []event{
+		{state.ModelEventAction, since.UTC(), "action-enqueued", "pending", "snapshot"},
+		{state.ModelEventStatus, now.UTC(), "workload", "active", "ready"},
+		{state.ModelEventAction, since.Add(2 * time.Second).UTC(), "action-started", "running", "snapshot"},
+		{state.ModelEventAction, since.Add(3 * time.Second).UTC(), "action-completed", "failed", "snapshot: oops"},
+	})
+
+	limited, err := model.Events(since, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(limited, jc.DeepEquals, events[:2])
+
+	later, err := model.Events(since.Add(2*time.Second), 100)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(later, jc.DeepEquals, events[2:])
+
+	_, err = model.Events(since, 0)
+	c.Assert(err, gc.ErrorMatches, "limit 0 not valid")
+}
@@ -0,0 +1,302 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Operation groups the actions that were enqueued by a single request.
+type Operation struct {
+	id      string
+	actions []Action
+}
+
+// Id returns the operation's id.
+func (o *Operation) Id() string {
+	return o.id
+}
+
+// Actions returns the operation's actions, ordered by receiver.
+func (o *Operation) Actions() []Action {
+	return o.actions
+}
+
+// Enqueued returns the time the operation's first action was added.
+func (o *Operation) Enqueued() time.Time {
+	var enqueued time.Time
+	for _, a := range o.actions {
+		if enqueued.IsZero() || a.Enqueued().Before(enqueued) {
+			enqueued = a.Enqueued()
+		}
+	}
+	return enqueued
+}
+
+// Started returns the time the operation's first action began running,
+// or the zero time if none has.
+func (o *Operation) Started() time.Time {
+	var started time.Time
+	for _, a := range o.actions {
+		if a.Started().IsZero() {
+			continue
+		}
+		if started.IsZero() || a.Started().Before(started) {
+			started = a.Started()
+		}
+	}
+	return started
+}
+
+// Completed returns the time the operation's last action finished, or
+// the zero time if any of its actions have yet to finish.
+func (o *Operation) Completed() time.Time {
+	var completed time.Time
+	for _, a := range o.actions {
+		if !actionFinished(a.Status()) {
+			return time.Time{}
+		}
+		if a.Completed().After(completed) {
+			completed = a.Completed()
+		}
+	}
+	return completed
+}
+
+// Status summarises the status of the operation's actions. An
+// operation is pending until one of its actions starts, and running
+// until all of them have finished. A finished operation has failed if
+// any of its actions failed, is cancelled if all of its actions were
+// cancelled, and has completed otherwise.
+func (o *Operation) Status() ActionStatus {
+	statuses := make([]ActionStatus, len(o.actions))
+	for i, a := range o.actions {
+		statuses[i] = a.Status()
+	}
+	return operationStatus(statuses)
+}
+
+// operationStatus returns the status of an operation whose actions
+// have the given statuses.
+func operationStatus(statuses []ActionStatus) ActionStatus {
+	counts := make(map[ActionStatus]int)
+	for _, status := range statuses {
+		counts[status]++
+	}
+	switch {
+	case counts[ActionPending] == len(statuses):
+		return ActionPending
+	case counts[ActionPending] > 0, counts[ActionRunning] > 0:
+		return ActionRunning
+	case counts[ActionFailed] > 0:
+		return ActionFailed
+	case counts[ActionCancelled] == len(statuses):
+		return ActionCancelled
+	}
+	return ActionCompleted
+}
+
+func actionFinished(status ActionStatus) bool {
+	switch status {
+	case ActionCompleted, ActionCancelled, ActionFailed:
+		return true
+	}
+	return false
+}
+
+// NewOperationId returns the id for a new operation. Actions are
+// added to the operation by passing its id when enqueueing them.
+func (m *Model) NewOperationId() (string, error) {
+	seq, err := sequence(m.st, "operation")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return strconv.Itoa(seq), nil
+}
+
+// Operation returns the operation with the given id.
+func (m *Model) Operation(id string) (*Operation, error) {
+	operations, err := m.operations([]string{id})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(operations) == 0 {
+		return nil, errors.NotFoundf("operation %q", id)
+	}
+	return operations[0], nil
+}
+
+// OperationQuery selects the operations returned by ListOperations.
+// Empty fields match every operation.
+type OperationQuery struct {
+	// Applications selects operations with an action on a unit of
+	// any of these applications.
+	Applications []string
+
+	// ActionNames selects operations that run any of these actions.
+	ActionNames []string
+
+	// Status selects operations with any of these statuses.
+	Status []ActionStatus
+
+	// From and To select operations with an action enqueued no
+	// earlier than From and no later than To.
+	From, To time.Time
+
+	// Offset is the number of matching operations, newest first,
+	// to skip.
+	Offset int
+
+	// Limit is the maximum number of operations to return; zero
+	// means no limit.
+	Limit int
+}
+
+// ListOperations returns the operations that match the query, newest
+// first, and whether there are more matching operations beyond the
+// query's limit. Only the actions of the operations returned are
+// loaded.
+func (m *Model) ListOperations(query OperationQuery) ([]*Operation, bool, error) {
+	if query.Offset < 0 {
+		return nil, false, errors.NotValidf("offset %d", query.Offset)
+	}
+	if query.Limit < 0 {
+		return nil, false, errors.NotValidf("limit %d", query.Limit)
+	}
+	actions, closer := m.st.db().GetCollection(actionsC)
+	defer closer()
+
+	sel := bson.D{{"operation", bson.D{{"$exists", true}}}}
+	if len(query.Applications) > 0 {
+		prefixes := make([]string, len(query.Applications))
+		for i, app := range query.Applications {
+			prefixes[i] = regexp.QuoteMeta(app)
+		}
+		sel = append(sel, bson.DocElem{"receiver", bson.D{{
+			"$regex", "^(" + strings.Join(prefixes, "|") + ")/",
+		}}})
+	}
+	if len(query.ActionNames) > 0 {
+		sel = append(sel, bson.DocElem{"name", bson.D{{"$in", query.ActionNames}}})
+	}
+	enqueued := bson.D{}
+	if !query.From.IsZero() {
+		enqueued = append(enqueued, bson.DocElem{"$gte", query.From})
+	}
+	if !query.To.IsZero() {
+		enqueued = append(enqueued, bson.DocElem{"$lte", query.To})
+	}
+	if len(enqueued) > 0 {
+		sel = append(sel, bson.DocElem{"enqueued", enqueued})
+	}
+	var ids []string
+	if err := actions.Find(sel).Distinct("operation", &ids); err != nil {
+		return nil, false, errors.Annotate(err, "cannot find operations")
+	}
+	sortOperationIds(ids)
+
+	if len(query.Status) > 0 {
+		var err error
+		ids, err = m.operationsWithStatus(ids, query.Status)
+		if err != nil {
+			return nil, false, errors.Trace(err)
+		}
+	}
+	if query.Offset >= len(ids) {
+		return nil, false, nil
+	}
+	ids = ids[query.Offset:]
+	truncated := false
+	if query.Limit > 0 && len(ids) > query.Limit {
+		ids, truncated = ids[:query.Limit], true
+	}
+
+	operations, err := m.operations(ids)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	return operations, truncated, nil
+}
+
+// operationsWithStatus returns those of the given operation ids whose
+// operations have any of the given statuses, in the same order. Only
+// the status of each action is read.
+func (m *Model) operationsWithStatus(ids []string, statuses []ActionStatus) ([]string, error) {
+	actions, closer := m.st.db().GetCollection(actionsC)
+	defer closer()
+
+	var docs []struct {
+		Operation string       `bson:"operation"`
+		Status    ActionStatus `bson:"status"`
+	}
+	sel := bson.D{{"operation", bson.D{{"$in", ids}}}}
+	err := actions.Find(sel).Select(bson.D{{"operation", 1}, {"status", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get operation statuses")
+	}
+	byId := make(map[string][]ActionStatus)
+	for _, doc := range docs {
+		byId[doc.Operation] = append(byId[doc.Operation], doc.Status)
+	}
+	var matching []string
+	for _, id := range ids {
+		status := operationStatus(byId[id])
+		for _, want := range statuses {
+			if status == want {
+				matching = append(matching, id)
+				break
+			}
+		}
+	}
+	return matching, nil
+}
+
+// sortOperationIds sorts operation ids newest first. Operation ids
+// come from a sequence, so higher ids are newer.
+func sortOperationIds(ids []string) {
+	sort.Slice(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+		return a > b
+	})
+}
+
+// operations returns the operations with the given ids, newest first.
+// Ids that have no actions are ignored.
+func (m *Model) operations(ids []string) ([]*Operation, error) {
+	actions, closer := m.st.db().GetCollection(actionsC)
+	defer closer()
+
+	var docs []actionDoc
+	sel := bson.D{{"operation", bson.D{{"$in", ids}}}}
+	if err := actions.Find(sel).Sort("receiver").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get operation actions")
+	}
+	byId := make(map[string]*Operation)
+	var operations []*Operation
+	for _, doc := range docs {
+		op, ok := byId[doc.Operation]
+		if !ok {
+			op = &Operation{id: doc.Operation}
+			byId[doc.Operation] = op
+			operations = append(operations, op)
+		}
+		op.actions = append(op.actions, newAction(m.st, doc))
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		// Operation ids come from a sequence, so higher ids
+		// are newer.
+		a, _ := strconv.Atoi(operations[i].id)
+		b, _ := strconv.Atoi(operations[j].id)
+		return a > b
+	})
+	return operations, nil
+}
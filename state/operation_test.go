@@ -0,0 +1,217 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type OperationSuite struct {
+	ConnSuite
+	clock *jujutesting.Clock
+	model *state.Model
+	units map[string]*state.Unit
+}
+
+var _ = gc.Suite(&OperationSuite{})
+
+func (s *OperationSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(time.Date(2018, time.March, 1, 10, 0, 0, 0, time.UTC))
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.AddTestingCharm(c, "dummy")
+	s.units = make(map[string]*state.Unit)
+	for _, name := range []string{"dummy", "other"} {
+		app := s.AddTestingApplication(c, name, ch)
+		for i := 0; i < 2; i++ {
+			unit, err := app.AddUnit(state.AddUnitParams{})
+			c.Assert(err, jc.ErrorIsNil)
+			s.units[unit.Name()] = unit
+		}
+	}
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// enqueue adds an operation running the named action on the given
+// units, and returns its id.
+func (s *OperationSuite) enqueue(c *gc.C, name string, units ...string) string {
+	id, err := s.model.NewOperationId()
+	c.Assert(err, jc.ErrorIsNil)
+	var payload map[string]interface{}
+	if name == "juju-run" {
+		payload = map[string]interface{}{"command": "hostname", "timeout": 0}
+	}
+	for _, unit := range units {
+		_, err := s.units[unit].AddOperationAction(id, name, payload)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return id
+}
+
+func (s *OperationSuite) finish(c *gc.C, id string, status state.ActionStatus) {
+	op, err := s.model.Operation(id)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, a := range op.Actions() {
+		_, err := a.Finish(state.ActionResults{Status: status})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *OperationSuite) ids(c *gc.C, query state.OperationQuery) []string {
+	ops, _, err := s.model.ListOperations(query)
+	c.Assert(err, jc.ErrorIsNil)
+	ids := make([]string, len(ops))
+	for i, op := range ops {
+		ids[i] = op.Id()
+	}
+	return ids
+}
+
+func (s *OperationSuite) TestOperation(c *gc.C) {
+	id := s.enqueue(c, "snapshot", "dummy/0", "dummy/1")
+	s.enqueue(c, "snapshot", "dummy/0")
+
+	op, err := s.model.Operation(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Id(), gc.Equals, id)
+	c.Assert(op.Actions(), gc.HasLen, 2)
+	c.Assert(op.Actions()[0].Receiver(), gc.Equals, "dummy/0")
+	c.Assert(op.Actions()[0].Operation(), gc.Equals, id)
+	c.Assert(op.Actions()[1].Receiver(), gc.Equals, "dummy/1")
+	c.Assert(op.Enqueued(), gc.Equals, s.clock.Now())
+	c.Assert(op.Status(), gc.Equals, state.ActionPending)
+
+	_, err = s.model.Operation("42")
+	c.Assert(err, gc.ErrorMatches, `operation "42" not found`)
+}
+
+func (s *OperationSuite) TestOperationStatus(c *gc.C) {
+	id := s.enqueue(c, "snapshot", "dummy/0", "dummy/1")
+	op, err := s.model.Operation(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Actions()[0].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	op, err = s.model.Operation(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Status(), gc.Equals, state.ActionRunning)
+	c.Assert(op.Completed().IsZero(), jc.IsTrue)
+
+	_, err = op.Actions()[1].Finish(state.ActionResults{Status: state.ActionFailed})
+	c.Assert(err, jc.ErrorIsNil)
+	op, err = s.model.Operation(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Status(), gc.Equals, state.ActionFailed)
+	c.Assert(op.Completed().IsZero(), jc.IsFalse)
+
+	id = s.enqueue(c, "snapshot", "dummy/0", "dummy/1")
+	s.finish(c, id, state.ActionCancelled)
+	op, err = s.model.Operation(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Status(), gc.Equals, state.ActionCancelled)
+}
+
+func (s *OperationSuite) TestListOperationsFilters(c *gc.C) {
+	first := s.enqueue(c, "snapshot", "dummy/0", "dummy/1")
+	s.finish(c, first, state.ActionCompleted)
+	s.clock.Advance(time.Hour)
+	second := s.enqueue(c, "snapshot", "other/0")
+	s.clock.Advance(time.Hour)
+	third := s.enqueue(c, "juju-run", "dummy/1", "other/1")
+
+	c.Assert(s.ids(c, state.OperationQuery{}), jc.DeepEquals, []string{third, second, first})
+	c.Assert(s.ids(c, state.OperationQuery{
+		Applications: []string{"dummy"},
+	}), jc.DeepEquals, []string{third, first})
+	c.Assert(s.ids(c, state.OperationQuery{
+		ActionNames: []string{"snapshot"},
+	}), jc.DeepEquals, []string{second, first})
+	c.Assert(s.ids(c, state.OperationQuery{
+		Status: []state.ActionStatus{state.ActionCompleted},
+	}), jc.DeepEquals, []string{first})
+	c.Assert(s.ids(c, state.OperationQuery{
+		From: s.clock.Now().Add(-90 * time.Minute),
+		To:   s.clock.Now().Add(-30 * time.Minute),
+	}), jc.DeepEquals, []string{second})
+
+	// Actions enqueued outside of an operation are not listed.
+	_, err := s.units["dummy/0"].AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.ids(c, state.OperationQuery{}), gc.HasLen, 3)
+}
+
+func (s *OperationSuite) TestListOperationsPages(c *gc.C) {
+	var ids []string
+	for i := 0; i < 5; i++ {
+		ids = append([]string{s.enqueue(c, "snapshot", "dummy/0")}, ids...)
+	}
+
+	ops, truncated, err := s.model.ListOperations(state.OperationQuery{Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 2)
+	c.Assert(truncated, jc.IsTrue)
+	c.Assert(ops[0].Id(), gc.Equals, ids[0])
+
+	c.Assert(s.ids(c, state.OperationQuery{Offset: 2, Limit: 2}), jc.DeepEquals, ids[2:4])
+
+	ops, truncated, err = s.model.ListOperations(state.OperationQuery{Offset: 4, Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 1)
+	c.Assert(truncated, jc.IsFalse)
+
+	c.Assert(s.ids(c, state.OperationQuery{Offset: 5}), gc.HasLen, 0)
+
+	_, _, err = s.model.ListOperations(state.OperationQuery{Limit: -1})
+	c.Assert(err, gc.ErrorMatches, "limit -1 not valid")
+}
+
+func (s *OperationSuite) TestListOperationsPagesByStatus(c *gc.C) {
+	var failed []string
+	for i := 0; i < 4; i++ {
+		id := s.enqueue(c, "snapshot", "dummy/0")
+		if i%2 == 0 {
+			s.finish(c, id, state.ActionFailed)
+			failed = append([]string{id}, failed...)
+		}
+	}
+
+	ops, truncated, err := s.model.ListOperations(state.OperationQuery{
+		Status: []state.ActionStatus{state.ActionFailed},
+		Limit:  1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(truncated, jc.IsTrue)
+	c.Assert(ops, gc.HasLen, 1)
+	c.Assert(ops[0].Id(), gc.Equals, failed[0])
+
+	c.Assert(s.ids(c, state.OperationQuery{
+		Status: []state.ActionStatus{state.ActionFailed},
+		Offset: 1,
+	}), jc.DeepEquals, failed[1:])
+}
+
+func (s *OperationSuite) TestActionRolloutOperation(c *gc.C) {
+	rollout, err := s.model.AddActionRollout(state.ActionRolloutArgs{
+		Application: "dummy",
+		Name:        "snapshot",
+		MaxParallel: 2,
+		MaxFailures: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.model.AdvanceActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+
+	op, err := s.model.Operation(rollout.Operation())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Actions(), gc.HasLen, 2)
+}
@@ -1292,6 +1292,11 @@ func (st *State) processIAASModelApplicationArgs(args *AddApplicationArgs) error
 					err, "cannot deploy to machine %s", m,
 				)
 			}
+			if _, err := machinePoolAdmissionOps(m, args.Name); err != nil {
+				return errors.Annotatef(
+					err, "cannot deploy to machine %s", m,
+				)
+			}
 
 		case directivePlacement:
 			// Obtain volume attachment params corresponding to storage being
@@ -1535,6 +1540,15 @@ func (st *State) addMachineWithPlacement(unit *Unit, placement *instance.Placeme
 			Constraints: *unitCons,
 		}
 		if data.machineId != "" {
+			// Check the host's pool before creating a container
+			// the unit could not be assigned to.
+			host, err := st.Machine(data.machineId)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if _, err := machinePoolAdmissionOps(host, unit.ApplicationName()); err != nil {
+				return nil, errors.Trace(err)
+			}
 			return st.AddMachineInsideMachine(template, data.machineId, data.containerType)
 		}
 		return st.AddMachineInsideNewMachine(template, template, data.containerType)
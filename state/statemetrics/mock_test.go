@@ -4,6 +4,8 @@
 package statemetrics_test
 
 import (
+	"time"
+
 	"github.com/juju/testing"
 	"gopkg.in/juju/names.v2"
 
@@ -69,6 +71,7 @@ type mockState struct {
 	model      *mockModel
 	modelUUIDs []string
 	users      []*mockUser
+	txnCount   int
 }
 
 func (m *mockState) AllModelUUIDs() ([]string, error) {
@@ -109,6 +112,31 @@ func (m *mockState) UserAccess(subject names.UserTag, object names.Tag) (permiss
 	panic("subject not found")
 }
 
+func (m *mockState) PendingTransactionCount() (int, error) {
+	m.MethodCall(m, "PendingTransactionCount")
+	return m.txnCount, m.NextErr()
+}
+
+func (m *mockState) UnitCount() (int, error) {
+	m.MethodCall(m, "UnitCount")
+	return m.model.units, m.NextErr()
+}
+
+func (m *mockState) PendingActionCount() (int, error) {
+	m.MethodCall(m, "PendingActionCount")
+	return m.model.pendingActions, m.NextErr()
+}
+
+func (m *mockState) PendingCleanupCount() (int, error) {
+	m.MethodCall(m, "PendingCleanupCount")
+	return m.model.pendingCleanups, m.NextErr()
+}
+
+func (m *mockState) LogCountSince(t time.Time) (int, error) {
+	m.MethodCall(m, "LogCountSince", t)
+	return m.model.logCount, m.NextErr()
+}
+
 func (m *mockState) release() bool {
 	m.MethodCall(m, "release")
 	return false
@@ -132,6 +160,11 @@ type mockModel struct {
 	life     state.Life
 	status   status.StatusInfo
 	machines []*mockMachine
+
+	units           int
+	pendingActions  int
+	pendingCleanups int
+	logCount        int
 }
 
 func (m *mockModel) Life() state.Life {
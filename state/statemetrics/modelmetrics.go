@@ -0,0 +1,196 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statemetrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	modelSubsystem = "model"
+	modelLabel     = "model"
+
+	// otherModelsLabelValue is the model label value under which the
+	// metrics for models beyond the labelled limit are aggregated.
+	otherModelsLabelValue = "other"
+
+	// logIngestWindow is the period over which the log ingest
+	// rate is measured.
+	logIngestWindow = time.Minute
+)
+
+// DefaultMaxLabelledModels is the default maximum number of models
+// that are given their own label value by a ModelCollector.
+const DefaultMaxLabelledModels = 100
+
+// ModelCollector is a prometheus.Collector that collects per-model
+// workload metrics, and the depth of the controller's transaction
+// queue.
+//
+// To bound the cardinality of the exported metrics, at most
+// maxModels models are labelled with their UUID; the metrics for
+// any remaining models are summed under the model label "other".
+type ModelCollector struct {
+	pool      StatePool
+	clock     clock.Clock
+	maxModels int
+
+	scrapeErrors prometheus.Gauge
+
+	units           *prometheus.GaugeVec
+	logIngestRate   *prometheus.GaugeVec
+	pendingActions  *prometheus.GaugeVec
+	pendingCleanups *prometheus.GaugeVec
+	txnQueueDepth   prometheus.Gauge
+}
+
+// NewModelCollector returns a new ModelCollector that labels at
+// most maxModels models individually.
+func NewModelCollector(pool StatePool, clock clock.Clock, maxModels int) *ModelCollector {
+	newModelGaugeVec := func(name, help string) *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Subsystem: modelSubsystem,
+				Name:      name,
+				Help:      help,
+			},
+			[]string{modelLabel},
+		)
+	}
+	return &ModelCollector{
+		pool:      pool,
+		clock:     clock,
+		maxModels: maxModels,
+		scrapeErrors: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Subsystem: modelSubsystem,
+				Name:      "scrape_errors",
+				Help:      "Number of errors observed while collecting model metrics.",
+			},
+		),
+		units: newModelGaugeVec(
+			"units",
+			"Number of units in the model.",
+		),
+		logIngestRate: newModelGaugeVec(
+			"log_ingest_rate",
+			"Number of log records written per second for the model, averaged over the last minute.",
+		),
+		pendingActions: newModelGaugeVec(
+			"pending_actions",
+			"Number of actions enqueued but not yet started in the model.",
+		),
+		pendingCleanups: newModelGaugeVec(
+			"pending_cleanups",
+			"Number of cleanups scheduled but not yet run in the model.",
+		),
+		txnQueueDepth: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "txn_queue_depth",
+				Help:      "Number of transactions not yet applied or aborted.",
+			},
+		),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *ModelCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.units.Describe(ch)
+	c.logIngestRate.Describe(ch)
+	c.pendingActions.Describe(ch)
+	c.pendingCleanups.Describe(ch)
+	c.txnQueueDepth.Describe(ch)
+
+	c.scrapeErrors.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *ModelCollector) Collect(ch chan<- prometheus.Metric) {
+	c.scrapeErrors.Set(0)
+	defer c.scrapeErrors.Collect(ch)
+
+	c.units.Reset()
+	c.logIngestRate.Reset()
+	c.pendingActions.Reset()
+	c.pendingCleanups.Reset()
+
+	c.updateMetrics()
+
+	c.units.Collect(ch)
+	c.logIngestRate.Collect(ch)
+	c.pendingActions.Collect(ch)
+	c.pendingCleanups.Collect(ch)
+	c.txnQueueDepth.Collect(ch)
+}
+
+func (c *ModelCollector) updateMetrics() {
+	logger.Tracef("updating model metrics")
+	defer logger.Tracef("updated model metrics")
+
+	st := c.pool.SystemState()
+	depth, err := st.PendingTransactionCount()
+	if err != nil {
+		logger.Debugf("error getting txn queue depth: %v", err)
+		c.scrapeErrors.Inc()
+	}
+	c.txnQueueDepth.Set(float64(depth))
+
+	modelUUIDs, err := st.AllModelUUIDs()
+	if err != nil {
+		logger.Debugf("error getting models: %v", err)
+		c.scrapeErrors.Inc()
+		return
+	}
+	// Sort the models so that the same models are
+	// labelled individually from one scrape to the next.
+	sort.Strings(modelUUIDs)
+	since := c.clock.Now().Add(-logIngestWindow)
+	for i, modelUUID := range modelUUIDs {
+		label := modelUUID
+		if i >= c.maxModels {
+			label = otherModelsLabelValue
+		}
+		c.updateModelMetrics(modelUUID, label, since)
+	}
+}
+
+func (c *ModelCollector) updateModelMetrics(modelUUID, label string, logsSince time.Time) {
+	st, release, err := c.pool.Get(modelUUID)
+	if err != nil {
+		// The model may have been removed since
+		// we listed the models; skip it.
+		logger.Debugf("error getting model state: %v", err)
+		return
+	}
+	defer release()
+
+	labels := prometheus.Labels{modelLabel: label}
+	addCount := func(gauge *prometheus.GaugeVec, what string, count func() (int, error)) {
+		n, err := count()
+		if err != nil {
+			logger.Debugf("error getting %s: %v", what, err)
+			c.scrapeErrors.Inc()
+			return
+		}
+		gauge.With(labels).Add(float64(n))
+	}
+	addCount(c.units, "units", st.UnitCount)
+	addCount(c.pendingActions, "pending actions", st.PendingActionCount)
+	addCount(c.pendingCleanups, "pending cleanups", st.PendingCleanupCount)
+
+	logCount, err := st.LogCountSince(logsSince)
+	if err != nil {
+		logger.Debugf("error getting log count: %v", err)
+		c.scrapeErrors.Inc()
+		return
+	}
+	c.logIngestRate.With(labels).Add(float64(logCount) / logIngestWindow.Seconds())
+}
@@ -0,0 +1,140 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statemetrics_test
+
+import (
+	"errors"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state/statemetrics"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelCollectorSuite struct {
+	testing.IsolationSuite
+	pool  *mockStatePool
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&modelCollectorSuite{})
+
+func (s *modelCollectorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(coretesting.NonZeroTime())
+	s.pool = &mockStatePool{
+		models: []*mockModel{{
+			tag:             names.NewModelTag("1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
+			units:           3,
+			pendingActions:  1,
+			pendingCleanups: 2,
+			logCount:        120,
+		}, {
+			tag:             names.NewModelTag("b266dff7-eee8-4297-b03a-4692796ec193"),
+			units:           4,
+			pendingActions:  5,
+			pendingCleanups: 0,
+			logCount:        60,
+		}, {
+			tag:             names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d"),
+			units:           1,
+			pendingActions:  1,
+			pendingCleanups: 1,
+			logCount:        60,
+		}},
+	}
+	s.pool.system = &mockState{
+		modelUUIDs: s.pool.modelUUIDs(),
+		txnCount:   7,
+	}
+}
+
+func (s *modelCollectorSuite) collect(c *gc.C, maxModels int) map[string][]dto.Metric {
+	collector := statemetrics.NewModelCollector(s.pool, s.clock, maxModels)
+	registry := prometheus.NewRegistry()
+	err := registry.Register(collector)
+	c.Assert(err, jc.ErrorIsNil)
+
+	families, err := registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	result := make(map[string][]dto.Metric)
+	for _, family := range families {
+		for _, m := range family.Metric {
+			result[family.GetName()] = append(result[family.GetName()], *m)
+		}
+	}
+	return result
+}
+
+func modelValues(metrics []dto.Metric) map[string]float64 {
+	values := make(map[string]float64)
+	for _, m := range metrics {
+		for _, label := range m.Label {
+			if label.GetName() == "model" {
+				values[label.GetValue()] = m.Gauge.GetValue()
+			}
+		}
+	}
+	return values
+}
+
+func (s *modelCollectorSuite) TestCollect(c *gc.C) {
+	metrics := s.collect(c, statemetrics.DefaultMaxLabelledModels)
+
+	c.Assert(modelValues(metrics["juju_state_model_units"]), jc.DeepEquals, map[string]float64{
+		"1ab5799e-e72d-4de7-b70d-499edfab0e5c": 3,
+		"b266dff7-eee8-4297-b03a-4692796ec193": 4,
+		"deadbeef-0bad-400d-8000-4b1d0d06f00d": 1,
+	})
+	c.Assert(modelValues(metrics["juju_state_model_pending_actions"]), jc.DeepEquals, map[string]float64{
+		"1ab5799e-e72d-4de7-b70d-499edfab0e5c": 1,
+		"b266dff7-eee8-4297-b03a-4692796ec193": 5,
+		"deadbeef-0bad-400d-8000-4b1d0d06f00d": 1,
+	})
+	c.Assert(modelValues(metrics["juju_state_model_pending_cleanups"]), jc.DeepEquals, map[string]float64{
+		"1ab5799e-e72d-4de7-b70d-499edfab0e5c": 2,
+		"b266dff7-eee8-4297-b03a-4692796ec193": 0,
+		"deadbeef-0bad-400d-8000-4b1d0d06f00d": 1,
+	})
+	c.Assert(modelValues(metrics["juju_state_model_log_ingest_rate"]), jc.DeepEquals, map[string]float64{
+		"1ab5799e-e72d-4de7-b70d-499edfab0e5c": 2,
+		"b266dff7-eee8-4297-b03a-4692796ec193": 1,
+		"deadbeef-0bad-400d-8000-4b1d0d06f00d": 1,
+	})
+	c.Assert(metrics["juju_state_txn_queue_depth"], gc.HasLen, 1)
+	c.Assert(metrics["juju_state_txn_queue_depth"][0].Gauge.GetValue(), gc.Equals, float64(7))
+	c.Assert(metrics["juju_state_model_scrape_errors"][0].Gauge.GetValue(), gc.Equals, float64(0))
+}
+
+func (s *modelCollectorSuite) TestCollectBoundsModelLabels(c *gc.C) {
+	metrics := s.collect(c, 1)
+
+	c.Assert(modelValues(metrics["juju_state_model_units"]), jc.DeepEquals, map[string]float64{
+		"1ab5799e-e72d-4de7-b70d-499edfab0e5c": 3,
+		"other":                                5,
+	})
+	c.Assert(modelValues(metrics["juju_state_model_pending_actions"]), jc.DeepEquals, map[string]float64{
+		"1ab5799e-e72d-4de7-b70d-499edfab0e5c": 1,
+		"other":                                6,
+	})
+	c.Assert(modelValues(metrics["juju_state_model_log_ingest_rate"]), jc.DeepEquals, map[string]float64{
+		"1ab5799e-e72d-4de7-b70d-499edfab0e5c": 2,
+		"other":                                2,
+	})
+}
+
+func (s *modelCollectorSuite) TestCollectErrors(c *gc.C) {
+	s.pool.system.SetErrors(
+		errors.New("no txns for you"),
+		errors.New("no models for you"),
+	)
+	metrics := s.collect(c, statemetrics.DefaultMaxLabelledModels)
+	c.Assert(metrics["juju_state_model_units"], gc.HasLen, 0)
+	c.Assert(metrics["juju_state_model_scrape_errors"][0].Gauge.GetValue(), gc.Equals, float64(2))
+}
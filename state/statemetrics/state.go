@@ -3,6 +3,8 @@
 package statemetrics
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -24,6 +26,11 @@ type State interface {
 	AllModelUUIDs() ([]string, error)
 	AllUsers() ([]User, error)
 	ControllerTag() names.ControllerTag
+	LogCountSince(time.Time) (int, error)
+	PendingActionCount() (int, error)
+	PendingCleanupCount() (int, error)
+	PendingTransactionCount() (int, error)
+	UnitCount() (int, error)
 	UserAccess(names.UserTag, names.Tag) (permission.UserAccess, error)
 }
 
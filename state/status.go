@@ -421,14 +421,21 @@ func fetchNStatusResults(col mongo.Collection, key string,
 		query mongo.Query
 	)
 	baseQuery := bson.M{"globalkey": key}
+	updated := bson.M{}
 	if filter.Delta != nil {
 		delta := *filter.Delta
 		// TODO(perrito666) 2016-10-06 lp:1558657
-		updated := time.Now().Add(-delta)
-		baseQuery["updated"] = bson.M{"$gt": updated.UnixNano()}
+		from := time.Now().Add(-delta)
+		updated["$gt"] = from.UnixNano()
 	}
 	if filter.FromDate != nil {
-		baseQuery["updated"] = bson.M{"$gt": filter.FromDate.UnixNano()}
+		updated["$gt"] = filter.FromDate.UnixNano()
+	}
+	if filter.ToDate != nil {
+		updated["$lte"] = filter.ToDate.UnixNano()
+	}
+	if len(updated) > 0 {
+		baseQuery["updated"] = updated
 	}
 	excludes := []string{}
 	excludes = append(excludes, filter.Exclude.Values()...)
@@ -436,7 +443,11 @@ func fetchNStatusResults(col mongo.Collection, key string,
 		baseQuery["statusinfo"] = bson.M{"$nin": excludes}
 	}
 
-	query = col.Find(baseQuery).Sort("-updated")
+	if filter.Limit > 0 {
+		query = col.Find(baseQuery).Sort("updated").Limit(filter.Limit)
+	} else {
+		query = col.Find(baseQuery).Sort("-updated")
+	}
 	if filter.Size > 0 {
 		query = query.Limit(filter.Size)
 	}
@@ -447,6 +458,13 @@ func fetchNStatusResults(col mongo.Collection, key string,
 	} else if err != nil {
 		return []historicalStatusDoc{}, errors.Annotatef(err, "cannot get status history")
 	}
+	if filter.Limit > 0 {
+		// The earliest entries were selected, but they are
+		// returned latest first like any others.
+		for i, j := 0, len(docs)-1; i < j; i, j = i+1, j-1 {
+			docs[i], docs[j] = docs[j], docs[i]
+		}
+	}
 	return docs, nil
 
 }
@@ -293,6 +293,25 @@ func (s *StatusHistorySuite) TestStatusHistoryFiltersByDateAndDelta(c *gc.C) {
 	c.Assert(history[0].Message, gc.Equals, "current status")
 	c.Assert(history[1].Message, gc.Equals, "waiting for machine")
 	c.Assert(history[2].Message, gc.Equals, "2 days ago")
+
+	// Logs between three days ago and yesterday, using dates.
+	history, err = unit.StatusHistory(status.StatusHistoryFilter{FromDate: &threeDaysAgo, ToDate: &yesterday})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Message, gc.Equals, "2 days ago")
+
+	_, err = unit.StatusHistory(status.StatusHistoryFilter{FromDate: &yesterday, ToDate: &threeDaysAgo})
+	c.Assert(err, gc.ErrorMatches, "validating arguments: ToDate before Date not valid")
+
+	// The earliest logs since three days ago, using limit.
+	history, err = unit.StatusHistory(status.StatusHistoryFilter{FromDate: &threeDaysAgo, Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Message, gc.Equals, "waiting for machine")
+	c.Assert(history[1].Message, gc.Equals, "2 days ago")
+
+	_, err = unit.StatusHistory(status.StatusHistoryFilter{Delta: &oneDayBack, Limit: 2})
+	c.Assert(err, gc.ErrorMatches, "validating arguments: Limit without Date not valid")
 }
 
 func (s *StatusHistorySuite) TestSameValueNotRepeated(c *gc.C) {
@@ -0,0 +1,116 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"context"
+
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/tracing"
+)
+
+// WithContext returns a shallow copy of st whose transactions are
+// recorded as spans of the trace carried by ctx, if any. This lets
+// API facades attribute the time spent writing to mongo to the
+// requests that caused it.
+//
+// The returned State shares its session and workers with st, and
+// must not be closed; it may not be used after st is closed.
+func (st *State) WithContext(ctx context.Context) *State {
+	return st.WithContextFunc(func() context.Context {
+		return ctx
+	})
+}
+
+// WithContextFunc is like WithContext, but calls contextFunc for the
+// context of each transaction as it is run. It lets long-lived users
+// of the State, such as cached API facades, attribute transactions to
+// the calls they are serving at the time.
+func (st *State) WithContextFunc(contextFunc func() context.Context) *State {
+	copied := *st
+	copied.database = &tracingDatabase{
+		Database:    st.database,
+		contextFunc: contextFunc,
+	}
+	return &copied
+}
+
+// tracingDatabase is a Database that records a span for each of the
+// transactions run through its convenience methods.
+type tracingDatabase struct {
+	Database
+	contextFunc func() context.Context
+}
+
+// Copy is part of the Database interface.
+func (db *tracingDatabase) Copy() (Database, SessionCloser) {
+	copied, closer := db.Database.Copy()
+	return &tracingDatabase{Database: copied, contextFunc: db.contextFunc}, closer
+}
+
+// CopyForModel is part of the Database interface.
+func (db *tracingDatabase) CopyForModel(modelUUID string) (Database, SessionCloser) {
+	copied, closer := db.Database.CopyForModel(modelUUID)
+	return &tracingDatabase{Database: copied, contextFunc: db.contextFunc}, closer
+}
+
+// RunTransaction is part of the Database interface.
+func (db *tracingDatabase) RunTransaction(ops []txn.Op) error {
+	span := db.startSpan("state.RunTransaction", ops)
+	err := db.Database.RunTransaction(ops)
+	return endSpan(span, err)
+}
+
+// RunTransactionFor is part of the Database interface.
+func (db *tracingDatabase) RunTransactionFor(modelUUID string, ops []txn.Op) error {
+	span := db.startSpan("state.RunTransactionFor", ops)
+	span.SetAttribute("state.target-model-uuid", modelUUID)
+	err := db.Database.RunTransactionFor(modelUUID, ops)
+	return endSpan(span, err)
+}
+
+// RunRawTransaction is part of the Database interface.
+func (db *tracingDatabase) RunRawTransaction(ops []txn.Op) error {
+	span := db.startSpan("state.RunRawTransaction", ops)
+	err := db.Database.RunRawTransaction(ops)
+	return endSpan(span, err)
+}
+
+// Run is part of the Database interface.
+func (db *tracingDatabase) Run(transactions jujutxn.TransactionSource) error {
+	span := db.startSpan("state.Run", nil)
+	attempts := 0
+	err := db.Database.Run(func(attempt int) ([]txn.Op, error) {
+		attempts = attempt + 1
+		return transactions(attempt)
+	})
+	span.SetAttribute("state.attempts", attempts)
+	return endSpan(span, err)
+}
+
+func (db *tracingDatabase) startSpan(name string, ops []txn.Op) *tracing.Span {
+	_, span := tracing.Start(db.contextFunc(), name)
+	span.SetAttribute("state.model-uuid", db.modelUUID())
+	if ops != nil {
+		span.SetAttribute("state.ops", len(ops))
+	}
+	return span
+}
+
+// modelUUID returns the UUID of the model that the underlying
+// database is scoped to, if it is known.
+func (db *tracingDatabase) modelUUID() string {
+	if inner, ok := db.Database.(*database); ok {
+		return inner.modelUUID
+	}
+	return ""
+}
+
+func endSpan(span *tracing.Span, err error) error {
+	span.RecordError(err)
+	span.End()
+	return err
+}
@@ -0,0 +1,75 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"context"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/tracing"
+	"github.com/juju/juju/core/tracing/tracingtest"
+	"github.com/juju/juju/state"
+)
+
+type TracingSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&TracingSuite{})
+
+func (s *TracingSuite) TestWithContextRecordsTransactions(c *gc.C) {
+	exporter := &tracingtest.Exporter{}
+	ctx := tracing.WithTracer(context.Background(), tracing.NewTracer(exporter, clock.WallClock))
+	ctx, parent := tracing.Start(ctx, "request")
+
+	st := s.State.WithContext(ctx)
+	_, err := st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	parent.End()
+
+	spans := exporter.Spans()
+	c.Assert(spans, gc.HasLen, 2)
+	c.Assert(spans[0].Name, gc.Equals, "state.RunTransaction")
+	c.Assert(spans[0].ParentID, gc.Equals, spans[1].SpanID)
+	c.Assert(spans[0].Attributes["state.model-uuid"], gc.Equals, s.State.ModelUUID())
+	c.Assert(spans[0].Attributes["state.ops"], gc.Not(gc.Equals), 0)
+	c.Assert(spans[0].Error, gc.Equals, "")
+}
+
+func (s *TracingSuite) TestWithContextNoTracer(c *gc.C) {
+	st := s.State.WithContext(context.Background())
+	m, err := st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The original State sees the machine added through the copy.
+	_, err = s.State.Machine(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TracingSuite) TestWithContextFunc(c *gc.C) {
+	exporter := &tracingtest.Exporter{}
+	ctx := tracing.WithTracer(context.Background(), tracing.NewTracer(exporter, clock.WallClock))
+	current := context.Background()
+	st := s.State.WithContextFunc(func() context.Context {
+		return current
+	})
+
+	_, err := st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exporter.Spans(), gc.HasLen, 0)
+
+	// The context is looked up for each transaction.
+	current, parent := tracing.Start(ctx, "request")
+	_, err = st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	parent.End()
+
+	spans := exporter.Spans()
+	c.Assert(spans, gc.HasLen, 2)
+	c.Assert(spans[0].Name, gc.Equals, "state.RunTransaction")
+	c.Assert(spans[0].ParentID, gc.Equals, spans[1].SpanID)
+}
@@ -1356,6 +1356,10 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	); err != nil {
 		return nil, errors.Trace(err)
 	}
+	poolOps, err := machinePoolAdmissionOps(m, u.doc.Application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	storageOps, volumesAttached, filesystemsAttached, err := u.st.machineStorageOps(
 		&m.doc, storageParams,
 	)
@@ -1401,6 +1405,7 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	},
 		removeStagedAssignmentOp(u.doc.DocID),
 	}
+	ops = append(ops, poolOps...)
 	ops = append(ops, storageOps...)
 	return ops, nil
 }
@@ -1632,6 +1637,14 @@ func (u *Unit) assignToNewMachineOps(
 		return nil, nil, err
 	}
 
+	// A new container joins the pool of its host, which must admit
+	// the unit's application.
+	poolOps, err := machinePoolAdmissionOps(&Machine{u.st, *mdoc}, u.doc.Application)
+	if err != nil {
+		return nil, nil, err
+	}
+	ops = append(ops, poolOps...)
+
 	// Ensure the host machine is really clean.
 	if parentId != "" {
 		mparent, err := u.st.Machine(parentId)
@@ -2090,12 +2103,19 @@ func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value)
 	for i, cref := range containerRefs {
 		machinesWithContainers[i] = cref.Id
 	}
+	// Machines in pools that do not admit the unit's application
+	// are never chosen.
+	excludedPools, err := u.st.machinePoolsExcluding(u.doc.Application)
+	if err != nil {
+		return nil, err
+	}
 	terms := bson.D{
 		{"life", Alive},
 		{"series", u.doc.Series},
 		{"jobs", []MachineJob{JobHostUnits}},
 		{"clean", true},
 		{"machineid", bson.D{{"$nin", machinesWithContainers}}},
+		{"pool", bson.D{{"$nin", excludedPools}}},
 	}
 	// Add the container filter term if necessary.
 	var containerType instance.ContainerType
@@ -2347,6 +2367,28 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return u.AddOperationAction("", name, payload)
+}
+
+// AddOperationAction is part of the ActionReceiver interface.
+func (u *Unit) AddOperationAction(operation, name string, payload map[string]interface{}) (Action, error) {
+	payloadWithDefaults, err := u.actionPayload(name, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := u.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return model.EnqueueOperationAction(operation, u.Tag(), name, payloadWithDefaults)
+}
+
+// actionPayload checks that the named action is defined for the unit
+// and that the payload is valid for it, and returns the payload with
+// the action's defaults inserted.
+func (u *Unit) actionPayload(name string, payload map[string]interface{}) (map[string]interface{}, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -2368,17 +2410,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if err != nil {
 		return nil, err
 	}
-	payloadWithDefaults, err := spec.InsertDefaults(payload)
-	if err != nil {
-		return nil, err
-	}
-
-	model, err := u.st.Model()
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	return model.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return spec.InsertDefaults(payload)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
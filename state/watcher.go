@@ -4,6 +4,7 @@
 package state
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -403,6 +404,14 @@ func watchApplicationRelations(backend modelBackend, applicationName string) Str
 	return newRelationLifeSuspendedWatcher(backend, members, filter, nil)
 }
 
+// WatchApplicationCharms returns a StringsWatcher that notifies of
+// changes to the applications in the model, including changes to the
+// charm an application uses. It is used to keep the LXD profiles that
+// charms contribute up to date.
+func (st *State) WatchApplicationCharms() StringsWatcher {
+	return newCollectionWatcher(st, colWCfg{col: applicationsC})
+}
+
 // WatchModelMachines returns a StringsWatcher that notifies of changes to
 // the lifecycles of the machines (but not containers) in the model.
 func (st *State) WatchModelMachines() StringsWatcher {
@@ -2401,6 +2410,122 @@ func (st *State) watchEnqueuedActionsFilteredBy(receivers ...ActionReceiver) Str
 	})
 }
 
+// WatchActionLogs starts and returns a StringsWatcher that notifies
+// of the progress messages logged by the action with the given id.
+// Each change holds JSON-encoded ActionMessages, oldest first; the
+// first event holds the messages already logged.
+func (st *State) WatchActionLogs(actionId string) StringsWatcher {
+	return newActionLogsWatcher(st, st.docID(actionId))
+}
+
+// actionLogsWatcher reports the progress messages logged by a single
+// action.
+type actionLogsWatcher struct {
+	commonWatcher
+	docId string
+	out   chan []string
+
+	// seen is the number of messages, including those since
+	// pruned, that have been read from the action.
+	seen int
+}
+
+var _ Watcher = (*actionLogsWatcher)(nil)
+
+func newActionLogsWatcher(backend modelBackend, docId string) StringsWatcher {
+	w := &actionLogsWatcher{
+		commonWatcher: newCommonWatcher(backend),
+		docId:         docId,
+		out:           make(chan []string),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *actionLogsWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+func (w *actionLogsWatcher) loop() error {
+	actions, closer := w.db.GetCollection(actionsC)
+	txnRevno, err := getTxnRevno(actions, w.docId)
+	closer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	in := make(chan watcher.Change)
+	w.watcher.Watch(actions.Name(), w.docId, txnRevno, in)
+	defer w.watcher.Unwatch(actions.Name(), w.docId, in)
+
+	changes, err := w.newMessages()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case ch := <-in:
+			if _, ok := collect(ch, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			more, err := w.newMessages()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			changes = append(changes, more...)
+			if len(changes) > 0 {
+				out = w.out
+			}
+		case out <- changes:
+			changes = nil
+			out = nil
+		}
+	}
+}
+
+// newMessages returns the messages logged by the action since it was
+// last called, JSON-encoded.
+func (w *actionLogsWatcher) newMessages() ([]string, error) {
+	actions, closer := w.db.GetCollection(actionsC)
+	defer closer()
+
+	var doc actionDoc
+	fields := bson.D{{"messages", 1}, {"messages-count", 1}}
+	err := actions.FindId(w.docId).Select(fields).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action %q", w.backend.localID(w.docId))
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Messages dropped before we saw them are lost.
+	start := w.seen - (doc.LogsCount - len(doc.Logs))
+	if start < 0 {
+		start = 0
+	} else if start > len(doc.Logs) {
+		start = len(doc.Logs)
+	}
+	var changes []string
+	for _, message := range doc.Logs[start:] {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		changes = append(changes, string(data))
+	}
+	w.seen = doc.LogsCount
+	return changes, nil
+}
+
 // WatchControllerStatusChanges starts and returns a StringsWatcher that
 // notifies when the status of a controller machine changes.
 // TODO(cherylj) Add unit tests for this, as per bug 1543408.
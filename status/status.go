@@ -219,6 +219,10 @@ const (
 	Provisioning      Status = "allocating"
 	Running           Status = "running"
 	ProvisioningError Status = "provisioning error"
+
+	// Missing is set when:
+	// The cloud no longer knows about the machine's instance.
+	Missing Status = "missing"
 )
 
 const (
@@ -235,6 +239,7 @@ func (status Status) KnownInstanceStatus() bool {
 		ProvisioningError,
 		Allocating,
 		Running,
+		Missing,
 		Unknown:
 		return true
 	}
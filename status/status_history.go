@@ -16,11 +16,17 @@ type StatusHistoryFilter struct {
 	Size int
 	// FromDate indicates the earliest date from which logs are expected.
 	FromDate *time.Time
+	// ToDate indicates the latest date up to which logs are expected.
+	ToDate *time.Time
 	// Delta indicates the age of the oldest log expected.
 	Delta *time.Duration
 	// Exclude indicates the status messages that should be excluded
 	// from the returned result.
 	Exclude set.Strings
+	// Limit, if positive, restricts the results to the earliest Limit
+	// entries after FromDate. Unlike Size, which selects the latest
+	// entries, it allows a period to be read forward in pages.
+	Limit int
 }
 
 // Validate checks that the minimum requirements of a StatusHistoryFilter are met.
@@ -38,6 +44,12 @@ func (f *StatusHistoryFilter) Validate() error {
 		return errors.NotValidf("Size and Delta together")
 	case t && d:
 		return errors.NotValidf("Date and Delta together")
+	case t && f.ToDate != nil && f.ToDate.Before(*f.FromDate):
+		return errors.NotValidf("ToDate before Date")
+	case f.Limit < 0:
+		return errors.NotValidf("negative Limit")
+	case f.Limit > 0 && !t:
+		return errors.NotValidf("Limit without Date")
 	}
 	return nil
 }
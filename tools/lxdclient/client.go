@@ -106,6 +106,7 @@ type Client struct {
 	*imageClient
 	*networkClient
 	*storageClient
+	*clusterClient
 	baseURL                  string
 	defaultProfileBridgeName string
 }
@@ -134,6 +135,7 @@ func Connect(cfg Config, verifyBridgeConfig bool) (*Client, error) {
 
 	networkAPISupported := false
 	storageAPISupported := false
+	clustered := false
 	var defaultProfile *api.Profile
 	if cfg.Remote.Protocol != SimplestreamsProtocol {
 		status, err := raw.ServerStatus()
@@ -149,6 +151,13 @@ func Connect(cfg Config, verifyBridgeConfig bool) (*Client, error) {
 			storageAPISupported = true
 		}
 
+		if lxdshared.StringInSlice("clustering", status.APIExtensions) {
+			clustered, err = isClusterEnabled(restClient{raw})
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+
 		defaultProfile, err = raw.ProfileConfig("default")
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -174,14 +183,16 @@ func Connect(cfg Config, verifyBridgeConfig bool) (*Client, error) {
 		}
 	}
 
+	cluster := &clusterClient{restClient{raw}, clustered}
 	conn := &Client{
 		configClient:             &configClient{raw},
 		certClient:               &certClient{raw},
 		profileClient:            &profileClient{raw},
-		instanceClient:           &instanceClient{raw, remoteID},
+		instanceClient:           &instanceClient{raw, remoteID, cluster},
 		imageClient:              &imageClient{raw, connectToRaw},
 		networkClient:            &networkClient{raw, networkAPISupported},
 		storageClient:            &storageClient{raw, storageAPISupported},
+		clusterClient:            cluster,
 		baseURL:                  raw.BaseURL,
 		defaultProfileBridgeName: bridgeName,
 	}
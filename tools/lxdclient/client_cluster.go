@@ -0,0 +1,212 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/lxc/lxd"
+)
+
+// ClusterMemberOnline is the status reported by LXD for cluster
+// members that are up and reachable.
+const ClusterMemberOnline = "Online"
+
+// ClusterMember describes a single member of an LXD cluster.
+type ClusterMember struct {
+	// Name is the name of the cluster member.
+	Name string `json:"server_name"`
+
+	// URL is the address at which the member is reachable.
+	URL string `json:"url"`
+
+	// Database indicates whether or not the member is a
+	// database node.
+	Database bool `json:"database"`
+
+	// Status is the member's status, e.g. "Online" or "Offline".
+	Status string `json:"status"`
+
+	// Message holds any additional information about the status.
+	Message string `json:"message"`
+}
+
+// Online reports whether or not the member is online.
+func (m ClusterMember) Online() bool {
+	return m.Status == ClusterMemberOnline
+}
+
+// restResponse is the standard envelope for LXD REST API responses.
+type restResponse struct {
+	Type       string          `json:"type"`
+	Status     string          `json:"status"`
+	StatusCode int             `json:"status_code"`
+	Operation  string          `json:"operation"`
+	ErrorCode  int             `json:"error_code"`
+	Error      string          `json:"error"`
+	Metadata   json.RawMessage `json:"metadata"`
+}
+
+// rawClusterClient makes requests against the LXD REST API. The legacy
+// LXD client has no support for clustering, so the cluster endpoints
+// are queried directly.
+type rawClusterClient interface {
+	// Query sends a request with the given method to the path,
+	// relative to the API version, and returns the response's
+	// metadata and, for asynchronous requests, its operation.
+	Query(method, path string, body interface{}) (json.RawMessage, string, error)
+
+	WaitForSuccess(waitURL string) error
+}
+
+type restClient struct {
+	*lxd.Client
+}
+
+// Query implements rawClusterClient.
+func (c restClient) Query(method, path string, body interface{}) (json.RawMessage, string, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, "", errors.Trace(err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, c.BaseURL+"/1.0"+path, reader)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.Http.Do(req)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	defer resp.Body.Close()
+
+	var result restResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, "", errors.Annotatef(err, "decoding response to %s %s", method, path)
+	}
+	if result.Type == "error" {
+		if result.ErrorCode == http.StatusNotFound {
+			return nil, "", errors.NewNotFound(nil, result.Error)
+		}
+		return nil, "", errors.New(result.Error)
+	}
+	return result.Metadata, result.Operation, nil
+}
+
+type clusterClient struct {
+	raw       rawClusterClient
+	clustered bool
+}
+
+// IsClustered reports whether or not the LXD remote is a member
+// of a cluster.
+func (c *clusterClient) IsClustered() bool {
+	return c.clustered
+}
+
+// ClusterMembers returns the members of the LXD cluster.
+func (c *clusterClient) ClusterMembers() ([]ClusterMember, error) {
+	if !c.clustered {
+		return nil, errors.NotSupportedf("clustering on this remote")
+	}
+	metadata, _, err := c.raw.Query("GET", "/cluster/members?recursion=1", nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing cluster members")
+	}
+	var members []ClusterMember
+	if err := json.Unmarshal(metadata, &members); err != nil {
+		return nil, errors.Annotate(err, "decoding cluster members")
+	}
+	return members, nil
+}
+
+// InstanceLocations returns the name of the cluster member hosting
+// each of the instances with the given name prefix, keyed by instance
+// name.
+func (c *clusterClient) InstanceLocations(prefix string) (map[string]string, error) {
+	if !c.clustered {
+		return nil, errors.NotSupportedf("clustering on this remote")
+	}
+	metadata, _, err := c.raw.Query("GET", "/containers?recursion=1", nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing containers")
+	}
+	var containers []struct {
+		Name     string `json:"name"`
+		Location string `json:"location"`
+	}
+	if err := json.Unmarshal(metadata, &containers); err != nil {
+		return nil, errors.Annotate(err, "decoding containers")
+	}
+	locations := make(map[string]string)
+	for _, container := range containers {
+		if strings.HasPrefix(container.Name, prefix) {
+			locations[container.Name] = container.Location
+		}
+	}
+	return locations, nil
+}
+
+// initOnMember creates, but does not start, a container from a local
+// image on the named cluster member.
+func (c *clusterClient) initOnMember(
+	member, name, image string,
+	profiles []string,
+	config map[string]string,
+	devices map[string]map[string]string,
+	ephemeral bool,
+) error {
+	if !c.clustered {
+		return errors.NotSupportedf("placing containers on cluster members on this remote")
+	}
+	body := map[string]interface{}{
+		"name":      name,
+		"profiles":  profiles,
+		"config":    config,
+		"devices":   devices,
+		"ephemeral": ephemeral,
+		"source": map[string]string{
+			"type":  "image",
+			"alias": image,
+		},
+	}
+	_, operation, err := c.raw.Query("POST", "/containers?target="+url.QueryEscape(member), body)
+	if err != nil {
+		return errors.Annotatef(err, "creating container on cluster member %q", member)
+	}
+	if err := c.raw.WaitForSuccess(operation); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// isClusterEnabled reports whether or not the LXD server has
+// clustering enabled.
+func isClusterEnabled(raw rawClusterClient) (bool, error) {
+	metadata, _, err := raw.Query("GET", "/cluster", nil)
+	if err != nil {
+		return false, errors.Annotate(err, "querying cluster")
+	}
+	var cluster struct {
+		Enabled bool `json:"enabled"`
+	}
+	if err := json.Unmarshal(metadata, &cluster); err != nil {
+		return false, errors.Annotate(err, "decoding cluster")
+	}
+	return cluster.Enabled, nil
+}
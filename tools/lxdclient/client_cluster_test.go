@@ -0,0 +1,186 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient_test
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared"
+	lxdapi "github.com/lxc/lxd/shared/api"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+type ClusterClientSuite struct {
+	testing.IsolationSuite
+
+	raw *mockRawClusterClient
+}
+
+var _ = gc.Suite(&ClusterClientSuite{})
+
+func (s *ClusterClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.raw = &mockRawClusterClient{responses: make(map[string]string)}
+}
+
+func (s *ClusterClientSuite) TestNotClustered(c *gc.C) {
+	client := lxdclient.NewClusterClient(s.raw, false)
+	c.Assert(client.IsClustered(), jc.IsFalse)
+
+	_, err := client.ClusterMembers()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	_, err = client.InstanceLocations("juju-")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	s.raw.CheckNoCalls(c)
+}
+
+func (s *ClusterClientSuite) TestIsClusterEnabled(c *gc.C) {
+	s.raw.responses["/cluster"] = `{"server_name": "node1", "enabled": true}`
+	enabled, err := lxdclient.IsClusterEnabled(s.raw)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enabled, jc.IsTrue)
+
+	s.raw.responses["/cluster"] = `{"server_name": "", "enabled": false}`
+	enabled, err = lxdclient.IsClusterEnabled(s.raw)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enabled, jc.IsFalse)
+}
+
+func (s *ClusterClientSuite) TestClusterMembers(c *gc.C) {
+	s.raw.responses["/cluster/members?recursion=1"] = `[
+		{"server_name": "node1", "url": "https://10.0.0.1:8443", "database": true, "status": "Online", "message": "fully operational"},
+		{"server_name": "node2", "url": "https://10.0.0.2:8443", "database": false, "status": "Offline", "message": "no heartbeat"}
+	]`
+	client := lxdclient.NewClusterClient(s.raw, true)
+	members, err := client.ClusterMembers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(members, jc.DeepEquals, []lxdclient.ClusterMember{{
+		Name:     "node1",
+		URL:      "https://10.0.0.1:8443",
+		Database: true,
+		Status:   "Online",
+		Message:  "fully operational",
+	}, {
+		Name:    "node2",
+		URL:     "https://10.0.0.2:8443",
+		Status:  "Offline",
+		Message: "no heartbeat",
+	}})
+	c.Assert(members[0].Online(), jc.IsTrue)
+	c.Assert(members[1].Online(), jc.IsFalse)
+	s.raw.CheckCall(c, 0, "Query", "GET", "/cluster/members?recursion=1", nil)
+}
+
+func (s *ClusterClientSuite) TestClusterMembersError(c *gc.C) {
+	s.raw.SetErrors(errors.New("boom"))
+	client := lxdclient.NewClusterClient(s.raw, true)
+	_, err := client.ClusterMembers()
+	c.Assert(err, gc.ErrorMatches, "listing cluster members: boom")
+}
+
+func (s *ClusterClientSuite) TestInstanceLocations(c *gc.C) {
+	s.raw.responses["/containers?recursion=1"] = `[
+		{"name": "juju-abc-0", "location": "node1"},
+		{"name": "juju-abc-1", "location": "node2"},
+		{"name": "other", "location": "node1"}
+	]`
+	client := lxdclient.NewClusterClient(s.raw, true)
+	locations, err := client.InstanceLocations("juju-abc-")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locations, jc.DeepEquals, map[string]string{
+		"juju-abc-0": "node1",
+		"juju-abc-1": "node2",
+	})
+}
+
+func (s *ClusterClientSuite) TestAddInstanceOnMember(c *gc.C) {
+	rawInstances := &clusterInstanceClient{}
+	client := lxdclient.NewClusteredInstanceClient(rawInstances, s.raw)
+	inst, err := client.AddInstance(lxdclient.InstanceSpec{
+		Name:     "juju-abc-0",
+		Image:    "juju/xenial/amd64",
+		Profiles: []string{"default"},
+		Target:   "node2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inst.Name, gc.Equals, "juju-abc-0")
+
+	s.raw.CheckCallNames(c, "Query", "WaitForSuccess")
+	s.raw.CheckCall(c, 0, "Query", "POST", "/containers?target=node2", map[string]interface{}{
+		"name":      "juju-abc-0",
+		"profiles":  []string{"default"},
+		"config":    map[string]string{},
+		"devices":   map[string]map[string]string{},
+		"ephemeral": false,
+		"source": map[string]string{
+			"type":  "image",
+			"alias": "juju/xenial/amd64",
+		},
+	})
+	s.raw.CheckCall(c, 1, "WaitForSuccess", "/1.0/operations/op")
+	rawInstances.CheckCallNames(c, "Action", "WaitForSuccess", "ContainerInfo")
+}
+
+func (s *ClusterClientSuite) TestAddInstanceOnMemberNotClustered(c *gc.C) {
+	client := lxdclient.NewInstanceClient(&clusterInstanceClient{})
+	_, err := client.AddInstance(lxdclient.InstanceSpec{
+		Name:   "juju-abc-0",
+		Image:  "juju/xenial/amd64",
+		Target: "node2",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+type mockRawClusterClient struct {
+	testing.Stub
+
+	responses map[string]string
+}
+
+func (c *mockRawClusterClient) Query(method, path string, body interface{}) (json.RawMessage, string, error) {
+	c.AddCall("Query", method, path, body)
+	if err := c.NextErr(); err != nil {
+		return nil, "", err
+	}
+	if method != "GET" {
+		return nil, "/1.0/operations/op", nil
+	}
+	return json.RawMessage(c.responses[path]), "", nil
+}
+
+func (c *mockRawClusterClient) WaitForSuccess(waitURL string) error {
+	c.AddCall("WaitForSuccess", waitURL)
+	return c.NextErr()
+}
+
+// clusterInstanceClient implements the instance operations that follow
+// the creation of a container on a cluster member.
+type clusterInstanceClient struct {
+	lxdclient.RawInstanceClient
+	testing.Stub
+}
+
+func (c *clusterInstanceClient) Action(name string, action shared.ContainerAction, timeout int, force bool, stateful bool) (*lxdapi.Response, error) {
+	c.AddCall("Action", name, action)
+	return &lxdapi.Response{}, c.NextErr()
+}
+
+func (c *clusterInstanceClient) WaitForSuccess(waitURL string) error {
+	c.AddCall("WaitForSuccess", waitURL)
+	return c.NextErr()
+}
+
+func (c *clusterInstanceClient) ContainerInfo(name string) (*lxdapi.Container, error) {
+	c.AddCall("ContainerInfo", name)
+	return &lxdapi.Container{Name: name}, c.NextErr()
+}
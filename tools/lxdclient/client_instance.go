@@ -33,6 +33,7 @@ type DiskDevice struct {
 type rawInstanceClient interface {
 	ListContainers() ([]api.Container, error)
 	ContainerInfo(name string) (*api.Container, error)
+	UpdateContainerConfig(container string, st api.ContainerPut) error
 	Init(name string, imgremote string, image string, profiles *[]string, config map[string]string, devices map[string]map[string]string, ephem bool) (*api.Response, error)
 	Action(name string, action shared.ContainerAction, timeout int, force bool, stateful bool) (*api.Response, error)
 	Delete(name string) (*api.Response, error)
@@ -45,8 +46,9 @@ type rawInstanceClient interface {
 }
 
 type instanceClient struct {
-	raw    rawInstanceClient
-	remote string
+	raw     rawInstanceClient
+	remote  string
+	cluster *clusterClient
 }
 
 func (client *instanceClient) addInstance(spec InstanceSpec) error {
@@ -74,6 +76,9 @@ func (client *instanceClient) addInstance(spec InstanceSpec) error {
 	}
 
 	config := spec.config()
+	if spec.Target != "" {
+		return client.addInstanceOnMember(spec, imageRemote, profiles, config, lxdDevices)
+	}
 	resp, err := client.raw.Init(spec.Name, imageRemote, imageAlias, profiles, config, lxdDevices, spec.Ephemeral)
 	if err != nil {
 		return errors.Trace(err)
@@ -91,6 +96,33 @@ func (client *instanceClient) addInstance(spec InstanceSpec) error {
 	return nil
 }
 
+// addInstanceOnMember creates the instance on the cluster member
+// named by the spec's target.
+func (client *instanceClient) addInstanceOnMember(
+	spec InstanceSpec,
+	imageRemote string,
+	profiles *[]string,
+	config map[string]string,
+	devices map[string]map[string]string,
+) error {
+	if client.cluster == nil || !client.cluster.IsClustered() {
+		return errors.NotSupportedf("placing containers on cluster members on this remote")
+	}
+	if imageRemote != client.remote {
+		// Images are shared between cluster members, so the
+		// image must already have been copied to the cluster.
+		return errors.NotSupportedf("placing containers from remote %q images", imageRemote)
+	}
+	var profileNames []string
+	if profiles != nil {
+		profileNames = *profiles
+	}
+	err := client.cluster.initOnMember(
+		spec.Target, spec.Name, spec.Image, profileNames, config, devices, spec.Ephemeral,
+	)
+	return errors.Trace(err)
+}
+
 func (client *instanceClient) startInstance(spec InstanceSpec) error {
 	timeout := -1
 	force := false
@@ -144,6 +176,36 @@ func (client *instanceClient) Instance(name string) (*Instance, error) {
 	return inst, nil
 }
 
+// ReplaceProfiles removes the named profiles from the instance and
+// appends the added ones, returning the profiles the instance now has.
+func (client *instanceClient) ReplaceProfiles(name string, remove, add []string) ([]string, error) {
+	info, err := client.raw.ContainerInfo(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	put := info.ContainerPut
+	removed := make(map[string]bool)
+	for _, profile := range remove {
+		removed[profile] = true
+	}
+	profiles := make([]string, 0, len(put.Profiles)+len(add))
+	for _, profile := range put.Profiles {
+		if !removed[profile] {
+			profiles = append(profiles, profile)
+		}
+	}
+	for _, profile := range add {
+		if !shared.StringInSlice(profile, profiles) {
+			profiles = append(profiles, profile)
+		}
+	}
+	put.Profiles = profiles
+	if err := client.raw.UpdateContainerConfig(name, put); err != nil {
+		return nil, errors.Annotatef(err, "updating profiles of %q", name)
+	}
+	return profiles, nil
+}
+
 func (client *instanceClient) Status(name string) (string, error) {
 	info, err := client.raw.ContainerInfo(name)
 	if err != nil {
@@ -256,3 +256,119 @@ func (s *devicesSuite) TestRemoveDeviceAsyncError(c *gc.C) {
 	err := client.RemoveDevice("instance", "device")
 	c.Assert(err, gc.ErrorMatches, "async error")
 }
+
+type profilesSuite struct {
+	lxdclient.BaseSuite
+}
+
+var _ = gc.Suite(&profilesSuite{})
+
+func (s *profilesSuite) TestReplaceProfiles(c *gc.C) {
+	s.Client.Info = &lxdapi.Container{
+		ContainerPut: lxdapi.ContainerPut{
+			Profiles: []string{"default", "juju-model", "juju-model-app-1"},
+		},
+	}
+	client := lxdclient.NewInstanceClient(s.Client)
+	profiles, err := client.ReplaceProfiles("instance", []string{"juju-model-app-1"}, []string{"juju-model-app-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profiles, jc.DeepEquals, []string{"default", "juju-model", "juju-model-app-2"})
+
+	s.Stub.CheckCalls(c, []testing.StubCall{
+		{"ContainerInfo", []interface{}{"instance"}},
+		{"UpdateContainerConfig", []interface{}{"instance", lxdapi.ContainerPut{
+			Profiles: []string{"default", "juju-model", "juju-model-app-2"},
+		}}},
+	})
+}
+
+func (s *profilesSuite) TestReplaceProfilesError(c *gc.C) {
+	s.Stub.SetErrors(nil, errors.New("boom"))
+	client := lxdclient.NewInstanceClient(s.Client)
+	_, err := client.ReplaceProfiles("instance", nil, []string{"juju-model-app-2"})
+	c.Assert(err, gc.ErrorMatches, `updating profiles of "instance": boom`)
+}
+
+func (s *profilesSuite) TestEnsureProfile(c *gc.C) {
+	raw := &profileTester{}
+	client := lxdclient.NewProfileClient(raw)
+	config := map[string]string{"security.nesting": "true"}
+	devices := map[string]map[string]string{"tun": {"type": "unix-char", "path": "/dev/net/tun"}}
+	err := client.EnsureProfile("juju-model-app-1", "app profile", config, devices)
+	c.Assert(err, jc.ErrorIsNil)
+
+	raw.CheckCalls(c, []testing.StubCall{
+		{"ListProfiles", nil},
+		{"ProfileCreate", []interface{}{"juju-model-app-1"}},
+		{"PutProfile", []interface{}{"juju-model-app-1", lxdapi.ProfilePut{
+			Config:      config,
+			Description: "app profile",
+			Devices:     devices,
+		}}},
+	})
+}
+
+func (s *profilesSuite) TestEnsureProfileExists(c *gc.C) {
+	raw := &profileTester{profiles: []lxdapi.Profile{{Name: "juju-model-app-1"}}}
+	client := lxdclient.NewProfileClient(raw)
+	err := client.EnsureProfile("juju-model-app-1", "", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	raw.CheckCallNames(c, "ListProfiles")
+}
+
+func (s *profilesSuite) TestDeleteProfileIfUnused(c *gc.C) {
+	raw := &profileTester{profiles: []lxdapi.Profile{{Name: "juju-model-app-1"}}}
+	client := lxdclient.NewProfileClient(raw)
+	err := client.DeleteProfileIfUnused("juju-model-app-1")
+	c.Assert(err, jc.ErrorIsNil)
+	raw.CheckCalls(c, []testing.StubCall{
+		{"ListProfiles", nil},
+		{"ProfileDelete", []interface{}{"juju-model-app-1"}},
+	})
+}
+
+func (s *profilesSuite) TestDeleteProfileIfUnusedInUse(c *gc.C) {
+	raw := &profileTester{profiles: []lxdapi.Profile{{
+		Name:   "juju-model-app-1",
+		UsedBy: []string{"/1.0/containers/juju-machine-1-lxd-0"},
+	}}}
+	client := lxdclient.NewProfileClient(raw)
+	err := client.DeleteProfileIfUnused("juju-model-app-1")
+	c.Assert(err, jc.ErrorIsNil)
+	raw.CheckCallNames(c, "ListProfiles")
+}
+
+func (s *profilesSuite) TestDeleteProfileIfUnusedNotFound(c *gc.C) {
+	raw := &profileTester{}
+	client := lxdclient.NewProfileClient(raw)
+	err := client.DeleteProfileIfUnused("juju-model-app-1")
+	c.Assert(err, jc.ErrorIsNil)
+	raw.CheckCallNames(c, "ListProfiles")
+}
+
+type profileTester struct {
+	lxdclient.RawProfileClient
+	testing.Stub
+
+	profiles []lxdapi.Profile
+}
+
+func (p *profileTester) ListProfiles() ([]lxdapi.Profile, error) {
+	p.AddCall("ListProfiles")
+	return p.profiles, p.NextErr()
+}
+
+func (p *profileTester) ProfileCreate(name string) error {
+	p.AddCall("ProfileCreate", name)
+	return p.NextErr()
+}
+
+func (p *profileTester) ProfileDelete(name string) error {
+	p.AddCall("ProfileDelete", name)
+	return p.NextErr()
+}
+
+func (p *profileTester) PutProfile(name string, profile lxdapi.ProfilePut) error {
+	p.AddCall("PutProfile", name, profile)
+	return p.NextErr()
+}
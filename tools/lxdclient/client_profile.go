@@ -18,6 +18,7 @@ type rawProfileClient interface {
 	ProfileDelete(profile string) error
 	ProfileDeviceAdd(profile, devname, devtype string, props []string) (*api.Response, error)
 	ProfileConfig(profile string) (*api.Profile, error)
+	PutProfile(name string, profile api.ProfilePut) error
 }
 
 type profileClient struct {
@@ -59,6 +60,53 @@ func (p profileClient) CreateProfile(name string, config map[string]string) erro
 	return nil
 }
 
+// EnsureProfile creates a profile with the given description, config
+// and devices, if a profile with that name does not already exist.
+func (p profileClient) EnsureProfile(name, description string, config map[string]string, devices map[string]map[string]string) error {
+	exists, err := p.HasProfile(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exists {
+		return nil
+	}
+	if err := p.raw.ProfileCreate(name); err != nil {
+		return errors.Trace(err)
+	}
+	put := api.ProfilePut{
+		Config:      config,
+		Description: description,
+		Devices:     devices,
+	}
+	if err := p.raw.PutProfile(name, put); err != nil {
+		return errors.Annotatef(err, "writing profile %q", name)
+	}
+	return nil
+}
+
+// DeleteProfileIfUnused deletes the named profile, unless it is still
+// used by any instances. It is not an error for the profile not to
+// exist.
+func (p profileClient) DeleteProfileIfUnused(name string) error {
+	profiles, err := p.raw.ListProfiles()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, profile := range profiles {
+		if profile.Name != name {
+			continue
+		}
+		if len(profile.UsedBy) > 0 {
+			return nil
+		}
+		if err := p.raw.ProfileDelete(name); err != nil {
+			return errors.Annotatef(err, "deleting profile %q", name)
+		}
+		return nil
+	}
+	return nil
+}
+
 // HasProfile returns true/false if the profile exists.
 func (p profileClient) HasProfile(name string) (bool, error) {
 	profiles, err := p.raw.ListProfiles()
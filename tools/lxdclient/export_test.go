@@ -14,6 +14,8 @@ var NewInstanceSummary = newInstanceSummary
 type (
 	RawInstanceClient rawInstanceClient
 	RawStorageClient  rawStorageClient
+	RawClusterClient  rawClusterClient
+	RawProfileClient  rawProfileClient
 )
 
 func NewInstanceClient(raw RawInstanceClient) *instanceClient {
@@ -23,6 +25,10 @@ func NewInstanceClient(raw RawInstanceClient) *instanceClient {
 	}
 }
 
+func NewProfileClient(raw RawProfileClient) profileClient {
+	return profileClient{raw: raw}
+}
+
 func NewStorageClient(raw RawStorageClient, supported bool) *storageClient {
 	return &storageClient{
 		raw:       raw,
@@ -30,6 +36,25 @@ func NewStorageClient(raw RawStorageClient, supported bool) *storageClient {
 	}
 }
 
+func NewClusterClient(raw RawClusterClient, clustered bool) *clusterClient {
+	return &clusterClient{
+		raw:       raw,
+		clustered: clustered,
+	}
+}
+
+func NewClusteredInstanceClient(raw RawInstanceClient, cluster RawClusterClient) *instanceClient {
+	return &instanceClient{
+		raw:     rawInstanceClient(raw),
+		remote:  "",
+		cluster: NewClusterClient(cluster, true),
+	}
+}
+
+func IsClusterEnabled(raw RawClusterClient) (bool, error) {
+	return isClusterEnabled(raw)
+}
+
 func PatchGenerateCertificate(s *testing.CleanupSuite, cert, key string) {
 	s.PatchValue(&generateCertificate, func() ([]byte, []byte, error) {
 		return []byte(cert), []byte(key), nil
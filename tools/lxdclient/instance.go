@@ -106,6 +106,10 @@ type InstanceSpec struct {
 	// Devices to be added at container initialisation time.
 	Devices
 
+	// Target is the name of the cluster member on which to create
+	// the instance. If empty, LXD chooses the member.
+	Target string
+
 	// TODO(ericsnow) Other possible fields:
 	// Disks
 	// Networks
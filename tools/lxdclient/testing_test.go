@@ -49,6 +49,7 @@ type stubClient struct {
 
 	Instance   *api.ContainerState
 	Instances  []api.Container
+	Info       *api.Container
 	ReturnCode int
 	Response   *api.Response
 	Aliases    map[string]string
@@ -172,9 +173,17 @@ func (s *stubClient) ContainerInfo(name string) (*api.Container, error) {
 	if err := s.stub.NextErr(); err != nil {
 		return nil, err
 	}
+	if s.Info != nil {
+		return s.Info, nil
+	}
 	return &api.Container{}, nil
 }
 
+func (s *stubClient) UpdateContainerConfig(container string, st api.ContainerPut) error {
+	s.stub.AddCall("UpdateContainerConfig", container, st)
+	return s.stub.NextErr()
+}
+
 func (s *stubClient) PushFile(container, path string, gid int, uid int, mode string, buf io.ReadSeeker) error {
 	s.stub.AddCall("PushFile", container, path, gid, uid, mode, buf)
 	if err := s.stub.NextErr(); err != nil {
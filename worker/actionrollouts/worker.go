@@ -0,0 +1,92 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollouts
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// period is the longest time the worker waits before advancing the
+// rollouts again. Nothing in state changes when a rollout's pause
+// between batches runs out, so the watcher alone can't be relied on
+// to start the next batch.
+const period = 10 * time.Second
+
+var logger = loggo.GetLogger("juju.worker.actionrollouts")
+
+// Facade defines the API methods used by the worker.
+type Facade interface {
+	AdvanceActionRollouts() error
+	WatchActionRollouts() (watcher.NotifyWatcher, error)
+}
+
+// Worker advances the model's action rollouts as their actions finish.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	facade   Facade
+	watcher  watcher.NotifyWatcher
+	clock    clock.Clock
+}
+
+// NewWorker returns a worker that advances action rollouts whenever
+// they change, and periodically.
+func NewWorker(facade Facade, clock clock.Clock) (worker.Worker, error) {
+	watcher, err := facade.WatchActionRollouts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		facade:  facade,
+		watcher: watcher,
+		clock:   clock,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+		Init: []worker.Worker{watcher},
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *Worker) loop() error {
+	timer := w.clock.NewTimer(period)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-w.watcher.Changes():
+			if !ok {
+				return errors.New("change channel closed")
+			}
+		case <-timer.Chan():
+		}
+		if err := w.facade.AdvanceActionRollouts(); err != nil {
+			// A rollout that can't be advanced now is
+			// retried when the timer next fires.
+			logger.Errorf("cannot advance action rollouts: %v", err)
+		}
+		timer.Reset(period)
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
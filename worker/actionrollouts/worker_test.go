@@ -0,0 +1,120 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollouts_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	worker "gopkg.in/juju/worker.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/watcher/watchertest"
+	"github.com/juju/juju/worker/actionrollouts"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	facade *mockFacade
+	clock  *testing.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &mockFacade{
+		calls:   make(chan string, 10),
+		changes: make(chan struct{}, 1),
+	}
+	s.facade.changes <- struct{}{}
+	s.clock = testing.NewClock(time.Time{})
+}
+
+func (s *WorkerSuite) assertCalled(c *gc.C, expect string) {
+	select {
+	case call := <-s.facade.calls:
+		c.Assert(call, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %s", expect)
+	}
+}
+
+func (s *WorkerSuite) assertNotCalled(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Fatalf("unexpected %s", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestAdvancesOnChange(c *gc.C) {
+	w, err := actionrollouts.NewWorker(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.assertCalled(c, "WatchActionRollouts")
+	s.assertCalled(c, "AdvanceActionRollouts")
+	s.assertNotCalled(c)
+
+	s.facade.changes <- struct{}{}
+	s.assertCalled(c, "AdvanceActionRollouts")
+	s.assertNotCalled(c)
+}
+
+func (s *WorkerSuite) TestAdvancesPeriodically(c *gc.C) {
+	w, err := actionrollouts.NewWorker(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.assertCalled(c, "WatchActionRollouts")
+	s.assertCalled(c, "AdvanceActionRollouts")
+
+	s.clock.WaitAdvance(9*time.Second, coretesting.LongWait, 1)
+	s.assertNotCalled(c)
+	s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	s.assertCalled(c, "AdvanceActionRollouts")
+}
+
+func (s *WorkerSuite) TestAdvanceErrorNotFatal(c *gc.C) {
+	s.facade.advanceErr = errors.New("boom")
+	w, err := actionrollouts.NewWorker(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertCalled(c, "WatchActionRollouts")
+	s.assertCalled(c, "AdvanceActionRollouts")
+	err = worker.Stop(w)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains, "cannot advance action rollouts: boom")
+}
+
+func (s *WorkerSuite) TestWatchError(c *gc.C) {
+	s.facade.watchErr = errors.New("boom")
+	_, err := actionrollouts.NewWorker(s.facade, s.clock)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockFacade struct {
+	calls      chan string
+	changes    chan struct{}
+	advanceErr error
+	watchErr   error
+}
+
+func (f *mockFacade) AdvanceActionRollouts() error {
+	f.calls <- "AdvanceActionRollouts"
+	return f.advanceErr
+}
+
+func (f *mockFacade) WatchActionRollouts() (watcher.NotifyWatcher, error) {
+	f.calls <- "WatchActionRollouts"
+	if f.watchErr != nil {
+		return nil, f.watchErr
+	}
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionschedules
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// period is the longest time the worker waits before running the
+// schedules again, so that it keeps up if the controller's clock is
+// changed.
+const period = time.Minute
+
+var logger = loggo.GetLogger("juju.worker.actionschedules")
+
+// Facade defines the API methods used by the worker.
+type Facade interface {
+	RunActionSchedules() (time.Time, error)
+	WatchActionSchedules() (watcher.NotifyWatcher, error)
+}
+
+// Worker enqueues the actions of the model's action schedules as they
+// fall due.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	facade   Facade
+	watcher  watcher.NotifyWatcher
+	clock    clock.Clock
+}
+
+// NewWorker returns a worker that runs action schedules when they fall
+// due.
+func NewWorker(facade Facade, clock clock.Clock) (worker.Worker, error) {
+	watcher, err := facade.WatchActionSchedules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		facade:  facade,
+		watcher: watcher,
+		clock:   clock,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+		Init: []worker.Worker{watcher},
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *Worker) loop() error {
+	timer := w.clock.NewTimer(period)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-w.watcher.Changes():
+			if !ok {
+				return errors.New("change channel closed")
+			}
+		case <-timer.Chan():
+		}
+		next, err := w.facade.RunActionSchedules()
+		if err != nil {
+			// Schedules that couldn't be run now are
+			// retried when the timer next fires.
+			logger.Errorf("cannot run action schedules: %v", err)
+			next = time.Time{}
+		}
+		timer.Reset(w.delay(next))
+	}
+}
+
+// delay returns the time to wait until next, but no more than period.
+func (w *Worker) delay(next time.Time) time.Duration {
+	if next.IsZero() {
+		return period
+	}
+	delay := next.Sub(w.clock.Now())
+	switch {
+	case delay < 0:
+		return 0
+	case delay > period:
+		return period
+	}
+	return delay
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
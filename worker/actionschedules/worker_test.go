@@ -0,0 +1,137 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionschedules_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	worker "gopkg.in/juju/worker.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/watcher/watchertest"
+	"github.com/juju/juju/worker/actionschedules"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	facade *mockFacade
+	clock  *testing.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &mockFacade{
+		calls:   make(chan string, 10),
+		changes: make(chan struct{}, 1),
+	}
+	s.facade.changes <- struct{}{}
+	s.clock = testing.NewClock(time.Date(2018, time.March, 1, 10, 0, 0, 0, time.UTC))
+}
+
+func (s *WorkerSuite) assertCalled(c *gc.C, expect string) {
+	select {
+	case call := <-s.facade.calls:
+		c.Assert(call, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %s", expect)
+	}
+}
+
+func (s *WorkerSuite) assertNotCalled(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Fatalf("unexpected %s", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestRunsOnChange(c *gc.C) {
+	w, err := actionschedules.NewWorker(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.assertCalled(c, "WatchActionSchedules")
+	s.assertCalled(c, "RunActionSchedules")
+	s.assertNotCalled(c)
+
+	s.facade.changes <- struct{}{}
+	s.assertCalled(c, "RunActionSchedules")
+	s.assertNotCalled(c)
+}
+
+func (s *WorkerSuite) TestRunsWhenDue(c *gc.C) {
+	s.facade.next = s.clock.Now().Add(10 * time.Second)
+	w, err := actionschedules.NewWorker(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.assertCalled(c, "WatchActionSchedules")
+	s.assertCalled(c, "RunActionSchedules")
+
+	s.clock.WaitAdvance(9*time.Second, coretesting.LongWait, 1)
+	s.assertNotCalled(c)
+	s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	s.assertCalled(c, "RunActionSchedules")
+}
+
+func (s *WorkerSuite) TestRunsPeriodically(c *gc.C) {
+	s.facade.next = s.clock.Now().Add(time.Hour)
+	w, err := actionschedules.NewWorker(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.assertCalled(c, "WatchActionSchedules")
+	s.assertCalled(c, "RunActionSchedules")
+
+	s.clock.WaitAdvance(59*time.Second, coretesting.LongWait, 1)
+	s.assertNotCalled(c)
+	s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	s.assertCalled(c, "RunActionSchedules")
+}
+
+func (s *WorkerSuite) TestRunErrorNotFatal(c *gc.C) {
+	s.facade.runErr = errors.New("boom")
+	w, err := actionschedules.NewWorker(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertCalled(c, "WatchActionSchedules")
+	s.assertCalled(c, "RunActionSchedules")
+	err = worker.Stop(w)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains, "cannot run action schedules: boom")
+}
+
+func (s *WorkerSuite) TestWatchError(c *gc.C) {
+	s.facade.watchErr = errors.New("boom")
+	_, err := actionschedules.NewWorker(s.facade, s.clock)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockFacade struct {
+	calls    chan string
+	changes  chan struct{}
+	next     time.Time
+	runErr   error
+	watchErr error
+}
+
+func (f *mockFacade) RunActionSchedules() (time.Time, error) {
+	f.calls <- "RunActionSchedules"
+	return f.next, f.runErr
+}
+
+func (f *mockFacade) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	f.calls <- "WatchActionSchedules"
+	if f.watchErr != nil {
+		return nil, f.watchErr
+	}
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
@@ -18,11 +18,23 @@ import (
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/state"
 )
 
 var logger = loggo.GetLogger("juju.worker.apiserver")
 
+// These values control the rotation of the API server's audit log.
+const (
+	auditLogMaxSizeMB  = 300
+	auditLogMaxBackups = 10
+)
+
+// tracingServiceName is the service name attached to the traces
+// that the API server sends to the configured tracing endpoint.
+const tracingServiceName = "juju-apiserver"
+
 // Config is the configuration required for running an API server worker.
 type Config struct {
 	AgentConfig                       agent.Config
@@ -131,10 +143,41 @@ func NewWorker(config Config) (worker.Worker, error) {
 		RateLimitConfig:               rateLimitConfig,
 		LogSinkConfig:                 &logSinkConfig,
 		PrometheusRegisterer:          config.PrometheusRegisterer,
+		MetricsPassword:               controllerConfig.MetricsPassword(),
+	}
+	// Once started, the server closes the audit log and tracer
+	// when it stops; until then, we must close them ourselves.
+	closeLogs := func() {
+		if serverConfig.AuditLog != nil {
+			if err := serverConfig.AuditLog.Close(); err != nil {
+				logger.Warningf("failed to close audit log: %s", err)
+			}
+		}
+		if serverConfig.Tracer != nil {
+			if err := serverConfig.Tracer.Close(); err != nil {
+				logger.Warningf("failed to close tracer: %s", err)
+			}
+		}
+	}
+	if controllerConfig.AuditingEnabled() {
+		serverConfig.AuditLog = auditlog.NewLogFile(logDir, auditLogMaxSizeMB, auditLogMaxBackups)
+	}
+	if endpoint := controllerConfig.TracingEndpoint(); endpoint != "" {
+		exporter, err := tracing.NewOTLPExporter(tracing.OTLPExporterConfig{
+			Endpoint:    endpoint,
+			ServiceName: tracingServiceName,
+			Clock:       config.Clock,
+		})
+		if err != nil {
+			closeLogs()
+			return nil, errors.Annotate(err, "cannot create tracing exporter")
+		}
+		serverConfig.Tracer = tracing.NewTracer(exporter, config.Clock)
 	}
 
 	listener, err := net.Listen("tcp", listenAddr)
 	if err != nil {
+		closeLogs()
 		return nil, errors.Trace(err)
 	}
 	server, err := config.NewServer(config.StatePool, listener, serverConfig)
@@ -142,6 +185,7 @@ func NewWorker(config Config) (worker.Worker, error) {
 		if err := listener.Close(); err != nil {
 			logger.Warningf("failed to close listener: %s", err)
 		}
+		closeLogs()
 		return nil, errors.Trace(err)
 	}
 	return server, nil
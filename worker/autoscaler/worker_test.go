@@ -0,0 +1,100 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscaler_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	worker "gopkg.in/juju/worker.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/autoscaler"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	facade *mockFacade
+	clock  *testing.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &mockFacade{calls: make(chan string, 10)}
+	s.clock = testing.NewClock(time.Date(2018, time.March, 1, 10, 0, 0, 0, time.UTC))
+}
+
+func (s *WorkerSuite) assertCalled(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Assert(call, gc.Equals, "Autoscale")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for Autoscale")
+	}
+}
+
+func (s *WorkerSuite) assertNotCalled(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Fatalf("unexpected %s", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestRunsWhenDue(c *gc.C) {
+	s.facade.next = s.clock.Now().Add(10 * time.Second)
+	w, err := autoscaler.NewWorker(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	s.assertCalled(c)
+
+	s.clock.WaitAdvance(9*time.Second, coretesting.LongWait, 1)
+	s.assertNotCalled(c)
+	s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	s.assertCalled(c)
+}
+
+func (s *WorkerSuite) TestRunsPeriodically(c *gc.C) {
+	w, err := autoscaler.NewWorker(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	s.assertCalled(c)
+
+	s.clock.WaitAdvance(59*time.Second, coretesting.LongWait, 1)
+	s.assertNotCalled(c)
+	s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	s.assertCalled(c)
+}
+
+func (s *WorkerSuite) TestAutoscaleErrorNotFatal(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := autoscaler.NewWorker(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	s.assertCalled(c)
+	err = worker.Stop(w)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains, "cannot autoscale applications: boom")
+}
+
+type mockFacade struct {
+	calls chan string
+	next  time.Time
+	err   error
+}
+
+func (f *mockFacade) Autoscale() (time.Time, error) {
+	f.calls <- "Autoscale"
+	return f.next, f.err
+}
@@ -42,6 +42,10 @@ type EngineConfig struct {
 	// a worker that was deliberately stopped because its dependencies
 	// changed. It must not be negative.
 	BounceDelay time.Duration
+
+	// Metrics, if not nil, is updated as the engine's manifold workers
+	// start and stop.
+	Metrics *Metrics
 }
 
 // Validate returns an error if any field is invalid.
@@ -76,6 +80,7 @@ func NewEngine(config EngineConfig) (*Engine, error) {
 		manifolds:  Manifolds{},
 		dependents: map[string][]string{},
 		current:    map[string]workerInfo{},
+		starts:     map[string]int{},
 
 		install: make(chan installTicket),
 		started: make(chan startedTicket),
@@ -117,6 +122,10 @@ type Engine struct {
 	// current holds the active worker information for each installed manifold.
 	current map[string]workerInfo
 
+	// starts holds, for each installed manifold, the number of times
+	// its worker has been started.
+	starts map[string]int
+
 	// install, started, report and stopped each communicate requests and changes into
 	// the loop goroutine.
 	install chan installTicket
@@ -230,10 +239,17 @@ func (engine *Engine) liveReport() map[string]interface{} {
 func (engine *Engine) manifoldsReport() map[string]interface{} {
 	manifolds := map[string]interface{}{}
 	for name, info := range engine.current {
+		starts := engine.starts[name]
+		restarts := 0
+		if starts > 1 {
+			restarts = starts - 1
+		}
 		report := map[string]interface{}{
-			KeyState:       info.state(),
-			KeyInputs:      engine.manifolds[name].Inputs,
-			KeyResourceLog: resourceLogReport(info.resourceLog),
+			KeyState:        info.state(),
+			KeyInputs:       engine.manifolds[name].Inputs,
+			KeyResourceLog:  resourceLogReport(info.resourceLog),
+			KeyStartCount:   starts,
+			KeyRestartCount: restarts,
 		}
 		if info.err != nil {
 			report[KeyError] = info.err.Error()
@@ -292,6 +308,10 @@ func (engine *Engine) uninstall(name string) {
 	}
 	delete(engine.current, name)
 	delete(engine.manifolds, name)
+	delete(engine.starts, name)
+	if engine.config.Metrics != nil {
+		engine.config.Metrics.uninstalled(name)
+	}
 }
 
 // checkAcyclic returns an error if the introduction of the supplied manifold
@@ -331,6 +351,7 @@ func (engine *Engine) requestStart(name string, delay time.Duration) {
 	info.starting = true
 	info.abort = make(chan struct{})
 	engine.current[name] = info
+	engine.recordState(name)
 	context := engine.context(name, manifold.Inputs, info.abort)
 
 	// Always fuzz the delay a bit to help randomise the order of workers starting,
@@ -488,6 +509,11 @@ func (engine *Engine) gotStarted(name string, worker worker.Worker, resourceLog
 			worker:      worker,
 			resourceLog: resourceLog,
 		}
+		engine.starts[name]++
+		if engine.config.Metrics != nil {
+			engine.config.Metrics.workerStarted(name)
+		}
+		engine.recordState(name)
 
 		// Any manifold that declares this one as an input needs to be restarted.
 		engine.bounceDependents(name)
@@ -520,6 +546,7 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 		err:         err,
 		resourceLog: resourceLog,
 	}
+	engine.recordState(name)
 	if engine.isDying() {
 		logger.Tracef("permanently stopped %q manifold worker (shutting down)", name)
 		return
@@ -549,6 +576,9 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 		default:
 			// Something went wrong but we don't know what. Try again soon.
 			logger.Errorf("%q manifold worker returned unexpected error: %v", name, err)
+			if engine.config.Metrics != nil {
+				engine.config.Metrics.workerFailed(name)
+			}
 			if tracer, ok := err.(stackTracer); ok {
 				logger.Debugf("stack trace:\n%s", strings.Join(tracer.StackTrace(), "\n"))
 			}
@@ -583,6 +613,17 @@ func (engine *Engine) requestStop(name string) {
 		info.worker.Kill()
 	}
 	engine.current[name] = info
+	engine.recordState(name)
+}
+
+// recordState updates the engine's metrics, if any, with the current
+// state of the named manifold's worker. It must only be called from the
+// loop goroutine.
+func (engine *Engine) recordState(name string) {
+	if engine.config.Metrics == nil {
+		return
+	}
+	engine.config.Metrics.setState(name, engine.current[name].state())
 }
 
 // isDying returns true if the engine is shutting down. It's safe to call it
@@ -0,0 +1,161 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dependency
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace = "juju_engine"
+
+	manifoldLabel = "manifold"
+	stateLabel    = "state"
+)
+
+// workerStates holds the values reported by workerInfo.state; each
+// manifold has a state gauge for each, exactly one of which is set.
+var workerStates = []string{"starting", "started", "stopping", "stopped"}
+
+// Metrics is a prometheus.Collector that exposes the activity of the
+// manifolds run by an Engine: how often their workers start, restart
+// and fail, what state they are in now, and how long it has been since
+// each last started. A manifold whose worker is restarting in a loop
+// shows up as a steadily increasing restart count, with a time since
+// start that never grows large.
+//
+// A single Metrics may outlive, and be shared by, successive engines
+// that run the same manifolds; the counters accumulate across them.
+type Metrics struct {
+	clock clock.Clock
+
+	starts   *prometheus.CounterVec
+	restarts *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	states   *prometheus.GaugeVec
+
+	sinceStartDesc *prometheus.Desc
+
+	mu        sync.Mutex
+	lastStart map[string]time.Time
+}
+
+// NewMetrics returns a new Metrics, which uses the given clock to
+// measure the time since each manifold's worker last started.
+func NewMetrics(clock clock.Clock) *Metrics {
+	return &Metrics{
+		clock: clock,
+		starts: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "manifold_starts_total",
+				Help:      "Number of times a manifold's worker has started.",
+			},
+			[]string{manifoldLabel},
+		),
+		restarts: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "manifold_restarts_total",
+				Help:      "Number of times a manifold's worker has started after its first start.",
+			},
+			[]string{manifoldLabel},
+		),
+		errors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "manifold_errors_total",
+				Help:      "Number of times a manifold's worker, or its start func, has failed with an unexpected error.",
+			},
+			[]string{manifoldLabel},
+		),
+		states: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "manifold_state",
+				Help:      "Current state of a manifold's worker; 1 for the current state, 0 otherwise.",
+			},
+			[]string{manifoldLabel, stateLabel},
+		),
+		sinceStartDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "manifold_seconds_since_start"),
+			"Time since a manifold's worker last started.",
+			[]string{manifoldLabel},
+			nil,
+		),
+		lastStart: make(map[string]time.Time),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.starts.Describe(ch)
+	m.restarts.Describe(ch)
+	m.errors.Describe(ch)
+	m.states.Describe(ch)
+	ch <- m.sinceStartDesc
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.starts.Collect(ch)
+	m.restarts.Collect(ch)
+	m.errors.Collect(ch)
+	m.states.Collect(ch)
+
+	now := m.clock.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for name, started := range m.lastStart {
+		ch <- prometheus.MustNewConstMetric(
+			m.sinceStartDesc,
+			prometheus.GaugeValue,
+			now.Sub(started).Seconds(),
+			name,
+		)
+	}
+}
+
+// workerStarted records that the named manifold's worker has started.
+func (m *Metrics) workerStarted(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.lastStart[name]; ok {
+		m.restarts.WithLabelValues(name).Inc()
+	}
+	m.lastStart[name] = m.clock.Now()
+	m.starts.WithLabelValues(name).Inc()
+}
+
+// workerFailed records that the named manifold's worker has stopped,
+// or failed to start, with an unexpected error.
+func (m *Metrics) workerFailed(name string) {
+	m.errors.WithLabelValues(name).Inc()
+}
+
+// setState records the current state of the named manifold's worker.
+func (m *Metrics) setState(name, state string) {
+	for _, s := range workerStates {
+		var value float64
+		if s == state {
+			value = 1
+		}
+		m.states.WithLabelValues(name, s).Set(value)
+	}
+}
+
+// uninstalled removes the gauges for the named manifold. Its counters
+// are retained, so that rates computed over them remain correct.
+func (m *Metrics) uninstalled(name string) {
+	for _, s := range workerStates {
+		m.states.DeleteLabelValues(name, s)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.lastStart, name)
+}
@@ -0,0 +1,132 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dependency_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/dependency"
+)
+
+type MetricsSuite struct {
+	testing.IsolationSuite
+	clock   *testing.Clock
+	metrics *dependency.Metrics
+	fix     *engineFixture
+}
+
+var _ = gc.Suite(&MetricsSuite{})
+
+func (s *MetricsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(coretesting.NonZeroTime())
+	s.metrics = dependency.NewMetrics(s.clock)
+	s.fix = &engineFixture{metrics: s.metrics}
+}
+
+// gather returns the value of each metric collected, keyed by metric
+// name and then by the values of its labels, joined with ",".
+func (s *MetricsSuite) gather(c *gc.C) map[string]map[string]float64 {
+	registry := prometheus.NewRegistry()
+	err := registry.Register(s.metrics)
+	c.Assert(err, jc.ErrorIsNil)
+	families, err := registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := make(map[string]map[string]float64)
+	for _, family := range families {
+		values := make(map[string]float64)
+		for _, m := range family.Metric {
+			var key string
+			for i, label := range m.Label {
+				if i > 0 {
+					key += ","
+				}
+				key += label.GetValue()
+			}
+			values[key] = metricValue(m)
+		}
+		result[family.GetName()] = values
+	}
+	return result
+}
+
+func metricValue(m *dto.Metric) float64 {
+	if m.Counter != nil {
+		return m.Counter.GetValue()
+	}
+	return m.Gauge.GetValue()
+}
+
+func (s *MetricsSuite) TestStartsAndRestarts(c *gc.C) {
+	s.fix.run(c, func(engine *dependency.Engine) {
+		mh := newManifoldHarness()
+		err := engine.Install("task", mh.Manifold())
+		c.Assert(err, jc.ErrorIsNil)
+		mh.AssertOneStart(c)
+
+		s.clock.Advance(time.Minute)
+		mh.InjectError(c, errors.New("ZAP"))
+		mh.AssertOneStart(c)
+		s.clock.Advance(time.Second)
+
+		metrics := s.gather(c)
+		c.Check(metrics["juju_engine_manifold_starts_total"], jc.DeepEquals, map[string]float64{"task": 2})
+		c.Check(metrics["juju_engine_manifold_restarts_total"], jc.DeepEquals, map[string]float64{"task": 1})
+		c.Check(metrics["juju_engine_manifold_errors_total"], jc.DeepEquals, map[string]float64{"task": 1})
+		c.Check(metrics["juju_engine_manifold_state"], jc.DeepEquals, map[string]float64{
+			"task,starting": 0,
+			"task,started":  1,
+			"task,stopping": 0,
+			"task,stopped":  0,
+		})
+		c.Check(metrics["juju_engine_manifold_seconds_since_start"], jc.DeepEquals, map[string]float64{"task": 1})
+
+		report := engine.Report()
+		task := report["manifolds"].(map[string]interface{})["task"].(map[string]interface{})
+		c.Check(task["start-count"], gc.Equals, 2)
+		c.Check(task["restart-count"], gc.Equals, 1)
+	})
+}
+
+func (s *MetricsSuite) TestBounceIsNotError(c *gc.C) {
+	s.fix.run(c, func(engine *dependency.Engine) {
+		mh := newManifoldHarness()
+		err := engine.Install("task", mh.Manifold())
+		c.Assert(err, jc.ErrorIsNil)
+		mh.AssertOneStart(c)
+
+		mh.InjectError(c, dependency.ErrBounce)
+		mh.AssertOneStart(c)
+
+		metrics := s.gather(c)
+		c.Check(metrics["juju_engine_manifold_restarts_total"], jc.DeepEquals, map[string]float64{"task": 1})
+		c.Check(metrics["juju_engine_manifold_errors_total"], gc.HasLen, 0)
+	})
+}
+
+func (s *MetricsSuite) TestUninstallRemovesGauges(c *gc.C) {
+	s.fix.run(c, func(engine *dependency.Engine) {
+		mh := newManifoldHarness()
+		err := engine.Install("task", mh.Manifold())
+		c.Assert(err, jc.ErrorIsNil)
+		mh.AssertOneStart(c)
+
+		mh.InjectError(c, dependency.ErrUninstall)
+		mh.AssertNoStart(c)
+
+		metrics := s.gather(c)
+		c.Check(metrics["juju_engine_manifold_starts_total"], jc.DeepEquals, map[string]float64{"task": 1})
+		c.Check(metrics["juju_engine_manifold_state"], gc.HasLen, 0)
+		c.Check(metrics["juju_engine_manifold_seconds_since_start"], gc.HasLen, 0)
+	})
+}
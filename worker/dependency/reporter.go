@@ -57,6 +57,15 @@ const (
 	// error encountered.
 	KeyResourceLog = "resource-log"
 
+	// KeyStartCount holds the number of times a manifold's worker has
+	// been started.
+	KeyStartCount = "start-count"
+
+	// KeyRestartCount holds the number of times a manifold's worker has
+	// been started after its first start. A count that keeps growing
+	// indicates a worker that is failing or being bounced repeatedly.
+	KeyRestartCount = "restart-count"
+
 	// KeyName holds the name of some resource.
 	KeyName = "name"
 
@@ -91,9 +91,11 @@ func (s *ReportSuite) TestReportStopping(c *gc.C) {
 			"state": "stopping",
 			"manifolds": map[string]interface{}{
 				"task": map[string]interface{}{
-					"state":        "stopping",
-					"inputs":       ([]string)(nil),
-					"resource-log": []map[string]interface{}{},
+					"state":         "stopping",
+					"inputs":        ([]string)(nil),
+					"resource-log":  []map[string]interface{}{},
+					"start-count":   1,
+					"restart-count": 0,
 					"report": map[string]interface{}{
 						"key1": "hello there",
 					},
@@ -120,16 +122,20 @@ func (s *ReportSuite) TestReportInputs(c *gc.C) {
 			"state": "started",
 			"manifolds": map[string]interface{}{
 				"task": map[string]interface{}{
-					"state":        "started",
-					"inputs":       ([]string)(nil),
-					"resource-log": []map[string]interface{}{},
+					"state":         "started",
+					"inputs":        ([]string)(nil),
+					"resource-log":  []map[string]interface{}{},
+					"start-count":   1,
+					"restart-count": 0,
 					"report": map[string]interface{}{
 						"key1": "hello there",
 					},
 				},
 				"another task": map[string]interface{}{
-					"state":  "started",
-					"inputs": []string{"task"},
+					"state":         "started",
+					"inputs":        []string{"task"},
+					"start-count":   1,
+					"restart-count": 0,
 					"resource-log": []map[string]interface{}{{
 						"name": "task",
 						"type": "<nil>",
@@ -157,9 +163,11 @@ func (s *ReportSuite) TestReportError(c *gc.C) {
 			"state": "stopped",
 			"manifolds": map[string]interface{}{
 				"task": map[string]interface{}{
-					"state":  "stopped",
-					"error":  `"missing" not running: dependency not available`,
-					"inputs": []string{"missing"},
+					"state":         "stopped",
+					"error":         `"missing" not running: dependency not available`,
+					"inputs":        []string{"missing"},
+					"start-count":   0,
+					"restart-count": 0,
 					"resource-log": []map[string]interface{}{{
 						"name":  "missing",
 						"type":  "<nil>",
@@ -20,6 +20,7 @@ type engineFixture struct {
 	isFatal    dependency.IsFatalFunc
 	worstError dependency.WorstErrorFunc
 	filter     dependency.FilterFunc
+	metrics    *dependency.Metrics
 	dirty      bool
 }
 
@@ -44,6 +45,7 @@ func (fix *engineFixture) run(c *gc.C, test func(*dependency.Engine)) {
 		Filter:      fix.filter, // can be nil anyway
 		ErrorDelay:  coretesting.ShortWait / 2,
 		BounceDelay: coretesting.ShortWait / 10,
+		Metrics:     fix.metrics, // can be nil anyway
 	}
 
 	engine, err := dependency.NewEngine(config)
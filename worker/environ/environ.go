@@ -8,6 +8,7 @@ import (
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
@@ -30,6 +31,11 @@ type ConfigObserver interface {
 type Config struct {
 	Observer       ConfigObserver
 	NewEnvironFunc environs.NewEnvironFunc
+
+	// Tracer, if not nil, is used by the workers that use the
+	// environ to trace their calls to it. The Tracker closes it
+	// when it stops.
+	Tracer *tracing.Tracer
 }
 
 // Validate returns an error if the config cannot be used to start a Tracker.
@@ -86,7 +92,20 @@ func (t *Tracker) Environ() environs.Environ {
 	return t.environ
 }
 
+// Tracer returns the tracer with which calls to the Environ should be
+// traced, or nil if tracing is disabled.
+func (t *Tracker) Tracer() *tracing.Tracer {
+	return t.config.Tracer
+}
+
 func (t *Tracker) loop() error {
+	if t.config.Tracer != nil {
+		defer func() {
+			if err := t.config.Tracer.Close(); err != nil {
+				logger.Warningf("failed to close tracer: %v", err)
+			}
+		}()
+	}
 	environWatcher, err := t.config.Observer.WatchForModelConfigChanges()
 	if err != nil {
 		return errors.Annotate(err, "cannot watch environ config")
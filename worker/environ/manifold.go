@@ -5,26 +5,36 @@ package environ
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/api/agent"
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker/dependency"
 )
 
+// tracingServiceName is the service name attached to the traces that
+// the model's workers send to the controller's tracing endpoint.
+const tracingServiceName = "juju-model-workers"
+
 // ManifoldConfig describes the resources used by a Tracker.
 type ManifoldConfig struct {
 	APICallerName  string
+	ClockName      string
 	NewEnvironFunc environs.NewEnvironFunc
 }
 
 // Manifold returns a Manifold that encapsulates a *Tracker and exposes it as
-// an environs.Environ resource.
+// an environs.Environ resource. It also exposes, as a *tracing.Tracer
+// resource, the tracer with which workers should trace their calls to the
+// environ; the tracer is nil unless the controller has a tracing endpoint.
 func Manifold(config ManifoldConfig) dependency.Manifold {
 	manifold := dependency.Manifold{
 		Inputs: []string{
 			config.APICallerName,
+			config.ClockName,
 		},
 		Output: manifoldOutput,
 		Start: func(context dependency.Context) (worker.Worker, error) {
@@ -32,15 +42,27 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			if err := context.Get(config.APICallerName, &apiCaller); err != nil {
 				return nil, errors.Trace(err)
 			}
+			var clock clock.Clock
+			if err := context.Get(config.ClockName, &clock); err != nil {
+				return nil, errors.Trace(err)
+			}
 			apiSt, err := agent.NewState(apiCaller)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
+			tracer, err := newTracer(apiSt, clock)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
 			w, err := NewTracker(Config{
 				Observer:       apiSt,
 				NewEnvironFunc: config.NewEnvironFunc,
+				Tracer:         tracer,
 			})
 			if err != nil {
+				if tracer != nil {
+					tracer.Close()
+				}
 				return nil, errors.Trace(err)
 			}
 			return w, nil
@@ -49,16 +71,58 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 	return manifold
 }
 
-// manifoldOutput extracts an environs.Environ resource from a *Tracker.
+// newTracer returns a tracer that sends traces to the controller's
+// tracing endpoint, or nil if it has none.
+func newTracer(apiSt *agent.State, clock clock.Clock) (*tracing.Tracer, error) {
+	controllerConfig, err := apiSt.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read controller config")
+	}
+	endpoint := controllerConfig.TracingEndpoint()
+	if endpoint == "" {
+		return nil, nil
+	}
+	exporter, err := tracing.NewOTLPExporter(tracing.OTLPExporterConfig{
+		Endpoint:    endpoint,
+		ServiceName: tracingServiceName,
+		Clock:       clock,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create tracing exporter")
+	}
+	return tracing.NewTracer(exporter, clock), nil
+}
+
+// manifoldOutput extracts an environs.Environ or *tracing.Tracer resource
+// from a *Tracker.
 func manifoldOutput(in worker.Worker, out interface{}) error {
 	inTracker, ok := in.(*Tracker)
 	if !ok {
 		return errors.Errorf("expected *environ.Tracker, got %T", in)
 	}
-	outEnviron, ok := out.(*environs.Environ)
-	if !ok {
-		return errors.Errorf("expected *environs.Environ, got %T", out)
+	switch out := out.(type) {
+	case *environs.Environ:
+		*out = inTracker.Environ()
+	case **tracing.Tracer:
+		*out = inTracker.Tracer()
+	default:
+		return errors.Errorf("expected *environs.Environ or **tracing.Tracer, got %T", out)
 	}
-	*outEnviron = inTracker.Environ()
 	return nil
 }
+
+// TracingEnviron returns env wrapped so that its calls are traced with
+// the tracer exposed by the environ tracker resource with the given
+// name, from which env was obtained. If the resource exposes no tracer,
+// env is returned unchanged.
+//
+// The wrapped Environ hides most optional provider interfaces, so
+// TracingEnviron must only be used by workers that do not check for
+// them; see environs.NewTracingEnviron.
+func TracingEnviron(context dependency.Context, name string, env environs.Environ) environs.Environ {
+	var tracer *tracing.Tracer
+	if err := context.Get(name, &tracer); err != nil || tracer == nil {
+		return env
+	}
+	return environs.NewTracingEnviron(tracing.NewContext(tracer), env)
+}
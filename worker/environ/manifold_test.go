@@ -0,0 +1,65 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environ_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/tracing"
+	"github.com/juju/juju/core/tracing/tracingtest"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	coretesting "github.com/juju/juju/testing"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/environ"
+)
+
+type TracingEnvironSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&TracingEnvironSuite{})
+
+func (s *TracingEnvironSuite) TestNoTracer(c *gc.C) {
+	env := &stoppingEnviron{}
+	context := dt.StubContext(nil, map[string]interface{}{
+		"environ": env,
+	})
+	c.Assert(environ.TracingEnviron(context, "environ", env), gc.Equals, env)
+}
+
+func (s *TracingEnvironSuite) TestNilTracer(c *gc.C) {
+	env := &stoppingEnviron{}
+	context := dt.StubContext(nil, map[string]interface{}{
+		"environ": (*tracing.Tracer)(nil),
+	})
+	c.Assert(environ.TracingEnviron(context, "environ", env), gc.Equals, env)
+}
+
+func (s *TracingEnvironSuite) TestTracer(c *gc.C) {
+	exporter := &tracingtest.Exporter{}
+	env := &stoppingEnviron{}
+	context := dt.StubContext(nil, map[string]interface{}{
+		"environ": tracing.NewTracer(exporter, clock.WallClock),
+	})
+	traced := environ.TracingEnviron(context, "environ", env)
+	c.Assert(traced, gc.Not(gc.Equals), env)
+
+	err := traced.StopInstances("i-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.stopped, jc.DeepEquals, []instance.Id{"i-0"})
+	c.Assert(exporter.SpanNames(), jc.DeepEquals, []string{"environs.StopInstances"})
+}
+
+type stoppingEnviron struct {
+	environs.Environ
+	stopped []instance.Id
+}
+
+func (e *stoppingEnviron) StopInstances(ids ...instance.Id) error {
+	e.stopped = append(e.stopped, ids...)
+	return nil
+}
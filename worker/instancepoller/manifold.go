@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/api/instancepoller"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker/dependency"
+	workerenviron "github.com/juju/juju/worker/environ"
 )
 
 // ManifoldConfig describes the resources used by the instancepoller worker.
@@ -33,6 +34,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	if err := context.Get(config.EnvironName, &environ); err != nil {
 		return nil, errors.Trace(err)
 	}
+	environ = workerenviron.TracingEnviron(context, config.EnvironName, environ)
 
 	var apiCaller base.APICaller
 	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
@@ -12,6 +12,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
@@ -259,6 +260,12 @@ func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo,
 		return instanceInfo{}, errors.Annotate(err, "cannot get machine's instance id")
 	}
 	instInfo, err = context.instanceInfo(instId)
+	if errors.IsNotFound(err) || errors.Cause(err) == environs.ErrNoInstances {
+		// The cloud no longer knows about the instance. Record that
+		// on the machine so that it may be replaced, but leave its
+		// addresses alone.
+		return instanceInfo{}, setMissingInstanceStatus(m, instId)
+	}
 	if err != nil {
 		// TODO (anastasiamac 2016-02-01) This does not look like it needs to be removed now.
 		if params.IsCodeNotImplemented(err) {
@@ -303,6 +310,25 @@ func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo,
 	return instInfo, nil
 }
 
+// setMissingInstanceStatus sets the machine's instance status to
+// missing, unless it is already.
+func setMissingInstanceStatus(m machine, instId instance.Id) error {
+	instStat, err := m.InstanceStatus()
+	if err != nil {
+		logger.Warningf("cannot get current instance status for machine %v: %v", m.Id(), err)
+		return nil
+	}
+	if status.Status(instStat.Status) == status.Missing {
+		return nil
+	}
+	logger.Warningf("machine %q instance %q not found in the cloud", m.Id(), instId)
+	if err := m.SetInstanceStatus(status.Missing, "instance not found", nil); err != nil {
+		logger.Errorf("cannot set instance status on %q: %v", m, err)
+		return err
+	}
+	return nil
+}
+
 // addressesEqual compares the addresses of the machine and the instance information.
 func addressesEqual(a0, a1 []network.Address) bool {
 	if len(a0) != len(a1) {
@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lostmachines
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// period is the longest time the worker waits before looking for lost
+// machines again, so that it notices instances that have newly gone
+// missing and changes to the model's replacement policy.
+const period = time.Minute
+
+var logger = loggo.GetLogger("juju.worker.lostmachines")
+
+// Facade defines the API methods used by the worker.
+type Facade interface {
+	ReplaceLostMachines() (time.Time, error)
+}
+
+// Worker replaces machines whose instances have disappeared from the
+// cloud, once the model's grace period has passed.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	facade   Facade
+	clock    clock.Clock
+}
+
+// NewWorker returns a worker that replaces lost machines.
+func NewWorker(facade Facade, clock clock.Clock) (worker.Worker, error) {
+	w := &Worker{
+		facade: facade,
+		clock:  clock,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *Worker) loop() error {
+	timer := w.clock.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timer.Chan():
+		}
+		next, err := w.facade.ReplaceLostMachines()
+		if err != nil {
+			// Machines that couldn't be replaced now are
+			// retried when the timer next fires.
+			logger.Errorf("cannot replace lost machines: %v", err)
+			next = time.Time{}
+		}
+		timer.Reset(w.delay(next))
+	}
+}
+
+// delay returns the time to wait until next, but no more than period.
+func (w *Worker) delay(next time.Time) time.Duration {
+	if next.IsZero() {
+		return period
+	}
+	delay := next.Sub(w.clock.Now())
+	switch {
+	case delay < 0:
+		return 0
+	case delay > period:
+		return period
+	}
+	return delay
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/api/machineactions"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/workertest"
 )
@@ -26,11 +27,19 @@ func mockHandleAction(stub *testing.Stub) func(string, map[string]interface{}) (
 	}
 }
 
+func mockRunScript(stub *testing.Stub) func(string, string, map[string]interface{}) (map[string]interface{}, error) {
+	return func(name, script string, params map[string]interface{}) (map[string]interface{}, error) {
+		stub.AddCall("RunScript", name, script)
+		return nil, stub.NextErr()
+	}
+}
+
 // mockFacade implements machineactions.Facade for use in the tests.
 type mockFacade struct {
 	stub                     *testing.Stub
 	runningActions           []params.ActionResult
 	watcherSendInvalidValues bool
+	scriptActions            bool
 }
 
 // RunningActions is part of the machineactions.Facade interface.
@@ -48,9 +57,21 @@ func (mock *mockFacade) Action(tag names.ActionTag) (*machineactions.Action, err
 	if err := mock.stub.NextErr(); err != nil {
 		return nil, err
 	}
+	if mock.scriptActions {
+		return scriptAction, nil
+	}
 	return tagToActionMap[tag], nil
 }
 
+// ActionScript is part of the machineactions.Facade interface.
+func (mock *mockFacade) ActionScript(tag names.ActionTag) (string, error) {
+	mock.stub.AddCall("ActionScript", tag)
+	if err := mock.stub.NextErr(); err != nil {
+		return "", err
+	}
+	return fakeScript, nil
+}
+
 // ActionBegin is part of the machineactions.Facade interface.
 func (mock *mockFacade) ActionBegin(tag names.ActionTag) error {
 	mock.stub.AddCall("ActionBegin", tag)
@@ -98,9 +119,11 @@ func (stubWatcher *stubWatcher) Changes() watcher.StringsChannel {
 }
 
 var (
-	firstAction     = machineactions.NewAction("foo", nil)
-	secondAction    = machineactions.NewAction("baz", nil)
-	thirdAction     = machineactions.NewAction("boo", nil)
+	firstAction     = machineactions.NewAction(actions.JujuRunActionName, map[string]interface{}{"command": "foo"})
+	secondAction    = machineactions.NewAction(actions.JujuRunActionName, map[string]interface{}{"command": "baz"})
+	thirdAction     = machineactions.NewAction(actions.JujuRunActionName, map[string]interface{}{"command": "boo"})
+	scriptAction    = machineactions.NewAction("sosreport", nil)
+	fakeScript      = "#!/bin/sh\nsosreport --batch\n"
 	firstActionID   = "11234567-89ab-cdef-0123-456789abcdef"
 	secondActionID  = "21234567-89ab-cdef-0123-456789abcdef"
 	thirdActionID   = "31234567-89ab-cdef-0123-456789abcdef"
@@ -6,19 +6,23 @@ package machineactions
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"time"
 	"unicode/utf8"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/exec"
 
 	"github.com/juju/juju/core/actions"
 )
 
-// RunAsUser is the user that the machine juju-run action is executed as.
+// RunAsUser is the user that machine actions are executed as.
 var RunAsUser = "ubuntu"
 
 // HandleAction receives a name and a map of parameters for a given machine action.
@@ -49,23 +53,63 @@ func handleJujuRunAction(params map[string]interface{}) (results map[string]inte
 	// But due to serialization it comes out as float64
 	timeout, _ := params["timeout"].(float64)
 
-	res, err := runCommandWithTimeout(command, time.Duration(timeout), clock.WallClock)
+	res, err := runCommandWithTimeout(command, os.Environ(), time.Duration(timeout), clock.WallClock)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	return makeResults(res), nil
+}
+
+// RunScript runs the script of an operator-defined machine action. The
+// action's name and its parameters, encoded as JSON, are passed to the
+// script in $JUJU_ACTION_NAME and $JUJU_ACTION_PARAMS. Parameters have
+// already been validated against the action's schema when it was
+// enqueued.
+func RunScript(name, script string, params map[string]interface{}) (results map[string]interface{}, err error) {
+	encodedParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dir, err := ioutil.TempDir("", "juju-machine-action")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
 
+	// The script runs as RunAsUser, so it must be able to read the
+	// script from the otherwise private temporary directory.
+	if err := os.Chmod(dir, 0755); err != nil {
+		return nil, errors.Trace(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(script), 0755); err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Tracef("running machine action %q", name)
+
+	environment := append(os.Environ(),
+		"JUJU_ACTION_NAME="+name,
+		"JUJU_ACTION_PARAMS="+string(encodedParams),
+	)
+	res, err := runCommandWithTimeout(utils.ShQuote(path), environment, 0, clock.WallClock)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return makeResults(res), nil
+}
+
+func makeResults(res *exec.ExecResponse) map[string]interface{} {
 	actionResults := map[string]interface{}{}
 	actionResults["Code"] = fmt.Sprintf("%d", res.Code)
 	storeOutput(actionResults, "Stdout", res.Stdout)
 	storeOutput(actionResults, "Stderr", res.Stderr)
-
-	return actionResults, nil
+	return actionResults
 }
 
-func runCommandWithTimeout(command string, timeout time.Duration, clock clock.Clock) (*exec.ExecResponse, error) {
+func runCommandWithTimeout(command string, environment []string, timeout time.Duration, clock clock.Clock) (*exec.ExecResponse, error) {
 	cmd := exec.RunParams{
 		Commands:    command,
-		Environment: os.Environ(),
+		Environment: environment,
 		Clock:       clock,
 		User:        RunAsUser,
 	}
@@ -78,3 +78,14 @@ func (s *HandleSuite) TestErrorRun(c *gc.C) {
 	c.Assert(results["Stdout"], gc.Equals, "")
 	c.Assert(results["Stderr"], gc.Equals, "")
 }
+
+func (s *HandleSuite) TestRunScript(c *gc.C) {
+	script := "#!/bin/sh\necho $JUJU_ACTION_NAME $JUJU_ACTION_PARAMS\nexit 3\n"
+	params := map[string]interface{}{"case-id": "1234"}
+
+	results, err := machineactions.RunScript("sosreport", script, params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results["Code"], gc.Equals, "3")
+	c.Assert(strings.TrimRight(results["Stdout"].(string), "\r\n"), gc.Equals, `sosreport {"case-id":"1234"}`)
+	c.Assert(results["Stderr"], gc.Equals, "")
+}
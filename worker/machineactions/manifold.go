@@ -35,6 +35,7 @@ func (config ManifoldConfig) start(a agent.Agent, apiCaller base.APICaller) (wor
 		Facade:       machineActionsFacade,
 		MachineTag:   machineTag,
 		HandleAction: HandleAction,
+		RunScript:    RunScript,
 	})
 }
 
@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/api/machineactions"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/watcher"
 )
 
@@ -25,6 +26,7 @@ type Facade interface {
 	Action(names.ActionTag) (*machineactions.Action, error)
 	ActionBegin(names.ActionTag) error
 	ActionFinish(tag names.ActionTag, status string, results map[string]interface{}, message string) error
+	ActionScript(names.ActionTag) (string, error)
 }
 
 // WorkerConfig defines the worker's dependencies.
@@ -32,6 +34,7 @@ type WorkerConfig struct {
 	Facade       Facade
 	MachineTag   names.MachineTag
 	HandleAction func(name string, params map[string]interface{}) (results map[string]interface{}, err error)
+	RunScript    func(name, script string, params map[string]interface{}) (results map[string]interface{}, err error)
 }
 
 // Validate returns an error if the configuration is not complete.
@@ -45,6 +48,9 @@ func (c WorkerConfig) Validate() error {
 	if c.HandleAction == nil {
 		return errors.NotValidf("nil HandleAction")
 	}
+	if c.RunScript == nil {
+		return errors.NotValidf("nil RunScript")
+	}
 	return nil
 }
 
@@ -112,7 +118,7 @@ func (h *handler) Handle(_ <-chan struct{}, actionsSlice []string) error {
 		// We try to handle the action. The result returned from handling the action is
 		// sent through using ActionFinish. We only stop the loop if ActionFinish fails.
 		var finishErr error
-		results, err := h.config.HandleAction(action.Name(), action.Params())
+		results, err := h.handle(actionTag, action)
 		if err != nil {
 			finishErr = h.config.Facade.ActionFinish(actionTag, params.ActionFailed, nil, err.Error())
 		} else {
@@ -125,6 +131,19 @@ func (h *handler) Handle(_ <-chan struct{}, actionsSlice []string) error {
 	return nil
 }
 
+// handle runs a predefined action with HandleAction, and any other
+// action as an operator-defined machine action with RunScript.
+func (h *handler) handle(tag names.ActionTag, action *machineactions.Action) (map[string]interface{}, error) {
+	if _, ok := actions.PredefinedActionsSpec[action.Name()]; ok {
+		return h.config.HandleAction(action.Name(), action.Params())
+	}
+	script, err := h.config.Facade.ActionScript(tag)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get script for action %s", action.Name())
+	}
+	return h.config.RunScript(action.Name(), script, action.Params())
+}
+
 // TearDown is part of the watcher.NotifyHandler interface.
 func (h *handler) TearDown() error {
 	// Nothing to cleanup, only state is the watcher
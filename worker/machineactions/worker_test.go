@@ -52,11 +52,24 @@ func (*WorkerSuite) TestInvalidHandleAction(c *gc.C) {
 	c.Assert(worker, gc.IsNil)
 }
 
+func (*WorkerSuite) TestInvalidRunScript(c *gc.C) {
+	worker, err := machineactions.NewMachineActionsWorker(machineactions.WorkerConfig{
+		Facade:       &mockFacade{},
+		MachineTag:   fakeTag,
+		HandleAction: mockHandleAction(nil),
+		RunScript:    nil,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil RunScript not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(worker, gc.IsNil)
+}
+
 func defaultConfig(stub *testing.Stub) machineactions.WorkerConfig {
 	return machineactions.WorkerConfig{
 		Facade:       &mockFacade{stub: stub},
 		MachineTag:   fakeTag,
 		HandleAction: mockHandleAction(stub),
+		RunScript:    mockRunScript(stub),
 	}
 }
 
@@ -81,6 +94,7 @@ func (*WorkerSuite) TestInvalidActionId(c *gc.C) {
 		Facade:       facade,
 		MachineTag:   fakeTag,
 		HandleAction: mockHandleAction(stub),
+		RunScript:    mockRunScript(stub),
 	}
 	worker, err := machineactions.NewMachineActionsWorker(config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -101,6 +115,7 @@ func (*WorkerSuite) TestWatchErrorNonEmptyRunningActions(c *gc.C) {
 		Facade:       facade,
 		MachineTag:   fakeTag,
 		HandleAction: mockHandleAction(stub),
+		RunScript:    mockRunScript(stub),
 	}
 	worker, err := machineactions.NewMachineActionsWorker(config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -190,6 +205,56 @@ func (*WorkerSuite) TestWorkerNoErr(c *gc.C) {
 	stub.CheckCalls(c, getSuccessfulCalls(allCalls))
 }
 
+func (*WorkerSuite) TestScriptActions(c *gc.C) {
+	stub := &testing.Stub{}
+	// The first script can't be fetched, and the second fails to run.
+	stub.SetErrors(nil, nil, nil, nil, errors.New("removed"), nil, nil, nil, nil, errors.New("exit 1"))
+	config := defaultConfig(stub)
+	config.Facade = &mockFacade{stub: stub, scriptActions: true}
+	worker, err := machineactions.NewMachineActionsWorker(config)
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CheckAlive(c, worker)
+	workertest.CleanKill(c, worker)
+
+	calls := []testing.StubCall{{
+		FuncName: "RunningActions",
+		Args:     []interface{}{fakeTag},
+	}, {
+		FuncName: "WatchActionNotifications",
+		Args:     []interface{}{fakeTag},
+	}}
+	for i, tag := range []names.ActionTag{firstActionTag, secondActionTag, thirdActionTag} {
+		calls = append(calls, testing.StubCall{
+			FuncName: "Action",
+			Args:     []interface{}{tag},
+		}, testing.StubCall{
+			FuncName: "ActionBegin",
+			Args:     []interface{}{tag},
+		}, testing.StubCall{
+			FuncName: "ActionScript",
+			Args:     []interface{}{tag},
+		})
+		status, message := params.ActionCompleted, ""
+		switch i {
+		case 0:
+			status, message = params.ActionFailed, "cannot get script for action sosreport: removed"
+		case 1:
+			status, message = params.ActionFailed, "exit 1"
+		}
+		if i > 0 {
+			calls = append(calls, testing.StubCall{
+				FuncName: "RunScript",
+				Args:     []interface{}{"sosreport", fakeScript},
+			})
+		}
+		calls = append(calls, testing.StubCall{
+			FuncName: "ActionFinish",
+			Args:     []interface{}{tag, status, message},
+		})
+	}
+	stub.CheckCalls(c, calls)
+}
+
 const allCalls = 14
 
 func getSuccessfulCalls(index int) []testing.StubCall {
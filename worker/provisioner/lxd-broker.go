@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 )
@@ -119,6 +120,12 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		return nil, err
 	}
 
+	charmProfiles, err := broker.writeCharmProfiles(args.CharmLXDProfiles)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args.InstanceConfig.CharmLXDProfiles = charmProfiles
+
 	storageConfig := &container.StorageConfig{}
 	inst, hardware, err := broker.manager.CreateContainer(
 		args.InstanceConfig, args.Constraints,
@@ -168,3 +175,33 @@ func (broker *lxdBroker) MaintainInstance(args environs.StartInstanceParams) err
 	)
 	return err
 }
+
+// writeCharmProfiles writes the LXD profiles contributed by charms,
+// returning their names.
+func (broker *lxdBroker) writeCharmProfiles(profiles []lxdprofile.NamedProfile) ([]string, error) {
+	if len(profiles) == 0 {
+		return nil, nil
+	}
+	profileManager, ok := broker.manager.(container.LXDProfileManager)
+	if !ok {
+		lxdLogger.Warningf("container manager does not support charm LXD profiles")
+		return nil, nil
+	}
+	names := make([]string, len(profiles))
+	for i, profile := range profiles {
+		if err := profileManager.MaybeWriteLXDProfile(profile.Name, profile.Profile); err != nil {
+			return nil, errors.Trace(err)
+		}
+		names[i] = profile.Name
+	}
+	return names, nil
+}
+
+// ReplaceLXDProfiles implements environs.LXDProfiler.
+func (broker *lxdBroker) ReplaceLXDProfiles(id instance.Id, remove []string, add []lxdprofile.NamedProfile) error {
+	profileManager, ok := broker.manager.(container.LXDProfileManager)
+	if !ok {
+		return errors.NotSupportedf("charm LXD profiles")
+	}
+	return errors.Trace(profileManager.ReplaceLXDProfiles(id, remove, add))
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -153,6 +154,40 @@ func (s *lxdBrokerSuite) TestStartInstanceNoHostArchTools(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `need agent binaries for arch amd64, only found \[arm64\]`)
 }
 
+func (s *lxdBrokerSuite) TestStartInstanceWritesCharmProfiles(c *gc.C) {
+	broker, brokerErr := s.newLXDBroker(c)
+	c.Assert(brokerErr, jc.ErrorIsNil)
+
+	profile := lxdprofile.Profile{Config: map[string]string{"security.nesting": "true"}}
+	_, err := broker.StartInstance(environs.StartInstanceParams{
+		Tools:          makePossibleTools(),
+		InstanceConfig: makeInstanceConfig(c, s, "1/lxd/0"),
+		StatusCallback: makeNoOpStatusCallback(),
+		CharmLXDProfiles: []lxdprofile.NamedProfile{{
+			Name:    "juju-model-app-3",
+			Profile: profile,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.manager.CheckCallNames(c, "MaybeWriteLXDProfile", "CreateContainer")
+	s.manager.CheckCall(c, 0, "MaybeWriteLXDProfile", "juju-model-app-3", profile)
+	instanceConfig := s.manager.Calls()[1].Args[0].(*instancecfg.InstanceConfig)
+	c.Assert(instanceConfig.CharmLXDProfiles, jc.DeepEquals, []string{"juju-model-app-3"})
+}
+
+func (s *lxdBrokerSuite) TestReplaceLXDProfiles(c *gc.C) {
+	broker, brokerErr := s.newLXDBroker(c)
+	c.Assert(brokerErr, jc.ErrorIsNil)
+
+	add := []lxdprofile.NamedProfile{{
+		Name:    "juju-model-app-4",
+		Profile: lxdprofile.Profile{Config: map[string]string{"security.nesting": "true"}},
+	}}
+	err := broker.(environs.LXDProfiler).ReplaceLXDProfiles("juju-machine-1-lxd-0", []string{"juju-model-app-3"}, add)
+	c.Assert(err, jc.ErrorIsNil)
+	s.manager.CheckCall(c, 0, "ReplaceLXDProfiles", instance.Id("juju-machine-1-lxd-0"), []string{"juju-model-app-3"}, add)
+}
+
 type fakeContainerManager struct {
 	gitjujutesting.Stub
 }
@@ -183,6 +218,16 @@ func (m *fakeContainerManager) Namespace() instance.Namespace {
 	return ns
 }
 
+func (m *fakeContainerManager) MaybeWriteLXDProfile(name string, profile lxdprofile.Profile) error {
+	m.MethodCall(m, "MaybeWriteLXDProfile", name, profile)
+	return m.NextErr()
+}
+
+func (m *fakeContainerManager) ReplaceLXDProfiles(id instance.Id, remove []string, add []lxdprofile.NamedProfile) error {
+	m.MethodCall(m, "ReplaceLXDProfiles", id, remove, add)
+	return m.NextErr()
+}
+
 func (m *fakeContainerManager) IsInitialized() bool {
 	m.MethodCall(m, "IsInitialized")
 	m.PopNoErr()
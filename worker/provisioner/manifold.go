@@ -12,6 +12,7 @@ import (
 	apiprovisioner "github.com/juju/juju/api/provisioner"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker/dependency"
+	workerenviron "github.com/juju/juju/worker/environ"
 )
 
 // ManifoldConfig defines an environment provisioner's dependencies. It's not
@@ -51,6 +52,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			if err := context.Get(config.EnvironName, &environ); err != nil {
 				return nil, errors.Trace(err)
 			}
+			environ = workerenviron.TracingEnviron(context, config.EnvironName, environ)
 
 			api := apiprovisioner.NewState(apiCaller)
 			agentConfig := agent.CurrentConfig()
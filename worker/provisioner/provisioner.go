@@ -140,6 +140,10 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 	if err != nil && !errors.IsNotImplemented(err) {
 		return nil, err
 	}
+	charmWatcher, err := p.getCharmWatcher()
+	if err != nil {
+		return nil, err
+	}
 	tag := p.agentConfig.Tag()
 	machineTag, ok := tag.(names.MachineTag)
 	if !ok {
@@ -165,6 +169,7 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 		p.toolsFinder,
 		machineWatcher,
 		retryWatcher,
+		charmWatcher,
 		p.broker,
 		auth,
 		modelCfg.ImageStream(),
@@ -176,6 +181,22 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 	return task, nil
 }
 
+// getCharmWatcher returns a watcher of changes to the model's
+// application charms if the broker can update the charm LXD profiles
+// of its instances, and nil otherwise.
+func (p *provisioner) getCharmWatcher() (watcher.StringsWatcher, error) {
+	if _, ok := p.broker.(environs.LXDProfiler); !ok {
+		return nil, nil
+	}
+	w, err := p.st.WatchApplicationCharms()
+	if errors.IsNotImplemented(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
 // NewEnvironProvisioner returns a new Provisioner for an environment.
 // When new machines are added to the state, it allocates instances
 // from the environment and allocates them to the new machines.
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/controller/authentication"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
@@ -55,6 +56,12 @@ type MachineGetter interface {
 	MachinesWithTransientErrors() ([]apiprovisioner.MachineStatusResult, error)
 }
 
+// CharmProfileInfoGetter is implemented by machine getters that can
+// report the charm LXD profiles of many machines in a single call.
+type CharmProfileInfoGetter interface {
+	CharmProfileInfo(...names.MachineTag) ([]params.CharmProfileInfoResult, error)
+}
+
 type DistributionGroupFinder interface {
 	DistributionGroupByMachineId(...names.MachineTag) ([]apiprovisioner.DistributionGroupResult, error)
 }
@@ -77,6 +84,7 @@ func NewProvisionerTask(
 	toolsFinder ToolsFinder,
 	machineWatcher watcher.StringsWatcher,
 	retryWatcher watcher.NotifyWatcher,
+	charmWatcher watcher.StringsWatcher,
 	broker environs.InstanceBroker,
 	auth authentication.AuthenticationProvider,
 	imageStream string,
@@ -89,6 +97,11 @@ func NewProvisionerTask(
 		retryChanges = retryWatcher.Changes()
 		workers = append(workers, retryWatcher)
 	}
+	var charmChanges watcher.StringsChannel
+	if charmWatcher != nil {
+		charmChanges = charmWatcher.Changes()
+		workers = append(workers, charmWatcher)
+	}
 	task := &provisionerTask{
 		controllerUUID:             controllerUUID,
 		machineTag:                 machineTag,
@@ -97,6 +110,7 @@ func NewProvisionerTask(
 		toolsFinder:                toolsFinder,
 		machineChanges:             machineChanges,
 		retryChanges:               retryChanges,
+		charmChanges:               charmChanges,
 		broker:                     broker,
 		auth:                       auth,
 		harvestMode:                harvestMode,
@@ -131,6 +145,7 @@ type provisionerTask struct {
 	toolsFinder                ToolsFinder
 	machineChanges             watcher.StringsChannel
 	retryChanges               watcher.NotifyChannel
+	charmChanges               watcher.StringsChannel
 	broker                     environs.InstanceBroker
 	catacomb                   catacomb.Catacomb
 	auth                       authentication.AuthenticationProvider
@@ -198,6 +213,13 @@ func (task *provisionerTask) loop() error {
 			if err := task.processMachinesWithTransientErrors(); err != nil {
 				return errors.Annotate(err, "failed to process machines with transient errors")
 			}
+		case _, ok := <-task.charmChanges:
+			if !ok {
+				return errors.New("application charm watcher closed channel")
+			}
+			if err := task.processCharmProfileChanges(); err != nil {
+				return errors.Annotate(err, "failed to process charm LXD profile changes")
+			}
 		}
 	}
 }
@@ -692,12 +714,128 @@ func (task *provisionerTask) constructStartInstanceParams(
 		SubnetsToZones:    subnetsToZones,
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     possibleImageMetadata,
+		CharmLXDProfiles:  charmLXDProfiles(provisioningInfo.CharmLXDProfiles),
 		StatusCallback:    machine.SetInstanceStatus,
 	}
 
 	return startInstanceParams, nil
 }
 
+// charmLXDProfiles converts the charm LXD profiles in a machine's
+// provisioning info to the form expected by the broker.
+func charmLXDProfiles(profiles []params.CharmLXDProfile) []lxdprofile.NamedProfile {
+	if len(profiles) == 0 {
+		return nil
+	}
+	result := make([]lxdprofile.NamedProfile, len(profiles))
+	for i, p := range profiles {
+		result[i] = lxdprofile.NamedProfile{
+			Name: p.Name,
+			Profile: lxdprofile.Profile{
+				Config:      p.Config,
+				Description: p.Description,
+				Devices:     p.Devices,
+			},
+		}
+	}
+	return result
+}
+
+// processCharmProfileChanges brings the charm LXD profiles of the
+// provisioned machines up to date, for example after a charm upgrade.
+// The profiles of all the machines are fetched in a single call, and
+// only the instances whose profiles have changed are updated. Failures
+// are reported in the machine's instance status rather than stopping
+// the provisioner.
+func (task *provisionerTask) processCharmProfileChanges() error {
+	profiler, ok := task.broker.(environs.LXDProfiler)
+	if !ok {
+		return nil
+	}
+	getter, ok := task.machineGetter.(CharmProfileInfoGetter)
+	if !ok {
+		return nil
+	}
+	var machines []*apiprovisioner.Machine
+	var instIds []instance.Id
+	var tags []names.MachineTag
+	for _, machine := range task.machines {
+		if machine.Life() != params.Alive {
+			continue
+		}
+		instId, err := machine.InstanceId()
+		if params.IsCodeNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		machines = append(machines, machine)
+		instIds = append(instIds, instId)
+		tags = append(tags, machine.MachineTag())
+	}
+	if len(machines) == 0 {
+		return nil
+	}
+	results, err := getter.CharmProfileInfo(tags...)
+	if errors.IsNotImplemented(err) {
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "getting charm LXD profiles")
+	}
+	for i, machine := range machines {
+		result := results[i]
+		if result.Error != nil {
+			logger.Errorf("cannot get charm LXD profiles of machine %q: %v", machine, result.Error)
+			continue
+		}
+		err := task.updateCharmProfiles(profiler, machine, instIds[i], result.Profiles, result.Current)
+		if errors.IsNotImplemented(err) || errors.IsNotSupported(err) {
+			logger.Debugf("not applying charm LXD profiles to machine %q: %v", machine, err)
+			continue
+		} else if err != nil {
+			logger.Errorf("cannot apply charm LXD profiles to machine %q: %v", machine, err)
+			message := fmt.Sprintf("cannot apply charm LXD profiles: %v", err)
+			if err := machine.SetInstanceStatus(status.Running, message, nil); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// updateCharmProfiles replaces the charm LXD profiles applied to the
+// machine's instance, named by current, with those its units' charms
+// currently require.
+func (task *provisionerTask) updateCharmProfiles(
+	profiler environs.LXDProfiler,
+	machine *apiprovisioner.Machine,
+	instId instance.Id,
+	profiles []params.CharmLXDProfile,
+	current []string,
+) error {
+	applied := set.NewStrings(current...)
+	desired := set.NewStrings()
+	var add []lxdprofile.NamedProfile
+	for _, profile := range charmLXDProfiles(profiles) {
+		desired.Add(profile.Name)
+		if !applied.Contains(profile.Name) {
+			add = append(add, profile)
+		}
+	}
+	remove := applied.Difference(desired).SortedValues()
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+	logger.Infof("updating charm LXD profiles of machine %s: removing %v, adding %d", machine, remove, len(add))
+	if err := profiler.ReplaceLXDProfiles(instId, remove, add); err != nil {
+		return errors.Trace(err)
+	}
+	if err := machine.SetInstanceStatus(status.Running, "", nil); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(machine.SetCharmProfiles(desired.SortedValues()))
+}
+
 func (task *provisionerTask) maintainMachines(machines []*apiprovisioner.Machine) error {
 	for _, m := range machines {
 		logger.Infof("maintainMachines: %v", m)
@@ -1133,6 +1271,16 @@ func (task *provisionerTask) startMachine(
 		return errors.Annotate(err, "cannot set instance info")
 	}
 
+	if len(startInstanceParams.CharmLXDProfiles) > 0 {
+		profileNames := make([]string, len(startInstanceParams.CharmLXDProfiles))
+		for i, profile := range startInstanceParams.CharmLXDProfiles {
+			profileNames[i] = profile.Name
+		}
+		if err := machine.SetCharmProfiles(profileNames); err != nil && !errors.IsNotImplemented(err) {
+			return errors.Annotate(err, "cannot record charm LXD profiles")
+		}
+	}
+
 	logger.Infof(
 		"started machine %s as instance %s with hardware %q, network config %+v, volumes %v, volume attachments %v, subnets to zones %v",
 		machine,
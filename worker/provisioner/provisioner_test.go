@@ -1298,6 +1298,7 @@ func (s *ProvisionerSuite) newProvisionerTaskWithRetryStrategy(
 		toolsFinder,
 		machineWatcher,
 		retryWatcher,
+		nil,
 		broker,
 		auth,
 		imagemetadata.ReleasedStream,
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"github.com/prometheus/client_golang/prometheus"
 	worker "gopkg.in/juju/worker.v1"
 	"gopkg.in/tomb.v1"
@@ -158,6 +159,14 @@ func (w *stateWorker) loop() error {
 	w.prometheusRegisterer.Register(collector)
 	defer w.prometheusRegisterer.Unregister(collector)
 
+	modelCollector := statemetrics.NewModelCollector(
+		statemetrics.NewStatePool(pool),
+		clock.WallClock,
+		statemetrics.DefaultMaxLabelledModels,
+	)
+	w.prometheusRegisterer.Register(modelCollector)
+	defer w.prometheusRegisterer.Unregister(modelCollector)
+
 	w.setStatePool(pool)
 	defer w.setStatePool(nil)
 
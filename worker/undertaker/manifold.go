@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker/dependency"
+	workerenviron "github.com/juju/juju/worker/environ"
 )
 
 // ManifoldConfig holds the names of the resources used by, and the
@@ -32,6 +33,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	if err := context.Get(config.EnvironName, &environ); err != nil {
 		return nil, errors.Trace(err)
 	}
+	environ = workerenviron.TracingEnviron(context, config.EnvironName, environ)
 
 	facade, err := config.NewFacade(apiCaller)
 	if err != nil {
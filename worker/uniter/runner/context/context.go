@@ -517,6 +517,16 @@ func (ctx *HookContext) SetActionFailed() error {
 	return nil
 }
 
+// LogActionMessage sends a progress message for the Action to the
+// controller straight away, rather than with the Action's results, so
+// that it can be followed while the Action runs.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.state.LogActionMessage(ctx.actionData.Tag, message)
+}
+
 // UpdateActionResults inserts new values for use with action-set and
 // action-fail.  The results struct will be delivered to the controller
 // upon completion of the Action.  It returns an error if not called on an
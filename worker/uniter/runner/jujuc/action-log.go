@@ -0,0 +1,66 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// maxActionLogMessageLength is the maximum length, in bytes, of a
+// message recorded by action-log.
+const maxActionLogMessageLength = 1024
+
+// ActionLogCommand implements the action-log command.
+type ActionLogCommand struct {
+	cmd.CommandBase
+	ctx     Context
+	Message string
+}
+
+// NewActionLogCommand returns a new ActionLogCommand with the given context.
+func NewActionLogCommand(ctx Context) (cmd.Command, error) {
+	return &ActionLogCommand{ctx: ctx}, nil
+}
+
+// Info returns the content for --help.
+func (c *ActionLogCommand) Info() *cmd.Info {
+	doc := `
+action-log records a progress message for the running action. Each message is
+timestamped and can be followed while the action runs, using
+"juju show-action-output --watch" or "juju run-action --wait".
+Messages may be at most 1024 bytes long. When old action results are
+pruned, only the most recent 1000 messages of each action are kept.
+`
+	return &cmd.Info{
+		Name:    "action-log",
+		Args:    "<message>",
+		Purpose: "record a progress message for the current action",
+		Doc:     doc,
+	}
+}
+
+// SetFlags handles any option flags, but there are none.
+func (c *ActionLogCommand) SetFlags(f *gnuflag.FlagSet) {
+}
+
+// Init sets the message and checks for malformed invocations.
+func (c *ActionLogCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no message specified")
+	}
+	c.Message = strings.Join(args, " ")
+	if len(c.Message) > maxActionLogMessageLength {
+		return errors.Errorf("message longer than %d bytes", maxActionLogMessageLength)
+	}
+	return nil
+}
+
+// Run records the message for the Action.
+func (c *ActionLogCommand) Run(ctx *cmd.Context) error {
+	return c.ctx.LogActionMessage(c.Message)
+}
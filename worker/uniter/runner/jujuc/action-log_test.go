@@ -0,0 +1,91 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type ActionLogSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&ActionLogSuite{})
+
+type actionLogContext struct {
+	jujuc.Context
+	logged []string
+}
+
+func (ctx *actionLogContext) LogActionMessage(message string) error {
+	ctx.logged = append(ctx.logged, message)
+	return nil
+}
+
+type nonActionLogContext struct {
+	jujuc.Context
+}
+
+func (ctx *nonActionLogContext) LogActionMessage(message string) error {
+	return fmt.Errorf("not running an action")
+}
+
+func (s *ActionLogSuite) TestActionLog(c *gc.C) {
+	var actionLogTests = []struct {
+		summary string
+		command []string
+		logged  []string
+		errMsg  string
+		code    int
+	}{{
+		summary: "a message is required",
+		command: []string{},
+		errMsg:  "ERROR no message specified\n",
+		code:    2,
+	}, {
+		summary: "a single argument is logged",
+		command: []string{"reindexing 10%"},
+		logged:  []string{"reindexing 10%"},
+	}, {
+		summary: "multiple arguments are joined",
+		command: []string{"reindexing", "20%"},
+		logged:  []string{"reindexing 20%"},
+	}, {
+		summary: "long messages are rejected",
+		command: []string{strings.Repeat("x", 1025)},
+		errMsg:  "ERROR message longer than 1024 bytes\n",
+		code:    2,
+	}}
+
+	for i, t := range actionLogTests {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx := &actionLogContext{}
+		com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.command)
+		c.Check(code, gc.Equals, t.code)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.errMsg)
+		c.Check(hctx.logged, jc.DeepEquals, t.logged)
+	}
+}
+
+func (s *ActionLogSuite) TestNonActionLogFails(c *gc.C) {
+	hctx := &nonActionLogContext{}
+	com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"oops"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR not running an action\n")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+}
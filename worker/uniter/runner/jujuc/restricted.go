@@ -139,6 +139,9 @@ func (*RestrictedContext) SetActionMessage(string) error { return ErrRestrictedC
 // SetActionFailed implements hooks.Context.
 func (*RestrictedContext) SetActionFailed() error { return ErrRestrictedContext }
 
+// LogActionMessage implements hooks.Context.
+func (*RestrictedContext) LogActionMessage(string) error { return ErrRestrictedContext }
+
 // Component implements jujc.Context.
 func (*RestrictedContext) Component(string) (ContextComponent, error) {
 	return nil, ErrRestrictedContext